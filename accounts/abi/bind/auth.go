@@ -113,6 +113,21 @@ func NewKeyedTransactorWithKeystore(address common.Address, ks *keystore.KeyStor
 	}
 }
 
+// NewWalletTransactor is a utility method to easily create a transaction signer
+// from a wallet, such as the one of an external signer.
+func NewWalletTransactor(wallet accounts.Wallet, address common.Address, chainID *big.Int) *TransactOpts {
+	keyAddr := address
+	return &TransactOpts{
+		From: keyAddr,
+		Signer: func(signer types.Signer, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != keyAddr {
+				return nil, errors.New("not authorized to sign this account")
+			}
+			return wallet.SignTx(accounts.Account{Address: address}, tx, chainID)
+		},
+	}
+}
+
 // MakeTransactOpts creates a transaction signer with nonce, gasLimit, and gasPrice from a single private key.
 func MakeTransactOpts(accountKey *ecdsa.PrivateKey, nonce *big.Int, gasLimit uint64, gasPrice *big.Int) *TransactOpts {
 	if accountKey == nil {
@@ -137,3 +152,16 @@ func MakeTransactOptsWithKeystore(ks *keystore.KeyStore, from common.Address, no
 	auth.Nonce = nonce
 	return auth
 }
+
+// MakeTransactOptsWithWallet creates a transaction signer with nonce, gasLimit, and gasPrice from a wallet.
+func MakeTransactOptsWithWallet(wallet accounts.Wallet, from common.Address, nonce *big.Int, chainID *big.Int, gasLimit uint64, gasPrice *big.Int) *TransactOpts {
+	if wallet == nil {
+		return nil
+	}
+
+	auth := NewWalletTransactor(wallet, from, chainID)
+	auth.GasLimit = gasLimit
+	auth.GasPrice = gasPrice
+	auth.Nonce = nonce
+	return auth
+}
@@ -0,0 +1,296 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/klaytn/klaytn"
+	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/log"
+	"github.com/klaytn/klaytn/networks/rpc"
+)
+
+// ExternalSignerType is the reflect type of the external signer backend.
+var ExternalSignerType = reflect.TypeOf(&ExternalBackend{})
+
+var (
+	logger = log.NewModuleLogger(log.AccountsExternal)
+
+	errChainIDNil = errors.New("chain ID is nil")
+)
+
+// SignTxArgs is the request of the external signer to sign a transaction.
+type SignTxArgs struct {
+	Hash    common.Hash            `json:"hash"`    // the hash to sign.
+	ChainID *hexutil.Big           `json:"chainId"` // the chain ID the hash is computed with.
+	Tx      map[string]interface{} `json:"tx"`      // the transaction, for the policy and the audit of the signer.
+}
+
+// ExternalBackend is an accounts.Backend with a single external signer.
+type ExternalBackend struct {
+	signers []accounts.Wallet
+}
+
+// NewExternalBackend connects to the external signer at the given endpoint, an
+// IPC socket path or an http(s) URL, authenticating with the given token.
+func NewExternalBackend(endpoint, token string) (*ExternalBackend, error) {
+	signer, err := NewExternalSigner(endpoint, token)
+	if err != nil {
+		return nil, err
+	}
+	return &ExternalBackend{signers: []accounts.Wallet{signer}}, nil
+}
+
+// Wallets implements accounts.Backend, returning the external signer.
+func (eb *ExternalBackend) Wallets() []accounts.Wallet {
+	return eb.signers
+}
+
+// Subscribe implements accounts.Backend. The external signer never comes or goes.
+func (eb *ExternalBackend) Subscribe(sink chan<- accounts.WalletEvent) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+}
+
+// ExternalSigner is an accounts.Wallet forwarding the signing requests to an
+// external signer. The external signer authenticates the node by its token, so
+// the passphrases are not used.
+type ExternalSigner struct {
+	client   *rpc.Client
+	endpoint string
+	status   string
+
+	cacheMu sync.RWMutex
+	cache   []accounts.Account
+}
+
+// NewExternalSigner connects to the external signer at the given endpoint and
+// checks that the node is authorized with the given token.
+func NewExternalSigner(endpoint, token string) (*ExternalSigner, error) {
+	client, err := dial(endpoint, token)
+	if err != nil {
+		return nil, err
+	}
+	signer := &ExternalSigner{client: client, endpoint: endpoint}
+
+	var version string
+	if err := client.Call(&version, "account_version"); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to the external signer %s: %v", endpoint, err)
+	}
+	signer.status = fmt.Sprintf("ok [version=%v]", version)
+	logger.Info("Connected to the external signer", "endpoint", endpoint, "version", version)
+	return signer, nil
+}
+
+// dial creates a client of the external signer. The token is sent in the HTTP
+// header or, for a local socket, as the first line of the connection.
+func dial(endpoint, token string) (*rpc.Client, error) {
+	if strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://") {
+		client, err := rpc.DialHTTP(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		client.SetHeader("Authorization", "Bearer "+token)
+		return client, nil
+	}
+	return rpc.NewClient(context.Background(), func(ctx context.Context) (rpc.ServerCodec, error) {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "unix", endpoint)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := conn.Write([]byte(token + "\n")); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return rpc.NewCodec(conn), nil
+	})
+}
+
+// URL implements accounts.Wallet, returning the endpoint of the external signer.
+func (api *ExternalSigner) URL() accounts.URL {
+	return accounts.URL{Scheme: "extapi", Path: api.endpoint}
+}
+
+// Status implements accounts.Wallet, returning whether the external signer is reachable.
+func (api *ExternalSigner) Status() (string, error) {
+	var version string
+	if err := api.client.Call(&version, "account_version"); err != nil {
+		return "", err
+	}
+	return api.status, nil
+}
+
+// Open implements accounts.Wallet, but is a noop since the connection is
+// established on the creation of the signer.
+func (api *ExternalSigner) Open(passphrase string) error { return nil }
+
+// Close implements accounts.Wallet, closing the connection to the external signer.
+func (api *ExternalSigner) Close() error {
+	api.client.Close()
+	return nil
+}
+
+// Accounts implements accounts.Wallet, returning the accounts of the external
+// signer. The list is fetched once and refreshed when an unknown account is used.
+func (api *ExternalSigner) Accounts() []accounts.Account {
+	api.cacheMu.RLock()
+	cache := api.cache
+	api.cacheMu.RUnlock()
+	if cache != nil {
+		return cache
+	}
+	accs, err := api.listAccounts()
+	if err != nil {
+		logger.Error("Failed to list the accounts of the external signer", "endpoint", api.endpoint, "err", err)
+		return nil
+	}
+	return accs
+}
+
+func (api *ExternalSigner) listAccounts() ([]accounts.Account, error) {
+	var addrs []common.Address
+	if err := api.client.Call(&addrs, "account_list"); err != nil {
+		return nil, err
+	}
+	accs := make([]accounts.Account, 0, len(addrs))
+	for _, addr := range addrs {
+		accs = append(accs, accounts.Account{Address: addr, URL: api.URL()})
+	}
+
+	api.cacheMu.Lock()
+	api.cache = accs
+	api.cacheMu.Unlock()
+	return accs, nil
+}
+
+// Contains implements accounts.Wallet, returning whether the external signer
+// holds the key of the given account.
+func (api *ExternalSigner) Contains(account accounts.Account) bool {
+	if account.URL != (accounts.URL{}) && account.URL != api.URL() {
+		return false
+	}
+	contains := func(accs []accounts.Account) bool {
+		for _, acc := range accs {
+			if acc.Address == account.Address {
+				return true
+			}
+		}
+		return false
+	}
+	if contains(api.Accounts()) {
+		return true
+	}
+	// The account may have been added to the signer after the list was fetched.
+	accs, err := api.listAccounts()
+	return err == nil && contains(accs)
+}
+
+// Derive implements accounts.Wallet, but is not supported by the external signer.
+func (api *ExternalSigner) Derive(path accounts.DerivationPath, pin bool) (accounts.Account, error) {
+	return accounts.Account{}, accounts.ErrNotSupported
+}
+
+// SelfDerive implements accounts.Wallet, but is a noop for the external signer.
+func (api *ExternalSigner) SelfDerive(base accounts.DerivationPath, chain klaytn.ChainReader) {}
+
+// SignHash implements accounts.Wallet, requesting the external signer to sign
+// the given hash with the given account.
+func (api *ExternalSigner) SignHash(account accounts.Account, hash []byte) ([]byte, error) {
+	if !api.Contains(account) {
+		return nil, accounts.ErrUnknownAccount
+	}
+	var sig hexutil.Bytes
+	if err := api.client.Call(&sig, "account_signHash", account.Address, hexutil.Bytes(hash)); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// SignTx implements accounts.Wallet, requesting the external signer to sign the
+// given transaction with the given account.
+func (api *ExternalSigner) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if chainID == nil {
+		return nil, errChainIDNil
+	}
+	signer := types.LatestSignerForChainID(chainID)
+	sig, err := api.signTx(account, "account_signTransaction", signer.Hash(tx), tx, chainID)
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(signer, sig)
+}
+
+// SignTxAsFeePayer implements accounts.Wallet, requesting the external signer to
+// sign the given transaction as a fee payer with the given account.
+func (api *ExternalSigner) SignTxAsFeePayer(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if chainID == nil {
+		return nil, errChainIDNil
+	}
+	signer := types.LatestSignerForChainID(chainID)
+	hash, err := signer.HashFeePayer(tx)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := api.signTx(account, "account_signTransactionAsFeePayer", hash, tx, chainID)
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithFeePayerSignature(signer, sig)
+}
+
+func (api *ExternalSigner) signTx(account accounts.Account, method string, hash common.Hash, tx *types.Transaction, chainID *big.Int) ([]byte, error) {
+	if !api.Contains(account) {
+		return nil, accounts.ErrUnknownAccount
+	}
+	args := &SignTxArgs{Hash: hash, ChainID: (*hexutil.Big)(chainID), Tx: tx.MakeRPCOutput()}
+	var sig hexutil.Bytes
+	if err := api.client.Call(&sig, method, account.Address, args); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// SignHashWithPassphrase implements accounts.Wallet, ignoring the passphrase.
+func (api *ExternalSigner) SignHashWithPassphrase(account accounts.Account, passphrase string, hash []byte) ([]byte, error) {
+	return api.SignHash(account, hash)
+}
+
+// SignTxWithPassphrase implements accounts.Wallet, ignoring the passphrase.
+func (api *ExternalSigner) SignTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return api.SignTx(account, tx, chainID)
+}
+
+// SignTxAsFeePayerWithPassphrase implements accounts.Wallet, ignoring the passphrase.
+func (api *ExternalSigner) SignTxAsFeePayerWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return api.SignTxAsFeePayer(account, tx, chainID)
+}
@@ -0,0 +1,43 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+Package external implements an accounts.Backend which forwards signing requests
+to an external signer process, so that the keys can be kept in a separate
+service such as one fronted by an HSM.
+
+The node talks JSON-RPC to the signer over a local socket or HTTP(S). The node
+authenticates itself with a token: it is sent in the "Authorization: Bearer"
+header of every HTTP request, and as the first line of a local socket connection
+before any JSON-RPC message. The signer serves the following methods.
+
+	account_version() string
+	account_list() []address
+	account_signHash(address, hash) signature
+	account_signTransaction(address, SignTxArgs) signature
+	account_signTransactionAsFeePayer(address, SignTxArgs) signature
+
+The signatures are in the [R || S || V] format where V is 0 or 1. The node
+computes the hash of a transaction and the signer returns its signature over
+the hash, while the transaction itself is passed for the policy and the audit
+of the signer.
+
+Source Files
+
+  - backend.go         : Provides `ExternalBackend` and `ExternalSigner`, the wallet forwarding the signing requests
+  - stand_in_signer.go : Provides `StandInSigner`, a reference signer holding its keys in memory, which is meant for tests
+*/
+package external
@@ -0,0 +1,129 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package external
+
+import (
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStandInSigner(t *testing.T) (*StandInSigner, accounts.Account, accounts.Account) {
+	senderKey, _ := crypto.GenerateKey()
+	feePayerKey, _ := crypto.GenerateKey()
+	s, err := NewStandInSigner("secret", senderKey, feePayerKey)
+	require.NoError(t, err)
+	t.Cleanup(s.Close)
+	return s, accounts.Account{Address: crypto.PubkeyToAddress(senderKey.PublicKey)},
+		accounts.Account{Address: crypto.PubkeyToAddress(feePayerKey.PublicKey)}
+}
+
+func testExternalSigner(t *testing.T, wallet accounts.Wallet, sender, feePayer accounts.Account) {
+	accs := wallet.Accounts()
+	assert.Equal(t, 2, len(accs))
+	assert.True(t, wallet.Contains(sender))
+	assert.True(t, wallet.Contains(feePayer))
+	assert.False(t, wallet.Contains(accounts.Account{Address: common.HexToAddress("0x1")}))
+
+	status, err := wallet.Status()
+	assert.NoError(t, err)
+	assert.Contains(t, status, StandInSignerVersion)
+
+	// Sign a hash.
+	hash := crypto.Keccak256([]byte("consensus message"))
+	sig, err := wallet.SignHash(sender, hash)
+	require.NoError(t, err)
+	pub, err := crypto.SigToPub(hash, sig)
+	require.NoError(t, err)
+	assert.Equal(t, sender.Address, crypto.PubkeyToAddress(*pub))
+
+	_, err = wallet.SignHash(accounts.Account{Address: common.HexToAddress("0x1")}, hash)
+	assert.Equal(t, accounts.ErrUnknownAccount, err)
+
+	// Sign a transaction as the sender and as the fee payer.
+	chainID := big.NewInt(1000)
+	signer := types.LatestSignerForChainID(chainID)
+	tx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedValueTransfer, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:    uint64(0),
+		types.TxValueKeyTo:       common.HexToAddress("0x2"),
+		types.TxValueKeyAmount:   big.NewInt(100),
+		types.TxValueKeyGasLimit: uint64(100000),
+		types.TxValueKeyGasPrice: big.NewInt(25),
+		types.TxValueKeyFrom:     sender.Address,
+		types.TxValueKeyFeePayer: feePayer.Address,
+	})
+	require.NoError(t, err)
+
+	_, err = wallet.SignTx(sender, tx, nil)
+	assert.Equal(t, errChainIDNil, err)
+
+	tx, err = wallet.SignTx(sender, tx, chainID)
+	require.NoError(t, err)
+	from, err := types.Sender(signer, tx)
+	assert.NoError(t, err)
+	assert.Equal(t, sender.Address, from)
+
+	tx, err = wallet.SignTxAsFeePayerWithPassphrase(feePayer, "", tx, chainID)
+	require.NoError(t, err)
+	payer, err := types.SenderFeePayer(signer, tx)
+	assert.NoError(t, err)
+	assert.Equal(t, feePayer.Address, payer)
+}
+
+func TestExternalSigner_IPC(t *testing.T) {
+	s, sender, feePayer := newTestStandInSigner(t)
+
+	dir, err := os.MkdirTemp("", "klaytn-signer")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	endpoint := filepath.Join(dir, "signer.ipc")
+	require.NoError(t, s.ServeIPC(endpoint))
+
+	backend, err := NewExternalBackend(endpoint, "secret")
+	require.NoError(t, err)
+	wallet := backend.Wallets()[0]
+	defer wallet.Close()
+	testExternalSigner(t, wallet, sender, feePayer)
+
+	_, err = NewExternalBackend(endpoint, "wrong")
+	assert.Error(t, err)
+}
+
+func TestExternalSigner_HTTP(t *testing.T) {
+	s, sender, feePayer := newTestStandInSigner(t)
+
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	backend, err := NewExternalBackend(server.URL, "secret")
+	require.NoError(t, err)
+	wallet := backend.Wallets()[0]
+	defer wallet.Close()
+	testExternalSigner(t, wallet, sender, feePayer)
+
+	_, err = NewExternalBackend(server.URL, "wrong")
+	assert.Error(t, err)
+}
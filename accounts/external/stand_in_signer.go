@@ -0,0 +1,190 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package external
+
+import (
+	"crypto/ecdsa"
+	"crypto/subtle"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/rpc"
+)
+
+// StandInSignerVersion is the version the stand-in signer reports.
+const StandInSignerVersion = "stand-in/1.0"
+
+var (
+	errUnknownSignerAccount = errors.New("unknown account")
+	errInvalidHash          = errors.New("invalid hash length")
+)
+
+// StandInSigner is a reference external signer which holds its keys in memory.
+// It signs every request of an authenticated node and is meant for tests and as
+// an example of the protocol; a production signer should keep the keys in an
+// HSM and check the requests against its policy.
+type StandInSigner struct {
+	token  []byte
+	keys   map[common.Address]*ecdsa.PrivateKey
+	addrs  []common.Address
+	server *rpc.Server
+
+	mu        sync.Mutex
+	listeners []net.Listener
+}
+
+// NewStandInSigner creates a stand-in signer holding the given keys, which
+// accepts the nodes authenticated with the given token.
+func NewStandInSigner(token string, keys ...*ecdsa.PrivateKey) (*StandInSigner, error) {
+	s := &StandInSigner{
+		token:  []byte(token),
+		keys:   make(map[common.Address]*ecdsa.PrivateKey, len(keys)),
+		server: rpc.NewServer(),
+	}
+	for _, key := range keys {
+		addr := crypto.PubkeyToAddress(key.PublicKey)
+		if _, ok := s.keys[addr]; !ok {
+			s.addrs = append(s.addrs, addr)
+		}
+		s.keys[addr] = key
+	}
+	if err := s.server.RegisterName("account", &standInSignerAPI{s}); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *StandInSigner) authorized(token []byte) bool {
+	return subtle.ConstantTimeCompare(token, s.token) == 1
+}
+
+// ServeHTTP implements http.Handler, serving the requests with the token in
+// their Authorization header.
+func (s *StandInSigner) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized([]byte(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	s.server.ServeHTTP(w, r)
+}
+
+// ServeIPC listens on a unix socket at the given path, which only the owner can
+// access, and serves the connections which start with the token.
+func (s *StandInSigner) ServeIPC(endpoint string) error {
+	os.Remove(endpoint)
+	l, err := net.Listen("unix", endpoint)
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(endpoint, 0o600); err != nil {
+		l.Close()
+		return err
+	}
+	s.mu.Lock()
+	s.listeners = append(s.listeners, l)
+	s.mu.Unlock()
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serveConn(conn)
+		}
+	}()
+	return nil
+}
+
+// serveConn reads the token line byte by byte, not to consume any JSON-RPC
+// message, and serves the connection if the token is valid.
+func (s *StandInSigner) serveConn(conn net.Conn) {
+	var token []byte
+	buf := make([]byte, 1)
+	for {
+		if _, err := conn.Read(buf); err != nil {
+			conn.Close()
+			return
+		}
+		if buf[0] == '\n' {
+			break
+		}
+		if len(token) > 1024 {
+			conn.Close()
+			return
+		}
+		token = append(token, buf[0])
+	}
+	if !s.authorized(token) {
+		logger.Warn("Rejected an unauthorized connection to the stand-in signer")
+		conn.Close()
+		return
+	}
+	s.server.ServeCodec(rpc.NewCodec(conn), 0)
+}
+
+// Close stops serving the requests.
+func (s *StandInSigner) Close() {
+	s.mu.Lock()
+	for _, l := range s.listeners {
+		l.Close()
+	}
+	s.listeners = nil
+	s.mu.Unlock()
+	s.server.Stop()
+}
+
+// standInSignerAPI is the JSON-RPC API of the stand-in signer.
+type standInSignerAPI struct {
+	s *StandInSigner
+}
+
+func (api *standInSignerAPI) Version() string {
+	return StandInSignerVersion
+}
+
+func (api *standInSignerAPI) List() []common.Address {
+	return api.s.addrs
+}
+
+func (api *standInSignerAPI) SignHash(addr common.Address, hash hexutil.Bytes) (hexutil.Bytes, error) {
+	key, ok := api.s.keys[addr]
+	if !ok {
+		return nil, errUnknownSignerAccount
+	}
+	if len(hash) != common.HashLength {
+		return nil, errInvalidHash
+	}
+	return crypto.Sign(hash, key)
+}
+
+func (api *standInSignerAPI) SignTransaction(addr common.Address, args SignTxArgs) (hexutil.Bytes, error) {
+	logger.Debug("Signing a transaction", "from", addr, "hash", args.Hash, "chainID", args.ChainID, "tx", args.Tx)
+	return api.SignHash(addr, args.Hash.Bytes())
+}
+
+func (api *standInSignerAPI) SignTransactionAsFeePayer(addr common.Address, args SignTxArgs) (hexutil.Bytes, error) {
+	logger.Debug("Signing a transaction as a fee payer", "feePayer", addr, "hash", args.Hash, "chainID", args.ChainID, "tx", args.Tx)
+	return api.SignHash(addr, args.Hash.Bytes())
+}
@@ -244,6 +244,76 @@ func (s *PublicBlockChainAPI) GetStorageAt(ctx context.Context, address common.A
 	return res[:], state.Error()
 }
 
+// AccountProofResult is the result of klay_getProof. Account is the account in
+// the Klaytn serialization, which is nil for non-existent accounts. StorageRoot
+// is the storage root in the ExtHash form as stored in the account, while
+// StorageHash is its merkle hash against which the storage proofs are verified.
+type AccountProofResult struct {
+	Address      common.Address             `json:"address"`
+	Account      *account.AccountSerializer `json:"account"`
+	AccountProof []hexutil.Bytes            `json:"accountProof"`
+	StorageRoot  hexutil.Bytes              `json:"storageRoot"`
+	StorageHash  common.Hash                `json:"storageHash"`
+	StorageProof []StorageProofResult       `json:"storageProof"`
+}
+
+// StorageProofResult is the value and the merkle proof of a storage slot.
+type StorageProofResult struct {
+	Key   common.Hash     `json:"key"`
+	Value common.Hash     `json:"value"`
+	Proof []hexutil.Bytes `json:"proof"`
+}
+
+// GetProof returns the account and the storage slots of the given address with
+// their merkle proofs. Unlike eth_getProof, the proofs are of the Klaytn account
+// serialization, and can be verified with the proofverifier package.
+func (s *PublicBlockChainAPI) GetProof(ctx context.Context, address common.Address, storageKeys []string, blockNrOrHash rpc.BlockNumberOrHash) (*AccountProofResult, error) {
+	state, _, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	accountProof, err := state.GetProof(address)
+	if err != nil {
+		return nil, err
+	}
+	storageRoot := types.EmptyRootHashOriginal.ExtendZero()
+	result := &AccountProofResult{
+		Address:      address,
+		AccountProof: toHexBytesSlice(accountProof),
+		StorageProof: make([]StorageProofResult, len(storageKeys)),
+	}
+	if acc := state.GetAccount(address); acc != nil {
+		result.Account = account.NewAccountSerializerWithAccount(acc)
+		if pa := account.GetProgramAccount(acc); pa != nil {
+			storageRoot = pa.GetStorageRoot()
+		}
+	}
+	result.StorageRoot = storageRoot.Bytes()
+	result.StorageHash = storageRoot.Unextend()
+
+	for i, hexKey := range storageKeys {
+		key := common.HexToHash(hexKey)
+		proof, err := state.GetStorageProof(address, key)
+		if err != nil {
+			return nil, err
+		}
+		result.StorageProof[i] = StorageProofResult{
+			Key:   key,
+			Value: state.GetState(address, key),
+			Proof: toHexBytesSlice(proof),
+		}
+	}
+	return result, state.Error()
+}
+
+func toHexBytesSlice(values [][]byte) []hexutil.Bytes {
+	result := make([]hexutil.Bytes, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
+
 // GetAccountKey returns the account key of EOA at a given address.
 // If the account of the given address is a Legacy Account or a Smart Contract Account, it will return nil.
 func (s *PublicBlockChainAPI) GetAccountKey(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*accountkey.AccountKeySerializer, error) {
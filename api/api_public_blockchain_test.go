@@ -23,8 +23,17 @@ import (
 	"github.com/golang/mock/gomock"
 	mock_api "github.com/klaytn/klaytn/api/mocks"
 	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/klaytn/klaytn/storage/statedb/proofverifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func testInitForKlayApi(t *testing.T) (*gomock.Controller, *mock_api.MockBackend, *PublicBlockChainAPI) {
@@ -60,3 +69,49 @@ func TestKlaytnAPI_EstimateGas(t *testing.T) {
 		return api.EstimateGas(context.Background(), args)
 	})
 }
+
+func TestKlaytnAPI_GetProof(t *testing.T) {
+	mockCtrl, mockBackend, api := testInitForKlayApi(t)
+	defer mockCtrl.Finish()
+
+	var (
+		contract = common.HexToAddress("0xc0de")
+		slot     = common.HexToHash("0x01")
+	)
+	stateDB, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	require.NoError(t, err)
+	stateDB.CreateSmartContractAccount(contract, params.CodeFormatEVM, params.TestChainConfig.Rules(common.Big0))
+	stateDB.SetState(contract, slot, common.HexToHash("0xff"))
+	root, err := stateDB.Commit(false)
+	require.NoError(t, err)
+	stateDB, err = state.New(root, stateDB.Database(), nil, nil)
+	require.NoError(t, err)
+
+	header := &types.Header{Number: common.Big1, Root: root}
+	mockBackend.EXPECT().StateAndHeaderByNumberOrHash(gomock.Any(), gomock.Any()).Return(stateDB, header, nil).AnyTimes()
+
+	result, err := api.GetProof(context.Background(), contract, []string{slot.Hex()}, rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+	require.NoError(t, err)
+	require.NotNil(t, result.Account)
+	assert.Equal(t, account.SmartContractAccountType, result.Account.GetAccount().Type())
+	assert.Len(t, result.StorageRoot, common.ExtHashLength)
+	assert.Equal(t, result.StorageHash, common.BytesToExtHash(result.StorageRoot).Unextend())
+
+	acc, err := proofverifier.VerifyAccount(root, contract, toBytesSlice(result.AccountProof))
+	require.NoError(t, err)
+	assert.Equal(t, result.StorageHash, proofverifier.StorageRoot(acc))
+
+	require.Len(t, result.StorageProof, 1)
+	value, err := proofverifier.VerifyStorage(result.StorageHash, slot, toBytesSlice(result.StorageProof[0].Proof))
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToHash("0xff"), value)
+	assert.Equal(t, value, result.StorageProof[0].Value)
+}
+
+func toBytesSlice(values []hexutil.Bytes) [][]byte {
+	result := make([][]byte, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
@@ -24,6 +24,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/klaytn/klaytn/blockchain/types/accountkey"
 	"github.com/klaytn/klaytn/common/hexutil"
@@ -109,6 +110,13 @@ func (s *PublicKlayAPI) FeeHistory(ctx context.Context, blockCount DecimalOrHex,
 // - highestBlock:  block number of the highest block header this node has received from peers
 // - pulledStates:  number of state entries processed until now
 // - knownStates:   number of known state entries that still need to be pulled
+//
+// While snap syncing the state, the progress of the snap sync is reported as well:
+// - snapPhase:      "sync" while downloading the state in ranges, "heal" while healing it
+// - snapETA:        estimated seconds left until the healing phase, zero if unknown
+// - synced*:        number of accounts, bytecodes and storage slots downloaded, and their size
+// - healed*:        number of trie nodes and bytecodes downloaded while healing, and their size
+// - healing*:       number of trie nodes and bytecodes pending to heal
 func (s *PublicKlayAPI) Syncing() (interface{}, error) {
 	progress := s.b.Progress()
 
@@ -117,13 +125,34 @@ func (s *PublicKlayAPI) Syncing() (interface{}, error) {
 		return false, nil
 	}
 	// Otherwise gather the block sync stats
-	return map[string]interface{}{
+	status := map[string]interface{}{
 		"startingBlock": hexutil.Uint64(progress.StartingBlock),
 		"currentBlock":  hexutil.Uint64(progress.CurrentBlock),
 		"highestBlock":  hexutil.Uint64(progress.HighestBlock),
 		"pulledStates":  hexutil.Uint64(progress.PulledStates),
 		"knownStates":   hexutil.Uint64(progress.KnownStates),
-	}, nil
+	}
+	if progress.SnapSyncing {
+		phase := "sync"
+		if progress.SnapHealing {
+			phase = "heal"
+		}
+		status["snapPhase"] = phase
+		status["snapETA"] = hexutil.Uint64(progress.SnapETA / time.Second)
+		status["syncedAccounts"] = hexutil.Uint64(progress.SyncedAccounts)
+		status["syncedAccountBytes"] = hexutil.Uint64(progress.SyncedAccountBytes)
+		status["syncedBytecodes"] = hexutil.Uint64(progress.SyncedBytecodes)
+		status["syncedBytecodeBytes"] = hexutil.Uint64(progress.SyncedBytecodeBytes)
+		status["syncedStorage"] = hexutil.Uint64(progress.SyncedStorage)
+		status["syncedStorageBytes"] = hexutil.Uint64(progress.SyncedStorageBytes)
+		status["healedTrienodes"] = hexutil.Uint64(progress.HealedTrienodes)
+		status["healedTrienodeBytes"] = hexutil.Uint64(progress.HealedTrienodeBytes)
+		status["healedBytecodes"] = hexutil.Uint64(progress.HealedBytecodes)
+		status["healedBytecodeBytes"] = hexutil.Uint64(progress.HealedBytecodeBytes)
+		status["healingTrienodes"] = hexutil.Uint64(progress.HealingTrienodes)
+		status["healingBytecode"] = hexutil.Uint64(progress.HealingBytecode)
+	}
+	return status, nil
 }
 
 // EncodeAccountKey gets an account key of JSON format and returns RLP encoded bytes of the key.
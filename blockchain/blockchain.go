@@ -87,12 +87,13 @@ var (
 	blockPrefetchExecuteTimer   = klaytnmetrics.NewRegisteredHybridTimer("chain/prefetch/executes", nil)
 	blockPrefetchInterruptMeter = metrics.NewRegisteredMeter("chain/prefetch/interrupts", nil)
 
-	ErrNoGenesis            = errors.New("genesis not found in chain")
-	ErrNotExistNode         = errors.New("the node does not exist in cached node")
-	ErrQuitBySignal         = errors.New("quit by signal")
-	ErrNotInWarmUp          = errors.New("not in warm up")
-	logger                  = log.NewModuleLogger(log.Blockchain)
-	kesCachePrefixBlockLogs = []byte("blockLogs")
+	ErrNoGenesis             = errors.New("genesis not found in chain")
+	ErrNotExistNode          = errors.New("the node does not exist in cached node")
+	ErrQuitBySignal          = errors.New("quit by signal")
+	ErrNotInWarmUp           = errors.New("not in warm up")
+	ErrPathSchemeLivePruning = errors.New("live pruning is not supported in the path scheme")
+	logger                   = log.NewModuleLogger(log.Blockchain)
+	kesCachePrefixBlockLogs  = []byte("blockLogs")
 )
 
 // Below is the list of the constants for cache size.
@@ -265,6 +266,10 @@ func NewBlockChain(db database.DBManager, cacheConfig *CacheConfig, chainConfig
 		prefetchTxCh:       make(chan prefetchTx, MaxPrefetchTxs),
 	}
 
+	if bc.stateCache.TrieDB().Scheme() == statedb.PathScheme && cacheConfig.LivePruningEnabled {
+		return nil, ErrPathSchemeLivePruning
+	}
+
 	// set hardForkBlockNumberConfig which will be used as a global variable
 	if err := fork.SetHardForkBlockNumberConfig(bc.chainConfig); err != nil {
 		return nil, err
@@ -1350,7 +1355,8 @@ func (bc *BlockChain) writeReceipts(hash common.Hash, number uint64, receipts ty
 // writeStateTrie writes state trie to database if possible.
 // If an archiving node is running, it always flushes state trie to DB.
 // If not, it flushes state trie to DB periodically. (period = bc.cacheConfig.BlockInterval)
-func (bc *BlockChain) writeStateTrie(block *types.Block, state *state.StateDB) error {
+// In the path scheme, only the state of a block becoming canonical is flushed.
+func (bc *BlockChain) writeStateTrie(block *types.Block, state *state.StateDB, canonical bool) error {
 	state.LockGCCachedNode()
 	defer state.UnlockGCCachedNode()
 
@@ -1363,7 +1369,16 @@ func (bc *BlockChain) writeStateTrie(block *types.Block, state *state.StateDB) e
 
 	// If we're running an archive node, always flush
 	if bc.isArchiveMode() {
-		if err := trieDB.Commit(root, false, block.NumberU64()); err != nil {
+		if trieDB.Scheme() == statedb.PathScheme {
+			// The disk keeps a single state in the path scheme. The state of a
+			// side block stays in memory until its chain becomes canonical.
+			if !canonical {
+				return nil
+			}
+			if err := bc.commitPathState(block, root); err != nil {
+				return err
+			}
+		} else if err := trieDB.Commit(root, false, block.NumberU64()); err != nil {
 			return err
 		}
 
@@ -1418,6 +1433,47 @@ func (bc *BlockChain) writeStateTrie(block *types.Block, state *state.StateDB) e
 	return nil
 }
 
+// commitPathState persists the state of the given block becoming canonical in
+// the path scheme. If the block is not built on top of the current head, the
+// disk state is rolled back to the common ancestor, and the states of the
+// blocks of the new chain, kept in memory while they were side blocks, are
+// persisted first.
+func (bc *BlockChain) commitPathState(block *types.Block, root common.Hash) error {
+	trieDB := bc.stateCache.TrieDB()
+	head := bc.CurrentBlock().Header()
+	if block.NumberU64() == 0 || block.ParentHash() == head.Hash() {
+		return trieDB.Commit(root, false, block.NumberU64())
+	}
+
+	var newChain []*types.Header
+	ancestor := bc.GetHeader(block.ParentHash(), block.NumberU64()-1)
+	for ancestor != nil && ancestor.Number.Cmp(head.Number) > 0 {
+		newChain = append(newChain, ancestor)
+		ancestor = bc.GetHeader(ancestor.ParentHash, ancestor.Number.Uint64()-1)
+	}
+	for head != nil && ancestor != nil && head.Number.Cmp(ancestor.Number) > 0 {
+		head = bc.GetHeader(head.ParentHash, head.Number.Uint64()-1)
+	}
+	for head != nil && ancestor != nil && head.Hash() != ancestor.Hash() {
+		newChain = append(newChain, ancestor)
+		ancestor = bc.GetHeader(ancestor.ParentHash, ancestor.Number.Uint64()-1)
+		head = bc.GetHeader(head.ParentHash, head.Number.Uint64()-1)
+	}
+	if head == nil || ancestor == nil {
+		return consensus.ErrUnknownAncestor
+	}
+
+	if err := trieDB.Recover(ancestor.Root); err != nil {
+		return err
+	}
+	for i := len(newChain) - 1; i >= 0; i-- {
+		if err := trieDB.Commit(newChain[i].Root, false, newChain[i].Number.Uint64()); err != nil {
+			return err
+		}
+	}
+	return trieDB.Commit(root, false, block.NumberU64())
+}
+
 // RLockGCCachedNode locks the GC lock of CachedNode.
 func (bc *BlockChain) RLockGCCachedNode() {
 	bc.stateCache.RLockGCCachedNode()
@@ -1633,6 +1689,16 @@ func (bc *BlockChain) writeBlockWithStateSerial(block *types.Block, receipts []*
 	localTd := bc.GetTd(currentBlock.Hash(), currentBlock.NumberU64())
 	externTd := new(big.Int).Add(block.BlockScore(), ptd)
 
+	// TODO-Klaytn-Issue264 If we are using istanbul BFT, then we always have a canonical chain.
+	//         Later we may be able to refine below code.
+
+	// If the total blockscore is higher than our known, add it to the canonical chain
+	// Second clause in the if statement reduces the vulnerability to selfish mining.
+	// Please refer to http://www.cs.cornell.edu/~ie53/publications/btcProcFC.pdf
+	// It is decided before writing the state, since the state is persisted
+	// in the path scheme only for the canonical blocks.
+	reorg := isReorganizationRequired(localTd, externTd, currentBlock, block)
+
 	// Irrelevant of the canonical status, write the block itself to the database
 	bc.hc.WriteTd(block.Hash(), block.NumberU64(), externTd)
 
@@ -1640,21 +1706,13 @@ func (bc *BlockChain) writeBlockWithStateSerial(block *types.Block, receipts []*
 	bc.writeBlock(block)
 
 	trieWriteStart := time.Now()
-	if err := bc.writeStateTrie(block, state); err != nil {
+	if err := bc.writeStateTrie(block, state, reorg); err != nil {
 		return WriteResult{Status: NonStatTy}, err
 	}
 	trieWriteTime := time.Since(trieWriteStart)
 
 	bc.writeReceipts(block.Hash(), block.NumberU64(), receipts)
 
-	// TODO-Klaytn-Issue264 If we are using istanbul BFT, then we always have a canonical chain.
-	//         Later we may be able to refine below code.
-
-	// If the total blockscore is higher than our known, add it to the canonical chain
-	// Second clause in the if statement reduces the vulnerability to selfish mining.
-	// Please refer to http://www.cs.cornell.edu/~ie53/publications/btcProcFC.pdf
-	currentBlock = bc.CurrentBlock()
-	reorg := isReorganizationRequired(localTd, externTd, currentBlock, block)
 	if reorg {
 		// Reorganise the chain if the parent is not the head block
 		if block.ParentHash() != currentBlock.Hash() {
@@ -1698,6 +1756,16 @@ func (bc *BlockChain) writeBlockWithStateParallel(block *types.Block, receipts [
 	localTd := bc.GetTd(currentBlock.Hash(), currentBlock.NumberU64())
 	externTd := new(big.Int).Add(block.BlockScore(), ptd)
 
+	// TODO-Klaytn-Issue264 If we are using istanbul BFT, then we always have a canonical chain.
+	//         Later we may be able to refine below code.
+
+	// If the total blockscore is higher than our known, add it to the canonical chain
+	// Second clause in the if statement reduces the vulnerability to selfish mining.
+	// Please refer to http://www.cs.cornell.edu/~ie53/publications/btcProcFC.pdf
+	// It is decided before writing the state, since the state is persisted
+	// in the path scheme only for the canonical blocks.
+	reorg := isReorganizationRequired(localTd, externTd, currentBlock, block)
+
 	parallelDBWriteWG := sync.WaitGroup{}
 	parallelDBWriteErrCh := make(chan error, 2)
 	// Irrelevant of the canonical status, write the block itself to the database
@@ -1718,7 +1786,7 @@ func (bc *BlockChain) writeBlockWithStateParallel(block *types.Block, receipts [
 	trieWriteStart := time.Now()
 	go func() {
 		defer parallelDBWriteWG.Done()
-		if err := bc.writeStateTrie(block, state); err != nil {
+		if err := bc.writeStateTrie(block, state, reorg); err != nil {
 			parallelDBWriteErrCh <- err
 		}
 		trieWriteTime = time.Since(trieWriteStart)
@@ -1737,14 +1805,6 @@ func (bc *BlockChain) writeBlockWithStateParallel(block *types.Block, receipts [
 	default:
 	}
 
-	// TODO-Klaytn-Issue264 If we are using istanbul BFT, then we always have a canonical chain.
-	//         Later we may be able to refine below code.
-
-	// If the total blockscore is higher than our known, add it to the canonical chain
-	// Second clause in the if statement reduces the vulnerability to selfish mining.
-	// Please refer to http://www.cs.cornell.edu/~ie53/publications/btcProcFC.pdf
-	currentBlock = bc.CurrentBlock()
-	reorg := isReorganizationRequired(localTd, externTd, currentBlock, block)
 	if reorg {
 		// Reorganise the chain if the parent is not the head block
 		if block.ParentHash() != currentBlock.Hash() {
@@ -2701,8 +2761,10 @@ func (bc *BlockChain) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscript
 
 // isArchiveMode returns whether current blockchain is in archiving mode or not.
 // cacheConfig.ArchiveMode means trie caching is disabled.
+// In the path scheme, trie caching is always disabled as well since the state
+// trie is written to database on every block.
 func (bc *BlockChain) isArchiveMode() bool {
-	return bc.cacheConfig.ArchiveMode
+	return bc.cacheConfig.ArchiveMode || bc.stateCache.TrieDB().Scheme() == statedb.PathScheme
 }
 
 // IsParallelDBWrite returns if parallel write is enabled or not.
@@ -2730,6 +2792,12 @@ func (bc *BlockChain) SaveTrieNodeCacheToDisk() error {
 // for the transaction, gas used and an error if the transaction failed,
 // indicating the block was invalid.
 func (bc *BlockChain) ApplyTransaction(chainConfig *params.ChainConfig, author *common.Address, statedb *state.StateDB, header *types.Header, tx *types.Transaction, usedGas *uint64, vmConfig *vm.Config) (*types.Receipt, *vm.InternalTxTrace, error) {
+	return applyTransaction(chainConfig, bc, author, statedb, header, tx, usedGas, vmConfig)
+}
+
+// applyTransaction is ApplyTransaction with the chain which provides the consensus
+// engine and the ancestor headers for the EVM environment.
+func applyTransaction(chainConfig *params.ChainConfig, chain ChainContext, author *common.Address, statedb *state.StateDB, header *types.Header, tx *types.Transaction, usedGas *uint64, vmConfig *vm.Config) (*types.Receipt, *vm.InternalTxTrace, error) {
 	// TODO-Klaytn We reject transactions with unexpected gasPrice and do not put the transaction into TxPool.
 	//         And we run transactions regardless of gasPrice if we push transactions in the TxPool.
 	/*
@@ -2751,7 +2819,7 @@ func (bc *BlockChain) ApplyTransaction(chainConfig *params.ChainConfig, author *
 		return nil, nil, err
 	}
 	// Create a new context to be used in the EVM environment
-	blockContext := NewEVMBlockContext(header, bc, author)
+	blockContext := NewEVMBlockContext(header, chain, author)
 	txContext := NewEVMTxContext(msg, header)
 	// Create a new environment which holds all relevant information
 	// about the transaction and calling mechanisms.
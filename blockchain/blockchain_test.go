@@ -2205,3 +2205,125 @@ func TestTransientStorageReset(t *testing.T) {
 		t.Fatalf("Unexpected dirty storage slot")
 	}
 }
+
+// Tests that a chain storing the state in the path scheme serves the state of
+// the new head after reorganising to a side chain.
+func TestPathSchemeReorg(t *testing.T) {
+	var (
+		gendb   = database.NewMemoryDBManager()
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		funds   = big.NewInt(1000000000)
+		gspec   = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  GenesisAlloc{address: {Balance: funds}},
+		}
+		genesis = gspec.MustCommit(gendb)
+		signer  = types.LatestSignerForChainID(gspec.Config.ChainID)
+		engine  = gxhash.NewFaker()
+	)
+	transfer := func(to common.Address) func(int, *BlockGen) {
+		return func(i int, block *BlockGen) {
+			tx, err := types.SignTx(types.NewTransaction(block.TxNonce(address), to, big.NewInt(1000), params.TxGas, nil, nil), signer, key)
+			if err != nil {
+				panic(err)
+			}
+			block.AddTx(tx)
+		}
+	}
+	blocks, _ := GenerateChain(gspec.Config, genesis, engine, gendb, 10, transfer(common.Address{0x01}))
+	forks, _ := GenerateChain(gspec.Config, blocks[4], engine, gendb, 8, transfer(common.Address{0x02}))
+
+	db := database.NewMemoryDBManager()
+	db.WriteStateScheme(statedb.PathScheme)
+	gspec.MustCommit(db)
+	chain, err := NewBlockChain(db, nil, gspec.Config, engine, vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert block %d: %v", n, err)
+	}
+	if n, err := chain.InsertChain(forks); err != nil {
+		t.Fatalf("failed to insert fork block %d: %v", n, err)
+	}
+	head := chain.CurrentBlock()
+	if head.Hash() != forks[len(forks)-1].Hash() {
+		t.Fatalf("head mismatch: have %x, want %x", head.Hash(), forks[len(forks)-1].Hash())
+	}
+	state, err := chain.State()
+	if err != nil {
+		t.Fatalf("failed to read the head state: %v", err)
+	}
+	if have, want := state.GetBalance(common.Address{0x01}), big.NewInt(5*1000); have.Cmp(want) != 0 {
+		t.Errorf("balance mismatch of the main chain recipient: have %v, want %v", have, want)
+	}
+	if have, want := state.GetBalance(common.Address{0x02}), big.NewInt(8*1000); have.Cmp(want) != 0 {
+		t.Errorf("balance mismatch of the fork recipient: have %v, want %v", have, want)
+	}
+}
+
+// Tests that inserting a shorter side chain does not change the state of the
+// canonical head persisted in the path scheme.
+func TestPathSchemeShorterSideChain(t *testing.T) {
+	var (
+		gendb   = database.NewMemoryDBManager()
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		funds   = big.NewInt(1000000000)
+		gspec   = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  GenesisAlloc{address: {Balance: funds}},
+		}
+		genesis = gspec.MustCommit(gendb)
+		signer  = types.LatestSignerForChainID(gspec.Config.ChainID)
+		engine  = gxhash.NewFaker()
+	)
+	transfer := func(to common.Address) func(int, *BlockGen) {
+		return func(i int, block *BlockGen) {
+			tx, err := types.SignTx(types.NewTransaction(block.TxNonce(address), to, big.NewInt(1000), params.TxGas, nil, nil), signer, key)
+			if err != nil {
+				panic(err)
+			}
+			block.AddTx(tx)
+		}
+	}
+	blocks, _ := GenerateChain(gspec.Config, genesis, engine, gendb, 10, transfer(common.Address{0x01}))
+	forks, _ := GenerateChain(gspec.Config, blocks[3], engine, gendb, 2, transfer(common.Address{0x02}))
+
+	db := database.NewMemoryDBManager()
+	db.WriteStateScheme(statedb.PathScheme)
+	gspec.MustCommit(db)
+	chain, err := NewBlockChain(db, nil, gspec.Config, engine, vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert block %d: %v", n, err)
+	}
+	if n, err := chain.InsertChain(forks); err != nil {
+		t.Fatalf("failed to insert fork block %d: %v", n, err)
+	}
+	head := chain.CurrentBlock()
+	if head.Hash() != blocks[len(blocks)-1].Hash() {
+		t.Fatalf("head mismatch: have %x, want %x", head.Hash(), blocks[len(blocks)-1].Hash())
+	}
+	state, err := chain.State()
+	if err != nil {
+		t.Fatalf("failed to read the head state: %v", err)
+	}
+	if have, want := state.GetBalance(common.Address{0x01}), big.NewInt(10*1000); have.Cmp(want) != 0 {
+		t.Errorf("balance mismatch of the main chain recipient: have %v, want %v", have, want)
+	}
+	if have := state.GetBalance(common.Address{0x02}); have.Sign() != 0 {
+		t.Errorf("balance of the side chain recipient is found in the head state: %v", have)
+	}
+	// The state of a recent canonical block is still served.
+	if _, err := chain.StateAt(blocks[7].Root()); err != nil {
+		t.Errorf("failed to read the state of block %d: %v", blocks[7].NumberU64(), err)
+	}
+}
@@ -109,7 +109,7 @@ type Trie interface {
 	// If the trie does not contain a value for key, the returned proof contains all
 	// nodes of the longest existing prefix of the key (at least the root), ending
 	// with the node that proves the absence of the key.
-	Prove(key []byte, fromLevel uint, proofDb database.DBManager) error
+	Prove(key []byte, fromLevel uint, proofDb statedb.ProofDBWriter) error
 }
 
 // NewDatabase creates a backing store for state. The returned database is safe for
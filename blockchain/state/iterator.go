@@ -126,7 +126,12 @@ func (it *NodeIterator) step() error {
 	obj := serializer.GetAccount()
 
 	if pa := account.GetProgramAccount(obj); pa != nil {
-		dataTrie, err := it.state.db.OpenStorageTrie(pa.GetStorageRoot(), nil)
+		// The owner is known only if the iterator is rooted at the state root
+		var opts *statedb.TrieOpts
+		if len(it.stateIt.Path()) == 2*common.HashLength+1 {
+			opts = &statedb.TrieOpts{Owner: common.BytesToHash(it.stateIt.LeafKey())}
+		}
+		dataTrie, err := it.state.db.OpenStorageTrie(pa.GetStorageRoot(), opts)
 		if err != nil {
 			return err
 		}
@@ -35,6 +35,7 @@ import (
 	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/kerrors"
 	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/statedb"
 )
 
 var emptyCodeHash = crypto.Keccak256(nil)
@@ -167,7 +168,12 @@ func (s *stateObject) touch() {
 }
 
 func (s *stateObject) openStorageTrie(hash common.ExtHash, db Database) (Trie, error) {
-	return db.OpenStorageTrie(hash, s.db.trieOpts)
+	opts := statedb.TrieOpts{}
+	if s.db.trieOpts != nil {
+		opts = *s.db.trieOpts
+	}
+	opts.Owner = s.addrHash
+	return db.OpenStorageTrie(hash, &opts)
 }
 
 func (s *stateObject) getStorageTrie(db Database) Trie {
@@ -205,6 +211,9 @@ func (s *stateObject) GetCommittedState(db Database, key common.Hash) common.Has
 	if cached {
 		return value
 	}
+	if s.db.witness != nil {
+		s.db.witness.addStorage(s.address, key)
+	}
 	// Track the amount of time wasted on reading the storage trie
 	var (
 		enc   []byte
@@ -347,6 +356,11 @@ func (s *stateObject) updateStorageTrie(db Database) Trie {
 	var storage map[common.Hash][]byte
 	// Insert all the pending updates into the trie
 	tr := s.getStorageTrie(db)
+	// Deletions are applied after the updates, so that the trie nodes resolved
+	// to collapse the trie do not depend on the iteration order of the map.
+	// Otherwise the nodes recorded in an execution witness could differ from
+	// the nodes needed to re-execute the block.
+	var deletions []common.Hash
 	for key, value := range s.dirtyStorage {
 		delete(s.dirtyStorage, key)
 
@@ -358,7 +372,7 @@ func (s *stateObject) updateStorageTrie(db Database) Trie {
 
 		var v []byte
 		if (value == common.Hash{}) {
-			s.setError(tr.TryDelete(key[:]))
+			deletions = append(deletions, key)
 		} else {
 			// Encoding []byte cannot fail, ok to ignore the error.
 			v, _ = rlp.EncodeToBytes(bytes.TrimLeft(value[:], "\x00"))
@@ -376,6 +390,9 @@ func (s *stateObject) updateStorageTrie(db Database) Trie {
 			storage[crypto.Keccak256Hash(key[:])] = v // v will be nil if it's deleted
 		}
 	}
+	for _, key := range deletions {
+		s.setError(tr.TryDelete(key[:]))
+	}
 	return tr
 }
 
@@ -502,6 +519,9 @@ func (s *stateObject) Code(db Database) []byte {
 	if err != nil {
 		s.setError(fmt.Errorf("can't load code hash %x: %v", s.CodeHash(), err))
 	}
+	if s.db.witness != nil {
+		s.db.witness.addCode(common.BytesToHash(s.CodeHash()), code)
+	}
 	s.code = code
 	return code
 }
@@ -516,6 +536,10 @@ func (s *stateObject) CodeSize(db Database) int {
 	if bytes.Equal(s.CodeHash(), emptyCodeHash) {
 		return 0
 	}
+	if s.db.witness != nil {
+		// The code itself is needed by the witness to prove its size.
+		return len(s.Code(db))
+	}
 	size, err := db.ContractCodeSize(common.BytesToHash(s.CodeHash()))
 	if err != nil {
 		s.setError(fmt.Errorf("can't load code size %x: %v", s.CodeHash(), err))
@@ -106,6 +106,9 @@ type StateDB struct {
 
 	prefetching bool
 
+	// If witness is set, the accessed state is recorded into it.
+	witness *Witness
+
 	// Measurements gathered during execution for debugging purposes
 	AccountReads         time.Duration
 	AccountHashes        time.Duration
@@ -412,6 +415,32 @@ func (s *StateDB) StorageTrie(addr common.Address) Trie {
 	return cpy.updateStorageTrie(s.db)
 }
 
+// proofList collects the encoded trie nodes of a merkle proof in order from the root.
+type proofList [][]byte
+
+func (n *proofList) WriteMerkleProof(key, value []byte) {
+	*n = append(*n, value)
+}
+
+// GetProof returns the merkle proof of the account of the given address.
+func (s *StateDB) GetProof(addr common.Address) ([][]byte, error) {
+	var proof proofList
+	err := s.trie.Prove(crypto.Keccak256(addr.Bytes()), 0, &proof)
+	return proof, err
+}
+
+// GetStorageProof returns the merkle proof of the given storage slot of an account.
+// The proof is empty for non-existent accounts.
+func (s *StateDB) GetStorageProof(addr common.Address, key common.Hash) ([][]byte, error) {
+	var proof proofList
+	trie := s.StorageTrie(addr)
+	if trie == nil {
+		return proof, nil
+	}
+	err := trie.Prove(crypto.Keccak256(key.Bytes()), 0, &proof)
+	return proof, err
+}
+
 func (s *StateDB) HasSelfDestructed(addr common.Address) bool {
 	stateObject := s.getStateObject(addr)
 	if stateObject != nil {
@@ -606,6 +635,18 @@ func (s *StateDB) deleteStateObject(stateObject *stateObject) {
 	s.setError(s.trie.TryDelete(addr[:]))
 }
 
+// SetWitness sets the witness which records the state accessed by the StateDB.
+// The tries of the StateDB should be opened with the trie options of the
+// witness to record the accessed trie nodes as well.
+func (s *StateDB) SetWitness(w *Witness) {
+	s.witness = w
+}
+
+// Witness returns the witness set to the StateDB, if any.
+func (s *StateDB) Witness() *Witness {
+	return s.witness
+}
+
 // getStateObject retrieves a state object given by the address, returning nil if
 // the object is not found or was deleted in this execution context. If you need
 // to differentiate between non-existent/just-deleted, use getDeletedStateObject.
@@ -625,6 +666,9 @@ func (s *StateDB) getDeletedStateObject(addr common.Address) *stateObject {
 	if obj := s.stateObjects[addr]; obj != nil {
 		return obj
 	}
+	if s.witness != nil {
+		s.witness.addAccount(addr)
+	}
 	// If no live objects are available, attempt to use snapshots
 	var (
 		acc account.Account
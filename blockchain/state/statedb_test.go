@@ -34,6 +34,7 @@ import (
 
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/storage/database"
 	"github.com/klaytn/klaytn/storage/statedb"
@@ -832,3 +833,45 @@ func TestStateDBTransientStorage(t *testing.T) {
 		t.Fatalf("transient storage mismatch: have %x, want %x", got, value)
 	}
 }
+
+// Tests that the storage tries are stored by their owners in the path scheme,
+// and are deleted and recovered along with the accounts.
+func TestPathSchemeStorage(t *testing.T) {
+	memDBManager := database.NewMemoryDBManager()
+	memDBManager.WriteStateScheme(statedb.PathScheme)
+	sdb := NewDatabase(memDBManager)
+	triedb := sdb.TrieDB()
+
+	addr := common.HexToAddress("0x1234")
+	owner := crypto.Keccak256Hash(addr[:])
+
+	// Block 1 creates a contract with storage
+	state, _ := New(common.Hash{}, sdb, nil, nil)
+	state.CreateSmartContractAccount(addr, params.CodeFormatEVM, params.Rules{IsIstanbul: true})
+	for i := byte(1); i <= 20; i++ {
+		state.SetState(addr, common.BytesToHash([]byte{i}), common.BytesToHash([]byte{i, i}))
+	}
+	root1, err := state.Commit(false)
+	assert.NoError(t, err)
+	assert.NoError(t, triedb.Commit(root1, false, 1))
+
+	paths, _ := memDBManager.ReadStorageTrieNodes(owner)
+	assert.NotEmpty(t, paths)
+
+	// Block 2 destructs the contract
+	state, _ = New(root1, sdb, nil, nil)
+	state.SelfDestruct(addr)
+	root2, err := state.Commit(true)
+	assert.NoError(t, err)
+	assert.NoError(t, triedb.Commit(root2, false, 2))
+
+	paths, _ = memDBManager.ReadStorageTrieNodes(owner)
+	assert.Empty(t, paths)
+
+	// The storage is readable again after rolling the state back
+	assert.NoError(t, triedb.Recover(root1))
+	state, _ = New(root1, NewDatabase(memDBManager), nil, nil)
+	for i := byte(1); i <= 20; i++ {
+		assert.Equal(t, common.BytesToHash([]byte{i, i}), state.GetState(addr, common.BytesToHash([]byte{i})))
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/storage/statedb"
+)
+
+// Witness collects the accounts, storage slots, contract codes and trie nodes
+// accessed while executing a block. Together with the block headers, the
+// collected data is enough to re-execute the block without the state database.
+//
+// Witness implements statedb.NodeRecorder and statedb.ProofDBWriter, so that it
+// can be used to record the trie nodes resolved by the tries of a StateDB and
+// the merkle proofs of the accessed state.
+type Witness struct {
+	accounts map[common.Address]struct{}
+	storage  map[common.Address]map[common.Hash]struct{}
+	codes    map[common.Hash][]byte
+	nodes    map[common.Hash][]byte
+
+	lock sync.Mutex
+}
+
+// NewWitness returns an empty witness.
+func NewWitness() *Witness {
+	return &Witness{
+		accounts: make(map[common.Address]struct{}),
+		storage:  make(map[common.Address]map[common.Hash]struct{}),
+		codes:    make(map[common.Hash][]byte),
+		nodes:    make(map[common.Hash][]byte),
+	}
+}
+
+// TrieOpts returns the trie options which record the resolved trie nodes into the witness.
+func (w *Witness) TrieOpts() *statedb.TrieOpts {
+	return &statedb.TrieOpts{Recorder: w}
+}
+
+// RecordNode implements statedb.NodeRecorder.
+func (w *Witness) RecordNode(hash common.Hash, enc []byte) {
+	if len(enc) == 0 {
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if _, ok := w.nodes[hash]; !ok {
+		w.nodes[hash] = common.CopyBytes(enc)
+	}
+}
+
+// WriteMerkleProof implements statedb.ProofDBWriter. The key is ignored since
+// the witness indexes the trie nodes by their hashes.
+func (w *Witness) WriteMerkleProof(key, value []byte) {
+	w.RecordNode(crypto.Keccak256Hash(value), value)
+}
+
+func (w *Witness) addAccount(addr common.Address) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.accounts[addr] = struct{}{}
+}
+
+func (w *Witness) addStorage(addr common.Address, key common.Hash) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.accounts[addr] = struct{}{}
+	if w.storage[addr] == nil {
+		w.storage[addr] = make(map[common.Hash]struct{})
+	}
+	w.storage[addr][key] = struct{}{}
+}
+
+func (w *Witness) addCode(hash common.Hash, code []byte) {
+	if len(code) == 0 {
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.codes[hash] = code
+}
+
+// Prove adds the merkle proofs of all accessed accounts and storage slots
+// against the state of the given root to the witness.
+func (w *Witness) Prove(db Database, root common.Hash) error {
+	accounts := w.Accounts()
+	storage := w.Storage()
+
+	tr, err := db.OpenTrie(root, w.TrieOpts())
+	if err != nil {
+		return err
+	}
+	for _, addr := range accounts {
+		if err := tr.Prove(crypto.Keccak256(addr[:]), 0, w); err != nil {
+			return err
+		}
+	}
+	if len(storage) == 0 {
+		return nil
+	}
+	stateDB, err := New(root, db, nil, w.TrieOpts())
+	if err != nil {
+		return err
+	}
+	for _, addr := range accounts {
+		keys := storage[addr]
+		if len(keys) == 0 {
+			continue
+		}
+		obj := stateDB.getStateObject(addr)
+		if obj == nil {
+			continue
+		}
+		st := obj.getStorageTrie(db)
+		for _, key := range keys {
+			if err := st.Prove(crypto.Keccak256(key[:]), 0, w); err != nil {
+				return err
+			}
+		}
+	}
+	return stateDB.Error()
+}
+
+// Accounts returns the accessed accounts in ascending order.
+func (w *Witness) Accounts() []common.Address {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	accounts := make([]common.Address, 0, len(w.accounts))
+	for addr := range w.accounts {
+		accounts = append(accounts, addr)
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return bytes.Compare(accounts[i][:], accounts[j][:]) < 0
+	})
+	return accounts
+}
+
+// Storage returns the accessed storage slots of each account in ascending order.
+func (w *Witness) Storage() map[common.Address][]common.Hash {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	storage := make(map[common.Address][]common.Hash, len(w.storage))
+	for addr, slots := range w.storage {
+		keys := make([]common.Hash, 0, len(slots))
+		for key := range slots {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return bytes.Compare(keys[i][:], keys[j][:]) < 0
+		})
+		storage[addr] = keys
+	}
+	return storage
+}
+
+// Codes returns the accessed contract codes ordered by their hashes.
+func (w *Witness) Codes() [][]byte {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return sortedValues(w.codes)
+}
+
+// Nodes returns the encoded trie nodes ordered by their hashes.
+func (w *Witness) Nodes() [][]byte {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return sortedValues(w.nodes)
+}
+
+func sortedValues(m map[common.Hash][]byte) [][]byte {
+	hashes := make([]common.Hash, 0, len(m))
+	for hash := range m {
+		hashes = append(hashes, hash)
+	}
+	sort.Slice(hashes, func(i, j int) bool {
+		return bytes.Compare(hashes[i][:], hashes[j][:]) < 0
+	})
+	values := make([][]byte, len(hashes))
+	for i, hash := range hashes {
+		values[i] = m[hash]
+	}
+	return values
+}
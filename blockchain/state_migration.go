@@ -316,6 +316,10 @@ func (bc *BlockChain) PrepareStateMigration() error {
 		return errors.New("state migration not supported with live pruning enabled")
 	}
 
+	if bc.stateCache.TrieDB().Scheme() == statedb.PathScheme {
+		return errors.New("state migration not supported in the path scheme")
+	}
+
 	if bc.db.InMigration() || bc.prepareStateMigration {
 		return errors.New("migration already started")
 	}
@@ -63,6 +63,12 @@ func NewStateProcessor(config *params.ChainConfig, bc *BlockChain, engine consen
 // returns the amount of gas that was used in the process. If any of the
 // transactions failed to execute due to insufficient gas it will return an error.
 func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg vm.Config) (types.Receipts, []*types.Log, uint64, []*vm.InternalTxTrace, ProcessStats, error) {
+	return process(p.config, p.bc, p.engine, block, statedb, cfg)
+}
+
+// process is the body of StateProcessor.Process, which executes the block on
+// top of the given chain instead of the canonical block chain.
+func process(config *params.ChainConfig, chain consensus.ChainReader, engine consensus.Engine, block *types.Block, statedb *state.StateDB, cfg vm.Config) (types.Receipts, []*types.Log, uint64, []*vm.InternalTxTrace, ProcessStats, error) {
 	var (
 		receipts         types.Receipts
 		usedGas          = new(uint64)
@@ -76,13 +82,13 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 	cfg.UseOpcodeComputationCost = true
 
 	// Extract author from the header
-	author, _ := p.bc.Engine().Author(header) // Ignore error, we're past header validation
+	author, _ := chain.Engine().Author(header) // Ignore error, we're past header validation
 
 	processStats.BeforeApplyTxs = time.Now()
 	// Iterate over and process the individual transactions
 	for i, tx := range block.Transactions() {
 		statedb.SetTxContext(tx.Hash(), block.Hash(), i)
-		receipt, internalTxTrace, err := p.bc.ApplyTransaction(p.config, &author, statedb, header, tx, usedGas, &cfg)
+		receipt, internalTxTrace, err := applyTransaction(config, chain, &author, statedb, header, tx, usedGas, &cfg)
 		if err != nil {
 			return nil, nil, 0, nil, processStats, err
 		}
@@ -93,7 +99,7 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 	processStats.AfterApplyTxs = time.Now()
 
 	// Finalize the block, applying any consensus engine specific extras (e.g. block rewards)
-	if _, err := p.engine.Finalize(p.bc, header, statedb, block.Transactions(), receipts); err != nil {
+	if _, err := engine.Finalize(chain, header, statedb, block.Transactions(), receipts); err != nil {
 		return nil, nil, 0, nil, processStats, err
 	}
 	processStats.AfterFinalize = time.Now()
@@ -28,10 +28,14 @@ import (
 
 const (
 	AnchoringDataType0    uint8 = 0
+	AnchoringDataType1    uint8 = 1
 	AnchoringJSONDataType uint8 = 128
 )
 
-var errUnknownAnchoringTxType = errors.New("unknown anchoring tx type")
+var (
+	errUnknownAnchoringTxType      = errors.New("unknown anchoring tx type")
+	errInvalidAnchoringBlockHashes = errors.New("block hashes do not end with the anchored block")
+)
 
 type AnchoringDataInternal interface {
 	GetBlockHash() common.Hash
@@ -98,6 +102,48 @@ func NewAnchoringDataType0(block *Block, blockCount uint64, txCount uint64) (*An
 	return &AnchoringData{AnchoringDataType0, encodedCCTxData}, nil
 }
 
+// AnchoringDataInternalType1 commits to every block of an anchoring period with a
+// Merkle root over their block hashes. BlockHash and BlockNumber are the ones of
+// the last block of the period.
+type AnchoringDataInternalType1 struct {
+	BlockHash        common.Hash `json:"blockHash"`
+	BlockNumber      *big.Int    `json:"blockNumber"`
+	StartBlockNumber *big.Int    `json:"startBlockNumber"`
+	BlockCount       *big.Int    `json:"blockCount"`
+	TxCount          *big.Int    `json:"txCount"`
+	BlockHashesRoot  common.Hash `json:"blockHashesRoot"`
+}
+
+func (data *AnchoringDataInternalType1) GetBlockHash() common.Hash {
+	return data.BlockHash
+}
+
+func (data *AnchoringDataInternalType1) GetBlockNumber() *big.Int {
+	return data.BlockNumber
+}
+
+// NewAnchoringDataType1 returns an anchoring data of the given block which ends an
+// anchoring period. blockHashes are the hashes of all blocks of the period in order.
+func NewAnchoringDataType1(block *Block, blockHashes []common.Hash, txCount uint64) (*AnchoringData, error) {
+	blockCount := uint64(len(blockHashes))
+	if blockCount == 0 || blockHashes[blockCount-1] != block.Hash() {
+		return nil, errInvalidAnchoringBlockHashes
+	}
+	data := &AnchoringDataInternalType1{
+		BlockHash:        block.Hash(),
+		BlockNumber:      block.Number(),
+		StartBlockNumber: new(big.Int).SetUint64(block.NumberU64() - blockCount + 1),
+		BlockCount:       new(big.Int).SetUint64(blockCount),
+		TxCount:          new(big.Int).SetUint64(txCount),
+		BlockHashesRoot:  AnchoringMerkleRoot(blockHashes),
+	}
+	encodedCCTxData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	return &AnchoringData{AnchoringDataType1, encodedCCTxData}, nil
+}
+
 func NewAnchoringJSONDataType(v interface{}) (*AnchoringData, error) {
 	encoded, err := json.Marshal(v)
 	if err != nil {
@@ -125,6 +171,14 @@ func DecodeAnchoringData(data []byte) (AnchoringDataInternal, error) {
 		logger.Trace("decoded type0 anchoring tx", "blockNum", anchoringDataInternal.BlockNumber.String(), "blockHash", anchoringDataInternal.BlockHash.String(), "txHash", anchoringDataInternal.TxHash.String(), "txCount", anchoringDataInternal.TxCount)
 		return anchoringDataInternal, nil
 	}
+	if anchoringData.Type == AnchoringDataType1 {
+		anchoringDataInternal := new(AnchoringDataInternalType1)
+		if err := rlp.DecodeBytes(anchoringData.Data, anchoringDataInternal); err != nil {
+			return nil, err
+		}
+		logger.Trace("decoded type1 anchoring tx", "blockNum", anchoringDataInternal.BlockNumber.String(), "blockHash", anchoringDataInternal.BlockHash.String(), "blockHashesRoot", anchoringDataInternal.BlockHashesRoot.String(), "blockCount", anchoringDataInternal.BlockCount)
+		return anchoringDataInternal, nil
+	}
 	return nil, errUnknownAnchoringTxType
 }
 
@@ -145,6 +199,13 @@ func DecodeAnchoringDataToJSON(data []byte) (interface{}, error) {
 		}
 		return anchoringDataInternal, nil
 	}
+	if anchoringData.Type == AnchoringDataType1 {
+		anchoringDataInternal := new(AnchoringDataInternalType1)
+		if err := rlp.DecodeBytes(anchoringData.Data, anchoringDataInternal); err != nil {
+			return nil, err
+		}
+		return anchoringDataInternal, nil
+	}
 	if anchoringData.Type == AnchoringJSONDataType {
 		var v map[string]interface{}
 		if err := json.Unmarshal(anchoringData.Data, &v); err != nil {
@@ -116,6 +116,37 @@ func TestDecodingAnchoringTxType0(t *testing.T) {
 	assert.Equal(t, expResult, actResult)
 }
 
+func TestDecodingAnchoringTxType1(t *testing.T) {
+	block := genBlock()
+	block.header.Number = big.NewInt(100)
+	blockHashes := []common.Hash{genRandomHash(), genRandomHash(), block.Hash()}
+	txCount := rand.Uint64()
+	anchoringData, err := NewAnchoringDataType1(block, blockHashes, txCount)
+	assert.NoError(t, err)
+
+	data, err := rlp.EncodeToBytes(anchoringData)
+	assert.NoError(t, err)
+
+	decodedData, err := DecodeAnchoringData(data)
+	assert.NoError(t, err)
+	decodedInternalData, ok := decodedData.(*AnchoringDataInternalType1)
+	assert.True(t, ok)
+	assert.Equal(t, block.Hash(), decodedInternalData.BlockHash)
+	assert.Equal(t, uint64(100), decodedInternalData.BlockNumber.Uint64())
+	assert.Equal(t, uint64(98), decodedInternalData.StartBlockNumber.Uint64())
+	assert.Equal(t, uint64(3), decodedInternalData.BlockCount.Uint64())
+	assert.Equal(t, txCount, decodedInternalData.TxCount.Uint64())
+	assert.Equal(t, AnchoringMerkleRoot(blockHashes), decodedInternalData.BlockHashesRoot)
+
+	decodedDataJSON, err := DecodeAnchoringDataToJSON(data)
+	assert.NoError(t, err)
+	assert.Equal(t, decodedInternalData, decodedDataJSON)
+
+	// The block hashes must end with the anchored block.
+	_, err = NewAnchoringDataType1(block, blockHashes[:2], txCount)
+	assert.Error(t, err)
+}
+
 func TestDecodingAnchoringTxJSONType(t *testing.T) {
 	originalData := map[string]interface{}{
 		"int":    1,
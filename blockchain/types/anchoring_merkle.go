@@ -0,0 +1,112 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"errors"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+)
+
+var errAnchoringProofIndex = errors.New("leaf index out of range")
+
+// AnchoringBatch is a run of child chain blocks anchored by one anchoring tx
+// which commits to the Merkle root over their block hashes.
+type AnchoringBatch struct {
+	StartBlockNumber uint64
+	BlockHashes      []common.Hash
+	AnchoringTxHash  common.Hash
+}
+
+// anchoringMerkleLevels builds the levels of the Merkle tree over the given leaves,
+// from the leaves up to the root. A level with an odd number of nodes pairs its
+// last node with itself.
+func anchoringMerkleLevels(leaves []common.Hash) [][]common.Hash {
+	levels := [][]common.Hash{leaves}
+	for level := leaves; len(level) > 1; {
+		next := make([]common.Hash, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			right := level[i]
+			if i+1 < len(level) {
+				right = level[i+1]
+			}
+			next = append(next, crypto.Keccak256Hash(level[i].Bytes(), right.Bytes()))
+		}
+		levels = append(levels, next)
+		level = next
+	}
+	return levels
+}
+
+// anchoringMerkleDepth returns the number of levels above the leaves in the
+// Merkle tree over the given number of leaves.
+func anchoringMerkleDepth(leaves uint64) int {
+	depth := 0
+	for ; leaves > 1; leaves = (leaves + 1) / 2 {
+		depth++
+	}
+	return depth
+}
+
+// AnchoringMerkleRoot returns the Merkle root over the given block hashes.
+// The root of a single hash is the hash itself.
+func AnchoringMerkleRoot(leaves []common.Hash) common.Hash {
+	if len(leaves) == 0 {
+		return common.Hash{}
+	}
+	levels := anchoringMerkleLevels(leaves)
+	return levels[len(levels)-1][0]
+}
+
+// AnchoringMerkleProof returns the sibling hashes on the path from the leaf of the
+// given index up to the Merkle root, starting from the leaf level.
+func AnchoringMerkleProof(leaves []common.Hash, index uint64) ([]common.Hash, error) {
+	if index >= uint64(len(leaves)) {
+		return nil, errAnchoringProofIndex
+	}
+	levels := anchoringMerkleLevels(leaves)
+	proof := make([]common.Hash, 0, len(levels)-1)
+	for _, level := range levels[:len(levels)-1] {
+		sibling := index ^ 1
+		if sibling >= uint64(len(level)) {
+			sibling = index
+		}
+		proof = append(proof, level[sibling])
+		index /= 2
+	}
+	return proof, nil
+}
+
+// VerifyAnchoringMerkleProof checks that the leaf of the given index is included
+// in the Merkle tree of the given root over blockCount leaves. The proof must have
+// exactly one sibling per level of that tree.
+func VerifyAnchoringMerkleProof(root, leaf common.Hash, index, blockCount uint64, proof []common.Hash) bool {
+	if index >= blockCount || len(proof) != anchoringMerkleDepth(blockCount) {
+		return false
+	}
+	hash := leaf
+	for _, sibling := range proof {
+		if index%2 == 0 {
+			hash = crypto.Keccak256Hash(hash.Bytes(), sibling.Bytes())
+		} else {
+			hash = crypto.Keccak256Hash(sibling.Bytes(), hash.Bytes())
+		}
+		index /= 2
+	}
+	return index == 0 && hash == root
+}
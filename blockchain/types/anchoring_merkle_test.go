@@ -0,0 +1,61 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnchoringMerkleProof(t *testing.T) {
+	assert.Equal(t, common.Hash{}, AnchoringMerkleRoot(nil))
+
+	for n := 1; n <= 9; n++ {
+		leaves := make([]common.Hash, n)
+		for i := range leaves {
+			leaves[i] = crypto.Keccak256Hash([]byte{byte(n), byte(i)})
+		}
+		root := AnchoringMerkleRoot(leaves)
+
+		for i := range leaves {
+			proof, err := AnchoringMerkleProof(leaves, uint64(i))
+			assert.NoError(t, err)
+			assert.True(t, VerifyAnchoringMerkleProof(root, leaves[i], uint64(i), uint64(n), proof), "leaves=%d index=%d", n, i)
+
+			// A proof does not hold for another index or leaf.
+			assert.False(t, VerifyAnchoringMerkleProof(root, leaves[i], uint64(i)+uint64(1)<<uint(len(proof)), uint64(n), proof))
+			assert.False(t, VerifyAnchoringMerkleProof(root, common.Hash{}, uint64(i), uint64(n), proof))
+
+			// Nor for a block count whose tree has another depth.
+			assert.False(t, VerifyAnchoringMerkleProof(root, leaves[i], uint64(i), uint64(2*n), proof))
+			if len(proof) > 0 {
+				assert.False(t, VerifyAnchoringMerkleProof(root, leaves[i], uint64(i), uint64(n), proof[:len(proof)-1]))
+			}
+		}
+
+		_, err := AnchoringMerkleProof(leaves, uint64(n))
+		assert.Error(t, err)
+	}
+
+	leaves := []common.Hash{{1}, {2}, {3}}
+	left := crypto.Keccak256Hash(leaves[0].Bytes(), leaves[1].Bytes())
+	right := crypto.Keccak256Hash(leaves[2].Bytes(), leaves[2].Bytes())
+	assert.Equal(t, crypto.Keccak256Hash(left.Bytes(), right.Bytes()), AnchoringMerkleRoot(leaves))
+}
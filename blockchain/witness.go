@@ -0,0 +1,255 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+var (
+	// ErrWitnessLivePruning is returned if an execution witness is requested
+	// from a database with live pruning enabled.
+	ErrWitnessLivePruning = errors.New("execution witness is not supported with live pruning")
+
+	// ErrWitnessGenesis is returned if an execution witness is requested for the genesis block.
+	ErrWitnessGenesis = errors.New("execution witness is not available for the genesis block")
+
+	// ErrInvalidWitnessHeaders is returned if the headers of an execution witness
+	// are not the ancestors of the block.
+	ErrInvalidWitnessHeaders = errors.New("invalid witness headers")
+
+	errWitnessNoState = errors.New("state is not available in the execution witness")
+)
+
+// ExecutionWitness contains everything needed to execute a block statelessly.
+// Headers are the ancestors of the block accessed during the execution, starting
+// with the parent. Codes and State are the contract codes and the encoded trie
+// nodes, which include the proof paths of the accessed accounts and storage
+// slots against the state root of the parent. Accounts and Storage list the
+// accessed accounts and storage slots.
+type ExecutionWitness struct {
+	Headers  []*types.Header                  `json:"headers"`
+	Codes    []hexutil.Bytes                  `json:"codes"`
+	State    []hexutil.Bytes                  `json:"state"`
+	Accounts []common.Address                 `json:"accounts"`
+	Storage  map[common.Address][]common.Hash `json:"storage"`
+}
+
+// ExecutionWitness re-executes the block on top of the state of its parent and
+// returns the witness of the execution.
+func (bc *BlockChain) ExecutionWitness(block *types.Block) (*ExecutionWitness, error) {
+	if bc.cacheConfig.LivePruningEnabled {
+		return nil, ErrWitnessLivePruning
+	}
+	if block.NumberU64() == 0 {
+		return nil, ErrWitnessGenesis
+	}
+	parent := bc.GetHeader(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	// Snapshots are not used to make all state accesses go through the tries.
+	witness := state.NewWitness()
+	statedb, err := state.New(parent.Root, bc.stateCache, nil, witness.TrieOpts())
+	if err != nil {
+		return nil, err
+	}
+	statedb.SetWitness(witness)
+
+	chain := &headerRecorder{BlockChain: bc, parent: parent, oldest: parent.Number.Uint64()}
+	receipts, _, usedGas, _, _, err := process(bc.chainConfig, chain, bc.engine, block, statedb, vm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	if err := statedb.Error(); err != nil {
+		return nil, err
+	}
+	if err := validateState(block, statedb, receipts, usedGas); err != nil {
+		return nil, err
+	}
+	if err := witness.Prove(bc.stateCache, parent.Root); err != nil {
+		return nil, err
+	}
+
+	result := &ExecutionWitness{
+		Headers:  chain.ancestors(),
+		Accounts: witness.Accounts(),
+		Storage:  witness.Storage(),
+	}
+	for _, code := range witness.Codes() {
+		result.Codes = append(result.Codes, code)
+	}
+	for _, node := range witness.Nodes() {
+		result.State = append(result.State, node)
+	}
+	return result, nil
+}
+
+// VerifyExecutionWitness re-executes the block on an empty database filled only
+// with the witness, and checks the result against the block header.
+func VerifyExecutionWitness(config *params.ChainConfig, engine consensus.Engine, block *types.Block, witness *ExecutionWitness) error {
+	chain, err := newWitnessChain(config, engine, block, witness.Headers)
+	if err != nil {
+		return err
+	}
+	db := database.NewMemoryDBManager()
+	for _, node := range witness.State {
+		db.WriteTrieNode(crypto.Keccak256Hash(node).ExtendZero(), node)
+	}
+	for _, code := range witness.Codes {
+		db.WriteCode(crypto.Keccak256Hash(code), code)
+	}
+	statedb, err := state.New(chain.parent.Root, state.NewDatabase(db), nil, nil)
+	if err != nil {
+		return err
+	}
+	receipts, _, usedGas, _, _, err := process(config, chain, engine, block, statedb, vm.Config{})
+	if err != nil {
+		return err
+	}
+	if err := statedb.Error(); err != nil {
+		return fmt.Errorf("incomplete execution witness: %v", err)
+	}
+	return validateState(block, statedb, receipts, usedGas)
+}
+
+// headerRecorder is a BlockChain which records the ancestor headers accessed
+// during the execution of a block.
+type headerRecorder struct {
+	*BlockChain
+
+	parent *types.Header
+	oldest uint64 // Number of the oldest ancestor accessed
+	lock   sync.Mutex
+}
+
+func (r *headerRecorder) record(header *types.Header) *types.Header {
+	if header != nil {
+		r.lock.Lock()
+		if n := header.Number.Uint64(); n < r.oldest {
+			r.oldest = n
+		}
+		r.lock.Unlock()
+	}
+	return header
+}
+
+func (r *headerRecorder) GetHeader(hash common.Hash, number uint64) *types.Header {
+	return r.record(r.BlockChain.GetHeader(hash, number))
+}
+
+func (r *headerRecorder) GetHeaderByHash(hash common.Hash) *types.Header {
+	return r.record(r.BlockChain.GetHeaderByHash(hash))
+}
+
+func (r *headerRecorder) GetHeaderByNumber(number uint64) *types.Header {
+	return r.record(r.BlockChain.GetHeaderByNumber(number))
+}
+
+// ancestors returns the headers from the parent to the oldest accessed ancestor.
+// The headers between them are included to prove their ancestry.
+func (r *headerRecorder) ancestors() []*types.Header {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	headers := []*types.Header{r.parent}
+	for header := r.parent; header.Number.Uint64() > r.oldest; {
+		if header = r.BlockChain.GetHeader(header.ParentHash, header.Number.Uint64()-1); header == nil {
+			break
+		}
+		headers = append(headers, header)
+	}
+	return headers
+}
+
+// witnessChain is a consensus.ChainReader serving the headers of an execution
+// witness. It has no access to the state of the chain.
+type witnessChain struct {
+	config *params.ChainConfig
+	engine consensus.Engine
+	parent *types.Header
+
+	byHash   map[common.Hash]*types.Header
+	byNumber map[uint64]*types.Header
+}
+
+// newWitnessChain checks that the witness headers are the consecutive ancestors
+// of the block, and returns a chain serving them.
+func newWitnessChain(config *params.ChainConfig, engine consensus.Engine, block *types.Block, headers []*types.Header) (*witnessChain, error) {
+	if len(headers) == 0 || headers[0].Hash() != block.ParentHash() || headers[0].Number.Uint64()+1 != block.NumberU64() {
+		return nil, ErrInvalidWitnessHeaders
+	}
+	chain := &witnessChain{
+		config:   config,
+		engine:   engine,
+		parent:   headers[0],
+		byHash:   make(map[common.Hash]*types.Header, len(headers)),
+		byNumber: make(map[uint64]*types.Header, len(headers)),
+	}
+	for i, header := range headers {
+		if i > 0 && (headers[i-1].ParentHash != header.Hash() || headers[i-1].Number.Uint64() != header.Number.Uint64()+1) {
+			return nil, ErrInvalidWitnessHeaders
+		}
+		chain.byHash[header.Hash()] = header
+		chain.byNumber[header.Number.Uint64()] = header
+	}
+	return chain, nil
+}
+
+func (c *witnessChain) Config() *params.ChainConfig  { return c.config }
+func (c *witnessChain) CurrentHeader() *types.Header { return c.parent }
+func (c *witnessChain) CurrentBlock() *types.Block   { return nil }
+func (c *witnessChain) Engine() consensus.Engine     { return c.engine }
+
+func (c *witnessChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header := c.byHash[hash]; header != nil && header.Number.Uint64() == number {
+		return header
+	}
+	return nil
+}
+
+func (c *witnessChain) GetHeaderByNumber(number uint64) *types.Header {
+	return c.byNumber[number]
+}
+
+func (c *witnessChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	return c.byHash[hash]
+}
+
+func (c *witnessChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	return nil
+}
+
+func (c *witnessChain) State() (*state.StateDB, error) {
+	return nil, errWitnessNoState
+}
+
+func (c *witnessChain) StateAt(root common.Hash) (*state.StateDB, error) {
+	return nil, errWitnessNoState
+}
@@ -0,0 +1,145 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus/gxhash"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/klaytn/klaytn/storage/statedb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionWitness(t *testing.T) {
+	for _, scheme := range []string{statedb.HashScheme, statedb.PathScheme} {
+		t.Run(scheme, func(t *testing.T) {
+			testExecutionWitness(t, scheme)
+		})
+	}
+}
+
+func testExecutionWitness(t *testing.T, scheme string) {
+	var (
+		key, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address  = crypto.PubkeyToAddress(key.PublicKey)
+		contract = common.HexToAddress("0xc0de")
+		// The contract increments slot 0, clears slot 1 and stores the hash
+		// of the third ancestor of the block in slot 2.
+		code = []byte{
+			byte(vm.PUSH1), 0, byte(vm.SLOAD), byte(vm.PUSH1), 1, byte(vm.ADD), byte(vm.PUSH1), 0, byte(vm.SSTORE),
+			byte(vm.PUSH1), 0, byte(vm.PUSH1), 1, byte(vm.SSTORE),
+			byte(vm.PUSH1), 3, byte(vm.NUMBER), byte(vm.SUB), byte(vm.BLOCKHASH), byte(vm.PUSH1), 2, byte(vm.SSTORE),
+			byte(vm.STOP),
+		}
+		gspec = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc: GenesisAlloc{
+				address: {Balance: big.NewInt(1000000000000000000)},
+				contract: {
+					Balance: big.NewInt(0),
+					Code:    code,
+					Storage: map[common.Hash]common.Hash{
+						common.BigToHash(big.NewInt(1)): common.BigToHash(big.NewInt(1)),
+						common.BigToHash(big.NewInt(3)): common.BigToHash(big.NewInt(3)),
+					},
+				},
+			},
+		}
+		gendb   = database.NewMemoryDBManager()
+		genesis = gspec.MustCommit(gendb)
+		signer  = types.LatestSignerForChainID(gspec.Config.ChainID)
+		engine  = gxhash.NewFaker()
+	)
+	// The blocks are generated one by one on top of a chain to serve BLOCKHASH.
+	genchain, err := NewBlockChain(gendb, nil, gspec.Config, engine, vm.Config{})
+	require.NoError(t, err)
+	defer genchain.Stop()
+
+	var blocks types.Blocks
+	for parent, i := genesis, 0; i < 5; i++ {
+		generated, _ := GenerateChain(gspec.Config, parent, engine, gendb, 1, func(_ int, block *BlockGen) {
+			tx, err := types.SignTx(types.NewTransaction(block.TxNonce(address), common.Address{byte(i + 1)}, big.NewInt(1000), params.TxGas, nil, nil), signer, key)
+			require.NoError(t, err)
+			block.AddTxWithChain(genchain, tx)
+
+			tx, err = types.SignTx(types.NewTransaction(block.TxNonce(address), contract, big.NewInt(0), 100000, nil, nil), signer, key)
+			require.NoError(t, err)
+			block.AddTxWithChain(genchain, tx)
+		})
+		_, err = genchain.InsertChain(generated)
+		require.NoError(t, err)
+		parent = generated[0]
+		blocks = append(blocks, parent)
+	}
+
+	db := database.NewMemoryDBManager()
+	if scheme == statedb.PathScheme {
+		db.WriteStateScheme(statedb.PathScheme)
+	}
+	gspec.MustCommit(db)
+	chain, err := NewBlockChain(db, nil, gspec.Config, engine, vm.Config{})
+	require.NoError(t, err)
+	defer chain.Stop()
+
+	_, err = chain.InsertChain(blocks)
+	require.NoError(t, err)
+
+	_, err = chain.ExecutionWitness(chain.Genesis())
+	assert.ErrorIs(t, err, ErrWitnessGenesis)
+
+	for _, block := range blocks {
+		witness, err := chain.ExecutionWitness(block)
+		require.NoError(t, err, block.NumberU64())
+		assert.Contains(t, witness.Accounts, contract)
+		assert.Len(t, witness.Storage[contract], 3)
+		assert.Equal(t, [][]byte{code}, toBytes(witness.Codes))
+		assert.Equal(t, block.ParentHash(), witness.Headers[0].Hash())
+		if n := block.NumberU64(); n > 3 {
+			assert.Len(t, witness.Headers, 2)
+		}
+		assert.NoError(t, VerifyExecutionWitness(gspec.Config, engine, block, witness), block.NumberU64())
+
+		// The witness is not valid for another block
+		if block.NumberU64() > 1 {
+			assert.ErrorIs(t, VerifyExecutionWitness(gspec.Config, engine, blocks[0], witness), ErrInvalidWitnessHeaders)
+		}
+
+		// The verification fails without any of the trie nodes
+		for i := range witness.State {
+			tampered := *witness
+			tampered.State = append(witness.State[:i:i], witness.State[i+1:]...)
+			assert.Error(t, VerifyExecutionWitness(gspec.Config, engine, block, &tampered), "block %d, node %d", block.NumberU64(), i)
+		}
+	}
+}
+
+func toBytes(values []hexutil.Bytes) [][]byte {
+	result := make([][]byte, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
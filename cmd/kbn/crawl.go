@@ -0,0 +1,447 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/cmd/utils"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul/backend"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/p2p"
+	"github.com/klaytn/klaytn/networks/p2p/discover"
+	"github.com/klaytn/klaytn/node/cn"
+	"github.com/klaytn/klaytn/params"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	crawlClientName   = "kbn-crawler"
+	crawlIdleRounds   = 10 // lookup rounds without new nodes after which the crawl ends
+	crawlLookupBuffer = 64
+)
+
+// crawlNodeTypes are the types of the nodes looked up in a crawl.
+var crawlNodeTypes = []discover.NodeType{discover.NodeTypePN, discover.NodeTypeEN}
+
+var (
+	crawlBootnodesFlag = &cli.StringFlag{
+		Name:  "bootnodes",
+		Usage: "Comma separated kni URLs of the bootnodes the crawl starts from (default: bootnodes of the network)",
+	}
+	crawlTimeoutFlag = &cli.DurationFlag{
+		Name:  "timeout",
+		Usage: "Maximum duration of the crawl",
+		Value: 30 * time.Minute,
+	}
+	crawlProbeTimeoutFlag = &cli.DurationFlag{
+		Name:  "probe-timeout",
+		Usage: "Timeout of the handshakes with a node",
+		Value: 10 * time.Second,
+	}
+	crawlParallelFlag = &cli.IntFlag{
+		Name:  "parallel",
+		Usage: "Number of nodes handshaked with in parallel",
+		Value: 16,
+	}
+	crawlNoHandshakeFlag = &cli.BoolFlag{
+		Name:  "nohandshake",
+		Usage: "Only discover the nodes without handshaking with them",
+	}
+	crawlReportFlag = &cli.StringFlag{
+		Name:  "report",
+		Usage: "Output file of the summary statistics (default: standard output)",
+		Value: "-",
+	}
+	crawlMinVersionFlag = &cli.StringFlag{
+		Name:  "min-version",
+		Usage: "Client version below which the nodes are reported as outdated (e.g. v1.12.0)",
+	}
+)
+
+var crawlCommand = &cli.Command{
+	Action:    crawl,
+	Name:      "crawl",
+	Usage:     "Crawl the network and report the nodes found",
+	ArgsUsage: "<node set file>",
+	Flags: []cli.Flag{
+		crawlBootnodesFlag,
+		crawlTimeoutFlag,
+		crawlProbeTimeoutFlag,
+		crawlParallelFlag,
+		crawlNoHandshakeFlag,
+		crawlReportFlag,
+		crawlMinVersionFlag,
+	},
+	Description: `
+This command looks up the PNs and ENs of the network through the discovery
+protocol, starting from the bootnodes, until no new node is found or the
+timeout expires. Every node found is handshaked with to record its client
+version, node type, protocol versions and head block.
+
+The nodes are merged into the node set file, which is created if it does not
+exist, and summary statistics of the nodes seen in the crawl are reported.`,
+}
+
+// klayStatus is the status message of the klay protocol, the first message a
+// node sends on the protocol whichever consensus engine names it.
+type klayStatus struct {
+	ProtocolVersion uint32
+	NetworkId       uint64
+	TD              *big.Int
+	CurrentBlock    common.Hash
+	GenesisBlock    common.Hash
+	ChainID         *big.Int
+}
+
+func crawl(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("need the node set file as argument")
+	}
+	file := ctx.Args().First()
+	ns := make(nodeSet)
+	if _, err := os.Stat(file); err == nil {
+		if ns, err = loadNodeSet(file); err != nil {
+			return fmt.Errorf("failed to load the node set: %v", err)
+		}
+	}
+	networkID := ctx.Uint64(utils.NetworkIdFlag.Name)
+	bootnodes, err := crawlBootnodes(ctx.String(crawlBootnodesFlag.Name), networkID)
+	if err != nil {
+		return err
+	}
+	if ctx.Int(crawlParallelFlag.Name) < 1 {
+		return errors.New("--" + crawlParallelFlag.Name + " must be positive")
+	}
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return err
+	}
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{})
+	if err != nil {
+		return err
+	}
+	addr := conn.LocalAddr().(*net.UDPAddr)
+	tab, err := discover.ListenUDP(&discover.Config{
+		NetworkID:  networkID,
+		PrivateKey: key,
+		Conn:       conn,
+		Addr:       addr,
+		Id:         discover.PubkeyID(&key.PublicKey),
+		NodeType:   discover.NodeTypeEN,
+		Bootnodes:  bootnodes,
+	})
+	if err != nil {
+		return err
+	}
+	defer tab.Close()
+
+	c := &crawler{
+		tab:      tab,
+		ns:       ns,
+		parallel: ctx.Int(crawlParallelFlag.Name),
+		seen:     make(map[discover.NodeID]bool),
+	}
+	if !ctx.Bool(crawlNoHandshakeFlag.Name) {
+		c.probeCfg = &p2p.ProbeConfig{
+			PrivateKey: key,
+			Name:       common.MakeName(crawlClientName, params.Version),
+			ConnType:   common.ENDPOINTNODE,
+			Caps:       crawlCaps(),
+			Timeout:    ctx.Duration(crawlProbeTimeoutFlag.Name),
+		}
+	}
+
+	start := time.Now()
+	logger.Info("Crawling the network", "networkID", networkID, "bootnodes", len(bootnodes), "timeout", ctx.Duration(crawlTimeoutFlag.Name))
+	c.run(start.Add(ctx.Duration(crawlTimeoutFlag.Name)))
+	logger.Info("Crawl finished", "nodes", len(c.seen), "elapsed", common.PrettyDuration(time.Since(start)))
+
+	if err := writeNodeSet(file, ns); err != nil {
+		return err
+	}
+	report := makeCrawlReport(ns, start, ctx.String(crawlMinVersionFlag.Name))
+	out, closeOut, err := openOutput(ctx.String(crawlReportFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer closeOut()
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// crawlCaps returns the capabilities of the klay protocol, which is named after
+// the consensus engine on the nodes running Istanbul.
+func crawlCaps() []p2p.Cap {
+	var caps []p2p.Cap
+	for _, version := range backend.IstanbulProtocol.Versions {
+		caps = append(caps, p2p.Cap{Name: backend.IstanbulProtocol.Name, Version: version})
+	}
+	for _, version := range cn.ProtocolVersions {
+		caps = append(caps, p2p.Cap{Name: cn.ProtocolName, Version: version})
+	}
+	return caps
+}
+
+// crawlBootnodes parses the bootnode URLs, defaulting to the bootnodes of the
+// known networks.
+func crawlBootnodes(urls string, networkID uint64) ([]*discover.Node, error) {
+	var addrs []string
+	switch {
+	case urls != "":
+		addrs = strings.Split(urls, ",")
+	case networkID == params.CypressNetworkId:
+		addrs = params.MainnetBootnodes[common.ENDPOINTNODE].Addrs
+	case networkID == params.BaobabNetworkId:
+		addrs = params.BaobabBootnodes[common.ENDPOINTNODE].Addrs
+	default:
+		return nil, errors.New("--" + crawlBootnodesFlag.Name + " is required for network " + strconv.FormatUint(networkID, 10))
+	}
+	nodes := make([]*discover.Node, 0, len(addrs))
+	for _, url := range addrs {
+		n, err := discover.ParseNode(strings.TrimSpace(url))
+		if err != nil {
+			return nil, fmt.Errorf("invalid bootnode %q: %v", url, err)
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+// crawler looks up the nodes of the network and handshakes with every new node
+// found.
+type crawler struct {
+	tab      discover.Discovery
+	probeCfg *p2p.ProbeConfig // nil if the nodes are not handshaked with
+	parallel int
+
+	mu   sync.Mutex
+	ns   nodeSet
+	seen map[discover.NodeID]bool // nodes found in this crawl
+}
+
+func (c *crawler) run(deadline time.Time) {
+	var (
+		probes = make(chan *discover.Node)
+		wg     sync.WaitGroup
+		buf    = make([]*discover.Node, crawlLookupBuffer)
+	)
+	for i := 0; i < c.parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range probes {
+				c.probe(n)
+			}
+		}()
+	}
+	for idle := 0; idle < crawlIdleRounds && time.Now().Before(deadline); {
+		var found []*discover.Node
+		for _, nType := range crawlNodeTypes {
+			var target discover.NodeID
+			rand.Read(target[:])
+			found = append(found, c.tab.Lookup(target, nType)...)
+			n := c.tab.ReadRandomNodes(buf, nType)
+			found = append(found, buf[:n]...)
+		}
+		fresh := c.add(found, time.Now())
+		if len(fresh) == 0 {
+			idle++
+			time.Sleep(time.Second)
+			continue
+		}
+		idle = 0
+		logger.Info("Found new nodes", "new", len(fresh), "total", len(c.seen))
+		if c.probeCfg != nil {
+			for _, n := range fresh {
+				probes <- n
+			}
+		}
+	}
+	close(probes)
+	wg.Wait()
+}
+
+// add records the nodes in the node set and returns the nodes which are new in
+// this crawl.
+func (c *crawler) add(nodes []*discover.Node, now time.Time) []*discover.Node {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var fresh []*discover.Node
+	for _, n := range nodes {
+		if !containsNodeType(crawlNodeTypes, n.NType) {
+			continue
+		}
+		entry, ok := c.ns[n.ID]
+		if !ok {
+			entry.FirstSeen = now
+		}
+		entry.Node, entry.LastSeen = n, now
+		c.ns[n.ID] = entry
+		if !c.seen[n.ID] {
+			c.seen[n.ID] = true
+			fresh = append(fresh, n)
+		}
+	}
+	return fresh
+}
+
+func (c *crawler) probe(n *discover.Node) {
+	probe := &nodeProbe{Time: time.Now()}
+	res, err := p2p.ProbeNode(c.probeCfg, n)
+	if err != nil {
+		probe.Error = err.Error()
+	}
+	if res != nil {
+		probe.ClientName = res.Name
+		if res.ConnType != common.ConnTypeUndefined {
+			probe.NodeType = discover.StringNodeType(p2p.ConvertNodeType(res.ConnType))
+		}
+		for _, c := range res.Caps {
+			probe.Caps = append(probe.Caps, c.String())
+		}
+		if res.Msg != nil && res.Msg.Code == cn.StatusMsg {
+			var status klayStatus
+			if err := res.Msg.Decode(&status); err != nil {
+				probe.Error = fmt.Sprintf("invalid status: %v", err)
+			} else {
+				probe.ProtocolVersion, probe.NetworkID, probe.BlockScore = status.ProtocolVersion, status.NetworkId, status.TD
+				probe.Head, probe.Genesis = &status.CurrentBlock, &status.GenesisBlock
+			}
+		}
+	}
+	logger.Debug("Probed node", "id", n.ID, "addr", n.IP, "client", probe.ClientName, "err", probe.Error)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := c.ns[n.ID]
+	entry.Probe = probe
+	c.ns[n.ID] = entry
+}
+
+// crawlReport is the summary statistics of the nodes seen in a crawl.
+type crawlReport struct {
+	Nodes     int                       `json:"nodes"`
+	Reachable int                       `json:"reachable"`
+	NodeTypes map[string]int            `json:"nodeTypes"` // node counts by discovered type
+	Clients   map[string]map[string]int `json:"clients"`   // client version counts by reported node type
+	Protocols map[string]int            `json:"protocols"`
+	Networks  map[string]int            `json:"networks"`
+	Heads     map[string]int            `json:"heads"`
+
+	MinVersion string   `json:"minVersion,omitempty"`
+	Outdated   []string `json:"outdated,omitempty"` // URLs of the nodes running clients older than MinVersion
+}
+
+// makeCrawlReport summarizes the nodes seen since the given time.
+func makeCrawlReport(ns nodeSet, since time.Time, minVersion string) *crawlReport {
+	report := &crawlReport{
+		NodeTypes:  make(map[string]int),
+		Clients:    make(map[string]map[string]int),
+		Protocols:  make(map[string]int),
+		Networks:   make(map[string]int),
+		Heads:      make(map[string]int),
+		MinVersion: minVersion,
+	}
+	for _, n := range ns {
+		if n.LastSeen.Before(since) {
+			continue
+		}
+		report.Nodes++
+		report.NodeTypes[discover.StringNodeType(n.Node.NType)]++
+		if !n.reachable() {
+			continue
+		}
+		report.Reachable++
+
+		client, version := clientVersion(n.Probe.ClientName)
+		nodeType := n.Probe.NodeType
+		if nodeType == "" {
+			nodeType = discover.StringNodeType(discover.NodeTypeUnknown)
+		}
+		if report.Clients[nodeType] == nil {
+			report.Clients[nodeType] = make(map[string]int)
+		}
+		report.Clients[nodeType][client+"/"+version]++
+		for _, c := range n.Probe.Caps {
+			report.Protocols[c]++
+		}
+		if n.Probe.NetworkID != 0 {
+			report.Networks[strconv.FormatUint(n.Probe.NetworkID, 10)]++
+		}
+		if n.Probe.Head != nil {
+			report.Heads[n.Probe.Head.Hex()]++
+		}
+		if minVersion != "" && compareVersions(version, minVersion) < 0 {
+			report.Outdated = append(report.Outdated, n.Node.String())
+		}
+	}
+	sort.Strings(report.Outdated)
+	return report
+}
+
+// clientVersion splits a client name like "Klaytn/v1.12.0/linux-amd64/go1.20"
+// into the client and its version.
+func clientVersion(name string) (string, string) {
+	parts := strings.SplitN(name, "/", 3)
+	if len(parts) < 2 {
+		return name, ""
+	}
+	return parts[0], parts[1]
+}
+
+// compareVersions compares two semantic versions like "v1.12.0", ignoring
+// their pre-release and build suffixes. Missing or invalid numbers are
+// considered zero.
+func compareVersions(a, b string) int {
+	parse := func(v string) [3]int {
+		var nums [3]int
+		v = strings.TrimPrefix(v, "v")
+		if i := strings.IndexAny(v, "-+"); i >= 0 {
+			v = v[:i]
+		}
+		for i, s := range strings.SplitN(v, ".", 3) {
+			nums[i], _ = strconv.Atoi(s)
+		}
+		return nums
+	}
+	va, vb := parse(a), parse(b)
+	for i := range va {
+		switch {
+		case va[i] < vb[i]:
+			return -1
+		case va[i] > vb[i]:
+			return 1
+		}
+	}
+	return 0
+}
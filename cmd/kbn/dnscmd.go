@@ -0,0 +1,224 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/p2p/discover"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	dnsDomainFlag = &cli.StringFlag{
+		Name:  "domain",
+		Usage: "Domain name the tree is published at",
+	}
+	dnsKeyFlag = &cli.StringFlag{
+		Name:  "key",
+		Usage: "File containing the hex encoded private key signing the tree",
+	}
+	dnsSeqFlag = &cli.UintFlag{
+		Name:  "seq",
+		Usage: "Sequence number of the tree (default: current unix time)",
+	}
+	dnsLinkFlag = &cli.StringSliceFlag{
+		Name:  "link",
+		Usage: "URL of another tree linked from the tree (kntree://<key>@<domain>)",
+	}
+	dnsNodeTypeFlag = &cli.StringSliceFlag{
+		Name:  "ntype",
+		Usage: "Types of the nodes included in the tree (cn, pn, en, bn; default: all)",
+	}
+	dnsOutputFlag = &cli.StringFlag{
+		Name:  "output",
+		Usage: "Output file (default: standard output)",
+		Value: "-",
+	}
+	dnsZoneFileFlag = &cli.BoolFlag{
+		Name:  "zonefile",
+		Usage: "Write the TXT records in the zone file format instead of JSON",
+	}
+	dnsTTLFlag = &cli.UintFlag{
+		Name:  "ttl",
+		Usage: "TTL of the records in the zone file",
+		Value: 1800,
+	}
+)
+
+var dnsCommand = &cli.Command{
+	Name:  "dns",
+	Usage: "DNS node list management",
+	Subcommands: []*cli.Command{
+		{
+			Action:    dnsSign,
+			Name:      "sign",
+			Usage:     "Create and sign a DNS node tree from a crawled node set",
+			ArgsUsage: "<node set file>",
+			Flags: []cli.Flag{
+				dnsDomainFlag,
+				dnsKeyFlag,
+				dnsSeqFlag,
+				dnsLinkFlag,
+				dnsNodeTypeFlag,
+				dnsOutputFlag,
+				dnsZoneFileFlag,
+				dnsTTLFlag,
+			},
+			Description: `
+This command creates a tree of the nodes in the node set, signs it and writes
+the TXT records to be published at the domain. The URL of the tree is printed,
+which is given to the nodes with --discovery.dns.`,
+		},
+		{
+			Action:    dnsResolve,
+			Name:      "resolve",
+			Usage:     "Resolve a published DNS node tree",
+			ArgsUsage: "<tree URL>",
+			Flags: []cli.Flag{
+				dnsOutputFlag,
+			},
+			Description: `
+This command downloads and verifies the tree at the URL, and writes its nodes
+as a node set.`,
+		},
+	},
+}
+
+// dnsRecords is the JSON output of the signed tree.
+type dnsRecords struct {
+	URL     string            `json:"url"`
+	Seq     uint              `json:"seq"`
+	Records map[string]string `json:"records"`
+}
+
+func dnsSign(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("need the node set file as argument")
+	}
+	domain := ctx.String(dnsDomainFlag.Name)
+	if domain == "" {
+		return errors.New("--" + dnsDomainFlag.Name + " is required")
+	}
+	if !ctx.IsSet(dnsKeyFlag.Name) {
+		return errors.New("--" + dnsKeyFlag.Name + " is required")
+	}
+	key, err := crypto.LoadECDSA(ctx.String(dnsKeyFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to load the key: %v", err)
+	}
+	ns, err := loadNodeSet(ctx.Args().First())
+	if err != nil {
+		return fmt.Errorf("failed to load the node set: %v", err)
+	}
+	types, err := parseNodeTypes(ctx.StringSlice(dnsNodeTypeFlag.Name))
+	if err != nil {
+		return err
+	}
+	seq := uint(time.Now().Unix())
+	if ctx.IsSet(dnsSeqFlag.Name) {
+		seq = ctx.Uint(dnsSeqFlag.Name)
+	}
+
+	nodes := ns.nodes(types...)
+	tree, err := discover.MakeDNSTree(seq, nodes, ctx.StringSlice(dnsLinkFlag.Name))
+	if err != nil {
+		return err
+	}
+	url, err := tree.Sign(key, domain)
+	if err != nil {
+		return err
+	}
+	logger.Info("Signed DNS node tree", "url", url, "seq", seq, "nodes", len(nodes))
+
+	out, closeOut, err := openOutput(ctx.String(dnsOutputFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer closeOut()
+	records := tree.ToTXT(domain)
+	if ctx.Bool(dnsZoneFileFlag.Name) {
+		return writeZoneFile(out, records, ctx.Uint(dnsTTLFlag.Name))
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(dnsRecords{URL: url, Seq: seq, Records: records})
+}
+
+func dnsResolve(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("need the tree URL as argument")
+	}
+	tree, err := discover.NewDNSClient(nil).SyncTree(context.Background(), ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	for _, link := range tree.Links() {
+		logger.Info("Linked DNS node tree", "url", link)
+	}
+	ns := make(nodeSet)
+	for _, n := range tree.Nodes() {
+		ns[n.ID] = nodeJSON{Node: n}
+	}
+	logger.Info("Resolved DNS node tree", "seq", tree.Seq(), "nodes", len(ns))
+	return writeNodeSet(ctx.String(dnsOutputFlag.Name), ns)
+}
+
+// writeZoneFile writes the records in the zone file format. Texts longer than
+// the limit of a character string are split into several strings.
+func writeZoneFile(w io.Writer, records map[string]string, ttl uint) error {
+	names := make([]string, 0, len(records))
+	for name := range records {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var (
+			txt    = records[name]
+			chunks []string
+		)
+		for len(txt) > 255 {
+			chunks = append(chunks, fmt.Sprintf("%q", txt[:255]))
+			txt = txt[255:]
+		}
+		chunks = append(chunks, fmt.Sprintf("%q", txt))
+		if _, err := fmt.Fprintf(w, "%s. %d IN TXT %s\n", name, ttl, strings.Join(chunks, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func openOutput(file string) (io.Writer, func(), error) {
+	if file == "-" || file == "" {
+		return os.Stdout, func() {}, nil
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, func() { f.Close() }, nil
+}
@@ -29,8 +29,11 @@ Each file contains the following contents
  - api.go	: Provides various APIs to use bootnode services
  - backend.go	: Provides supporting functions for APIs
  - config.go	: Provides `bootnodeConfig` which contains a configuration and accompanying setter and parser functions
+ - crawl.go	: Provides the `crawl` command which crawls the network and reports its nodes
+ - dnscmd.go	: Provides the `dns` command which signs and resolves DNS node lists
  - main.go	: Main entry point of the application
  - node.go	: Provides `Node` struct which defines what kind of APIs can be provided through which port and protocols
+ - nodeset.go	: Provides the JSON file format of node sets
 
 */
 package main
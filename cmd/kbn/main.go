@@ -196,6 +196,8 @@ func main() {
 	app.Commands = []*cli.Command{
 		nodecmd.VersionCommand,
 		nodecmd.AttachCommand,
+		dnsCommand,
+		crawlCommand,
 	}
 
 	app.Action = bootnode
@@ -0,0 +1,126 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/p2p/discover"
+)
+
+// nodeSet is the JSON file format of a set of nodes found by a crawl, keyed by
+// the node ID.
+type nodeSet map[discover.NodeID]nodeJSON
+
+type nodeJSON struct {
+	Node      *discover.Node `json:"node"`
+	FirstSeen time.Time      `json:"firstSeen"`
+	LastSeen  time.Time      `json:"lastSeen"`
+
+	// Probe is the result of the last handshake with the node, if any.
+	Probe *nodeProbe `json:"probe,omitempty"`
+}
+
+// nodeProbe is what a node revealed in the handshakes of a crawl.
+type nodeProbe struct {
+	Time  time.Time `json:"time"`
+	Error string    `json:"error,omitempty"`
+
+	ClientName string   `json:"clientName,omitempty"`
+	NodeType   string   `json:"nodeType,omitempty"` // type reported in the handshake
+	Caps       []string `json:"caps,omitempty"`
+
+	// Status of the klay protocol, if the node runs it.
+	ProtocolVersion uint32       `json:"protocolVersion,omitempty"`
+	NetworkID       uint64       `json:"networkId,omitempty"`
+	Head            *common.Hash `json:"head,omitempty"`
+	BlockScore      *big.Int     `json:"blockScore,omitempty"`
+	Genesis         *common.Hash `json:"genesis,omitempty"`
+}
+
+// reachable reports whether the node completed the handshakes of its last probe.
+func (n nodeJSON) reachable() bool {
+	return n.Probe != nil && n.Probe.ClientName != ""
+}
+
+func loadNodeSet(file string) (nodeSet, error) {
+	var ns nodeSet
+	if err := common.LoadJSON(file, &ns); err != nil {
+		return nil, err
+	}
+	for id, n := range ns {
+		if n.Node == nil || n.Node.ID != id {
+			return nil, fmt.Errorf("invalid node set entry %v", id)
+		}
+	}
+	return ns, nil
+}
+
+func writeNodeSet(file string, ns nodeSet) error {
+	data, err := json.MarshalIndent(ns, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if file == "-" || file == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(file, data, 0o644)
+}
+
+// nodes returns the nodes of the given types sorted by ID. All nodes are
+// returned if no type is given.
+func (ns nodeSet) nodes(types ...discover.NodeType) []*discover.Node {
+	var nodes []*discover.Node
+	for _, n := range ns {
+		if len(types) == 0 || containsNodeType(types, n.Node.NType) {
+			nodes = append(nodes, n.Node)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool { return bytes.Compare(nodes[i].ID[:], nodes[j].ID[:]) < 0 })
+	return nodes
+}
+
+func containsNodeType(types []discover.NodeType, nType discover.NodeType) bool {
+	for _, t := range types {
+		if t == nType {
+			return true
+		}
+	}
+	return false
+}
+
+// parseNodeTypes parses node type names such as "bn" or "en".
+func parseNodeTypes(names []string) ([]discover.NodeType, error) {
+	types := make([]discover.NodeType, 0, len(names))
+	for _, name := range names {
+		nType := discover.ParseNodeType(name)
+		if nType == discover.NodeTypeUnknown {
+			return nil, fmt.Errorf("invalid node type %q", name)
+		}
+		types = append(types, nType)
+	}
+	return types, nil
+}
@@ -68,6 +68,9 @@ func init() {
 
 		// See utils/nodecmd/snapshot.go:
 		nodecmd.SnapshotCommand,
+
+		// See utils/nodecmd/bridgecmd.go:
+		nodecmd.BridgeCommand,
 	}
 	sort.Sort(cli.CommandsByName(app.Commands))
 
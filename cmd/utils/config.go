@@ -44,6 +44,7 @@ import (
 	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/crypto/bls"
 	"github.com/klaytn/klaytn/datasync/chaindatafetcher"
+	"github.com/klaytn/klaytn/datasync/chaindatafetcher/file"
 	"github.com/klaytn/klaytn/datasync/chaindatafetcher/kafka"
 	"github.com/klaytn/klaytn/datasync/chaindatafetcher/kas"
 	"github.com/klaytn/klaytn/datasync/dbsyncer"
@@ -179,6 +180,7 @@ func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config) {
 
 	// set bootnodes via this function by check specified parameters
 	setBootstrapNodes(ctx, cfg)
+	setDNSDiscovery(ctx, cfg)
 
 	if ctx.IsSet(MaxConnectionsFlag.Name) {
 		cfg.MaxPhysicalConnections = ctx.Int(MaxConnectionsFlag.Name)
@@ -203,6 +205,44 @@ func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config) {
 		cfg.NetRestrict = list
 	}
 
+	cfg.PeerScoring = p2p.ScoringConfig{
+		Disable:       ctx.Bool(PeerScoreDisableFlag.Name),
+		BanThreshold:  ctx.Float64(PeerScoreBanThresholdFlag.Name),
+		BanDuration:   ctx.Duration(PeerScoreBanDurationFlag.Name),
+		HalfLife:      ctx.Duration(PeerScoreHalfLifeFlag.Name),
+		ExemptTrusted: ctx.Bool(PeerScoreExemptTrustedFlag.Name),
+	}
+	cfg.Bandwidth = p2p.BandwidthConfig{
+		EgressCN: ctx.Uint64(BandwidthEgressCNFlag.Name),
+		EgressPN: ctx.Uint64(BandwidthEgressPNFlag.Name),
+		EgressEN: ctx.Uint64(BandwidthEgressENFlag.Name),
+	}
+	if ctx.IsSet(CaptureFileFlag.Name) {
+		cfg.Capture = p2p.CaptureConfig{
+			File:       ctx.String(CaptureFileFlag.Name),
+			MaxSize:    ctx.Int(CaptureMaxSizeFlag.Name),
+			MaxBackups: ctx.Int(CaptureMaxBackupsFlag.Name),
+		}
+		for _, peer := range ctx.StringSlice(CapturePeersFlag.Name) {
+			id, err := discover.HexID(peer)
+			if err != nil {
+				node, nodeErr := discover.ParseNode(peer)
+				if nodeErr != nil {
+					log.Fatalf("Option %q: invalid peer %q: %v", CapturePeersFlag.Name, peer, err)
+				}
+				id = node.ID
+			}
+			cfg.Capture.Peers = append(cfg.Capture.Peers, id)
+		}
+		for _, code := range ctx.StringSlice(CaptureCodesFlag.Name) {
+			c, err := strconv.ParseUint(code, 0, 64)
+			if err != nil {
+				log.Fatalf("Option %q: invalid message code %q: %v", CaptureCodesFlag.Name, code, err)
+			}
+			cfg.Capture.Codes = append(cfg.Capture.Codes, c)
+		}
+	}
+
 	common.MaxRequestContentLength = ctx.Int(MaxRequestContentLengthFlag.Name)
 
 	cfg.NetworkID, _ = getNetworkId(ctx)
@@ -353,6 +393,19 @@ func setBootstrapNodes(ctx *cli.Context, cfg *p2p.Config) {
 	}
 }
 
+// setDNSDiscovery applies the DNS node list URLs to the p2p config.
+func setDNSDiscovery(ctx *cli.Context, cfg *p2p.Config) {
+	if !ctx.IsSet(DNSDiscoveryFlag.Name) {
+		return
+	}
+	cfg.DNSDiscoveryURLs = nil
+	for _, url := range strings.Split(ctx.String(DNSDiscoveryFlag.Name), ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			cfg.DNSDiscoveryURLs = append(cfg.DNSDiscoveryURLs, url)
+		}
+	}
+}
+
 // setNodeConfig applies node-related command line flags to the config.
 func (kCfg *KlayConfig) SetNodeConfig(ctx *cli.Context) {
 	cfg := &kCfg.Node
@@ -397,6 +450,10 @@ func (kCfg *KlayConfig) SetNodeConfig(ctx *cli.Context) {
 	if ctx.IsSet(KeyStoreDirFlag.Name) {
 		cfg.KeyStoreDir = ctx.String(KeyStoreDirFlag.Name)
 	}
+	if ctx.IsSet(ExternalSignerFlag.Name) {
+		cfg.ExternalSigner = ctx.String(ExternalSignerFlag.Name)
+		cfg.ExternalSignerToken = ctx.String(ExternalSignerTokenFlag.Name)
+	}
 	if ctx.IsSet(LightKDFFlag.Name) {
 		cfg.UseLightweightKDF = ctx.Bool(LightKDFFlag.Name)
 	}
@@ -528,8 +585,8 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 
 	if ctx.IsSet(SyncModeFlag.Name) {
 		cfg.SyncMode = *GlobalTextMarshaler(ctx, SyncModeFlag.Name).(*downloader.SyncMode)
-		if cfg.SyncMode != downloader.FullSync && cfg.SyncMode != downloader.SnapSync {
-			log.Fatalf("Full Sync or Snap Sync (prototype) is supported only!")
+		if cfg.SyncMode != downloader.FullSync && cfg.SyncMode != downloader.SnapSync && cfg.SyncMode != downloader.LightSync {
+			log.Fatalf("Full Sync, Snap Sync (prototype) or Light Sync is supported only!")
 		}
 		if cfg.SyncMode == downloader.SnapSync {
 			logger.Info("Snap sync requested, enabling --snapshot")
@@ -539,6 +596,23 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 		}
 	}
 
+	if ctx.IsSet(CheckpointFileFlag.Name) {
+		cfg.TrustedCheckpoint = ctx.String(CheckpointFileFlag.Name)
+		for _, signer := range ctx.StringSlice(CheckpointSignersFlag.Name) {
+			if !common.IsHexAddress(signer) {
+				log.Fatalf("Invalid trusted checkpoint signer: %v", signer)
+			}
+			cfg.TrustedCheckpointSigners = append(cfg.TrustedCheckpointSigners, common.HexToAddress(signer))
+		}
+		cfg.TrustedCheckpointThreshold = ctx.Int(CheckpointThresholdFlag.Name)
+		cfg.HistoryBackfill = ctx.Bool(CheckpointBackfillFlag.Name)
+	}
+
+	cfg.LightServ = ctx.Bool(LightServFlag.Name)
+	if ctx.IsSet(LightPeersFlag.Name) {
+		cfg.LightPeers = ctx.Int(LightPeersFlag.Name)
+	}
+
 	if ctx.Bool(KESNodeTypeServiceFlag.Name) {
 		cfg.FetcherDisable = true
 		cfg.DownloaderDisable = true
@@ -609,6 +683,7 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 	cfg.TriesInMemory = ctx.Uint64(TriesInMemoryFlag.Name)
 	cfg.LivePruning = ctx.Bool(LivePruningFlag.Name)
 	cfg.LivePruningRetention = ctx.Uint64(LivePruningRetentionFlag.Name)
+	cfg.StateScheme = ctx.String(StateSchemeFlag.Name)
 
 	if ctx.IsSet(CacheScaleFlag.Name) {
 		common.CacheScale = ctx.Int(CacheScaleFlag.Name)
@@ -663,6 +738,13 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 	}
 	cfg.EnableInternalTxTracing = ctx.Bool(VMTraceInternalTxFlag.Name)
 	cfg.EnableOpDebug = ctx.Bool(VMOpDebugFlag.Name)
+	cfg.ExternalConsensusSigner = ctx.Bool(ExternalConsensusSignerFlag.Name)
+	if addr := ctx.String(ExternalConsensusAddressFlag.Name); addr != "" {
+		if !common.IsHexAddress(addr) {
+			log.Fatalf("Option %q: invalid address %q", ExternalConsensusAddressFlag.Name, addr)
+		}
+		cfg.ExternalConsensusAddress = common.HexToAddress(addr)
+	}
 
 	cfg.AutoRestartFlag = ctx.Bool(AutoRestartFlag.Name)
 	cfg.RestartTimeOutFlag = ctx.Duration(RestartTimeOutFlag.Name)
@@ -701,6 +783,9 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 	} else {
 		cfg.SnapshotCacheSize = 0 // snapshot disabled
 	}
+	cfg.SnapServeResponseSize = ctx.Uint64(SnapServeResponseSizeFlag.Name)
+	cfg.SnapServeResponseTime = ctx.Duration(SnapServeResponseTimeFlag.Name)
+	cfg.SnapServeCPUShare = ctx.Float64(SnapServeCPUShareFlag.Name)
 
 	// disable unsafe debug APIs
 	cfg.DisableUnsafeDebug = ctx.Bool(UnsafeDebugDisableFlag.Name)
@@ -719,6 +804,7 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 	*/
 	// Set the Tx resending related configuration variables
 	setTxResendConfig(ctx, cfg)
+	setTxAnnounceConfig(ctx, cfg)
 }
 
 // raiseFDLimit increases the file descriptor limit to process's maximum value
@@ -876,6 +962,14 @@ func setTxResendConfig(ctx *cli.Context, cfg *cn.Config) {
 	logger.Debug("TxResend config", "Interval", cfg.TxResendInterval, "TxResendCount", cfg.TxResendCount, "UseLegacy", cfg.TxResendUseLegacy)
 }
 
+func setTxAnnounceConfig(ctx *cli.Context, cfg *cn.Config) {
+	// Set the Tx announcement related configuration variables
+	cfg.TxAnnounceSizeCN = ctx.Uint64(TxAnnounceSizeCNFlag.Name)
+	cfg.TxAnnounceSizePN = ctx.Uint64(TxAnnounceSizePNFlag.Name)
+	cfg.TxAnnounceSizeEN = ctx.Uint64(TxAnnounceSizeENFlag.Name)
+	logger.Debug("TxAnnounce config", "CN", cfg.TxAnnounceSizeCN, "PN", cfg.TxAnnounceSizePN, "EN", cfg.TxAnnounceSizeEN)
+}
+
 func (kCfg *KlayConfig) SetChainDataFetcherConfig(ctx *cli.Context) {
 	cfg := &kCfg.ChainDataFetcher
 	if ctx.Bool(EnableChainDataFetcherFlag.Name) {
@@ -906,8 +1000,11 @@ func (kCfg *KlayConfig) SetChainDataFetcherConfig(ctx *cli.Context) {
 		case "kafka":
 			cfg.Mode = chaindatafetcher.ModeKafka
 			cfg.KafkaConfig = makeKafkaConfig(ctx)
+		case "file":
+			cfg.Mode = chaindatafetcher.ModeFile
+			cfg.FileConfig = makeFileConfig(ctx)
 		default:
-			logger.Crit("unsupported chaindatafetcher mode (\"kas\", \"kafka\")", "mode", cfg.Mode)
+			logger.Crit("unsupported chaindatafetcher mode (\"kas\", \"kafka\", \"file\")", "mode", cfg.Mode)
 		}
 	}
 }
@@ -980,6 +1077,7 @@ func makeKafkaConfig(ctx *cli.Context) *kafka.KafkaConfig {
 	kafkaConfig.SegmentSizeBytes = ctx.Int(ChainDataFetcherKafkaSegmentSizeBytesFlag.Name)
 	kafkaConfig.MsgVersion = ctx.String(ChainDataFetcherKafkaMessageVersionFlag.Name)
 	kafkaConfig.ProducerId = ctx.String(ChainDataFetcherKafkaProducerIdFlag.Name)
+	kafkaConfig.PublishDecoded = ctx.Bool(ChainDataFetcherKafkaPublishDecodedFlag.Name)
 	requiredAcks := sarama.RequiredAcks(ctx.Int(ChainDataFetcherKafkaRequiredAcksFlag.Name))
 	if requiredAcks != sarama.NoResponse && requiredAcks != sarama.WaitForLocal && requiredAcks != sarama.WaitForAll {
 		logger.Crit("not supported requiredAcks. it must be NoResponse(0), WaitForLocal(1), or WaitForAll(-1)", "given", requiredAcks)
@@ -988,11 +1086,33 @@ func makeKafkaConfig(ctx *cli.Context) *kafka.KafkaConfig {
 	return kafkaConfig
 }
 
+func makeFileConfig(ctx *cli.Context) *file.FileConfig {
+	fileConfig := file.GetDefaultFileConfig()
+	if ctx.IsSet(ChainDataFetcherFileDirFlag.Name) {
+		fileConfig.Dir = ctx.String(ChainDataFetcherFileDirFlag.Name)
+	} else {
+		logger.Crit("The chaindata file directory must be set")
+	}
+	fileConfig.MaxFileSize = ctx.Int(ChainDataFetcherFileMaxSizeFlag.Name)
+	return fileConfig
+}
+
 func (kCfg *KlayConfig) SetDBSyncerConfig(ctx *cli.Context) {
 	cfg := &kCfg.DB
 	if ctx.Bool(EnableDBSyncerFlag.Name) {
 		cfg.EnabledDBSyncer = true
 
+		if ctx.IsSet(DBTypeFlag.Name) {
+			switch dbtype := strings.ToLower(ctx.String(DBTypeFlag.Name)); dbtype {
+			case dbsyncer.MYSQL:
+				cfg.DBType = dbsyncer.MYSQL
+			case dbsyncer.POSTGRES:
+				cfg.DBType = dbsyncer.POSTGRES
+				cfg.DBPort = "5432"
+			default:
+				logger.Crit("unsupported dbsyncer db type (\"mysql\", \"postgres\")", "type", dbtype)
+			}
+		}
 		if ctx.IsSet(DBHostFlag.Name) {
 			dbhost := ctx.String(DBHostFlag.Name)
 			cfg.DBHost = dbhost
@@ -1021,6 +1141,9 @@ func (kCfg *KlayConfig) SetDBSyncerConfig(ctx *cli.Context) {
 		} else {
 			logger.Crit("DBName must be set !", "key", DBNameFlag.Name)
 		}
+		if ctx.IsSet(DBSSLModeFlag.Name) {
+			cfg.DBSSLMode = ctx.String(DBSSLModeFlag.Name)
+		}
 		if ctx.Bool(EnabledLogModeFlag.Name) {
 			cfg.EnabledLogMode = true
 		}
@@ -1075,12 +1198,45 @@ func (kCfg *KlayConfig) SetServiceChainConfig(ctx *cli.Context) {
 	cfg.ChildChainIndexing = ctx.Bool(ChildChainIndexingFlag.Name)
 	cfg.AnchoringPeriod = ctx.Uint64(AnchoringPeriodFlag.Name)
 	cfg.SentChainTxsLimit = ctx.Uint64(SentChainTxsLimit.Name)
+	cfg.MerkleAnchoring = ctx.Bool(MerkleAnchoringFlag.Name)
 	cfg.ParentChainID = ctx.Uint64(ParentChainIDFlag.Name)
+	if ctx.IsSet(ParentChainsFlag.Name) {
+		parents, err := sc.ParseParentChains(ctx.String(ParentChainsFlag.Name))
+		if err != nil {
+			log.Fatalf("Option %q: %v", ParentChainsFlag.Name, err)
+		}
+		for _, parent := range parents {
+			if parent.ChainID == cfg.ParentChainID {
+				log.Fatalf("Option %q: parent chain %d is already set by %q", ParentChainsFlag.Name, parent.ChainID, ParentChainIDFlag.Name)
+			}
+		}
+		cfg.ParentChains = parents
+	}
 	cfg.VTRecovery = ctx.Bool(VTRecoveryFlag.Name)
 	cfg.VTRecoveryInterval = ctx.Uint64(VTRecoveryIntervalFlag.Name)
+	if ctx.IsSet(TransferLimitsFlag.Name) {
+		limits, err := sc.ParseTransferLimits(ctx.String(TransferLimitsFlag.Name))
+		if err != nil {
+			log.Fatalf("Option %q: %v", TransferLimitsFlag.Name, err)
+		}
+		cfg.TransferLimits = limits
+	}
+	cfg.TransferLimitPeriod = ctx.Uint64(TransferLimitPeriodFlag.Name)
 	cfg.ServiceChainConsensus = ServiceChainConsensusFlag.Value
 	cfg.ServiceChainParentOperatorGasLimit = ctx.Uint64(ServiceChainParentOperatorTxGasLimitFlag.Name)
 	cfg.ServiceChainChildOperatorGasLimit = ctx.Uint64(ServiceChainChildOperatorTxGasLimitFlag.Name)
+	if signer := ctx.String(ServiceChainParentOperatorSignerFlag.Name); signer != "" {
+		if !common.IsHexAddress(signer) {
+			log.Fatalf("Option %q: invalid address %q", ServiceChainParentOperatorSignerFlag.Name, signer)
+		}
+		cfg.ServiceChainParentOperatorSigner = common.HexToAddress(signer)
+	}
+	if signer := ctx.String(ServiceChainChildOperatorSignerFlag.Name); signer != "" {
+		if !common.IsHexAddress(signer) {
+			log.Fatalf("Option %q: invalid address %q", ServiceChainChildOperatorSignerFlag.Name, signer)
+		}
+		cfg.ServiceChainChildOperatorSigner = common.HexToAddress(signer)
+	}
 
 	cfg.KASAnchor = ctx.Bool(KASServiceChainAnchorFlag.Name)
 	if cfg.KASAnchor {
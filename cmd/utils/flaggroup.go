@@ -44,7 +44,13 @@ var FlagGroups = []FlagGroup{
 			ChainDataDirFlag,
 			IdentityFlag,
 			SyncModeFlag,
+			CheckpointFileFlag,
+			CheckpointSignersFlag,
+			CheckpointThresholdFlag,
+			CheckpointBackfillFlag,
 			GCModeFlag,
+			LightServFlag,
+			LightPeersFlag,
 			SrvTypeFlag,
 			ExtraDataFlag,
 			ConfigFileFlag,
@@ -62,6 +68,10 @@ var FlagGroups = []FlagGroup{
 			PasswordFileFlag,
 			LightKDFFlag,
 			KeyStoreDirFlag,
+			ExternalSignerFlag,
+			ExternalSignerTokenFlag,
+			ExternalConsensusSignerFlag,
+			ExternalConsensusAddressFlag,
 		},
 	},
 	{
@@ -83,6 +93,9 @@ var FlagGroups = []FlagGroup{
 			TxResendIntervalFlag,
 			TxResendCountFlag,
 			TxResendUseLegacyFlag,
+			TxAnnounceSizeCNFlag,
+			TxAnnounceSizePNFlag,
+			TxAnnounceSizeENFlag,
 		},
 	},
 	{
@@ -117,11 +130,13 @@ var FlagGroups = []FlagGroup{
 		Name: "DATABASE SYNCER",
 		Flags: []cli.Flag{
 			EnableDBSyncerFlag,
+			DBTypeFlag,
 			DBHostFlag,
 			DBPortFlag,
 			DBNameFlag,
 			DBUserFlag,
 			DBPasswordFlag,
+			DBSSLModeFlag,
 			EnabledLogModeFlag,
 			MaxIdleConnsFlag,
 			MaxOpenConnsFlag,
@@ -164,6 +179,9 @@ var FlagGroups = []FlagGroup{
 			ChainDataFetcherKafkaRequiredAcksFlag,
 			ChainDataFetcherKafkaMessageVersionFlag,
 			ChainDataFetcherKafkaProducerIdFlag,
+			ChainDataFetcherKafkaPublishDecodedFlag,
+			ChainDataFetcherFileDirFlag,
+			ChainDataFetcherFileMaxSizeFlag,
 		},
 	},
 	{
@@ -199,6 +217,7 @@ var FlagGroups = []FlagGroup{
 			TriesInMemoryFlag,
 			LivePruningFlag,
 			LivePruningRetentionFlag,
+			StateSchemeFlag,
 		},
 	},
 	{
@@ -230,6 +249,7 @@ var FlagGroups = []FlagGroup{
 		Name: "NETWORKING",
 		Flags: []cli.Flag{
 			BootnodesFlag,
+			DNSDiscoveryFlag,
 			ListenPortFlag,
 			SubListenPortFlag,
 			MultiChannelUseFlag,
@@ -241,6 +261,19 @@ var FlagGroups = []FlagGroup{
 			RWTimerWaitTimeFlag,
 			RWTimerIntervalFlag,
 			NetrestrictFlag,
+			PeerScoreDisableFlag,
+			PeerScoreBanThresholdFlag,
+			PeerScoreBanDurationFlag,
+			PeerScoreHalfLifeFlag,
+			PeerScoreExemptTrustedFlag,
+			BandwidthEgressCNFlag,
+			BandwidthEgressPNFlag,
+			BandwidthEgressENFlag,
+			CaptureFileFlag,
+			CapturePeersFlag,
+			CaptureCodesFlag,
+			CaptureMaxSizeFlag,
+			CaptureMaxBackupsFlag,
 			NodeKeyFileFlag,
 			NodeKeyHexFlag,
 			NetworkIdFlag,
@@ -323,13 +356,19 @@ var FlagGroups = []FlagGroup{
 			SubBridgeListenPortFlag,
 			AnchoringPeriodFlag,
 			SentChainTxsLimit,
+			MerkleAnchoringFlag,
 			ParentChainIDFlag,
+			ParentChainsFlag,
 			VTRecoveryFlag,
 			VTRecoveryIntervalFlag,
+			TransferLimitsFlag,
+			TransferLimitPeriodFlag,
 			ServiceChainAnchoringFlag,
 			ServiceChainNewAccountFlag,
 			ServiceChainParentOperatorTxGasLimitFlag,
 			ServiceChainChildOperatorTxGasLimitFlag,
+			ServiceChainParentOperatorSignerFlag,
+			ServiceChainChildOperatorSignerFlag,
 			KASServiceChainAnchorFlag,
 			KASServiceChainAnchorPeriodFlag,
 			KASServiceChainAnchorUrlFlag,
@@ -352,6 +391,9 @@ var FlagGroups = []FlagGroup{
 			SnapshotFlag,
 			SnapshotCacheSizeFlag,
 			SnapshotAsyncGen,
+			SnapServeResponseSizeFlag,
+			SnapServeResponseTimeFlag,
+			SnapServeCPUShareFlag,
 			DocRootFlag,
 		},
 	},
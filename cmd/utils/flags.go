@@ -30,14 +30,18 @@ import (
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/datasync/chaindatafetcher"
+	"github.com/klaytn/klaytn/datasync/chaindatafetcher/file"
 	"github.com/klaytn/klaytn/datasync/chaindatafetcher/kafka"
 	"github.com/klaytn/klaytn/datasync/dbsyncer"
+	"github.com/klaytn/klaytn/datasync/downloader"
 	"github.com/klaytn/klaytn/log"
 	metricutils "github.com/klaytn/klaytn/metrics/utils"
+	"github.com/klaytn/klaytn/networks/p2p"
 	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/node"
 	"github.com/klaytn/klaytn/node/cn"
 	"github.com/klaytn/klaytn/node/cn/filters"
+	"github.com/klaytn/klaytn/node/cn/light"
 	"github.com/klaytn/klaytn/node/sc"
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/storage/database"
@@ -131,6 +135,34 @@ var (
 		EnvVars:  []string{"KLAYTN_KEYSTORE"},
 		Category: "ACCOUNT",
 	}
+	ExternalSignerFlag = &cli.StringFlag{
+		Name:     "signer",
+		Usage:    "External signer endpoint, an IPC socket path or an http(s) URL, whose accounts can sign like the keystore ones",
+		Aliases:  []string{"common.signer"},
+		EnvVars:  []string{"KLAYTN_SIGNER"},
+		Category: "ACCOUNT",
+	}
+	ExternalSignerTokenFlag = &cli.StringFlag{
+		Name:     "signer.token",
+		Usage:    "Token authenticating the node to the external signer",
+		Aliases:  []string{"common.signer-token"},
+		EnvVars:  []string{"KLAYTN_SIGNER_TOKEN"},
+		Category: "ACCOUNT",
+	}
+	ExternalConsensusSignerFlag = &cli.BoolFlag{
+		Name:     "signer.consensus",
+		Usage:    "Sign the consensus messages with an account of the external signer (the node key file is still used for p2p and must have the same address)",
+		Aliases:  []string{"common.signer-consensus"},
+		EnvVars:  []string{"KLAYTN_SIGNER_CONSENSUS"},
+		Category: "ACCOUNT",
+	}
+	ExternalConsensusAddressFlag = &cli.StringFlag{
+		Name:     "signer.consensus.address",
+		Usage:    "Account of the external signer signing the consensus messages (default: the only account of the signer)",
+		Aliases:  []string{"common.signer-consensus-address"},
+		EnvVars:  []string{"KLAYTN_SIGNER_CONSENSUS_ADDRESS"},
+		Category: "ACCOUNT",
+	}
 	// TODO-Klaytn-Bootnode: redefine networkid
 	NetworkIdFlag = &cli.Uint64Flag{
 		Name:     "networkid",
@@ -159,12 +191,40 @@ var (
 	defaultSyncMode = cn.GetDefaultConfig().SyncMode
 	SyncModeFlag    = &TextMarshalerFlag{
 		Name:     "syncmode",
-		Usage:    `Blockchain sync mode ("full" or "snap")`,
+		Usage:    `Blockchain sync mode ("full", "snap" or "light")`,
 		Value:    &defaultSyncMode,
 		Aliases:  []string{"common.syncmode"},
 		EnvVars:  []string{"KLAYTN_SYNCMODE"},
 		Category: "KLAY",
 	}
+	CheckpointFileFlag = &cli.StringFlag{
+		Name:     "checkpoint.file",
+		Usage:    "Signed trusted checkpoint file to start an empty chain from with snap sync, instead of from the genesis",
+		Aliases:  []string{"common.checkpoint.file"},
+		EnvVars:  []string{"KLAYTN_CHECKPOINT_FILE"},
+		Category: "KLAY",
+	}
+	CheckpointSignersFlag = &cli.StringSliceFlag{
+		Name:     "checkpoint.signers",
+		Usage:    "Addresses trusted to sign the checkpoint (comma separated)",
+		Aliases:  []string{"common.checkpoint.signers"},
+		EnvVars:  []string{"KLAYTN_CHECKPOINT_SIGNERS"},
+		Category: "KLAY",
+	}
+	CheckpointThresholdFlag = &cli.IntFlag{
+		Name:     "checkpoint.threshold",
+		Usage:    "Number of the trusted signers required to sign the checkpoint (0 = all)",
+		Aliases:  []string{"common.checkpoint.threshold"},
+		EnvVars:  []string{"KLAYTN_CHECKPOINT_THRESHOLD"},
+		Category: "KLAY",
+	}
+	CheckpointBackfillFlag = &cli.BoolFlag{
+		Name:     "checkpoint.backfill",
+		Usage:    "Backfill the headers and bodies of the blocks below the trusted checkpoint in the background",
+		Aliases:  []string{"common.checkpoint.backfill"},
+		EnvVars:  []string{"KLAYTN_CHECKPOINT_BACKFILL"},
+		Category: "KLAY",
+	}
 	GCModeFlag = &cli.StringFlag{
 		Name:     "gcmode",
 		Usage:    `Blockchain garbage collection mode ("full", "archive")`,
@@ -173,6 +233,21 @@ var (
 		EnvVars:  []string{"KLAYTN_GCMODE"},
 		Category: "KLAY",
 	}
+	LightServFlag = &cli.BoolFlag{
+		Name:     "lightserv",
+		Usage:    "Serve the headers, bodies, receipts and state proofs to light clients",
+		Aliases:  []string{"common.light-serv"},
+		EnvVars:  []string{"KLAYTN_LIGHTSERV"},
+		Category: "KLAY",
+	}
+	LightPeersFlag = &cli.IntFlag{
+		Name:     "lightpeers",
+		Usage:    "Maximum number of light clients to serve",
+		Value:    cn.GetDefaultConfig().LightPeers,
+		Aliases:  []string{"common.light-peers"},
+		EnvVars:  []string{"KLAYTN_LIGHTPEERS"},
+		Category: "KLAY",
+	}
 	LightKDFFlag = &cli.BoolFlag{
 		Name:     "lightkdf",
 		Usage:    "Reduce key-derivation RAM & CPU usage at some expense of KDF strength",
@@ -501,6 +576,27 @@ var (
 		EnvVars:  []string{"KLAYTN_SNAPSHOT_BACKGROUND_GENERATION"},
 		Category: "MISC",
 	}
+	SnapServeResponseSizeFlag = &cli.Uint64Flag{
+		Name:     "snapshot.serve-response-size",
+		Usage:    "Target maximum size of the responses to snap sync requests (in bytes, 0 = 2 MiB)",
+		Aliases:  []string{"snapshot-database.serve-response-size"},
+		EnvVars:  []string{"KLAYTN_SNAPSHOT_SERVE_RESPONSE_SIZE"},
+		Category: "MISC",
+	}
+	SnapServeResponseTimeFlag = &cli.DurationFlag{
+		Name:     "snapshot.serve-response-time",
+		Usage:    "Maximum time spent on assembling a response to a snap sync request (0 = 5s)",
+		Aliases:  []string{"snapshot-database.serve-response-time"},
+		EnvVars:  []string{"KLAYTN_SNAPSHOT_SERVE_RESPONSE_TIME"},
+		Category: "MISC",
+	}
+	SnapServeCPUShareFlag = &cli.Float64Flag{
+		Name:     "snapshot.serve-cpu-share",
+		Usage:    "Share of a CPU core spent on serving snap sync requests, e.g. 0.5 for half a core (0 = unlimited)",
+		Aliases:  []string{"snapshot-database.serve-cpu-share"},
+		EnvVars:  []string{"KLAYTN_SNAPSHOT_SERVE_CPU_SHARE"},
+		Category: "MISC",
+	}
 	TrieMemoryCacheSizeFlag = &cli.IntFlag{
 		Name:     "state.cache-size",
 		Usage:    "Size of in-memory cache of the global state (in MiB) to flush matured singleton trie nodes to disk",
@@ -540,6 +636,13 @@ var (
 		EnvVars:  []string{"KLAYTN_STATE_LIVE_PRUNING_RETENTION"},
 		Category: "STATE",
 	}
+	StateSchemeFlag = &cli.StringFlag{
+		Name:     "state.scheme",
+		Usage:    "Scheme to use for storing the state trie nodes ('hash' or 'path'). It is fixed when the database is created",
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_STATE_SCHEME"},
+		Category: "STATE",
+	}
 	CacheTypeFlag = &cli.IntFlag{
 		Name:     "cache.type",
 		Usage:    "Cache Type: 0=LRUCache, 1=LRUShardCache, 2=FIFOCache",
@@ -708,6 +811,30 @@ var (
 		EnvVars:  []string{"KLAYTN_TXRESEND_USE_LEGACY"},
 		Category: "TXPOOL",
 	}
+	TxAnnounceSizeCNFlag = &cli.Uint64Flag{
+		Name:     "txannounce.size.cn",
+		Usage:    "Announce transactions larger than the size in bytes to CN peers by hash (0 = disabled)",
+		Value:    0,
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_TXANNOUNCE_SIZE_CN"},
+		Category: "TXPOOL",
+	}
+	TxAnnounceSizePNFlag = &cli.Uint64Flag{
+		Name:     "txannounce.size.pn",
+		Usage:    "Announce transactions larger than the size in bytes to PN peers by hash (0 = disabled)",
+		Value:    cn.DefaultTxAnnounceSize,
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_TXANNOUNCE_SIZE_PN"},
+		Category: "TXPOOL",
+	}
+	TxAnnounceSizeENFlag = &cli.Uint64Flag{
+		Name:     "txannounce.size.en",
+		Usage:    "Announce transactions larger than the size in bytes to EN peers by hash (0 = disabled)",
+		Value:    cn.DefaultTxAnnounceSize,
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_TXANNOUNCE_SIZE_EN"},
+		Category: "TXPOOL",
+	}
 	// Account settings
 	UnlockedAccountFlag = &cli.StringFlag{
 		Name:     "unlock",
@@ -1132,6 +1259,14 @@ var (
 		EnvVars:  []string{"KLAYTN_BOOTNODES"},
 		Category: "NETWORK",
 	}
+	DNSDiscoveryFlag = &cli.StringFlag{
+		Name:     "discovery.dns",
+		Usage:    "Comma separated kntree URLs of signed DNS node lists used as additional discovery seeds",
+		Value:    "",
+		Aliases:  []string{"p2p.discovery-dns"},
+		EnvVars:  []string{"KLAYTN_DISCOVERY_DNS"},
+		Category: "NETWORK",
+	}
 	NodeKeyFileFlag = &cli.StringFlag{
 		Name:     "nodekey",
 		Usage:    "P2P node key file",
@@ -1189,6 +1324,102 @@ var (
 		EnvVars:  []string{"KLAYTN_NETRESTRICT"},
 		Category: "NETWORK",
 	}
+	PeerScoreDisableFlag = &cli.BoolFlag{
+		Name:     "peerscore.disable",
+		Usage:    "Disables the reputation scoring and the automatic banning of misbehaving peers",
+		Aliases:  []string{"p2p.peer-score.disable"},
+		EnvVars:  []string{"KLAYTN_PEERSCORE_DISABLE"},
+		Category: "NETWORK",
+	}
+	PeerScoreBanThresholdFlag = &cli.Float64Flag{
+		Name:     "peerscore.ban-threshold",
+		Usage:    "Reputation score at or below which a peer is banned",
+		Value:    p2p.DefaultBanThreshold,
+		Aliases:  []string{"p2p.peer-score.ban-threshold"},
+		EnvVars:  []string{"KLAYTN_PEERSCORE_BAN_THRESHOLD"},
+		Category: "NETWORK",
+	}
+	PeerScoreBanDurationFlag = &cli.DurationFlag{
+		Name:     "peerscore.ban-duration",
+		Usage:    "Duration for which a misbehaving peer is banned",
+		Value:    p2p.DefaultBanDuration,
+		Aliases:  []string{"p2p.peer-score.ban-duration"},
+		EnvVars:  []string{"KLAYTN_PEERSCORE_BAN_DURATION"},
+		Category: "NETWORK",
+	}
+	PeerScoreHalfLifeFlag = &cli.DurationFlag{
+		Name:     "peerscore.half-life",
+		Usage:    "Time in which a reputation score decays halfway back to zero",
+		Value:    p2p.DefaultScoreHalfLife,
+		Aliases:  []string{"p2p.peer-score.half-life"},
+		EnvVars:  []string{"KLAYTN_PEERSCORE_HALF_LIFE"},
+		Category: "NETWORK",
+	}
+	PeerScoreExemptTrustedFlag = &cli.BoolFlag{
+		Name:     "peerscore.exempt-trusted",
+		Usage:    "Exempts the trusted nodes from the reputation scoring and the automatic banning",
+		Aliases:  []string{"p2p.peer-score.exempt-trusted"},
+		EnvVars:  []string{"KLAYTN_PEERSCORE_EXEMPT_TRUSTED"},
+		Category: "NETWORK",
+	}
+	BandwidthEgressCNFlag = &cli.Uint64Flag{
+		Name:     "bandwidth.egress-cn",
+		Usage:    "Caps the bandwidth for sending snap sync messages to all the CNs together, in bytes per second (0 = unlimited)",
+		Aliases:  []string{"p2p.bandwidth.egress-cn"},
+		EnvVars:  []string{"KLAYTN_BANDWIDTH_EGRESS_CN"},
+		Category: "NETWORK",
+	}
+	BandwidthEgressPNFlag = &cli.Uint64Flag{
+		Name:     "bandwidth.egress-pn",
+		Usage:    "Caps the bandwidth for sending snap sync messages to all the PNs together, in bytes per second (0 = unlimited)",
+		Aliases:  []string{"p2p.bandwidth.egress-pn"},
+		EnvVars:  []string{"KLAYTN_BANDWIDTH_EGRESS_PN"},
+		Category: "NETWORK",
+	}
+	BandwidthEgressENFlag = &cli.Uint64Flag{
+		Name:     "bandwidth.egress-en",
+		Usage:    "Caps the bandwidth for sending snap sync messages to all the ENs together, in bytes per second (0 = unlimited)",
+		Aliases:  []string{"p2p.bandwidth.egress-en"},
+		EnvVars:  []string{"KLAYTN_BANDWIDTH_EGRESS_EN"},
+		Category: "NETWORK",
+	}
+	CaptureFileFlag = &cli.StringFlag{
+		Name:     "capture.file",
+		Usage:    "File to record the messages exchanged with the selected peers to, for debugging (disabled if empty)",
+		Aliases:  []string{"p2p.capture.file"},
+		EnvVars:  []string{"KLAYTN_CAPTURE_FILE"},
+		Category: "NETWORK",
+	}
+	CapturePeersFlag = &cli.StringSliceFlag{
+		Name:     "capture.peers",
+		Usage:    "Comma separated node IDs or enode URLs of the peers whose messages are recorded (all if empty)",
+		Aliases:  []string{"p2p.capture.peers"},
+		EnvVars:  []string{"KLAYTN_CAPTURE_PEERS"},
+		Category: "NETWORK",
+	}
+	CaptureCodesFlag = &cli.StringSliceFlag{
+		Name:     "capture.codes",
+		Usage:    "Comma separated protocol message codes recorded (all if empty)",
+		Aliases:  []string{"p2p.capture.codes"},
+		EnvVars:  []string{"KLAYTN_CAPTURE_CODES"},
+		Category: "NETWORK",
+	}
+	CaptureMaxSizeFlag = &cli.IntFlag{
+		Name:     "capture.maxsize",
+		Usage:    "Size in megabytes at which the capture file is rotated",
+		Value:    100,
+		Aliases:  []string{"p2p.capture.maxsize"},
+		EnvVars:  []string{"KLAYTN_CAPTURE_MAXSIZE"},
+		Category: "NETWORK",
+	}
+	CaptureMaxBackupsFlag = &cli.IntFlag{
+		Name:     "capture.maxbackups",
+		Usage:    "Number of rotated capture files kept (0 = all)",
+		Value:    10,
+		Aliases:  []string{"p2p.capture.maxbackups"},
+		EnvVars:  []string{"KLAYTN_CAPTURE_MAXBACKUPS"},
+		Category: "NETWORK",
+	}
 	RWTimerIntervalFlag = &cli.Uint64Flag{
 		Name:     "rwtimerinterval",
 		Usage:    "Interval of using rw timer to check if it works well",
@@ -1276,6 +1507,13 @@ var (
 		EnvVars:  []string{"KLAYTN_CHAINTXLIMIT"},
 		Category: "SERVICECHAIN",
 	}
+	MerkleAnchoringFlag = &cli.BoolFlag{
+		Name:     "chaintxmerkle",
+		Usage:    "Anchor a Merkle root over every block of the anchoring period instead of the last block only",
+		Aliases:  []string{"servicechain.chain-tx-merkle"},
+		EnvVars:  []string{"KLAYTN_CHAINTXMERKLE"},
+		Category: "SERVICECHAIN",
+	}
 	MainBridgeFlag = &cli.BoolFlag{
 		Name:     "mainbridge",
 		Usage:    "Enable main bridge service for service chain",
@@ -1314,6 +1552,13 @@ var (
 		EnvVars:  []string{"KLAYTN_PARENTCHAINID"},
 		Category: "SERVICECHAIN",
 	}
+	ParentChainsFlag = &cli.StringFlag{
+		Name:     "parentchains",
+		Usage:    "Comma-separated chainID[:anchoringPeriod] list of parent chains to connect besides the one of --parentchainid. Anchoring to a parent chain is disabled if its period is not given",
+		Aliases:  []string{"servicechain.parent-chains"},
+		EnvVars:  []string{"KLAYTN_PARENTCHAINS"},
+		Category: "SERVICECHAIN",
+	}
 	VTRecoveryFlag = &cli.BoolFlag{
 		Name:     "vtrecovery",
 		Usage:    "Enable value transfer recovery (default: false)",
@@ -1329,6 +1574,21 @@ var (
 		EnvVars:  []string{"KLAYTN_VTRECOVERYINTERVAL"},
 		Category: "SERVICECHAIN",
 	}
+	TransferLimitsFlag = &cli.StringFlag{
+		Name:     "vtlimits",
+		Usage:    "Comma-separated token:perTransfer:perPeriod value transfer limits; transfers over a limit are held until approved (token: 'klay' or a token address, empty or 0: unlimited)",
+		Aliases:  []string{"servicechain.vt-limits"},
+		EnvVars:  []string{"KLAYTN_VTLIMITS"},
+		Category: "SERVICECHAIN",
+	}
+	TransferLimitPeriodFlag = &cli.Uint64Flag{
+		Name:     "vtlimitperiod",
+		Usage:    "Set the period of the per-period value transfer limits (seconds)",
+		Value:    86400,
+		Aliases:  []string{"servicechain.vt-limit-period"},
+		EnvVars:  []string{"KLAYTN_VTLIMITPERIOD"},
+		Category: "SERVICECHAIN",
+	}
 	ServiceChainParentOperatorTxGasLimitFlag = &cli.Uint64Flag{
 		Name:     "sc.parentoperator.gaslimit",
 		Usage:    "Set the default value of gas limit for transactions made by bridge parent operator",
@@ -1345,6 +1605,20 @@ var (
 		EnvVars:  []string{"KLAYTN_SC_CHILDOPERATOR_GASLIMIT"},
 		Category: "SERVICECHAIN",
 	}
+	ServiceChainParentOperatorSignerFlag = &cli.StringFlag{
+		Name:     "sc.parentoperator.signer",
+		Usage:    "Use the given account of the node's wallets, such as the external signer, as the bridge parent operator",
+		Aliases:  []string{"servicechain.parent-operator-signer"},
+		EnvVars:  []string{"KLAYTN_SC_PARENTOPERATOR_SIGNER"},
+		Category: "SERVICECHAIN",
+	}
+	ServiceChainChildOperatorSignerFlag = &cli.StringFlag{
+		Name:     "sc.childoperator.signer",
+		Usage:    "Use the given account of the node's wallets, such as the external signer, as the bridge child operator",
+		Aliases:  []string{"servicechain.child-operator-signer"},
+		EnvVars:  []string{"KLAYTN_SC_CHILDOPERATOR_SIGNER"},
+		Category: "SERVICECHAIN",
+	}
 	ServiceChainNewAccountFlag = &cli.BoolFlag{
 		Name:     "scnewaccount",
 		Usage:    "Enable account creation for the service chain (default: false). If set true, generated account can't be synced with the parent chain.",
@@ -1438,7 +1712,7 @@ var (
 	}
 	ChainDataFetcherMode = &cli.StringFlag{
 		Name:     "chaindatafetcher.mode",
-		Usage:    "The mode of chaindatafetcher (\"kas\", \"kafka\")",
+		Usage:    "The mode of chaindatafetcher (\"kas\", \"kafka\", \"file\")",
 		Value:    "kas",
 		Aliases:  []string{"chain-data-fetcher.mode"},
 		EnvVars:  []string{"KLAYTN_CHAINDATAFETCHER_MODE"},
@@ -1626,6 +1900,28 @@ var (
 		EnvVars:  []string{"KLAYTN_CHAINDATAFETCHER_KAFKA_PRODUCER_ID"},
 		Category: "CHAINDATAFETCHER",
 	}
+	ChainDataFetcherKafkaPublishDecodedFlag = &cli.BoolFlag{
+		Name:     "chaindatafetcher.kafka.publish.decoded",
+		Usage:    "Publish the decoded token transfers and contracts to kafka as well",
+		Aliases:  []string{"chain-data-fetcher.kafka.publish-decoded"},
+		EnvVars:  []string{"KLAYTN_CHAINDATAFETCHER_KAFKA_PUBLISH_DECODED"},
+		Category: "CHAINDATAFETCHER",
+	}
+	ChainDataFetcherFileDirFlag = &cli.StringFlag{
+		Name:     "chaindatafetcher.file.dir",
+		Usage:    "The directory where chaindata files are exported",
+		Aliases:  []string{"chain-data-fetcher.file.dir"},
+		EnvVars:  []string{"KLAYTN_CHAINDATAFETCHER_FILE_DIR"},
+		Category: "CHAINDATAFETCHER",
+	}
+	ChainDataFetcherFileMaxSizeFlag = &cli.IntFlag{
+		Name:     "chaindatafetcher.file.max.size",
+		Usage:    "The size of an exported file which makes it rotated (in MB)",
+		Value:    file.DefaultMaxFileSize,
+		Aliases:  []string{"chain-data-fetcher.file.max-size"},
+		EnvVars:  []string{"KLAYTN_CHAINDATAFETCHER_FILE_MAX_SIZE"},
+		Category: "CHAINDATAFETCHER",
+	}
 	// DBSyncer
 	EnableDBSyncerFlag = &cli.BoolFlag{
 		Name:     "dbsyncer",
@@ -1634,6 +1930,14 @@ var (
 		EnvVars:  []string{"KLAYTN_DBSYNCER"},
 		Category: "DATABASE SYNCER",
 	}
+	DBTypeFlag = &cli.StringFlag{
+		Name:     "dbsyncer.db.type",
+		Usage:    "db.type in dbsyncer (mysql, postgres)",
+		Value:    dbsyncer.MYSQL,
+		Aliases:  []string{"db-syncer.db.type"},
+		EnvVars:  []string{"KLAYTN_DBSYNCER_DB_TYPE"},
+		Category: "DATABASE SYNCER",
+	}
 	DBHostFlag = &cli.StringFlag{
 		Name:     "dbsyncer.db.host",
 		Usage:    "db.host in dbsyncer",
@@ -1643,7 +1947,7 @@ var (
 	}
 	DBPortFlag = &cli.StringFlag{
 		Name:     "dbsyncer.db.port",
-		Usage:    "db.port in dbsyncer",
+		Usage:    "db.port in dbsyncer (default 5432 for postgres)",
 		Value:    "3306",
 		Aliases:  []string{"db-syncer.db.port"},
 		EnvVars:  []string{"KLAYTN_DBSYNCER_DB_PORT"},
@@ -1670,6 +1974,14 @@ var (
 		EnvVars:  []string{"KLAYTN_DBSYNCER_DB_PASSWORD"},
 		Category: "DATABASE SYNCER",
 	}
+	DBSSLModeFlag = &cli.StringFlag{
+		Name:     "dbsyncer.db.sslmode",
+		Usage:    "db.sslmode in dbsyncer, only used for postgres (disable, require, verify-ca, verify-full)",
+		Value:    "disable",
+		Aliases:  []string{"db-syncer.db.sslmode"},
+		EnvVars:  []string{"KLAYTN_DBSYNCER_DB_SSLMODE"},
+		Category: "DATABASE SYNCER",
+	}
 	EnabledLogModeFlag = &cli.BoolFlag{
 		Name:     "dbsyncer.logmode",
 		Usage:    "Enable the dbsyncer logmode",
@@ -2020,11 +2332,15 @@ func MakePasswordList(ctx *cli.Context) []string {
 
 // RegisterCNService adds a CN client to the stack.
 func RegisterCNService(stack *node.Node, cfg *cn.Config) {
-	// TODO-Klaytn add syncMode.LightSync func and add LesServer
-
 	err := stack.Register(func(ctx *node.ServiceContext) (node.Service, error) {
 		cfg.WsEndpoint = stack.WSEndpoint()
+		if cfg.SyncMode == downloader.LightSync {
+			return light.New(ctx, cfg)
+		}
 		fullNode, err := cn.New(ctx, cfg)
+		if err == nil && cfg.LightServ {
+			fullNode.AddLesServer(light.NewServer(fullNode.BlockChain(), cfg.NetworkId, cfg.LightPeers))
+		}
 		return fullNode, err
 	})
 	if err != nil {
@@ -0,0 +1,135 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package nodecmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/klaytn/klaytn/cmd/utils"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/node"
+	"github.com/urfave/cli/v2"
+)
+
+var errInconsistentValueTransfers = errors.New("value transfers of the bridge pair are inconsistent")
+
+var (
+	reconcileEndpointFlag = &cli.StringFlag{
+		Name:  "endpoint",
+		Usage: "RPC endpoint of the running sub-bridge node (default: klay.ipc in the data directory)",
+	}
+	reconcileChildFromFlag = &cli.Uint64Flag{
+		Name:  "child.from",
+		Usage: "First child chain block to scan",
+	}
+	reconcileChildToFlag = &cli.Uint64Flag{
+		Name:  "child.to",
+		Usage: "Last child chain block to scan (default: the current block)",
+	}
+	reconcileParentFromFlag = &cli.Uint64Flag{
+		Name:  "parent.from",
+		Usage: "First parent chain block to scan",
+	}
+	reconcileParentToFlag = &cli.Uint64Flag{
+		Name:  "parent.to",
+		Usage: "Last parent chain block to scan (default: the current block)",
+	}
+)
+
+var BridgeCommand = &cli.Command{
+	Name:     "bridge",
+	Usage:    "A set of commands for the service chain bridges",
+	Category: "SERVICECHAIN COMMANDS",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "reconcile",
+			Usage:     "Reconcile the value transfers of a bridge pair on both chains",
+			ArgsUsage: "<childBridge> <parentBridge>",
+			Action:    reconcileBridge,
+			Flags: []cli.Flag{
+				utils.DataDirFlag,
+				reconcileEndpointFlag,
+				reconcileChildFromFlag,
+				reconcileChildToFlag,
+				reconcileParentFromFlag,
+				reconcileParentToFlag,
+			},
+			Description: `
+The reconcile command connects to a running sub-bridge node and scans the
+RequestValueTransfer and HandleValueTransfer events of the given bridge pair in
+the given block ranges of the child and parent chains. It pairs the requests
+with their handles by nonce and prints a JSON report of the unhandled requests,
+double handles, mismatched amounts and the locked and minted totals per token.
+
+The command exits with an error if the value transfers are inconsistent.`,
+		},
+	},
+}
+
+// reconcileBridge prints the reconciliation report of a bridge pair from a running node.
+func reconcileBridge(ctx *cli.Context) error {
+	if ctx.Args().Len() != 2 {
+		return fmt.Errorf("%w: expected <childBridge> <parentBridge>", ErrInvalidCmd)
+	}
+	cBridgeAddr, pBridgeAddr := ctx.Args().Get(0), ctx.Args().Get(1)
+	if !common.IsHexAddress(cBridgeAddr) || !common.IsHexAddress(pBridgeAddr) {
+		return fmt.Errorf("%w: invalid bridge address", ErrInvalidCmd)
+	}
+
+	endpoint := ctx.String(reconcileEndpointFlag.Name)
+	if endpoint == "" {
+		path := node.DefaultDataDir()
+		if ctx.IsSet(utils.DataDirFlag.Name) {
+			path = ctx.String(utils.DataDirFlag.Name)
+		}
+		endpoint = filepath.Join(path, "klay.ipc")
+	}
+	client, err := dialRPC(endpoint)
+	if err != nil {
+		return fmt.Errorf("unable to attach to the node: %v", err)
+	}
+	defer client.Close()
+
+	var report json.RawMessage
+	if err := client.Call(&report, "subbridge_reconcileValueTransfers",
+		common.HexToAddress(cBridgeAddr), common.HexToAddress(pBridgeAddr),
+		ctx.Uint64(reconcileChildFromFlag.Name), ctx.Uint64(reconcileChildToFlag.Name),
+		ctx.Uint64(reconcileParentFromFlag.Name), ctx.Uint64(reconcileParentToFlag.Name)); err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+
+	var result struct {
+		Consistent bool `json:"consistent"`
+	}
+	if err := json.Unmarshal(report, &result); err != nil {
+		return err
+	}
+	if !result.Consistent {
+		return errInconsistentValueTransfers
+	}
+	return nil
+}
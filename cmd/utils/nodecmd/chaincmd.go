@@ -34,6 +34,7 @@ import (
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/rlp"
 	"github.com/klaytn/klaytn/storage/database"
+	"github.com/klaytn/klaytn/storage/statedb"
 	"github.com/urfave/cli/v2"
 )
 
@@ -68,6 +69,7 @@ var (
 			utils.RocksDBMaxOpenFilesFlag,
 			utils.RocksDBCacheIndexAndFilterFlag,
 			utils.OverwriteGenesisFlag,
+			utils.StateSchemeFlag,
 		},
 		Category: "BLOCKCHAIN COMMANDS",
 		Description: `
@@ -182,6 +184,10 @@ func initGenesis(ctx *cli.Context) error {
 		}
 		chainDB := stack.OpenDatabase(dbc)
 
+		if _, err := statedb.ParseStateScheme(ctx.String(utils.StateSchemeFlag.Name), chainDB); err != nil {
+			logger.Crit("Failed to set the state scheme", "err", err)
+		}
+
 		// Initialize DeriveSha implementation
 		blockchain.InitDeriveSha(genesis.Config)
 
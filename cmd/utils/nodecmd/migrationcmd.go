@@ -19,11 +19,14 @@ package nodecmd
 
 import (
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/klaytn/klaytn/cmd/utils"
 	"github.com/klaytn/klaytn/storage/database"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli/v2"
+	"github.com/urfave/cli/v2/altsrc"
 )
 
 var (
@@ -58,10 +61,33 @@ to the original db dir name.
 
 Note: This feature is only provided when srcDB is single LevelDB.`,
 			},
+			{
+				Name:   "reshard",
+				Usage:  "Increase the number of state trie DB shards",
+				Flags:  dbReshardFlags,
+				Action: startReshard,
+				Description: `
+This command increases the number of shards of the state trie DB in place.
+Each shard is split and its keys are moved to their new shards.
+
+The current number of shards is given by db.num-statetrie-shards and the new
+number of shards is given by db.dst.num-statetrie-shards. The new number
+should be a larger power of two. If the command is interrupted, it can be
+run again with the same flags to resume. The node also resumes an interrupted
+resharding in the background when it starts.
+
+After resharding, set db.num-statetrie-shards to the new number of shards.
+A node refuses to start with a number of shards different from the database.`,
+			},
 		},
 	}
+
+	dbReshardFlags = append(utils.DBMigrationSrcFlags, altsrc.NewUintFlag(utils.DstNumStateTrieShardsFlag))
 )
 
+// reshardLogInterval is the interval of logging the progress of resharding.
+const reshardLogInterval = 30 * time.Second
+
 func startMigration(ctx *cli.Context) error {
 	srcDBManager, dstDBManager, err := createDBManagerForMigration(ctx)
 	if err != nil {
@@ -92,8 +118,63 @@ func createDBManagerForMigration(ctx *cli.Context) (database.DBManager, database
 	return srcDBManager, dstDBManager, nil
 }
 
+func startReshard(ctx *cli.Context) error {
+	srcDBC, err := createSrcDBConfigForMigration(ctx)
+	if err != nil {
+		return err
+	}
+	numShards := ctx.Uint(utils.DstNumStateTrieShardsFlag.Name)
+
+	dbm := database.NewDBManager(srcDBC)
+	defer dbm.Close()
+
+	// An interrupted resharding is resumed when the database is opened.
+	status, err := dbm.StateTrieReshardingStatus()
+	if err != nil {
+		return err
+	}
+	if status.InProgress && status.To != numShards {
+		return fmt.Errorf("resharding to %d shards is in progress", status.To)
+	}
+	if !status.InProgress {
+		if _, err := dbm.ReshardStateTrieDB(numShards); err != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(reshardLogInterval)
+	defer ticker.Stop()
+	for {
+		status, err := dbm.StateTrieReshardingStatus()
+		if err != nil {
+			return err
+		}
+		if status.Err != "" {
+			return errors.New(status.Err)
+		}
+		if !status.InProgress {
+			logger.Info("Resharding is finished", "numShards", status.To)
+			return nil
+		}
+		logger.Info("Resharding in progress", "from", status.From, "to", status.To,
+			"splitShards", status.SplitShards, "movedKeys", status.MovedKeys)
+		<-ticker.C
+	}
+}
+
 func createDBConfigForMigration(ctx *cli.Context) (*database.DBConfig, *database.DBConfig, error) {
-	// srcDB
+	srcDBC, err := createSrcDBConfigForMigration(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	dstDBC, err := createDstDBConfigForMigration(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return srcDBC, dstDBC, nil
+}
+
+func createSrcDBConfigForMigration(ctx *cli.Context) (*database.DBConfig, error) {
 	srcDBC := &database.DBConfig{
 		Dir:                ctx.String(utils.DataDirFlag.Name),
 		DBType:             database.DBType(ctx.String(utils.DbTypeFlag.Name)).ToValid(),
@@ -127,9 +208,12 @@ func createDBConfigForMigration(ctx *cli.Context) (*database.DBConfig, *database
 		},
 	}
 	if len(srcDBC.DBType) == 0 { // changed to invalid type
-		return nil, nil, errors.New("srcDB is not specified or invalid : " + ctx.String(utils.DbTypeFlag.Name))
+		return nil, errors.New("srcDB is not specified or invalid : " + ctx.String(utils.DbTypeFlag.Name))
 	}
+	return srcDBC, nil
+}
 
+func createDstDBConfigForMigration(ctx *cli.Context) (*database.DBConfig, error) {
 	// dstDB
 	dstDBC := &database.DBConfig{
 		Dir:                ctx.String(utils.DstDataDirFlag.Name),
@@ -164,10 +248,9 @@ func createDBConfigForMigration(ctx *cli.Context) (*database.DBConfig, *database
 		},
 	}
 	if len(dstDBC.DBType) == 0 { // changed to invalid type
-		return nil, nil, errors.New("dstDB is not specified or invalid : " + ctx.String(utils.DstDbTypeFlag.Name))
+		return nil, errors.New("dstDB is not specified or invalid : " + ctx.String(utils.DstDbTypeFlag.Name))
 	}
-
-	return srcDBC, dstDBC, nil
+	return dstDBC, nil
 }
 
 // TODO When it is stopped, store previous db migration info.
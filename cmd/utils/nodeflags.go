@@ -166,6 +166,7 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewStringFlag(NtpServerFlag),
 	altsrc.NewPathFlag(DocRootFlag),
 	altsrc.NewStringFlag(BootnodesFlag),
+	altsrc.NewStringFlag(DNSDiscoveryFlag),
 	altsrc.NewStringFlag(IdentityFlag),
 	altsrc.NewStringFlag(UnlockedAccountFlag),
 	altsrc.NewStringFlag(PasswordFileFlag),
@@ -175,6 +176,8 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewBoolFlag(OverwriteGenesisFlag),
 	altsrc.NewUint64Flag(StartBlockNumberFlag),
 	altsrc.NewPathFlag(KeyStoreDirFlag),
+	altsrc.NewStringFlag(ExternalSignerFlag),
+	altsrc.NewStringFlag(ExternalSignerTokenFlag),
 	altsrc.NewBoolFlag(TxPoolNoLocalsFlag),
 	altsrc.NewBoolFlag(TxPoolAllowLocalAnchorTxFlag),
 	altsrc.NewBoolFlag(TxPoolDenyRemoteTxFlag),
@@ -189,7 +192,13 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewDurationFlag(TxPoolLifetimeFlag),
 	altsrc.NewBoolFlag(TxPoolKeepLocalsFlag),
 	NewWrappedTextMarshalerFlag(SyncModeFlag),
+	altsrc.NewStringFlag(CheckpointFileFlag),
+	altsrc.NewStringSliceFlag(CheckpointSignersFlag),
+	altsrc.NewIntFlag(CheckpointThresholdFlag),
+	altsrc.NewBoolFlag(CheckpointBackfillFlag),
 	altsrc.NewStringFlag(GCModeFlag),
+	altsrc.NewBoolFlag(LightServFlag),
+	altsrc.NewIntFlag(LightPeersFlag),
 	altsrc.NewBoolFlag(LightKDFFlag),
 	altsrc.NewBoolFlag(SingleDBFlag),
 	altsrc.NewUintFlag(NumStateTrieShardsFlag),
@@ -219,6 +228,7 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewUint64Flag(TriesInMemoryFlag),
 	altsrc.NewBoolFlag(LivePruningFlag),
 	altsrc.NewUint64Flag(LivePruningRetentionFlag),
+	altsrc.NewStringFlag(StateSchemeFlag),
 	altsrc.NewIntFlag(CacheTypeFlag),
 	altsrc.NewIntFlag(CacheScaleFlag),
 	altsrc.NewStringFlag(CacheUsageLevelFlag),
@@ -244,6 +254,19 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewDurationFlag(RWTimerWaitTimeFlag),
 	altsrc.NewUint64Flag(RWTimerIntervalFlag),
 	altsrc.NewStringFlag(NetrestrictFlag),
+	altsrc.NewBoolFlag(PeerScoreDisableFlag),
+	altsrc.NewFloat64Flag(PeerScoreBanThresholdFlag),
+	altsrc.NewDurationFlag(PeerScoreBanDurationFlag),
+	altsrc.NewDurationFlag(PeerScoreHalfLifeFlag),
+	altsrc.NewBoolFlag(PeerScoreExemptTrustedFlag),
+	altsrc.NewUint64Flag(BandwidthEgressCNFlag),
+	altsrc.NewUint64Flag(BandwidthEgressPNFlag),
+	altsrc.NewUint64Flag(BandwidthEgressENFlag),
+	altsrc.NewStringFlag(CaptureFileFlag),
+	altsrc.NewStringSliceFlag(CapturePeersFlag),
+	altsrc.NewStringSliceFlag(CaptureCodesFlag),
+	altsrc.NewIntFlag(CaptureMaxSizeFlag),
+	altsrc.NewIntFlag(CaptureMaxBackupsFlag),
 	altsrc.NewStringFlag(NodeKeyFileFlag),
 	altsrc.NewStringFlag(NodeKeyHexFlag),
 	altsrc.NewBoolFlag(VMEnableDebugFlag),
@@ -266,6 +289,9 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewBoolFlag(SnapshotFlag),
 	altsrc.NewIntFlag(SnapshotCacheSizeFlag),
 	altsrc.NewBoolFlag(SnapshotAsyncGen),
+	altsrc.NewUint64Flag(SnapServeResponseSizeFlag),
+	altsrc.NewDurationFlag(SnapServeResponseTimeFlag),
+	altsrc.NewFloat64Flag(SnapServeCPUShareFlag),
 }
 
 // Common RPC flags
@@ -328,12 +354,17 @@ var KCNFlags = []cli.Flag{
 	altsrc.NewBoolFlag(BaobabFlag),
 	altsrc.NewInt64Flag(BlockGenerationIntervalFlag),
 	altsrc.NewDurationFlag(BlockGenerationTimeLimitFlag),
+	altsrc.NewBoolFlag(ExternalConsensusSignerFlag),
+	altsrc.NewStringFlag(ExternalConsensusAddressFlag),
 }
 
 var KPNFlags = []cli.Flag{
 	altsrc.NewUint64Flag(TxResendIntervalFlag),
 	altsrc.NewIntFlag(TxResendCountFlag),
 	altsrc.NewBoolFlag(TxResendUseLegacyFlag),
+	altsrc.NewUint64Flag(TxAnnounceSizeCNFlag),
+	altsrc.NewUint64Flag(TxAnnounceSizePNFlag),
+	altsrc.NewUint64Flag(TxAnnounceSizeENFlag),
 	altsrc.NewBoolFlag(CypressFlag),
 	altsrc.NewBoolFlag(BaobabFlag),
 	altsrc.NewBoolFlag(TxPoolSpamThrottlerDisableFlag),
@@ -349,11 +380,13 @@ var KENFlags = []cli.Flag{
 	altsrc.NewBoolFlag(KESNodeTypeServiceFlag),
 	// DBSyncer
 	altsrc.NewBoolFlag(EnableDBSyncerFlag),
+	altsrc.NewStringFlag(DBTypeFlag),
 	altsrc.NewStringFlag(DBHostFlag),
 	altsrc.NewStringFlag(DBPortFlag),
 	altsrc.NewStringFlag(DBNameFlag),
 	altsrc.NewStringFlag(DBUserFlag),
 	altsrc.NewStringFlag(DBPasswordFlag),
+	altsrc.NewStringFlag(DBSSLModeFlag),
 	altsrc.NewBoolFlag(EnabledLogModeFlag),
 	altsrc.NewIntFlag(MaxIdleConnsFlag),
 	altsrc.NewIntFlag(MaxOpenConnsFlag),
@@ -368,27 +401,38 @@ var KENFlags = []cli.Flag{
 	altsrc.NewUint64Flag(TxResendIntervalFlag),
 	altsrc.NewIntFlag(TxResendCountFlag),
 	altsrc.NewBoolFlag(TxResendUseLegacyFlag),
+	altsrc.NewUint64Flag(TxAnnounceSizeCNFlag),
+	altsrc.NewUint64Flag(TxAnnounceSizePNFlag),
+	altsrc.NewUint64Flag(TxAnnounceSizeENFlag),
 }
 
 var KSCNFlags = []cli.Flag{
 	altsrc.NewStringFlag(RewardbaseFlag),
 	altsrc.NewInt64Flag(BlockGenerationIntervalFlag),
 	altsrc.NewDurationFlag(BlockGenerationTimeLimitFlag),
+	altsrc.NewBoolFlag(ExternalConsensusSignerFlag),
+	altsrc.NewStringFlag(ExternalConsensusAddressFlag),
 	altsrc.NewStringFlag(ServiceChainSignerFlag),
 	altsrc.NewUint64Flag(AnchoringPeriodFlag),
 	altsrc.NewUint64Flag(SentChainTxsLimit),
+	altsrc.NewBoolFlag(MerkleAnchoringFlag),
 	altsrc.NewBoolFlag(MainBridgeFlag),
 	altsrc.NewIntFlag(MainBridgeListenPortFlag),
 	altsrc.NewBoolFlag(ChildChainIndexingFlag),
 	altsrc.NewBoolFlag(SubBridgeFlag),
 	altsrc.NewIntFlag(SubBridgeListenPortFlag),
 	altsrc.NewIntFlag(ParentChainIDFlag),
+	altsrc.NewStringFlag(ParentChainsFlag),
 	altsrc.NewBoolFlag(VTRecoveryFlag),
 	altsrc.NewUint64Flag(VTRecoveryIntervalFlag),
+	altsrc.NewStringFlag(TransferLimitsFlag),
+	altsrc.NewUint64Flag(TransferLimitPeriodFlag),
 	altsrc.NewBoolFlag(ServiceChainNewAccountFlag),
 	altsrc.NewBoolFlag(ServiceChainAnchoringFlag),
 	altsrc.NewUint64Flag(ServiceChainParentOperatorTxGasLimitFlag),
 	altsrc.NewUint64Flag(ServiceChainChildOperatorTxGasLimitFlag),
+	altsrc.NewStringFlag(ServiceChainParentOperatorSignerFlag),
+	altsrc.NewStringFlag(ServiceChainChildOperatorSignerFlag),
 	// KAS
 	altsrc.NewBoolFlag(KASServiceChainAnchorFlag),
 	altsrc.NewUint64Flag(KASServiceChainAnchorPeriodFlag),
@@ -404,22 +448,31 @@ var KSPNFlags = []cli.Flag{
 	altsrc.NewUint64Flag(TxResendIntervalFlag),
 	altsrc.NewIntFlag(TxResendCountFlag),
 	altsrc.NewBoolFlag(TxResendUseLegacyFlag),
+	altsrc.NewUint64Flag(TxAnnounceSizeCNFlag),
+	altsrc.NewUint64Flag(TxAnnounceSizePNFlag),
+	altsrc.NewUint64Flag(TxAnnounceSizeENFlag),
 	altsrc.NewBoolFlag(TxPoolSpamThrottlerDisableFlag),
 	altsrc.NewStringFlag(ServiceChainSignerFlag),
 	altsrc.NewUint64Flag(AnchoringPeriodFlag),
 	altsrc.NewUint64Flag(SentChainTxsLimit),
+	altsrc.NewBoolFlag(MerkleAnchoringFlag),
 	altsrc.NewBoolFlag(MainBridgeFlag),
 	altsrc.NewIntFlag(MainBridgeListenPortFlag),
 	altsrc.NewBoolFlag(ChildChainIndexingFlag),
 	altsrc.NewBoolFlag(SubBridgeFlag),
 	altsrc.NewIntFlag(SubBridgeListenPortFlag),
 	altsrc.NewIntFlag(ParentChainIDFlag),
+	altsrc.NewStringFlag(ParentChainsFlag),
 	altsrc.NewBoolFlag(VTRecoveryFlag),
 	altsrc.NewUint64Flag(VTRecoveryIntervalFlag),
+	altsrc.NewStringFlag(TransferLimitsFlag),
+	altsrc.NewUint64Flag(TransferLimitPeriodFlag),
 	altsrc.NewBoolFlag(ServiceChainNewAccountFlag),
 	altsrc.NewBoolFlag(ServiceChainAnchoringFlag),
 	altsrc.NewUint64Flag(ServiceChainParentOperatorTxGasLimitFlag),
 	altsrc.NewUint64Flag(ServiceChainChildOperatorTxGasLimitFlag),
+	altsrc.NewStringFlag(ServiceChainParentOperatorSignerFlag),
+	altsrc.NewStringFlag(ServiceChainChildOperatorSignerFlag),
 	// KAS
 	altsrc.NewBoolFlag(KASServiceChainAnchorFlag),
 	altsrc.NewUint64Flag(KASServiceChainAnchorPeriodFlag),
@@ -440,13 +493,19 @@ var KSENFlags = []cli.Flag{
 	altsrc.NewIntFlag(SubBridgeListenPortFlag),
 	altsrc.NewUint64Flag(AnchoringPeriodFlag),
 	altsrc.NewUint64Flag(SentChainTxsLimit),
+	altsrc.NewBoolFlag(MerkleAnchoringFlag),
 	altsrc.NewIntFlag(ParentChainIDFlag),
+	altsrc.NewStringFlag(ParentChainsFlag),
 	altsrc.NewBoolFlag(VTRecoveryFlag),
 	altsrc.NewUint64Flag(VTRecoveryIntervalFlag),
+	altsrc.NewStringFlag(TransferLimitsFlag),
+	altsrc.NewUint64Flag(TransferLimitPeriodFlag),
 	altsrc.NewBoolFlag(ServiceChainAnchoringFlag),
 	altsrc.NewBoolFlag(KESNodeTypeServiceFlag),
 	altsrc.NewUint64Flag(ServiceChainParentOperatorTxGasLimitFlag),
 	altsrc.NewUint64Flag(ServiceChainChildOperatorTxGasLimitFlag),
+	altsrc.NewStringFlag(ServiceChainParentOperatorSignerFlag),
+	altsrc.NewStringFlag(ServiceChainChildOperatorSignerFlag),
 	// KAS
 	altsrc.NewBoolFlag(KASServiceChainAnchorFlag),
 	altsrc.NewUint64Flag(KASServiceChainAnchorPeriodFlag),
@@ -458,11 +517,13 @@ var KSENFlags = []cli.Flag{
 	altsrc.NewDurationFlag(KASServiceChainAnchorRequestTimeoutFlag),
 	// DBSyncer
 	altsrc.NewBoolFlag(EnableDBSyncerFlag),
+	altsrc.NewStringFlag(DBTypeFlag),
 	altsrc.NewStringFlag(DBHostFlag),
 	altsrc.NewStringFlag(DBPortFlag),
 	altsrc.NewStringFlag(DBNameFlag),
 	altsrc.NewStringFlag(DBUserFlag),
 	altsrc.NewStringFlag(DBPasswordFlag),
+	altsrc.NewStringFlag(DBSSLModeFlag),
 	altsrc.NewBoolFlag(EnabledLogModeFlag),
 	altsrc.NewIntFlag(MaxIdleConnsFlag),
 	altsrc.NewIntFlag(MaxOpenConnsFlag),
@@ -477,6 +538,9 @@ var KSENFlags = []cli.Flag{
 	altsrc.NewUint64Flag(TxResendIntervalFlag),
 	altsrc.NewIntFlag(TxResendCountFlag),
 	altsrc.NewBoolFlag(TxResendUseLegacyFlag),
+	altsrc.NewUint64Flag(TxAnnounceSizeCNFlag),
+	altsrc.NewUint64Flag(TxAnnounceSizePNFlag),
+	altsrc.NewUint64Flag(TxAnnounceSizeENFlag),
 }
 
 var SnapshotFlags = []cli.Flag{
@@ -576,4 +640,7 @@ var ChainDataFetcherFlags = []cli.Flag{
 	altsrc.NewIntFlag(ChainDataFetcherKafkaRequiredAcksFlag),
 	altsrc.NewStringFlag(ChainDataFetcherKafkaMessageVersionFlag),
 	altsrc.NewStringFlag(ChainDataFetcherKafkaProducerIdFlag),
+	altsrc.NewBoolFlag(ChainDataFetcherKafkaPublishDecodedFlag),
+	altsrc.NewStringFlag(ChainDataFetcherFileDirFlag),
+	altsrc.NewIntFlag(ChainDataFetcherFileMaxSizeFlag),
 }
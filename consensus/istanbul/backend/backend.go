@@ -51,6 +51,19 @@ const (
 var logger = log.NewModuleLogger(log.ConsensusIstanbulBackend)
 
 func New(rewardbase common.Address, config *istanbul.Config, privateKey *ecdsa.PrivateKey, db database.DBManager, governance governance.Engine, nodetype common.ConnType) consensus.Istanbul {
+	return newBackend(rewardbase, config, privateKey, crypto.PubkeyToAddress(privateKey.PublicKey), db, governance, nodetype)
+}
+
+// NewWithSigner creates an Istanbul backend of the given validator address which
+// signs the consensus messages with config.SignHash instead of a private key.
+func NewWithSigner(rewardbase common.Address, config *istanbul.Config, address common.Address, db database.DBManager, governance governance.Engine, nodetype common.ConnType) consensus.Istanbul {
+	if config.SignHash == nil {
+		logger.Crit("No signer of the consensus messages", "address", address)
+	}
+	return newBackend(rewardbase, config, nil, address, db, governance, nodetype)
+}
+
+func newBackend(rewardbase common.Address, config *istanbul.Config, privateKey *ecdsa.PrivateKey, address common.Address, db database.DBManager, governance governance.Engine, nodetype common.ConnType) consensus.Istanbul {
 	recents, _ := lru.NewARC(inmemorySnapshots)
 	recentMessages, _ := lru.NewARC(inmemoryPeers)
 	knownMessages, _ := lru.NewARC(inmemoryMessages)
@@ -58,7 +71,7 @@ func New(rewardbase common.Address, config *istanbul.Config, privateKey *ecdsa.P
 		config:            config,
 		istanbulEventMux:  new(event.TypeMux),
 		privateKey:        privateKey,
-		address:           crypto.PubkeyToAddress(privateKey.PublicKey),
+		address:           address,
 		logger:            logger.NewWith(),
 		db:                db,
 		commitCh:          make(chan *types.Result, 1),
@@ -338,14 +351,25 @@ func (sb *backend) Verify(proposal istanbul.Proposal) (time.Duration, error) {
 	if err == nil || err == errEmptyCommittedSeals {
 		return 0, nil
 	} else if err == consensus.ErrFutureBlock {
-		return time.Unix(block.Header().Time.Int64(), 0).Sub(now()), consensus.ErrFutureBlock
+		return time.Unix(block.Header().Time.Int64(), 0).Sub(sb.now()), consensus.ErrFutureBlock
 	}
 	return 0, err
 }
 
+// now returns the current time of the node's clock.
+func (sb *backend) now() time.Time {
+	if sb.config.Now != nil {
+		return sb.config.Now()
+	}
+	return now()
+}
+
 // Sign implements istanbul.Backend.Sign
 func (sb *backend) Sign(data []byte) ([]byte, error) {
 	hashData := crypto.Keccak256([]byte(data))
+	if sb.config.SignHash != nil {
+		return sb.config.SignHash(hashData)
+	}
 	return crypto.Sign(hashData, sb.privateKey)
 }
 
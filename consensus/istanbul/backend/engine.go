@@ -208,7 +208,7 @@ func (sb *backend) verifyHeader(chain consensus.ChainReader, header *types.Heade
 	}
 
 	// Don't waste time checking blocks from the future
-	if header.Time.Cmp(big.NewInt(now().Add(allowedFutureBlockTime).Unix())) > 0 {
+	if header.Time.Cmp(big.NewInt(sb.now().Add(allowedFutureBlockTime).Unix())) > 0 {
 		return consensus.ErrFutureBlock
 	}
 
@@ -436,8 +436,7 @@ func (sb *backend) Prepare(chain consensus.ChainReader, header *types.Header) er
 	// set header's timestamp
 	header.Time = new(big.Int).Add(parent.Time, new(big.Int).SetUint64(sb.config.BlockPeriod))
 	header.TimeFoS = parent.TimeFoS
-	if header.Time.Int64() < time.Now().Unix() {
-		t := time.Now()
+	if t := sb.now(); header.Time.Int64() < t.Unix() {
 		header.Time = big.NewInt(t.Unix())
 		header.TimeFoS = uint8((t.UnixNano() / 1000 / 1000 / 10) % 100)
 	}
@@ -561,7 +560,7 @@ func (sb *backend) Seal(chain consensus.ChainReader, block *types.Block, stop <-
 	}
 
 	// wait for the timestamp of header, use this to adjust the block period
-	delay := time.Unix(block.Header().Time.Int64(), 0).Sub(now())
+	delay := time.Unix(block.Header().Time.Int64(), 0).Sub(sb.now())
 	select {
 	case <-time.After(delay):
 	case <-stop:
@@ -392,6 +392,14 @@ func TestVerifyHeader(t *testing.T) {
 		t.Errorf("error mismatch: have %v, want %v", err, consensus.ErrFutureBlock)
 	}
 
+	// the block is not from the future for a node whose clock is ahead
+	engine.config.Now = func() time.Time { return time.Now().Add(20 * time.Second) }
+	err = engine.VerifyHeader(chain, header, false)
+	engine.config.Now = nil
+	if err == consensus.ErrFutureBlock {
+		t.Errorf("future block error with skewed clock")
+	}
+
 	// TODO-Klaytn: add more tests for header.Governance, header.Rewardbase, header.Vote
 }
 
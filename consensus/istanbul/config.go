@@ -20,6 +20,8 @@
 
 package istanbul
 
+import "time"
+
 type ProposerPolicy uint64
 
 const (
@@ -34,6 +36,14 @@ type Config struct {
 	ProposerPolicy ProposerPolicy `toml:",omitempty"` // The policy for proposer selection
 	Epoch          uint64         `toml:",omitempty"` // The number of blocks after which to checkpoint and reset the pending votes
 	SubGroupSize   uint64         `toml:",omitempty"`
+
+	// Now returns the current time of the node. It is used to simulate clock
+	// skews, and time.Now is used if it is nil.
+	Now func() time.Time `toml:"-"`
+
+	// SignHash signs the hash of a consensus message with the node key. It is used
+	// to sign with an external signer, and the node key is used if it is nil.
+	SignHash func(hash []byte) ([]byte, error) `toml:"-"`
 }
 
 // TODO-Klaytn-Istanbul: Do not use DefaultConfig except for assigning new config
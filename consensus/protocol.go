@@ -32,12 +32,13 @@ const (
 	Klay63 = 63
 	Klay64 = 64
 	Klay65 = 65
+	Klay66 = 66
 )
 
 var KlayProtocol = Protocol{
 	Name:     "klay",
-	Versions: []uint{Klay65, Klay64, Klay63, Klay62},
-	Lengths:  []uint64{21, 19, 17, 8},
+	Versions: []uint{Klay66, Klay65, Klay64, Klay63, Klay62},
+	Lengths:  []uint64{24, 21, 19, 17, 8},
 }
 
 // Protocol defines the protocol of the consensus
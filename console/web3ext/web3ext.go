@@ -390,6 +390,12 @@ web3._extend({
 			call: 'admin_removePeer',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'banPeer',
+			call: 'admin_banPeer',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
 		new web3._extend.Method({
 			name: 'exportChain',
 			call: 'admin_exportChain',
@@ -451,6 +457,11 @@ web3._extend({
 			name: 'stopStateMigration',
 			call: 'admin_stopStateMigration',
 		}),
+		new web3._extend.Method({
+			name: 'startStateTrieResharding',
+			call: 'admin_startStateTrieResharding',
+			params: 1,
+		}),
 		new web3._extend.Method({
 			name: 'saveTrieNodeCacheToDisk',
 			call: 'admin_saveTrieNodeCacheToDisk',
@@ -506,6 +517,10 @@ web3._extend({
 			name: 'peers',
 			getter: 'admin_peers'
 		}),
+		new web3._extend.Property({
+			name: 'peerScores',
+			getter: 'admin_peerScores'
+		}),
 		new web3._extend.Property({
 			name: 'datadir',
 			getter: 'admin_datadir'
@@ -514,6 +529,10 @@ web3._extend({
 			name: 'stateMigrationStatus',
 			getter: 'admin_stateMigrationStatus'
 		}),
+		new web3._extend.Property({
+			name: 'stateTrieReshardingStatus',
+			getter: 'admin_stateTrieReshardingStatus'
+		}),
 		new web3._extend.Property({
 			name: 'spamThrottlerConfig',
 			getter: 'admin_spamThrottlerConfig'
@@ -824,6 +843,18 @@ web3._extend({
 			call: 'debug_storageRangeAt',
 			params: 5,
 		}),
+		new web3._extend.Method({
+			name: 'executionWitness',
+			call: 'debug_executionWitness',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'verifyExecutionWitness',
+			call: 'debug_verifyExecutionWitness',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
 		new web3._extend.Method({
 			name: 'setVMLogTarget',
 			call: 'debug_setVMLogTarget',
@@ -996,6 +1027,12 @@ web3._extend({
 			params: 2,
 			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'getProof',
+			call: 'klay_getProof',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
 		new web3._extend.Method({
 			name: 'getRawTransactionFromBlock',
 			call: function(args) {
@@ -1353,6 +1390,40 @@ web3._extend({
 			call: 'subbridge_getAnchoringTxHashByBlockNumber',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'reconcileValueTransfers',
+			call: 'subbridge_reconcileValueTransfers',
+			params: 6
+		}),
+		new web3._extend.Method({
+			name: 'getAnchoringProof',
+			call: 'subbridge_getAnchoringProof',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'approveValueTransfer',
+			call: 'subbridge_approveValueTransfer',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, web3._extend.formatters.inputEmptyFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'rejectValueTransfer',
+			call: 'subbridge_rejectValueTransfer',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, web3._extend.formatters.inputEmptyFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'pauseValueTransfers',
+			call: 'subbridge_pauseValueTransfers',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputEmptyFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'resumeValueTransfers',
+			call: 'subbridge_resumeValueTransfers',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputEmptyFormatter]
+		}),
 		new web3._extend.Method({
 			name: 'registerOperator',
 			call: 'subbridge_registerOperator',
@@ -1406,6 +1477,17 @@ web3._extend({
 			params: 3,
 			inputFormatter: [null, null, web3._extend.formatters.inputEmptyFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'registerParentChainBridge',
+			call: 'subbridge_registerParentChainBridge',
+			params: 4,
+			inputFormatter: [null, null, null, web3._extend.formatters.inputEmptyFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getParentChains',
+			call: 'subbridge_getParentChains',
+			params: 0
+		}),
 		new web3._extend.Method({
 			name: 'deregisterBridge',
 			call: 'subbridge_deregisterBridge',
@@ -1434,6 +1516,16 @@ web3._extend({
 			call: 'subbridge_getBridgeInformation',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'getMessageInformation',
+			call: 'subbridge_getMessageInformation',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getMessageStatus',
+			call: 'subbridge_getMessageStatus',
+			params: 2
+		}),
 		new web3._extend.Method({
 			name: 'getParentTransactionReceipt',
 			call: 'subbridge_getParentTransactionReceipt',
@@ -1482,6 +1574,11 @@ web3._extend({
 			call: 'subbridge_unlockParentOperator',
 			params: 2
 		}),
+		new web3._extend.Method({
+			name: 'unlockParentChainOperator',
+			call: 'subbridge_unlockParentChainOperator',
+			params: 3
+		}),
 		new web3._extend.Method({
 			name: 'unlockChildOperator',
 			call: 'subbridge_unlockChildOperator',
@@ -1586,6 +1683,14 @@ web3._extend({
 			name: 'listBridge',
 			getter: 'subbridge_listBridge'
 		}),
+		new web3._extend.Property({
+			name: 'transferLimits',
+			getter: 'subbridge_getTransferLimits'
+		}),
+		new web3._extend.Property({
+			name: 'heldValueTransfers',
+			getter: 'subbridge_getHeldValueTransfers'
+		}),
 		new web3._extend.Property({
 			name: 'txPendingCount',
 			getter: 'subbridge_txPendingCount'
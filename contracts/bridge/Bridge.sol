@@ -19,11 +19,13 @@ pragma solidity 0.5.6;
 import "./BridgeTransferKLAY.sol";
 import "./BridgeTransferERC20.sol";
 import "./BridgeTransferERC721.sol";
+import "./BridgeTransferERC1155.sol";
+import "./BridgeMessage.sol";
 import "./BridgeCounterPart.sol";
 
 
-contract Bridge is BridgeCounterPart, BridgeTransferKLAY, BridgeTransferERC20, BridgeTransferERC721 {
-    uint64 public constant VERSION = 1;
+contract Bridge is BridgeCounterPart, BridgeTransferKLAY, BridgeTransferERC20, BridgeTransferERC721, BridgeTransferERC1155, BridgeMessage {
+    uint64 public constant VERSION = 3;
 
     constructor(bool _modeMintBurn) BridgeTransfer(_modeMintBurn) public payable {
     }
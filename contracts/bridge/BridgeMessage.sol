@@ -0,0 +1,154 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+pragma solidity 0.5.6;
+
+import "./BridgeTokens.sol";
+import "./BridgeTransfer.sol";
+
+
+contract BridgeMessage is BridgeTokens, BridgeTransfer {
+    // MESSAGE_GAS_LIMIT is the gas forwarded to the target of a message. A message
+    // running out of it fails alone, without reverting the vote that executes it.
+    uint256 constant MESSAGE_GAS_LIMIT = 1000000;
+    // MESSAGE_GAS_RESERVE is the gas kept to finish handleMessage after a call.
+    uint256 constant MESSAGE_GAS_RESERVE = 50000;
+
+    struct Message {
+        bytes32 requestTxHash;
+        address from;
+        address target;
+        uint64 requestedBlockNumber;
+        bytes data;
+    }
+
+    uint64 public requestMessageNonce; // the number of messages that this contract sent.
+    uint64 public handleMessageNonce; // the nonce of the next message of the counterpart bridge to execute.
+    uint64 public messageRecoveryBlockNumber = 1; // the block number that message recovery start to filter log from.
+    mapping(bytes32 => bool) public handledMessageTx; // <request tx hash, bool>
+    mapping(uint64 => Message) private queuedMessages; // <message nonce, message> voted, waiting for the previous messages.
+
+    // messageSender is the sender of the message being executed on the counterpart chain,
+    // so that the target can check it. It is zero out of a message execution.
+    address public messageSender;
+
+    /**
+     * Event to log the request of a message to the counterpart chain.
+     * @param from is the sender of the message.
+     * @param target is the contract to call on the counterpart chain.
+     * @param messageNonce is the order number of the message.
+     * @param data is the calldata of the call.
+     */
+    event RequestMessage(
+        address indexed from,
+        address indexed target,
+        uint64 messageNonce,
+        bytes data
+    );
+
+    /**
+     * Event to log the execution of a message from the counterpart chain.
+     * @param requestTxHash is a transaction hash of the message request.
+     * @param from is the sender of the message.
+     * @param target is the called contract.
+     * @param messageNonce is the order number of the message.
+     * @param success is whether the call succeeded.
+     */
+    event HandleMessage(
+        bytes32 requestTxHash,
+        address indexed from,
+        address indexed target,
+        uint64 messageNonce,
+        bool success
+    );
+
+    // requestMessage requests to call _target with _data on the counterpart chain.
+    function requestMessage(address _target, bytes calldata _data)
+        external
+    {
+        require(isRunning, "stopped bridge");
+        require(_target != address(0), "zero target");
+
+        emit RequestMessage(msg.sender, _target, requestMessageNonce, _data);
+        requestMessageNonce++;
+    }
+
+    // handleMessage votes for the message of the counterpart bridge. Once voted,
+    // the messages are executed in the order of their nonce.
+    function handleMessage(
+        bytes32 _requestTxHash,
+        address _from,
+        address _target,
+        uint64 _messageNonce,
+        uint64 _requestedBlockNumber,
+        bytes memory _data
+    )
+        public
+        onlyOperators
+    {
+        require(handleMessageNonce <= _messageNonce, "handled message");
+        require(!handledMessageTx[_requestTxHash], "handled request tx");
+        require(_target != address(0), "zero target");
+
+        if (!_voteMessage(_messageNonce)) {
+            return;
+        }
+
+        queuedMessages[_messageNonce] = Message(_requestTxHash, _from, _target, _requestedBlockNumber, _data);
+
+        while (queuedMessages[handleMessageNonce].target != address(0)) {
+            _executeMessage(handleMessageNonce);
+            handleMessageNonce++;
+        }
+    }
+
+    // _executeMessage calls the target of the queued message. A failed call does not
+    // revert, so that it does not block the next messages.
+    //
+    // The bridge itself and the registered tokens are never called, as a message
+    // would otherwise act with the roles and the funds of the bridge. The call
+    // gets at most MESSAGE_GAS_LIMIT, and its return data is not copied, so that
+    // it cannot use up the gas needed to finish the vote.
+    function _executeMessage(uint64 _messageNonce) private {
+        Message memory message = queuedMessages[_messageNonce];
+        delete queuedMessages[_messageNonce];
+
+        handledMessageTx[message.requestTxHash] = true;
+        messageRecoveryBlockNumber = message.requestedBlockNumber;
+
+        bool success = false;
+        if (_isMessageTarget(message.target)) {
+            require(gasleft() >= MESSAGE_GAS_LIMIT + MESSAGE_GAS_RESERVE, "insufficient gas for message");
+
+            address target = message.target;
+            bytes memory data = message.data;
+            uint256 gasLimit = MESSAGE_GAS_LIMIT;
+
+            messageSender = message.from;
+            assembly {
+                success := call(gasLimit, target, 0, add(data, 0x20), mload(data), 0, 0)
+            }
+            messageSender = address(0);
+        }
+
+        emit HandleMessage(message.requestTxHash, message.from, message.target, _messageNonce, success);
+    }
+
+    // _isMessageTarget returns whether a message may call _target.
+    function _isMessageTarget(address _target) private view returns(bool) {
+        return _target != address(this) && registeredTokens[_target] == address(0);
+    }
+}
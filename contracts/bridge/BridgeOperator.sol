@@ -33,6 +33,7 @@ contract BridgeOperator is Ownable {
 
     mapping(uint8 => mapping (uint64 => VotesData)) private votes; // <voteType, <nonce, VotesData>
     mapping(uint64 => bool) public closedValueTransferVotes; // <nonce, bool>
+    mapping(uint64 => bool) public closedMessageVotes; // <message nonce, bool>
 
     uint64 public constant MAX_OPERATOR = 12;
     mapping(address => bool) public operators;
@@ -44,6 +45,7 @@ contract BridgeOperator is Ownable {
     enum VoteType {
         ValueTransfer,
         Configuration,
+        Message,
         Max
     }
 
@@ -127,6 +129,22 @@ contract BridgeOperator is Ownable {
         return false;
     }
 
+    // _voteMessage votes message transaction with the operator.
+    function _voteMessage(uint64 _messageNonce)
+        internal
+        returns(bool)
+    {
+        require(!closedMessageVotes[_messageNonce], "closed vote");
+
+        bytes32 voteKey = keccak256(msg.data);
+        if (_voteCommon(VoteType.Message, _messageNonce, voteKey)) {
+            closedMessageVotes[_messageNonce] = true;
+            return true;
+        }
+
+        return false;
+    }
+
     // _voteConfiguration votes contract configuration transaction with the operator.
     function _voteConfiguration(uint64 _requestNonce)
         internal
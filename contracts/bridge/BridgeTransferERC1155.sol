@@ -0,0 +1,154 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+pragma solidity 0.5.6;
+
+import "../sc_erc1155/IERC1155.sol";
+import "../sc_erc1155/IERC1155Receiver.sol";
+import "../sc_erc1155/ERC1155MintableBurnable.sol";
+
+import "../sc_erc1155/IERC1155BridgeReceiver.sol";
+import "./BridgeTransfer.sol";
+
+
+contract BridgeTransferERC1155 is BridgeTokens, IERC1155BridgeReceiver, IERC1155Receiver, BridgeTransfer {
+    // handleERC1155Transfer sends the ERC1155 tokens by the request.
+    function handleERC1155Transfer(
+        bytes32 _requestTxHash,
+        address _from,
+        address _to,
+        address _tokenAddress,
+        uint256 _tokenId,
+        uint256 _amount,
+        uint64 _requestedNonce,
+        uint64 _requestedBlockNumber,
+        string memory _tokenURI,
+        bytes memory _extraData
+    )
+        public
+        onlyOperators
+    {
+        _lowerHandleNonceCheck(_requestedNonce);
+
+        if (!_voteValueTransfer(_requestedNonce)) {
+            return;
+        }
+
+        _setHandledRequestTxHash(_requestTxHash);
+
+        handleNoncesToBlockNums[_requestedNonce] = _requestedBlockNumber;
+        _updateHandleNonce(_requestedNonce);
+
+        emit HandleValueTransfer(
+            _requestTxHash,
+            TokenType.ERC1155,
+            _from,
+            _to,
+            _tokenAddress,
+            _tokenId,
+            _requestedNonce,
+            lowerHandleNonce,
+            _extraData
+        );
+
+        if (modeMintBurn) {
+            require(ERC1155Mintable(_tokenAddress).mintWithURI(_to, _tokenId, _amount, _tokenURI), "mint failed");
+        } else {
+            IERC1155(_tokenAddress).safeTransferFrom(address(this), _to, _tokenId, _amount, "");
+        }
+    }
+
+    // _requestERC1155Transfer requests transfer ERC1155 to _to on relative chain.
+    function _requestERC1155Transfer(
+        address _tokenAddress,
+        address _from,
+        address _to,
+        uint256 _tokenId,
+        uint256 _amount,
+        bytes memory _extraData
+    )
+        internal
+        onlyRegisteredToken(_tokenAddress)
+        onlyUnlockedToken(_tokenAddress)
+    {
+        require(isRunning, "stopped bridge");
+        require(_amount > 0, "zero amount");
+        (bool success, bytes memory uri) = _tokenAddress.call(abi.encodePacked(ERC1155(_tokenAddress).uri.selector, abi.encode(_tokenId)));
+        if (success == false) {
+            uri = "";
+        }
+        if (modeMintBurn) {
+            ERC1155Burnable(_tokenAddress).burn(_tokenId, _amount);
+        }
+        emit RequestValueTransferEncoded(
+            TokenType.ERC1155,
+            _from,
+            _to,
+            _tokenAddress,
+            _tokenId,
+            requestNonce,
+            0,
+            _extraData,
+            3,
+            abi.encode(_amount, string(uri))
+        );
+        requestNonce++;
+    }
+
+    // onERC1155BridgeReceived function of ERC1155 token for 1-step deposits to the Bridge
+    function onERC1155BridgeReceived(
+        address _from,
+        uint256 _tokenId,
+        uint256 _amount,
+        address _to,
+        bytes memory _extraData
+    )
+        public
+    {
+        _requestERC1155Transfer(msg.sender, _from, _to, _tokenId, _amount, _extraData);
+    }
+
+    // requestERC1155Transfer requests transfer ERC1155 to _to on relative chain.
+    function requestERC1155Transfer(
+        address _tokenAddress,
+        address _to,
+        uint256 _tokenId,
+        uint256 _amount,
+        bytes memory _extraData
+    )
+        public
+    {
+        IERC1155(_tokenAddress).safeTransferFrom(msg.sender, address(this), _tokenId, _amount, "");
+        _requestERC1155Transfer(_tokenAddress, msg.sender, _to, _tokenId, _amount, _extraData);
+    }
+
+    // onERC1155Received accepts only the tokens the bridge transfers to itself in requestERC1155Transfer.
+    function onERC1155Received(address _operator, address, uint256, uint256, bytes calldata)
+        external
+        returns (bytes4)
+    {
+        require(_operator == address(this), "use requestERC1155Transfer");
+        return _ERC1155_RECEIVED;
+    }
+
+    // onERC1155BatchReceived refuses batch transfers, which are requested one token ID at a time.
+    function onERC1155BatchReceived(address, address, uint256[] calldata, uint256[] calldata, bytes calldata)
+        external
+        returns (bytes4)
+    {
+        revert("use requestERC1155Transfer");
+    }
+}
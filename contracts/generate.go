@@ -26,6 +26,8 @@ package contracts
 
 //go:generate abigen --sol ./sc_erc20/sc_token.sol --pkg sctoken --out ./sc_erc20/sc_token.go
 
+//go:generate abigen --sol ./sc_erc1155/sc_multitoken.sol --pkg scmultitoken --out ./sc_erc1155/sc_multitoken.go
+
 //go:generate abigen --sol ./kip13/InterfaceIdentifier.sol --pkg kip13 --out ./kip13/InterfaceIdentifier.go
 
 //go:generate abigen --sol ./kip103/TreasuryRebalance.sol --pkg kip103 --out ./kip103/TreasuryRebalance.go
@@ -0,0 +1,169 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+pragma solidity 0.5.6;
+
+import "../externals/openzeppelin-solidity/contracts/introspection/ERC165.sol";
+import "../externals/openzeppelin-solidity/contracts/math/SafeMath.sol";
+import "../externals/openzeppelin-solidity/contracts/utils/Address.sol";
+
+import "./IERC1155.sol";
+import "./IERC1155Receiver.sol";
+
+
+/**
+ * @title ERC1155
+ * @dev Basic implementation of the ERC1155 multi-token standard, with a URI per token ID.
+ */
+contract ERC1155 is ERC165, IERC1155 {
+    using SafeMath for uint256;
+    using Address for address;
+
+    // bytes4(keccak256("onERC1155Received(address,address,uint256,uint256,bytes)"))
+    bytes4 private constant _ERC1155_RECEIVED = 0xf23a6e61;
+    // bytes4(keccak256("onERC1155BatchReceived(address,address,uint256[],uint256[],bytes)"))
+    bytes4 private constant _ERC1155_BATCH_RECEIVED = 0xbc197c81;
+
+    /*
+     *     bytes4(keccak256('balanceOf(address,uint256)')) == 0x00fdd58e
+     *     bytes4(keccak256('balanceOfBatch(address[],uint256[])')) == 0x4e1273f4
+     *     bytes4(keccak256('setApprovalForAll(address,bool)')) == 0xa22cb465
+     *     bytes4(keccak256('isApprovedForAll(address,address)')) == 0xe985e9c5
+     *     bytes4(keccak256('safeTransferFrom(address,address,uint256,uint256,bytes)')) == 0xf242432a
+     *     bytes4(keccak256('safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)')) == 0x2eb2c2d6
+     *
+     *     => 0x00fdd58e ^ 0x4e1273f4 ^ 0xa22cb465 ^
+     *        0xe985e9c5 ^ 0xf242432a ^ 0x2eb2c2d6 == 0xd9b67a26
+     */
+    bytes4 private constant _INTERFACE_ID_ERC1155 = 0xd9b67a26;
+
+    /*
+     *     bytes4(keccak256('uri(uint256)')) == 0x0e89341c
+     */
+    bytes4 private constant _INTERFACE_ID_ERC1155_METADATA_URI = 0x0e89341c;
+
+    // Mapping from token ID to owner balances
+    mapping(uint256 => mapping(address => uint256)) private _balances;
+
+    // Mapping from owner to operator approvals
+    mapping(address => mapping(address => bool)) private _operatorApprovals;
+
+    // Mapping from token ID to its URI
+    mapping(uint256 => string) private _tokenURIs;
+
+    constructor () public {
+        _registerInterface(_INTERFACE_ID_ERC1155);
+        _registerInterface(_INTERFACE_ID_ERC1155_METADATA_URI);
+    }
+
+    function uri(uint256 id) external view returns (string memory) {
+        return _tokenURIs[id];
+    }
+
+    function balanceOf(address owner, uint256 id) public view returns (uint256) {
+        require(owner != address(0), "ERC1155: balance query for the zero address");
+        return _balances[id][owner];
+    }
+
+    function balanceOfBatch(address[] memory owners, uint256[] memory ids) public view returns (uint256[] memory) {
+        require(owners.length == ids.length, "ERC1155: owners and ids length mismatch");
+
+        uint256[] memory balances = new uint256[](owners.length);
+        for (uint256 i = 0; i < owners.length; ++i) {
+            balances[i] = balanceOf(owners[i], ids[i]);
+        }
+        return balances;
+    }
+
+    function setApprovalForAll(address operator, bool approved) public {
+        require(operator != msg.sender, "ERC1155: approve to caller");
+
+        _operatorApprovals[msg.sender][operator] = approved;
+        emit ApprovalForAll(msg.sender, operator, approved);
+    }
+
+    function isApprovedForAll(address owner, address operator) public view returns (bool) {
+        return _operatorApprovals[owner][operator];
+    }
+
+    function safeTransferFrom(address from, address to, uint256 id, uint256 value, bytes memory data) public {
+        require(from == msg.sender || isApprovedForAll(from, msg.sender), "ERC1155: caller is not owner nor approved");
+
+        _transferFrom(from, to, id, value);
+        require(_checkOnERC1155Received(msg.sender, from, to, id, value, data), "ERC1155: transfer to non ERC1155Receiver implementer");
+    }
+
+    function safeBatchTransferFrom(address from, address to, uint256[] memory ids, uint256[] memory values, bytes memory data) public {
+        require(ids.length == values.length, "ERC1155: ids and values length mismatch");
+        require(from == msg.sender || isApprovedForAll(from, msg.sender), "ERC1155: caller is not owner nor approved");
+        require(to != address(0), "ERC1155: transfer to the zero address");
+
+        for (uint256 i = 0; i < ids.length; ++i) {
+            _balances[ids[i]][from] = _balances[ids[i]][from].sub(values[i]);
+            _balances[ids[i]][to] = _balances[ids[i]][to].add(values[i]);
+        }
+        emit TransferBatch(msg.sender, from, to, ids, values);
+
+        require(_checkOnERC1155BatchReceived(msg.sender, from, to, ids, values, data), "ERC1155: transfer to non ERC1155Receiver implementer");
+    }
+
+    /**
+     * @dev Internal function to transfer tokens without calling the receiver hook.
+     * Derived contracts should use it only when the receiver is known to accept the tokens.
+     */
+    function _transferFrom(address from, address to, uint256 id, uint256 value) internal {
+        require(to != address(0), "ERC1155: transfer to the zero address");
+
+        _balances[id][from] = _balances[id][from].sub(value);
+        _balances[id][to] = _balances[id][to].add(value);
+        emit TransferSingle(msg.sender, from, to, id, value);
+    }
+
+    function _mint(address to, uint256 id, uint256 value) internal {
+        require(to != address(0), "ERC1155: mint to the zero address");
+
+        _balances[id][to] = _balances[id][to].add(value);
+        emit TransferSingle(msg.sender, address(0), to, id, value);
+    }
+
+    function _burn(address owner, uint256 id, uint256 value) internal {
+        _balances[id][owner] = _balances[id][owner].sub(value);
+        emit TransferSingle(msg.sender, owner, address(0), id, value);
+    }
+
+    function _setURI(uint256 id, string memory tokenURI) internal {
+        _tokenURIs[id] = tokenURI;
+        emit URI(tokenURI, id);
+    }
+
+    function _checkOnERC1155Received(address operator, address from, address to, uint256 id, uint256 value, bytes memory data)
+        internal returns (bool)
+    {
+        if (!to.isContract()) {
+            return true;
+        }
+        return IERC1155Receiver(to).onERC1155Received(operator, from, id, value, data) == _ERC1155_RECEIVED;
+    }
+
+    function _checkOnERC1155BatchReceived(address operator, address from, address to, uint256[] memory ids, uint256[] memory values, bytes memory data)
+        internal returns (bool)
+    {
+        if (!to.isContract()) {
+            return true;
+        }
+        return IERC1155Receiver(to).onERC1155BatchReceived(operator, from, ids, values, data) == _ERC1155_BATCH_RECEIVED;
+    }
+}
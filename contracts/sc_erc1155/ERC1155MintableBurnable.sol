@@ -0,0 +1,46 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+pragma solidity 0.5.6;
+
+import "../externals/openzeppelin-solidity/contracts/access/roles/MinterRole.sol";
+import "./ERC1155.sol";
+
+
+/**
+ * @title ERC1155Mintable
+ * @dev ERC1155 minting logic, with the URI of the minted tokens.
+ */
+contract ERC1155Mintable is ERC1155, MinterRole {
+    function mintWithURI(address to, uint256 id, uint256 value, string memory tokenURI) public onlyMinter returns (bool) {
+        _mint(to, id, value);
+        if (bytes(tokenURI).length > 0) {
+            _setURI(id, tokenURI);
+        }
+        return true;
+    }
+}
+
+
+/**
+ * @title ERC1155Burnable
+ * @dev ERC1155 tokens that can be burned by their owner.
+ */
+contract ERC1155Burnable is ERC1155 {
+    function burn(uint256 id, uint256 value) public {
+        _burn(msg.sender, id, value);
+    }
+}
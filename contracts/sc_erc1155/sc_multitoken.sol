@@ -0,0 +1,38 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+pragma solidity 0.5.6;
+
+import "./ERC1155.sol";
+import "./ERC1155MintableBurnable.sol";
+import "./ERC1155ServiceChain.sol";
+
+
+contract ServiceChainMultiToken is ERC1155, ERC1155Burnable, ERC1155Mintable, ERC1155ServiceChain {
+    string public constant NAME = "ServiceChainMultiToken";
+    string public constant SYMBOL = "SCMT";
+
+    constructor(address _bridge) ERC1155ServiceChain(_bridge) public {
+    }
+
+    // registerBulk mints _amount of (startID, endID-1) tokens to the user once.
+    // This is only for load test.
+    function registerBulk(address _user, uint256 _startID, uint256 _endID, uint256 _amount) external onlyOwner {
+        for (uint256 uid = _startID; uid < _endID; uid++) {
+            mintWithURI(_user, uid, _amount, "testURI");
+        }
+    }
+}
@@ -48,10 +48,16 @@ func (api *PublicChainDataFetcherAPI) StartRangeFetching(start, end uint64, reqT
 		t = types.RequestTypeBlockGroup
 	case "trace":
 		t = types.RequestTypeTraceGroup
+	case "tokentransfer":
+		t = types.RequestTypeTokenTransferGroup
+	case "contract":
+		t = types.RequestTypeContractGroup
+	case "decoded":
+		t = types.RequestTypeDecodedGroupAll
 	default:
 		ut, ok := reqType.(float64)
 		if !ok {
-			return errors.New("the request type should be 'all', 'block', 'trace', or uint type")
+			return errors.New("the request type should be 'all', 'block', 'trace', 'tokentransfer', 'contract', 'decoded', or uint type")
 		}
 		t = types.RequestType(ut)
 	}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -29,6 +30,7 @@ import (
 	"github.com/klaytn/klaytn/blockchain/vm"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/datasync/chaindatafetcher/file"
 	"github.com/klaytn/klaytn/datasync/chaindatafetcher/kafka"
 	"github.com/klaytn/klaytn/datasync/chaindatafetcher/kas"
 	cfTypes "github.com/klaytn/klaytn/datasync/chaindatafetcher/types"
@@ -115,6 +117,11 @@ func NewChainDataFetcher(ctx *node.ServiceContext, cfg *ChainDataFetcherConfig)
 		if err != nil {
 			return nil, err
 		}
+	case ModeFile:
+		repo, checkpointDB, setters, err = getFileComponents(cfg.FileConfig)
+		if err != nil {
+			return nil, err
+		}
 	default:
 		logger.Error("the chaindatafetcher mode is not supported", "mode", cfg.Mode)
 		return nil, errUnsupportedMode
@@ -151,6 +158,23 @@ func getKafkaComponents(cfg *kafka.KafkaConfig) (Repository, CheckpointDB, []Com
 	return repo, checkpointDB, []ComponentSetter{repo, checkpointDB}, nil
 }
 
+func getFileComponents(cfg *file.FileConfig) (Repository, CheckpointDB, []ComponentSetter, error) {
+	repo, err := file.NewRepository(cfg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return getSinkComponents(repo)
+}
+
+// getSinkComponents returns the components of a sink which works as both the repository and the checkpoint database.
+func getSinkComponents(sink Sink) (Repository, CheckpointDB, []ComponentSetter, error) {
+	var setters []ComponentSetter
+	if setter, ok := sink.(ComponentSetter); ok {
+		setters = append(setters, setter)
+	}
+	return sink, sink, setters, nil
+}
+
 func (f *ChainDataFetcher) Protocols() []p2p.Protocol {
 	return []p2p.Protocol{}
 }
@@ -188,6 +212,9 @@ func (f *ChainDataFetcher) Stop() error {
 	logger.Info("wait for all goroutines to be terminated...", "numGoroutines", f.config.NumHandlers)
 	close(f.stopCh)
 	f.wg.Wait()
+	if closer, ok := f.repo.(io.Closer); ok {
+		closer.Close()
+	}
 	logger.Info("chaindata fetcher is stopped")
 	return nil
 }
@@ -242,8 +269,8 @@ func (f *ChainDataFetcher) startFetching() error {
 		switch f.config.Mode {
 		case ModeKAS:
 			f.sendRequests(uint64(f.checkpoint), currentBlock, cfTypes.RequestTypeAll, true, f.fetchingStopCh)
-		case ModeKafka:
-			f.sendRequests(uint64(f.checkpoint), currentBlock, cfTypes.RequestTypeGroupAll, true, f.fetchingStopCh)
+		case ModeKafka, ModeFile:
+			f.sendRequests(uint64(f.checkpoint), currentBlock, f.groupRequestType(), true, f.fetchingStopCh)
 		default:
 			logger.Error("the chaindatafetcher mode is not supported", "mode", f.config.Mode, "checkpoint", f.checkpoint, "currentBlock", currentBlock)
 		}
@@ -252,6 +279,15 @@ func (f *ChainDataFetcher) startFetching() error {
 	return nil
 }
 
+// groupRequestType returns the request type handled by default in the kafka and file modes.
+// The decoded data is included only if it is enabled in the kafka mode.
+func (f *ChainDataFetcher) groupRequestType() cfTypes.RequestType {
+	if f.config.Mode == ModeKafka && f.config.KafkaConfig != nil && f.config.KafkaConfig.PublishDecoded {
+		return cfTypes.RequestTypeGroupAll | cfTypes.RequestTypeDecodedGroupAll
+	}
+	return cfTypes.RequestTypeGroupAll
+}
+
 func (f *ChainDataFetcher) stopFetching() error {
 	if !atomic.CompareAndSwapUint32(&f.fetchingStarted, running, stopped) {
 		return errors.New("fetching is not running")
@@ -394,6 +430,8 @@ func (f *ChainDataFetcher) handleRequestByType(reqType cfTypes.RequestType, shou
 	// - RequestTypeTrace
 	// - RequestTypeBlockGroup
 	// - RequestTypeTraceGroup
+	// - RequestTypeTokenTransferGroup
+	// - RequestTypeContractGroup
 	for targetType := cfTypes.RequestTypeTransaction; targetType < cfTypes.RequestTypeLength; targetType = targetType << 1 {
 		if cfTypes.CheckRequestType(reqType, targetType) {
 			if err := f.updateInsertionTimeGauge(f.retryFunc(f.repo.HandleChainEvent))(ev, targetType); err != nil {
@@ -459,8 +497,8 @@ func (f *ChainDataFetcher) handleRequest() {
 			switch f.config.Mode {
 			case ModeKAS:
 				err = f.handleRequestByType(cfTypes.RequestTypeAll, true, ev)
-			case ModeKafka:
-				err = f.handleRequestByType(cfTypes.RequestTypeGroupAll, true, ev)
+			case ModeKafka, ModeFile:
+				err = f.handleRequestByType(f.groupRequestType(), true, ev)
 			default:
 				logger.Error("the chaindatafetcher mode is not supported", "mode", f.config.Mode, "blockNumber", ev.Block.NumberU64())
 			}
@@ -527,6 +565,10 @@ func getInsertionTimeGauge(reqType cfTypes.RequestType) metrics.Gauge {
 		return blockGroupInsertionTimeGauge
 	case cfTypes.RequestTypeTraceGroup:
 		return traceGroupInsertionTimeGauge
+	case cfTypes.RequestTypeTokenTransferGroup:
+		return tokenTransferGroupInsertionTimeGauge
+	case cfTypes.RequestTypeContractGroup:
+		return contractGroupInsertionTimeGauge
 	default:
 		logger.Warn("the request type is not supported", "type", reqType)
 		return metrics.NilGauge{}
@@ -560,6 +602,10 @@ func getInsertionRetryGauge(reqType cfTypes.RequestType) metrics.Gauge {
 		return blockGroupInsertionRetryGauge
 	case cfTypes.RequestTypeTraceGroup:
 		return traceGroupInsertionRetryGauge
+	case cfTypes.RequestTypeTokenTransferGroup:
+		return tokenTransferGroupInsertionRetryGauge
+	case cfTypes.RequestTypeContractGroup:
+		return contractGroupInsertionRetryGauge
 	default:
 		logger.Warn("the request type is not supported", "type", reqType)
 		return metrics.NilGauge{}
@@ -19,6 +19,7 @@ package chaindatafetcher
 import (
 	"time"
 
+	"github.com/klaytn/klaytn/datasync/chaindatafetcher/file"
 	"github.com/klaytn/klaytn/datasync/chaindatafetcher/kafka"
 	"github.com/klaytn/klaytn/datasync/chaindatafetcher/kas"
 )
@@ -28,6 +29,7 @@ type ChainDataFetcherMode int
 const (
 	ModeKAS = ChainDataFetcherMode(iota)
 	ModeKafka
+	ModeFile
 )
 
 const (
@@ -51,6 +53,7 @@ type ChainDataFetcherConfig struct {
 
 	KasConfig   *kas.KASConfig     `json:"-"` // Deprecated: This configuration is not used anymore.
 	KafkaConfig *kafka.KafkaConfig `toml:",omitempty"`
+	FileConfig  *file.FileConfig   `toml:",omitempty"`
 }
 
 func DefaultChainDataFetcherConfig() *ChainDataFetcherConfig {
@@ -65,5 +68,6 @@ func DefaultChainDataFetcherConfig() *ChainDataFetcherConfig {
 
 		KasConfig:   kas.DefaultKASConfig,
 		KafkaConfig: kafka.GetDefaultKafkaConfig(),
+		FileConfig:  file.GetDefaultFileConfig(),
 	}
 }
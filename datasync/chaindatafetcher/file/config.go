@@ -0,0 +1,38 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package file
+
+import "fmt"
+
+const (
+	DefaultMaxFileSize = 64 // in MB
+)
+
+type FileConfig struct {
+	Dir         string // Dir is the directory where the exported files are written.
+	MaxFileSize int    // MaxFileSize is the size (in MB) of an active file which makes it sealed at the next checkpoint.
+}
+
+func GetDefaultFileConfig() *FileConfig {
+	return &FileConfig{
+		MaxFileSize: DefaultMaxFileSize,
+	}
+}
+
+func (c *FileConfig) String() string {
+	return fmt.Sprintf("Dir: %v, MaxFileSize: %vMB", c.Dir, c.MaxFileSize)
+}
@@ -0,0 +1,38 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+Package file implements a chaindatafetcher sink which exports chaindata to local files in NDJSON format.
+
+Each kind of data (blockgroup, tracegroup) is written to its own series of segment files in the configured directory.
+A line of a file has the same JSON format as the message published to kafka, i.e. {"blockNumber":...,"result":...}.
+The lines are not sorted by block number because the blocks are handled concurrently.
+
+  - <name>-<seq>.ndjson.partial : the active segment which is being appended
+  - <name>-<seq>.ndjson.gz      : a sealed segment which is not changed anymore
+  - state.json                  : the checkpoint and the sequence number of each active segment
+
+The state is committed atomically at every checkpoint, and an active segment larger than the max file size
+is sealed at that time. On restart, the files written after the last committed state are cleaned up,
+so that every block before the checkpoint is exported exactly once.
+
+Source Files
+  - config.go     : includes file sink configurations
+  - repository.go : implements the repository and the checkpoint database writing chaindata to files
+  - stream.go     : implements segment files of a stream and their recovery
+*/
+
+package file
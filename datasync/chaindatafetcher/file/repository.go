@@ -0,0 +1,224 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/datasync/chaindatafetcher/kafka"
+	"github.com/klaytn/klaytn/datasync/chaindatafetcher/types"
+	"github.com/klaytn/klaytn/log"
+)
+
+const stateFileName = "state.json"
+
+var logger = log.NewModuleLogger(log.ChainDataFetcher)
+
+type traceGroupResult struct {
+	BlockNumber      *big.Int              `json:"blockNumber"`
+	InternalTxTraces []*vm.InternalTxTrace `json:"result"`
+}
+
+type blockGroupResult struct {
+	BlockNumber *big.Int               `json:"blockNumber"`
+	Result      map[string]interface{} `json:"result"`
+}
+
+// state is committed atomically at every checkpoint. Segments has the sequence number
+// of the active segment of each stream.
+type state struct {
+	Checkpoint int64             `json:"checkpoint"`
+	Segments   map[string]uint64 `json:"segments"`
+}
+
+// repository exports the chain data to local NDJSON files. It works as the checkpoint
+// database as well, so that the exported files and the checkpoint are always consistent.
+type repository struct {
+	config     *FileConfig
+	blockchain *blockchain.BlockChain
+	engine     consensus.Engine
+
+	mu         sync.Mutex
+	checkpoint int64
+	streams    map[string]*stream
+}
+
+func NewRepository(config *FileConfig) (*repository, error) {
+	if config.Dir == "" {
+		return nil, fmt.Errorf("the directory of the chaindata files is not set")
+	}
+	if err := os.MkdirAll(config.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	st, err := readState(config.Dir)
+	if err != nil {
+		logger.Error("Failed to read the chaindata file state", "err", err, "dir", config.Dir)
+		return nil, err
+	}
+
+	r := &repository{
+		config:     config,
+		checkpoint: st.Checkpoint,
+		streams:    make(map[string]*stream),
+	}
+	for _, name := range []string{kafka.EventBlockGroup, kafka.EventTraceGroup} {
+		s, err := openStream(config.Dir, name, st.Segments[name], st.Checkpoint)
+		if err != nil {
+			logger.Error("Failed to open the chaindata file", "err", err, "stream", name)
+			r.Close()
+			return nil, err
+		}
+		r.streams[name] = s
+	}
+	logger.Info("The chaindata file repository is opened", "config", config, "checkpoint", st.Checkpoint)
+	return r, nil
+}
+
+func (r *repository) SetComponent(component interface{}) {
+	switch c := component.(type) {
+	case *blockchain.BlockChain:
+		r.blockchain = c
+	case consensus.Engine:
+		r.engine = c
+	}
+}
+
+func (r *repository) HandleChainEvent(event blockchain.ChainEvent, dataType types.RequestType) error {
+	switch dataType {
+	case types.RequestTypeBlockGroup:
+		cInfo, err := r.engine.GetConsensusInfo(event.Block)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve consensusinfo with the given block number: %v", event.Block.Number())
+		}
+		return r.write(kafka.EventBlockGroup, &blockGroupResult{
+			BlockNumber: event.Block.Number(),
+			Result:      kafka.MakeBlockGroupOutput(r.blockchain, event.Block, cInfo, event.Receipts),
+		})
+	case types.RequestTypeTraceGroup:
+		if len(event.InternalTxTraces) > 0 {
+			return r.write(kafka.EventTraceGroup, &traceGroupResult{
+				BlockNumber:      event.Block.Number(),
+				InternalTxTraces: event.InternalTxTraces,
+			})
+		}
+		return nil
+	default:
+		return fmt.Errorf("not supported type. [blockNumber: %v, reqType: %v]", event.Block.NumberU64(), dataType)
+	}
+}
+
+func (r *repository) write(name string, result interface{}) error {
+	record, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.streams[name].append(record)
+}
+
+func (r *repository) ReadCheckpoint() (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.checkpoint, nil
+}
+
+// WriteCheckpoint makes the written records durable and commits the checkpoint.
+// The active segments larger than the max file size are sealed together.
+func (r *repository) WriteCheckpoint(checkpoint int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	newState := &state{Checkpoint: checkpoint, Segments: make(map[string]uint64)}
+	var sealing []*stream
+	for name, s := range r.streams {
+		newState.Segments[name] = s.seq
+		if err := s.sync(); err != nil {
+			return err
+		}
+		if s.size < int64(r.config.MaxFileSize)*1024*1024 {
+			continue
+		}
+		sealed, err := s.prepareSeal(checkpoint)
+		if err != nil {
+			logger.Error("Failed to seal the chaindata file", "err", err, "stream", name, "seq", s.seq)
+			for _, prepared := range sealing {
+				prepared.abortSeal()
+			}
+			return err
+		}
+		if sealed {
+			sealing = append(sealing, s)
+			newState.Segments[name] = s.seq + 1
+		}
+	}
+
+	if err := writeState(r.config.Dir, newState); err != nil {
+		for _, prepared := range sealing {
+			prepared.abortSeal()
+		}
+		return err
+	}
+	r.checkpoint = checkpoint
+
+	for _, s := range sealing {
+		logger.Info("A chaindata file is sealed", "stream", s.name, "seq", s.seq, "checkpoint", checkpoint)
+		if err := s.commitSeal(); err != nil {
+			logger.Crit("Failed to open the next chaindata file", "err", err, "stream", s.name)
+		}
+	}
+	return nil
+}
+
+func (r *repository) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range r.streams {
+		s.close()
+	}
+	return nil
+}
+
+func readState(dir string) (*state, error) {
+	data, err := os.ReadFile(filepath.Join(dir, stateFileName))
+	if os.IsNotExist(err) {
+		return &state{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	st := &state{}
+	if err := json.Unmarshal(data, st); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+func writeState(dir string, st *state) error {
+	data, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, stateFileName), data)
+}
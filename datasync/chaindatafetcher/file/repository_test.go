@@ -0,0 +1,153 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package file
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/datasync/chaindatafetcher/kafka"
+	cfTypes "github.com/klaytn/klaytn/datasync/chaindatafetcher/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRepository(t *testing.T, dir string, maxFileSize int) *repository {
+	repo, err := NewRepository(&FileConfig{Dir: dir, MaxFileSize: maxFileSize})
+	require.NoError(t, err)
+	return repo
+}
+
+func writeTestTraces(t *testing.T, repo *repository, from, to int64) {
+	for i := from; i <= to; i++ {
+		ev := blockchain.ChainEvent{
+			Block:            types.NewBlockWithHeader(&types.Header{Number: big.NewInt(i)}),
+			InternalTxTraces: []*vm.InternalTxTrace{{Value: "0x0"}},
+		}
+		require.NoError(t, repo.HandleChainEvent(ev, cfTypes.RequestTypeTraceGroup))
+	}
+}
+
+func readBlockNumbers(t *testing.T, path string) []int64 {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	if filepath.Ext(path) == ".gz" {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		require.NoError(t, err)
+		data, err = io.ReadAll(zr)
+		require.NoError(t, err)
+	}
+
+	var numbers []int64
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for decoder.More() {
+		var record traceGroupResult
+		require.NoError(t, decoder.Decode(&record))
+		numbers = append(numbers, record.BlockNumber.Int64())
+	}
+	return numbers
+}
+
+func TestRepository_ResumeFromCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	repo := newTestRepository(t, dir, DefaultMaxFileSize)
+
+	// 1. nothing is stored
+	checkpoint, err := repo.ReadCheckpoint()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), checkpoint)
+
+	// 2. blocks 1~5 are written, but only the blocks before 4 are checkpointed
+	writeTestTraces(t, repo, 1, 3)
+	assert.NoError(t, repo.WriteCheckpoint(4))
+	writeTestTraces(t, repo, 4, 5)
+
+	// 3. reopen the repository without closing it, as if the node was crashed
+	repo = newTestRepository(t, dir, DefaultMaxFileSize)
+	defer repo.Close()
+
+	checkpoint, err = repo.ReadCheckpoint()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), checkpoint)
+
+	path := segmentPath(dir, kafka.EventTraceGroup, 0, false)
+	assert.Equal(t, []int64{1, 2, 3}, readBlockNumbers(t, path))
+
+	// 4. the blocks after the checkpoint are exported again without duplication
+	writeTestTraces(t, repo, 4, 5)
+	assert.NoError(t, repo.WriteCheckpoint(6))
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, readBlockNumbers(t, path))
+}
+
+func TestRepository_SealFiles(t *testing.T) {
+	dir := t.TempDir()
+	// every active file is sealed at the checkpoint if the max file size is 0.
+	repo := newTestRepository(t, dir, 0)
+	defer repo.Close()
+
+	writeTestTraces(t, repo, 1, 3)
+	assert.NoError(t, repo.WriteCheckpoint(3))
+
+	// the records before the checkpoint are sealed, and the others are carried over.
+	assert.Equal(t, []int64{1, 2}, readBlockNumbers(t, segmentPath(dir, kafka.EventTraceGroup, 0, true)))
+	assert.Equal(t, []int64{3}, readBlockNumbers(t, segmentPath(dir, kafka.EventTraceGroup, 1, false)))
+	_, err := os.Stat(segmentPath(dir, kafka.EventTraceGroup, 0, false))
+	assert.True(t, os.IsNotExist(err))
+
+	// nothing is sealed if there is no record before the checkpoint.
+	assert.NoError(t, repo.WriteCheckpoint(3))
+	_, err = os.Stat(segmentPath(dir, kafka.EventTraceGroup, 1, true))
+	assert.True(t, os.IsNotExist(err))
+
+	writeTestTraces(t, repo, 4, 4)
+	assert.NoError(t, repo.WriteCheckpoint(5))
+	assert.Equal(t, []int64{3, 4}, readBlockNumbers(t, segmentPath(dir, kafka.EventTraceGroup, 1, true)))
+	assert.Empty(t, readBlockNumbers(t, segmentPath(dir, kafka.EventTraceGroup, 2, false)))
+}
+
+func TestRepository_RecoverUncommittedSeal(t *testing.T) {
+	dir := t.TempDir()
+	repo := newTestRepository(t, dir, DefaultMaxFileSize)
+	writeTestTraces(t, repo, 1, 2)
+	assert.NoError(t, repo.WriteCheckpoint(3))
+	repo.Close()
+
+	// the files of a seal which was interrupted before the state is committed
+	sealed := segmentPath(dir, kafka.EventTraceGroup, 0, true)
+	next := segmentPath(dir, kafka.EventTraceGroup, 1, false)
+	assert.NoError(t, os.WriteFile(sealed, []byte{}, 0o644))
+	assert.NoError(t, os.WriteFile(next, []byte{}, 0o644))
+	assert.NoError(t, os.WriteFile(next+tmpFileSuffix, []byte{}, 0o644))
+
+	repo = newTestRepository(t, dir, DefaultMaxFileSize)
+	defer repo.Close()
+
+	for _, path := range []string{sealed, next, next + tmpFileSuffix} {
+		_, err := os.Stat(path)
+		assert.True(t, os.IsNotExist(err), path)
+	}
+	assert.Equal(t, []int64{1, 2}, readBlockNumbers(t, segmentPath(dir, kafka.EventTraceGroup, 0, false)))
+}
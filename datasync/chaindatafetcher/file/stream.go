@@ -0,0 +1,243 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	partialFileSuffix = ".ndjson.partial"
+	sealedFileSuffix  = ".ndjson.gz"
+	tmpFileSuffix     = ".tmp"
+)
+
+// stream appends the records of one data type to a series of segment files.
+// Only the segment with the sequence number of the stream is written, and it is
+// sealed into a gzip-compressed file once it becomes larger than the max file size.
+type stream struct {
+	name string
+	dir  string
+	seq  uint64
+	file *os.File
+	size int64
+}
+
+func segmentPath(dir, name string, seq uint64, sealed bool) string {
+	suffix := partialFileSuffix
+	if sealed {
+		suffix = sealedFileSuffix
+	}
+	return filepath.Join(dir, fmt.Sprintf("%s-%08d%s", name, seq, suffix))
+}
+
+// parseSegmentPath returns the sequence number of the given segment file of the stream.
+func parseSegmentPath(name, path string) (seq uint64, sealed bool, ok bool) {
+	base := filepath.Base(path)
+	if !strings.HasPrefix(base, name+"-") {
+		return 0, false, false
+	}
+	base = strings.TrimPrefix(base, name+"-")
+	switch {
+	case strings.HasSuffix(base, sealedFileSuffix):
+		base, sealed = strings.TrimSuffix(base, sealedFileSuffix), true
+	case strings.HasSuffix(base, partialFileSuffix):
+		base = strings.TrimSuffix(base, partialFileSuffix)
+	default:
+		return 0, false, false
+	}
+	seq, err := strconv.ParseUint(base, 10, 64)
+	if err != nil {
+		return 0, false, false
+	}
+	return seq, sealed, true
+}
+
+// openStream recovers the files of the stream from a previous run and opens the active segment.
+// The files which were written after the last committed state are removed, and the records of the
+// blocks which are not covered by the checkpoint are dropped from the active segment because they
+// are going to be exported again.
+func openStream(dir, name string, seq uint64, checkpoint int64) (*stream, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, name+"-*"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		if strings.HasSuffix(path, tmpFileSuffix) {
+			if err := os.Remove(path); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		fileSeq, sealed, ok := parseSegmentPath(name, path)
+		if !ok {
+			continue
+		}
+		if (sealed && fileSeq >= seq) || (!sealed && fileSeq != seq) {
+			logger.Warn("Removing an uncommitted chaindata file", "path", path)
+			if err := os.Remove(path); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	path := segmentPath(dir, name, seq, false)
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if kept, _ := splitRecords(data, checkpoint); len(kept) != len(data) {
+		logger.Info("Dropping the records after the checkpoint", "path", path, "checkpoint", checkpoint,
+			"droppedBytes", len(data)-len(kept))
+		if err := writeFileAtomic(path, kept); err != nil {
+			return nil, err
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &stream{name: name, dir: dir, seq: seq, file: file, size: info.Size()}, nil
+}
+
+func (s *stream) append(record []byte) error {
+	n, err := s.file.Write(append(record, '\n'))
+	s.size += int64(n)
+	return err
+}
+
+func (s *stream) sync() error {
+	return s.file.Sync()
+}
+
+// prepareSeal writes the sealed file of the active segment which contains the records of
+// the blocks before the checkpoint, and the next segment which carries over the rest of them.
+// It returns false if there is no record to be sealed.
+func (s *stream) prepareSeal(checkpoint int64) (bool, error) {
+	data, err := os.ReadFile(segmentPath(s.dir, s.name, s.seq, false))
+	if err != nil {
+		return false, err
+	}
+	sealed, carried := splitRecords(data, checkpoint)
+	if len(sealed) == 0 {
+		return false, nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(sealed); err != nil {
+		return false, err
+	}
+	if err := zw.Close(); err != nil {
+		return false, err
+	}
+	if err := writeFileAtomic(segmentPath(s.dir, s.name, s.seq, true), buf.Bytes()); err != nil {
+		return false, err
+	}
+	if err := writeFileAtomic(segmentPath(s.dir, s.name, s.seq+1, false), carried); err != nil {
+		s.abortSeal()
+		return false, err
+	}
+	return true, nil
+}
+
+// commitSeal switches the active segment to the next one after the new state is committed.
+func (s *stream) commitSeal() error {
+	s.file.Close()
+	if err := os.Remove(segmentPath(s.dir, s.name, s.seq, false)); err != nil {
+		logger.Warn("Failed to remove the sealed chaindata file", "err", err)
+	}
+	s.seq++
+	file, err := os.OpenFile(segmentPath(s.dir, s.name, s.seq, false), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	s.file, s.size = file, info.Size()
+	return nil
+}
+
+// abortSeal removes the files written by prepareSeal.
+func (s *stream) abortSeal() {
+	os.Remove(segmentPath(s.dir, s.name, s.seq, true))
+	os.Remove(segmentPath(s.dir, s.name, s.seq+1, false))
+}
+
+func (s *stream) close() error {
+	return s.file.Close()
+}
+
+// splitRecords splits the given records into the ones of the blocks before the checkpoint and the others.
+// A record which cannot be parsed, e.g. the last line written partially before a crash, is dropped.
+func splitRecords(data []byte, checkpoint int64) (before, after []byte) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		var record struct {
+			BlockNumber *big.Int `json:"blockNumber"`
+		}
+		if err := json.Unmarshal(line, &record); err != nil || record.BlockNumber == nil {
+			continue
+		}
+		if record.BlockNumber.Int64() < checkpoint {
+			before = append(append(before, line...), '\n')
+		} else {
+			after = append(append(after, line...), '\n')
+		}
+	}
+	return before, after
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + tmpFileSuffix
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
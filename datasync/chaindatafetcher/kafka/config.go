@@ -26,8 +26,10 @@ import (
 )
 
 const (
-	EventBlockGroup = "blockgroup"
-	EventTraceGroup = "tracegroup"
+	EventBlockGroup         = "blockgroup"
+	EventTraceGroup         = "tracegroup"
+	EventTokenTransferGroup = "tokentransfergroup"
+	EventContractGroup      = "contractgroup"
 )
 
 const (
@@ -69,6 +71,8 @@ type KafkaConfig struct {
 	// default max number of messages is 100
 	MaxMessageNumber int // MaxMessageNumber is the maximum number of consumer messages.
 
+	PublishDecoded bool // PublishDecoded publishes the decoded token transfers and contracts as well as the block and trace groups.
+
 	ExpirationTime time.Duration
 	ErrCallback    func(string) error
 	Setup          func(s sarama.ConsumerGroupSession) error
@@ -112,6 +116,6 @@ func (c *KafkaConfig) GetTopicName(event string) string {
 }
 
 func (c *KafkaConfig) String() string {
-	return fmt.Sprintf("brokers: %v, topicEnvironment: %v, topicResourceName: %v, partitions: %v, replicas: %v, maxMessageBytes: %v, requiredAcks: %v, segmentSize: %v, msgVersion: %v, producerId: %v",
-		c.Brokers, c.TopicEnvironmentName, c.TopicResourceName, c.Partitions, c.Replicas, c.SaramaConfig.Producer.MaxMessageBytes, c.SaramaConfig.Producer.RequiredAcks, c.SegmentSizeBytes, c.MsgVersion, c.ProducerId)
+	return fmt.Sprintf("brokers: %v, topicEnvironment: %v, topicResourceName: %v, partitions: %v, replicas: %v, maxMessageBytes: %v, requiredAcks: %v, segmentSize: %v, msgVersion: %v, producerId: %v, publishDecoded: %v",
+		c.Brokers, c.TopicEnvironmentName, c.TopicResourceName, c.Partitions, c.Replicas, c.SaramaConfig.Producer.MaxMessageBytes, c.SaramaConfig.Producer.RequiredAcks, c.SegmentSizeBytes, c.MsgVersion, c.ProducerId, c.PublishDecoded)
 }
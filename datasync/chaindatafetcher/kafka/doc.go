@@ -20,6 +20,7 @@ Source Files
   - checkpoint_db.go : implements checkpoint database in order to read and write chaindatafetcher checkpoint
   - config.go        : includes kafka configurations
   - kafka.go         : implements kafka structure to produce messages
+  - repository.go    : implements the repository publishing the block, trace, token transfer and contract groups
 */
 
 package kafka
@@ -87,6 +87,14 @@ func NewKafka(conf *KafkaConfig) (*Kafka, error) {
 	if err := kafka.setupTopic(traceGroupTopic); err != nil {
 		return nil, err
 	}
+
+	if conf.PublishDecoded {
+		for _, event := range []string{EventTokenTransferGroup, EventContractGroup} {
+			if err := kafka.setupTopic(conf.GetTopicName(event)); err != nil {
+				return nil, err
+			}
+		}
+	}
 	return kafka, nil
 }
 
@@ -20,10 +20,13 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/klaytn/klaytn/api"
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/vm"
 	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/datasync/chaindatafetcher/kct"
 	"github.com/klaytn/klaytn/datasync/chaindatafetcher/types"
+	"github.com/klaytn/klaytn/networks/rpc"
 )
 
 type traceGroupResult struct {
@@ -44,10 +47,29 @@ func (r *blockGroupResult) Key() string {
 	return r.BlockNumber.String()
 }
 
+type tokenTransferGroupResult struct {
+	BlockNumber    *big.Int             `json:"blockNumber"`
+	TokenTransfers []*kct.TokenTransfer `json:"result"`
+}
+
+func (r *tokenTransferGroupResult) Key() string {
+	return r.BlockNumber.String()
+}
+
+type contractGroupResult struct {
+	BlockNumber *big.Int        `json:"blockNumber"`
+	Contracts   []*kct.Contract `json:"result"`
+}
+
+func (r *contractGroupResult) Key() string {
+	return r.BlockNumber.String()
+}
+
 type repository struct {
-	blockchain *blockchain.BlockChain
-	engine     consensus.Engine
-	kafka      *Kafka
+	blockchain     *blockchain.BlockChain
+	engine         consensus.Engine
+	contractCaller *kct.ContractCaller
+	kafka          *Kafka
 }
 
 func NewRepository(config *KafkaConfig) (*repository, error) {
@@ -67,6 +89,16 @@ func (r *repository) SetComponent(component interface{}) {
 		r.blockchain = c
 	case consensus.Engine:
 		r.engine = c
+	case []rpc.API:
+		r.setBlockchainAPI(c)
+	}
+}
+
+func (r *repository) setBlockchainAPI(apis []rpc.API) {
+	for _, a := range apis {
+		if s, ok := a.Service.(*api.PublicBlockChainAPI); ok {
+			r.contractCaller = kct.NewContractCaller(s)
+		}
 	}
 }
 
@@ -79,7 +111,7 @@ func (r *repository) HandleChainEvent(event blockchain.ChainEvent, dataType type
 		}
 		result := &blockGroupResult{
 			BlockNumber: event.Block.Number(),
-			Result:      makeBlockGroupOutput(r.blockchain, event.Block, cInfo, event.Receipts),
+			Result:      MakeBlockGroupOutput(r.blockchain, event.Block, cInfo, event.Receipts),
 		}
 		return r.kafka.Publish(r.kafka.getTopicName(EventBlockGroup), result)
 	case types.RequestTypeTraceGroup:
@@ -91,6 +123,31 @@ func (r *repository) HandleChainEvent(event blockchain.ChainEvent, dataType type
 			return r.kafka.Publish(r.kafka.getTopicName(EventTraceGroup), result)
 		}
 		return nil
+	case types.RequestTypeTokenTransferGroup:
+		if transfers := kct.DecodeTokenTransfers(event); len(transfers) > 0 {
+			result := &tokenTransferGroupResult{
+				BlockNumber:    event.Block.Number(),
+				TokenTransfers: transfers,
+			}
+			return r.kafka.Publish(r.kafka.getTopicName(EventTokenTransferGroup), result)
+		}
+		return nil
+	case types.RequestTypeContractGroup:
+		if r.contractCaller == nil {
+			return fmt.Errorf("the blockchain api is not set. [blockNumber: %v]", event.Block.NumberU64())
+		}
+		contracts, err := kct.DecodeContracts(r.contractCaller, event)
+		if err != nil {
+			return err
+		}
+		if len(contracts) > 0 {
+			result := &contractGroupResult{
+				BlockNumber: event.Block.Number(),
+				Contracts:   contracts,
+			}
+			return r.kafka.Publish(r.kafka.getTopicName(EventContractGroup), result)
+		}
+		return nil
 	default:
 		return fmt.Errorf("not supported type. [blockNumber: %v, reqType: %v]", event.Block.NumberU64(), dataType)
 	}
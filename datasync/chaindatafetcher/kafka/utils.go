@@ -62,7 +62,7 @@ func sigHash(header *types.Header) (hash common.Hash, err error) {
 	return hash, nil
 }
 
-func makeBlockGroupOutput(blockchain *blockchain.BlockChain, block *types.Block, cInfo consensus.ConsensusInfo, receipts types.Receipts) map[string]interface{} {
+func MakeBlockGroupOutput(blockchain *blockchain.BlockChain, block *types.Block, cInfo consensus.ConsensusInfo, receipts types.Receipts) map[string]interface{} {
 	head := block.Header() // copies the header once
 	hash := head.Hash()
 
@@ -0,0 +1,193 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package kct
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/klaytn/klaytn"
+	"github.com/klaytn/klaytn/accounts/abi/bind"
+	"github.com/klaytn/klaytn/api"
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/contracts/kip13"
+	"github.com/klaytn/klaytn/networks/rpc"
+)
+
+const callTimeout = 300 * time.Millisecond
+
+var (
+	// KIP 13: Interface Query Standard - https://kips.klaytn.com/KIPs/kip-13
+	IKIP13Id  = [4]byte{0x01, 0xff, 0xc9, 0xa7}
+	InvalidId = [4]byte{0xff, 0xff, 0xff, 0xff}
+
+	// KIP 7: Fungible Token Standard - https://kips.klaytn.com/KIPs/kip-7
+	IKIP7Id = [4]byte{0x65, 0x78, 0x73, 0x71}
+
+	// KIP 17: Non-fungible Token Standard - https://kips.klaytn.com/KIPs/kip-17 (same as ERC-721)
+	IKIP17Id = [4]byte{0x80, 0xac, 0x58, 0xcd}
+
+	// KIP 37: Multi Token Standard - https://kips.klaytn.com/KIPs/kip-37
+	IKIP37Id   = [4]byte{0x64, 0x33, 0xca, 0x1f}
+	IERC1155Id = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+
+	errMsgEmptyOutput = "abi: unmarshalling empty output"
+)
+
+// BlockchainAPI is a subset of the klay namespace APIs used to call the deployed contracts.
+type BlockchainAPI interface {
+	GetCode(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error)
+	Call(ctx context.Context, args api.CallArgs, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error)
+}
+
+// Contract is a contract deployed by a successful transaction.
+// Its standard is detected by KIP-13 `supportsInterface`, so a contract which does not implement KIP-13
+// (e.g. most of ERC-20 contracts) is classified as unknown.
+type Contract struct {
+	Standard         Standard       `json:"standard"`
+	Address          common.Address `json:"address"`
+	Creator          common.Address `json:"creator"`
+	TransactionHash  common.Hash    `json:"transactionHash"`
+	TransactionIndex uint           `json:"transactionIndex"`
+	Timestamp        int64          `json:"timestamp"`
+}
+
+// ContractCaller performs KIP-13 method `supportsInterface` to detect the standard of the deployed contracts.
+type ContractCaller struct {
+	blockchainAPI BlockchainAPI
+	callTimeout   time.Duration
+}
+
+func NewContractCaller(api BlockchainAPI) *ContractCaller {
+	return &ContractCaller{blockchainAPI: api, callTimeout: callTimeout}
+}
+
+func (c *ContractCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	num := rpc.LatestBlockNumber
+	if blockNumber != nil {
+		num = rpc.BlockNumber(blockNumber.Int64())
+	}
+	return c.blockchainAPI.GetCode(ctx, contract, rpc.NewBlockNumberOrHashWithNumber(num))
+}
+
+func (c *ContractCaller) CallContract(ctx context.Context, call klaytn.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	num := rpc.LatestBlockNumber
+	if blockNumber != nil {
+		num = rpc.BlockNumber(blockNumber.Int64())
+	}
+	callArgs := api.CallArgs{
+		From: call.From,
+		To:   call.To,
+		Data: hexutil.Bytes(call.Data),
+	}
+	return c.blockchainAPI.Call(ctx, callArgs, rpc.NewBlockNumberOrHashWithNumber(num))
+}
+
+// supportsInterface returns true if the given interfaceID is supported, otherwise returns false.
+// A failed contract call is regarded as not supported, since the contract may not implement the method at all.
+func (c *ContractCaller) supportsInterface(contract common.Address, blockNumber *big.Int, interfaceID [4]byte) (bool, error) {
+	caller, err := kip13.NewInterfaceIdentifierCaller(contract, c)
+	if err != nil {
+		return false, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.callTimeout)
+	defer cancel()
+
+	isSupported, err := caller.SupportsInterface(&bind.CallOpts{Context: ctx, BlockNumber: blockNumber}, interfaceID)
+	if err != nil {
+		if !strings.Contains(err.Error(), errMsgEmptyOutput) && err != vm.ErrExecutionReverted && err != bind.ErrNoCode && err != blockchain.ErrVMDefault {
+			logger.Warn("supports interface returns an abnormal error", "err", err, "contract", contract.String(), "interfaceID", hexutil.Encode(interfaceID[:]))
+		}
+		return false, nil
+	}
+	return isSupported, nil
+}
+
+// DetectStandard returns the token standard of the given contract at the given block.
+func (c *ContractCaller) DetectStandard(contract common.Address, blockNumber *big.Int) (Standard, error) {
+	if isKIP13, err := c.supportsInterface(contract, blockNumber, IKIP13Id); err != nil || !isKIP13 {
+		return StandardUnknown, err
+	}
+	if isInvalid, err := c.supportsInterface(contract, blockNumber, InvalidId); err != nil || isInvalid {
+		return StandardUnknown, err
+	}
+
+	candidates := []struct {
+		standard Standard
+		id       [4]byte
+	}{
+		{StandardKIP7, IKIP7Id},
+		{StandardKIP17, IKIP17Id},
+		{StandardKIP37, IKIP37Id},
+		{StandardKIP37, IERC1155Id},
+	}
+	for _, candidate := range candidates {
+		if ok, err := c.supportsInterface(contract, blockNumber, candidate.id); err != nil {
+			return StandardUnknown, err
+		} else if ok {
+			return candidate.standard, nil
+		}
+	}
+	return StandardUnknown, nil
+}
+
+// DecodeContracts returns the contracts deployed in the given chain event with their standards.
+func DecodeContracts(caller *ContractCaller, event blockchain.ChainEvent) ([]*Contract, error) {
+	block := event.Block
+	txs := block.Transactions()
+	var contracts []*Contract
+	for idx, receipt := range event.Receipts {
+		if receipt.Status != types.ReceiptStatusSuccessful || receipt.ContractAddress == (common.Address{}) {
+			continue
+		}
+		standard, err := caller.DetectStandard(receipt.ContractAddress, block.Number())
+		if err != nil {
+			logger.Error("Failed to detect the contract standard", "err", err, "contract", receipt.ContractAddress.String())
+			return nil, err
+		}
+		contract := &Contract{
+			Standard:         standard,
+			Address:          receipt.ContractAddress,
+			TransactionHash:  receipt.TxHash,
+			TransactionIndex: uint(idx),
+			Timestamp:        block.Time().Int64(),
+		}
+		if idx < len(txs) {
+			contract.Creator = sender(txs[idx])
+		}
+		contracts = append(contracts, contract)
+	}
+	return contracts, nil
+}
+
+// sender returns the sender of the given transaction.
+func sender(tx *types.Transaction) common.Address {
+	var from common.Address
+	if tx.IsEthereumTransaction() {
+		signer := types.LatestSignerForChainID(tx.ChainId())
+		from, _ = types.Sender(signer, tx)
+	} else {
+		from, _ = tx.From()
+	}
+	return from
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package kct
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/api"
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBlockchainAPI answers `supportsInterface` calls with the interfaces registered for each contract.
+type testBlockchainAPI struct {
+	interfaces map[common.Address][][4]byte
+}
+
+func (a *testBlockchainAPI) GetCode(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	if _, ok := a.interfaces[address]; !ok {
+		return nil, nil
+	}
+	return hexutil.Bytes{0x60, 0x80}, nil
+}
+
+func (a *testBlockchainAPI) Call(ctx context.Context, args api.CallArgs, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	ids, ok := a.interfaces[*args.To]
+	if !ok {
+		return nil, nil
+	}
+	var interfaceID [4]byte
+	copy(interfaceID[:], args.Data[4:8])
+	for _, id := range ids {
+		if id == interfaceID {
+			return common.BigToHash(big.NewInt(1)).Bytes(), nil
+		}
+	}
+	return common.Hash{}.Bytes(), nil
+}
+
+func TestContractCaller_DetectStandard(t *testing.T) {
+	var (
+		kip7    = common.HexToAddress("0x01")
+		kip17   = common.HexToAddress("0x02")
+		kip37   = common.HexToAddress("0x03")
+		erc1155 = common.HexToAddress("0x04")
+		kip13   = common.HexToAddress("0x05")
+		invalid = common.HexToAddress("0x06")
+		noKIP13 = common.HexToAddress("0x07")
+		eoa     = common.HexToAddress("0x08")
+	)
+	caller := NewContractCaller(&testBlockchainAPI{interfaces: map[common.Address][][4]byte{
+		kip7:    {IKIP13Id, IKIP7Id},
+		kip17:   {IKIP13Id, IKIP17Id},
+		kip37:   {IKIP13Id, IKIP37Id},
+		erc1155: {IKIP13Id, IERC1155Id},
+		kip13:   {IKIP13Id},
+		invalid: {IKIP13Id, InvalidId, IKIP7Id},
+		noKIP13: {IKIP7Id},
+	}})
+
+	testcases := []struct {
+		contract common.Address
+		expected Standard
+	}{
+		{kip7, StandardKIP7},
+		{kip17, StandardKIP17},
+		{kip37, StandardKIP37},
+		{erc1155, StandardKIP37},
+		{kip13, StandardUnknown},
+		{invalid, StandardUnknown},
+		{noKIP13, StandardUnknown},
+		{eoa, StandardUnknown},
+	}
+	for _, tc := range testcases {
+		standard, err := caller.DetectStandard(tc.contract, big.NewInt(1))
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, standard, "contract: %v", tc.contract.String())
+	}
+}
+
+func TestDecodeContracts(t *testing.T) {
+	var (
+		kip17   = common.HexToAddress("0x02")
+		unknown = common.HexToAddress("0x09")
+	)
+	caller := NewContractCaller(&testBlockchainAPI{interfaces: map[common.Address][][4]byte{
+		kip17:   {IKIP13Id, IKIP17Id},
+		unknown: {},
+	}})
+
+	receipts := types.Receipts{
+		{Status: types.ReceiptStatusSuccessful, ContractAddress: kip17, TxHash: common.HexToHash("0x11")},
+		{Status: types.ReceiptStatusSuccessful, TxHash: common.HexToHash("0x12")},
+		{Status: types.ReceiptStatusFailed, ContractAddress: common.HexToAddress("0x0a"), TxHash: common.HexToHash("0x13")},
+		{Status: types.ReceiptStatusSuccessful, ContractAddress: unknown, TxHash: common.HexToHash("0x14")},
+	}
+	header := &types.Header{Number: big.NewInt(10), Time: big.NewInt(1234)}
+	event := blockchain.ChainEvent{Block: types.NewBlockWithHeader(header), Receipts: receipts}
+
+	contracts, err := DecodeContracts(caller, event)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(contracts))
+
+	assert.Equal(t, StandardKIP17, contracts[0].Standard)
+	assert.Equal(t, kip17, contracts[0].Address)
+	assert.Equal(t, common.HexToHash("0x11"), contracts[0].TransactionHash)
+	assert.Equal(t, uint(0), contracts[0].TransactionIndex)
+	assert.Equal(t, int64(1234), contracts[0].Timestamp)
+
+	assert.Equal(t, StandardUnknown, contracts[1].Standard)
+	assert.Equal(t, unknown, contracts[1].Address)
+	assert.Equal(t, uint(3), contracts[1].TransactionIndex)
+}
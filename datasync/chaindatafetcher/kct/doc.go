@@ -0,0 +1,29 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+Package kct implements the decoding of Klaytn Compatible Token (KCT) data from a chain event.
+
+It decodes the transfer events of KIP-7, KIP-17 and KIP-37 tokens and detects the standards of the contracts
+deployed in a block, so that the consumers of the exported data do not need to decode them again.
+The ERC-20, ERC-721 and ERC-1155 tokens are decoded as well since they emit the same events.
+
+Source Files
+  - contract.go       : implements the detection of the standards of the deployed contracts via KIP-13
+  - token_transfer.go : implements the decoding of the token transfer events
+*/
+
+package kct
@@ -0,0 +1,213 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package kct
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/klaytn/klaytn/accounts/abi"
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/log"
+)
+
+var logger = log.NewModuleLogger(log.ChainDataFetcher)
+
+// Standard is the token standard of a transfer or a deployed contract.
+// The ERC counterparts are not distinguished since they emit the same events and share the interface ids.
+type Standard string
+
+const (
+	StandardKIP7    = Standard("kip7")  // KIP-7 or ERC-20 fungible token
+	StandardKIP17   = Standard("kip17") // KIP-17 or ERC-721 non-fungible token
+	StandardKIP37   = Standard("kip37") // KIP-37 or ERC-1155 multi token
+	StandardUnknown = Standard("unknown")
+)
+
+const transferEventsABI = `[
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"operator","type":"address"},{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"id","type":"uint256"},{"indexed":false,"name":"value","type":"uint256"}],"name":"TransferSingle","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"operator","type":"address"},{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"ids","type":"uint256[]"},{"indexed":false,"name":"values","type":"uint256[]"}],"name":"TransferBatch","type":"event"}
+]`
+
+var (
+	transferEvents, _ = abi.JSON(strings.NewReader(transferEventsABI))
+
+	transferEventHash       = transferEvents.Events["Transfer"].ID
+	transferSingleEventHash = transferEvents.Events["TransferSingle"].ID
+	transferBatchEventHash  = transferEvents.Events["TransferBatch"].ID
+)
+
+// TokenTransfer is a decoded token transfer event.
+// A TransferBatch event is decoded to multiple transfers which have the same log index and different batch indexes.
+type TokenTransfer struct {
+	Standard         Standard        `json:"standard"`
+	ContractAddress  common.Address  `json:"contractAddress"`
+	Operator         *common.Address `json:"operator,omitempty"` // only for KIP-37
+	From             common.Address  `json:"from"`
+	To               common.Address  `json:"to"`
+	TokenId          *hexutil.Big    `json:"tokenId,omitempty"` // only for KIP-17 and KIP-37
+	Value            *hexutil.Big    `json:"value"`
+	TransactionHash  common.Hash     `json:"transactionHash"`
+	TransactionIndex uint            `json:"transactionIndex"`
+	LogIndex         uint            `json:"logIndex"`
+	BatchIndex       uint            `json:"batchIndex"`
+	Timestamp        int64           `json:"timestamp"`
+}
+
+// splitToWords divides log data to the words.
+// It returns false if the data length is not a multiple of the word size.
+func splitToWords(data []byte) ([]common.Hash, bool) {
+	if len(data)%common.HashLength != 0 {
+		return nil, false
+	}
+	var words []common.Hash
+	for i := 0; i < len(data); i += common.HashLength {
+		words = append(words, common.BytesToHash(data[i:i+common.HashLength]))
+	}
+	return words, true
+}
+
+// wordToAddress trims input word to get address field only.
+func wordToAddress(word common.Hash) common.Address {
+	return common.BytesToAddress(word[common.HashLength-common.AddressLength:])
+}
+
+func wordToBig(word common.Hash) *hexutil.Big {
+	return (*hexutil.Big)(new(big.Int).SetBytes(word.Bytes()))
+}
+
+// DecodeTokenTransfers decodes the token transfer events of KIP-7, KIP-17 and KIP-37 in the given chain event.
+// The logs which have the same topic but an unexpected layout are skipped.
+func DecodeTokenTransfers(event blockchain.ChainEvent) []*TokenTransfer {
+	timestamp := event.Block.Time().Int64()
+	var transfers []*TokenTransfer
+	for _, log := range event.Logs {
+		if len(log.Topics) == 0 {
+			continue
+		}
+		var decoded []*TokenTransfer
+		switch log.Topics[0] {
+		case transferEventHash:
+			decoded = decodeTransfer(log)
+		case transferSingleEventHash:
+			decoded = decodeTransferSingle(log)
+		case transferBatchEventHash:
+			decoded = decodeTransferBatch(log)
+		default:
+			continue
+		}
+		if decoded == nil {
+			logger.Debug("skip the undecodable token transfer log", "blockNumber", log.BlockNumber, "txHash", log.TxHash.String(), "logIndex", log.Index)
+			continue
+		}
+		for _, transfer := range decoded {
+			transfer.ContractAddress = log.Address
+			transfer.TransactionHash = log.TxHash
+			transfer.TransactionIndex = log.TxIndex
+			transfer.LogIndex = log.Index
+			transfer.Timestamp = timestamp
+		}
+		transfers = append(transfers, decoded...)
+	}
+	return transfers
+}
+
+// decodeTransfer decodes a Transfer event of KIP-7 or KIP-17.
+// The event has the same signature in both standards,
+//   - KIP-7  : the value is not indexed, i.e. topics = [hash, from, to], data = value
+//   - KIP-17 : the token id is indexed,   i.e. topics = [hash, from, to, tokenId], data = empty
+//
+// Some old KIP-7 contracts do not index any parameter, i.e. topics = [hash], data = concat(from, to, value).
+func decodeTransfer(log *types.Log) []*TokenTransfer {
+	words, ok := splitToWords(log.Data)
+	if !ok {
+		return nil
+	}
+	data := append(append([]common.Hash{}, log.Topics...), words...)
+	if len(data) != 4 {
+		return nil
+	}
+	transfer := &TokenTransfer{
+		From: wordToAddress(data[1]),
+		To:   wordToAddress(data[2]),
+	}
+	if len(log.Topics) == 4 {
+		transfer.Standard = StandardKIP17
+		transfer.TokenId = wordToBig(data[3])
+		transfer.Value = (*hexutil.Big)(big.NewInt(1))
+	} else {
+		transfer.Standard = StandardKIP7
+		transfer.Value = wordToBig(data[3])
+	}
+	return []*TokenTransfer{transfer}
+}
+
+// decodeTransferSingle decodes a TransferSingle event of KIP-37,
+// i.e. topics = [hash, operator, from, to], data = concat(id, value).
+func decodeTransferSingle(log *types.Log) []*TokenTransfer {
+	words, ok := splitToWords(log.Data)
+	if !ok || len(log.Topics) != 4 || len(words) != 2 {
+		return nil
+	}
+	operator := wordToAddress(log.Topics[1])
+	return []*TokenTransfer{{
+		Standard: StandardKIP37,
+		Operator: &operator,
+		From:     wordToAddress(log.Topics[2]),
+		To:       wordToAddress(log.Topics[3]),
+		TokenId:  wordToBig(words[0]),
+		Value:    wordToBig(words[1]),
+	}}
+}
+
+// decodeTransferBatch decodes a TransferBatch event of KIP-37,
+// i.e. topics = [hash, operator, from, to], data = abi.encode(ids, values).
+func decodeTransferBatch(log *types.Log) []*TokenTransfer {
+	if len(log.Topics) != 4 {
+		return nil
+	}
+	values, err := transferEvents.Unpack("TransferBatch", log.Data)
+	if err != nil || len(values) != 2 {
+		return nil
+	}
+	ids, ok1 := values[0].([]*big.Int)
+	amounts, ok2 := values[1].([]*big.Int)
+	if !ok1 || !ok2 || len(ids) != len(amounts) {
+		return nil
+	}
+
+	operator := wordToAddress(log.Topics[1])
+	from := wordToAddress(log.Topics[2])
+	to := wordToAddress(log.Topics[3])
+	transfers := make([]*TokenTransfer, 0, len(ids))
+	for i := range ids {
+		transfers = append(transfers, &TokenTransfer{
+			Standard:   StandardKIP37,
+			Operator:   &operator,
+			From:       from,
+			To:         to,
+			TokenId:    (*hexutil.Big)(ids[i]),
+			Value:      (*hexutil.Big)(amounts[i]),
+			BatchIndex: uint(i),
+		})
+	}
+	return transfers
+}
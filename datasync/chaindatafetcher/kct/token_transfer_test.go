@@ -0,0 +1,156 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package kct
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testContract = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	testOperator = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	testFrom     = common.HexToAddress("0x3000000000000000000000000000000000000003")
+	testTo       = common.HexToAddress("0x4000000000000000000000000000000000000004")
+	testTxHash   = common.HexToHash("0x5000000000000000000000000000000000000000000000000000000000000005")
+)
+
+func makeTestChainEvent(logs ...*types.Log) blockchain.ChainEvent {
+	header := &types.Header{Number: big.NewInt(10), Time: big.NewInt(1234)}
+	for idx, log := range logs {
+		log.Address = testContract
+		log.BlockNumber = header.Number.Uint64()
+		log.TxHash = testTxHash
+		log.TxIndex = 1
+		log.Index = uint(idx)
+	}
+	return blockchain.ChainEvent{Block: types.NewBlockWithHeader(header), Logs: logs}
+}
+
+func bigToWord(v int64) common.Hash {
+	return common.BigToHash(big.NewInt(v))
+}
+
+func TestTransferEventHashes(t *testing.T) {
+	assert.Equal(t, common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"), transferEventHash)
+	assert.Equal(t, common.HexToHash("0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62"), transferSingleEventHash)
+	assert.Equal(t, common.HexToHash("0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb"), transferBatchEventHash)
+}
+
+func TestDecodeTokenTransfers_KIP7(t *testing.T) {
+	indexed := &types.Log{
+		Topics: []common.Hash{transferEventHash, testFrom.Hash(), testTo.Hash()},
+		Data:   bigToWord(100).Bytes(),
+	}
+	notIndexed := &types.Log{
+		Topics: []common.Hash{transferEventHash},
+		Data:   append(append(testFrom.Hash().Bytes(), testTo.Hash().Bytes()...), bigToWord(200).Bytes()...),
+	}
+
+	transfers := DecodeTokenTransfers(makeTestChainEvent(indexed, notIndexed))
+	assert.Equal(t, 2, len(transfers))
+	for idx, expected := range []int64{100, 200} {
+		transfer := transfers[idx]
+		assert.Equal(t, StandardKIP7, transfer.Standard)
+		assert.Equal(t, testContract, transfer.ContractAddress)
+		assert.Equal(t, testFrom, transfer.From)
+		assert.Equal(t, testTo, transfer.To)
+		assert.Nil(t, transfer.Operator)
+		assert.Nil(t, transfer.TokenId)
+		assert.Equal(t, big.NewInt(expected), transfer.Value.ToInt())
+		assert.Equal(t, testTxHash, transfer.TransactionHash)
+		assert.Equal(t, uint(1), transfer.TransactionIndex)
+		assert.Equal(t, uint(idx), transfer.LogIndex)
+		assert.Equal(t, int64(1234), transfer.Timestamp)
+	}
+}
+
+func TestDecodeTokenTransfers_KIP17(t *testing.T) {
+	log := &types.Log{
+		Topics: []common.Hash{transferEventHash, testFrom.Hash(), testTo.Hash(), bigToWord(7)},
+	}
+
+	transfers := DecodeTokenTransfers(makeTestChainEvent(log))
+	assert.Equal(t, 1, len(transfers))
+	assert.Equal(t, StandardKIP17, transfers[0].Standard)
+	assert.Equal(t, testFrom, transfers[0].From)
+	assert.Equal(t, testTo, transfers[0].To)
+	assert.Equal(t, big.NewInt(7), transfers[0].TokenId.ToInt())
+	assert.Equal(t, big.NewInt(1), transfers[0].Value.ToInt())
+}
+
+func TestDecodeTokenTransfers_KIP37(t *testing.T) {
+	single := &types.Log{
+		Topics: []common.Hash{transferSingleEventHash, testOperator.Hash(), testFrom.Hash(), testTo.Hash()},
+		Data:   append(bigToWord(3).Bytes(), bigToWord(30).Bytes()...),
+	}
+	batchData, err := transferEvents.Events["TransferBatch"].Inputs.NonIndexed().Pack(
+		[]*big.Int{big.NewInt(4), big.NewInt(5)}, []*big.Int{big.NewInt(40), big.NewInt(50)})
+	assert.NoError(t, err)
+	batch := &types.Log{
+		Topics: []common.Hash{transferBatchEventHash, testOperator.Hash(), testFrom.Hash(), testTo.Hash()},
+		Data:   batchData,
+	}
+
+	transfers := DecodeTokenTransfers(makeTestChainEvent(single, batch))
+	assert.Equal(t, 3, len(transfers))
+
+	expected := []struct {
+		logIndex, batchIndex uint
+		id, value            int64
+	}{
+		{0, 0, 3, 30},
+		{1, 0, 4, 40},
+		{1, 1, 5, 50},
+	}
+	for idx, e := range expected {
+		transfer := transfers[idx]
+		assert.Equal(t, StandardKIP37, transfer.Standard)
+		assert.Equal(t, testOperator, *transfer.Operator)
+		assert.Equal(t, testFrom, transfer.From)
+		assert.Equal(t, testTo, transfer.To)
+		assert.Equal(t, big.NewInt(e.id), transfer.TokenId.ToInt())
+		assert.Equal(t, big.NewInt(e.value), transfer.Value.ToInt())
+		assert.Equal(t, e.logIndex, transfer.LogIndex)
+		assert.Equal(t, e.batchIndex, transfer.BatchIndex)
+	}
+}
+
+func TestDecodeTokenTransfers_SkipUndecodable(t *testing.T) {
+	logs := []*types.Log{
+		// not a transfer event
+		{Topics: []common.Hash{common.HexToHash("0x1234")}, Data: bigToWord(1).Bytes()},
+		// no topics
+		{Data: bigToWord(1).Bytes()},
+		// invalid data length
+		{Topics: []common.Hash{transferEventHash, testFrom.Hash(), testTo.Hash()}, Data: []byte{0x01}},
+		// too many words
+		{Topics: []common.Hash{transferEventHash, testFrom.Hash(), testTo.Hash(), bigToWord(1)}, Data: bigToWord(1).Bytes()},
+		// invalid TransferSingle data
+		{Topics: []common.Hash{transferSingleEventHash, testOperator.Hash(), testFrom.Hash(), testTo.Hash()}, Data: bigToWord(1).Bytes()},
+		// invalid TransferBatch data
+		{Topics: []common.Hash{transferBatchEventHash, testOperator.Hash(), testFrom.Hash(), testTo.Hash()}, Data: bigToWord(1).Bytes()},
+	}
+
+	transfers := DecodeTokenTransfers(makeTestChainEvent(logs...))
+	assert.Equal(t, 0, len(transfers))
+}
@@ -19,11 +19,15 @@ var (
 	tracesInsertionRetryGauge         = metrics.NewRegisteredGauge("chaindatafetcher/insertion/retry/traces/gauge", nil)
 
 	// Kafka specific metrics
-	blockGroupInsertionTimeGauge = metrics.NewRegisteredGauge("chaindatafetcher/insertion/time/blockgroup/gauge", nil)
-	traceGroupInsertionTimeGauge = metrics.NewRegisteredGauge("chaindatafetcher/insertion/time/tracegroup/gauge", nil)
-
-	blockGroupInsertionRetryGauge = metrics.NewRegisteredGauge("chaindatafetcher/insertion/retry/blockgroup/gauge", nil)
-	traceGroupInsertionRetryGauge = metrics.NewRegisteredGauge("chaindatafetcher/insertion/retry/tracegroup/gauge", nil)
+	blockGroupInsertionTimeGauge         = metrics.NewRegisteredGauge("chaindatafetcher/insertion/time/blockgroup/gauge", nil)
+	traceGroupInsertionTimeGauge         = metrics.NewRegisteredGauge("chaindatafetcher/insertion/time/tracegroup/gauge", nil)
+	tokenTransferGroupInsertionTimeGauge = metrics.NewRegisteredGauge("chaindatafetcher/insertion/time/tokentransfergroup/gauge", nil)
+	contractGroupInsertionTimeGauge      = metrics.NewRegisteredGauge("chaindatafetcher/insertion/time/contractgroup/gauge", nil)
+
+	blockGroupInsertionRetryGauge         = metrics.NewRegisteredGauge("chaindatafetcher/insertion/retry/blockgroup/gauge", nil)
+	traceGroupInsertionRetryGauge         = metrics.NewRegisteredGauge("chaindatafetcher/insertion/retry/tracegroup/gauge", nil)
+	tokenTransferGroupInsertionRetryGauge = metrics.NewRegisteredGauge("chaindatafetcher/insertion/retry/tokentransfergroup/gauge", nil)
+	contractGroupInsertionRetryGauge      = metrics.NewRegisteredGauge("chaindatafetcher/insertion/retry/contractgroup/gauge", nil)
 
 	handledBlockNumberGauge = metrics.NewRegisteredGauge("chaindatafetcher/handle/blocknumber/gauge", nil)
 
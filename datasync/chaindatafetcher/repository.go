@@ -43,6 +43,12 @@ type CheckpointDB interface {
 	WriteCheckpoint(checkpoint int64) error
 }
 
+// Sink is a repository which keeps its own checkpoint together with the handled data.
+type Sink interface {
+	Repository
+	CheckpointDB
+}
+
 type ComponentSetter interface {
 	SetComponent(component interface{})
 }
@@ -29,6 +29,8 @@ const (
 	// RequestTypes for Kafka
 	RequestTypeBlockGroup
 	RequestTypeTraceGroup
+	RequestTypeTokenTransferGroup
+	RequestTypeContractGroup
 
 	RequestTypeLength
 )
@@ -36,10 +38,13 @@ const (
 const (
 	RequestTypeAll      = RequestTypeTransaction | RequestTypeTokenTransfer | RequestTypeContract | RequestTypeTrace
 	RequestTypeGroupAll = RequestTypeBlockGroup | RequestTypeTraceGroup
+
+	// RequestTypeDecodedGroupAll includes the decoded data which is not published unless it is enabled.
+	RequestTypeDecodedGroupAll = RequestTypeTokenTransferGroup | RequestTypeContractGroup
 )
 
 func (t RequestType) IsValid() bool {
-	return t == RequestTypeBlockGroup || t == RequestTypeTraceGroup || t == RequestTypeGroupAll
+	return t != 0 && t&^(RequestTypeGroupAll|RequestTypeDecodedGroupAll) == 0
 }
 
 func (t RequestType) String() string {
@@ -50,6 +55,12 @@ func (t RequestType) String() string {
 		return "block"
 	case RequestTypeTraceGroup:
 		return "trace"
+	case RequestTypeTokenTransferGroup:
+		return "tokentransfer"
+	case RequestTypeContractGroup:
+		return "contract"
+	case RequestTypeDecodedGroupAll:
+		return "decoded"
 	default:
 		return "unknown"
 	}
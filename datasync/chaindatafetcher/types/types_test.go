@@ -84,3 +84,30 @@ func TestHasDataType(t *testing.T) {
 		}
 	}
 }
+
+func TestRequestType_IsValid(t *testing.T) {
+	validTypes := []RequestType{
+		RequestTypeBlockGroup,
+		RequestTypeTraceGroup,
+		RequestTypeTokenTransferGroup,
+		RequestTypeContractGroup,
+		RequestTypeGroupAll,
+		RequestTypeDecodedGroupAll,
+		RequestTypeGroupAll | RequestTypeDecodedGroupAll,
+		RequestTypeBlockGroup | RequestTypeTokenTransferGroup,
+	}
+	for _, rt := range validTypes {
+		assert.True(t, rt.IsValid(), "reqType: %v", uint(rt))
+	}
+
+	invalidTypes := []RequestType{
+		0,
+		RequestTypeTransaction,
+		RequestTypeAll,
+		RequestTypeLength,
+		RequestTypeBlockGroup | RequestTypeTokenTransfer,
+	}
+	for _, rt := range invalidTypes {
+		assert.False(t, rt.IsValid(), "reqType: %v", uint(rt))
+	}
+}
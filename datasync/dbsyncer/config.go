@@ -23,17 +23,24 @@ const (
 	HEAD_MODE  = "head"
 )
 
+const (
+	MYSQL    = "mysql"
+	POSTGRES = "postgres"
+)
+
 //go:generate gencodec -type DBConfig -formats toml -out gen_config.go
 type DBConfig struct {
 	EnabledDBSyncer bool
 	EnabledLogMode  bool
 
 	// DB Config
+	DBType     string `toml:",omitempty"`
 	DBHost     string `toml:",omitempty"`
 	DBPort     string `toml:",omitempty"`
 	DBUser     string `toml:",omitempty"`
 	DBPassword string `toml:",omitempty"`
 	DBName     string `toml:",omitempty"`
+	DBSSLMode  string `toml:",omitempty"` // DBSSLMode is only used for postgres
 
 	MaxIdleConns     int           `toml:",omitempty"`
 	MaxOpenConns     int           `toml:",omitempty"`
@@ -56,7 +63,9 @@ func DefaultDBConfig() *DBConfig {
 		EnabledDBSyncer: false,
 		EnabledLogMode:  false,
 
-		DBPort: "3306",
+		DBType:    MYSQL,
+		DBPort:    "3306",
+		DBSSLMode: "disable",
 
 		MaxIdleConns:     50,
 		MaxOpenConns:     30,
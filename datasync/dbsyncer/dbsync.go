@@ -38,6 +38,7 @@ var logger = log.NewModuleLogger(log.Node)
 
 type DBSyncer struct {
 	cfg        *DBConfig
+	dialect    dialect
 	dataSource string
 
 	blockchain *blockchain.BlockChain
@@ -71,7 +72,7 @@ type DBSyncer struct {
 }
 
 func NewDBSyncer(ctx *node.ServiceContext, cfg *DBConfig) (*DBSyncer, error) {
-	logger.Info("initialize DBSyncer", "db.host",
+	logger.Info("initialize DBSyncer", "db.type", cfg.DBType, "db.host",
 		cfg.DBHost, "db.port", cfg.DBPort, "db.name", cfg.DBName, "db.user", cfg.DBUser, "db.max.idle",
 		cfg.MaxIdleConns, "db.password", cfg.DBPassword, "db.max.open", cfg.MaxOpenConns, "db.max.lifetime",
 		cfg.ConnMaxLifetime, "block.ch.size", cfg.BlockChannelSize, "mode", cfg.Mode, "genquery.th",
@@ -88,8 +89,14 @@ func NewDBSyncer(ctx *node.ServiceContext, cfg *DBConfig) (*DBSyncer, error) {
 		return nil, errors.New("db config must be set (db.password)")
 	}
 
+	dialect, err := newDialect(cfg.DBType)
+	if err != nil {
+		return nil, err
+	}
+
 	return &DBSyncer{
 		cfg:            cfg,
+		dialect:        dialect,
 		logMode:        cfg.EnabledLogMode,
 		bulkInsertSize: cfg.BulkInsertSize,
 		eventMode:      cfg.EventMode,
@@ -106,10 +113,9 @@ func (ds *DBSyncer) APIs() []rpc.API {
 }
 
 func (ds *DBSyncer) Start(server p2p.Server) error {
-	ds.dataSource = ds.cfg.DBUser + ":" + ds.cfg.DBPassword + "@tcp(" + ds.cfg.DBHost + ":" +
-		ds.cfg.DBPort + ")/" + ds.cfg.DBName + "?writeTimeout=10s&timeout=10s"
+	ds.dataSource = ds.dialect.dataSource(ds.cfg)
 
-	db, err := sql.Open("mysql", ds.dataSource)
+	db, err := sql.Open(ds.dialect.driverName(), ds.dataSource)
 	if err != nil {
 		logger.Error("fail to connect database", "target", ds.dataSource)
 		return err
@@ -122,20 +128,16 @@ func (ds *DBSyncer) Start(server p2p.Server) error {
 	// initialize context
 	ds.ctx, ds.stop = context.WithCancel(context.Background())
 
-	// query
-	ds.blockInsertQuery = "INSERT INTO " + ds.cfg.DBName + ".block " + "(totalTx, " +
-		"committee, gasUsed, gasPrice, hash, " +
-		"number, parentHash, proposer, reward, size, " +
-		"timestamp, timestampFoS)" + "VALUES (?,?,?,?,?,?,?,?,?,?,?,?)"
-
-	ds.txInsertQuery = "INSERT INTO " + ds.cfg.DBName + ".transaction " + "(id, blockHash, blockNumber, " +
-		"contractAddress, `from`, gas, gasPrice, gasUsed, input, nonce, status, `to`, " +
-		"timestamp, txHash, type, value, feePayer, feeRatio, senderTxHash) VALUES "
-
-	ds.summaryInsertQuery = "INSERT INTO " + ds.cfg.DBName + ".account_summary " + "(address, type, " +
-		"creator, created_tx, hra) VALUES "
+	if err := migrate(ds.ctx, ds.db, ds.dialect); err != nil {
+		logger.Error("fail to migrate database", "target", ds.cfg.DBName, "err", err)
+		return err
+	}
 
-	ds.txHashMapInsertQuery = "INSERT INTO " + ds.cfg.DBName + ".sendertxhash_map " + "(senderTxHash, txHash) VALUES "
+	// query
+	ds.blockInsertQuery = ds.dialect.insertQuery(ds.cfg.DBName, blockTable) + blockTable.rowPlaceholder()
+	ds.txInsertQuery = ds.dialect.insertQuery(ds.cfg.DBName, txTable)
+	ds.summaryInsertQuery = ds.dialect.insertQuery(ds.cfg.DBName, summaryTable)
+	ds.txHashMapInsertQuery = ds.dialect.insertQuery(ds.cfg.DBName, txHashMapTable)
 
 	if ds.cfg.Mode == "single" {
 		ds.HandleBlock = ds.HandleChainEvent
@@ -278,6 +280,9 @@ func (ds *DBSyncer) HandleChainEvent(block *types.Block) error {
 
 func (ds *DBSyncer) syncBlockHeader(block *types.Block) error {
 	proposerAddr, committeeAddrs, err := getProposerAndValidatorsFromBlock(block)
+	if err != nil {
+		return err
+	}
 
 	totalTx := block.Transactions().Len()
 	committee := strings.ToLower(committeeAddrs)
@@ -292,20 +297,9 @@ func (ds *DBSyncer) syncBlockHeader(block *types.Block) error {
 	timestamp := block.Header().Time.String()
 	timestampFos := block.Header().TimeFoS
 
-	stmtIns, err := ds.db.Prepare(ds.blockInsertQuery)
-	if err != nil {
-		logger.Error("fail to prepare (block)", "query", ds.blockInsertQuery)
-		return err
-	}
-
-	defer func() {
-		if err := stmtIns.Close(); err != nil {
-			logger.Error("fail to close stmt", "err", err)
-		}
-	}()
-
-	if _, err := stmtIns.Exec(totalTx, committee, gasUsed, gasPrice, hash,
-		number, parentHash, proposer, reward, size, timestamp, timestampFos); err != nil {
+	vals := []interface{}{totalTx, committee, gasUsed, gasPrice, hash,
+		number, parentHash, proposer, reward, size, timestamp, timestampFos}
+	if err := ds.dialect.bulkInsert(ds.ctx, ds.db, blockTable, ds.blockInsertQuery, vals, 1); err != nil {
 		logger.Error("fail to insert DB (block)", "number", block.Number(), "err", err)
 		return err
 	}
@@ -333,7 +327,7 @@ func (ds *DBSyncer) SyncTransactions(block *types.Block) error {
 		insertCount++
 
 		if insertCount >= ds.bulkInsertSize {
-			if err := ds.bulkInsert(txStr, vals, block.NumberU64(), insertCount); err != nil {
+			if err := ds.bulkInsert(txTable, txStr, vals, block.NumberU64(), insertCount); err != nil {
 				return err
 			}
 			txStr, vals, insertCount = ds.resetTxParameter()
@@ -351,10 +345,10 @@ func (ds *DBSyncer) SyncTransactions(block *types.Block) error {
 		}
 
 		if summaryInsertCount >= ds.bulkInsertSize {
-			if err := ds.bulkInsert(summaryStr, summaryVals, block.NumberU64(), summaryInsertCount); err != nil {
+			if err := ds.bulkInsert(summaryTable, summaryStr, summaryVals, block.NumberU64(), summaryInsertCount); err != nil {
 				return err
 			}
-			summaryStr, summaryVals, summaryInsertCount = ds.resetTxParameter()
+			summaryStr, summaryVals, summaryInsertCount = ds.resetSummaryParameter()
 		}
 
 		tcols, tval, tcount, err := MakeTxMappingRow(txMapArg)
@@ -369,7 +363,7 @@ func (ds *DBSyncer) SyncTransactions(block *types.Block) error {
 		}
 
 		if txMapInsertCount >= ds.bulkInsertSize {
-			if err := ds.bulkInsert(txMapStr, txMapVals, block.NumberU64(), txMapInsertCount); err != nil {
+			if err := ds.bulkInsert(txHashMapTable, txMapStr, txMapVals, block.NumberU64(), txMapInsertCount); err != nil {
 				return err
 			}
 			txMapStr, txMapVals, txMapInsertCount = ds.resetTxMapParameter()
@@ -377,19 +371,19 @@ func (ds *DBSyncer) SyncTransactions(block *types.Block) error {
 	}
 
 	if insertCount > 0 {
-		if err := ds.bulkInsert(txStr, vals, block.NumberU64(), insertCount); err != nil {
+		if err := ds.bulkInsert(txTable, txStr, vals, block.NumberU64(), insertCount); err != nil {
 			return err
 		}
 	}
 
 	if summaryInsertCount > 0 {
-		if err := ds.bulkInsert(summaryStr, summaryVals, block.NumberU64(), summaryInsertCount); err != nil {
+		if err := ds.bulkInsert(summaryTable, summaryStr, summaryVals, block.NumberU64(), summaryInsertCount); err != nil {
 			return err
 		}
 	}
 
 	if txMapInsertCount > 0 {
-		if err := ds.bulkInsert(txMapStr, txMapVals, block.NumberU64(), txMapInsertCount); err != nil {
+		if err := ds.bulkInsert(txHashMapTable, txMapStr, txMapVals, block.NumberU64(), txMapInsertCount); err != nil {
 			return err
 		}
 	}
@@ -421,27 +415,16 @@ func (ds *DBSyncer) resetTxMapParameter() (txMapStr string, vals []interface{},
 	return txMapStr, vals, insertCount
 }
 
-func (ds *DBSyncer) bulkInsert(sqlStr string, vals []interface{}, blockNumber uint64, insertCount int) error {
+func (ds *DBSyncer) bulkInsert(t *dbTable, sqlStr string, vals []interface{}, blockNumber uint64, insertCount int) error {
 	start := time.Now()
 	// trim the last
 	sqlStr = sqlStr[0 : len(sqlStr)-1]
 
-	stmtTxs, err := ds.db.Prepare(sqlStr)
-	if err != nil {
-		logger.Error("fail to create prepare", "sql", sqlStr, "err", err)
+	if err := ds.dialect.bulkInsert(ds.ctx, ds.db, t, sqlStr, vals, insertCount); err != nil {
+		logger.Error("fail to insert DB (tx)", "number", blockNumber, "table", t.name, "err", err)
 		return err
 	}
 
-	if _, err := stmtTxs.Exec(vals...); err != nil {
-		logger.Error("fail to insert DB (tx)", "number", blockNumber, "err", err)
-		return err
-	}
-	defer func() {
-		if err := stmtTxs.Close(); err != nil {
-			logger.Error("fail to close stmt", "err", err)
-		}
-	}()
-
 	if ds.logMode {
 		txTime := time.Since(start)
 		logger.Info("TX/BLOCK INSERT", "block", blockNumber, "counts", insertCount, "time", txTime)
@@ -77,6 +77,9 @@ func (ds *DBSyncer) HandleChainEventContext(block *types.Block) error {
 
 func (ds *DBSyncer) syncBlockHeaderContext(ctx context.Context, tx *sql.Tx, block *types.Block) error {
 	proposerAddr, committeeAddrs, err := getProposerAndValidatorsFromBlock(block)
+	if err != nil {
+		return err
+	}
 
 	totalTx := block.Transactions().Len()
 	committee := strings.ToLower(committeeAddrs)
@@ -91,21 +94,9 @@ func (ds *DBSyncer) syncBlockHeaderContext(ctx context.Context, tx *sql.Tx, bloc
 	timestamp := block.Header().Time.String()
 	timestampFos := block.Header().TimeFoS
 
-	stmtIns, err := ds.db.Prepare(ds.blockInsertQuery)
-	if err != nil {
-		logger.Error("fail to prepare (block)", "query", ds.blockInsertQuery)
-		return err
-	}
-	tStmtIns := tx.StmtContext(ctx, stmtIns)
-
-	defer func() {
-		if err := tStmtIns.Close(); err != nil {
-			logger.Error("fail to close stmt", "err", err)
-		}
-	}()
-
-	if _, err := tStmtIns.Exec(totalTx, committee, gasUsed, gasPrice, hash,
-		number, parentHash, proposer, reward, size, timestamp, timestampFos); err != nil {
+	vals := []interface{}{totalTx, committee, gasUsed, gasPrice, hash,
+		number, parentHash, proposer, reward, size, timestamp, timestampFos}
+	if err := ds.dialect.bulkInsert(ctx, tx, blockTable, ds.blockInsertQuery, vals, 1); err != nil {
 		logger.Error("fail to insert DB (block)", "number", block.Number(), "err", err)
 		return err
 	}
@@ -133,7 +124,7 @@ func (ds *DBSyncer) syncTransactionsContext(ctx context.Context, syncTx *sql.Tx,
 		insertCount++
 
 		if insertCount >= ds.bulkInsertSize {
-			if err := ds.bulkInsertContext(ctx, syncTx, txStr, vals, block, insertCount); err != nil {
+			if err := ds.bulkInsertContext(ctx, syncTx, txTable, txStr, vals, block, insertCount); err != nil {
 				return err
 			}
 			txStr, vals, insertCount = ds.resetTxParameter()
@@ -148,10 +139,10 @@ func (ds *DBSyncer) syncTransactionsContext(ctx context.Context, syncTx *sql.Tx,
 		}
 
 		if summaryInsertCount >= ds.bulkInsertSize {
-			if err := ds.bulkInsertContext(ctx, syncTx, summaryStr, summaryVals, block, summaryInsertCount); err != nil {
+			if err := ds.bulkInsertContext(ctx, syncTx, summaryTable, summaryStr, summaryVals, block, summaryInsertCount); err != nil {
 				return err
 			}
-			summaryStr, summaryVals, summaryInsertCount = ds.resetTxParameter()
+			summaryStr, summaryVals, summaryInsertCount = ds.resetSummaryParameter()
 		}
 
 		tcols, tval, tcount, err := MakeTxMappingRow(txMapArg)
@@ -166,7 +157,7 @@ func (ds *DBSyncer) syncTransactionsContext(ctx context.Context, syncTx *sql.Tx,
 		}
 
 		if txMapInsertCount >= ds.bulkInsertSize {
-			if err := ds.bulkInsertContext(ctx, syncTx, txMapStr, txMapVals, block, txMapInsertCount); err != nil {
+			if err := ds.bulkInsertContext(ctx, syncTx, txHashMapTable, txMapStr, txMapVals, block, txMapInsertCount); err != nil {
 				return err
 			}
 			txMapStr, txMapVals, txMapInsertCount = ds.resetTxMapParameter()
@@ -174,19 +165,19 @@ func (ds *DBSyncer) syncTransactionsContext(ctx context.Context, syncTx *sql.Tx,
 	}
 
 	if insertCount > 0 {
-		if err := ds.bulkInsertContext(ctx, syncTx, txStr, vals, block, insertCount); err != nil {
+		if err := ds.bulkInsertContext(ctx, syncTx, txTable, txStr, vals, block, insertCount); err != nil {
 			return err
 		}
 	}
 
 	if summaryInsertCount > 0 {
-		if err := ds.bulkInsertContext(ctx, syncTx, summaryStr, summaryVals, block, summaryInsertCount); err != nil {
+		if err := ds.bulkInsertContext(ctx, syncTx, summaryTable, summaryStr, summaryVals, block, summaryInsertCount); err != nil {
 			return err
 		}
 	}
 
 	if txMapInsertCount > 0 {
-		if err := ds.bulkInsertContext(ctx, syncTx, txMapStr, txMapVals, block, txMapInsertCount); err != nil {
+		if err := ds.bulkInsertContext(ctx, syncTx, txHashMapTable, txMapStr, txMapVals, block, txMapInsertCount); err != nil {
 			return err
 		}
 	}
@@ -194,27 +185,15 @@ func (ds *DBSyncer) syncTransactionsContext(ctx context.Context, syncTx *sql.Tx,
 	return nil
 }
 
-func (ds *DBSyncer) bulkInsertContext(ctx context.Context, tx *sql.Tx, sqlStr string, vals []interface{}, block *types.Block, insertCount int) error {
+func (ds *DBSyncer) bulkInsertContext(ctx context.Context, tx *sql.Tx, t *dbTable, sqlStr string, vals []interface{}, block *types.Block, insertCount int) error {
 	start := time.Now()
 	// trim the last
 	sqlStr = sqlStr[0 : len(sqlStr)-1]
 
-	stmtTxs, err := ds.db.Prepare(sqlStr)
-	if err != nil {
-		logger.Error("fail to create prepare", "sql", sqlStr, "err", err)
+	if err := ds.dialect.bulkInsert(ctx, tx, t, sqlStr, vals, insertCount); err != nil {
+		logger.Error("fail to insert DB (tx)", "number", block.Number(), "table", t.name, "err", err)
 		return err
 	}
-	tStmtTxs := tx.StmtContext(ctx, stmtTxs)
-
-	if _, err := tStmtTxs.Exec(vals...); err != nil {
-		logger.Error("fail to insert DB (tx)", "number", block.Number(), "err", err)
-		return err
-	}
-	defer func() {
-		if err := tStmtTxs.Close(); err != nil {
-			logger.Error("fail to close stmt", "err", err)
-		}
-	}()
 
 	if ds.logMode {
 		txTime := time.Since(start)
@@ -108,7 +108,7 @@ func (ds *DBSyncer) parallelSyncBlockHeader(block *types.Block) ([]*BulkInsertQu
 	vals = append(vals, totalTx, committee, gasUsed, gasPrice, hash,
 		number, parentHash, proposer, reward, size, timestamp, timestampFos)
 
-	bulkInsertQuerys = append(bulkInsertQuerys, &BulkInsertQuery{blockStr, vals, block.NumberU64(), 1})
+	bulkInsertQuerys = append(bulkInsertQuerys, &BulkInsertQuery{blockTable, blockStr, vals, block.NumberU64(), 1})
 
 	return bulkInsertQuerys, nil
 }
@@ -143,7 +143,7 @@ QUERY:
 		insertCount++
 
 		if insertCount >= ds.bulkInsertSize {
-			bulkInsertQuerys = append(bulkInsertQuerys, &BulkInsertQuery{txStr, vals, block.NumberU64(), insertCount})
+			bulkInsertQuerys = append(bulkInsertQuerys, &BulkInsertQuery{txTable, txStr, vals, block.NumberU64(), insertCount})
 
 			txStr, vals, insertCount = ds.resetTxParameter()
 		}
@@ -155,9 +155,9 @@ QUERY:
 		}
 
 		if summaryInsertCount >= ds.bulkInsertSize {
-			bulkInsertQuerys = append(bulkInsertQuerys, &BulkInsertQuery{summaryStr, summaryVals, block.NumberU64(), summaryInsertCount})
+			bulkInsertQuerys = append(bulkInsertQuerys, &BulkInsertQuery{summaryTable, summaryStr, summaryVals, block.NumberU64(), summaryInsertCount})
 
-			summaryStr, summaryVals, summaryInsertCount = ds.resetTxParameter()
+			summaryStr, summaryVals, summaryInsertCount = ds.resetSummaryParameter()
 		}
 
 		if record.tcount == 1 {
@@ -167,7 +167,7 @@ QUERY:
 		}
 
 		if txMapInsertCount >= ds.bulkInsertSize {
-			bulkInsertQuerys = append(bulkInsertQuerys, &BulkInsertQuery{txMapStr, txMapVals, block.NumberU64(), txMapInsertCount})
+			bulkInsertQuerys = append(bulkInsertQuerys, &BulkInsertQuery{txHashMapTable, txMapStr, txMapVals, block.NumberU64(), txMapInsertCount})
 
 			txMapStr, txMapVals, txMapInsertCount = ds.resetTxMapParameter()
 		}
@@ -179,15 +179,15 @@ QUERY:
 	}
 
 	if insertCount > 0 {
-		bulkInsertQuerys = append(bulkInsertQuerys, &BulkInsertQuery{txStr, vals, block.NumberU64(), insertCount})
+		bulkInsertQuerys = append(bulkInsertQuerys, &BulkInsertQuery{txTable, txStr, vals, block.NumberU64(), insertCount})
 	}
 
 	if summaryInsertCount > 0 {
-		bulkInsertQuerys = append(bulkInsertQuerys, &BulkInsertQuery{summaryStr, summaryVals, block.NumberU64(), summaryInsertCount})
+		bulkInsertQuerys = append(bulkInsertQuerys, &BulkInsertQuery{summaryTable, summaryStr, summaryVals, block.NumberU64(), summaryInsertCount})
 	}
 
 	if txMapInsertCount > 0 {
-		bulkInsertQuerys = append(bulkInsertQuerys, &BulkInsertQuery{txMapStr, txMapVals, block.NumberU64(), txMapInsertCount})
+		bulkInsertQuerys = append(bulkInsertQuerys, &BulkInsertQuery{txHashMapTable, txMapStr, txMapVals, block.NumberU64(), txMapInsertCount})
 	}
 
 	return bulkInsertQuerys, nil
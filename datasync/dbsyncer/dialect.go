@@ -0,0 +1,123 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package dbsyncer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// dbTable describes a table synchronised by dbsyncer. The order of the columns is
+// the order of the values made by the row functions (e.g. MakeTxDBRow).
+type dbTable struct {
+	name    string
+	columns []string
+}
+
+var (
+	blockTable = &dbTable{"block", []string{
+		"totalTx", "committee", "gasUsed", "gasPrice", "hash", "number", "parentHash",
+		"proposer", "reward", "size", "timestamp", "timestampFoS",
+	}}
+	txTable = &dbTable{"transaction", []string{
+		"id", "blockHash", "blockNumber", "contractAddress", "from", "gas", "gasPrice", "gasUsed",
+		"input", "nonce", "status", "to", "timestamp", "txHash", "type", "value", "feePayer",
+		"feeRatio", "senderTxHash",
+	}}
+	summaryTable = &dbTable{"account_summary", []string{
+		"address", "type", "creator", "created_tx", "hra",
+	}}
+	txHashMapTable = &dbTable{"sendertxhash_map", []string{
+		"senderTxHash", "txHash",
+	}}
+)
+
+// rowPlaceholder returns the placeholders of a row of the table, e.g. "(?,?,?)".
+func (t *dbTable) rowPlaceholder() string {
+	return "(" + strings.TrimSuffix(strings.Repeat("?,", len(t.columns)), ",") + ")"
+}
+
+// sqlConn is implemented by both *sql.DB and *sql.Tx.
+type sqlConn interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// dialect hides the differences of the databases which dbsyncer can synchronise to.
+type dialect interface {
+	driverName() string
+	dataSource(cfg *DBConfig) string
+
+	// insertQuery returns the beginning of a bulk insert query of the table.
+	// The rows made by the row functions are appended to it with a comma.
+	insertQuery(dbName string, t *dbTable) string
+
+	// bulkInsert inserts the rows into the table. query is the bulk insert query
+	// made of insertQuery, and vals has the values of all rows in order.
+	bulkInsert(ctx context.Context, conn sqlConn, t *dbTable, query string, vals []interface{}, rows int) error
+
+	// migrations returns the schema migrations applied when dbsyncer starts.
+	migrations() []migration
+}
+
+func newDialect(dbType string) (dialect, error) {
+	switch dbType {
+	case MYSQL:
+		return &mysqlDialect{}, nil
+	case POSTGRES:
+		return &postgresDialect{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported db type (mysql, postgres): %v", dbType)
+	}
+}
+
+type mysqlDialect struct{}
+
+func (d *mysqlDialect) driverName() string {
+	return "mysql"
+}
+
+func (d *mysqlDialect) dataSource(cfg *DBConfig) string {
+	return cfg.DBUser + ":" + cfg.DBPassword + "@tcp(" + cfg.DBHost + ":" +
+		cfg.DBPort + ")/" + cfg.DBName + "?writeTimeout=10s&timeout=10s"
+}
+
+func (d *mysqlDialect) insertQuery(dbName string, t *dbTable) string {
+	return "INSERT INTO " + dbName + "." + t.name + " (`" + strings.Join(t.columns, "`, `") + "`) VALUES "
+}
+
+func (d *mysqlDialect) bulkInsert(ctx context.Context, conn sqlConn, t *dbTable, query string, vals []interface{}, rows int) error {
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		logger.Error("fail to create prepare", "sql", query, "err", err)
+		return err
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			logger.Error("fail to close stmt", "err", err)
+		}
+	}()
+
+	_, err = stmt.ExecContext(ctx, vals...)
+	return err
+}
+
+// The mysql schema is managed outside of dbsyncer.
+func (d *mysqlDialect) migrations() []migration {
+	return nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package dbsyncer
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"net/url"
+
+	"github.com/lib/pq"
+)
+
+// postgresDialect loads the rows with COPY instead of multi-row INSERT statements,
+// so the query text made of insertQuery is not used.
+type postgresDialect struct{}
+
+func (d *postgresDialect) driverName() string {
+	return "postgres"
+}
+
+func (d *postgresDialect) dataSource(cfg *DBConfig) string {
+	dsn := &url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.DBUser, cfg.DBPassword),
+		Host:     net.JoinHostPort(cfg.DBHost, cfg.DBPort),
+		Path:     "/" + cfg.DBName,
+		RawQuery: url.Values{"sslmode": {cfg.DBSSLMode}, "connect_timeout": {"10"}}.Encode(),
+	}
+	return dsn.String()
+}
+
+func (d *postgresDialect) insertQuery(dbName string, t *dbTable) string {
+	return pq.CopyIn(t.name, t.columns...)
+}
+
+func (d *postgresDialect) bulkInsert(ctx context.Context, conn sqlConn, t *dbTable, query string, vals []interface{}, rows int) error {
+	// COPY must be run in a transaction. It joins the transaction if the given connection is the one.
+	tx, ok := conn.(*sql.Tx)
+	if !ok {
+		db := conn.(*sql.DB)
+		var err error
+		if tx, err = db.BeginTx(ctx, nil); err != nil {
+			return err
+		}
+		if err := d.copyIn(ctx, tx, t, vals, rows); err != nil {
+			if rerr := tx.Rollback(); rerr != nil {
+				logger.Error("fail to rollback tx", "err", rerr)
+			}
+			return err
+		}
+		return tx.Commit()
+	}
+	return d.copyIn(ctx, tx, t, vals, rows)
+}
+
+func (d *postgresDialect) copyIn(ctx context.Context, tx *sql.Tx, t *dbTable, vals []interface{}, rows int) error {
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn(t.name, t.columns...))
+	if err != nil {
+		logger.Error("fail to prepare copy", "table", t.name, "err", err)
+		return err
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			logger.Error("fail to close stmt", "err", err)
+		}
+	}()
+
+	numColumns := len(t.columns)
+	for i := 0; i < rows; i++ {
+		if _, err := stmt.ExecContext(ctx, vals[i*numColumns:(i+1)*numColumns]...); err != nil {
+			return err
+		}
+	}
+	// flush the buffered rows
+	_, err = stmt.ExecContext(ctx)
+	return err
+}
+
+func (d *postgresDialect) migrations() []migration {
+	return []migration{
+		{
+			version: 1,
+			statements: []string{
+				`CREATE TABLE IF NOT EXISTS block (
+					"number"       BIGINT PRIMARY KEY,
+					"totalTx"      INTEGER NOT NULL,
+					"committee"    TEXT NOT NULL,
+					"gasUsed"      BIGINT NOT NULL,
+					"gasPrice"     BIGINT NOT NULL,
+					"hash"         VARCHAR(66) NOT NULL,
+					"parentHash"   VARCHAR(66) NOT NULL,
+					"proposer"     VARCHAR(42) NOT NULL,
+					"reward"       VARCHAR(42) NOT NULL,
+					"size"         BIGINT NOT NULL,
+					"timestamp"    BIGINT NOT NULL,
+					"timestampFoS" SMALLINT NOT NULL
+				)`,
+				`CREATE UNIQUE INDEX IF NOT EXISTS block_hash_idx ON block ("hash")`,
+				`CREATE TABLE IF NOT EXISTS transaction (
+					"id"              BIGINT PRIMARY KEY,
+					"blockHash"       VARCHAR(66) NOT NULL,
+					"blockNumber"     BIGINT NOT NULL,
+					"contractAddress" VARCHAR(42) NOT NULL,
+					"from"            VARCHAR(42) NOT NULL,
+					"gas"             BIGINT NOT NULL,
+					"gasPrice"        NUMERIC(78) NOT NULL,
+					"gasUsed"         BIGINT NOT NULL,
+					"input"           TEXT NOT NULL,
+					"nonce"           NUMERIC(20) NOT NULL,
+					"status"          BIGINT NOT NULL,
+					"to"              VARCHAR(42) NOT NULL,
+					"timestamp"       BIGINT NOT NULL,
+					"txHash"          VARCHAR(66) NOT NULL,
+					"type"            VARCHAR(64) NOT NULL,
+					"value"           NUMERIC(78) NOT NULL,
+					"feePayer"        VARCHAR(42) NOT NULL,
+					"feeRatio"        SMALLINT NOT NULL,
+					"senderTxHash"    VARCHAR(66) NOT NULL
+				)`,
+				`CREATE INDEX IF NOT EXISTS transaction_block_number_idx ON transaction ("blockNumber")`,
+				`CREATE INDEX IF NOT EXISTS transaction_tx_hash_idx ON transaction ("txHash")`,
+				`CREATE INDEX IF NOT EXISTS transaction_from_idx ON transaction ("from")`,
+				`CREATE INDEX IF NOT EXISTS transaction_to_idx ON transaction ("to")`,
+				`CREATE TABLE IF NOT EXISTS account_summary (
+					"address"    VARCHAR(42) NOT NULL,
+					"type"       SMALLINT NOT NULL,
+					"creator"    VARCHAR(42) NOT NULL,
+					"created_tx" VARCHAR(66) NOT NULL,
+					"hra"        BOOLEAN NOT NULL
+				)`,
+				`CREATE INDEX IF NOT EXISTS account_summary_address_idx ON account_summary ("address")`,
+				`CREATE TABLE IF NOT EXISTS sendertxhash_map (
+					"senderTxHash" VARCHAR(66) PRIMARY KEY,
+					"txHash"       VARCHAR(66) NOT NULL
+				)`,
+			},
+		},
+	}
+}
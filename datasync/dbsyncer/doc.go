@@ -15,17 +15,20 @@
 // along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
 
 /*
-Package dbsyncer implements blockchain data synchronisation to relational database.
+Package dbsyncer implements blockchain data synchronisation to relational database (MySQL or PostgreSQL).
 
 Source Files
 
-  - config.go         : includes configurations, mostly related to the connected database
-  - dbsync.go         : implements data synchronisation operations
-  - dbsync_context.go : provides context for chain event, block header, transactions and bulk inserts
-  - dbsync_multi.go   : supports parallel synchronisation
-  - gen_config.go     : is automatically generated from config.go
-  - query_engine.go   : supports query level requests and results
-  - tx_record.go      : manages transaction data handling
-  - utils.go          : includes utility functions for dbsyncer package
+  - config.go           : includes configurations, mostly related to the connected database
+  - dbsync.go           : implements data synchronisation operations
+  - dbsync_context.go   : provides context for chain event, block header, transactions and bulk inserts
+  - dbsync_multi.go     : supports parallel synchronisation
+  - dialect.go          : hides the differences of the supported databases and implements the MySQL dialect
+  - dialect_postgres.go : implements the PostgreSQL dialect loading rows with COPY
+  - gen_config.go       : is automatically generated from config.go
+  - query_engine.go     : supports query level requests and results
+  - schema.go           : applies versioned schema migrations
+  - tx_record.go        : manages transaction data handling
+  - utils.go            : includes utility functions for dbsyncer package
 */
 package dbsyncer
@@ -11,11 +11,13 @@ func (d DBConfig) MarshalTOML() (interface{}, error) {
 	type DBConfig struct {
 		EnabledDBSyncer  bool
 		EnabledLogMode   bool
+		DBType           string        `toml:",omitempty"`
 		DBHost           string        `toml:",omitempty"`
 		DBPort           string        `toml:",omitempty"`
 		DBUser           string        `toml:",omitempty"`
 		DBPassword       string        `toml:",omitempty"`
 		DBName           string        `toml:",omitempty"`
+		DBSSLMode        string        `toml:",omitempty"`
 		MaxIdleConns     int           `toml:",omitempty"`
 		MaxOpenConns     int           `toml:",omitempty"`
 		ConnMaxLifetime  time.Duration `toml:",omitempty"`
@@ -30,11 +32,13 @@ func (d DBConfig) MarshalTOML() (interface{}, error) {
 	var enc DBConfig
 	enc.EnabledDBSyncer = d.EnabledDBSyncer
 	enc.EnabledLogMode = d.EnabledLogMode
+	enc.DBType = d.DBType
 	enc.DBHost = d.DBHost
 	enc.DBPort = d.DBPort
 	enc.DBUser = d.DBUser
 	enc.DBPassword = d.DBPassword
 	enc.DBName = d.DBName
+	enc.DBSSLMode = d.DBSSLMode
 	enc.MaxIdleConns = d.MaxIdleConns
 	enc.MaxOpenConns = d.MaxOpenConns
 	enc.ConnMaxLifetime = d.ConnMaxLifetime
@@ -53,11 +57,13 @@ func (d *DBConfig) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	type DBConfig struct {
 		EnabledDBSyncer  *bool
 		EnabledLogMode   *bool
+		DBType           *string        `toml:",omitempty"`
 		DBHost           *string        `toml:",omitempty"`
 		DBPort           *string        `toml:",omitempty"`
 		DBUser           *string        `toml:",omitempty"`
 		DBPassword       *string        `toml:",omitempty"`
 		DBName           *string        `toml:",omitempty"`
+		DBSSLMode        *string        `toml:",omitempty"`
 		MaxIdleConns     *int           `toml:",omitempty"`
 		MaxOpenConns     *int           `toml:",omitempty"`
 		ConnMaxLifetime  *time.Duration `toml:",omitempty"`
@@ -79,6 +85,9 @@ func (d *DBConfig) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.EnabledLogMode != nil {
 		d.EnabledLogMode = *dec.EnabledLogMode
 	}
+	if dec.DBType != nil {
+		d.DBType = *dec.DBType
+	}
 	if dec.DBHost != nil {
 		d.DBHost = *dec.DBHost
 	}
@@ -94,6 +103,9 @@ func (d *DBConfig) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.DBName != nil {
 		d.DBName = *dec.DBName
 	}
+	if dec.DBSSLMode != nil {
+		d.DBSSLMode = *dec.DBSSLMode
+	}
 	if dec.MaxIdleConns != nil {
 		d.MaxIdleConns = *dec.MaxIdleConns
 	}
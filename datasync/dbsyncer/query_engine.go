@@ -124,7 +124,7 @@ func (qe *QueryEngine) execute(insertQuery *BulkInsertQuery, result chan *BulkIn
 			return
 		}
 	}()
-	if err := qe.ds.bulkInsert(insertQuery.parameters, insertQuery.vals, insertQuery.blockNumber, insertQuery.insertCount); err != nil {
+	if err := qe.ds.bulkInsert(insertQuery.table, insertQuery.parameters, insertQuery.vals, insertQuery.blockNumber, insertQuery.insertCount); err != nil {
 		logger.Error("fail to bulkinsert (tx/summary/senderHash)", "err", err)
 		result <- &BulkInsertResult{err}
 	}
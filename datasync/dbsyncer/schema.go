@@ -0,0 +1,78 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package dbsyncer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+const schemaVersionTable = "dbsyncer_schema_version"
+
+// migration is a versioned step of the schema. The migrations of a dialect are applied in order
+// of their versions, and the applied version is recorded in schemaVersionTable.
+type migration struct {
+	version    int
+	statements []string
+}
+
+// migrate applies the migrations of the dialect which are not applied to the database yet.
+func migrate(ctx context.Context, db *sql.DB, d dialect) error {
+	migrations := d.migrations()
+	if len(migrations) == 0 {
+		return nil
+	}
+
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+schemaVersionTable+" (version INTEGER NOT NULL)"); err != nil {
+		return err
+	}
+	var current int
+	if err := db.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM "+schemaVersionTable).Scan(&current); err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		if err := applyMigration(ctx, db, m); err != nil {
+			logger.Error("fail to migrate the schema", "version", m.version, "err", err)
+			return err
+		}
+		logger.Info("dbsyncer schema is migrated", "version", m.version)
+	}
+	return nil
+}
+
+func applyMigration(ctx context.Context, db *sql.DB, m migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	statements := append([]string{}, m.statements...)
+	statements = append(statements, fmt.Sprintf("INSERT INTO %s (version) VALUES (%d)", schemaVersionTable, m.version))
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			if rerr := tx.Rollback(); rerr != nil {
+				logger.Error("fail to rollback tx", "err", rerr)
+			}
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -44,6 +44,7 @@ type TxReceiptArgument struct {
 }
 
 type BulkInsertQuery struct {
+	table       *dbTable
 	parameters  string
 	vals        []interface{}
 	blockNumber uint64
@@ -0,0 +1,456 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package downloader
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+// backfillCycle is the time BackfillHistory spends downloading from a peer
+// before giving it back to the regular synchronisation.
+const backfillCycle = 5 * time.Second
+
+var (
+	errCheckpointNotSigned    = errors.New("trusted checkpoint is not signed by enough trusted signers")
+	errCheckpointChainStarted = errors.New("trusted checkpoint can only be applied to an empty chain")
+	errHistoryUnavailable     = errors.New("peer does not serve the history below the trusted checkpoint")
+)
+
+// TrustedCheckpoint is a block header trusted to be canonical, from which a
+// node syncs instead of from the genesis. Along with the header, it carries
+// what the consensus engine needs to verify the blocks after it without their
+// history: the Istanbul validator snapshot at the header, as returned by
+// istanbul_getSnapshot, the governance entries it depends on, and the staking
+// information of the staking blocks before it which the blocks after it use
+// under the weighted random proposer policy.
+//
+// The checkpoint is trusted as long as it is signed by enough of the signers
+// listed in the node configuration.
+type TrustedCheckpoint struct {
+	Header     *types.Header          `json:"header"`
+	Snapshot   json.RawMessage        `json:"snapshot"`
+	Governance []CheckpointGovernance `json:"governance,omitempty"`
+	Staking    []*reward.StakingInfo  `json:"staking,omitempty"`
+	Signatures []hexutil.Bytes        `json:"signatures"`
+}
+
+// CheckpointGovernance is a governance entry as stored in the database, as
+// returned by governance_itemCacheFromDb. A checkpoint needs the entry in effect
+// at it, and all the later ones up to it.
+type CheckpointGovernance struct {
+	Number uint64                 `json:"number"`
+	Items  map[string]interface{} `json:"items"`
+}
+
+// LoadTrustedCheckpoint reads a trusted checkpoint from a JSON file.
+func LoadTrustedCheckpoint(file string) (*TrustedCheckpoint, error) {
+	blob, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	// Keep the governance numbers as they are, so that they are stored as given.
+	dec := json.NewDecoder(bytes.NewReader(blob))
+	dec.UseNumber()
+
+	checkpoint := new(TrustedCheckpoint)
+	if err := dec.Decode(checkpoint); err != nil {
+		return nil, fmt.Errorf("invalid trusted checkpoint %s: %w", file, err)
+	}
+	if err := checkpoint.validate(); err != nil {
+		return nil, fmt.Errorf("invalid trusted checkpoint %s: %w", file, err)
+	}
+	return checkpoint, nil
+}
+
+// validate checks that the parts of the checkpoint are consistent.
+func (c *TrustedCheckpoint) validate() error {
+	if c.Header == nil {
+		return errors.New("missing header")
+	}
+	number := c.Header.Number.Uint64()
+	// The consensus engine only loads the snapshots of the checkpoint intervals.
+	if !params.IsCheckpointInterval(number) {
+		return fmt.Errorf("header #%d is not at a multiple of %d", number, params.CheckpointInterval)
+	}
+	var snapshot struct {
+		Number uint64      `json:"number"`
+		Hash   common.Hash `json:"hash"`
+	}
+	if err := json.Unmarshal(c.Snapshot, &snapshot); err != nil {
+		return fmt.Errorf("invalid snapshot: %w", err)
+	}
+	if snapshot.Number != number || snapshot.Hash != c.Header.Hash() {
+		return fmt.Errorf("snapshot of #%d [%x…] does not match header #%d [%x…]",
+			snapshot.Number, snapshot.Hash[:4], number, c.Header.Hash().Bytes()[:4])
+	}
+	for i, gov := range c.Governance {
+		if gov.Number > number || (i > 0 && gov.Number <= c.Governance[i-1].Number) {
+			return fmt.Errorf("governance entries are not in order up to #%d", number)
+		}
+	}
+	for i, info := range c.Staking {
+		if info == nil || !params.IsStakingUpdateInterval(info.BlockNum) || info.BlockNum > number ||
+			(i > 0 && info.BlockNum <= c.Staking[i-1].BlockNum) {
+			return fmt.Errorf("staking information is not in order on the staking blocks up to #%d", number)
+		}
+	}
+	return nil
+}
+
+// SigHash returns the hash signed by the signers of the checkpoint. It covers
+// the header, the snapshot, the governance entries and the staking information.
+func (c *TrustedCheckpoint) SigHash() (common.Hash, error) {
+	governance, err := json.Marshal(c.Governance)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	staking, err := json.Marshal(c.Staking)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(c.Header.Hash().Bytes(), crypto.Keccak256(c.Snapshot), crypto.Keccak256(governance), crypto.Keccak256(staking)), nil
+}
+
+// Sign adds the signature of the given key to the checkpoint.
+func (c *TrustedCheckpoint) Sign(key *ecdsa.PrivateKey) error {
+	hash, err := c.SigHash()
+	if err != nil {
+		return err
+	}
+	sig, err := crypto.Sign(hash.Bytes(), key)
+	if err != nil {
+		return err
+	}
+	c.Signatures = append(c.Signatures, sig)
+	return nil
+}
+
+// Verify checks that the checkpoint is signed by at least threshold distinct
+// signers among the given ones. A zero threshold requires all of them.
+func (c *TrustedCheckpoint) Verify(signers []common.Address, threshold int) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+	if len(signers) == 0 {
+		return errors.New("no trusted checkpoint signers configured")
+	}
+	if threshold <= 0 || threshold > len(signers) {
+		threshold = len(signers)
+	}
+	hash, err := c.SigHash()
+	if err != nil {
+		return err
+	}
+	trusted := make(map[common.Address]bool, len(signers))
+	for _, signer := range signers {
+		trusted[signer] = true
+	}
+	signed := make(map[common.Address]bool)
+	for _, sig := range c.Signatures {
+		pub, err := crypto.SigToPub(hash.Bytes(), sig)
+		if err != nil {
+			return fmt.Errorf("invalid checkpoint signature: %w", err)
+		}
+		if addr := crypto.PubkeyToAddress(*pub); trusted[addr] {
+			signed[addr] = true
+		}
+	}
+	if len(signed) < threshold {
+		return fmt.Errorf("%w: have %d, want %d", errCheckpointNotSigned, len(signed), threshold)
+	}
+	return nil
+}
+
+// WriteTrustedCheckpoint starts the chain in db from the checkpoint. It stores
+// the checkpoint header as canonical, with the validator snapshot and the
+// governance entries, and marks the blocks between the genesis and the
+// checkpoint as missing. The chain then syncs the blocks after the checkpoint,
+// and the missing ones are backfilled later if at all.
+//
+// It must be called before the governance engine and the blockchain are
+// created on db, as they load their state from it. It does nothing if the
+// checkpoint was already applied, even if the history was backfilled since.
+// The staking information is stored separately by WriteCheckpointStakingInfo.
+func WriteTrustedCheckpoint(db database.DBManager, c *TrustedCheckpoint) error {
+	var (
+		number = c.Header.Number.Uint64()
+		hash   = c.Header.Hash()
+	)
+	if db.ReadCanonicalHash(number) == hash {
+		return nil
+	}
+	genesis := db.ReadCanonicalHash(0)
+	if head := db.ReadHeadHeaderHash(); head != genesis || db.ReadHistoryTail() != 0 {
+		return errCheckpointChainStarted
+	}
+	genesisTd := db.ReadTd(genesis, 0)
+	if genesisTd == nil {
+		return errors.New("genesis block is not stored")
+	}
+	for _, gov := range c.Governance {
+		if err := db.WriteGovernance(gov.Items, gov.Number); err != nil {
+			return err
+		}
+	}
+	if err := db.WriteIstanbulSnapshot(hash, c.Snapshot); err != nil {
+		return err
+	}
+	// Every block after the genesis has a blockscore of 1 under Istanbul.
+	td := new(big.Int).Add(genesisTd, new(big.Int).SetUint64(number))
+
+	db.WriteHeader(c.Header)
+	db.WriteTd(hash, number, td)
+	db.WriteCanonicalHash(hash, number)
+	db.WriteHistoryTail(number)
+
+	logger.Info("Applied trusted checkpoint", "number", number, "hash", hash)
+	return nil
+}
+
+// WriteCheckpointStakingInfo stores the staking information of the checkpoint.
+// It must be called once the staking manager is set, as it stores them in the
+// staking information database of the manager.
+func WriteCheckpointStakingInfo(c *TrustedCheckpoint) error {
+	for _, info := range c.Staking {
+		if err := reward.AddStakingInfoToDB(info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BackfillHistory downloads from the given peer the blocks below the history
+// tail left by a trusted checkpoint, newest first, linking each batch to the
+// oldest block already stored by its hash. It works for up to backfillCycle,
+// so that it can be called again and again between synchronisations, and
+// does nothing once the history is complete.
+//
+// Only the headers and the bodies are backfilled. The receipts and the staking
+// information of the backfilled blocks are not, and the bloom bits indexer
+// cannot index them until the history is complete.
+func (d *Downloader) BackfillHistory(id string) error {
+	if d.stateDB.ReadHistoryTail() == 0 {
+		return nil
+	}
+	if !atomic.CompareAndSwapInt32(&d.synchronising, 0, 1) {
+		return errBusy
+	}
+	defer atomic.StoreInt32(&d.synchronising, 0)
+
+	p := d.peers.Peer(id)
+	if p == nil {
+		return errUnknownPeer
+	}
+	for _, ch := range []chan dataPack{d.headerCh, d.bodyCh} {
+		for empty := false; !empty; {
+			select {
+			case <-ch:
+			default:
+				empty = true
+			}
+		}
+	}
+	d.cancelLock.Lock()
+	d.cancelCh = make(chan struct{})
+	d.cancelPeer = id
+	d.cancelLock.Unlock()
+
+	defer d.Cancel()
+
+	for deadline := time.Now().Add(backfillCycle); time.Now().Before(deadline); {
+		done, err := d.backfillBatch(p)
+		if err != nil || done {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillBatch downloads and stores the headers and bodies of up to
+// MaxHeaderFetch blocks right below the history tail, and lowers the tail
+// accordingly. It reports whether the history is complete.
+func (d *Downloader) backfillBatch(p *peerConnection) (bool, error) {
+	tail := d.stateDB.ReadHistoryTail()
+	if tail == 0 {
+		return true, nil
+	}
+	tailHeader := d.stateDB.ReadHeader(d.stateDB.ReadCanonicalHash(tail), tail)
+	if tailHeader == nil {
+		return false, fmt.Errorf("history tail #%d is not stored", tail)
+	}
+	from := uint64(1)
+	if tail > uint64(MaxHeaderFetch) {
+		from = tail - uint64(MaxHeaderFetch)
+	}
+	headers, err := d.fetchHistoryHeaders(p, from, int(tail-from))
+	if err != nil {
+		return false, err
+	}
+	// Link the headers to the tail by their hashes, and to the genesis at the end.
+	parent := tailHeader.ParentHash
+	for i := len(headers) - 1; i >= 0; i-- {
+		if headers[i].Number.Uint64() != from+uint64(i) || headers[i].Hash() != parent {
+			return false, fmt.Errorf("%w: backfilled header #%d does not link to the history", errInvalidChain, from+uint64(i))
+		}
+		parent = headers[i].ParentHash
+	}
+	if from == 1 && parent != d.stateDB.ReadCanonicalHash(0) {
+		return false, fmt.Errorf("%w: backfilled history does not link to the genesis", errInvalidChain)
+	}
+	// The body of the tail is missing too, if it is the checkpoint.
+	bodies, err := d.fetchHistoryBodies(p, append(headers, tailHeader))
+	if err != nil {
+		return false, err
+	}
+	td := d.stateDB.ReadTd(tailHeader.Hash(), tail)
+	if td == nil {
+		return false, fmt.Errorf("total blockscore of history tail #%d is not stored", tail)
+	}
+	// The total blockscore of a block is the one of its child minus the child's blockscore.
+	child := tailHeader
+	for i := len(headers) - 1; i >= 0; i-- {
+		header, hash := headers[i], headers[i].Hash()
+		td = new(big.Int).Sub(td, child.BlockScore)
+
+		d.stateDB.WriteHeader(header)
+		d.stateDB.WriteTd(hash, header.Number.Uint64(), td)
+		d.stateDB.WriteCanonicalHash(hash, header.Number.Uint64())
+		child = header
+	}
+	for i, header := range append(headers, tailHeader) {
+		block := types.NewBlockWithHeader(header).WithBody(bodies[i])
+		d.stateDB.WriteBody(block.Hash(), block.NumberU64(), block.Body())
+		d.stateDB.WriteTxLookupEntries(block)
+	}
+	if from == 1 {
+		d.stateDB.DeleteHistoryTail()
+		logger.Info("History backfill complete")
+		return true, nil
+	}
+	d.stateDB.WriteHistoryTail(from)
+	p.logger.Debug("Backfilled history", "from", from, "to", tail-1)
+	return false, nil
+}
+
+// fetchHistoryHeaders retrieves count consecutive headers from the peer,
+// starting at the given number.
+func (d *Downloader) fetchHistoryHeaders(p *peerConnection, from uint64, count int) ([]*types.Header, error) {
+	go p.peer.RequestHeadersByNumber(from, count, 0, false)
+
+	ttl := d.requestTTL()
+	timeout := time.After(ttl)
+	for {
+		select {
+		case <-d.cancelCh:
+			return nil, errCanceled
+
+		case packet := <-d.headerCh:
+			// Discard anything not from the origin peer
+			if packet.PeerId() != p.id {
+				logger.Debug("Received headers from incorrect peer", "peer", packet.PeerId())
+				break
+			}
+			headers := packet.(*headerPack).headers
+			if len(headers) != count {
+				return nil, fmt.Errorf("%w: returned headers %d != requested %d", errHistoryUnavailable, len(headers), count)
+			}
+			return headers, nil
+
+		case <-timeout:
+			p.logger.Debug("Waiting for history headers timed out", "elapsed", ttl)
+			return nil, errTimeout
+
+		case <-d.receiptCh:
+		case <-d.stakingInfoCh:
+			// Out of bounds delivery, ignore
+		}
+	}
+}
+
+// fetchHistoryBodies retrieves the bodies of the given headers from the peer,
+// and checks them against the headers.
+func (d *Downloader) fetchHistoryBodies(p *peerConnection, headers []*types.Header) ([][]*types.Transaction, error) {
+	bodies := make([][]*types.Transaction, 0, len(headers))
+	for len(bodies) < len(headers) {
+		batch := headers[len(bodies):]
+		if len(batch) > MaxBlockFetch {
+			batch = batch[:MaxBlockFetch]
+		}
+		hashes := make([]common.Hash, len(batch))
+		for i, header := range batch {
+			hashes[i] = header.Hash()
+		}
+		go p.peer.RequestBodies(hashes)
+
+		ttl := d.requestTTL()
+		timeout := time.After(ttl)
+		for arrived := false; !arrived; {
+			select {
+			case <-d.cancelCh:
+				return nil, errCanceled
+
+			case packet := <-d.bodyCh:
+				// Discard anything not from the origin peer
+				if packet.PeerId() != p.id {
+					logger.Debug("Received bodies from incorrect peer", "peer", packet.PeerId())
+					break
+				}
+				// The peer may serve a prefix of the request, but nothing else.
+				txLists := packet.(*bodyPack).transactions
+				if len(txLists) == 0 {
+					return nil, fmt.Errorf("%w: no bodies returned", errHistoryUnavailable)
+				}
+				if len(txLists) > len(batch) {
+					return nil, fmt.Errorf("%w: returned bodies %d > requested %d", errBadPeer, len(txLists), len(batch))
+				}
+				for i, txs := range txLists {
+					if types.DeriveSha(types.Transactions(txs), batch[i].Number) != batch[i].TxHash {
+						return nil, errInvalidBody
+					}
+				}
+				bodies = append(bodies, txLists...)
+				arrived = true
+
+			case <-timeout:
+				p.logger.Debug("Waiting for history bodies timed out", "elapsed", ttl)
+				return nil, errTimeout
+
+			case <-d.headerCh:
+			case <-d.receiptCh:
+			case <-d.stakingInfoCh:
+				// Out of bounds delivery, ignore
+			}
+		}
+	}
+	return bodies, nil
+}
@@ -0,0 +1,288 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package downloader
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCheckpoint creates an unsigned checkpoint at the given block number.
+func newTestCheckpoint(number uint64) *TrustedCheckpoint {
+	header := &types.Header{
+		ParentHash: common.HexToHash("0x01"),
+		Number:     new(big.Int).SetUint64(number),
+		BlockScore: common.Big1,
+		Time:       new(big.Int).SetUint64(number),
+	}
+	return &TrustedCheckpoint{
+		Header:   header,
+		Snapshot: json.RawMessage(fmt.Sprintf(`{"number":%d,"hash":"%s"}`, number, header.Hash().Hex())),
+		Governance: []CheckpointGovernance{
+			{Number: 0, Items: map[string]interface{}{"governance.unitprice": json.Number("25000000000")}},
+			{Number: number - params.CheckpointInterval/2, Items: map[string]interface{}{"governance.unitprice": json.Number("50000000000")}},
+		},
+		Staking: []*reward.StakingInfo{{
+			BlockNum:              0,
+			CouncilNodeAddrs:      []common.Address{common.HexToAddress("0x02")},
+			CouncilStakingAddrs:   []common.Address{common.HexToAddress("0x03")},
+			CouncilRewardAddrs:    []common.Address{common.HexToAddress("0x04")},
+			CouncilStakingAmounts: []uint64{5000000},
+			Gini:                  -1,
+		}},
+	}
+}
+
+func newCheckpointSigners(t *testing.T, n int) ([]*ecdsa.PrivateKey, []common.Address) {
+	keys := make([]*ecdsa.PrivateKey, n)
+	addrs := make([]common.Address, n)
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys[i], addrs[i] = key, crypto.PubkeyToAddress(key.PublicKey)
+	}
+	return keys, addrs
+}
+
+func TestTrustedCheckpoint_Verify(t *testing.T) {
+	keys, signers := newCheckpointSigners(t, 3)
+	outsider, _ := crypto.GenerateKey()
+
+	checkpoint := newTestCheckpoint(params.CheckpointInterval)
+	require.NoError(t, checkpoint.Sign(keys[0]))
+	require.NoError(t, checkpoint.Sign(keys[0]))
+	require.NoError(t, checkpoint.Sign(outsider))
+	require.NoError(t, checkpoint.Sign(keys[1]))
+
+	// Repeated signatures and signatures of unknown keys do not count.
+	assert.NoError(t, checkpoint.Verify(signers, 2))
+	assert.ErrorIs(t, checkpoint.Verify(signers, 3), errCheckpointNotSigned)
+	assert.ErrorIs(t, checkpoint.Verify(signers, 0), errCheckpointNotSigned)
+	assert.Error(t, checkpoint.Verify(nil, 0))
+
+	require.NoError(t, checkpoint.Sign(keys[2]))
+	assert.NoError(t, checkpoint.Verify(signers, 0))
+
+	// The signatures cover the governance entries and the staking information
+	// along with the header.
+	checkpoint.Staking[0].CouncilStakingAmounts[0] = 1
+	assert.ErrorIs(t, checkpoint.Verify(signers, 1), errCheckpointNotSigned)
+	checkpoint.Staking[0].CouncilStakingAmounts[0] = 5000000
+	assert.NoError(t, checkpoint.Verify(signers, 1))
+
+	checkpoint.Governance[1].Items["governance.unitprice"] = json.Number("1")
+	assert.ErrorIs(t, checkpoint.Verify(signers, 1), errCheckpointNotSigned)
+}
+
+func TestTrustedCheckpoint_Validate(t *testing.T) {
+	assert.NoError(t, newTestCheckpoint(params.CheckpointInterval).validate())
+	assert.Error(t, (&TrustedCheckpoint{}).validate())
+
+	// The snapshot of a block that is not at a checkpoint interval is never loaded.
+	assert.Error(t, newTestCheckpoint(params.CheckpointInterval+1).validate())
+
+	checkpoint := newTestCheckpoint(params.CheckpointInterval)
+	checkpoint.Snapshot = newTestCheckpoint(2 * params.CheckpointInterval).Snapshot
+	assert.Error(t, checkpoint.validate())
+
+	checkpoint = newTestCheckpoint(params.CheckpointInterval)
+	checkpoint.Governance[0], checkpoint.Governance[1] = checkpoint.Governance[1], checkpoint.Governance[0]
+	assert.Error(t, checkpoint.validate())
+
+	checkpoint = newTestCheckpoint(params.CheckpointInterval)
+	checkpoint.Governance[1].Number = params.CheckpointInterval + 1
+	assert.Error(t, checkpoint.validate())
+
+	// The staking information is only stored on the staking blocks.
+	checkpoint = newTestCheckpoint(params.CheckpointInterval)
+	checkpoint.Staking[0].BlockNum = 1
+	assert.Error(t, checkpoint.validate())
+
+	checkpoint = newTestCheckpoint(params.CheckpointInterval)
+	checkpoint.Staking = append(checkpoint.Staking, checkpoint.Staking[0])
+	assert.Error(t, checkpoint.validate())
+}
+
+func TestLoadTrustedCheckpoint(t *testing.T) {
+	keys, signers := newCheckpointSigners(t, 1)
+
+	checkpoint := newTestCheckpoint(params.CheckpointInterval)
+	require.NoError(t, checkpoint.Sign(keys[0]))
+
+	blob, err := json.Marshal(checkpoint)
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "checkpoint.json")
+	require.NoError(t, os.WriteFile(file, blob, 0o600))
+
+	loaded, err := LoadTrustedCheckpoint(file)
+	require.NoError(t, err)
+	assert.Equal(t, checkpoint.Header.Hash(), loaded.Header.Hash())
+	assert.Equal(t, checkpoint.Governance, loaded.Governance)
+	assert.Equal(t, checkpoint.Staking, loaded.Staking)
+	assert.NoError(t, loaded.Verify(signers, 1))
+
+	require.NoError(t, os.WriteFile(file, []byte(`{"header":null}`), 0o600))
+	_, err = LoadTrustedCheckpoint(file)
+	assert.Error(t, err)
+}
+
+func TestWriteTrustedCheckpoint(t *testing.T) {
+	db := database.NewMemoryDBManager()
+	genesis := blockchain.GenesisBlockForTesting(db, testAddress, big.NewInt(1000000000))
+
+	checkpoint := newTestCheckpoint(params.CheckpointInterval)
+	number, hash := checkpoint.Header.Number.Uint64(), checkpoint.Header.Hash()
+	require.NoError(t, WriteTrustedCheckpoint(db, checkpoint))
+
+	assert.Equal(t, hash, db.ReadCanonicalHash(number))
+	assert.Equal(t, hash, db.ReadHeader(hash, number).Hash())
+	assert.Equal(t, new(big.Int).Add(db.ReadTd(genesis.Hash(), 0), new(big.Int).SetUint64(number)), db.ReadTd(hash, number))
+	assert.Equal(t, number, db.ReadHistoryTail())
+	assert.Equal(t, genesis.Hash(), db.ReadHeadHeaderHash())
+
+	snapshot, err := db.ReadIstanbulSnapshot(hash)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(checkpoint.Snapshot), string(snapshot))
+
+	items, err := db.ReadGovernance(number - params.CheckpointInterval/2)
+	require.NoError(t, err)
+	assert.Equal(t, float64(50000000000), items["governance.unitprice"])
+
+	// Applying the same checkpoint again is a no-op, but another one is refused.
+	assert.NoError(t, WriteTrustedCheckpoint(db, checkpoint))
+	assert.ErrorIs(t, WriteTrustedCheckpoint(db, newTestCheckpoint(2*params.CheckpointInterval)), errCheckpointChainStarted)
+
+	// The checkpoint is still applied after a restart once the chain moved on
+	// and the history is backfilled.
+	db.WriteHeadHeaderHash(hash)
+	db.DeleteHistoryTail()
+	assert.NoError(t, WriteTrustedCheckpoint(db, checkpoint))
+	assert.Equal(t, uint64(0), db.ReadHistoryTail())
+	assert.ErrorIs(t, WriteTrustedCheckpoint(db, newTestCheckpoint(2*params.CheckpointInterval)), errCheckpointChainStarted)
+}
+
+func TestWriteCheckpointStakingInfo(t *testing.T) {
+	db := database.NewMemoryDBManager()
+	orig := reward.GetStakingManager()
+	defer reward.SetTestStakingManager(orig)
+	reward.SetTestStakingManagerWithDB(db)
+
+	checkpoint := newTestCheckpoint(params.CheckpointInterval)
+	require.NoError(t, WriteCheckpointStakingInfo(checkpoint))
+
+	blob, err := db.ReadStakingInfo(0)
+	require.NoError(t, err)
+	stored := new(reward.StakingInfo)
+	require.NoError(t, json.Unmarshal(blob, stored))
+	assert.Equal(t, checkpoint.Staking[0], stored)
+}
+
+// startFromCheckpoint makes the tester start from the given header of its
+// peers' chain, as WriteTrustedCheckpoint does: the header is known, but none
+// of the blocks between the genesis and it.
+func (dl *downloadTester) startFromCheckpoint(header *types.Header, td *big.Int) {
+	dl.lock.Lock()
+	defer dl.lock.Unlock()
+
+	number, hash := header.Number.Uint64(), header.Hash()
+	dl.ownHashes = append(dl.ownHashes, hash)
+	dl.ownHeaders[hash] = header
+	dl.ownChainTd[hash] = td
+	dl.checkpoint = hash
+
+	dl.stateDb.WriteCanonicalHash(dl.genesis.Hash(), 0)
+	dl.stateDb.WriteHeader(header)
+	dl.stateDb.WriteTd(hash, number, td)
+	dl.stateDb.WriteCanonicalHash(hash, number)
+	dl.stateDb.WriteHistoryTail(number)
+}
+
+// Tests that a chain started from a trusted checkpoint syncs the blocks after
+// it only, and refuses peers that are behind it.
+func TestCheckpointSynchronisation(t *testing.T) {
+	tester := newTester()
+	defer tester.terminate()
+
+	const checkpoint = 300
+	targetBlocks := 600
+	hashes, headers, blocks, receipts, stakingInfos := tester.makeChain(targetBlocks, 0, tester.genesis, nil, false)
+	tester.newPeer("peer", 66, hashes, headers, blocks, receipts, stakingInfos)
+	tester.newPeer("behind", 66, hashes[targetBlocks-checkpoint+10:], headers, blocks, receipts, stakingInfos)
+
+	cpHash := hashes[targetBlocks-checkpoint]
+	tester.startFromCheckpoint(headers[cpHash], tester.peerChainTds["peer"][cpHash])
+
+	assert.ErrorIs(t, tester.sync("behind", nil, FastSync), errBehindCheckpoint)
+	require.NoError(t, tester.sync("peer", nil, FastSync))
+
+	assert.Equal(t, uint64(targetBlocks), tester.CurrentFastBlock().NumberU64())
+	for i, hash := range hashes[:len(hashes)-1] {
+		number := uint64(targetBlocks - i)
+		assert.Equal(t, number > checkpoint, tester.HasBlock(hash, number), "block #%d", number)
+		assert.Equal(t, number >= checkpoint, tester.HasHeader(hash, number), "header #%d", number)
+	}
+}
+
+// Tests that the history below a trusted checkpoint is backfilled from a peer.
+func TestBackfillHistory(t *testing.T) {
+	tester := newTester()
+	defer tester.terminate()
+
+	const checkpoint = 300
+	targetBlocks := 400
+	hashes, headers, blocks, receipts, stakingInfos := tester.makeChain(targetBlocks, 0, tester.genesis, nil, false)
+	tester.newPeer("peer", 66, hashes, headers, blocks, receipts, stakingInfos)
+
+	cpHash := hashes[targetBlocks-checkpoint]
+	tester.startFromCheckpoint(headers[cpHash], tester.peerChainTds["peer"][cpHash])
+
+	// Every call backfills MaxHeaderFetch blocks at least, and the last one ends it.
+	for i := 0; tester.stateDb.ReadHistoryTail() != 0; i++ {
+		require.Less(t, i, checkpoint/MaxHeaderFetch+1)
+		require.NoError(t, tester.downloader.BackfillHistory("peer"))
+	}
+	assert.NoError(t, tester.downloader.BackfillHistory("peer"))
+
+	for i := targetBlocks - checkpoint; i < len(hashes)-1; i++ {
+		hash, number := hashes[i], uint64(targetBlocks-i)
+		assert.Equal(t, hash, tester.stateDb.ReadCanonicalHash(number))
+		assert.Equal(t, tester.peerChainTds["peer"][hash], tester.stateDb.ReadTd(hash, number), "td #%d", number)
+
+		body := tester.stateDb.ReadBody(hash, number)
+		require.NotNil(t, body, "body #%d", number)
+		assert.Equal(t, len(blocks[hash].Transactions()), len(body.Transactions))
+		for _, tx := range blocks[hash].Transactions() {
+			txHash, _, _ := tester.stateDb.ReadTxLookupEntry(tx.Hash())
+			assert.Equal(t, hash, txHash)
+		}
+	}
+}
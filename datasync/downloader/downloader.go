@@ -94,6 +94,7 @@ var (
 	errCanceled                = errors.New("syncing canceled (requested)")
 	errNoSyncActive            = errors.New("no sync active")
 	errTooOld                  = errors.New("peer doesn't speak recent enough protocol version (need version >= 62)")
+	errBehindCheckpoint        = errors.New("remote chain does not reach past the trusted checkpoint")
 )
 
 type Downloader struct {
@@ -287,13 +288,33 @@ func (d *Downloader) Progress() klaytn.SyncProgress {
 	case LightSync:
 		current = d.lightchain.CurrentHeader().Number.Uint64()
 	}
-	return klaytn.SyncProgress{
+	progress := klaytn.SyncProgress{
 		StartingBlock: d.syncStatsChainOrigin,
 		CurrentBlock:  current,
 		HighestBlock:  d.syncStatsChainHeight,
 		PulledStates:  d.syncStatsState.processed,
 		KnownStates:   d.syncStatsState.processed + d.syncStatsState.pending,
 	}
+	if mode == SnapSync && d.SnapSyncer != nil {
+		if synced, pending := d.SnapSyncer.Progress(); synced != nil {
+			progress.SnapSyncing = true
+			progress.SnapHealing = pending.Healing
+			progress.SnapETA = pending.ETA
+			progress.SyncedAccounts = synced.AccountSynced
+			progress.SyncedAccountBytes = uint64(synced.AccountBytes)
+			progress.SyncedBytecodes = synced.BytecodeSynced
+			progress.SyncedBytecodeBytes = uint64(synced.BytecodeBytes)
+			progress.SyncedStorage = synced.StorageSynced
+			progress.SyncedStorageBytes = uint64(synced.StorageBytes)
+			progress.HealedTrienodes = synced.TrienodeHealSynced
+			progress.HealedTrienodeBytes = uint64(synced.TrienodeHealBytes)
+			progress.HealedBytecodes = synced.BytecodeHealSynced
+			progress.HealedBytecodeBytes = uint64(synced.BytecodeHealBytes)
+			progress.HealingTrienodes = pending.TrienodeHeal
+			progress.HealingBytecode = pending.BytecodeHeal
+		}
+	}
+	return progress
 }
 
 func (d *Downloader) getMode() SyncMode {
@@ -500,6 +521,11 @@ func (d *Downloader) syncWithPeer(p *peerConnection, hash common.Hash, td *big.I
 	}
 	height := latest.Number.Uint64()
 
+	// A chain started from a trusted checkpoint has nothing to sync below it
+	tail := d.stateDB.ReadHistoryTail()
+	if height < tail {
+		return errBehindCheckpoint
+	}
 	origin, err := d.findAncestor(p, height)
 	if err != nil {
 		return err
@@ -521,6 +547,9 @@ func (d *Downloader) syncWithPeer(p *peerConnection, hash common.Hash, td *big.I
 				origin = pivotNumber - 1
 			}
 		}
+		if origin < tail {
+			return errBehindCheckpoint
+		}
 	}
 	d.committed = 1
 	if (mode == FastSync || mode == SnapSync) && pivot.Number.Uint64() != 0 {
@@ -818,6 +847,16 @@ func (d *Downloader) findAncestor(p *peerConnection, height uint64) (uint64, err
 	if ceil >= MaxForkAncestry {
 		floor = int64(ceil - MaxForkAncestry)
 	}
+	// If the chain started from a trusted checkpoint, the checkpoint is the
+	// oldest block the ancestor can be, even before its first block is synced.
+	if tail := d.stateDB.ReadHistoryTail(); tail > 0 {
+		if ceil < tail {
+			ceil = tail
+		}
+		if floor < int64(tail)-1 {
+			floor = int64(tail) - 1
+		}
+	}
 	p.logger.Debug("Looking for common ancestor", "local", ceil, "remote", height)
 
 	// Request the topmost blocks to short circuit binary ancestor lookup
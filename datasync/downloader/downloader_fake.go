@@ -61,3 +61,4 @@ func (*FakeDownloader) Cancel()                       {}
 func (*FakeDownloader) GetSnapSyncer() *snap.Syncer                      { return nil }
 func (*FakeDownloader) SyncStakingInfo(id string, from, to uint64) error { return nil }
 func (*FakeDownloader) SyncStakingInfoStatus() *SyncingStatus            { return nil }
+func (*FakeDownloader) BackfillHistory(id string) error                  { return nil }
@@ -111,6 +111,7 @@ type downloadTester struct {
 	ownReceipts    map[common.Hash]types.Receipts      // Receipts belonging to the tester
 	ownStakingInfo map[common.Hash]*reward.StakingInfo // Staking info belonging to the tester
 	ownChainTd     map[common.Hash]*big.Int            // Total difficulties of the blocks in the local chain
+	checkpoint     common.Hash                         // Trusted checkpoint the local chain started from, if any
 
 	peerHashes       map[string][]common.Hash                       // Hash chain belonging to different test peers
 	peerHeaders      map[string]map[common.Hash]*types.Header       // Headers belonging to different test peers
@@ -453,7 +454,7 @@ func (dl *downloadTester) InsertReceiptChain(blocks types.Blocks, receipts []typ
 		if _, ok := dl.ownHeaders[blocks[i].Hash()]; !ok {
 			return i, errors.New("unknown owner")
 		}
-		if _, ok := dl.ownBlocks[blocks[i].ParentHash()]; !ok {
+		if _, ok := dl.ownBlocks[blocks[i].ParentHash()]; !ok && blocks[i].ParentHash() != dl.checkpoint {
 			return i, errors.New("InsertReceiptChain: unknown parent")
 		}
 		dl.ownBlocks[blocks[i].Hash()] = blocks[i]
@@ -814,6 +815,8 @@ func TestCanonicalSynchronisation64Light(t *testing.T) {
 }
 func TestCanonicalSynchronisation65Full(t *testing.T) { testCanonicalSynchronisation(t, 65, FullSync) }
 func TestCanonicalSynchronisation65Fast(t *testing.T) { testCanonicalSynchronisation(t, 65, FastSync) }
+func TestCanonicalSynchronisation66Full(t *testing.T) { testCanonicalSynchronisation(t, 66, FullSync) }
+func TestCanonicalSynchronisation66Fast(t *testing.T) { testCanonicalSynchronisation(t, 66, FastSync) }
 
 func testCanonicalSynchronisation(t *testing.T, protocol int, mode SyncMode) {
 	t.Parallel()
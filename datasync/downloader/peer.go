@@ -535,7 +535,7 @@ func (ps *peerSet) HeaderIdlePeers() ([]*peerConnection, int) {
 		defer p.lock.RUnlock()
 		return p.headerThroughput
 	}
-	return ps.idlePeers(62, 65, idleCheck, throughput)
+	return ps.idlePeers(62, 66, idleCheck, throughput)
 }
 
 // BodyIdlePeers retrieves a flat list of all the currently body-idle peers within
@@ -549,7 +549,7 @@ func (ps *peerSet) BodyIdlePeers() ([]*peerConnection, int) {
 		defer p.lock.RUnlock()
 		return p.blockThroughput
 	}
-	return ps.idlePeers(62, 65, idleCheck, throughput)
+	return ps.idlePeers(62, 66, idleCheck, throughput)
 }
 
 // ReceiptIdlePeers retrieves a flat list of all the currently receipt-idle peers
@@ -563,7 +563,7 @@ func (ps *peerSet) ReceiptIdlePeers() ([]*peerConnection, int) {
 		defer p.lock.RUnlock()
 		return p.receiptThroughput
 	}
-	return ps.idlePeers(63, 65, idleCheck, throughput)
+	return ps.idlePeers(63, 66, idleCheck, throughput)
 }
 
 func (ps *peerSet) StakingInfoIdlePeers() ([]*peerConnection, int) {
@@ -575,7 +575,7 @@ func (ps *peerSet) StakingInfoIdlePeers() ([]*peerConnection, int) {
 		defer p.lock.RUnlock()
 		return p.stakingInfoThroughput
 	}
-	return ps.idlePeers(65, 65, idleCheck, throughput)
+	return ps.idlePeers(65, 66, idleCheck, throughput)
 }
 
 // NodeDataIdlePeers retrieves a flat list of all the currently node-data-idle
@@ -589,7 +589,7 @@ func (ps *peerSet) NodeDataIdlePeers() ([]*peerConnection, int) {
 		defer p.lock.RUnlock()
 		return p.stateThroughput
 	}
-	return ps.idlePeers(63, 65, idleCheck, throughput)
+	return ps.idlePeers(63, 66, idleCheck, throughput)
 }
 
 // TODO-Klaytn-Downloader when idlePeers is called magic numbers are used for minProtocol and maxProtocol. Use a constant instead.
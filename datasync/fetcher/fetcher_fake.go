@@ -44,3 +44,25 @@ func (*FakeFetcher) Notify(peer string, hash common.Hash, number uint64, time ti
 }
 func (*FakeFetcher) Start() {}
 func (*FakeFetcher) Stop()  {}
+
+// FakeTxFetcher does not fetch announced transactions, but still adds the
+// broadcast transactions to the pool.
+type FakeTxFetcher struct {
+	addTxs txAddFn
+}
+
+func NewFakeTxFetcher(addTxs txAddFn) *FakeTxFetcher {
+	logger.Warn("transaction fetcher is disabled; announced transactions will not be fetched from peers")
+	return &FakeTxFetcher{addTxs: addTxs}
+}
+
+func (*FakeTxFetcher) Notify(peer string, hashes []common.Hash) error { return nil }
+func (f *FakeTxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool) error {
+	if len(txs) > 0 {
+		f.addTxs(txs)
+	}
+	return nil
+}
+func (*FakeTxFetcher) Drop(peer string) error { return nil }
+func (*FakeTxFetcher) Start()                 {}
+func (*FakeTxFetcher) Stop()                  {}
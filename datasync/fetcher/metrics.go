@@ -44,3 +44,12 @@ var (
 	bodyFilterInMeter    = metrics.NewRegisteredMeter("cn/fetcher/filter/bodies/in", nil)
 	bodyFilterOutMeter   = metrics.NewRegisteredMeter("cn/fetcher/filter/bodies/out", nil)
 )
+
+var (
+	txAnnounceInMeter     = metrics.NewRegisteredMeter("cn/fetcher/transaction/announces/in", nil)
+	txAnnounceKnownMeter  = metrics.NewRegisteredMeter("cn/fetcher/transaction/announces/known", nil)
+	txAnnounceDOSMeter    = metrics.NewRegisteredMeter("cn/fetcher/transaction/announces/dos", nil)
+	txRequestOutMeter     = metrics.NewRegisteredMeter("cn/fetcher/transaction/request/out", nil)
+	txRequestTimeoutMeter = metrics.NewRegisteredMeter("cn/fetcher/transaction/request/timeout", nil)
+	txReplyInMeter        = metrics.NewRegisteredMeter("cn/fetcher/transaction/replies/in", nil)
+)
@@ -0,0 +1,436 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package fetcher
+
+import (
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+)
+
+const (
+	// MaxTxAnnounces is the maximum number of unique transactions a peer may have
+	// announced and not yet delivered. Announcements above it are dropped.
+	MaxTxAnnounces = 4096
+
+	// MaxTxRetrievals is the maximum number of transactions to retrieve from a
+	// peer in a single request.
+	MaxTxRetrievals = 256
+
+	txArriveTimeout = 500 * time.Millisecond // Time allowance before an announced transaction is explicitly requested
+	txGatherSlack   = 100 * time.Millisecond // Interval used to collate the announcements and to check the requests
+	txFetchTimeout  = 5 * time.Second        // Maximum allotted time to return an explicitly requested transaction
+)
+
+// txRetrievalFn is a callback type for checking if a transaction is already known.
+type txRetrievalFn func(common.Hash) bool
+
+// txAddFn is a callback type for adding a batch of transactions to the pool.
+type txAddFn func(types.Transactions)
+
+// txPenaltyFn is a callback type for penalizing a peer which did not answer a
+// request in time (timeout) or delivered transactions nobody requested.
+type txPenaltyFn func(peer string, timeout bool)
+
+// TxRequesterFn is a callback type for sending a transaction retrieval request
+// to the given peer.
+type TxRequesterFn func(peer string, hashes []common.Hash) error
+
+// txAnnounce is the hash notification of the availability of a batch of new
+// transactions in the network.
+type txAnnounce struct {
+	origin string        // Identifier of the peer originating the notification
+	hashes []common.Hash // Batch of transaction hashes being announced
+}
+
+// txDelivery is the notification that a batch of transactions have been added
+// to the pool and should be untracked.
+type txDelivery struct {
+	origin string        // Identifier of the peer delivering the transactions
+	hashes []common.Hash // Batch of transaction hashes having been delivered
+	direct bool          // Whether this is a direct reply to a request or a broadcast
+}
+
+// txRequest represents an in-flight transaction retrieval request to a peer.
+type txRequest struct {
+	hashes []common.Hash // Transactions having been requested
+	time   time.Time     // Timestamp of the request
+}
+
+// TxFetcher is responsible for retrieving new transactions based on hash
+// announcements. An announced transaction first waits a short while for a full
+// broadcast from any peer, then is requested from a single announcing peer at a
+// time; the other announcing peers are kept as alternates in case the request
+// fails or times out.
+type TxFetcher struct {
+	notify  chan *txAnnounce
+	cleanup chan *txDelivery
+	drop    chan string
+	quit    chan struct{}
+
+	// Stage 1: Waiting for a full broadcast to arrive
+	waitlist  map[common.Hash]map[string]struct{} // Transactions waiting for a broadcast, with the announcing peers
+	waittime  map[common.Hash]time.Time           // Timestamps of the first announcement of the waiting transactions
+	waitslots map[string]map[common.Hash]struct{} // Waiting announcements grouped by peer (DoS protection)
+
+	// Stage 2: Queued for retrieval or being retrieved
+	announces map[string]map[common.Hash]struct{} // Transactions which can be retrieved from each peer
+	announced map[common.Hash]map[string]struct{} // Peers which can provide each transaction
+	fetching  map[common.Hash]string              // Transactions being retrieved and the peer retrieving them
+	requests  map[string]*txRequest               // In-flight retrieval requests per peer
+
+	// Callbacks
+	hasTx    txRetrievalFn // Checks if a transaction is already in the pool
+	addTxs   txAddFn       // Adds a batch of transactions to the pool
+	fetchTxs TxRequesterFn // Retrieves a batch of transactions from a peer
+	penalize txPenaltyFn   // Penalizes a misbehaving peer, may be nil
+}
+
+// NewTxFetcher creates a transaction fetcher to retrieve transactions based on
+// hash announcements. penalize is called for the peers which time out or deliver
+// unrequested transactions, if it is not nil.
+func NewTxFetcher(hasTx txRetrievalFn, addTxs txAddFn, fetchTxs TxRequesterFn, penalize txPenaltyFn) *TxFetcher {
+	return &TxFetcher{
+		notify:    make(chan *txAnnounce),
+		cleanup:   make(chan *txDelivery),
+		drop:      make(chan string),
+		quit:      make(chan struct{}),
+		waitlist:  make(map[common.Hash]map[string]struct{}),
+		waittime:  make(map[common.Hash]time.Time),
+		waitslots: make(map[string]map[common.Hash]struct{}),
+		announces: make(map[string]map[common.Hash]struct{}),
+		announced: make(map[common.Hash]map[string]struct{}),
+		fetching:  make(map[common.Hash]string),
+		requests:  make(map[string]*txRequest),
+		hasTx:     hasTx,
+		addTxs:    addTxs,
+		fetchTxs:  fetchTxs,
+		penalize:  penalize,
+	}
+}
+
+// Notify announces the fetcher of the potential availability of a batch of new
+// transactions in the network.
+func (f *TxFetcher) Notify(peer string, hashes []common.Hash) error {
+	txAnnounceInMeter.Mark(int64(len(hashes)))
+
+	// Skip the transactions already in the pool, as there is no need to track
+	// them in the fetcher loop.
+	unknowns := make([]common.Hash, 0, len(hashes))
+	for _, hash := range hashes {
+		if !f.hasTx(hash) {
+			unknowns = append(unknowns, hash)
+		}
+	}
+	txAnnounceKnownMeter.Mark(int64(len(hashes) - len(unknowns)))
+	if len(unknowns) == 0 {
+		return nil
+	}
+	select {
+	case f.notify <- &txAnnounce{origin: peer, hashes: unknowns}:
+		return nil
+	case <-f.quit:
+		return errTerminated
+	}
+}
+
+// Enqueue adds a batch of transactions received from a peer to the pool, and
+// stops tracking them in the fetcher. direct is true if the transactions are
+// the reply to a retrieval request of the fetcher.
+func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool) error {
+	if direct {
+		txReplyInMeter.Mark(int64(len(txs)))
+	}
+	hashes := make([]common.Hash, 0, len(txs))
+	for _, tx := range txs {
+		hashes = append(hashes, tx.Hash())
+	}
+	if len(txs) > 0 {
+		f.addTxs(txs)
+	}
+	select {
+	case f.cleanup <- &txDelivery{origin: peer, hashes: hashes, direct: direct}:
+		return nil
+	case <-f.quit:
+		return errTerminated
+	}
+}
+
+// Drop stops tracking the announcements and the requests of a disconnected peer.
+func (f *TxFetcher) Drop(peer string) error {
+	select {
+	case f.drop <- peer:
+		return nil
+	case <-f.quit:
+		return errTerminated
+	}
+}
+
+// Start boots up the transaction fetcher.
+func (f *TxFetcher) Start() {
+	go f.loop()
+}
+
+// Stop terminates the transaction fetcher, canceling all pending operations.
+func (f *TxFetcher) Stop() {
+	close(f.quit)
+}
+
+func (f *TxFetcher) loop() {
+	ticker := time.NewTicker(txGatherSlack)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case ann := <-f.notify:
+			f.handleAnnounce(ann, time.Now())
+
+		case delivery := <-f.cleanup:
+			f.handleDelivery(delivery)
+			f.scheduleFetches(time.Now())
+
+		case peer := <-f.drop:
+			f.handleDrop(peer)
+			f.scheduleFetches(time.Now())
+
+		case <-ticker.C:
+			now := time.Now()
+			f.expireRequests(now)
+			f.promoteWaiting(now)
+			f.scheduleFetches(now)
+
+		case <-f.quit:
+			return
+		}
+	}
+}
+
+// handleAnnounce tracks the announced transactions. Transactions already
+// scheduled for retrieval get the announcing peer as an alternate source, while
+// new ones are put on the waitlist for a full broadcast to arrive.
+func (f *TxFetcher) handleAnnounce(ann *txAnnounce, now time.Time) {
+	peer := ann.origin
+	for _, hash := range ann.hashes {
+		if _, ok := f.waitslots[peer][hash]; ok {
+			continue
+		}
+		if _, ok := f.announces[peer][hash]; ok {
+			continue
+		}
+		if len(f.waitslots[peer])+len(f.announces[peer]) >= MaxTxAnnounces {
+			txAnnounceDOSMeter.Mark(1)
+			continue
+		}
+		if peers, ok := f.announced[hash]; ok {
+			peers[peer] = struct{}{}
+			addTxHash(f.announces, peer, hash)
+			continue
+		}
+		if _, ok := f.waitlist[hash]; !ok {
+			f.waitlist[hash] = make(map[string]struct{})
+			f.waittime[hash] = now
+		}
+		f.waitlist[hash][peer] = struct{}{}
+		addTxHash(f.waitslots, peer, hash)
+	}
+}
+
+// promoteWaiting schedules the retrieval of the transactions which have not
+// arrived by broadcast in time. Transactions which have been added to the pool
+// in the meantime are not tracked anymore.
+func (f *TxFetcher) promoteWaiting(now time.Time) {
+	for hash, waited := range f.waittime {
+		if now.Sub(waited) < txArriveTimeout {
+			continue
+		}
+		if f.hasTx(hash) {
+			f.untrack(hash)
+			continue
+		}
+		peers := f.waitlist[hash]
+		for peer := range peers {
+			removeTxHash(f.waitslots, peer, hash)
+			addTxHash(f.announces, peer, hash)
+		}
+		f.announced[hash] = peers
+		delete(f.waitlist, hash)
+		delete(f.waittime, hash)
+	}
+}
+
+// scheduleFetches requests the scheduled transactions from the idle peers. Each
+// transaction is requested from a single peer at a time, and transactions which
+// have been added to the pool in the meantime are not requested.
+func (f *TxFetcher) scheduleFetches(now time.Time) {
+	for peer, hashes := range f.announces {
+		if _, ok := f.requests[peer]; ok {
+			continue
+		}
+		var request []common.Hash
+		for hash := range hashes {
+			if _, ok := f.fetching[hash]; ok {
+				continue
+			}
+			if f.hasTx(hash) {
+				f.untrack(hash)
+				continue
+			}
+			request = append(request, hash)
+			if len(request) == MaxTxRetrievals {
+				break
+			}
+		}
+		if len(request) == 0 {
+			continue
+		}
+		for _, hash := range request {
+			f.fetching[hash] = peer
+		}
+		f.requests[peer] = &txRequest{hashes: request, time: now}
+		txRequestOutMeter.Mark(int64(len(request)))
+
+		go func(peer string, hashes []common.Hash) {
+			if err := f.fetchTxs(peer, hashes); err != nil {
+				logger.Debug("Failed to request transactions", "peer", peer, "count", len(hashes), "err", err)
+				f.Drop(peer)
+			}
+		}(peer, request)
+	}
+}
+
+// expireRequests fails the requests which have not been answered in time. The
+// slow peer is not asked for the same transactions again, which are requested
+// from the alternates instead.
+func (f *TxFetcher) expireRequests(now time.Time) {
+	for peer, req := range f.requests {
+		if now.Sub(req.time) < txFetchTimeout {
+			continue
+		}
+		txRequestTimeoutMeter.Mark(int64(len(req.hashes)))
+		logger.Debug("Transaction request timed out", "peer", peer, "count", len(req.hashes))
+		if f.penalize != nil {
+			f.penalize(peer, true)
+		}
+
+		for _, hash := range req.hashes {
+			if f.fetching[hash] == peer {
+				delete(f.fetching, hash)
+			}
+			f.forgetSource(peer, hash)
+		}
+		delete(f.requests, peer)
+	}
+}
+
+// handleDelivery stops tracking the delivered transactions. If the delivery is a
+// reply to a request, the requested transactions missing from it are requested
+// from the alternates.
+func (f *TxFetcher) handleDelivery(delivery *txDelivery) {
+	for _, hash := range delivery.hashes {
+		f.untrack(hash)
+	}
+	if !delivery.direct {
+		return
+	}
+	req, ok := f.requests[delivery.origin]
+	if !ok {
+		// Nothing was requested from the peer.
+		if f.penalize != nil {
+			f.penalize(delivery.origin, false)
+		}
+		return
+	}
+	delete(f.requests, delivery.origin)
+	for _, hash := range req.hashes {
+		if f.fetching[hash] != delivery.origin {
+			continue
+		}
+		// The peer does not have the transaction anymore.
+		delete(f.fetching, hash)
+		f.forgetSource(delivery.origin, hash)
+	}
+}
+
+// untrack stops tracking the transaction for all peers. An in-flight request of
+// the transaction is left to be answered or to expire.
+func (f *TxFetcher) untrack(hash common.Hash) {
+	for peer := range f.waitlist[hash] {
+		removeTxHash(f.waitslots, peer, hash)
+	}
+	delete(f.waitlist, hash)
+	delete(f.waittime, hash)
+
+	for peer := range f.announced[hash] {
+		removeTxHash(f.announces, peer, hash)
+	}
+	delete(f.announced, hash)
+	delete(f.fetching, hash)
+}
+
+// handleDrop stops tracking the announcements and the requests of a peer. Its
+// in-flight transactions are requested from the alternates.
+func (f *TxFetcher) handleDrop(peer string) {
+	for hash := range f.waitslots[peer] {
+		delete(f.waitlist[hash], peer)
+		if len(f.waitlist[hash]) == 0 {
+			delete(f.waitlist, hash)
+			delete(f.waittime, hash)
+		}
+	}
+	delete(f.waitslots, peer)
+
+	if req, ok := f.requests[peer]; ok {
+		for _, hash := range req.hashes {
+			if f.fetching[hash] == peer {
+				delete(f.fetching, hash)
+			}
+		}
+		delete(f.requests, peer)
+	}
+	for hash := range f.announces[peer] {
+		f.forgetSource(peer, hash)
+	}
+}
+
+// forgetSource removes the peer from the sources of the transaction, and stops
+// tracking the transaction if no sources remain.
+func (f *TxFetcher) forgetSource(peer string, hash common.Hash) {
+	removeTxHash(f.announces, peer, hash)
+	if peers, ok := f.announced[hash]; ok {
+		delete(peers, peer)
+		if len(peers) == 0 {
+			delete(f.announced, hash)
+		}
+	}
+}
+
+func addTxHash(set map[string]map[common.Hash]struct{}, peer string, hash common.Hash) {
+	if _, ok := set[peer]; !ok {
+		set[peer] = make(map[common.Hash]struct{})
+	}
+	set[peer][hash] = struct{}{}
+}
+
+func removeTxHash(set map[string]map[common.Hash]struct{}, peer string, hash common.Hash) {
+	if hashes, ok := set[peer]; ok {
+		delete(hashes, hash)
+		if len(hashes) == 0 {
+			delete(set, peer)
+		}
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package fetcher
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type txFetchRequest struct {
+	peer   string
+	hashes []common.Hash
+}
+
+// newTestTxFetcher creates a transaction fetcher which records the retrieval
+// requests instead of sending them.
+func newTestTxFetcher(fetchErr error) (*TxFetcher, chan txFetchRequest) {
+	requests := make(chan txFetchRequest, 16)
+	f := NewTxFetcher(
+		func(common.Hash) bool { return false },
+		func(types.Transactions) {},
+		func(peer string, hashes []common.Hash) error {
+			requests <- txFetchRequest{peer, hashes}
+			return fetchErr
+		},
+		nil,
+	)
+	return f, requests
+}
+
+func waitTxRequest(t *testing.T, requests chan txFetchRequest) txFetchRequest {
+	select {
+	case req := <-requests:
+		sort.Slice(req.hashes, func(i, j int) bool { return req.hashes[i].Big().Cmp(req.hashes[j].Big()) < 0 })
+		return req
+	case <-time.After(time.Second):
+		t.Fatal("transactions not requested")
+	}
+	return txFetchRequest{}
+}
+
+func assertNoTxRequest(t *testing.T, requests chan txFetchRequest) {
+	select {
+	case req := <-requests:
+		t.Fatalf("unexpected request: %v", req)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func testTxHashes(n int) []common.Hash {
+	hashes := make([]common.Hash, n)
+	for i := range hashes {
+		hashes[i] = common.BigToHash(big.NewInt(int64(i + 1)))
+	}
+	return hashes
+}
+
+// Tests that announced transactions wait for a broadcast first, and are then
+// requested from a single announcing peer only.
+func TestTxFetcher_AnnounceDedup(t *testing.T) {
+	f, requests := newTestTxFetcher(nil)
+	hashes := testTxHashes(2)
+	now := time.Now()
+
+	f.handleAnnounce(&txAnnounce{origin: "A", hashes: hashes}, now)
+	f.handleAnnounce(&txAnnounce{origin: "B", hashes: hashes}, now)
+
+	// Nothing is requested before the arrival timeout.
+	f.promoteWaiting(now.Add(txArriveTimeout / 2))
+	f.scheduleFetches(now)
+	assertNoTxRequest(t, requests)
+
+	f.promoteWaiting(now.Add(txArriveTimeout))
+	f.scheduleFetches(now.Add(txArriveTimeout))
+	req := waitTxRequest(t, requests)
+	assert.Equal(t, hashes, req.hashes)
+	assertNoTxRequest(t, requests)
+
+	// The delivery stops tracking the transactions for all peers.
+	f.handleDelivery(&txDelivery{origin: req.peer, hashes: hashes, direct: true})
+	assert.Empty(t, f.announces)
+	assert.Empty(t, f.announced)
+	assert.Empty(t, f.fetching)
+	assert.Empty(t, f.requests)
+}
+
+// Tests that transactions broadcast before the arrival timeout are not requested.
+func TestTxFetcher_BroadcastBeforeTimeout(t *testing.T) {
+	f, requests := newTestTxFetcher(nil)
+	hashes := testTxHashes(2)
+	now := time.Now()
+
+	f.handleAnnounce(&txAnnounce{origin: "A", hashes: hashes}, now)
+	f.handleDelivery(&txDelivery{origin: "B", hashes: hashes[:1]})
+
+	f.promoteWaiting(now.Add(txArriveTimeout))
+	f.scheduleFetches(now.Add(txArriveTimeout))
+	req := waitTxRequest(t, requests)
+	assert.Equal(t, "A", req.peer)
+	assert.Equal(t, hashes[1:], req.hashes)
+	assert.Empty(t, f.waitslots)
+}
+
+// Tests that timed out transactions are requested from the alternate peers.
+func TestTxFetcher_TimeoutAlternate(t *testing.T) {
+	f, requests := newTestTxFetcher(nil)
+	hashes := testTxHashes(1)
+	now := time.Now()
+
+	f.handleAnnounce(&txAnnounce{origin: "A", hashes: hashes}, now)
+	f.handleAnnounce(&txAnnounce{origin: "B", hashes: hashes}, now)
+	f.promoteWaiting(now.Add(txArriveTimeout))
+	f.scheduleFetches(now.Add(txArriveTimeout))
+	first := waitTxRequest(t, requests)
+
+	// Not expired yet.
+	f.expireRequests(now.Add(txArriveTimeout + txFetchTimeout/2))
+	f.scheduleFetches(now.Add(txArriveTimeout + txFetchTimeout/2))
+	assertNoTxRequest(t, requests)
+
+	expired := now.Add(txArriveTimeout + txFetchTimeout)
+	f.expireRequests(expired)
+	f.scheduleFetches(expired)
+	second := waitTxRequest(t, requests)
+	assert.NotEqual(t, first.peer, second.peer)
+	assert.Equal(t, hashes, second.hashes)
+
+	// Without alternates left, the transaction is forgotten.
+	f.expireRequests(expired.Add(txFetchTimeout))
+	f.scheduleFetches(expired.Add(txFetchTimeout))
+	assertNoTxRequest(t, requests)
+	assert.Empty(t, f.announced)
+	assert.Empty(t, f.fetching)
+}
+
+// Tests that the transactions missing from a reply are requested from the
+// alternate peers.
+func TestTxFetcher_PartialReply(t *testing.T) {
+	f, requests := newTestTxFetcher(nil)
+	hashes := testTxHashes(2)
+	now := time.Now()
+
+	f.handleAnnounce(&txAnnounce{origin: "A", hashes: hashes}, now)
+	f.promoteWaiting(now.Add(txArriveTimeout))
+	f.handleAnnounce(&txAnnounce{origin: "B", hashes: hashes}, now)
+	f.scheduleFetches(now.Add(txArriveTimeout))
+
+	first := waitTxRequest(t, requests)
+	f.handleDelivery(&txDelivery{origin: first.peer, hashes: hashes[:1], direct: true})
+	f.scheduleFetches(now.Add(txArriveTimeout))
+
+	second := waitTxRequest(t, requests)
+	assert.NotEqual(t, first.peer, second.peer)
+	assert.Equal(t, hashes[1:], second.hashes)
+}
+
+// Tests that the in-flight transactions of a dropped peer are requested from the
+// alternate peers, and that failed requests drop the peer.
+func TestTxFetcher_Drop(t *testing.T) {
+	f, requests := newTestTxFetcher(nil)
+	hashes := testTxHashes(1)
+	now := time.Now()
+
+	f.handleAnnounce(&txAnnounce{origin: "A", hashes: hashes}, now)
+	f.handleAnnounce(&txAnnounce{origin: "B", hashes: hashes}, now)
+	f.handleAnnounce(&txAnnounce{origin: "C", hashes: testTxHashes(2)[1:]}, now)
+	f.handleDrop("C")
+	assert.NotContains(t, f.waitslots, "C")
+	assert.Len(t, f.waitlist, 1)
+
+	f.promoteWaiting(now.Add(txArriveTimeout))
+	f.scheduleFetches(now.Add(txArriveTimeout))
+	first := waitTxRequest(t, requests)
+
+	f.handleDrop(first.peer)
+	f.scheduleFetches(now.Add(txArriveTimeout))
+	second := waitTxRequest(t, requests)
+	assert.NotEqual(t, first.peer, second.peer)
+
+	// A failing request drops the peer through the fetcher loop.
+	f, requests = newTestTxFetcher(errors.New("failed"))
+	f.Start()
+	defer f.Stop()
+	require.NoError(t, f.Notify("A", hashes))
+
+	req := waitTxRequest(t, requests)
+	assert.Equal(t, "A", req.peer)
+}
+
+// Tests that the peers which time out or deliver unrequested transactions are
+// penalized.
+func TestTxFetcher_Penalize(t *testing.T) {
+	type penalty struct {
+		peer    string
+		timeout bool
+	}
+	var penalties []penalty
+	f, requests := newTestTxFetcher(nil)
+	f.penalize = func(peer string, timeout bool) { penalties = append(penalties, penalty{peer, timeout}) }
+	hashes := testTxHashes(1)
+	now := time.Now()
+
+	f.handleAnnounce(&txAnnounce{origin: "A", hashes: hashes}, now)
+	f.promoteWaiting(now.Add(txArriveTimeout))
+	f.scheduleFetches(now.Add(txArriveTimeout))
+	waitTxRequest(t, requests)
+
+	f.expireRequests(now.Add(txArriveTimeout + txFetchTimeout))
+	assert.Equal(t, []penalty{{"A", true}}, penalties)
+
+	// Broadcasts are not penalized, but unrequested replies are.
+	f.handleDelivery(&txDelivery{origin: "B", hashes: hashes})
+	assert.Len(t, penalties, 1)
+	f.handleDelivery(&txDelivery{origin: "B", hashes: hashes, direct: true})
+	assert.Equal(t, []penalty{{"A", true}, {"B", false}}, penalties)
+}
+
+// Tests that the number of pending announcements of a peer is capped.
+func TestTxFetcher_AnnounceLimit(t *testing.T) {
+	f, _ := newTestTxFetcher(nil)
+	hashes := testTxHashes(MaxTxAnnounces + 10)
+	now := time.Now()
+
+	f.handleAnnounce(&txAnnounce{origin: "A", hashes: hashes}, now)
+	assert.Len(t, f.waitslots["A"], MaxTxAnnounces)
+
+	f.promoteWaiting(now.Add(txArriveTimeout))
+	f.handleAnnounce(&txAnnounce{origin: "A", hashes: hashes}, now)
+	assert.Len(t, f.announces["A"], MaxTxAnnounces)
+	assert.Empty(t, f.waitslots["A"])
+}
+
+// Tests that the transactions already in the pool are not tracked.
+func TestTxFetcher_NotifyKnown(t *testing.T) {
+	hashes := testTxHashes(2)
+	var added types.Transactions
+	f := NewTxFetcher(
+		func(hash common.Hash) bool { return hash == hashes[0] },
+		func(txs types.Transactions) { added = append(added, txs...) },
+		func(string, []common.Hash) error { return nil },
+		nil,
+	)
+	f.Start()
+
+	require.NoError(t, f.Notify("A", hashes))
+	tx := types.NewTransaction(0, common.Address{}, big.NewInt(0), 0, big.NewInt(0), nil)
+	require.NoError(t, f.Enqueue("B", []*types.Transaction{tx}, false))
+	f.Stop()
+
+	assert.Len(t, added, 1)
+	assert.ErrorIs(t, f.Notify("A", hashes), errTerminated)
+	assert.ErrorIs(t, f.Drop("A"), errTerminated)
+}
+
+// Tests that a broadcast of an announced transaction cancels its pending
+// retrieval, both while waiting and after being scheduled.
+func TestTxFetcher_BroadcastCancelsFetch(t *testing.T) {
+	f, requests := newTestTxFetcher(nil)
+	hashes := testTxHashes(2)
+	now := time.Now()
+
+	f.handleAnnounce(&txAnnounce{origin: "A", hashes: hashes}, now)
+	f.promoteWaiting(now.Add(txArriveTimeout))
+	f.handleDelivery(&txDelivery{origin: "B", hashes: hashes})
+
+	f.scheduleFetches(now.Add(txArriveTimeout))
+	assertNoTxRequest(t, requests)
+	assert.Empty(t, f.announces)
+	assert.Empty(t, f.announced)
+}
+
+// Tests that the transactions added to the pool by other means while being
+// tracked are not requested.
+func TestTxFetcher_KnownBeforeFetch(t *testing.T) {
+	hashes := testTxHashes(3)
+	known := make(map[common.Hash]bool)
+	requests := make(chan txFetchRequest, 16)
+	f := NewTxFetcher(
+		func(hash common.Hash) bool { return known[hash] },
+		func(types.Transactions) {},
+		func(peer string, hashes []common.Hash) error {
+			requests <- txFetchRequest{peer, hashes}
+			return nil
+		},
+		nil,
+	)
+	now := time.Now()
+
+	f.handleAnnounce(&txAnnounce{origin: "A", hashes: hashes}, now)
+	known[hashes[0]] = true
+	f.promoteWaiting(now.Add(txArriveTimeout))
+	assert.NotContains(t, f.announced, hashes[0])
+
+	known[hashes[1]] = true
+	f.scheduleFetches(now.Add(txArriveTimeout))
+	req := waitTxRequest(t, requests)
+	assert.Equal(t, hashes[2:], req.hashes)
+	assert.NotContains(t, f.announced, hashes[1])
+}
@@ -32,6 +32,7 @@ require (
 	github.com/jackpal/go-nat-pmp v1.0.2
 	github.com/jinzhu/gorm v1.9.15
 	github.com/julienschmidt/httprouter v1.3.0
+	github.com/lib/pq v1.10.2
 	github.com/linxGnu/grocksdb v1.7.17-0.20230425035833-f16fdbe0eb3c
 	github.com/mattn/go-colorable v0.1.11
 	github.com/mattn/go-isatty v0.0.14
@@ -126,6 +126,8 @@ const (
 	KAS
 	FORK
 	NodeCnGasPrice
+	NodeCNLight
+	AccountsExternal
 
 	// ModuleNameLen should be placed at the end of the list.
 	ModuleNameLen
@@ -203,4 +205,6 @@ var moduleNames = [ModuleNameLen]string{
 	"kas",
 	"fork",
 	"node/cn/gasprice",
+	"node/cn/light",
+	"accounts/external",
 }
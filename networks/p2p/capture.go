@@ -0,0 +1,258 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/networks/p2p/discover"
+	"github.com/klaytn/klaytn/rlp"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+var errRecorderClosed = errors.New("message recorder closed")
+
+// CaptureConfig selects the messages to record, and where to. The recording is
+// disabled if File is empty.
+type CaptureConfig struct {
+	File       string            `toml:",omitempty"` // File to record the messages to
+	Peers      []discover.NodeID `toml:",omitempty"` // Peers whose messages are recorded, all if empty
+	Codes      []uint64          `toml:",omitempty"` // Protocol-relative message codes recorded, all if empty
+	MaxSize    int               `toml:",omitempty"` // Size in megabytes at which the file is rotated
+	MaxBackups int               `toml:",omitempty"` // Number of rotated files kept, all if zero
+}
+
+// CapturedMsg is a message exchanged with a peer, as recorded by a MsgRecorder.
+type CapturedMsg struct {
+	Time     uint64 // Unix time in nanoseconds when the message was read or written
+	Peer     discover.NodeID
+	Protocol string
+	Inbound  bool
+	Code     uint64 // Message code relative to the protocol
+	Payload  []byte
+}
+
+// Msg returns the captured message as it was read from or written to the
+// protocol.
+func (m *CapturedMsg) Msg() Msg {
+	return Msg{
+		Code:       m.Code,
+		Size:       uint32(len(m.Payload)),
+		Payload:    bytes.NewReader(m.Payload),
+		ReceivedAt: time.Unix(0, int64(m.Time)),
+	}
+}
+
+// MsgRecorder records the messages the protocols exchange with the selected
+// peers to a rotating file, for debugging. Each file is a sequence of
+// RLP-encoded CapturedMsgs, which ReadCapture reads back.
+type MsgRecorder struct {
+	peers map[discover.NodeID]bool
+	codes map[uint64]bool
+
+	lock   sync.Mutex
+	out    io.WriteCloser
+	closed bool
+}
+
+// NewMsgRecorder creates a recorder writing to the file of the config.
+func NewMsgRecorder(cfg CaptureConfig) (*MsgRecorder, error) {
+	if cfg.File == "" {
+		return nil, errors.New("no capture file")
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.File), 0o700); err != nil {
+		return nil, err
+	}
+	r := &MsgRecorder{
+		out: &lumberjack.Logger{
+			Filename:   cfg.File,
+			MaxSize:    cfg.MaxSize,
+			MaxBackups: cfg.MaxBackups,
+		},
+	}
+	if len(cfg.Peers) > 0 {
+		r.peers = make(map[discover.NodeID]bool, len(cfg.Peers))
+		for _, id := range cfg.Peers {
+			r.peers[id] = true
+		}
+	}
+	if len(cfg.Codes) > 0 {
+		r.codes = make(map[uint64]bool, len(cfg.Codes))
+		for _, code := range cfg.Codes {
+			r.codes[code] = true
+		}
+	}
+	return r, nil
+}
+
+// Hook returns a ProtocolRWHook recording the messages of the selected peers
+// and wrapping the MsgReadWriters further with next, if set.
+func (r *MsgRecorder) Hook(next ProtocolRWHook) ProtocolRWHook {
+	return func(p *Peer, protocol string, rw MsgReadWriter) MsgReadWriter {
+		if next != nil {
+			rw = next(p, protocol, rw)
+		}
+		if r.peers != nil && !r.peers[p.ID()] {
+			return rw
+		}
+		return &recordingRW{MsgReadWriter: rw, recorder: r, peer: p.ID(), protocol: protocol}
+	}
+}
+
+// record writes a message to the file.
+func (r *MsgRecorder) record(msg *CapturedMsg) error {
+	blob, err := rlp.EncodeToBytes(msg)
+	if err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.closed {
+		return errRecorderClosed
+	}
+	// The file is only rotated between writes, so a message is never split.
+	_, err = r.out.Write(blob)
+	return err
+}
+
+// Close stops the recording and closes the file.
+func (r *MsgRecorder) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	return r.out.Close()
+}
+
+// recordingRW is a MsgReadWriter recording the messages it reads and writes.
+type recordingRW struct {
+	MsgReadWriter
+
+	recorder *MsgRecorder
+	peer     discover.NodeID
+	protocol string
+}
+
+func (rw *recordingRW) ReadMsg() (Msg, error) {
+	msg, err := rw.MsgReadWriter.ReadMsg()
+	if err != nil {
+		return msg, err
+	}
+	return rw.capture(msg, true)
+}
+
+func (rw *recordingRW) WriteMsg(msg Msg) error {
+	msg, err := rw.capture(msg, false)
+	if err != nil {
+		return err
+	}
+	return rw.MsgReadWriter.WriteMsg(msg)
+}
+
+// capture records the message, and returns it with its payload to be read again.
+func (rw *recordingRW) capture(msg Msg, inbound bool) (Msg, error) {
+	if rw.recorder.codes != nil && !rw.recorder.codes[msg.Code] {
+		return msg, nil
+	}
+	payload, err := io.ReadAll(msg.Payload)
+	if err != nil {
+		return msg, err
+	}
+	msg.Payload = bytes.NewReader(payload)
+
+	err = rw.recorder.record(&CapturedMsg{
+		Time:     uint64(time.Now().UnixNano()),
+		Peer:     rw.peer,
+		Protocol: rw.protocol,
+		Inbound:  inbound,
+		Code:     msg.Code,
+		Payload:  payload,
+	})
+	if err != nil && err != errRecorderClosed {
+		logger.Warn("Failed to record message", "peer", rw.peer, "protocol", rw.protocol, "code", msg.Code, "err", err)
+	}
+	return msg, nil
+}
+
+// Close closes the underlying MsgReadWriter if it implements the io.Closer
+// interface
+func (rw *recordingRW) Close() error {
+	if v, ok := rw.MsgReadWriter.(io.Closer); ok {
+		return v.Close()
+	}
+	return nil
+}
+
+// CaptureFiles returns the files of a recording, oldest first: the rotated
+// files, then the file still being written.
+func CaptureFiles(file string) ([]string, error) {
+	ext := filepath.Ext(file)
+	prefix := strings.TrimSuffix(file, ext) + "-"
+
+	// The rotated files are named after their rotation time, which sorts them.
+	rotated, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(rotated)
+
+	files := rotated
+	if _, err := os.Stat(file); err == nil {
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no capture files at %s", file)
+	}
+	return files, nil
+}
+
+// ReadCapture reads the messages recorded in the given files, in order.
+func ReadCapture(files ...string) ([]*CapturedMsg, error) {
+	var msgs []*CapturedMsg
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		stream := rlp.NewStream(f, 0)
+		for {
+			msg := new(CapturedMsg)
+			if err := stream.Decode(msg); err == io.EOF {
+				break
+			} else if err != nil {
+				f.Close()
+				return nil, fmt.Errorf("invalid capture file %s: %w", file, err)
+			}
+			msgs = append(msgs, msg)
+		}
+		f.Close()
+	}
+	return msgs, nil
+}
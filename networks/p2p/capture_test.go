@@ -0,0 +1,121 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/networks/p2p/discover"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMsgRecorder(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "capture", "msgs.rlp")
+	selected, other := NewPeer(randomID(), "selected", nil), NewPeer(randomID(), "other", nil)
+
+	recorder, err := NewMsgRecorder(CaptureConfig{File: file, Peers: []discover.NodeID{selected.ID()}, Codes: []uint64{1, 2}})
+	require.NoError(t, err)
+	hook := recorder.Hook(nil)
+
+	local, remote := MsgPipe()
+	defer local.Close()
+	rw := hook(selected, "klay", local)
+	assert.Equal(t, MsgReadWriter(local), hook(other, "klay", local))
+
+	// The messages go through unchanged, whether recorded or not.
+	go func() {
+		Send(rw, 1, "hello")
+		Send(rw, 3, "ignored")
+		Send(remote, 2, []uint{4, 5})
+	}()
+	require.NoError(t, ExpectMsg(remote, 1, "hello"))
+	require.NoError(t, ExpectMsg(remote, 3, "ignored"))
+	msg, err := rw.ReadMsg()
+	require.NoError(t, err)
+	var numbers []uint
+	require.NoError(t, msg.Decode(&numbers))
+	assert.Equal(t, []uint{4, 5}, numbers)
+
+	// Nothing is recorded after closing, but the messages still go through.
+	require.NoError(t, recorder.Close())
+	go Send(rw, 1, "after close")
+	require.NoError(t, ExpectMsg(remote, 1, "after close"))
+
+	files, err := CaptureFiles(file)
+	require.NoError(t, err)
+	msgs, err := ReadCapture(files...)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+
+	hello, _ := rlp.EncodeToBytes("hello")
+	assert.Equal(t, selected.ID(), msgs[0].Peer)
+	assert.Equal(t, "klay", msgs[0].Protocol)
+	assert.False(t, msgs[0].Inbound)
+	assert.Equal(t, uint64(1), msgs[0].Code)
+	assert.Equal(t, hello, msgs[0].Payload)
+
+	assert.True(t, msgs[1].Inbound)
+	assert.Equal(t, uint64(2), msgs[1].Code)
+	require.NoError(t, msgs[1].Msg().Decode(&numbers))
+	assert.Equal(t, []uint{4, 5}, numbers)
+	assert.LessOrEqual(t, msgs[0].Time, msgs[1].Time)
+}
+
+// discardRW is a MsgReadWriter discarding the messages written to it.
+type discardRW struct{}
+
+func (discardRW) ReadMsg() (Msg, error) { return Msg{}, io.EOF }
+func (discardRW) WriteMsg(msg Msg) error {
+	_, err := io.Copy(io.Discard, msg.Payload)
+	return err
+}
+
+func TestMsgRecorder_Rotation(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "msgs.rlp")
+	recorder, err := NewMsgRecorder(CaptureConfig{File: file, MaxSize: 1})
+	require.NoError(t, err)
+	rw := recorder.Hook(nil)(NewPeer(randomID(), "peer", nil), "klay", discardRW{})
+
+	// Every message is too big to share a file of 1MB with another. The rotated
+	// files are named after the millisecond of their rotation.
+	for i := 0; i < 3; i++ {
+		require.NoError(t, Send(rw, uint64(i), bytes.Repeat([]byte{byte(i)}, 600*1024)))
+		time.Sleep(2 * time.Millisecond)
+	}
+	require.NoError(t, recorder.Close())
+
+	files, err := CaptureFiles(file)
+	require.NoError(t, err)
+	assert.Len(t, files, 3)
+	assert.Equal(t, file, files[len(files)-1])
+
+	msgs, err := ReadCapture(files...)
+	require.NoError(t, err)
+	require.Len(t, msgs, 3)
+	for i, msg := range msgs {
+		assert.Equal(t, uint64(i), msg.Code)
+	}
+
+	_, err = CaptureFiles(filepath.Join(t.TempDir(), "none.rlp"))
+	assert.Error(t, err)
+}
@@ -129,6 +129,9 @@ func (t fakeTable) GetAuthorizedNodes() []*discover.Node         { return nil }
 func (t fakeTable) PutAuthorizedNodes(nodes []*discover.Node)    {}
 func (t fakeTable) DeleteAuthorizedNodes(nodes []*discover.Node) {}
 
+func (t fakeTable) Bans() map[discover.NodeID]time.Time                { return nil }
+func (t fakeTable) StoreBan(id discover.NodeID, until time.Time) error { return nil }
+
 // This test checks that dynamic dials are launched from discovery results.
 func TestDialStateDynDial(t *testing.T) {
 	runDialTest(t, dialtest{
@@ -966,3 +969,11 @@ func (t *resolveMock) PutAuthorizedNodes(nodes []*discover.Node) {
 func (t *resolveMock) DeleteAuthorizedNodes(nodes []*discover.Node) {
 	panic("implement me")
 }
+
+func (t *resolveMock) Bans() map[discover.NodeID]time.Time {
+	panic("implement me")
+}
+
+func (t *resolveMock) StoreBan(id discover.NodeID, until time.Time) error {
+	panic("implement me")
+}
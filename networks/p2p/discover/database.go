@@ -61,6 +61,7 @@ type nodeDB struct {
 var (
 	nodeDBVersionKey = []byte("version") // Version of the database to flush if changes
 	nodeDBItemPrefix = []byte("n:")      // Identifier to prefix node entries with
+	nodeDBBanPrefix  = []byte("ban:")    // Identifier to prefix peer ban entries with
 
 	nodeDBDiscoverRoot      = ":discover"
 	nodeDBDiscoverPing      = nodeDBDiscoverRoot + ":lastping"
@@ -370,6 +371,38 @@ func nextNode(db *nodeDB, it iterator.Iterator) *Node {
 	return nil
 }
 
+// bans retrieves the ban expiration times of the banned peers. The bans are kept
+// apart from the node entries, so that they outlive the expiration of the nodes.
+func (db *nodeDB) bans() map[NodeID]time.Time {
+	it := db.lvl.NewIterator(util.BytesPrefix(nodeDBBanPrefix), nil)
+	defer it.Release()
+
+	bans := make(map[NodeID]time.Time)
+	for it.Next() {
+		var id NodeID
+		key := it.Key()[len(nodeDBBanPrefix):]
+		if len(key) != len(id) {
+			continue
+		}
+		copy(id[:], key)
+		until, read := binary.Varint(it.Value())
+		if read <= 0 {
+			continue
+		}
+		bans[id] = time.Unix(until, 0)
+	}
+	return bans
+}
+
+// updateBan stores the ban expiration time of a peer. A zero time removes the ban.
+func (db *nodeDB) updateBan(id NodeID, until time.Time) error {
+	key := append(append([]byte{}, nodeDBBanPrefix...), id[:]...)
+	if until.IsZero() {
+		return db.lvl.Delete(key, nil)
+	}
+	return db.storeInt64(key, until.Unix())
+}
+
 // close flushes and closes the database files.
 func (db *nodeDB) close() {
 	close(db.quit)
@@ -398,3 +398,33 @@ func TestNodeDBSelfExpiration(t *testing.T) {
 		t.Errorf("self not evacuated")
 	}
 }
+
+func TestNodeDBBans(t *testing.T) {
+	db, _ := newNodeDB("", Version, NodeID{})
+	defer db.close()
+
+	node := nodeDBExpirationNodes[0].node
+	until := time.Unix(time.Now().Add(time.Hour).Unix(), 0)
+	if err := db.updateBan(node.ID, until); err != nil {
+		t.Fatalf("failed to store ban: %v", err)
+	}
+	if bans := db.bans(); len(bans) != 1 || !bans[node.ID].Equal(until) {
+		t.Errorf("ban mismatch: have %v, want %v", bans, until)
+	}
+	// The bans outlive the expiration of the nodes.
+	if err := db.updateNode(node); err != nil {
+		t.Fatalf("failed to insert node: %v", err)
+	}
+	if err := db.expireNodes(); err != nil {
+		t.Fatalf("failed to expire nodes: %v", err)
+	}
+	if bans := db.bans(); len(bans) != 1 {
+		t.Errorf("ban expired with the node: %v", bans)
+	}
+	if err := db.updateBan(node.ID, time.Time{}); err != nil {
+		t.Fatalf("failed to remove ban: %v", err)
+	}
+	if bans := db.bans(); len(bans) != 0 {
+		t.Errorf("ban not removed: %v", bans)
+	}
+}
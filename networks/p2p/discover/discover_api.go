@@ -18,6 +18,7 @@ package discover
 
 import (
 	"errors"
+	"time"
 )
 
 func (tab *Table) Name() string { return "TableDiscovery" }
@@ -108,3 +109,14 @@ func (tab *Table) DeleteAuthorizedNodes(nodes []*Node) {
 		}
 	}
 }
+
+// Bans returns the ban expiration times of the banned peers in peer database.
+func (tab *Table) Bans() map[NodeID]time.Time {
+	return tab.db.bans()
+}
+
+// StoreBan stores the ban expiration time of a peer in peer database. A zero
+// time removes the ban.
+func (tab *Table) StoreBan(id NodeID, until time.Time) error {
+	return tab.db.updateBan(id, until)
+}
@@ -233,7 +233,16 @@ func (s *simpleStorage) setTable(t *Table) {
 }
 
 func (s *simpleStorage) readRandomNodes(buf []*Node) (n int) {
-	panic("implement me")
+	s.nodesMutex.Lock()
+	defer s.nodesMutex.Unlock()
+	for _, node := range s.shuffle(s.nodes) {
+		if n == len(buf) {
+			break
+		}
+		buf[n] = &(*node)
+		n++
+	}
+	return n
 }
 
 func (s *simpleStorage) add(n *Node) {
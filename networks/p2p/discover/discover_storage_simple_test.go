@@ -320,3 +320,29 @@ func TestSimple_closest(t *testing.T) {
 		}
 	}
 }
+
+func TestSimple_readRandomNodes(t *testing.T) {
+	storage := testStorages[NodeTypePN]
+	storage.init()
+	for _, size := range []int{0, 5, len(storage.nodes), 2 * len(storage.nodes)} {
+		buf := make([]*Node, size)
+		n := storage.readRandomNodes(buf)
+		expected := size
+		if expected > len(storage.nodes) {
+			expected = len(storage.nodes)
+		}
+		if n != expected {
+			t.Errorf("the number of read nodes is wrong. expected: %v, actual: %v", expected, n)
+		}
+		seen := make(map[NodeID]bool)
+		for _, node := range buf[:n] {
+			if !isIn(node, storage.nodes) {
+				t.Errorf("node does not exist in the storage. unknown node: %v", node)
+			}
+			if seen[node.ID] {
+				t.Errorf("node is read twice: %v", node)
+			}
+			seen[node.ID] = true
+		}
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package discover
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	dnsLookupTimeout   = 5 * time.Second  // Timeout of a single TXT record lookup
+	dnsSyncTimeout     = 2 * time.Minute  // Timeout of resolving all configured trees
+	dnsRefreshInterval = 30 * time.Minute // Interval between the resolutions of the trees
+	dnsCacheLimit      = 1000             // Maximum number of cached tree entries
+	dnsMaxTreeEntries  = 10000            // Maximum number of entries of a single tree
+	dnsMaxTrees        = 32               // Maximum number of trees resolved through links
+)
+
+// DNSResolver looks up the TXT records of a domain. It is implemented by
+// net.Resolver.
+type DNSResolver interface {
+	LookupTXT(ctx context.Context, domain string) ([]string, error)
+}
+
+// DNSClient resolves the node trees published as DNS TXT records.
+type DNSClient struct {
+	resolver DNSResolver
+	entries  *lru.Cache // verified entries by subdomain, shared between the syncs
+
+	treesMu sync.Mutex
+	trees   map[string]*DNSTree // last successfully resolved tree by URL
+}
+
+// NewDNSClient creates a client resolving the trees with the given resolver. The
+// system resolver is used if it is nil.
+func NewDNSClient(resolver DNSResolver) *DNSClient {
+	if resolver == nil {
+		resolver = new(net.Resolver)
+	}
+	entries, _ := lru.New(dnsCacheLimit)
+	return &DNSClient{
+		resolver: resolver,
+		entries:  entries,
+		trees:    make(map[string]*DNSTree),
+	}
+}
+
+// SyncTree downloads the complete tree at the given URL. The signature of the
+// root and the hashes of all entries are verified.
+func (c *DNSClient) SyncTree(ctx context.Context, url string) (*DNSTree, error) {
+	link, err := parseDNSLink(url)
+	if err != nil {
+		return nil, err
+	}
+	root, err := c.resolveRoot(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+	t := &DNSTree{root: root, entries: make(map[string]dnsEntry)}
+	if err := c.syncSubtree(ctx, link.domain, root.eroot, t, false); err != nil {
+		return nil, err
+	}
+	if err := c.syncSubtree(ctx, link.domain, root.lroot, t, true); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// SyncNodes resolves the trees at the given URLs and the trees linked from them,
+// and returns the nodes of all trees. The last known content is used for the
+// trees which cannot be resolved at the moment.
+func (c *DNSClient) SyncNodes(ctx context.Context, urls []string) []*Node {
+	var (
+		nodes   []*Node
+		seen    = make(map[NodeID]bool)
+		visited = make(map[string]bool)
+		queue   = append([]string{}, urls...)
+	)
+	for len(queue) > 0 && len(visited) < dnsMaxTrees {
+		url := queue[0]
+		queue = queue[1:]
+		if visited[url] {
+			continue
+		}
+		visited[url] = true
+
+		t, err := c.syncTree(ctx, url)
+		if err != nil {
+			logger.Warn("Failed to resolve DNS node tree", "url", url, "err", err)
+			continue
+		}
+		for _, n := range t.Nodes() {
+			if !seen[n.ID] {
+				seen[n.ID] = true
+				nodes = append(nodes, n)
+			}
+		}
+		queue = append(queue, t.Links()...)
+	}
+	return nodes
+}
+
+// syncTree resolves the tree at the given URL, falling back to the last known
+// content on failure. Trees with a sequence number lower than the known one are
+// rejected.
+func (c *DNSClient) syncTree(ctx context.Context, url string) (*DNSTree, error) {
+	c.treesMu.Lock()
+	last := c.trees[url]
+	c.treesMu.Unlock()
+
+	t, err := c.SyncTree(ctx, url)
+	switch {
+	case err != nil && last != nil:
+		logger.Debug("Using last known DNS node tree", "url", url, "seq", last.Seq(), "err", err)
+		return last, nil
+	case err != nil:
+		return nil, err
+	case last != nil && t.Seq() < last.Seq():
+		logger.Warn("Ignoring DNS node tree with lower sequence number", "url", url, "seq", t.Seq(), "known", last.Seq())
+		return last, nil
+	}
+	c.treesMu.Lock()
+	c.trees[url] = t
+	c.treesMu.Unlock()
+	return t, nil
+}
+
+// syncSubtree adds the entry with the given hash and all its descendants to the
+// tree. Node entries are only allowed below the node root, and link entries only
+// below the link root.
+func (c *DNSClient) syncSubtree(ctx context.Context, domain, hash string, t *DNSTree, links bool) error {
+	if _, ok := t.entries[hash]; ok {
+		return nil
+	}
+	if len(t.entries) >= dnsMaxTreeEntries {
+		return errDNSTooLarge
+	}
+	e, err := c.resolveEntry(ctx, domain, hash)
+	if err != nil {
+		return err
+	}
+	t.entries[hash] = e
+
+	switch e := e.(type) {
+	case *dnsBranch:
+		for _, child := range e.children {
+			if err := c.syncSubtree(ctx, domain, child, t, links); err != nil {
+				return err
+			}
+		}
+	case *dnsLink:
+		if !links {
+			return errDNSLinkInNodes
+		}
+	case *dnsNode:
+		if links {
+			return errDNSNodeInLinks
+		}
+	}
+	return nil
+}
+
+// resolveRoot retrieves the root of the tree and verifies its signature.
+func (c *DNSClient) resolveRoot(ctx context.Context, link *dnsLink) (*dnsRoot, error) {
+	txts, err := c.lookupTXT(ctx, link.domain)
+	if err != nil {
+		return nil, err
+	}
+	for _, txt := range txts {
+		if !strings.HasPrefix(txt, dnsRootPrefix) {
+			continue
+		}
+		root, err := parseDNSRoot(txt)
+		if err != nil {
+			return nil, err
+		}
+		if !root.verifySignature(link.pubkey) {
+			return nil, errDNSInvalidSig
+		}
+		return root, nil
+	}
+	return nil, errDNSNoRoot
+}
+
+// resolveEntry retrieves the entry with the given hash and verifies that its
+// content matches the hash.
+func (c *DNSClient) resolveEntry(ctx context.Context, domain, hash string) (dnsEntry, error) {
+	name := hash + "." + domain
+	if e, ok := c.entries.Get(name); ok {
+		return e.(dnsEntry), nil
+	}
+	txts, err := c.lookupTXT(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, txt := range txts {
+		e, err := parseDNSEntry(txt)
+		if err == errDNSUnknownEntry {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid entry at %s: %v", name, err)
+		}
+		if dnsTextHash(txt) != hash {
+			return nil, errDNSHashMismatch
+		}
+		c.entries.Add(name, e)
+		return e, nil
+	}
+	return nil, fmt.Errorf("no DNS tree entry at %s", name)
+}
+
+func (c *DNSClient) lookupTXT(ctx context.Context, name string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, dnsLookupTimeout)
+	defer cancel()
+	return c.resolver.LookupTXT(ctx, name)
+}
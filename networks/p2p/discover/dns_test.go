@@ -0,0 +1,271 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package discover
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mapResolver is an in-process stand-in of DNS serving the TXT records of a map.
+type mapResolver struct {
+	mu      sync.Mutex
+	records map[string]string
+	lookups int
+}
+
+func newMapResolver(records ...map[string]string) *mapResolver {
+	r := &mapResolver{records: make(map[string]string)}
+	for _, m := range records {
+		r.add(m)
+	}
+	return r
+}
+
+func (r *mapResolver) add(records map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for name, txt := range records {
+		r.records[name] = txt
+	}
+}
+
+func (r *mapResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookups++
+	if txt, ok := r.records[name]; ok {
+		return []string{txt}, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func testDNSNodes(n int, nType NodeType) []*Node {
+	nodes := make([]*Node, n)
+	for i := range nodes {
+		nodes[i] = NewNode(PubkeyID(&newkey().PublicKey), net.IP{127, 0, 0, byte(i + 1)}, 32323, 32323, nil, nType)
+	}
+	return nodes
+}
+
+func signTestTree(t *testing.T, seq uint, nodes []*Node, links []string, domain string) (string, map[string]string) {
+	tree, err := MakeDNSTree(seq, nodes, links)
+	if err != nil {
+		t.Fatalf("failed to make tree: %v", err)
+	}
+	url, err := tree.Sign(newkey(), domain)
+	if err != nil {
+		t.Fatalf("failed to sign tree: %v", err)
+	}
+	return url, tree.ToTXT(domain)
+}
+
+func nodeIDs(nodes []*Node) map[NodeID]bool {
+	ids := make(map[NodeID]bool, len(nodes))
+	for _, n := range nodes {
+		ids[n.ID] = true
+	}
+	return ids
+}
+
+func TestDNSTree_SyncTree(t *testing.T) {
+	for _, n := range []int{0, 1, dnsMaxChildren, 3*dnsMaxChildren*dnsMaxChildren + 1} {
+		t.Run(fmt.Sprintf("%d nodes", n), func(t *testing.T) {
+			nodes := testDNSNodes(n, NodeTypeBN)
+			url, records := signTestTree(t, 7, nodes, nil, "nodes.example.org")
+
+			tree, err := NewDNSClient(newMapResolver(records)).SyncTree(context.Background(), url)
+			if err != nil {
+				t.Fatalf("failed to sync tree: %v", err)
+			}
+			if tree.Seq() != 7 {
+				t.Errorf("wrong seq: have %d, want 7", tree.Seq())
+			}
+			if have, want := nodeIDs(tree.Nodes()), nodeIDs(nodes); !reflect.DeepEqual(have, want) {
+				t.Errorf("wrong nodes: have %d, want %d", len(have), len(want))
+			}
+			for _, n := range tree.Nodes() {
+				if n.NType != NodeTypeBN || n.UDP != 32323 {
+					t.Errorf("wrong node %v", n)
+				}
+			}
+		})
+	}
+}
+
+func TestDNSTree_Deterministic(t *testing.T) {
+	nodes := testDNSNodes(20, NodeTypeEN)
+	reversed := make([]*Node, len(nodes))
+	for i, n := range nodes {
+		reversed[len(nodes)-1-i] = n
+	}
+	tree1, _ := MakeDNSTree(1, nodes, nil)
+	tree2, _ := MakeDNSTree(1, reversed, nil)
+	if tree1.root.content() != tree2.root.content() {
+		t.Errorf("tree root depends on the node order: %s != %s", tree1.root.content(), tree2.root.content())
+	}
+}
+
+func TestDNSTree_Invalid(t *testing.T) {
+	nodes := testDNSNodes(3, NodeTypeEN)
+	url, records := signTestTree(t, 1, nodes, nil, "nodes.example.org")
+	ctx := context.Background()
+
+	// A root signed by another key is rejected.
+	otherURL, _ := signTestTree(t, 1, nodes, nil, "nodes.example.org")
+	if _, err := NewDNSClient(newMapResolver(records)).SyncTree(ctx, otherURL); err != errDNSInvalidSig {
+		t.Errorf("wrong error for foreign signature: %v", err)
+	}
+
+	// A tampered entry is rejected.
+	tampered := newMapResolver(records)
+	for name, txt := range records {
+		if name == "nodes.example.org" || txt[:len(dnsNodePrefix)] != dnsNodePrefix {
+			continue
+		}
+		tampered.add(map[string]string{name: NewNode(PubkeyID(&newkey().PublicKey), net.IP{10, 0, 0, 1}, 1, 1, nil, NodeTypeEN).String()})
+		break
+	}
+	if _, err := NewDNSClient(tampered).SyncTree(ctx, url); err != errDNSHashMismatch {
+		t.Errorf("wrong error for tampered entry: %v", err)
+	}
+
+	// A missing entry fails the sync.
+	missing := newMapResolver()
+	missing.add(map[string]string{"nodes.example.org": records["nodes.example.org"]})
+	if _, err := NewDNSClient(missing).SyncTree(ctx, url); err == nil {
+		t.Error("sync of incomplete tree succeeded")
+	}
+
+	if _, err := NewDNSClient(newMapResolver(records)).SyncTree(ctx, "kni://nodes.example.org"); err == nil {
+		t.Error("sync of invalid URL succeeded")
+	}
+}
+
+func TestDNSClient_SyncNodes(t *testing.T) {
+	var (
+		ctx           = context.Background()
+		bns, ens      = testDNSNodes(2, NodeTypeBN), testDNSNodes(30, NodeTypeEN)
+		enURL, enTree = signTestTree(t, 1, ens, nil, "en.example.org")
+		url, tree     = signTestTree(t, 1, bns, []string{enURL}, "example.org")
+		resolver      = newMapResolver(enTree, tree)
+		client        = NewDNSClient(resolver)
+	)
+	nodes := client.SyncNodes(ctx, []string{url})
+	if have, want := nodeIDs(nodes), nodeIDs(append(bns, ens...)); !reflect.DeepEqual(have, want) {
+		t.Errorf("wrong nodes: have %d, want %d", len(have), len(want))
+	}
+
+	// The cached entries are not looked up again.
+	lookups := resolver.lookups
+	client.SyncNodes(ctx, []string{url})
+	if have := resolver.lookups - lookups; have != 2 {
+		t.Errorf("wrong number of lookups of unchanged trees: have %d, want 2", have)
+	}
+
+	// The last known trees are used while they cannot be resolved.
+	resolver.mu.Lock()
+	resolver.records = make(map[string]string)
+	resolver.mu.Unlock()
+	if nodes := client.SyncNodes(ctx, []string{url}); len(nodes) != len(bns)+len(ens) {
+		t.Errorf("wrong number of last known nodes: have %d, want %d", len(nodes), len(bns)+len(ens))
+	}
+}
+
+func TestDNSClient_SeqRollback(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		key    = newkey()
+		nodes  = testDNSNodes(4, NodeTypeBN)
+		client = NewDNSClient(nil)
+	)
+	sign := func(seq uint, nodes []*Node) (string, map[string]string) {
+		tree, _ := MakeDNSTree(seq, nodes, nil)
+		url, _ := tree.Sign(key, "example.org")
+		return url, tree.ToTXT("example.org")
+	}
+	url, records := sign(2, nodes[:2])
+	client.resolver = newMapResolver(records)
+	client.SyncNodes(ctx, []string{url})
+
+	_, records = sign(1, nodes[2:])
+	client.resolver = newMapResolver(records)
+	if have, want := nodeIDs(client.SyncNodes(ctx, []string{url})), nodeIDs(nodes[:2]); !reflect.DeepEqual(have, want) {
+		t.Error("tree with lower sequence number was accepted")
+	}
+
+	_, records = sign(3, nodes[2:])
+	client.resolver = newMapResolver(records)
+	if have, want := nodeIDs(client.SyncNodes(ctx, []string{url})), nodeIDs(nodes[2:]); !reflect.DeepEqual(have, want) {
+		t.Error("tree with higher sequence number was not accepted")
+	}
+}
+
+func TestTable_DNSSeeds(t *testing.T) {
+	nodes := testDNSNodes(3, NodeTypeBN)
+	url, records := signTestTree(t, 1, nodes, nil, "example.org")
+
+	transport := newPingRecorder()
+	conf := Config{
+		udp:         transport,
+		Id:          NodeID{},
+		Addr:        &net.UDPAddr{},
+		DNSURLs:     []string{url},
+		DNSResolver: newMapResolver(records),
+	}
+	discv, err := newTable(&conf)
+	if err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	tab := discv.(*Table)
+	tab.addStorage(NodeTypeBN, &simpleStorage{targetType: NodeTypeBN, max: 3})
+	go tab.loop()
+	defer tab.Close()
+
+	// The nodes of the tree are bonded with once resolved.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		transport.mu.Lock()
+		pinged := 0
+		for _, n := range nodes {
+			if transport.pinged[n.ID] {
+				pinged++
+			}
+		}
+		transport.mu.Unlock()
+		if pinged == len(nodes) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("DNS seeds not bonded: %d of %d pinged", pinged, len(nodes))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if seeds := tab.dnsSeeds(); len(seeds) != len(nodes) {
+		t.Errorf("wrong number of DNS seeds: have %d, want %d", len(seeds), len(nodes))
+	}
+
+	conf.DNSURLs = []string{"kni://example.org"}
+	if _, err := newTable(&conf); err == nil {
+		t.Error("table with invalid DNS URL was created")
+	}
+}
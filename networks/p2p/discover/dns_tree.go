@@ -0,0 +1,367 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package discover
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/klaytn/klaytn/crypto"
+)
+
+// The node lists are published as a tree of DNS TXT records, following the
+// format of EIP-1459 with the node URLs of Klaytn as leaves:
+//
+//	<domain>          kntree-root:v1 e=<node root> l=<link root> seq=<n> sig=<signature>
+//	<hash>.<domain>   kntree-branch:<hash>,<hash>,...
+//	<hash>.<domain>   kni://<hex node id>@10.3.58.6:32323?discport=32323&ntype=bn
+//	<hash>.<domain>   kntree://<public key>@<other domain>
+//
+// The hash of an entry is the base32 encoding of the first 16 bytes of the
+// keccak256 hash of its text. The root is signed by the key of the tree, which
+// is part of the tree URL.
+const (
+	dnsRootPrefix   = "kntree-root:v1"
+	dnsBranchPrefix = "kntree-branch:"
+	dnsLinkPrefix   = "kntree://"
+	dnsNodePrefix   = "kni://"
+
+	dnsHashAbbrev  = 16 // bytes of the entry hash used as subdomain
+	dnsMaxChildren = 13 // maximum number of hashes in a branch
+)
+
+var (
+	dnsB32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+	dnsB64 = base64.RawURLEncoding
+)
+
+var (
+	errDNSUnknownEntry = errors.New("unknown DNS tree entry")
+	errDNSNoRoot       = errors.New("no DNS tree root found")
+	errDNSInvalidSig   = errors.New("invalid DNS tree root signature")
+	errDNSHashMismatch = errors.New("DNS tree entry hash mismatch")
+	errDNSInvalidChild = errors.New("invalid DNS tree child hash")
+	errDNSLinkInNodes  = errors.New("link entry in the node subtree")
+	errDNSNodeInLinks  = errors.New("node entry in the link subtree")
+	errDNSTooLarge     = errors.New("DNS tree has too many entries")
+)
+
+// DNSTree is a signed tree of node URLs and links to other trees, which can be
+// published as DNS TXT records.
+type DNSTree struct {
+	root    *dnsRoot
+	entries map[string]dnsEntry
+}
+
+// MakeDNSTree creates an unsigned tree containing the given nodes and links to
+// other trees. The nodes must be complete.
+func MakeDNSTree(seq uint, nodes []*Node, links []string) (*DNSTree, error) {
+	var (
+		nodeEntries = make([]dnsEntry, 0, len(nodes))
+		linkEntries = make([]dnsEntry, 0, len(links))
+	)
+	for _, n := range nodes {
+		if err := n.validateComplete(); err != nil {
+			return nil, fmt.Errorf("invalid node %v: %v", n, err)
+		}
+		nodeEntries = append(nodeEntries, &dnsNode{node: n})
+	}
+	for _, l := range links {
+		link, err := parseDNSLink(l)
+		if err != nil {
+			return nil, err
+		}
+		linkEntries = append(linkEntries, link)
+	}
+	// Sort the leaves so that the same content always makes the same tree.
+	sortDNSEntries(nodeEntries)
+	sortDNSEntries(linkEntries)
+
+	t := &DNSTree{entries: make(map[string]dnsEntry)}
+	eroot := t.build(nodeEntries)
+	lroot := t.build(linkEntries)
+	t.root = &dnsRoot{eroot: dnsSubdomain(eroot), lroot: dnsSubdomain(lroot), seq: seq}
+	return t, nil
+}
+
+// Sign signs the tree with the given key and returns the URL of the tree
+// published at the domain.
+func (t *DNSTree) Sign(key *ecdsa.PrivateKey, domain string) (string, error) {
+	root := *t.root
+	sig, err := crypto.Sign(root.sigHash(), key)
+	if err != nil {
+		return "", err
+	}
+	root.sig = sig
+	t.root = &root
+	link := &dnsLink{domain: domain, pubkey: &key.PublicKey}
+	return link.String(), nil
+}
+
+// Seq returns the sequence number of the tree.
+func (t *DNSTree) Seq() uint {
+	return t.root.seq
+}
+
+// Signature returns the base64 encoded signature of the tree root.
+func (t *DNSTree) Signature() string {
+	return dnsB64.EncodeToString(t.root.sig)
+}
+
+// Nodes returns all nodes contained in the tree.
+func (t *DNSTree) Nodes() []*Node {
+	var nodes []*Node
+	for _, e := range t.entries {
+		if n, ok := e.(*dnsNode); ok {
+			nodes = append(nodes, n.node)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool { return bytes.Compare(nodes[i].ID[:], nodes[j].ID[:]) < 0 })
+	return nodes
+}
+
+// Links returns the URLs of all trees linked from the tree.
+func (t *DNSTree) Links() []string {
+	var links []string
+	for _, e := range t.entries {
+		if l, ok := e.(*dnsLink); ok {
+			links = append(links, l.String())
+		}
+	}
+	sort.Strings(links)
+	return links
+}
+
+// ToTXT returns the TXT records of the tree, keyed by the fully qualified
+// subdomain of the given domain. The root record is at the domain itself.
+func (t *DNSTree) ToTXT(domain string) map[string]string {
+	records := map[string]string{domain: t.root.String()}
+	for hash, e := range t.entries {
+		sd := hash
+		if domain != "" {
+			sd = hash + "." + domain
+		}
+		records[sd] = e.String()
+	}
+	return records
+}
+
+// build adds the entries to the tree below a hierarchy of branches and returns
+// the top entry.
+func (t *DNSTree) build(entries []dnsEntry) dnsEntry {
+	if len(entries) == 1 {
+		t.entries[dnsSubdomain(entries[0])] = entries[0]
+		return entries[0]
+	}
+	if len(entries) <= dnsMaxChildren {
+		b := &dnsBranch{children: make([]string, len(entries))}
+		for i, e := range entries {
+			hash := dnsSubdomain(e)
+			t.entries[hash] = e
+			b.children[i] = hash
+		}
+		t.entries[dnsSubdomain(b)] = b
+		return b
+	}
+	var subtrees []dnsEntry
+	for len(entries) > 0 {
+		n := dnsMaxChildren
+		if len(entries) < n {
+			n = len(entries)
+		}
+		subtrees = append(subtrees, t.build(entries[:n]))
+		entries = entries[n:]
+	}
+	return t.build(subtrees)
+}
+
+func sortDNSEntries(entries []dnsEntry) {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].String() < entries[j].String() })
+}
+
+// dnsEntry is an entry of the tree.
+type dnsEntry interface {
+	fmt.Stringer
+}
+
+type (
+	dnsRoot struct {
+		eroot string
+		lroot string
+		seq   uint
+		sig   []byte
+	}
+	dnsBranch struct {
+		children []string
+	}
+	dnsLink struct {
+		domain string
+		pubkey *ecdsa.PublicKey
+	}
+	dnsNode struct {
+		node *Node
+	}
+)
+
+func (e *dnsRoot) String() string {
+	return fmt.Sprintf("%s sig=%s", e.content(), dnsB64.EncodeToString(e.sig))
+}
+
+func (e *dnsRoot) content() string {
+	return fmt.Sprintf("%s e=%s l=%s seq=%d", dnsRootPrefix, e.eroot, e.lroot, e.seq)
+}
+
+func (e *dnsRoot) sigHash() []byte {
+	return crypto.Keccak256([]byte(e.content()))
+}
+
+func (e *dnsRoot) verifySignature(pubkey *ecdsa.PublicKey) bool {
+	if len(e.sig) != crypto.SignatureLength {
+		return false
+	}
+	return crypto.VerifySignature(crypto.FromECDSAPub(pubkey), e.sigHash(), e.sig[:crypto.RecoveryIDOffset])
+}
+
+func (e *dnsBranch) String() string {
+	return dnsBranchPrefix + strings.Join(e.children, ",")
+}
+
+func (e *dnsLink) String() string {
+	return dnsLinkPrefix + dnsB32.EncodeToString(crypto.CompressPubkey(e.pubkey)) + "@" + e.domain
+}
+
+func (e *dnsNode) String() string {
+	return e.node.String()
+}
+
+// dnsSubdomain returns the hash of the entry, which is the subdomain it is
+// published at.
+func dnsSubdomain(e dnsEntry) string {
+	return dnsTextHash(e.String())
+}
+
+// dnsTextHash returns the hash of the text of an entry.
+func dnsTextHash(s string) string {
+	h := crypto.Keccak256([]byte(s))
+	return dnsB32.EncodeToString(h[:dnsHashAbbrev])
+}
+
+// parseDNSEntry parses the text of a tree entry other than the root.
+func parseDNSEntry(s string) (dnsEntry, error) {
+	switch {
+	case strings.HasPrefix(s, dnsBranchPrefix):
+		return parseDNSBranch(s[len(dnsBranchPrefix):])
+	case strings.HasPrefix(s, dnsLinkPrefix):
+		return parseDNSLink(s)
+	case strings.HasPrefix(s, dnsNodePrefix):
+		n, err := ParseNode(s)
+		if err != nil {
+			return nil, err
+		}
+		if err := n.validateComplete(); err != nil {
+			return nil, fmt.Errorf("invalid node %v: %v", s, err)
+		}
+		return &dnsNode{node: n}, nil
+	default:
+		return nil, errDNSUnknownEntry
+	}
+}
+
+func parseDNSRoot(s string) (*dnsRoot, error) {
+	var (
+		e      dnsRoot
+		sig    string
+		fields = strings.Fields(s)
+	)
+	if len(fields) != 5 || fields[0] != dnsRootPrefix {
+		return nil, fmt.Errorf("invalid DNS tree root %q", s)
+	}
+	for _, f := range fields[1:] {
+		kv := strings.SplitN(f, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid DNS tree root field %q", f)
+		}
+		switch kv[0] {
+		case "e":
+			e.eroot = kv[1]
+		case "l":
+			e.lroot = kv[1]
+		case "seq":
+			seq, err := strconv.ParseUint(kv[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid DNS tree root seq %q", kv[1])
+			}
+			e.seq = uint(seq)
+		case "sig":
+			sig = kv[1]
+		default:
+			return nil, fmt.Errorf("invalid DNS tree root field %q", f)
+		}
+	}
+	if !isDNSHash(e.eroot) || !isDNSHash(e.lroot) {
+		return nil, errDNSInvalidChild
+	}
+	var err error
+	if e.sig, err = dnsB64.DecodeString(sig); err != nil {
+		return nil, errDNSInvalidSig
+	}
+	return &e, nil
+}
+
+func parseDNSBranch(s string) (*dnsBranch, error) {
+	if s == "" {
+		return &dnsBranch{}, nil
+	}
+	children := strings.Split(s, ",")
+	for _, c := range children {
+		if !isDNSHash(c) {
+			return nil, errDNSInvalidChild
+		}
+	}
+	return &dnsBranch{children: children}, nil
+}
+
+func parseDNSLink(s string) (*dnsLink, error) {
+	if !strings.HasPrefix(s, dnsLinkPrefix) {
+		return nil, fmt.Errorf("invalid DNS tree URL %q, want %q scheme", s, dnsLinkPrefix)
+	}
+	keyDomain := strings.SplitN(s[len(dnsLinkPrefix):], "@", 2)
+	if len(keyDomain) != 2 || keyDomain[1] == "" {
+		return nil, fmt.Errorf("invalid DNS tree URL %q, no domain", s)
+	}
+	keybytes, err := dnsB32.DecodeString(keyDomain[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid DNS tree URL %q, bad public key", s)
+	}
+	key, err := crypto.DecompressPubkey(keybytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DNS tree URL %q, bad public key", s)
+	}
+	return &dnsLink{domain: keyDomain[1], pubkey: key}, nil
+}
+
+func isDNSHash(s string) bool {
+	b, err := dnsB32.DecodeString(s)
+	return err == nil && len(b) == dnsHashAbbrev
+}
@@ -21,6 +21,7 @@
 package discover
 
 import (
+	"context"
 	crand "crypto/rand"
 	"encoding/binary"
 	"errors"
@@ -81,6 +82,10 @@ type Discovery interface {
 	GetAuthorizedNodes() []*Node
 	PutAuthorizedNodes(nodes []*Node)
 	DeleteAuthorizedNodes(nodes []*Node)
+
+	// interfaces for peer bans
+	Bans() map[NodeID]time.Time
+	StoreBan(id NodeID, until time.Time) error
 }
 
 type Table struct {
@@ -107,6 +112,11 @@ type Table struct {
 	storages   map[NodeType]discoverStorage
 	storagesMu sync.RWMutex
 
+	dns      *DNSClient // resolves the DNS node trees, nil if none are configured
+	dnsURLs  []string
+	dnsNodes []*Node // nodes of the DNS node trees, used as seeds
+	dnsMu    sync.Mutex
+
 	localLogger log.Logger
 }
 
@@ -170,6 +180,15 @@ func newTable(cfg *Config) (Discovery, error) {
 	if err := tab.setFallbackNodes(cfg.Bootnodes); err != nil {
 		return nil, err
 	}
+	if len(cfg.DNSURLs) > 0 {
+		for _, url := range cfg.DNSURLs {
+			if _, err := parseDNSLink(url); err != nil {
+				return nil, err
+			}
+		}
+		tab.dns = NewDNSClient(cfg.DNSResolver)
+		tab.dnsURLs = cfg.DNSURLs
+	}
 	for i := 0; i < cap(tab.bondslots); i++ {
 		tab.bondslots <- struct{}{}
 	}
@@ -431,13 +450,23 @@ func (tab *Table) loop() {
 		revalidate     = time.NewTimer(tab.nextRevalidateTime())
 		refresh        = time.NewTicker(refreshInterval)
 		copyNodes      = time.NewTicker(copyNodesInterval)
+		dnsSync        *time.Timer
 		revalidateDone = make(chan struct{})
 		refreshDone    = make(chan struct{})           // where doRefresh reports completion
 		waiting        = []chan struct{}{tab.initDone} // holds waiting callers while doRefresh runs
+		dnsDone        chan struct{}                   // where doDNSSync reports completion
+		dnsSyncC       <-chan time.Time                // nil without DNS node trees, so it never fires
+
+		dnsCtx, dnsCancel = context.WithCancel(context.Background())
 	)
 	defer refresh.Stop()
 	defer revalidate.Stop()
 	defer copyNodes.Stop()
+	if tab.dns != nil {
+		dnsSync = time.NewTimer(0)
+		dnsSyncC = dnsSync.C
+		defer dnsSync.Stop()
+	}
 
 	// Start initial refresh.
 	go tab.doRefresh(refreshDone)
@@ -469,6 +498,17 @@ loop:
 			revalidate.Reset(tt)
 		case <-copyNodes.C:
 			go tab.copyBondedNodes()
+		case <-dnsSyncC:
+			dnsDone = make(chan struct{})
+			go tab.doDNSSync(dnsCtx, dnsDone)
+		case <-dnsDone:
+			// Bond with the new nodes of the DNS node trees.
+			dnsDone = nil
+			dnsSync.Reset(dnsRefreshInterval)
+			if refreshDone == nil {
+				refreshDone = make(chan struct{})
+				go tab.doRefresh(refreshDone)
+			}
 		case <-tab.closeReq:
 			break loop
 		}
@@ -480,6 +520,10 @@ loop:
 	if refreshDone != nil {
 		<-refreshDone
 	}
+	dnsCancel()
+	if dnsDone != nil {
+		<-dnsDone
+	}
 	for _, ch := range waiting {
 		close(ch)
 	}
@@ -511,6 +555,7 @@ func (tab *Table) loadSeedNodes(bond bool) {
 	seeds := tab.db.querySeeds(seedCount, seedMaxAge)
 	seeds = removeBn(seeds)
 	seeds = append(seeds, tab.nursery...)
+	seeds = append(seeds, tab.dnsSeeds()...)
 	if bond {
 		seeds = tab.bondall(seeds)
 	}
@@ -522,6 +567,34 @@ func (tab *Table) loadSeedNodes(bond bool) {
 	}
 }
 
+// doDNSSync resolves the DNS node trees and keeps their nodes as seeds.
+func (tab *Table) doDNSSync(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ctx, cancel := context.WithTimeout(ctx, dnsSyncTimeout)
+	defer cancel()
+
+	var nodes []*Node
+	for _, n := range tab.dns.SyncNodes(ctx, tab.dnsURLs) {
+		if n.ID != tab.self.ID {
+			nodes = append(nodes, n)
+		}
+	}
+	tab.localLogger.Debug("Resolved DNS node trees", "urls", len(tab.dnsURLs), "nodes", len(nodes))
+
+	tab.dnsMu.Lock()
+	defer tab.dnsMu.Unlock()
+	if len(nodes) > 0 || ctx.Err() == nil {
+		tab.dnsNodes = nodes
+	}
+}
+
+func (tab *Table) dnsSeeds() []*Node {
+	tab.dnsMu.Lock()
+	defer tab.dnsMu.Unlock()
+	return append([]*Node{}, tab.dnsNodes...)
+}
+
 // doRevalidate checks that the last node in a random bucket is still live
 // and replaces or deletes the node if it isn't.
 func (tab *Table) doRevalidate(done chan<- struct{}) {
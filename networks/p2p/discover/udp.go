@@ -267,6 +267,8 @@ type Config struct {
 	NodeDBPath   string            // if set, the node database is stored at this filesystem location
 	NetRestrict  *netutil.Netlist  // network whitelist
 	Bootnodes    []*Node           // list of bootstrap nodes
+	DNSURLs      []string          // URLs of the DNS node trees used as additional seeds
+	DNSResolver  DNSResolver       // resolver of the DNS node trees, the system resolver if nil
 	Unhandled    chan<- ReadPacket // unhandled packets are sent on this channel
 
 	// These settings are required for create Table and UDP
@@ -296,6 +296,21 @@ func (t *Trackers) MedianRoundTrip() time.Duration {
 	return t.medianRoundTrip()
 }
 
+// RoundTrips returns the RTT of each tracked peer, to allow the callers to find
+// the peers which respond much slower than the others.
+func (t *Trackers) RoundTrips() map[string]time.Duration {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	rtts := make(map[string]time.Duration, len(t.trackers))
+	for id, tracker := range t.trackers {
+		tracker.lock.RLock()
+		rtts[id] = tracker.roundtrip
+		tracker.lock.RUnlock()
+	}
+	return rtts
+}
+
 // medianRoundTrip is the internal lockless version of MedianRoundTrip to be used
 // by the QoS tuner.
 func (t *Trackers) medianRoundTrip() time.Duration {
@@ -122,8 +122,24 @@ type Peer struct {
 
 	// events receives message send / receive events if set
 	events *event.Feed
+
+	// scorer records the reputation of the peer if set
+	scorer *PeerScorer
+
+	// rwHook wraps the MsgReadWriters of the protocols if set
+	rwHook ProtocolRWHook
+
+	// traffic counts the messages exchanged with the peer
+	traffic *peerTraffic
+
+	// egress caps the bandwidth of the throttled protocols sent to the peer if set
+	egress *egressLimiter
 }
 
+// ProtocolRWHook wraps the MsgReadWriter a protocol uses to exchange messages
+// with the peer.
+type ProtocolRWHook func(p *Peer, protocol string, rw MsgReadWriter) MsgReadWriter
+
 // NewPeer returns a peer for testing purposes.
 func NewPeer(id discover.NodeID, name string, caps []Cap) *Peer {
 	pipe, _ := net.Pipe()
@@ -224,10 +240,27 @@ func newPeer(conns []*conn, protocols []Protocol, tc RWTimerConfig) (*Peer, erro
 		protoErr: make(chan error, len(protomap)+len(conns)), // protocols + pingLoop
 		closed:   make(chan struct{}),
 		logger:   logger.NewWith("id", conns[ConnDefault].id, "conn", conns[ConnDefault].flags),
+		traffic:  newPeerTraffic(conns[ConnDefault].conntype),
 	}
 	return p, nil
 }
 
+// egressFor returns the egress limiter of the peer if the protocol is throttled.
+func (p *Peer) egressFor(proto Protocol) *egressLimiter {
+	if proto.Throttled {
+		return p.egress
+	}
+	return nil
+}
+
+// Report records a misbehaviour of the peer in its reputation score. A peer whose
+// score falls too low is disconnected and banned for a while.
+func (p *Peer) Report(ev ScoreEvent) {
+	if p.scorer != nil {
+		p.scorer.Report(p.ID(), ev)
+	}
+}
+
 func (p *Peer) Log() log.Logger {
 	return p.logger
 }
@@ -435,6 +468,7 @@ func (p *Peer) handle(connectionOrder int, rw *conn, msg Msg) error {
 		if err != nil {
 			return fmt.Errorf("msg code out of range: %v", msg.Code)
 		}
+		p.traffic.count(proto.Name, msg.Code-proto.offset, msg.Size, true)
 		select {
 		case proto.in <- msg:
 			return nil
@@ -503,10 +537,15 @@ func (p *Peer) startProtocols(writeStart <-chan struct{}, writeErr chan<- error)
 		proto.wstart = writeStart
 		proto.werr = writeErr
 		proto.tc = defaultRWTimerConfig
+		proto.traffic = p.traffic
+		proto.egress = p.egressFor(proto.Protocol)
 		var rw MsgReadWriter = proto
 		if p.events != nil {
 			rw = newMsgEventer(rw, p.events, p.ID(), proto.Name)
 		}
+		if p.rwHook != nil {
+			rw = p.rwHook(p, proto.Name, rw)
+		}
 		p.logger.Trace(fmt.Sprintf("Starting protocol %s/%d", proto.Name, proto.Version))
 		go func() {
 			// p.wg.Add(1)
@@ -540,11 +579,16 @@ func (p *Peer) startProtocolsWithRWs(writeStarts []chan struct{}, writeErrs []ch
 				writeErrs[i] <- errors.New("WriteStartsChannelSize")
 			}
 			proto.werr = writeErrs[i]
+			proto.traffic = p.traffic
+			proto.egress = p.egressFor(proto.Protocol)
 
 			var rw MsgReadWriter = proto
 			if p.events != nil {
 				rw = newMsgEventer(rw, p.events, p.ID(), proto.Name)
 			}
+			if p.rwHook != nil {
+				rw = p.rwHook(p, proto.Name, rw)
+			}
 			rws = append(rws, rw)
 		}
 
@@ -594,12 +638,21 @@ type protoRW struct {
 	w      MsgWriter
 	count  uint64 // count the number of WriteMsg calls
 	tc     RWTimerConfig
+
+	traffic *peerTraffic   // counts the written messages if set
+	egress  *egressLimiter // delays the writes to cap the bandwidth if set
 }
 
 func (rw *protoRW) WriteMsg(msg Msg) (err error) {
 	if msg.Code >= rw.Length {
 		return newPeerError(errInvalidMsgCode, "not handled, (code %x) (size %d)", msg.Code, msg.Size)
 	}
+	if rw.egress != nil {
+		if err := rw.egress.wait(msg.Size, rw.closed); err != nil {
+			return err
+		}
+	}
+	code := msg.Code
 	msg.Code += rw.offset
 	rwCount := atomic.AddUint64(&rw.count, 1)
 	if rwCount%rw.tc.Interval == 0 {
@@ -628,6 +681,9 @@ func (rw *protoRW) WriteMsg(msg Msg) (err error) {
 			return err
 		}
 	}
+	if err == nil && rw.traffic != nil {
+		rw.traffic.count(rw.Name, code, msg.Size, false)
+	}
 	select {
 	case rw.werr <- err:
 	default:
@@ -659,11 +715,12 @@ type NetworkInfo struct {
 // peer. Sub-protocol independent fields are contained and initialized here, with
 // protocol specifics delegated to all connected sub-protocols.
 type PeerInfo struct {
-	ID        string                 `json:"id"`        // Unique node identifier (also the encryption key)
-	Name      string                 `json:"name"`      // Name of the node, including client type, version, OS, custom data
-	Caps      []string               `json:"caps"`      // Sum-protocols advertised by this particular peer
-	Networks  []NetworkInfo          `json:"networks"`  // Networks is all the NetworkInfo associated with the peer
-	Protocols map[string]interface{} `json:"protocols"` // Sub-protocol specific metadata fields
+	ID        string                      `json:"id"`        // Unique node identifier (also the encryption key)
+	Name      string                      `json:"name"`      // Name of the node, including client type, version, OS, custom data
+	Caps      []string                    `json:"caps"`      // Sum-protocols advertised by this particular peer
+	Networks  []NetworkInfo               `json:"networks"`  // Networks is all the NetworkInfo associated with the peer
+	Protocols map[string]interface{}      `json:"protocols"` // Sub-protocol specific metadata fields
+	Traffic   map[string]*ProtocolTraffic `json:"traffic"`   // Messages exchanged per sub-protocol and message code
 }
 
 // Info gathers and returns a collection of metadata known about a peer.
@@ -679,6 +736,7 @@ func (p *Peer) Info() *PeerInfo {
 		Name:      p.Name(),
 		Caps:      caps,
 		Protocols: make(map[string]interface{}),
+		Traffic:   p.traffic.info(),
 	}
 
 	for _, rw := range p.rws {
@@ -688,18 +746,7 @@ func (p *Peer) Info() *PeerInfo {
 		network.Inbound = rw.is(inboundConn)
 		network.Trusted = rw.is(trustedConn)
 		network.Static = rw.is(staticDialedConn)
-		switch rw.conntype {
-		case common.CONSENSUSNODE:
-			network.NodeType = "cn"
-		case common.ENDPOINTNODE:
-			network.NodeType = "en"
-		case common.PROXYNODE:
-			network.NodeType = "pn"
-		case common.BOOTNODE:
-			network.NodeType = "bn"
-		default:
-			network.NodeType = "unknown"
-		}
+		network.NodeType = nodeTypeName(rw.conntype)
 		info.Networks = append(info.Networks, network)
 	}
 
@@ -0,0 +1,148 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"crypto/ecdsa"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/p2p/discover"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+// ProbeConfig configures the handshakes run by ProbeNode.
+type ProbeConfig struct {
+	PrivateKey *ecdsa.PrivateKey
+	Name       string          // client name sent in the protocol handshake
+	ConnType   common.ConnType // connection type sent in the connection type handshake
+	Caps       []Cap           // capabilities advertised in the protocol handshake
+	Timeout    time.Duration   // timeout of the whole probe
+}
+
+// ProbeResult is the information a node reveals in the handshakes.
+type ProbeResult struct {
+	ConnType     common.ConnType
+	Name         string
+	Caps         []Cap
+	ListenPorts  []uint64
+	Multichannel bool
+
+	// Shared is the first of the capabilities shared with the node, and Msg is
+	// the first message of its protocol, which is the status message of most
+	// protocols. Msg is nil if no capability is shared.
+	Shared *Cap
+	Msg    *Msg
+}
+
+// ProbeNode dials the node and runs the handshakes of a peer connection without
+// running any protocol. The result holds the information collected until an
+// error occurred, if any.
+func ProbeNode(cfg *ProbeConfig, dest *discover.Node) (*ProbeResult, error) {
+	pubkey, err := dest.ID.Pubkey()
+	if err != nil {
+		return nil, err
+	}
+	addr := &net.TCPAddr{IP: dest.IP, Port: int(dest.TCP)}
+	fd, err := net.DialTimeout("tcp", addr.String(), cfg.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	// Closing the connection aborts the pending reads and writes.
+	timer := time.AfterFunc(cfg.Timeout, func() { fd.Close() })
+	defer timer.Stop()
+
+	// The disconnect reason can only be sent after the encryption handshake.
+	var (
+		t      = newRLPX(fd, pubkey)
+		reason error
+	)
+	defer func() { t.close(reason) }()
+
+	res := new(ProbeResult)
+	if res.ConnType, err = t.doConnTypeHandshake(cfg.ConnType); err != nil {
+		return res, err
+	}
+	if _, err := t.doEncHandshake(cfg.PrivateKey); err != nil {
+		return res, err
+	}
+	reason = DiscQuitting
+	our := &protoHandshake{
+		Version: baseProtocolVersion,
+		Name:    cfg.Name,
+		Caps:    cfg.Caps,
+		ID:      discover.PubkeyID(&cfg.PrivateKey.PublicKey),
+	}
+	their, err := t.doProtoHandshake(our)
+	if err != nil {
+		return res, err
+	}
+	if their.ID != dest.ID {
+		return res, DiscUnexpectedIdentity
+	}
+	res.Name, res.Caps, res.ListenPorts, res.Multichannel = their.Name, their.Caps, their.ListenPort, their.Multichannel
+
+	if res.Shared = firstSharedCap(cfg.Caps, their.Caps); res.Shared == nil {
+		return res, nil
+	}
+	for {
+		msg, err := t.ReadMsg()
+		if err != nil {
+			return res, err
+		}
+		switch {
+		case msg.Code == discMsg:
+			var disc [1]DiscReason
+			rlp.Decode(msg.Payload, &disc)
+			return res, disc[0]
+		case msg.Code < baseProtocolLength:
+			msg.Discard()
+		default:
+			// The protocol of the first shared capability starts right after
+			// the base protocol.
+			msg.Code -= baseProtocolLength
+			res.Msg = &msg
+			return res, nil
+		}
+	}
+}
+
+// firstSharedCap returns the highest shared version of the shared capability
+// which comes first by name.
+func firstSharedCap(ours, theirs []Cap) *Cap {
+	var shared []Cap
+	for _, o := range ours {
+		for _, t := range theirs {
+			if o == t {
+				shared = append(shared, o)
+			}
+		}
+	}
+	if len(shared) == 0 {
+		return nil
+	}
+	sort.Sort(capsByNameAndVersion(shared))
+	first := shared[0]
+	for _, c := range shared[1:] {
+		if c.Name == first.Name {
+			first = c
+		}
+	}
+	return &first
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/p2p/discover"
+)
+
+func TestProbeNode(t *testing.T) {
+	key := newkey()
+	srv := &SingleChannelServer{
+		BaseServer: &BaseServer{
+			Config: Config{
+				Name:                   "probed/v1.0.0",
+				PrivateKey:             key,
+				MaxPhysicalConnections: 10,
+				ListenAddr:             "127.0.0.1:0",
+				NoDial:                 true,
+				ConnectionType:         common.ENDPOINTNODE,
+				Protocols: []Protocol{
+					{Name: "test", Version: 1, Length: 5, Run: func(p *Peer, rw MsgReadWriter) error {
+						return fmt.Errorf("unexpected protocol")
+					}},
+					{Name: "test", Version: 2, Length: 5, Run: func(p *Peer, rw MsgReadWriter) error {
+						if err := Send(rw, 0, []uint{2}); err != nil {
+							return err
+						}
+						_, err := rw.ReadMsg()
+						return err
+					}},
+				},
+			},
+		},
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatalf("could not start server: %v", err)
+	}
+	defer srv.Stop()
+
+	addr := srv.GetListenAddress()[ConnDefault]
+	tcp, _ := net.ResolveTCPAddr("tcp", addr)
+	dest := discover.NewNode(discover.PubkeyID(&key.PublicKey), tcp.IP, uint16(tcp.Port), uint16(tcp.Port), nil, discover.NodeTypeEN)
+	cfg := &ProbeConfig{
+		PrivateKey: newkey(),
+		Name:       "prober",
+		ConnType:   common.ENDPOINTNODE,
+		Caps:       []Cap{{"other", 1}, {"test", 1}, {"test", 2}},
+		Timeout:    5 * time.Second,
+	}
+
+	res, err := ProbeNode(cfg, dest)
+	if err != nil {
+		t.Fatalf("probe failed: %v", err)
+	}
+	if res.Name != "probed/v1.0.0" || res.ConnType != common.ENDPOINTNODE {
+		t.Errorf("wrong node info: name %q, conntype %v", res.Name, res.ConnType)
+	}
+	if want := (&Cap{"test", 2}); !reflect.DeepEqual(res.Shared, want) {
+		t.Errorf("wrong shared capability: have %v, want %v", res.Shared, want)
+	}
+	if res.Msg == nil || res.Msg.Code != 0 {
+		t.Fatalf("wrong first message: %v", res.Msg)
+	}
+	var status []uint
+	if err := res.Msg.Decode(&status); err != nil || !reflect.DeepEqual(status, []uint{2}) {
+		t.Errorf("wrong first message content %v: %v", status, err)
+	}
+
+	// Without shared capabilities, only the handshakes are run.
+	cfg.Caps = []Cap{{"other", 1}}
+	if res, err = ProbeNode(cfg, dest); err != nil {
+		t.Fatalf("probe failed: %v", err)
+	}
+	if res.Shared != nil || res.Msg != nil || len(res.Caps) != 2 {
+		t.Errorf("wrong result without shared capabilities: %+v", res)
+	}
+
+	// The identity of the node is verified.
+	wrong := discover.NewNode(randomID(), tcp.IP, uint16(tcp.Port), uint16(tcp.Port), nil, discover.NodeTypeEN)
+	if _, err := ProbeNode(cfg, wrong); err == nil {
+		t.Error("probe of node with wrong identity succeeded")
+	}
+}
@@ -39,6 +39,11 @@ type Protocol struct {
 	// by the protocol.
 	Length uint64
 
+	// Throttled subjects the messages of the protocol to the egress bandwidth
+	// caps of the server. It is meant for bulk transfers such as snap sync, so
+	// that the consensus and block propagation messages are never delayed.
+	Throttled bool
+
 	// Run is called in a new groutine when the protocol has been
 	// negotiated with a peer. It should read and write messages from
 	// rw. The Payload for each message must be fully consumed.
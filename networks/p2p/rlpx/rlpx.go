@@ -22,7 +22,6 @@
 package rlpx
 
 import (
-	"bufio"
 	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
@@ -313,8 +312,11 @@ func (c *Conn) ConnTypeHandshake(myConnType common.ConnType) (common.ConnType, e
 		werr <- err
 	}()
 
-	// receive connType
-	byteVal, receiveErr := bufio.NewReader(c.conn).ReadByte()
+	// receive connType. Exactly one byte is read, since the encryption
+	// handshake of the remote may follow right after it.
+	var buf [1]byte
+	_, receiveErr := io.ReadFull(c.conn, buf[:])
+	byteVal := buf[0]
 
 	// ensure sending is done
 	sendErr := <-werr
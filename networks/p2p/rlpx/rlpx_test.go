@@ -31,8 +31,10 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/crypto/ecies"
 	"github.com/klaytn/klaytn/networks/p2p/simulations/pipes"
@@ -52,6 +54,26 @@ func TestHandshake(t *testing.T) {
 	p2.Close()
 }
 
+// This test checks that the connection type handshake does not consume the data
+// the remote writes right after its connection type.
+func TestConnTypeHandshakeFollowedByData(t *testing.T) {
+	conn1, conn2 := net.Pipe()
+	defer conn1.Close()
+	defer conn2.Close()
+
+	go conn1.Write([]byte{byte(common.ENDPOINTNODE), 0xab})
+	go io.ReadFull(conn1, make([]byte, 1))
+	conn2.SetDeadline(time.Now().Add(time.Second))
+	conntype, err := NewConn(conn2, nil).ConnTypeHandshake(common.CONSENSUSNODE)
+	assert.NoError(t, err)
+	assert.Equal(t, common.ENDPOINTNODE, conntype)
+
+	next := make([]byte, 1)
+	_, err = io.ReadFull(conn2, next)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0xab}, next, "data following the connection type was consumed")
+}
+
 // This test checks that messages can be sent and received through WriteMsg/ReadMsg.
 func TestReadWriteMsg(t *testing.T) {
 	peer1, peer2 := createPeers(t)
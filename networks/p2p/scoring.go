@@ -0,0 +1,453 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/networks/p2p/discover"
+)
+
+const (
+	DefaultBanThreshold  = -100.0
+	DefaultBanDuration   = time.Hour
+	DefaultScoreHalfLife = 10 * time.Minute
+
+	scoreCheckInterval = 30 * time.Second // Interval of checking the peer latencies and pruning the scores
+	scorePruneLimit    = 0.5              // Scores closer to zero than this are forgotten
+	slowPeerFactor     = 3                // Peers slower than this many times the median round trip are penalized
+	slowPenaltyLimit   = 0.5              // Slow responses alone lower a score down to this fraction of the ban threshold
+)
+
+// ScoreEvent is a misbehaviour of a peer which lowers its reputation score.
+type ScoreEvent int
+
+const (
+	ScoreInvalidMessage  ScoreEvent = iota // The peer sent a malformed or invalid message (e.g. an invalid block)
+	ScoreUselessResponse                   // The peer sent a response nobody asked for or without useful data
+	ScoreTimeout                           // The peer did not answer a request in time
+	ScoreSlowResponse                      // The peer responds much slower than the other peers
+)
+
+var scoreEventWeights = [...]float64{
+	ScoreInvalidMessage:  -50,
+	ScoreUselessResponse: -10,
+	ScoreTimeout:         -20,
+	ScoreSlowResponse:    -5,
+}
+
+var scoreEventToString = [...]string{
+	ScoreInvalidMessage:  "invalid message",
+	ScoreUselessResponse: "useless response",
+	ScoreTimeout:         "timeout",
+	ScoreSlowResponse:    "slow response",
+}
+
+func (e ScoreEvent) String() string {
+	if e < 0 || int(e) >= len(scoreEventToString) {
+		return fmt.Sprintf("unknown score event %d", e)
+	}
+	return scoreEventToString[e]
+}
+
+// ScoringConfig holds the peer reputation scoring options.
+type ScoringConfig struct {
+	// Disable turns off the peer scoring and the automatic banning.
+	Disable bool `toml:",omitempty"`
+
+	// BanThreshold is the score at or below which a peer is banned.
+	// Zero defaults to DefaultBanThreshold.
+	BanThreshold float64 `toml:",omitempty"`
+
+	// BanDuration is how long a peer stays banned.
+	// Zero defaults to DefaultBanDuration.
+	BanDuration time.Duration `toml:",omitempty"`
+
+	// HalfLife is the time in which a score decays halfway back to zero.
+	// Zero defaults to DefaultScoreHalfLife.
+	HalfLife time.Duration `toml:",omitempty"`
+
+	// ExemptTrusted excludes the trusted nodes from the scoring.
+	ExemptTrusted bool `toml:",omitempty"`
+}
+
+// BanStore persists the bans of the peers across restarts.
+type BanStore interface {
+	Bans() map[discover.NodeID]time.Time
+	StoreBan(id discover.NodeID, until time.Time) error
+}
+
+// LatencySource provides the round trip times of the peers measured by a
+// protocol, e.g. msgrate.Trackers.
+type LatencySource interface {
+	RoundTrips() map[string]time.Duration
+	MedianRoundTrip() time.Duration
+}
+
+// latencySource is a registered LatencySource with the function resolving its
+// peer identifiers to node IDs.
+type latencySource struct {
+	source  LatencySource
+	resolve func(id string) (discover.NodeID, bool)
+}
+
+// PeerScoreInfo is the reputation of a peer, as exposed by the admin APIs.
+type PeerScoreInfo struct {
+	ID          string     `json:"id"`
+	Score       float64    `json:"score"`
+	BannedUntil *time.Time `json:"bannedUntil,omitempty"`
+	Exempt      bool       `json:"exempt,omitempty"`
+}
+
+type peerScore struct {
+	value   float64
+	updated time.Time
+}
+
+// PeerScorer keeps the reputation scores of the peers. Scores are lowered by
+// misbehaviours and decay back to zero over time; a peer whose score falls to the
+// ban threshold is disconnected and banned for a while.
+type PeerScorer struct {
+	config     ScoringConfig
+	store      BanStore
+	exempt     map[discover.NodeID]bool
+	protected  map[discover.NodeID]bool // Connected static and consensus node peers
+	disconnect func(discover.NodeID)
+
+	scores  map[discover.NodeID]*peerScore
+	bans    map[discover.NodeID]time.Time
+	sources []latencySource
+
+	now  func() time.Time
+	lock sync.Mutex
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewPeerScorer creates a peer scorer. The bans are loaded from and persisted to
+// the given store if it is not nil. The exempt nodes are never penalized, and
+// disconnect is called to drop a peer when it gets banned.
+func NewPeerScorer(config ScoringConfig, store BanStore, exempt []*discover.Node, disconnect func(discover.NodeID)) *PeerScorer {
+	if config.BanThreshold == 0 {
+		config.BanThreshold = DefaultBanThreshold
+	}
+	if config.BanDuration == 0 {
+		config.BanDuration = DefaultBanDuration
+	}
+	if config.HalfLife == 0 {
+		config.HalfLife = DefaultScoreHalfLife
+	}
+	s := &PeerScorer{
+		config:     config,
+		store:      store,
+		exempt:     make(map[discover.NodeID]bool, len(exempt)),
+		protected:  make(map[discover.NodeID]bool),
+		disconnect: disconnect,
+		scores:     make(map[discover.NodeID]*peerScore),
+		bans:       make(map[discover.NodeID]time.Time),
+		now:        time.Now,
+		quit:       make(chan struct{}),
+	}
+	for _, n := range exempt {
+		s.exempt[n.ID] = true
+	}
+	if store != nil {
+		now := s.now()
+		for id, until := range store.Bans() {
+			if until.After(now) {
+				s.bans[id] = until
+			} else {
+				store.StoreBan(id, time.Time{})
+			}
+		}
+	}
+	return s
+}
+
+// Start starts checking the latencies of the peers and pruning the scores.
+func (s *PeerScorer) Start() {
+	s.wg.Add(1)
+	go s.loop()
+}
+
+// Stop terminates the scorer. The bans made afterwards are not persisted.
+func (s *PeerScorer) Stop() {
+	close(s.quit)
+	s.wg.Wait()
+
+	s.lock.Lock()
+	s.store = nil
+	s.lock.Unlock()
+}
+
+func (s *PeerScorer) loop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(scoreCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.checkLatencies()
+			s.prune()
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// AddLatencySource registers the round trip times measured by a protocol. The
+// peers much slower than the median are penalized periodically. resolve maps the
+// peer identifiers of the source to node IDs.
+func (s *PeerScorer) AddLatencySource(source LatencySource, resolve func(id string) (discover.NodeID, bool)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.sources = append(s.sources, latencySource{source: source, resolve: resolve})
+}
+
+// protect exempts a connected peer from the scoring until unprotect is called.
+// It is used for the static and consensus node connections, which must not be
+// dropped for being slow or unresponsive.
+func (s *PeerScorer) protect(id discover.NodeID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.protected[id] = true
+	delete(s.scores, id)
+}
+
+// unprotect scores a peer again after its protected connection is closed.
+func (s *PeerScorer) unprotect(id discover.NodeID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.protected, id)
+}
+
+// Report records a misbehaviour of a peer in its score, and bans the peer if the
+// score falls to the ban threshold. Slow responses alone never get a peer
+// banned: they lower the score only down to a fraction of the ban threshold.
+func (s *PeerScorer) Report(id discover.NodeID, ev ScoreEvent) {
+	if ev < 0 || int(ev) >= len(scoreEventWeights) {
+		return
+	}
+	s.lock.Lock()
+	if s.exempt[id] || s.protected[id] {
+		s.lock.Unlock()
+		return
+	}
+	if _, banned := s.bans[id]; banned {
+		s.lock.Unlock()
+		return
+	}
+	now := s.now()
+	score := s.decayedScore(id, now)
+	if ev == ScoreSlowResponse {
+		limit := slowPenaltyLimit * s.config.BanThreshold
+		if score <= limit {
+			s.lock.Unlock()
+			return
+		}
+		score = math.Max(score+scoreEventWeights[ev], limit)
+	} else {
+		score += scoreEventWeights[ev]
+	}
+	s.scores[id] = &peerScore{value: score, updated: now}
+	peerPenaltyCounter.Inc(1)
+
+	if score > s.config.BanThreshold {
+		s.lock.Unlock()
+		return
+	}
+	until := now.Add(s.config.BanDuration)
+	s.ban(id, until)
+	s.lock.Unlock()
+
+	logger.Info("Banned misbehaving peer", "id", id, "score", score, "lastEvent", ev, "until", until)
+	if s.disconnect != nil {
+		s.disconnect(id)
+	}
+}
+
+// Ban bans a peer for the given duration, or for the configured ban duration if
+// it is zero. Exempt peers can be banned explicitly.
+func (s *PeerScorer) Ban(id discover.NodeID, duration time.Duration) (time.Time, error) {
+	if duration < 0 {
+		return time.Time{}, fmt.Errorf("invalid ban duration %v", duration)
+	}
+	if duration == 0 {
+		duration = s.config.BanDuration
+	}
+	s.lock.Lock()
+	until := s.now().Add(duration)
+	s.ban(id, until)
+	s.lock.Unlock()
+
+	logger.Info("Banned peer", "id", id, "until", until)
+	if s.disconnect != nil {
+		s.disconnect(id)
+	}
+	return until, nil
+}
+
+// ban records the ban of a peer and resets its score. The caller must hold the lock.
+func (s *PeerScorer) ban(id discover.NodeID, until time.Time) {
+	s.bans[id] = until
+	delete(s.scores, id)
+	peerBanCounter.Inc(1)
+	peerBannedGauge.Update(int64(len(s.bans)))
+
+	if s.store != nil {
+		if err := s.store.StoreBan(id, until); err != nil {
+			logger.Warn("Failed to persist peer ban", "id", id, "err", err)
+		}
+	}
+}
+
+// IsBanned returns true if the peer is currently banned.
+func (s *PeerScorer) IsBanned(id discover.NodeID) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	until, ok := s.bans[id]
+	if !ok {
+		return false
+	}
+	if s.now().Before(until) {
+		return true
+	}
+	s.unban(id)
+	return false
+}
+
+// unban removes an expired ban. The caller must hold the lock.
+func (s *PeerScorer) unban(id discover.NodeID) {
+	delete(s.bans, id)
+	peerBannedGauge.Update(int64(len(s.bans)))
+
+	if s.store != nil {
+		if err := s.store.StoreBan(id, time.Time{}); err != nil {
+			logger.Warn("Failed to remove peer ban", "id", id, "err", err)
+		}
+	}
+}
+
+// Score returns the current score of a peer.
+func (s *PeerScorer) Score(id discover.NodeID) float64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.decayedScore(id, s.now())
+}
+
+// decayedScore returns the score of a peer decayed to the given time. The caller
+// must hold the lock.
+func (s *PeerScorer) decayedScore(id discover.NodeID, now time.Time) float64 {
+	score, ok := s.scores[id]
+	if !ok {
+		return 0
+	}
+	elapsed := now.Sub(score.updated)
+	if elapsed <= 0 {
+		return score.value
+	}
+	return score.value * math.Pow(0.5, float64(elapsed)/float64(s.config.HalfLife))
+}
+
+// Scores returns the scores of the peers which have a score, are banned or are
+// exempt, ordered by their node IDs.
+func (s *PeerScorer) Scores() []*PeerScoreInfo {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.now()
+	infos := make(map[discover.NodeID]*PeerScoreInfo)
+	info := func(id discover.NodeID) *PeerScoreInfo {
+		if _, ok := infos[id]; !ok {
+			infos[id] = &PeerScoreInfo{ID: id.String(), Score: s.decayedScore(id, now), Exempt: s.exempt[id] || s.protected[id]}
+		}
+		return infos[id]
+	}
+	for id := range s.scores {
+		info(id)
+	}
+	for id := range s.exempt {
+		info(id)
+	}
+	for id := range s.protected {
+		info(id)
+	}
+	for id, until := range s.bans {
+		if now.Before(until) {
+			until := until
+			info(id).BannedUntil = &until
+		}
+	}
+	result := make([]*PeerScoreInfo, 0, len(infos))
+	for _, info := range infos {
+		result = append(result, info)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// checkLatencies penalizes the peers much slower than the median of the
+// registered latency sources.
+func (s *PeerScorer) checkLatencies() {
+	s.lock.Lock()
+	sources := append([]latencySource{}, s.sources...)
+	s.lock.Unlock()
+
+	for _, src := range sources {
+		median := src.source.MedianRoundTrip()
+		if median <= 0 {
+			continue
+		}
+		for peer, rtt := range src.source.RoundTrips() {
+			if rtt <= slowPeerFactor*median {
+				continue
+			}
+			if id, ok := src.resolve(peer); ok {
+				s.Report(id, ScoreSlowResponse)
+			}
+		}
+	}
+}
+
+// prune forgets the scores which decayed close to zero and the expired bans.
+func (s *PeerScorer) prune() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.now()
+	for id := range s.scores {
+		if math.Abs(s.decayedScore(id, now)) < scorePruneLimit {
+			delete(s.scores, id)
+		}
+	}
+	for id, until := range s.bans {
+		if !now.Before(until) {
+			s.unban(id)
+		}
+	}
+}
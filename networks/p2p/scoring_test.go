@@ -0,0 +1,266 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/p2p/discover"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testBanStore map[discover.NodeID]time.Time
+
+func (s testBanStore) Bans() map[discover.NodeID]time.Time {
+	bans := make(map[discover.NodeID]time.Time, len(s))
+	for id, until := range s {
+		bans[id] = until
+	}
+	return bans
+}
+
+func (s testBanStore) StoreBan(id discover.NodeID, until time.Time) error {
+	if until.IsZero() {
+		delete(s, id)
+	} else {
+		s[id] = until
+	}
+	return nil
+}
+
+type testLatencySource struct {
+	rtts   map[string]time.Duration
+	median time.Duration
+}
+
+func (s *testLatencySource) RoundTrips() map[string]time.Duration { return s.rtts }
+func (s *testLatencySource) MedianRoundTrip() time.Duration       { return s.median }
+
+// newTestScorer creates a peer scorer with a manual clock, recording the
+// disconnected peers.
+func newTestScorer(store BanStore, exempt []*discover.Node) (*PeerScorer, *time.Time, *[]discover.NodeID) {
+	var (
+		now          = time.Unix(time.Now().Unix(), 0)
+		disconnected []discover.NodeID
+	)
+	s := NewPeerScorer(ScoringConfig{}, store, exempt, func(id discover.NodeID) {
+		disconnected = append(disconnected, id)
+	})
+	s.now = func() time.Time { return now }
+	return s, &now, &disconnected
+}
+
+func TestPeerScorer_BanAtThreshold(t *testing.T) {
+	store := make(testBanStore)
+	s, now, disconnected := newTestScorer(store, nil)
+	id := randomID()
+
+	s.Report(id, ScoreInvalidMessage)
+	assert.Equal(t, -50.0, s.Score(id))
+	assert.False(t, s.IsBanned(id))
+	assert.Empty(t, *disconnected)
+
+	s.Report(id, ScoreInvalidMessage)
+	assert.True(t, s.IsBanned(id))
+	assert.Equal(t, []discover.NodeID{id}, *disconnected)
+	assert.Equal(t, now.Add(DefaultBanDuration), store[id])
+
+	// The ban expires after the ban duration.
+	*now = now.Add(DefaultBanDuration)
+	assert.False(t, s.IsBanned(id))
+	assert.NotContains(t, store, id)
+	assert.Equal(t, 0.0, s.Score(id))
+}
+
+func TestPeerScorer_Decay(t *testing.T) {
+	s, now, disconnected := newTestScorer(nil, nil)
+	id := randomID()
+
+	s.Report(id, ScoreInvalidMessage)
+	*now = now.Add(DefaultScoreHalfLife)
+	assert.InDelta(t, -25.0, s.Score(id), 1e-9)
+
+	// The decayed score does not reach the threshold anymore.
+	s.Report(id, ScoreInvalidMessage)
+	assert.InDelta(t, -75.0, s.Score(id), 1e-9)
+	assert.False(t, s.IsBanned(id))
+	assert.Empty(t, *disconnected)
+
+	// Decayed scores are pruned.
+	*now = now.Add(10 * DefaultScoreHalfLife)
+	s.prune()
+	assert.Empty(t, s.scores)
+}
+
+func TestPeerScorer_Exempt(t *testing.T) {
+	trusted := &discover.Node{ID: randomID()}
+	s, _, disconnected := newTestScorer(nil, []*discover.Node{trusted})
+
+	for i := 0; i < 10; i++ {
+		s.Report(trusted.ID, ScoreInvalidMessage)
+	}
+	assert.False(t, s.IsBanned(trusted.ID))
+	assert.Equal(t, 0.0, s.Score(trusted.ID))
+	assert.Empty(t, *disconnected)
+
+	// Exempt peers can still be banned explicitly.
+	until, err := s.Ban(trusted.ID, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, s.IsBanned(trusted.ID))
+
+	scores := s.Scores()
+	require.Len(t, scores, 1)
+	assert.True(t, scores[0].Exempt)
+	assert.Equal(t, until, *scores[0].BannedUntil)
+
+	_, err = s.Ban(trusted.ID, -time.Minute)
+	assert.Error(t, err)
+}
+
+func TestPeerScorer_PersistentBans(t *testing.T) {
+	store := make(testBanStore)
+	s, now, _ := newTestScorer(store, nil)
+	banned, expired := randomID(), randomID()
+
+	_, err := s.Ban(banned, 0)
+	require.NoError(t, err)
+	store[expired] = now.Add(-time.Second)
+
+	// A restarted scorer loads the active bans and drops the expired ones.
+	restarted := NewPeerScorer(ScoringConfig{}, store, nil, nil)
+	assert.True(t, restarted.IsBanned(banned))
+	assert.False(t, restarted.IsBanned(expired))
+	assert.NotContains(t, store, expired)
+
+	// The bans made after stopping are not persisted.
+	restarted.Start()
+	restarted.Stop()
+	id := randomID()
+	restarted.Ban(id, 0)
+	assert.True(t, restarted.IsBanned(id))
+	assert.NotContains(t, store, id)
+}
+
+func TestPeerScorer_Latency(t *testing.T) {
+	s, _, _ := newTestScorer(nil, nil)
+	slow, fast := randomID(), randomID()
+
+	source := &testLatencySource{
+		rtts:   map[string]time.Duration{"slow": 10 * time.Second, "fast": time.Second, "gone": 10 * time.Second},
+		median: time.Second,
+	}
+	ids := map[string]discover.NodeID{"slow": slow, "fast": fast}
+	s.AddLatencySource(source, func(id string) (discover.NodeID, bool) {
+		nodeID, ok := ids[id]
+		return nodeID, ok
+	})
+
+	s.checkLatencies()
+	assert.Equal(t, scoreEventWeights[ScoreSlowResponse], s.Score(slow))
+	assert.Equal(t, 0.0, s.Score(fast))
+
+	// Nothing is penalized without a median.
+	source.median = 0
+	s.checkLatencies()
+	assert.Equal(t, scoreEventWeights[ScoreSlowResponse], s.Score(slow))
+}
+
+func TestPeerScorer_SlowPenaltyBounded(t *testing.T) {
+	s, _, disconnected := newTestScorer(nil, nil)
+	id := randomID()
+
+	// Slow responses alone never reach the ban threshold, however frequent.
+	for i := 0; i < 100; i++ {
+		s.Report(id, ScoreSlowResponse)
+	}
+	assert.Equal(t, slowPenaltyLimit*DefaultBanThreshold, s.Score(id))
+	assert.False(t, s.IsBanned(id))
+	assert.Empty(t, *disconnected)
+
+	// Other misbehaviours still lower the score further.
+	s.Report(id, ScoreInvalidMessage)
+	assert.True(t, s.IsBanned(id))
+}
+
+func TestPeerScorer_Protected(t *testing.T) {
+	s, _, disconnected := newTestScorer(nil, nil)
+	id := randomID()
+
+	s.Report(id, ScoreTimeout)
+	s.protect(id)
+	assert.Equal(t, 0.0, s.Score(id))
+	for i := 0; i < 10; i++ {
+		s.Report(id, ScoreInvalidMessage)
+	}
+	assert.False(t, s.IsBanned(id))
+	assert.Empty(t, *disconnected)
+
+	// The peer is scored again once its protected connection is closed.
+	s.unprotect(id)
+	s.Report(id, ScoreTimeout)
+	assert.Equal(t, scoreEventWeights[ScoreTimeout], s.Score(id))
+}
+
+func TestServerRejectsBannedPeers(t *testing.T) {
+	srv := &SingleChannelServer{
+		BaseServer: &BaseServer{
+			Config: Config{
+				PrivateKey:             newkey(),
+				MaxPhysicalConnections: 10,
+				NoDial:                 true,
+			},
+		},
+	}
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	newconn := func(id discover.NodeID) *conn {
+		fd, _ := net.Pipe()
+		tx := newTestTransport(id, fd, nil, false)
+		return &conn{fd: fd, transport: tx, flags: inboundConn, conntype: common.ConnTypeUndefined, id: id, cont: make(chan error)}
+	}
+
+	id := randomID()
+	_, err := srv.PeerScorer().Ban(id, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, DiscUselessPeer, srv.checkpoint(newconn(id), srv.posthandshake))
+	assert.NoError(t, srv.checkpoint(newconn(randomID()), srv.posthandshake))
+
+	// Banned static peers are still accepted.
+	static := newconn(id)
+	static.flags = staticDialedConn
+	assert.NoError(t, srv.checkpoint(static, srv.posthandshake))
+
+	// The scoring can be disabled.
+	disabled := &SingleChannelServer{
+		BaseServer: &BaseServer{
+			Config: Config{
+				PrivateKey:             newkey(),
+				MaxPhysicalConnections: 10,
+				NoDial:                 true,
+				PeerScoring:            ScoringConfig{Disable: true},
+			},
+		},
+	}
+	require.NoError(t, disabled.Start())
+	defer disabled.Stop()
+	assert.Nil(t, disabled.PeerScorer())
+}
@@ -94,6 +94,10 @@ type Config struct {
 	// with the rest of the network.
 	BootstrapNodes []*discover.Node
 
+	// DNSDiscoveryURLs are the URLs of the signed DNS node trees, whose nodes
+	// are used as additional seeds of the discovery.
+	DNSDiscoveryURLs []string `toml:",omitempty"`
+
 	//// BootstrapNodesV5 are used to establish connectivity
 	//// with the rest of the network using the V5 discovery
 	//// protocol.
@@ -107,6 +111,18 @@ type Config struct {
 	// allowed to connect, even above the peer limit.
 	TrustedNodes []*discover.Node
 
+	// PeerScoring configures the reputation scoring of the peers, which bans the
+	// misbehaving peers for a while.
+	PeerScoring ScoringConfig
+
+	// Bandwidth caps the bandwidth used for sending messages to the peers of
+	// each node type.
+	Bandwidth BandwidthConfig
+
+	// Capture records the messages exchanged with the selected peers to a file,
+	// for debugging.
+	Capture CaptureConfig
+
 	// Connectivity can be restricted to certain IP networks.
 	// If this option is set to a non-nil value, only hosts which match one of the
 	// IP networks contained in the list are considered.
@@ -154,6 +170,11 @@ type Config struct {
 	// whenever a message is sent to or received from a peer
 	EnableMsgEvents bool
 
+	// If ProtocolRWHook is set, the protocols exchange messages with the peers
+	// through the MsgReadWriters it returns. It is used to simulate faulty
+	// networks.
+	ProtocolRWHook ProtocolRWHook `toml:"-"`
+
 	// Logger is a custom logger to use with the p2p.Server.
 	Logger log.Logger `toml:",omitempty"`
 
@@ -271,6 +292,10 @@ type Server interface {
 	// Peers returns all connected peers.
 	Peers() []*Peer
 
+	// PeerScorer returns the reputation scorer of the peers, or nil if the peer
+	// scoring is disabled.
+	PeerScorer() *PeerScorer
+
 	// NodeDialer is used to connect to nodes in the network, typically by using
 	// an underlying net.Dialer but also using net.Pipe in tests.
 	NodeDialer
@@ -359,6 +384,7 @@ func (srv *MultiChannelServer) Start() (err error) {
 			NodeDBPath:   srv.NodeDatabase,
 			NetRestrict:  srv.NetRestrict,
 			Bootnodes:    srv.BootstrapNodes,
+			DNSURLs:      srv.DNSDiscoveryURLs,
 			Unhandled:    unhandled,
 			Conn:         conn,
 			Addr:         realaddr,
@@ -375,6 +401,11 @@ func (srv *MultiChannelServer) Start() (err error) {
 	}
 
 	dialer := newDialState(srv.StaticNodes, srv.BootstrapNodes, srv.ntab, srv.maxDialedConns(), srv.NetRestrict, srv.PrivateKey, srv.getTypeStatics())
+	if err := srv.startRecorder(); err != nil {
+		return err
+	}
+	srv.startScorer()
+	srv.egressLimiters = newEgressLimiters(srv.Bandwidth)
 
 	// handshake
 	srv.ourHandshake = &protoHandshake{Version: baseProtocolVersion, Name: srv.Name(), ID: discover.PubkeyID(&srv.PrivateKey.PublicKey), Multichannel: true}
@@ -738,6 +769,12 @@ running:
 					if srv.EnableMsgEvents {
 						p.events = &srv.peerFeed
 					}
+					p.scorer = srv.scorer
+					if srv.scorer != nil && srv.isProtectedConn(c) {
+						srv.scorer.protect(c.id)
+					}
+					p.rwHook = srv.rwHook
+					p.egress = srv.egressLimiters[p.ConnType()]
 					name := truncateName(c.name)
 					srv.logger.Debug("Adding p2p peer", "name", name, "addr", c.fd.RemoteAddr(), "peers", len(peers)+1)
 					go srv.runPeer(p)
@@ -760,6 +797,9 @@ running:
 			d := common.PrettyDuration(mclock.Now() - pd.created)
 			pd.logger.Debug("Removing p2p peer", "duration", d, "peers", len(peers)-1, "req", pd.requested, "err", pd.err)
 			delete(peers, pd.ID())
+			if srv.scorer != nil {
+				srv.scorer.unprotect(pd.ID())
+			}
 
 			peerCountGauge.Update(int64(len(peers)))
 			inboundCount, outboundCount = decreasesConnectionMetric(inboundCount, outboundCount, pd.Peer)
@@ -803,6 +843,12 @@ func (srv *MultiChannelServer) Stop() {
 		return
 	}
 	srv.running = false
+	if srv.scorer != nil {
+		srv.scorer.Stop()
+	}
+	if srv.recorder != nil {
+		srv.recorder.Close()
+	}
 	if srv.listener != nil {
 		// this unblocks listener Accept
 		srv.listener.Close()
@@ -917,11 +963,15 @@ type BaseServer struct {
 	lock    sync.Mutex // protects running
 	running bool
 
-	ntab         discover.Discovery
-	listener     net.Listener
-	ourHandshake *protoHandshake
-	lastLookup   time.Time
-	lastLookupMu sync.Mutex
+	ntab           discover.Discovery
+	scorer         *PeerScorer
+	egressLimiters map[common.ConnType]*egressLimiter
+	recorder       *MsgRecorder
+	rwHook         ProtocolRWHook
+	listener       net.Listener
+	ourHandshake   *protoHandshake
+	lastLookup     time.Time
+	lastLookupMu   sync.Mutex
 	// DiscV5       *discv5.Network
 
 	// These are for Peers, PeerCount (and nothing else).
@@ -1150,6 +1200,12 @@ func (srv *BaseServer) Stop() {
 		return
 	}
 	srv.running = false
+	if srv.scorer != nil {
+		srv.scorer.Stop()
+	}
+	if srv.recorder != nil {
+		srv.recorder.Close()
+	}
 	if srv.listener != nil {
 		// this unblocks listener Accept
 		srv.listener.Close()
@@ -1264,6 +1320,7 @@ func (srv *BaseServer) Start() (err error) {
 			NodeDBPath:   srv.NodeDatabase,
 			NetRestrict:  srv.NetRestrict,
 			Bootnodes:    srv.BootstrapNodes,
+			DNSURLs:      srv.DNSDiscoveryURLs,
 			Unhandled:    unhandled,
 			Conn:         conn,
 			Addr:         realaddr,
@@ -1285,6 +1342,11 @@ func (srv *BaseServer) Start() (err error) {
 	}
 
 	dialer := newDialState(srv.StaticNodes, srv.BootstrapNodes, srv.ntab, srv.maxDialedConns(), srv.NetRestrict, srv.PrivateKey, srv.getTypeStatics())
+	if err := srv.startRecorder(); err != nil {
+		return err
+	}
+	srv.startScorer()
+	srv.egressLimiters = newEgressLimiters(srv.Bandwidth)
 
 	// handshake
 	srv.ourHandshake = &protoHandshake{Version: baseProtocolVersion, Name: srv.Name(), ID: discover.PubkeyID(&srv.PrivateKey.PublicKey), Multichannel: false}
@@ -1451,6 +1513,12 @@ running:
 					if srv.EnableMsgEvents {
 						p.events = &srv.peerFeed
 					}
+					p.scorer = srv.scorer
+					if srv.scorer != nil && srv.isProtectedConn(c) {
+						srv.scorer.protect(c.id)
+					}
+					p.rwHook = srv.rwHook
+					p.egress = srv.egressLimiters[p.ConnType()]
 					name := truncateName(c.name)
 					srv.logger.Debug("Adding p2p peer", "name", name, "addr", c.fd.RemoteAddr(), "peers", len(peers)+1)
 					go srv.runPeer(p)
@@ -1477,6 +1545,9 @@ running:
 			d := common.PrettyDuration(mclock.Now() - pd.created)
 			pd.logger.Debug("Removing p2p peer", "duration", d, "peers", len(peers)-1, "req", pd.requested, "err", pd.err)
 			delete(peers, pd.ID())
+			if srv.scorer != nil {
+				srv.scorer.unprotect(pd.ID())
+			}
 
 			if pd.Inbound() {
 				inboundCount--
@@ -1528,6 +1599,8 @@ func (srv *BaseServer) protoHandshakeChecks(peers map[discover.NodeID]*Peer, inb
 
 func (srv *BaseServer) encHandshakeChecks(peers map[discover.NodeID]*Peer, inboundCount int, c *conn) error {
 	switch {
+	case srv.scorer != nil && !srv.isProtectedConn(c) && srv.scorer.IsBanned(c.id):
+		return DiscUselessPeer
 	case !c.is(trustedConn|staticDialedConn) && len(peers) >= srv.Config.MaxPhysicalConnections:
 		return DiscTooManyPeers
 	case !c.is(trustedConn) && c.is(inboundConn) && inboundCount >= srv.maxInboundConns():
@@ -1541,6 +1614,13 @@ func (srv *BaseServer) encHandshakeChecks(peers map[discover.NodeID]*Peer, inbou
 	}
 }
 
+// isProtectedConn returns true for the static dialed connections and, on a
+// consensus node, the connections to the other consensus nodes. They are not
+// scored nor rejected for a ban, as the node relies on them.
+func (srv *BaseServer) isProtectedConn(c *conn) bool {
+	return c.is(staticDialedConn) || (srv.ConnectionType == common.CONSENSUSNODE && c.conntype == common.CONSENSUSNODE)
+}
+
 func (srv *BaseServer) maxInboundConns() int {
 	return srv.Config.MaxPhysicalConnections - srv.maxDialedConns()
 }
@@ -1855,6 +1935,57 @@ func (srv *BaseServer) Disconnect(destID discover.NodeID) {
 	srv.discpeer <- destID
 }
 
+// PeerScorer returns the reputation scorer of the peers, or nil if the peer
+// scoring is disabled.
+func (srv *BaseServer) PeerScorer() *PeerScorer {
+	return srv.scorer
+}
+
+// startScorer starts the peer scorer unless the peer scoring is disabled. The
+// bans are persisted in the node database if the discovery is enabled.
+func (srv *BaseServer) startScorer() {
+	if srv.PeerScoring.Disable {
+		return
+	}
+	var store BanStore
+	if srv.ntab != nil {
+		store = srv.ntab
+	}
+	var exempt []*discover.Node
+	if srv.PeerScoring.ExemptTrusted {
+		exempt = srv.TrustedNodes
+	}
+	srv.scorer = NewPeerScorer(srv.PeerScoring, store, exempt, srv.disconnectBanned)
+	srv.scorer.Start()
+}
+
+// startRecorder starts recording the messages of the selected peers if a
+// capture file is configured, on top of the ProtocolRWHook.
+func (srv *BaseServer) startRecorder() error {
+	srv.rwHook = srv.ProtocolRWHook
+	if srv.Capture.File == "" {
+		return nil
+	}
+	recorder, err := NewMsgRecorder(srv.Capture)
+	if err != nil {
+		return err
+	}
+	srv.recorder = recorder
+	srv.rwHook = recorder.Hook(srv.ProtocolRWHook)
+	srv.logger.Warn("Recording peer messages", "file", srv.Capture.File, "peers", len(srv.Capture.Peers), "codes", srv.Capture.Codes)
+	return nil
+}
+
+// disconnectBanned disconnects a banned peer without blocking the caller.
+func (srv *BaseServer) disconnectBanned(id discover.NodeID) {
+	go func() {
+		select {
+		case srv.discpeer <- id:
+		case <-srv.quit:
+		}
+	}()
+}
+
 // CheckNilNetworkTable returns whether network table is nil.
 func (srv *BaseServer) CheckNilNetworkTable() bool {
 	return srv.ntab == nil
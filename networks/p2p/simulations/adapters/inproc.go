@@ -98,6 +98,7 @@ func (s *SimAdapter) NewNode(config *NodeConfig) (Node, error) {
 			NoDiscovery:            true,
 			Dialer:                 s,
 			EnableMsgEvents:        config.EnableMsgEvents,
+			ProtocolRWHook:         config.ProtocolRWHook,
 		},
 		Logger: logger.NewWith("node.id", id.String()),
 	})
@@ -80,6 +80,7 @@ func (s *CnAdapter) NewNode(config *NodeConfig) (Node, error) {
 	}
 
 	n, err := node.New(&node.Config{
+		DataDir: config.DataDir,
 		P2P: p2p.Config{
 			PrivateKey:             config.PrivateKey, // from p2psim client
 			MaxPhysicalConnections: math.MaxInt32,
@@ -87,6 +88,7 @@ func (s *CnAdapter) NewNode(config *NodeConfig) (Node, error) {
 			ListenAddr:             fmt.Sprintf(":%d", config.Port),
 			// Dialer:          s,
 			EnableMsgEvents: config.EnableMsgEvents,
+			ProtocolRWHook:  config.ProtocolRWHook,
 		},
 		// Logger: log.New("node.id", id.String()),
 		Logger: logger.NewWith("node.name", config.Name),
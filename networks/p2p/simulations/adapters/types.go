@@ -113,7 +113,15 @@ type NodeConfig struct {
 	// function to sanction or prevent suggesting a peer
 	Reachable func(id discover.NodeID) bool
 
+	// ProtocolRWHook wraps the MsgReadWriters the protocols exchange messages
+	// with, to inject faults into the simulated network (in-process nodes only)
+	ProtocolRWHook p2p.ProtocolRWHook
+
 	Port uint16
+
+	// DataDir is the directory the node keeps its data in, which is kept in
+	// memory if empty (CnNodes only)
+	DataDir string
 }
 
 // nodeConfigJSON is used to encode and decode NodeConfig as JSON by encoding
@@ -125,6 +133,7 @@ type nodeConfigJSON struct {
 	Services        []string `json:"services"`
 	EnableMsgEvents bool     `json:"enable_msg_events"`
 	Port            uint16   `json:"port"`
+	DataDir         string   `json:"data_dir,omitempty"`
 }
 
 // MarshalJSON implements the json.Marshaler interface by encoding the config
@@ -136,6 +145,7 @@ func (n *NodeConfig) MarshalJSON() ([]byte, error) {
 		Services:        n.Services,
 		Port:            n.Port,
 		EnableMsgEvents: n.EnableMsgEvents,
+		DataDir:         n.DataDir,
 	}
 	if n.PrivateKey != nil {
 		confJSON.PrivateKey = hex.EncodeToString(crypto.FromECDSA(n.PrivateKey))
@@ -175,6 +185,7 @@ func (n *NodeConfig) UnmarshalJSON(data []byte) error {
 	n.Services = confJSON.Services
 	n.Port = confJSON.Port
 	n.EnableMsgEvents = confJSON.EnableMsgEvents
+	n.DataDir = confJSON.DataDir
 
 	return nil
 }
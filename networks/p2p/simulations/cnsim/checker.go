@@ -0,0 +1,168 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cnsim
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/consensus/istanbul/core"
+	"github.com/klaytn/klaytn/work"
+)
+
+const (
+	safetyCheckInterval = 100 * time.Millisecond
+	maxViolations       = 16
+)
+
+// finalized is the first sighting of a finalized block.
+type finalized struct {
+	hash common.Hash
+	node int
+}
+
+// safetyChecker watches the chains of the validators and records the blocks
+// which finalized the same height differently, or which were finalized
+// without a quorum of committed seals.
+type safetyChecker struct {
+	cluster    *Cluster
+	validators map[common.Address]bool
+	quorum     int
+
+	mu         sync.Mutex
+	blocks     map[uint64]finalized
+	checked    []uint64 // highest block checked per validator
+	violations []error
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newSafetyChecker(c *Cluster) *safetyChecker {
+	quorum := c.Size()
+	if quorum >= 4 {
+		quorum = int(math.Ceil(float64(2*quorum) / 3))
+	}
+	validators := make(map[common.Address]bool, c.Size())
+	for i := 0; i < c.Size(); i++ {
+		validators[c.Address(i)] = true
+	}
+	return &safetyChecker{
+		cluster:    c,
+		validators: validators,
+		quorum:     quorum,
+		blocks:     make(map[uint64]finalized),
+		checked:    make([]uint64, c.Size()),
+		quit:       make(chan struct{}),
+	}
+}
+
+func (sc *safetyChecker) start() {
+	sc.wg.Add(1)
+	go func() {
+		defer sc.wg.Done()
+		ticker := time.NewTicker(safetyCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				sc.check()
+			case <-sc.quit:
+				return
+			}
+		}
+	}()
+}
+
+func (sc *safetyChecker) stop() {
+	close(sc.quit)
+	sc.wg.Wait()
+	sc.check()
+}
+
+func (sc *safetyChecker) check() {
+	sc.cluster.withChains(func(i int, chain work.BlockChain) {
+		sc.mu.Lock()
+		defer sc.mu.Unlock()
+
+		head := chain.CurrentHeader().Number.Uint64()
+		from := sc.checked[i]
+		if from > head {
+			// A restarted validator may have lost its latest blocks.
+			from = head
+		}
+		if from == 0 {
+			from = 1
+		}
+		// The last checked block is checked again to catch reorgs.
+		for number := from; number <= head; number++ {
+			header := chain.GetHeaderByNumber(number)
+			if header == nil {
+				break
+			}
+			sc.checkHeader(i, header)
+			sc.checked[i] = number
+		}
+	})
+}
+
+func (sc *safetyChecker) checkHeader(i int, header *types.Header) {
+	number, hash := header.Number.Uint64(), header.Hash()
+	if seen, ok := sc.blocks[number]; ok {
+		if seen.hash != hash {
+			sc.violate(fmt.Errorf("conflicting blocks finalized at %d: %x by node %d and %x by node %d",
+				number, seen.hash, seen.node, hash, i))
+		}
+		return
+	}
+	sc.blocks[number] = finalized{hash: hash, node: i}
+
+	extra, err := types.ExtractIstanbulExtra(header)
+	if err != nil {
+		sc.violate(fmt.Errorf("invalid istanbul extra of block %d: %v", number, err))
+		return
+	}
+	signers := make(map[common.Address]bool, len(extra.CommittedSeal))
+	for _, seal := range extra.CommittedSeal {
+		addr, err := istanbul.GetSignatureAddress(core.PrepareCommittedSeal(hash), seal)
+		if err != nil || !sc.validators[addr] {
+			sc.violate(fmt.Errorf("invalid committed seal in block %d", number))
+			return
+		}
+		signers[addr] = true
+	}
+	if len(signers) < sc.quorum {
+		sc.violate(fmt.Errorf("block %d finalized with %d committed seals, %d required", number, len(signers), sc.quorum))
+	}
+}
+
+func (sc *safetyChecker) violate(err error) {
+	if len(sc.violations) < maxViolations {
+		sc.violations = append(sc.violations, err)
+	}
+}
+
+func (sc *safetyChecker) errors() []error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return append([]error(nil), sc.violations...)
+}
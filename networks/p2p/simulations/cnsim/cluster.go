@@ -0,0 +1,385 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cnsim
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/log"
+	"github.com/klaytn/klaytn/networks/p2p"
+	"github.com/klaytn/klaytn/networks/p2p/discover"
+	"github.com/klaytn/klaytn/networks/p2p/simulations/adapters"
+	"github.com/klaytn/klaytn/node"
+	"github.com/klaytn/klaytn/node/cn"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/work"
+)
+
+const (
+	defaultChainID        = 2019
+	defaultRequestTimeout = 2 * time.Second
+
+	// genesisTimestamp is fixed so that the genesis block, like the validator
+	// keys, depends only on the seed and runs can be compared with each other.
+	genesisTimestamp = 1704067200 // 2024-01-01 00:00:00 UTC
+)
+
+var (
+	logger = log.NewModuleLogger(log.NetworksP2PSimulationsCnism)
+
+	errTooFewValidators = errors.New("at least one validator is required")
+	errUnknownNode      = errors.New("unknown node index")
+	errNodeRunning      = errors.New("node is already running")
+	errNodeStopped      = errors.New("node is not running")
+)
+
+// Config is the configuration of a simulated validator cluster.
+type Config struct {
+	// Validators is the number of validators, all of which are in the
+	// genesis council and run a CN.
+	Validators int
+
+	// Seed determines the validator keys, and therefore the proposer order,
+	// as well as every fault decision taken on the simulated links.
+	Seed int64
+
+	// DataDir is the directory under which the nodes keep their databases.
+	// Restarted nodes sync from scratch if it is empty.
+	DataDir string
+
+	// RequestTimeout is the round timeout of Istanbul (2s if zero). It is
+	// shared by every node of the process while the cluster runs.
+	RequestTimeout time.Duration
+}
+
+// Cluster is a set of in-process CNs validating the same chain, whose links
+// and clocks can be manipulated while they run.
+type Cluster struct {
+	config  Config
+	genesis *blockchain.Genesis
+	adapter *adapters.CnAdapter
+	nodes   []*clusterNode
+	index   map[discover.NodeID]int
+	faults  *faults
+
+	prevTimeout uint64 // the round timeout before the cluster started
+
+	mu sync.RWMutex // protects the running state of the nodes
+}
+
+type clusterNode struct {
+	key     *ecdsa.PrivateKey
+	addr    common.Address
+	node    *adapters.CnNode
+	skew    int64 // clock skew in nanoseconds, accessed atomically
+	running bool
+}
+
+// NewCluster creates the validators of a cluster without starting them.
+func NewCluster(config Config) (*Cluster, error) {
+	if config.Validators < 1 {
+		return nil, errTooFewValidators
+	}
+	if config.RequestTimeout == 0 {
+		config.RequestTimeout = defaultRequestTimeout
+	}
+	c := &Cluster{
+		config: config,
+		nodes:  make([]*clusterNode, config.Validators),
+		index:  make(map[discover.NodeID]int, config.Validators),
+	}
+	for i := range c.nodes {
+		key := validatorKey(config.Seed, i)
+		c.nodes[i] = &clusterNode{key: key, addr: crypto.PubkeyToAddress(key.PublicKey)}
+		c.index[discover.PubkeyID(&key.PublicKey)] = i
+	}
+	c.faults = newFaults(c)
+	c.genesis = c.makeGenesis()
+	c.adapter = adapters.NewCnAdapter(map[string]adapters.ServiceFunc{"cn": c.newService})
+
+	for i, n := range c.nodes {
+		port, err := freePort()
+		if err != nil {
+			return nil, err
+		}
+		name := fmt.Sprintf("cn%d", i)
+		nodeConfig := &adapters.NodeConfig{
+			ID:             discover.PubkeyID(&n.key.PublicKey),
+			PrivateKey:     n.key,
+			Name:           name,
+			Services:       []string{"cn"},
+			Port:           port,
+			ProtocolRWHook: c.faults.hook(i),
+		}
+		if config.DataDir != "" {
+			nodeConfig.DataDir = filepath.Join(config.DataDir, name)
+		}
+		cnNode, err := c.adapter.NewNode(nodeConfig)
+		if err != nil {
+			return nil, err
+		}
+		n.node = cnNode.(*adapters.CnNode)
+	}
+	return c, nil
+}
+
+// validatorKey derives the key of the i-th validator from the seed.
+func validatorKey(seed int64, i int) *ecdsa.PrivateKey {
+	buf := make([]byte, 16)
+	binary.BigEndian.PutUint64(buf, uint64(seed))
+	binary.BigEndian.PutUint64(buf[8:], uint64(i))
+	for {
+		key, err := crypto.ToECDSA(crypto.Keccak256(buf))
+		if err == nil {
+			return key
+		}
+		buf = crypto.Keccak256(buf)
+	}
+}
+
+func freePort() (uint16, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return uint16(l.Addr().(*net.TCPAddr).Port), nil
+}
+
+func (c *Cluster) makeGenesis() *blockchain.Genesis {
+	addrs := make([]common.Address, len(c.nodes))
+	for i, n := range c.nodes {
+		addrs[i] = n.addr
+	}
+	genesis := blockchain.DefaultGenesisBlock()
+	genesis.Timestamp = genesisTimestamp
+	genesis.Config = params.CypressChainConfig.Copy()
+	genesis.Config.ChainID = big.NewInt(defaultChainID)
+	genesis.Config.Istanbul.SubGroupSize = uint64(len(c.nodes))
+	genesis.Config.Istanbul.ProposerPolicy = uint64(istanbul.RoundRobin)
+
+	extra, err := rlp.EncodeToBytes(&types.IstanbulExtra{Validators: addrs, Seal: []byte{}, CommittedSeal: [][]byte{}})
+	if err != nil {
+		panic(err)
+	}
+	genesis.ExtraData = append(make([]byte, types.IstanbulExtraVanity), extra...)
+	return genesis
+}
+
+func (c *Cluster) newService(ctx *adapters.ServiceContext) (node.Service, error) {
+	i, ok := c.index[ctx.Config.ID]
+	if !ok {
+		return nil, errUnknownNode
+	}
+	n := c.nodes[i]
+
+	config := cn.GetDefaultConfig()
+	config.Genesis = c.genesis
+	config.NetworkId = defaultChainID
+	config.Rewardbase = n.addr
+	config.NoAccountCreation = true
+	config.Istanbul.Now = func() time.Time {
+		return time.Now().Add(time.Duration(atomic.LoadInt64(&n.skew)))
+	}
+	return cn.New(ctx.NodeContext, config)
+}
+
+// Size returns the number of validators.
+func (c *Cluster) Size() int {
+	return len(c.nodes)
+}
+
+// Address returns the address of the i-th validator.
+func (c *Cluster) Address(i int) common.Address {
+	return c.nodes[i].addr
+}
+
+// Start starts every validator, connects them to each other and starts
+// mining.
+func (c *Cluster) Start() error {
+	// The Istanbul core reads the round timeout from the default config.
+	timeout := uint64(c.config.RequestTimeout / time.Millisecond)
+	c.prevTimeout = atomic.SwapUint64(&istanbul.DefaultConfig.Timeout, timeout)
+
+	for i := range c.nodes {
+		if err := c.start(i); err != nil {
+			c.Stop()
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop stops every running validator.
+func (c *Cluster) Stop() {
+	for i := range c.nodes {
+		c.Crash(i)
+	}
+	atomic.StoreUint64(&istanbul.DefaultConfig.Timeout, c.prevTimeout)
+}
+
+func (c *Cluster) start(i int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := c.nodes[i]
+	if n.running {
+		return errNodeRunning
+	}
+	if err := n.node.Start(nil); err != nil {
+		return err
+	}
+	n.running = true
+	for j, other := range c.nodes {
+		if j == i {
+			continue
+		}
+		// Static peers are redialed by the servers until the peer comes back.
+		n.node.Server().AddPeer(other.node.Node())
+		if other.running {
+			other.node.Server().AddPeer(n.node.Node())
+		}
+	}
+	return n.cn().StartMining(false)
+}
+
+func (n *clusterNode) cn() *cn.CN {
+	return n.node.Services()[0].(*cn.CN)
+}
+
+// Crash stops the i-th validator.
+func (c *Cluster) Crash(i int) error {
+	if i < 0 || i >= len(c.nodes) {
+		return errUnknownNode
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := c.nodes[i]
+	if !n.running {
+		return errNodeStopped
+	}
+	n.running = false
+	return n.node.Stop()
+}
+
+// Restart starts the i-th validator again after it crashed.
+func (c *Cluster) Restart(i int) error {
+	if i < 0 || i >= len(c.nodes) {
+		return errUnknownNode
+	}
+	return c.start(i)
+}
+
+// Running reports whether the i-th validator is running.
+func (c *Cluster) Running(i int) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.nodes[i].running
+}
+
+// SkewClock sets the offset of the clock the i-th validator seals and
+// verifies block timestamps with.
+func (c *Cluster) SkewClock(i int, skew time.Duration) {
+	atomic.StoreInt64(&c.nodes[i].skew, int64(skew))
+}
+
+// Height returns the current block number of the i-th validator, and false
+// if it is not running.
+func (c *Cluster) Height(i int) (uint64, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	n := c.nodes[i]
+	if !n.running {
+		return 0, false
+	}
+	return n.cn().BlockChain().CurrentHeader().Number.Uint64(), true
+}
+
+// Peers returns the number of peers of the i-th validator.
+func (c *Cluster) Peers(i int) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	n := c.nodes[i]
+	if !n.running {
+		return 0
+	}
+	return n.node.Server().PeerCount()
+}
+
+// Partition splits the validators into the given groups, which can only
+// exchange messages within themselves. Validators not in any group are
+// isolated.
+func (c *Cluster) Partition(groups ...[]int) {
+	c.faults.partition(groups)
+}
+
+// Heal removes the partition.
+func (c *Cluster) Heal() {
+	c.faults.partition(nil)
+}
+
+// AddRule adds a fault rule applied to the messages between validators.
+func (c *Cluster) AddRule(rule Rule) {
+	c.faults.addRule(rule)
+}
+
+// ClearRules removes every fault rule.
+func (c *Cluster) ClearRules() {
+	c.faults.clearRules()
+}
+
+// SetByzantine makes the i-th validator propose conflicting blocks to the
+// validators, or behave honestly again.
+func (c *Cluster) SetByzantine(i int, byzantine bool) {
+	c.faults.setByzantine(i, byzantine)
+}
+
+// withChains calls fn with the chains of the running validators.
+func (c *Cluster) withChains(fn func(i int, chain work.BlockChain)) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for i, n := range c.nodes {
+		if n.running {
+			fn(i, n.cn().BlockChain())
+		}
+	}
+}
+
+// peerIndex returns the index of the validator the peer runs.
+func (c *Cluster) peerIndex(p *p2p.Peer) (int, bool) {
+	i, ok := c.index[p.ID()]
+	return i, ok
+}
@@ -0,0 +1,37 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+Package cnsim runs Istanbul validators as in-process CNs and injects faults
+into them to check the safety and the liveness of the consensus.
+
+A Cluster starts the validators with adapters.CnAdapter, connected to each
+other over the loopback interface. The messages they send to each other go
+through a p2p.ProtocolRWHook, which drops and delays them according to the
+partition and the Rules of the cluster, and makes byzantine validators propose
+conflicting blocks. The clock each validator seals and verifies blocks with
+can be skewed, and validators can be crashed and restarted.
+
+A Scenario is a timed sequence of such faults. Run executes it in a go test
+while checking that no conflicting blocks are finalized, and that the chain
+progresses again within a bound after the last fault.
+
+The seed of a scenario determines the validator keys, hence the proposer
+order, and the outcome of every fault rule for the n-th matching message on a
+link. Goroutine scheduling is not controlled, so a run is reproducible to the
+extent the order of the messages is.
+*/
+package cnsim
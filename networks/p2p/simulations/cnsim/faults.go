@@ -0,0 +1,376 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cnsim
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/consensus/istanbul/backend"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/crypto/sha3"
+	"github.com/klaytn/klaytn/networks/p2p"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+// Istanbul message codes, which Rule.ConsensusCodes are matched against.
+const (
+	MsgPreprepare  uint64 = 0
+	MsgPrepare     uint64 = 1
+	MsgCommit      uint64 = 2
+	MsgRoundChange uint64 = 3
+)
+
+// Rule describes the faults injected into the messages a validator sends to
+// another. Empty selectors match everything.
+type Rule struct {
+	From []int // indices of the sending validators
+	To   []int // indices of the receiving validators
+
+	Codes          []uint64 // protocol message codes
+	ConsensusCodes []uint64 // Istanbul message codes, matching Istanbul messages only
+
+	Drop   float64       // probability that a matched message is dropped
+	Delay  time.Duration // delay of the matched messages which are not dropped
+	Jitter time.Duration // random delay added on top of Delay
+}
+
+func (r *Rule) match(from, to int, code uint64, consensusCode uint64, isConsensus bool) bool {
+	if !containsInt(r.From, from) || !containsInt(r.To, to) || !containsUint64(r.Codes, code) {
+		return false
+	}
+	if len(r.ConsensusCodes) > 0 && (!isConsensus || !containsUint64(r.ConsensusCodes, consensusCode)) {
+		return false
+	}
+	return true
+}
+
+func containsInt(list []int, v int) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, e := range list {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func containsUint64(list []uint64, v uint64) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, e := range list {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+// consensusMessage mirrors the message of the Istanbul core, which is the
+// payload of an istanbul.ConsensusMsg.
+type consensusMessage struct {
+	Hash          common.Hash
+	Code          uint64
+	Msg           []byte
+	Address       common.Address
+	Signature     []byte
+	CommittedSeal []byte
+}
+
+// faults holds the faults of the links between the validators of a cluster.
+type faults struct {
+	cluster *Cluster
+
+	mu         sync.Mutex
+	rules      []Rule
+	groups     []int // partition group of every validator, nil if not partitioned
+	byzantine  []bool
+	counters   map[[2]int]uint64      // number of decisions taken per link
+	equivocate map[common.Hash][]byte // conflicting preprepares by original payload
+}
+
+func newFaults(c *Cluster) *faults {
+	return &faults{
+		cluster:    c,
+		byzantine:  make([]bool, len(c.nodes)),
+		counters:   make(map[[2]int]uint64),
+		equivocate: make(map[common.Hash][]byte),
+	}
+}
+
+func (f *faults) partition(groups [][]int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if groups == nil {
+		f.groups = nil
+		return
+	}
+	f.groups = make([]int, len(f.cluster.nodes))
+	for i := range f.groups {
+		f.groups[i] = -1 - i // isolated
+	}
+	for g, group := range groups {
+		for _, i := range group {
+			f.groups[i] = g
+		}
+	}
+}
+
+func (f *faults) addRule(rule Rule) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rules = append(f.rules, rule)
+}
+
+func (f *faults) clearRules() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rules = nil
+}
+
+func (f *faults) setByzantine(i int, byzantine bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.byzantine[i] = byzantine
+}
+
+// hook returns the p2p.ProtocolRWHook of the i-th validator.
+func (f *faults) hook(i int) p2p.ProtocolRWHook {
+	return func(p *p2p.Peer, protocol string, rw p2p.MsgReadWriter) p2p.MsgReadWriter {
+		to, ok := f.cluster.peerIndex(p)
+		if !ok {
+			return rw
+		}
+		return &faultRW{faults: f, from: i, to: to, consensus: protocol == backend.IstanbulProtocol.Name, rw: rw}
+	}
+}
+
+// decision is the fate of a message.
+type decision struct {
+	drop       bool
+	delay      time.Duration
+	equivocate bool
+}
+
+// decide takes the decision for a message. The randomness is derived from the
+// seed, the link and the number of decisions taken on the link so far, so the
+// same sequence of messages on a link always meets the same faults.
+func (f *faults) decide(from, to int, code, consensusCode uint64, isConsensus bool) decision {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var d decision
+	if f.groups != nil && f.groups[from] != f.groups[to] {
+		d.drop = true
+		return d
+	}
+	d.equivocate = f.byzantine[from] && isConsensus && consensusCode == MsgPreprepare && to%2 == 1
+
+	for i := range f.rules {
+		rule := &f.rules[i]
+		if !rule.match(from, to, code, consensusCode, isConsensus) {
+			continue
+		}
+		link := [2]int{from, to}
+		n := f.counters[link]
+		f.counters[link] = n + 1
+
+		drop, jitter := f.random(from, to, n)
+		if drop < rule.Drop {
+			d.drop = true
+			return d
+		}
+		d.delay += rule.Delay
+		if rule.Jitter > 0 {
+			d.delay += time.Duration(jitter % uint64(rule.Jitter))
+		}
+	}
+	return d
+}
+
+// random returns a number in [0, 1) and a random integer for the n-th decision
+// on a link.
+func (f *faults) random(from, to int, n uint64) (float64, uint64) {
+	var buf [32]byte
+	binary.BigEndian.PutUint64(buf[0:], uint64(f.cluster.config.Seed))
+	binary.BigEndian.PutUint64(buf[8:], uint64(from))
+	binary.BigEndian.PutUint64(buf[16:], uint64(to))
+	binary.BigEndian.PutUint64(buf[24:], n)
+	h := crypto.Keccak256(buf[:])
+	return float64(binary.BigEndian.Uint64(h[:8])>>11) / (1 << 53), binary.BigEndian.Uint64(h[8:16])
+}
+
+// conflicting returns the payload of an istanbul.ConsensusMsg carrying a
+// preprepare of the sender, with the proposal replaced by a conflicting block
+// of the same height re-sealed by the sender. The same conflicting block is
+// returned for every recipient.
+func (f *faults) conflicting(from int, payload []byte) ([]byte, error) {
+	key := crypto.Keccak256Hash(payload)
+
+	f.mu.Lock()
+	cached, ok := f.equivocate[key]
+	f.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	var cmsg istanbul.ConsensusMsg
+	if err := rlp.DecodeBytes(payload, &cmsg); err != nil {
+		return nil, err
+	}
+	var msg consensusMessage
+	if err := rlp.DecodeBytes(cmsg.Payload, &msg); err != nil {
+		return nil, err
+	}
+	node := f.cluster.nodes[from]
+	if msg.Code != MsgPreprepare || msg.Address != node.addr {
+		return payload, nil
+	}
+	var preprepare istanbul.Preprepare
+	if err := rlp.DecodeBytes(msg.Msg, &preprepare); err != nil {
+		return nil, err
+	}
+	block, ok := preprepare.Proposal.(*types.Block)
+	if !ok {
+		return payload, nil
+	}
+
+	// The vanity is not validated, but it is part of the block hash. Its
+	// last byte holds the round, so the first one is changed.
+	header := block.Header()
+	header.Extra = common.CopyBytes(header.Extra)
+	header.Extra[0] ^= 0xff
+	if err := sealHeader(header, node); err != nil {
+		return nil, err
+	}
+	preprepare.Proposal = block.WithSeal(header)
+
+	var err error
+	if msg.Msg, err = rlp.EncodeToBytes(&preprepare); err != nil {
+		return nil, err
+	}
+	msg.Signature = []byte{}
+	noSig, err := rlp.EncodeToBytes(&msg)
+	if err != nil {
+		return nil, err
+	}
+	if msg.Signature, err = crypto.Sign(crypto.Keccak256(noSig), node.key); err != nil {
+		return nil, err
+	}
+	if cmsg.Payload, err = rlp.EncodeToBytes(&msg); err != nil {
+		return nil, err
+	}
+	conflicting, err := rlp.EncodeToBytes(&cmsg)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	f.equivocate[key] = conflicting
+	f.mu.Unlock()
+	return conflicting, nil
+}
+
+// sealHeader replaces the proposer seal of the header with the one of the
+// node.
+func sealHeader(header *types.Header, node *clusterNode) error {
+	var hash common.Hash
+	hasher := sha3.NewKeccak256()
+	if err := rlp.Encode(hasher, types.IstanbulFilteredHeader(header, false)); err != nil {
+		return err
+	}
+	hasher.Sum(hash[:0])
+
+	seal, err := crypto.Sign(crypto.Keccak256(hash.Bytes()), node.key)
+	if err != nil {
+		return err
+	}
+	extra, err := types.ExtractIstanbulExtra(header)
+	if err != nil {
+		return err
+	}
+	extra.Seal = seal
+	payload, err := rlp.EncodeToBytes(&extra)
+	if err != nil {
+		return err
+	}
+	header.Extra = append(header.Extra[:types.IstanbulExtraVanity], payload...)
+	return nil
+}
+
+// faultRW injects the faults of a link into the messages written to it.
+type faultRW struct {
+	faults    *faults
+	from, to  int
+	consensus bool // whether the protocol is the Istanbul protocol
+	rw        p2p.MsgReadWriter
+}
+
+func (rw *faultRW) ReadMsg() (p2p.Msg, error) {
+	return rw.rw.ReadMsg()
+}
+
+func (rw *faultRW) WriteMsg(msg p2p.Msg) error {
+	payload, err := io.ReadAll(msg.Payload)
+	if err != nil {
+		return err
+	}
+
+	var (
+		isConsensus   = rw.consensus && msg.Code == backend.IstanbulMsg
+		consensusCode uint64
+	)
+	if isConsensus {
+		var cmsg istanbul.ConsensusMsg
+		var m consensusMessage
+		if rlp.DecodeBytes(payload, &cmsg) == nil && rlp.DecodeBytes(cmsg.Payload, &m) == nil {
+			consensusCode = m.Code
+		} else {
+			isConsensus = false
+		}
+	}
+
+	d := rw.faults.decide(rw.from, rw.to, msg.Code, consensusCode, isConsensus)
+	if d.drop {
+		return nil
+	}
+	if d.equivocate {
+		if payload, err = rw.faults.conflicting(rw.from, payload); err != nil {
+			logger.Error("Failed to make a conflicting proposal", "from", rw.from, "err", err)
+			return err
+		}
+	}
+	out := p2p.Msg{Code: msg.Code, Size: uint32(len(payload)), Payload: bytes.NewReader(payload), ReceivedAt: msg.ReceivedAt}
+	if d.delay == 0 {
+		return rw.rw.WriteMsg(out)
+	}
+	go func() {
+		time.Sleep(d.delay)
+		rw.rw.WriteMsg(out)
+	}()
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cnsim
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/crypto/sha3"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFaults(t *testing.T, seed int64) *faults {
+	c, err := NewCluster(Config{Validators: 4, Seed: seed})
+	require.NoError(t, err)
+	return c.faults
+}
+
+func TestFaults_Decide(t *testing.T) {
+	decisions := func(seed int64) []decision {
+		f := newTestFaults(t, seed)
+		f.addRule(Rule{From: []int{0}, ConsensusCodes: []uint64{MsgPrepare}, Drop: 0.5, Delay: time.Second, Jitter: time.Second})
+		var ds []decision
+		for i := 0; i < 100; i++ {
+			ds = append(ds, f.decide(0, 1, 0x11, MsgPrepare, true))
+		}
+		// Not matching the rule.
+		assert.Equal(t, decision{}, f.decide(1, 0, 0x11, MsgPrepare, true))
+		assert.Equal(t, decision{}, f.decide(0, 1, 0x11, MsgCommit, true))
+		assert.Equal(t, decision{}, f.decide(0, 1, 0x11, MsgPrepare, false))
+		return ds
+	}
+
+	ds := decisions(1)
+	assert.Equal(t, ds, decisions(1))
+	assert.NotEqual(t, ds, decisions(2))
+
+	dropped := 0
+	for _, d := range ds {
+		if d.drop {
+			dropped++
+			continue
+		}
+		assert.True(t, d.delay >= time.Second && d.delay < 2*time.Second, d.delay)
+	}
+	assert.True(t, dropped > 25 && dropped < 75, dropped)
+}
+
+func TestFaults_Partition(t *testing.T) {
+	f := newTestFaults(t, 1)
+	f.partition([][]int{{0, 1}, {2}})
+
+	assert.False(t, f.decide(0, 1, 0, 0, false).drop)
+	assert.True(t, f.decide(0, 2, 0, 0, false).drop)
+	assert.True(t, f.decide(2, 1, 0, 0, false).drop)
+	assert.True(t, f.decide(3, 2, 0, 0, false).drop) // isolated
+	assert.True(t, f.decide(1, 3, 0, 0, false).drop)
+
+	f.partition(nil)
+	assert.False(t, f.decide(0, 2, 0, 0, false).drop)
+}
+
+func TestFaults_Conflicting(t *testing.T) {
+	f := newTestFaults(t, 1)
+	f.setByzantine(0, true)
+	proposer := f.cluster.nodes[0]
+
+	assert.False(t, f.decide(0, 2, 0x11, MsgPreprepare, true).equivocate)
+	assert.True(t, f.decide(0, 1, 0x11, MsgPreprepare, true).equivocate)
+	assert.False(t, f.decide(0, 1, 0x11, MsgPrepare, true).equivocate)
+
+	// A preprepare of the proposer as sent by the backend.
+	header := &types.Header{
+		Number:     big.NewInt(1),
+		Time:       big.NewInt(time.Now().Unix()),
+		BlockScore: big.NewInt(1),
+		Extra:      f.cluster.genesis.ExtraData,
+	}
+	require.NoError(t, sealHeader(header, proposer))
+	block := types.NewBlockWithHeader(header)
+	preprepare, err := rlp.EncodeToBytes(&istanbul.Preprepare{
+		View:     &istanbul.View{Round: big.NewInt(0), Sequence: big.NewInt(1)},
+		Proposal: block,
+	})
+	require.NoError(t, err)
+	msg := consensusMessage{Code: MsgPreprepare, Msg: preprepare, Address: proposer.addr, Signature: []byte{}, CommittedSeal: []byte{}}
+	noSig, err := rlp.EncodeToBytes(&msg)
+	require.NoError(t, err)
+	msg.Signature, err = crypto.Sign(crypto.Keccak256(noSig), proposer.key)
+	require.NoError(t, err)
+	payload, err := rlp.EncodeToBytes(&msg)
+	require.NoError(t, err)
+	cmsgPayload, err := rlp.EncodeToBytes(&istanbul.ConsensusMsg{PrevHash: block.ParentHash(), Payload: payload})
+	require.NoError(t, err)
+
+	conflicting, err := f.conflicting(0, cmsgPayload)
+	require.NoError(t, err)
+	again, err := f.conflicting(0, cmsgPayload)
+	require.NoError(t, err)
+	assert.Equal(t, conflicting, again)
+
+	// The conflicting preprepare is validly signed and sealed by the proposer.
+	var cmsg istanbul.ConsensusMsg
+	require.NoError(t, rlp.DecodeBytes(conflicting, &cmsg))
+	var got consensusMessage
+	require.NoError(t, rlp.DecodeBytes(cmsg.Payload, &got))
+	sig := got.Signature
+	got.Signature = []byte{}
+	noSig, err = rlp.EncodeToBytes(&got)
+	require.NoError(t, err)
+	signer, err := istanbul.GetSignatureAddress(noSig, sig)
+	require.NoError(t, err)
+	assert.Equal(t, proposer.addr, signer)
+
+	var pp istanbul.Preprepare
+	require.NoError(t, rlp.DecodeBytes(got.Msg, &pp))
+	conflictingBlock := pp.Proposal.(*types.Block)
+	assert.Equal(t, block.NumberU64(), conflictingBlock.NumberU64())
+	assert.NotEqual(t, block.Hash(), conflictingBlock.Hash())
+
+	extra, err := types.ExtractIstanbulExtra(conflictingBlock.Header())
+	require.NoError(t, err)
+	hasher := sha3.NewKeccak256()
+	require.NoError(t, rlp.Encode(hasher, types.IstanbulFilteredHeader(conflictingBlock.Header(), false)))
+	sealer, err := istanbul.GetSignatureAddress(hasher.Sum(nil), extra.Seal)
+	require.NoError(t, err)
+	assert.Equal(t, proposer.addr, sealer)
+}
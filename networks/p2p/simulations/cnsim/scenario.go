@@ -0,0 +1,203 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cnsim
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+)
+
+const (
+	defaultLiveness  = 30 * time.Second
+	defaultMinBlocks = 3
+
+	livenessCheckInterval = 100 * time.Millisecond
+)
+
+// Step is an action taken on the cluster at a given time of a scenario.
+type Step struct {
+	At   time.Duration // offset from the start of the scenario
+	Desc string
+	Do   func(c *Cluster) error
+}
+
+// Scenario is a sequence of faults injected into a cluster of validators.
+// After the last step, the running validators must all finalize MinBlocks
+// blocks beyond the highest block at that time within Liveness. Throughout
+// the run, no two validators may finalize different blocks at the same height.
+type Scenario struct {
+	Validators int
+	Seed       int64
+	Steps      []Step
+
+	Liveness  time.Duration // 30s if zero
+	MinBlocks uint64        // 3 if zero
+
+	RequestTimeout time.Duration // the round timeout of Istanbul (2s if zero)
+}
+
+// Run runs the scenario in a new cluster, and fails the test if safety or
+// liveness is violated.
+func Run(t testing.TB, s Scenario) {
+	if s.Liveness == 0 {
+		s.Liveness = defaultLiveness
+	}
+	if s.MinBlocks == 0 {
+		s.MinBlocks = defaultMinBlocks
+	}
+	cluster, err := NewCluster(Config{
+		Validators:     s.Validators,
+		Seed:           s.Seed,
+		DataDir:        t.TempDir(),
+		RequestTimeout: s.RequestTimeout,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cluster.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer cluster.Stop()
+
+	checker := newSafetyChecker(cluster)
+	checker.start()
+	defer func() {
+		checker.stop()
+		for _, err := range checker.errors() {
+			t.Error("safety violated:", err)
+		}
+	}()
+
+	steps := append([]Step(nil), s.Steps...)
+	sort.SliceStable(steps, func(i, j int) bool { return steps[i].At < steps[j].At })
+	start := time.Now()
+	for _, step := range steps {
+		time.Sleep(time.Until(start.Add(step.At)))
+		t.Logf("%v: %s (heights %v)", step.At, step.Desc, heights(cluster))
+		if err := step.Do(cluster); err != nil {
+			t.Fatalf("step %q failed: %v", step.Desc, err)
+		}
+	}
+
+	if err := waitProgress(cluster, s.MinBlocks, s.Liveness); err != nil {
+		t.Error("liveness violated:", err)
+	}
+}
+
+// waitProgress waits until every running validator finalizes minBlocks
+// blocks beyond the highest block finalized now.
+func waitProgress(c *Cluster, minBlocks uint64, timeout time.Duration) error {
+	var target uint64
+	for i := 0; i < c.Size(); i++ {
+		if height, ok := c.Height(i); ok && height > target {
+			target = height
+		}
+	}
+	target += minBlocks
+
+	deadline := time.Now().Add(timeout)
+	for {
+		done := true
+		for i := 0; i < c.Size(); i++ {
+			if height, ok := c.Height(i); ok && height < target {
+				done = false
+			}
+		}
+		if done {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("block %d not reached within %v (heights %v)", target, timeout, heights(c))
+		}
+		time.Sleep(livenessCheckInterval)
+	}
+}
+
+// heights returns the block numbers of the validators, or -1 for the ones not
+// running.
+func heights(c *Cluster) []int64 {
+	heights := make([]int64, c.Size())
+	for i := range heights {
+		if height, ok := c.Height(i); ok {
+			heights[i] = int64(height)
+		} else {
+			heights[i] = -1
+		}
+	}
+	return heights
+}
+
+// Crash returns a step crashing the i-th validator.
+func Crash(at time.Duration, i int) Step {
+	return Step{At: at, Desc: fmt.Sprintf("crash node %d", i), Do: func(c *Cluster) error { return c.Crash(i) }}
+}
+
+// Restart returns a step restarting the i-th validator.
+func Restart(at time.Duration, i int) Step {
+	return Step{At: at, Desc: fmt.Sprintf("restart node %d", i), Do: func(c *Cluster) error { return c.Restart(i) }}
+}
+
+// Partition returns a step partitioning the validators into the groups.
+func Partition(at time.Duration, groups ...[]int) Step {
+	return Step{At: at, Desc: fmt.Sprintf("partition %v", groups), Do: func(c *Cluster) error {
+		c.Partition(groups...)
+		return nil
+	}}
+}
+
+// Heal returns a step removing the partition.
+func Heal(at time.Duration) Step {
+	return Step{At: at, Desc: "heal partition", Do: func(c *Cluster) error {
+		c.Heal()
+		return nil
+	}}
+}
+
+// SkewClock returns a step skewing the clock of the i-th validator.
+func SkewClock(at time.Duration, i int, skew time.Duration) Step {
+	return Step{At: at, Desc: fmt.Sprintf("skew clock of node %d by %v", i, skew), Do: func(c *Cluster) error {
+		c.SkewClock(i, skew)
+		return nil
+	}}
+}
+
+// AddRule returns a step adding the fault rule.
+func AddRule(at time.Duration, rule Rule) Step {
+	return Step{At: at, Desc: fmt.Sprintf("add rule %+v", rule), Do: func(c *Cluster) error {
+		c.AddRule(rule)
+		return nil
+	}}
+}
+
+// ClearRules returns a step removing every fault rule.
+func ClearRules(at time.Duration) Step {
+	return Step{At: at, Desc: "clear rules", Do: func(c *Cluster) error {
+		c.ClearRules()
+		return nil
+	}}
+}
+
+// Byzantine returns a step making the i-th validator equivocate as a proposer,
+// or behave honestly again.
+func Byzantine(at time.Duration, i int, byzantine bool) Step {
+	return Step{At: at, Desc: fmt.Sprintf("set node %d byzantine %v", i, byzantine), Do: func(c *Cluster) error {
+		c.SetByzantine(i, byzantine)
+		return nil
+	}}
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cnsim
+
+import (
+	"flag"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/log"
+)
+
+const testSeed = 2024
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	log.EnableLogForTest(log.LvlCrit, log.LvlError)
+	os.Exit(m.Run())
+}
+
+// runScenario runs a scenario unless the tests run in -short mode, as every
+// scenario runs a cluster of CNs for tens of seconds.
+func runScenario(t *testing.T, s Scenario) {
+	if testing.Short() {
+		t.Skip("skipped in -short mode")
+	}
+	Run(t, s)
+}
+
+func TestScenario_Baseline(t *testing.T) {
+	runScenario(t, Scenario{Validators: 4, Seed: testSeed, MinBlocks: 5})
+}
+
+func TestScenario_MinorityPartition(t *testing.T) {
+	runScenario(t, Scenario{
+		Validators: 4,
+		Seed:       testSeed,
+		Steps: []Step{
+			Partition(3*time.Second, []int{0}, []int{1, 2, 3}),
+			Heal(10 * time.Second),
+		},
+	})
+}
+
+func TestScenario_NoQuorumPartition(t *testing.T) {
+	runScenario(t, Scenario{
+		Validators: 4,
+		Seed:       testSeed,
+		Steps: []Step{
+			Partition(3*time.Second, []int{0, 1}, []int{2, 3}),
+			Heal(10 * time.Second),
+		},
+	})
+}
+
+func TestScenario_LossyConsensusMessages(t *testing.T) {
+	runScenario(t, Scenario{
+		Validators: 4,
+		Seed:       testSeed,
+		Steps: []Step{
+			AddRule(2*time.Second, Rule{ConsensusCodes: []uint64{MsgPrepare}, Drop: 0.3}),
+			AddRule(2*time.Second, Rule{ConsensusCodes: []uint64{MsgCommit}, Delay: 200 * time.Millisecond, Jitter: 300 * time.Millisecond}),
+			AddRule(2*time.Second, Rule{From: []int{3}, ConsensusCodes: []uint64{MsgRoundChange}, Drop: 1}),
+			ClearRules(12 * time.Second),
+		},
+	})
+}
+
+func TestScenario_CrashRestart(t *testing.T) {
+	runScenario(t, Scenario{
+		Validators: 4,
+		Seed:       testSeed,
+		Steps: []Step{
+			Crash(3*time.Second, 1),
+			Crash(8*time.Second, 2),
+			Restart(12*time.Second, 1),
+			Restart(14*time.Second, 2),
+		},
+	})
+}
+
+func TestScenario_ClockSkew(t *testing.T) {
+	runScenario(t, Scenario{
+		Validators: 4,
+		Seed:       testSeed,
+		Steps: []Step{
+			SkewClock(2*time.Second, 0, 3*time.Second),
+			SkewClock(2*time.Second, 2, -3*time.Second),
+			SkewClock(12*time.Second, 0, 0),
+			SkewClock(12*time.Second, 2, 0),
+		},
+	})
+}
+
+func TestScenario_EquivocatingProposer(t *testing.T) {
+	runScenario(t, Scenario{
+		Validators: 4,
+		Seed:       testSeed,
+		Steps: []Step{
+			Byzantine(2*time.Second, 0, true),
+		},
+		MinBlocks: 8,
+	})
+}
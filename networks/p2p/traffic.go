@@ -0,0 +1,252 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	metricutils "github.com/klaytn/klaytn/metrics/utils"
+	"github.com/rcrowley/go-metrics"
+)
+
+// egressBurst is how much unused bandwidth an egress limiter accumulates for
+// bursts, as the time it takes to send it at the capped rate.
+const egressBurst = time.Second
+
+var errShuttingDown = errors.New("shutting down")
+
+// MsgTraffic is the number of messages and bytes exchanged with a peer.
+type MsgTraffic struct {
+	InMsgs   uint64 `json:"inMsgs"`
+	InBytes  uint64 `json:"inBytes"`
+	OutMsgs  uint64 `json:"outMsgs"`
+	OutBytes uint64 `json:"outBytes"`
+}
+
+func (t *MsgTraffic) add(size uint32, ingress bool) {
+	if ingress {
+		t.InMsgs++
+		t.InBytes += uint64(size)
+	} else {
+		t.OutMsgs++
+		t.OutBytes += uint64(size)
+	}
+}
+
+// ProtocolTraffic is the traffic of a protocol with a peer, in total and per
+// message code. The message codes are relative to the protocol.
+type ProtocolTraffic struct {
+	MsgTraffic
+	Codes map[uint64]MsgTraffic `json:"codes"`
+}
+
+// peerTraffic counts the messages of the protocols running with a peer.
+//
+// The counters of each peer are reported by admin_peers. The metrics are only
+// kept per message code and per node type of the peers, because metrics named
+// after the peers would grow without bound as the peers come and go.
+type peerTraffic struct {
+	nodeType string
+
+	lock      sync.Mutex
+	protocols map[string]map[uint64]*MsgTraffic
+}
+
+func newPeerTraffic(ct common.ConnType) *peerTraffic {
+	return &peerTraffic{
+		nodeType:  nodeTypeName(ct),
+		protocols: make(map[string]map[uint64]*MsgTraffic),
+	}
+}
+
+// count records a message of the given protocol and protocol-relative code.
+func (t *peerTraffic) count(protocol string, code uint64, size uint32, ingress bool) {
+	t.lock.Lock()
+	codes := t.protocols[protocol]
+	if codes == nil {
+		codes = make(map[uint64]*MsgTraffic)
+		t.protocols[protocol] = codes
+	}
+	traffic := codes[code]
+	if traffic == nil {
+		traffic = new(MsgTraffic)
+		codes[code] = traffic
+	}
+	traffic.add(size, ingress)
+	t.lock.Unlock()
+
+	if metricutils.Enabled {
+		markTraffic(t.nodeType, protocol, code, size, ingress)
+	}
+}
+
+// info returns a copy of the counters.
+func (t *peerTraffic) info() map[string]*ProtocolTraffic {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	info := make(map[string]*ProtocolTraffic, len(t.protocols))
+	for protocol, codes := range t.protocols {
+		pt := &ProtocolTraffic{Codes: make(map[uint64]MsgTraffic, len(codes))}
+		for code, traffic := range codes {
+			pt.Codes[code] = *traffic
+			pt.InMsgs += traffic.InMsgs
+			pt.InBytes += traffic.InBytes
+			pt.OutMsgs += traffic.OutMsgs
+			pt.OutBytes += traffic.OutBytes
+		}
+		info[protocol] = pt
+	}
+	return info
+}
+
+type trafficKey struct {
+	nodeType string
+	protocol string
+	code     uint64
+	ingress  bool
+}
+
+type trafficCounters struct {
+	msgs, bytes         metrics.Counter // Per message code
+	nodeMsgs, nodeBytes metrics.Counter // Per node type of the peers
+}
+
+// trafficCountersCache caches the counters of each trafficKey, so that the metric
+// names are not formatted for every message.
+var trafficCountersCache sync.Map
+
+func markTraffic(nodeType, protocol string, code uint64, size uint32, ingress bool) {
+	key := trafficKey{nodeType, protocol, code, ingress}
+	cached, ok := trafficCountersCache.Load(key)
+	if !ok {
+		direction := "Outbound"
+		if ingress {
+			direction = "Inbound"
+		}
+		cached, _ = trafficCountersCache.LoadOrStore(key, &trafficCounters{
+			msgs:      metrics.GetOrRegisterCounter(fmt.Sprintf("p2p/msg/%s/%d/%sMessages", protocol, code, direction), nil),
+			bytes:     metrics.GetOrRegisterCounter(fmt.Sprintf("p2p/msg/%s/%d/%sTraffic", protocol, code, direction), nil),
+			nodeMsgs:  metrics.GetOrRegisterCounter(fmt.Sprintf("p2p/peer/%s/%sMessages", nodeType, direction), nil),
+			nodeBytes: metrics.GetOrRegisterCounter(fmt.Sprintf("p2p/peer/%s/%sTraffic", nodeType, direction), nil),
+		})
+	}
+	counters := cached.(*trafficCounters)
+	counters.msgs.Inc(1)
+	counters.bytes.Inc(int64(size))
+	counters.nodeMsgs.Inc(1)
+	counters.nodeBytes.Inc(int64(size))
+}
+
+// BandwidthConfig caps the egress bandwidth towards the peers of each node
+// type, in bytes per second. A cap is shared by all the peers of the type, and
+// zero means unlimited. Only the messages of the throttled protocols (snap sync)
+// are capped, the consensus and block propagation messages are not delayed.
+type BandwidthConfig struct {
+	EgressCN uint64 `toml:",omitempty"`
+	EgressPN uint64 `toml:",omitempty"`
+	EgressEN uint64 `toml:",omitempty"`
+}
+
+// newEgressLimiters returns the egress limiters of the capped node types.
+func newEgressLimiters(cfg BandwidthConfig) map[common.ConnType]*egressLimiter {
+	limiters := make(map[common.ConnType]*egressLimiter)
+	for ct, rate := range map[common.ConnType]uint64{
+		common.CONSENSUSNODE: cfg.EgressCN,
+		common.PROXYNODE:     cfg.EgressPN,
+		common.ENDPOINTNODE:  cfg.EgressEN,
+	} {
+		if rate > 0 {
+			limiters[ct] = newEgressLimiter(rate, nodeTypeName(ct))
+		}
+	}
+	return limiters
+}
+
+// egressLimiter delays the messages sent to a group of peers so that they are
+// sent at no more than a given rate on average.
+type egressLimiter struct {
+	rate      uint64 // bytes per second
+	now       func() time.Time
+	throttled metrics.Counter // Time spent waiting for the bandwidth, in nanoseconds
+
+	lock sync.Mutex
+	next time.Time // When the bandwidth reserved so far has been used up
+}
+
+func newEgressLimiter(rate uint64, nodeType string) *egressLimiter {
+	return &egressLimiter{
+		rate:      rate,
+		now:       time.Now,
+		throttled: metrics.GetOrRegisterCounter(fmt.Sprintf("p2p/peer/%s/EgressThrottledTime", nodeType), nil),
+	}
+}
+
+// reserve reserves the bandwidth for sending size bytes, and returns how long
+// the sender has to wait before sending them.
+func (l *egressLimiter) reserve(size uint32) time.Duration {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	if earliest := now.Add(-egressBurst); l.next.Before(earliest) {
+		l.next = earliest
+	}
+	l.next = l.next.Add(time.Duration(uint64(size) * uint64(time.Second) / l.rate))
+	if wait := l.next.Sub(now); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// wait blocks until size bytes may be sent, or closed is closed.
+func (l *egressLimiter) wait(size uint32, closed <-chan struct{}) error {
+	wait := l.reserve(size)
+	if wait == 0 {
+		return nil
+	}
+	l.throttled.Inc(int64(wait))
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-closed:
+		return errShuttingDown
+	}
+}
+
+// nodeTypeName returns the name of the node type of a connection.
+func nodeTypeName(ct common.ConnType) string {
+	switch ct {
+	case common.CONSENSUSNODE:
+		return "cn"
+	case common.ENDPOINTNODE:
+		return "en"
+	case common.PROXYNODE:
+		return "pn"
+	case common.BOOTNODE:
+		return "bn"
+	default:
+		return "unknown"
+	}
+}
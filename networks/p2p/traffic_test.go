@@ -0,0 +1,113 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerTraffic(t *testing.T) {
+	proto := Protocol{
+		Name:   "a",
+		Length: 5,
+		Run: func(peer *Peer, rw MsgReadWriter) error {
+			for i := 0; i < 2; i++ {
+				msg, err := rw.ReadMsg()
+				if err != nil {
+					return err
+				}
+				msg.Discard()
+			}
+			if err := SendItems(rw, 3, "foo"); err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+	closer, rw, peer, errc := testPeer([]Protocol{proto})
+	defer closer()
+
+	require.NoError(t, SendItems(rw, baseProtocolLength+2, uint(1)))
+	require.NoError(t, SendItems(rw, baseProtocolLength+2, []byte("hello")))
+	require.NoError(t, ExpectMsg(rw, baseProtocolLength+3, []string{"foo"}))
+	select {
+	case err := <-errc:
+		assert.Equal(t, errProtocolReturned, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("protocol did not return")
+	}
+
+	// The sizes are those of the RLP encoded payloads.
+	expected := &ProtocolTraffic{
+		MsgTraffic: MsgTraffic{InMsgs: 2, InBytes: 2 + 7, OutMsgs: 1, OutBytes: 5},
+		Codes: map[uint64]MsgTraffic{
+			2: {InMsgs: 2, InBytes: 2 + 7},
+			3: {OutMsgs: 1, OutBytes: 5},
+		},
+	}
+	assert.Equal(t, map[string]*ProtocolTraffic{"a": expected}, peer.Info().Traffic)
+}
+
+func TestEgressLimiter_Reserve(t *testing.T) {
+	now := time.Unix(1000, 0)
+	l := newEgressLimiter(1000, nodeTypeName(common.ENDPOINTNODE))
+	l.now = func() time.Time { return now }
+
+	// A second of bandwidth is available for a burst.
+	assert.Equal(t, time.Duration(0), l.reserve(600))
+	assert.Equal(t, time.Duration(0), l.reserve(400))
+	assert.Equal(t, 500*time.Millisecond, l.reserve(500))
+	assert.Equal(t, time.Second, l.reserve(500))
+
+	// The bandwidth reserved so far is used up, and a second more is available.
+	now = now.Add(3 * time.Second)
+	assert.Equal(t, time.Duration(0), l.reserve(1000))
+	assert.Equal(t, 100*time.Millisecond, l.reserve(100))
+}
+
+func TestEgressLimiter_Wait(t *testing.T) {
+	l := newEgressLimiter(1000, nodeTypeName(common.ENDPOINTNODE))
+	closed := make(chan struct{})
+
+	assert.NoError(t, l.wait(1000, closed))
+	start := time.Now()
+	assert.NoError(t, l.wait(50, closed))
+	assert.True(t, time.Since(start) >= 40*time.Millisecond)
+
+	close(closed)
+	assert.Equal(t, errShuttingDown, l.wait(10000, closed))
+}
+
+func TestNewEgressLimiters(t *testing.T) {
+	limiters := newEgressLimiters(BandwidthConfig{EgressEN: 1 << 20})
+	assert.Len(t, limiters, 1)
+	require.NotNil(t, limiters[common.ENDPOINTNODE])
+	assert.Equal(t, uint64(1<<20), limiters[common.ENDPOINTNODE].rate)
+}
+
+func TestPeerEgressFor(t *testing.T) {
+	p := &Peer{egress: newEgressLimiter(1000, nodeTypeName(common.ENDPOINTNODE))}
+
+	// Only the throttled protocols are delayed by the limiter.
+	assert.Equal(t, p.egress, p.egressFor(Protocol{Name: "snap", Throttled: true}))
+	assert.Nil(t, p.egressFor(Protocol{Name: "klay"}))
+}
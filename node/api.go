@@ -22,6 +22,7 @@ package node
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -89,6 +90,44 @@ func (api *PrivateAdminAPI) RemovePeer(url string) (bool, error) {
 	return true, nil
 }
 
+// errPeerScoringDisabled is returned if the peer scoring is disabled.
+var errPeerScoringDisabled = errors.New("peer scoring is disabled")
+
+// PeerScores retrieves the reputation scores and the bans of the peers.
+func (api *PrivateAdminAPI) PeerScores() ([]*p2p.PeerScoreInfo, error) {
+	server := api.node.Server()
+	if server == nil {
+		return nil, ErrNodeStopped
+	}
+	scorer := server.PeerScorer()
+	if scorer == nil {
+		return nil, errPeerScoringDisabled
+	}
+	return scorer.Scores(), nil
+}
+
+// BanPeer disconnects a remote node and refuses its connections for the given
+// number of seconds. The configured ban duration is used if it is not given.
+func (api *PrivateAdminAPI) BanPeer(url string, seconds *uint64) (time.Time, error) {
+	server := api.node.Server()
+	if server == nil {
+		return time.Time{}, ErrNodeStopped
+	}
+	scorer := server.PeerScorer()
+	if scorer == nil {
+		return time.Time{}, errPeerScoringDisabled
+	}
+	node, err := discover.ParseNode(url)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid kni: %v", err)
+	}
+	var duration time.Duration
+	if seconds != nil {
+		duration = time.Duration(*seconds) * time.Second
+	}
+	return scorer.Ban(node.ID, duration)
+}
+
 // PeerEvents creates an RPC subscription which receives peer events from the
 // node's p2p.Server
 func (api *PrivateAdminAPI) PeerEvents(ctx context.Context) (*rpc.Subscription, error) {
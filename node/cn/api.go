@@ -39,6 +39,7 @@ import (
 	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
 	"github.com/klaytn/klaytn/storage/statedb"
 	"github.com/klaytn/klaytn/work"
 )
@@ -209,6 +210,18 @@ func (api *PrivateAdminAPI) StateMigrationStatus() map[string]interface{} {
 	}
 }
 
+// StartStateTrieResharding increases the number of shards of the state trie
+// database in the background. The node keeps serving while keys are moved.
+func (api *PrivateAdminAPI) StartStateTrieResharding(numShards uint) error {
+	_, err := api.cn.ChainDB().ReshardStateTrieDB(numShards)
+	return err
+}
+
+// StateTrieReshardingStatus returns the progress of resharding the state trie database.
+func (api *PrivateAdminAPI) StateTrieReshardingStatus() (database.ReshardingStatus, error) {
+	return api.cn.ChainDB().StateTrieReshardingStatus()
+}
+
 func (api *PrivateAdminAPI) SaveTrieNodeCacheToDisk() error {
 	return api.cn.BlockChain().SaveTrieNodeCacheToDisk()
 }
@@ -404,6 +417,37 @@ func (api *PrivateDebugAPI) Preimage(ctx context.Context, hash common.Hash) (hex
 	return nil, errors.New("unknown preimage")
 }
 
+// ExecutionWitness re-executes the given block and returns the accounts, storage
+// slots, contract codes and trie nodes accessed during the execution, together
+// with their proofs against the state root of the parent block.
+func (api *PrivateDebugAPI) ExecutionWitness(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*blockchain.ExecutionWitness, error) {
+	block, err := api.cn.APIBackend.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		blockNrOrHashString, _ := blockNrOrHash.NumberOrHashString()
+		return nil, fmt.Errorf("block %v not found", blockNrOrHashString)
+	}
+	bc, ok := api.cn.BlockChain().(*blockchain.BlockChain)
+	if !ok {
+		return nil, errors.New("execution witness is not supported by the blockchain")
+	}
+	return bc.ExecutionWitness(block)
+}
+
+// VerifyExecutionWitness re-executes the given block only with the witness and
+// checks the result against the block.
+func (api *PrivateDebugAPI) VerifyExecutionWitness(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, witness blockchain.ExecutionWitness) (bool, error) {
+	block, err := api.cn.APIBackend.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		blockNrOrHashString, _ := blockNrOrHash.NumberOrHashString()
+		return false, fmt.Errorf("block %v not found", blockNrOrHashString)
+	}
+	bc := api.cn.BlockChain()
+	if err := blockchain.VerifyExecutionWitness(bc.Config(), bc.Engine(), block, &witness); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // TODO-klaytn: Rearrange PublicDebugAPI and PrivateDebugAPI receivers
 // GetBadBLocks returns a list of the last 'bad blocks' that the client has seen on the network
 // and returns them as a JSON list of block-hashes
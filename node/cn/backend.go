@@ -31,6 +31,7 @@ import (
 
 	"github.com/klaytn/klaytn"
 	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/accounts/external"
 	"github.com/klaytn/klaytn/api"
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/bloombits"
@@ -55,10 +56,14 @@ import (
 	"github.com/klaytn/klaytn/reward"
 	"github.com/klaytn/klaytn/rlp"
 	"github.com/klaytn/klaytn/storage/database"
+	"github.com/klaytn/klaytn/storage/statedb"
 	"github.com/klaytn/klaytn/work"
 )
 
-var errCNLightSync = errors.New("can't run cn.CN in light sync mode")
+var (
+	errCNLightSync       = errors.New("can't run cn.CN in light sync mode")
+	errCheckpointNoState = errors.New("trusted checkpoint requires fast or snap sync mode")
+)
 
 //go:generate mockgen -destination=node/cn/mocks/lesserver_mock.go -package=mocks github.com/klaytn/klaytn/node/cn LesServer
 type LesServer interface {
@@ -96,6 +101,7 @@ type BackendProtocolManager interface {
 	Start(maxPeers int)
 	Stop()
 	SetSyncStop(flag bool)
+	AddLatencySources(scorer *p2p.PeerScorer)
 }
 
 // CN implements the Klaytn consensus node service.
@@ -187,9 +193,29 @@ func checkSyncMode(config *Config) error {
 	if config.SyncMode == downloader.LightSync {
 		return errCNLightSync
 	}
+	// The state of the checkpoint is not available, so it has to be synced.
+	if config.TrustedCheckpoint != "" && config.SyncMode != downloader.FastSync && config.SyncMode != downloader.SnapSync {
+		return errCheckpointNoState
+	}
 	return nil
 }
 
+// applyTrustedCheckpoint starts an empty chain from the trusted checkpoint
+// configured, if any, and returns it.
+func applyTrustedCheckpoint(chainDB database.DBManager, config *Config) (*downloader.TrustedCheckpoint, error) {
+	if config.TrustedCheckpoint == "" {
+		return nil, nil
+	}
+	checkpoint, err := downloader.LoadTrustedCheckpoint(config.TrustedCheckpoint)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkpoint.Verify(config.TrustedCheckpointSigners, config.TrustedCheckpointThreshold); err != nil {
+		return nil, err
+	}
+	return checkpoint, downloader.WriteTrustedCheckpoint(chainDB, checkpoint)
+}
+
 func setEngineType(chainConfig *params.ChainConfig) {
 	if chainConfig.Clique != nil {
 		types.EngineType = types.Engine_Clique
@@ -208,6 +234,12 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 
 	chainDB := CreateDB(ctx, config, "chaindata")
 
+	scheme, err := statedb.ParseStateScheme(config.StateScheme, chainDB)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("Using state scheme", "scheme", scheme)
+
 	chainConfig, genesisHash, genesisErr := blockchain.SetupGenesisBlock(chainDB, config.Genesis, config.NetworkId, config.IsPrivate, false)
 	if _, ok := genesisErr.(*params.ConfigCompatError); genesisErr != nil && !ok {
 		return nil, genesisErr
@@ -215,6 +247,12 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 
 	setEngineType(chainConfig)
 
+	// The checkpoint carries governance entries, so it goes before the governance engine loads them.
+	checkpoint, err := applyTrustedCheckpoint(chainDB, config)
+	if err != nil {
+		return nil, err
+	}
+
 	// load governance state
 	chainConfig.SetDefaults()
 	// latest values will be applied to chainConfig after NewMixedEngine call
@@ -343,6 +381,13 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 	if pset.Policy() == uint64(istanbul.WeightedRandom) {
 		// NewStakingManager is called with proper non-nil parameters
 		reward.NewStakingManager(cn.blockchain, governance, cn.chainDB)
+
+		// The state of the staking blocks before the checkpoint is not available.
+		if checkpoint != nil {
+			if err := downloader.WriteCheckpointStakingInfo(checkpoint); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// Governance states which are not yet applied to the db remains at in-memory storage
@@ -490,9 +535,52 @@ func CreateConsensusEngine(ctx *node.ServiceContext, config *Config, chainConfig
 	if chainConfig.Governance == nil {
 		chainConfig.Governance = params.GetDefaultGovernanceConfig()
 	}
+	if config.ExternalConsensusSigner {
+		account, wallet, err := externalConsensusAccount(ctx.AccountManager, config.ExternalConsensusAddress)
+		if err != nil {
+			logger.Crit("Failed to find the consensus account in the external signer", "address", config.ExternalConsensusAddress, "err", err)
+		}
+		// The node key is still the p2p identity of the node, by which the other
+		// validators know it, so it cannot be of another account.
+		if nodeAddr := crypto.PubkeyToAddress(ctx.NodeKey().PublicKey); nodeAddr != account.Address {
+			logger.Crit("The node key is not of the consensus account of the external signer", "nodeAddress", nodeAddr, "consensusAddress", account.Address)
+		}
+		config.Istanbul.SignHash = func(hash []byte) ([]byte, error) {
+			return wallet.SignHash(account, hash)
+		}
+		logger.Info("Consensus messages are signed by the external signer", "address", account.Address, "wallet", wallet.URL())
+		return istanbulBackend.NewWithSigner(config.Rewardbase, &config.Istanbul, account.Address, db, gov, nodetype)
+	}
 	return istanbulBackend.New(config.Rewardbase, &config.Istanbul, ctx.NodeKey(), db, gov, nodetype)
 }
 
+// externalConsensusAccount returns the account of the external signer which signs
+// the consensus messages and its wallet. Without the given address, the signer
+// must have only one account.
+func externalConsensusAccount(am *accounts.Manager, addr common.Address) (accounts.Account, accounts.Wallet, error) {
+	var wallets []accounts.Wallet
+	for _, backend := range am.Backends(external.ExternalSignerType) {
+		wallets = append(wallets, backend.Wallets()...)
+	}
+	if addr == (common.Address{}) {
+		var found []accounts.Account
+		for _, wallet := range wallets {
+			found = append(found, wallet.Accounts()...)
+		}
+		if len(found) != 1 {
+			return accounts.Account{}, nil, fmt.Errorf("the external signer has %d accounts, but no consensus account is given", len(found))
+		}
+		addr = found[0].Address
+	}
+	account := accounts.Account{Address: addr}
+	for _, wallet := range wallets {
+		if wallet.Contains(account) {
+			return account, wallet, nil
+		}
+	}
+	return account, nil, accounts.ErrUnknownAccount
+}
+
 // APIs returns the collection of RPC services the ethereum package offers.
 // NOTE, some of these services probably need to be moved to somewhere else.
 func (s *CN) APIs() []rpc.API {
@@ -672,6 +760,9 @@ func (s *CN) Start(srvr p2p.Server) error {
 	maxPeers := srvr.MaxPeers()
 	// Start the networking layer and the light server if requested
 	s.protocolManager.Start(maxPeers)
+	if scorer := srvr.PeerScorer(); scorer != nil {
+		s.protocolManager.AddLatencySources(scorer)
+	}
 	if s.lesServer != nil {
 		s.lesServer.Start(srvr)
 	}
@@ -17,16 +17,23 @@
 package cn
 
 import (
+	"crypto/ecdsa"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/accounts/external"
 	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/datasync/downloader"
 	"github.com/klaytn/klaytn/node/cn/mocks"
 	"github.com/klaytn/klaytn/params"
 	mocks2 "github.com/klaytn/klaytn/work/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func newCN(t *testing.T) (*gomock.Controller, *MockBackendProtocolManager, *mocks.MockMiner, *CN) {
@@ -55,6 +62,14 @@ func TestCN_CheckSyncMode(t *testing.T) {
 
 	c.SyncMode = downloader.LightSync
 	assert.Equal(t, errCNLightSync, checkSyncMode(c))
+
+	// The state at a trusted checkpoint has to be synced.
+	c.TrustedCheckpoint = "checkpoint.json"
+	c.SyncMode = downloader.FullSync
+	assert.Equal(t, errCheckpointNoState, checkSyncMode(c))
+
+	c.SyncMode = downloader.SnapSync
+	assert.NoError(t, checkSyncMode(c))
 }
 
 func TestCN_SetEngineType(t *testing.T) {
@@ -145,3 +160,36 @@ func TestCN_ReBroadcastTxs(t *testing.T) {
 	mockPM.EXPECT().ReBroadcastTxs(txs).Times(1)
 	cn.ReBroadcastTxs(txs)
 }
+
+func TestExternalConsensusAccount(t *testing.T) {
+	key1, _ := crypto.GenerateKey()
+	key2, _ := crypto.GenerateKey()
+	addr1, addr2 := crypto.PubkeyToAddress(key1.PublicKey), crypto.PubkeyToAddress(key2.PublicKey)
+
+	newManager := func(keys ...*ecdsa.PrivateKey) *accounts.Manager {
+		signer, err := external.NewStandInSigner("secret", keys...)
+		require.NoError(t, err)
+		t.Cleanup(signer.Close)
+		server := httptest.NewServer(signer)
+		t.Cleanup(server.Close)
+		backend, err := external.NewExternalBackend(server.URL, "secret")
+		require.NoError(t, err)
+		return accounts.NewManager(backend)
+	}
+
+	// The only account of the signer is taken without an address.
+	account, wallet, err := externalConsensusAccount(newManager(key1), common.Address{})
+	assert.NoError(t, err)
+	assert.Equal(t, addr1, account.Address)
+	assert.True(t, wallet.Contains(account))
+
+	// Otherwise the address is required and must be of the signer.
+	am := newManager(key1, key2)
+	_, _, err = externalConsensusAccount(am, common.Address{})
+	assert.Error(t, err)
+	account, _, err = externalConsensusAccount(am, addr2)
+	assert.NoError(t, err)
+	assert.Equal(t, addr2, account.Address)
+	_, _, err = externalConsensusAccount(am, common.HexToAddress("0x1"))
+	assert.Equal(t, accounts.ErrUnknownAccount, err)
+}
@@ -60,6 +60,9 @@ func NewChannelManager(channelSize int) *ChannelManager {
 	channelMgr.RegisterMsgCode(BlockChannel, NewBlockMsg)
 
 	channelMgr.RegisterMsgCode(TxChannel, TxMsg)
+	channelMgr.RegisterMsgCode(TxChannel, NewPooledTransactionHashesMsg)
+	channelMgr.RegisterMsgCode(TxChannel, PooledTransactionsRequestMsg)
+	channelMgr.RegisterMsgCode(TxChannel, PooledTransactionsMsg)
 
 	channelMgr.RegisterMsgCode(MiscChannel, ReceiptsRequestMsg)
 	channelMgr.RegisterMsgCode(MiscChannel, ReceiptsMsg)
@@ -55,6 +55,9 @@ func GetDefaultConfig() *Config {
 		TriesInMemory:        blockchain.DefaultTriesInMemory,
 		LivePruningRetention: blockchain.DefaultLivePruningRetention,
 		GasPrice:             big.NewInt(18 * params.Ston),
+		LightPeers:           100,
+		TxAnnounceSizePN:     DefaultTxAnnounceSize,
+		TxAnnounceSizeEN:     DefaultTxAnnounceSize,
 
 		TxPool: blockchain.DefaultTxPoolConfig,
 		GPO: gasprice.Config{
@@ -92,6 +95,12 @@ type Config struct {
 	NoPruning     bool
 	WorkerDisable bool // disables worker and does not start istanbul
 
+	// Trusted checkpoint options
+	TrustedCheckpoint          string           `toml:",omitempty"` // File of a signed checkpoint to start an empty chain from, instead of from the genesis
+	TrustedCheckpointSigners   []common.Address `toml:",omitempty"` // Signers trusted to sign the checkpoint
+	TrustedCheckpointThreshold int              `toml:",omitempty"` // Number of the signers required to sign the checkpoint, zero for all
+	HistoryBackfill            bool             `toml:",omitempty"` // Whether to backfill the blocks below the checkpoint
+
 	// KES options
 	DownloaderDisable bool
 	FetcherDisable    bool
@@ -102,8 +111,8 @@ type Config struct {
 	SentChainTxsLimit  uint64          // Number of chain transactions stored for resending. Default value is 1000.
 
 	// Light client options
-	// LightServ  int `toml:",omitempty"` // Maximum percentage of time allowed for serving LES requests
-	// LightPeers int `toml:",omitempty"` // Maximum number of LES client peers
+	LightServ  bool `toml:",omitempty"` // Whether to serve light clients over the klight protocol
+	LightPeers int  `toml:",omitempty"` // Maximum number of light client peers
 
 	OverwriteGenesis bool
 	StartBlockNumber uint64
@@ -125,12 +134,18 @@ type Config struct {
 	TriesInMemory        uint64
 	LivePruning          bool
 	LivePruningRetention uint64
+	StateScheme          string
 	SenderTxHashIndexing bool
 	ParallelDBWrite      bool
 	TrieNodeCacheConfig  statedb.TrieNodeCacheConfig
 	SnapshotCacheSize    int
 	SnapshotAsyncGen     bool
 
+	// Snap serving options
+	SnapServeResponseSize uint64
+	SnapServeResponseTime time.Duration
+	SnapServeCPUShare     float64
+
 	// Mining-related options
 	ServiceChainSigner common.Address `toml:",omitempty"`
 	ExtraData          []byte         `toml:",omitempty"`
@@ -154,6 +169,11 @@ type Config struct {
 
 	// Istanbul options
 	Istanbul istanbul.Config
+	// Signs the consensus messages with an account of the external signer, which is
+	// ExternalConsensusAddress or the only account of the signer if it is not set.
+	// The node key is still used for p2p, so its address must be the same.
+	ExternalConsensusSigner  bool           `toml:",omitempty"`
+	ExternalConsensusAddress common.Address `toml:",omitempty"`
 
 	// Miscellaneous options
 	DocRoot string `toml:"-"`
@@ -165,6 +185,13 @@ type Config struct {
 	TxResendCount     int
 	TxResendUseLegacy bool
 
+	// Tx announcement options. Transactions larger than the size in bytes are
+	// announced by hash to the peers of the connection type, which fetch them on
+	// demand, instead of being sent in full. 0 disables the announcement.
+	TxAnnounceSizeCN uint64
+	TxAnnounceSizePN uint64
+	TxAnnounceSizeEN uint64
+
 	// Service Chain
 	NoAccountCreation bool
 
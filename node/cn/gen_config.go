@@ -18,58 +18,72 @@ import (
 // MarshalTOML marshals as TOML.
 func (c Config) MarshalTOML() (interface{}, error) {
 	type Config struct {
-		Genesis                 *blockchain.Genesis `toml:",omitempty"`
-		NetworkId               uint64
-		SyncMode                downloader.SyncMode
-		NoPruning               bool
-		WorkerDisable           bool
-		DownloaderDisable       bool
-		FetcherDisable          bool
-		ParentOperatorAddr      *common.Address `toml:",omitempty"`
-		AnchoringPeriod         uint64
-		SentChainTxsLimit       uint64
-		OverwriteGenesis        bool
-		StartBlockNumber        uint64
-		DBType                  database.DBType
-		SkipBcVersionCheck      bool `toml:"-"`
-		SingleDB                bool
-		NumStateTrieShards      uint
-		EnableDBPerfMetrics     bool
-		LevelDBCompression      database.LevelDBCompressionType
-		LevelDBBufferPool       bool
-		LevelDBCacheSize        int
-		DynamoDBConfig          database.DynamoDBConfig
-		TrieCacheSize           int
-		TrieTimeout             time.Duration
-		TrieBlockInterval       uint
-		TriesInMemory           uint64
-		SenderTxHashIndexing    bool
-		ParallelDBWrite         bool
-		TrieNodeCacheConfig     statedb.TrieNodeCacheConfig
-		SnapshotCacheSize       int
-		SnapshotAsyncGen        bool
-		ServiceChainSigner      common.Address `toml:",omitempty"`
-		ExtraData               []byte         `toml:",omitempty"`
-		GasPrice                *big.Int
-		Rewardbase              common.Address `toml:",omitempty"`
-		TxPool                  blockchain.TxPoolConfig
-		GPO                     gasprice.Config
-		EnablePreimageRecording bool
-		EnableInternalTxTracing bool
-		Istanbul                istanbul.Config
-		DocRoot                 string `toml:"-"`
-		WsEndpoint              string `toml:",omitempty"`
-		TxResendInterval        uint64
-		TxResendCount           int
-		TxResendUseLegacy       bool
-		NoAccountCreation       bool
-		IsPrivate               bool
-		AutoRestartFlag         bool
-		RestartTimeOutFlag      time.Duration
-		DaemonPathFlag          string
-		RPCGasCap               *big.Int `toml:",omitempty"`
-		RPCEVMTimeout           time.Duration
-		RPCTxFeeCap             float64
+		Genesis                    *blockchain.Genesis `toml:",omitempty"`
+		NetworkId                  uint64
+		SyncMode                   downloader.SyncMode
+		NoPruning                  bool
+		WorkerDisable              bool
+		TrustedCheckpoint          string           `toml:",omitempty"`
+		TrustedCheckpointSigners   []common.Address `toml:",omitempty"`
+		TrustedCheckpointThreshold int              `toml:",omitempty"`
+		HistoryBackfill            bool             `toml:",omitempty"`
+		DownloaderDisable          bool
+		FetcherDisable             bool
+		ParentOperatorAddr         *common.Address `toml:",omitempty"`
+		AnchoringPeriod            uint64
+		SentChainTxsLimit          uint64
+		LightServ                  bool `toml:",omitempty"`
+		LightPeers                 int  `toml:",omitempty"`
+		OverwriteGenesis           bool
+		StartBlockNumber           uint64
+		DBType                     database.DBType
+		SkipBcVersionCheck         bool `toml:"-"`
+		SingleDB                   bool
+		NumStateTrieShards         uint
+		EnableDBPerfMetrics        bool
+		LevelDBCompression         database.LevelDBCompressionType
+		LevelDBBufferPool          bool
+		LevelDBCacheSize           int
+		DynamoDBConfig             database.DynamoDBConfig
+		TrieCacheSize              int
+		TrieTimeout                time.Duration
+		TrieBlockInterval          uint
+		TriesInMemory              uint64
+		SenderTxHashIndexing       bool
+		ParallelDBWrite            bool
+		TrieNodeCacheConfig        statedb.TrieNodeCacheConfig
+		SnapshotCacheSize          int
+		SnapshotAsyncGen           bool
+		SnapServeResponseSize      uint64
+		SnapServeResponseTime      time.Duration
+		SnapServeCPUShare          float64
+		ServiceChainSigner         common.Address `toml:",omitempty"`
+		ExtraData                  []byte         `toml:",omitempty"`
+		GasPrice                   *big.Int
+		Rewardbase                 common.Address `toml:",omitempty"`
+		TxPool                     blockchain.TxPoolConfig
+		GPO                        gasprice.Config
+		EnablePreimageRecording    bool
+		EnableInternalTxTracing    bool
+		Istanbul                   istanbul.Config
+		ExternalConsensusSigner    bool           `toml:",omitempty"`
+		ExternalConsensusAddress   common.Address `toml:",omitempty"`
+		DocRoot                    string         `toml:"-"`
+		WsEndpoint                 string         `toml:",omitempty"`
+		TxResendInterval           uint64
+		TxResendCount              int
+		TxResendUseLegacy          bool
+		TxAnnounceSizeCN           uint64
+		TxAnnounceSizePN           uint64
+		TxAnnounceSizeEN           uint64
+		NoAccountCreation          bool
+		IsPrivate                  bool
+		AutoRestartFlag            bool
+		RestartTimeOutFlag         time.Duration
+		DaemonPathFlag             string
+		RPCGasCap                  *big.Int `toml:",omitempty"`
+		RPCEVMTimeout              time.Duration
+		RPCTxFeeCap                float64
 	}
 	var enc Config
 	enc.Genesis = c.Genesis
@@ -77,11 +91,17 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.SyncMode = c.SyncMode
 	enc.NoPruning = c.NoPruning
 	enc.WorkerDisable = c.WorkerDisable
+	enc.TrustedCheckpoint = c.TrustedCheckpoint
+	enc.TrustedCheckpointSigners = c.TrustedCheckpointSigners
+	enc.TrustedCheckpointThreshold = c.TrustedCheckpointThreshold
+	enc.HistoryBackfill = c.HistoryBackfill
 	enc.DownloaderDisable = c.DownloaderDisable
 	enc.FetcherDisable = c.FetcherDisable
 	enc.ParentOperatorAddr = c.ParentOperatorAddr
 	enc.AnchoringPeriod = c.AnchoringPeriod
 	enc.SentChainTxsLimit = c.SentChainTxsLimit
+	enc.LightServ = c.LightServ
+	enc.LightPeers = c.LightPeers
 	enc.OverwriteGenesis = c.OverwriteGenesis
 	enc.StartBlockNumber = c.StartBlockNumber
 	enc.DBType = c.DBType
@@ -102,6 +122,9 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.TrieNodeCacheConfig = c.TrieNodeCacheConfig
 	enc.SnapshotCacheSize = c.SnapshotCacheSize
 	enc.SnapshotAsyncGen = c.SnapshotAsyncGen
+	enc.SnapServeResponseSize = c.SnapServeResponseSize
+	enc.SnapServeResponseTime = c.SnapServeResponseTime
+	enc.SnapServeCPUShare = c.SnapServeCPUShare
 	enc.ServiceChainSigner = c.ServiceChainSigner
 	enc.ExtraData = c.ExtraData
 	enc.GasPrice = c.GasPrice
@@ -111,11 +134,16 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.EnablePreimageRecording = c.EnablePreimageRecording
 	enc.EnableInternalTxTracing = c.EnableInternalTxTracing
 	enc.Istanbul = c.Istanbul
+	enc.ExternalConsensusSigner = c.ExternalConsensusSigner
+	enc.ExternalConsensusAddress = c.ExternalConsensusAddress
 	enc.DocRoot = c.DocRoot
 	enc.WsEndpoint = c.WsEndpoint
 	enc.TxResendInterval = c.TxResendInterval
 	enc.TxResendCount = c.TxResendCount
 	enc.TxResendUseLegacy = c.TxResendUseLegacy
+	enc.TxAnnounceSizeCN = c.TxAnnounceSizeCN
+	enc.TxAnnounceSizePN = c.TxAnnounceSizePN
+	enc.TxAnnounceSizeEN = c.TxAnnounceSizeEN
 	enc.NoAccountCreation = c.NoAccountCreation
 	enc.IsPrivate = c.IsPrivate
 	enc.AutoRestartFlag = c.AutoRestartFlag
@@ -130,58 +158,72 @@ func (c Config) MarshalTOML() (interface{}, error) {
 // UnmarshalTOML unmarshals from TOML.
 func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	type Config struct {
-		Genesis                 *blockchain.Genesis `toml:",omitempty"`
-		NetworkId               *uint64
-		SyncMode                *downloader.SyncMode
-		NoPruning               *bool
-		WorkerDisable           *bool
-		DownloaderDisable       *bool
-		FetcherDisable          *bool
-		ParentOperatorAddr      *common.Address `toml:",omitempty"`
-		AnchoringPeriod         *uint64
-		SentChainTxsLimit       *uint64
-		OverwriteGenesis        *bool
-		StartBlockNumber        *uint64
-		DBType                  *database.DBType
-		SkipBcVersionCheck      *bool `toml:"-"`
-		SingleDB                *bool
-		NumStateTrieShards      *uint
-		EnableDBPerfMetrics     *bool
-		LevelDBCompression      *database.LevelDBCompressionType
-		LevelDBBufferPool       *bool
-		LevelDBCacheSize        *int
-		DynamoDBConfig          *database.DynamoDBConfig
-		TrieCacheSize           *int
-		TrieTimeout             *time.Duration
-		TrieBlockInterval       *uint
-		TriesInMemory           *uint64
-		SenderTxHashIndexing    *bool
-		ParallelDBWrite         *bool
-		TrieNodeCacheConfig     *statedb.TrieNodeCacheConfig
-		SnapshotCacheSize       *int
-		SnapshotAsyncGen        *bool
-		ServiceChainSigner      *common.Address `toml:",omitempty"`
-		ExtraData               []byte          `toml:",omitempty"`
-		GasPrice                *big.Int
-		Rewardbase              *common.Address `toml:",omitempty"`
-		TxPool                  *blockchain.TxPoolConfig
-		GPO                     *gasprice.Config
-		EnablePreimageRecording *bool
-		EnableInternalTxTracing *bool
-		Istanbul                *istanbul.Config
-		DocRoot                 *string `toml:"-"`
-		WsEndpoint              *string `toml:",omitempty"`
-		TxResendInterval        *uint64
-		TxResendCount           *int
-		TxResendUseLegacy       *bool
-		NoAccountCreation       *bool
-		IsPrivate               *bool
-		AutoRestartFlag         *bool
-		RestartTimeOutFlag      *time.Duration
-		DaemonPathFlag          *string
-		RPCGasCap               *big.Int `toml:",omitempty"`
-		RPCEVMTimeout           *time.Duration
-		RPCTxFeeCap             *float64
+		Genesis                    *blockchain.Genesis `toml:",omitempty"`
+		NetworkId                  *uint64
+		SyncMode                   *downloader.SyncMode
+		NoPruning                  *bool
+		WorkerDisable              *bool
+		TrustedCheckpoint          *string          `toml:",omitempty"`
+		TrustedCheckpointSigners   []common.Address `toml:",omitempty"`
+		TrustedCheckpointThreshold *int             `toml:",omitempty"`
+		HistoryBackfill            *bool            `toml:",omitempty"`
+		DownloaderDisable          *bool
+		FetcherDisable             *bool
+		ParentOperatorAddr         *common.Address `toml:",omitempty"`
+		AnchoringPeriod            *uint64
+		SentChainTxsLimit          *uint64
+		LightServ                  *bool `toml:",omitempty"`
+		LightPeers                 *int  `toml:",omitempty"`
+		OverwriteGenesis           *bool
+		StartBlockNumber           *uint64
+		DBType                     *database.DBType
+		SkipBcVersionCheck         *bool `toml:"-"`
+		SingleDB                   *bool
+		NumStateTrieShards         *uint
+		EnableDBPerfMetrics        *bool
+		LevelDBCompression         *database.LevelDBCompressionType
+		LevelDBBufferPool          *bool
+		LevelDBCacheSize           *int
+		DynamoDBConfig             *database.DynamoDBConfig
+		TrieCacheSize              *int
+		TrieTimeout                *time.Duration
+		TrieBlockInterval          *uint
+		TriesInMemory              *uint64
+		SenderTxHashIndexing       *bool
+		ParallelDBWrite            *bool
+		TrieNodeCacheConfig        *statedb.TrieNodeCacheConfig
+		SnapshotCacheSize          *int
+		SnapshotAsyncGen           *bool
+		SnapServeResponseSize      *uint64
+		SnapServeResponseTime      *time.Duration
+		SnapServeCPUShare          *float64
+		ServiceChainSigner         *common.Address `toml:",omitempty"`
+		ExtraData                  []byte          `toml:",omitempty"`
+		GasPrice                   *big.Int
+		Rewardbase                 *common.Address `toml:",omitempty"`
+		TxPool                     *blockchain.TxPoolConfig
+		GPO                        *gasprice.Config
+		EnablePreimageRecording    *bool
+		EnableInternalTxTracing    *bool
+		Istanbul                   *istanbul.Config
+		ExternalConsensusSigner    *bool           `toml:",omitempty"`
+		ExternalConsensusAddress   *common.Address `toml:",omitempty"`
+		DocRoot                    *string         `toml:"-"`
+		WsEndpoint                 *string         `toml:",omitempty"`
+		TxResendInterval           *uint64
+		TxResendCount              *int
+		TxResendUseLegacy          *bool
+		TxAnnounceSizeCN           *uint64
+		TxAnnounceSizePN           *uint64
+		TxAnnounceSizeEN           *uint64
+		NoAccountCreation          *bool
+		IsPrivate                  *bool
+		AutoRestartFlag            *bool
+		RestartTimeOutFlag         *time.Duration
+		DaemonPathFlag             *string
+		RPCGasCap                  *big.Int `toml:",omitempty"`
+		RPCEVMTimeout              *time.Duration
+		RPCTxFeeCap                *float64
 	}
 	var dec Config
 	if err := unmarshal(&dec); err != nil {
@@ -202,6 +244,18 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.WorkerDisable != nil {
 		c.WorkerDisable = *dec.WorkerDisable
 	}
+	if dec.TrustedCheckpoint != nil {
+		c.TrustedCheckpoint = *dec.TrustedCheckpoint
+	}
+	if dec.TrustedCheckpointSigners != nil {
+		c.TrustedCheckpointSigners = dec.TrustedCheckpointSigners
+	}
+	if dec.TrustedCheckpointThreshold != nil {
+		c.TrustedCheckpointThreshold = *dec.TrustedCheckpointThreshold
+	}
+	if dec.HistoryBackfill != nil {
+		c.HistoryBackfill = *dec.HistoryBackfill
+	}
 	if dec.DownloaderDisable != nil {
 		c.DownloaderDisable = *dec.DownloaderDisable
 	}
@@ -217,6 +271,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.SentChainTxsLimit != nil {
 		c.SentChainTxsLimit = *dec.SentChainTxsLimit
 	}
+	if dec.LightServ != nil {
+		c.LightServ = *dec.LightServ
+	}
+	if dec.LightPeers != nil {
+		c.LightPeers = *dec.LightPeers
+	}
 	if dec.OverwriteGenesis != nil {
 		c.OverwriteGenesis = *dec.OverwriteGenesis
 	}
@@ -277,6 +337,15 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.SnapshotAsyncGen != nil {
 		c.SnapshotAsyncGen = *dec.SnapshotAsyncGen
 	}
+	if dec.SnapServeResponseSize != nil {
+		c.SnapServeResponseSize = *dec.SnapServeResponseSize
+	}
+	if dec.SnapServeResponseTime != nil {
+		c.SnapServeResponseTime = *dec.SnapServeResponseTime
+	}
+	if dec.SnapServeCPUShare != nil {
+		c.SnapServeCPUShare = *dec.SnapServeCPUShare
+	}
 	if dec.ServiceChainSigner != nil {
 		c.ServiceChainSigner = *dec.ServiceChainSigner
 	}
@@ -304,6 +373,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.Istanbul != nil {
 		c.Istanbul = *dec.Istanbul
 	}
+	if dec.ExternalConsensusSigner != nil {
+		c.ExternalConsensusSigner = *dec.ExternalConsensusSigner
+	}
+	if dec.ExternalConsensusAddress != nil {
+		c.ExternalConsensusAddress = *dec.ExternalConsensusAddress
+	}
 	if dec.DocRoot != nil {
 		c.DocRoot = *dec.DocRoot
 	}
@@ -319,6 +394,15 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.TxResendUseLegacy != nil {
 		c.TxResendUseLegacy = *dec.TxResendUseLegacy
 	}
+	if dec.TxAnnounceSizeCN != nil {
+		c.TxAnnounceSizeCN = *dec.TxAnnounceSizeCN
+	}
+	if dec.TxAnnounceSizePN != nil {
+		c.TxAnnounceSizePN = *dec.TxAnnounceSizePN
+	}
+	if dec.TxAnnounceSizeEN != nil {
+		c.TxAnnounceSizeEN = *dec.TxAnnounceSizeEN
+	}
 	if dec.NoAccountCreation != nil {
 		c.NoAccountCreation = *dec.NoAccountCreation
 	}
@@ -73,6 +73,10 @@ const (
 	// DefaultTxResendInterval is the second of resending transactions period.
 	DefaultTxResendInterval = 4
 
+	// DefaultTxAnnounceSize is the default size in bytes above which transactions
+	// are announced by hash to PN and EN peers instead of being sent in full.
+	DefaultTxAnnounceSize = 4096
+
 	// ExtraNonSnapPeers is the number of non-snap peers allowed to connect more than snap peers.
 	ExtraNonSnapPeers = 5
 )
@@ -104,6 +108,7 @@ type ProtocolManager struct {
 
 	downloader ProtocolManagerDownloader
 	fetcher    ProtocolManagerFetcher
+	txFetcher  ProtocolManagerTxFetcher
 	peers      PeerSet
 
 	SubProtocols []p2p.Protocol
@@ -132,6 +137,17 @@ type ProtocolManager struct {
 	nodetype          common.ConnType
 	txResendUseLegacy bool
 
+	// txAnnounceSize is the size in bytes above which transactions are announced
+	// by hash to the peers of each connection type.
+	txAnnounceSize map[common.ConnType]uint64
+
+	// snapLimits limits the resources spent on serving the snap requests.
+	snapLimits *snap.ServingLimits
+
+	// historyBackfill is whether to download the blocks below a trusted
+	// checkpoint once synchronised.
+	historyBackfill bool
+
 	// syncStop is a flag to stop peer sync
 	syncStop int32
 }
@@ -158,6 +174,17 @@ func NewProtocolManager(config *params.ChainConfig, mode downloader.SyncMode, ne
 		engine:            engine,
 		nodetype:          nodetype,
 		txResendUseLegacy: cnconfig.TxResendUseLegacy,
+		txAnnounceSize: map[common.ConnType]uint64{
+			common.CONSENSUSNODE: cnconfig.TxAnnounceSizeCN,
+			common.PROXYNODE:     cnconfig.TxAnnounceSizePN,
+			common.ENDPOINTNODE:  cnconfig.TxAnnounceSizeEN,
+		},
+		snapLimits: snap.NewServingLimits(snap.ServingConfig{
+			ResponseSize: cnconfig.SnapServeResponseSize,
+			ResponseTime: cnconfig.SnapServeResponseTime,
+			CPUShare:     cnconfig.SnapServeCPUShare,
+		}),
+		historyBackfill: cnconfig.HistoryBackfill,
 	}
 
 	// istanbul BFT
@@ -259,6 +286,8 @@ func NewProtocolManager(config *params.ChainConfig, mode downloader.SyncMode, ne
 					Name:    snap.ProtocolName,
 					Version: version,
 					Length:  snap.ProtocolLengths[version],
+					// Snap sync serves bulk state data, whose bandwidth may be capped.
+					Throttled: true,
 					Run: func(p *p2p.Peer, rw p2p.MsgReadWriter) error {
 						manager.wg.Add(1)
 						defer manager.wg.Done()
@@ -309,7 +338,7 @@ func NewProtocolManager(config *params.ChainConfig, mode downloader.SyncMode, ne
 		if config.Istanbul != nil {
 			proposerPolicy = config.Istanbul.ProposerPolicy
 		}
-		manager.downloader = downloader.New(mode, chainDB, stateBloom, manager.eventMux, blockchain, nil, manager.removePeer, proposerPolicy)
+		manager.downloader = downloader.New(mode, chainDB, stateBloom, manager.eventMux, blockchain, nil, manager.dropStalledPeer, proposerPolicy)
 	}
 
 	// Create and set fetcher
@@ -331,7 +360,17 @@ func NewProtocolManager(config *params.ChainConfig, mode downloader.SyncMode, ne
 			atomic.StoreUint32(&manager.acceptTxs, 1) // Mark initial sync done on any fetcher import
 			return manager.blockchain.InsertChain(blocks)
 		}
-		manager.fetcher = fetcher.New(blockchain.GetBlockByHash, validator, manager.BroadcastBlock, manager.BroadcastBlockHash, heighter, inserter, manager.removePeer)
+		manager.fetcher = fetcher.New(blockchain.GetBlockByHash, validator, manager.BroadcastBlock, manager.BroadcastBlockHash, heighter, inserter, manager.dropPeer)
+	}
+
+	// Create and set transaction fetcher
+	if cnconfig.FetcherDisable {
+		manager.txFetcher = fetcher.NewFakeTxFetcher(txpool.HandleTxMsg)
+	} else {
+		hasTx := func(hash common.Hash) bool {
+			return txpool.Get(hash) != nil
+		}
+		manager.txFetcher = fetcher.NewTxFetcher(hasTx, txpool.HandleTxMsg, manager.requestTxs, manager.penalizeTxPeer)
 	}
 
 	if manager.useTxResend() {
@@ -360,8 +399,9 @@ func (pm *ProtocolManager) removePeer(id string) {
 		pm.downloader.GetSnapSyncer().Unregister(id)
 	}
 
-	// Unregister the peer from the downloader and peer set
+	// Unregister the peer from the downloader, the transaction fetcher and peer set
 	pm.downloader.UnregisterPeer(id)
+	pm.txFetcher.Drop(id)
 	if err := pm.peers.Unregister(id); err != nil {
 		logger.Error("Peer removal failed", "peer", id, "err", err)
 	}
@@ -371,6 +411,63 @@ func (pm *ProtocolManager) removePeer(id string) {
 	}
 }
 
+// dropPeer removes the peer of the given id for an invalid block detected by
+// the fetcher, lowering its reputation score.
+func (pm *ProtocolManager) dropPeer(id string) {
+	pm.reportPeer(id, p2p.ScoreInvalidMessage)
+	pm.removePeer(id)
+}
+
+// dropStalledPeer removes the peer of the given id for the downloader. Most of
+// these drops are for stalled or timed out syncs rather than bad data, so they
+// only lower the reputation score as a timeout does.
+func (pm *ProtocolManager) dropStalledPeer(id string) {
+	pm.reportPeer(id, p2p.ScoreTimeout)
+	pm.removePeer(id)
+}
+
+// penalizeTxPeer lowers the reputation score of the peer of the given id, which
+// timed out or delivered unrequested transactions to the transaction fetcher.
+func (pm *ProtocolManager) penalizeTxPeer(id string, timeout bool) {
+	if timeout {
+		pm.reportPeer(id, p2p.ScoreTimeout)
+	} else {
+		pm.reportPeer(id, p2p.ScoreUselessResponse)
+	}
+}
+
+// reportPeer records a misbehaviour of the peer of the given id in its
+// reputation score.
+func (pm *ProtocolManager) reportPeer(id string, ev p2p.ScoreEvent) {
+	if peer := pm.peers.Peer(id); peer != nil {
+		peer.GetP2PPeer().Report(ev)
+	}
+}
+
+// AddLatencySources lets the peer scorer penalize the peers responding much
+// slower than the others to the snap sync requests.
+func (pm *ProtocolManager) AddLatencySources(scorer *p2p.PeerScorer) {
+	syncer := pm.downloader.GetSnapSyncer()
+	if syncer == nil {
+		return
+	}
+	scorer.AddLatencySource(syncer.Rates(), func(id string) (discover.NodeID, bool) {
+		if peer := pm.peers.Peer(id); peer != nil {
+			return peer.GetP2PPeerID(), true
+		}
+		return discover.NodeID{}, false
+	})
+}
+
+// requestTxs requests a batch of announced transactions from the peer of the given id.
+func (pm *ProtocolManager) requestTxs(id string, hashes []common.Hash) error {
+	peer := pm.peers.Peer(id)
+	if peer == nil {
+		return errNotRegistered
+	}
+	return peer.RequestTxs(hashes)
+}
+
 // getChainID returns the current chain id.
 func (pm *ProtocolManager) getChainID() *big.Int {
 	return pm.blockchain.Config().ChainID
@@ -391,6 +488,7 @@ func (pm *ProtocolManager) Start(maxPeers int) {
 	// start sync handlers
 	go pm.syncer()
 	go pm.txsyncLoop()
+	pm.txFetcher.Start()
 }
 
 func (pm *ProtocolManager) Stop() {
@@ -410,6 +508,7 @@ func (pm *ProtocolManager) Stop() {
 
 	// Quit fetcher, txsyncLoop.
 	close(pm.quitSync)
+	pm.txFetcher.Stop()
 
 	// Disconnect existing sessions.
 	// This also closes the gate for any new registrations on the peer set.
@@ -461,7 +560,7 @@ func (pm *ProtocolManager) handleSnapPeer(peer *snap.Peer) error {
 		return err
 	}
 
-	return snap.Handle(pm.blockchain, pm.downloader, peer)
+	return snap.Handle(pm.blockchain, pm.downloader, pm.snapLimits, peer)
 }
 
 // handle is the callback invoked to manage the life cycle of a Klaytn peer. When
@@ -568,6 +667,7 @@ func (pm *ProtocolManager) handle(p Peer) error {
 		if msg.Size > ProtocolMaxMsgSize {
 			err := errResp(ErrMsgTooLarge, "%v > %v", msg.Size, ProtocolMaxMsgSize)
 			p.GetP2PPeer().Log().Warn("ProtocolManager over max msg size", "err", err)
+			p.GetP2PPeer().Report(p2p.ScoreInvalidMessage)
 			return err
 		}
 
@@ -605,6 +705,7 @@ func (pm *ProtocolManager) processMsg(msgCh <-chan p2p.Msg, p Peer, addr common.
 		for msg := range msgCh {
 			if err := pm.handleMsg(p, addr, msg); err != nil {
 				p.GetP2PPeer().Log().Error("ProtocolManager failed to handle message", "msg", msg, "err", err)
+				p.GetP2PPeer().Report(p2p.ScoreInvalidMessage)
 				errCh <- err
 				return
 			}
@@ -621,7 +722,7 @@ func (pm *ProtocolManager) processConsensusMsg(msgCh <-chan p2p.Msg, p Peer, add
 		if handler, ok := pm.engine.(consensus.Handler); ok {
 			_, err := handler.HandleMsg(addr, msg)
 			// if msg is istanbul msg, handled is true and err is nil if handle msg is successful.
-			if err != nil {
+			if err != nil && err != istanbul.ErrStoppedEngine {
 				p.GetP2PPeer().Log().Warn("ProtocolManager failed to handle consensus message. This can happen during block synchronization.", "msg", msg, "err", err)
 				errCh <- err
 				return
@@ -656,6 +757,11 @@ func (pm *ProtocolManager) handleMsg(p Peer, addr common.Address, msg p2p.Msg) e
 		handled, err := handler.HandleMsg(addr, msg)
 		// if msg is istanbul msg, handled is true and err is nil if handle msg is successful.
 		if handled {
+			// The engine is stopped during block synchronization. The message is
+			// discarded rather than dropping the peer, which may be needed to sync.
+			if err == istanbul.ErrStoppedEngine {
+				return nil
+			}
 			return err
 		}
 	}
@@ -717,6 +823,21 @@ func (pm *ProtocolManager) handleMsg(p Peer, addr common.Address, msg p2p.Msg) e
 			return err
 		}
 
+	case p.GetVersion() >= klay66 && msg.Code == NewPooledTransactionHashesMsg:
+		if err := handleNewPooledTransactionHashesMsg(pm, p, msg); err != nil {
+			return err
+		}
+
+	case p.GetVersion() >= klay66 && msg.Code == PooledTransactionsRequestMsg:
+		if err := handlePooledTransactionsRequestMsg(pm, p, msg); err != nil {
+			return err
+		}
+
+	case p.GetVersion() >= klay66 && msg.Code == PooledTransactionsMsg:
+		if err := handlePooledTransactionsMsg(pm, p, msg); err != nil {
+			return err
+		}
+
 	case msg.Code == NewBlockHashesMsg:
 		if err := handleNewBlockHashesMsg(pm, p, msg); err != nil {
 			return err
@@ -1233,10 +1354,87 @@ func handleTxMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
 		validTxs = append(validTxs, tx)
 		txReceiveCounter.Inc(1)
 	}
-	pm.txpool.HandleTxMsg(validTxs)
+	// The fetcher adds the transactions to the pool and stops waiting for the
+	// announced ones among them.
+	if enqueueErr := pm.txFetcher.Enqueue(p.GetID(), validTxs, false); enqueueErr != nil {
+		return enqueueErr
+	}
 	return err
 }
 
+// handleNewPooledTransactionHashesMsg handles transaction-announcing message.
+func handleNewPooledTransactionHashesMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
+	// Transactions arrived, make sure we have a valid and fresh chain to handle them
+	if atomic.LoadUint32(&pm.acceptTxs) == 0 {
+		return nil
+	}
+	var hashes []common.Hash
+	if err := msg.Decode(&hashes); err != nil {
+		return errResp(ErrDecode, "msg %v: %v", msg, err)
+	}
+	// Schedule all the unknown hashes for retrieval
+	for _, hash := range hashes {
+		p.AddToKnownTxs(hash)
+	}
+	return pm.txFetcher.Notify(p.GetID(), hashes)
+}
+
+// handlePooledTransactionsRequestMsg handles the request of announced transactions.
+func handlePooledTransactionsRequestMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
+	// Decode the retrieval message
+	msgStream := rlp.NewStream(msg.Payload, uint64(msg.Size))
+	if _, err := msgStream.List(); err != nil {
+		return err
+	}
+	// Gather transactions until the fetch or network limits is reached
+	var (
+		hash  common.Hash
+		bytes int
+		txs   []rlp.RawValue
+	)
+	for bytes < softResponseLimit && len(txs) < fetcher.MaxTxRetrievals {
+		// Retrieve the hash of the next transaction
+		if err := msgStream.Decode(&hash); err == rlp.EOL {
+			break
+		} else if err != nil {
+			return errResp(ErrDecode, "msg %v: %v", msg, err)
+		}
+		// Retrieve the requested transaction, skipping if unknown to us
+		tx := pm.txpool.Get(hash)
+		if tx == nil {
+			continue
+		}
+		encoded, err := rlp.EncodeToBytes(tx)
+		if err != nil {
+			logger.Error("Failed to encode transaction", "err", err)
+			continue
+		}
+		txs = append(txs, encoded)
+		bytes += len(encoded)
+	}
+	return p.SendPooledTransactionsRLP(txs)
+}
+
+// handlePooledTransactionsMsg handles the transactions requested by the transaction fetcher.
+func handlePooledTransactionsMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
+	// Transactions arrived, make sure we have a valid and fresh chain to handle them
+	if atomic.LoadUint32(&pm.acceptTxs) == 0 {
+		return nil
+	}
+	var txs types.Transactions
+	if err := msg.Decode(&txs); err != nil {
+		return errResp(ErrDecode, "msg %v: %v", msg, err)
+	}
+	for i, tx := range txs {
+		if tx == nil {
+			return errResp(ErrDecode, "transaction %d is nil", i)
+		}
+		p.AddToKnownTxs(tx.Hash())
+		txReceiveCounter.Inc(1)
+	}
+	return pm.txFetcher.Enqueue(p.GetID(), txs, true)
+}
+
 // sampleSize calculates the number of peers to send block.
 // If calcSampleSize is smaller than minNumPeersToSendBlock, it returns minNumPeersToSendBlock.
 // Otherwise, it returns calcSampleSize.
@@ -1334,7 +1532,13 @@ func (pm *ProtocolManager) broadcastTxsFromCN(txs types.Transactions) {
 	// FIXME include this again: peers = peers[:int(math.Sqrt(float64(len(peers))))]
 	for peer, txs2 := range cnPeersWithoutTxs {
 		// peer.SendTransactions(txs)
-		peer.AsyncSendTransactions(txs2)
+		txs2, hashes := pm.splitTxsToAnnounce(peer, txs2)
+		if len(txs2) > 0 {
+			peer.AsyncSendTransactions(txs2)
+		}
+		if len(hashes) > 0 {
+			peer.AsyncSendPooledTransactionHashes(hashes)
+		}
 	}
 }
 
@@ -1356,8 +1560,8 @@ func (pm *ProtocolManager) broadcastTxsFromPN(txs types.Transactions) {
 	}
 
 	propTxPeersGauge.Update(int64(len(peersWithoutTxs) + len(cnPeersWithoutTxs)))
-	sendTransactions(cnPeersWithoutTxs)
-	sendTransactions(peersWithoutTxs)
+	pm.broadcastTransactions(cnPeersWithoutTxs)
+	pm.broadcastTransactions(peersWithoutTxs)
 }
 
 func (pm *ProtocolManager) broadcastTxsFromEN(txs types.Transactions) {
@@ -1370,7 +1574,7 @@ func (pm *ProtocolManager) broadcastTxsFromEN(txs types.Transactions) {
 	}
 
 	propTxPeersGauge.Update(int64(len(peersWithoutTxs)))
-	sendTransactions(peersWithoutTxs)
+	pm.broadcastTransactions(peersWithoutTxs)
 }
 
 // ReBroadcastTxs sends transactions, not considering whether the peer has the transaction or not.
@@ -1411,6 +1615,61 @@ func sendTransactions(txsSet map[Peer]types.Transactions) {
 	}
 }
 
+// broadcastTransactions sends the paired transactions to each peer like
+// sendTransactions, except that the transactions above the announcement size of
+// the peer are announced by hash.
+func (pm *ProtocolManager) broadcastTransactions(txsSet map[Peer]types.Transactions) {
+	fullTxsSet := make(map[Peer]types.Transactions, len(txsSet))
+	for peer, txs := range txsSet {
+		txs, hashes := pm.splitTxsToAnnounce(peer, txs)
+		if len(txs) > 0 {
+			fullTxsSet[peer] = txs
+		}
+		if len(hashes) > 0 {
+			if err := peer.SendPooledTransactionHashes(hashes); err != nil {
+				logger.Error("Failed to announce txs", "peer", peer.GetAddr(), "peerType", peer.ConnType(), "numTxs", len(hashes), "err", err)
+			}
+		}
+	}
+	sendTransactions(fullTxsSet)
+}
+
+// splitTxsToAnnounce splits the transactions to send to the peer into the ones
+// sent in full and the hashes of the ones announced, which are larger than the
+// announcement size of the peer's connection type. Peers below klay/66 receive
+// all the transactions in full.
+func (pm *ProtocolManager) splitTxsToAnnounce(peer Peer, txs types.Transactions) (types.Transactions, []common.Hash) {
+	if !pm.announcesTxs() {
+		return txs, nil
+	}
+	size := pm.txAnnounceSize[peer.ConnType()]
+	if size == 0 || peer.GetVersion() < klay66 {
+		return txs, nil
+	}
+	var (
+		fullTxs = make(types.Transactions, 0, len(txs))
+		hashes  []common.Hash
+	)
+	for _, tx := range txs {
+		if uint64(tx.Size()) > size {
+			hashes = append(hashes, tx.Hash())
+		} else {
+			fullTxs = append(fullTxs, tx)
+		}
+	}
+	return fullTxs, hashes
+}
+
+// announcesTxs returns true if the transactions are announced to any type of peers.
+func (pm *ProtocolManager) announcesTxs() bool {
+	for _, size := range pm.txAnnounceSize {
+		if size > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func samplingPeers(peers []Peer, pickSize int) []Peer {
 	if len(peers) <= pickSize {
 		return peers
@@ -26,7 +26,9 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus"
 	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/consensus/istanbul/backend"
 	"github.com/klaytn/klaytn/networks/p2p"
 	mocks2 "github.com/klaytn/klaytn/node/cn/mocks"
 	"github.com/klaytn/klaytn/params"
@@ -254,6 +256,7 @@ func TestHandleTxMsg(t *testing.T) {
 	defer mockCtrl.Finish()
 	mockPeer := NewMockPeer(mockCtrl)
 	mockPeer.EXPECT().GetVersion().Return(klay63).AnyTimes()
+	mockPeer.EXPECT().GetID().Return(nodeids[0].String()).AnyTimes()
 
 	txs := types.Transactions{tx1}
 	msg := generateMsg(t, TxMsg, txs)
@@ -262,15 +265,15 @@ func TestHandleTxMsg(t *testing.T) {
 	{
 		assert.NoError(t, pm.handleMsg(mockPeer, addrs[0], msg))
 	}
-	// If pm.acceptTxs == 1, TxPool.HandleTxMsg is called.
+	// If pm.acceptTxs == 1, the transactions are delivered to the tx fetcher as a broadcast.
 	{
 		atomic.StoreUint32(&pm.acceptTxs, 1)
-		mockTxPool := mocks.NewMockTxPool(mockCtrl)
+		mockTxFetcher := mocks2.NewMockProtocolManagerTxFetcher(mockCtrl)
 
 		// The time field in received transaction through pm.handleMsg() has different value from generated transaction(`tx1`).
-		// It can check whether the transaction created `HandleTxMsg()` is the same as `tx1` through `AddToKnownTxs(txs[0].Hash())`.
-		mockTxPool.EXPECT().HandleTxMsg(gomock.Any()).AnyTimes()
-		pm.txpool = mockTxPool
+		// It can check whether the transaction delivered to the fetcher is the same as `tx1` through `AddToKnownTxs(txs[0].Hash())`.
+		mockTxFetcher.EXPECT().Enqueue(nodeids[0].String(), gomock.Any(), false).Return(nil).Times(1)
+		pm.txFetcher = mockTxFetcher
 
 		mockPeer.EXPECT().AddToKnownTxs(txs[0].Hash()).Times(1)
 		assert.NoError(t, pm.handleMsg(mockPeer, addrs[0], msg))
@@ -587,3 +590,150 @@ func TestHandleStakingInfoMsg(t *testing.T) {
 		mockCtrl.Finish()
 	}
 }
+
+func TestHandleNewPooledTransactionHashesMsg(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockPeer := NewMockPeer(mockCtrl)
+	mockPeer.EXPECT().GetID().Return(nodeids[0].String()).AnyTimes()
+
+	mockTxFetcher := mocks2.NewMockProtocolManagerTxFetcher(mockCtrl)
+	pm := &ProtocolManager{txFetcher: mockTxFetcher}
+
+	hashes := []common.Hash{tx1.Hash()}
+
+	// Peers below klay66 cannot announce transactions.
+	{
+		mockPeer.EXPECT().GetVersion().Return(klay65).AnyTimes()
+		assert.Error(t, pm.handleMsg(mockPeer, addrs[0], generateMsg(t, NewPooledTransactionHashesMsg, hashes)))
+	}
+	mockPeer = NewMockPeer(mockCtrl)
+	mockPeer.EXPECT().GetID().Return(nodeids[0].String()).AnyTimes()
+	mockPeer.EXPECT().GetVersion().Return(klay66).AnyTimes()
+	// If pm.acceptTxs == 0, nothing happens.
+	{
+		assert.NoError(t, pm.handleMsg(mockPeer, addrs[0], generateMsg(t, NewPooledTransactionHashesMsg, hashes)))
+	}
+	// If pm.acceptTxs == 1, the hashes are announced to the tx fetcher.
+	{
+		atomic.StoreUint32(&pm.acceptTxs, 1)
+		mockPeer.EXPECT().AddToKnownTxs(tx1.Hash()).Times(1)
+		mockTxFetcher.EXPECT().Notify(nodeids[0].String(), hashes).Return(nil).Times(1)
+		assert.NoError(t, pm.handleMsg(mockPeer, addrs[0], generateMsg(t, NewPooledTransactionHashesMsg, hashes)))
+	}
+}
+
+func TestHandlePooledTransactionsRequestMsg(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockPeer := NewMockPeer(mockCtrl)
+	mockPeer.EXPECT().GetVersion().Return(klay66).AnyTimes()
+
+	mockTxPool := mocks.NewMockTxPool(mockCtrl)
+	pm := &ProtocolManager{txpool: mockTxPool}
+
+	unknown := common.HexToHash("0x1")
+	mockTxPool.EXPECT().Get(tx1.Hash()).Return(tx1).Times(1)
+	mockTxPool.EXPECT().Get(unknown).Return(nil).Times(1)
+
+	// Unknown transactions are skipped in the response.
+	encoded, err := rlp.EncodeToBytes(tx1)
+	assert.NoError(t, err)
+	mockPeer.EXPECT().SendPooledTransactionsRLP([]rlp.RawValue{encoded}).Return(nil).Times(1)
+
+	msg := generateMsg(t, PooledTransactionsRequestMsg, []common.Hash{tx1.Hash(), unknown})
+	assert.NoError(t, pm.handleMsg(mockPeer, addrs[0], msg))
+}
+
+func TestHandlePooledTransactionsMsg(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockPeer := NewMockPeer(mockCtrl)
+	mockPeer.EXPECT().GetVersion().Return(klay66).AnyTimes()
+	mockPeer.EXPECT().GetID().Return(nodeids[0].String()).AnyTimes()
+
+	mockTxFetcher := mocks2.NewMockProtocolManagerTxFetcher(mockCtrl)
+	pm := &ProtocolManager{txFetcher: mockTxFetcher}
+
+	txs := types.Transactions{tx1}
+
+	// If pm.acceptTxs == 0, nothing happens.
+	{
+		assert.NoError(t, pm.handleMsg(mockPeer, addrs[0], generateMsg(t, PooledTransactionsMsg, txs)))
+	}
+	// If pm.acceptTxs == 1, the transactions are delivered to the tx fetcher as a reply.
+	{
+		atomic.StoreUint32(&pm.acceptTxs, 1)
+		mockPeer.EXPECT().AddToKnownTxs(tx1.Hash()).Times(1)
+		mockTxFetcher.EXPECT().Enqueue(nodeids[0].String(), gomock.Any(), true).Return(nil).Times(1)
+		assert.NoError(t, pm.handleMsg(mockPeer, addrs[0], generateMsg(t, PooledTransactionsMsg, txs)))
+	}
+}
+
+func TestSplitTxsToAnnounce(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	txs := types.Transactions{tx1}
+	pm := &ProtocolManager{}
+
+	// Without any announcement size, the transactions are sent in full.
+	{
+		mockPeer := NewMockPeer(mockCtrl)
+		full, hashes := pm.splitTxsToAnnounce(mockPeer, txs)
+		assert.Equal(t, txs, full)
+		assert.Empty(t, hashes)
+	}
+
+	pm.txAnnounceSize = map[common.ConnType]uint64{common.ENDPOINTNODE: 1}
+	// Transactions larger than the announcement size are announced to klay66 peers.
+	{
+		mockPeer := NewMockPeer(mockCtrl)
+		mockPeer.EXPECT().ConnType().Return(common.ENDPOINTNODE).AnyTimes()
+		mockPeer.EXPECT().GetVersion().Return(klay66).AnyTimes()
+		full, hashes := pm.splitTxsToAnnounce(mockPeer, txs)
+		assert.Empty(t, full)
+		assert.Equal(t, []common.Hash{tx1.Hash()}, hashes)
+	}
+	// Peers below klay66 or of other types receive the transactions in full.
+	{
+		mockPeer := NewMockPeer(mockCtrl)
+		mockPeer.EXPECT().ConnType().Return(common.ENDPOINTNODE).AnyTimes()
+		mockPeer.EXPECT().GetVersion().Return(klay65).AnyTimes()
+		full, hashes := pm.splitTxsToAnnounce(mockPeer, txs)
+		assert.Equal(t, txs, full)
+		assert.Empty(t, hashes)
+
+		mockPeer = NewMockPeer(mockCtrl)
+		mockPeer.EXPECT().ConnType().Return(common.PROXYNODE).AnyTimes()
+		mockPeer.EXPECT().GetVersion().Return(klay66).AnyTimes()
+		full, hashes = pm.splitTxsToAnnounce(mockPeer, txs)
+		assert.Equal(t, txs, full)
+		assert.Empty(t, hashes)
+	}
+}
+
+// stoppedEngine is a consensus engine handling the consensus messages while it
+// is stopped, as the istanbul engine does during block synchronization.
+type stoppedEngine struct {
+	consensus.Engine
+}
+
+func (stoppedEngine) NewChainHead() error { return nil }
+func (stoppedEngine) HandleMsg(common.Address, p2p.Msg) (bool, error) {
+	return true, istanbul.ErrStoppedEngine
+}
+func (stoppedEngine) SetBroadcaster(consensus.Broadcaster, common.ConnType) {}
+func (stoppedEngine) RegisterConsensusMsgCode(consensus.Peer)               {}
+
+// TestHandleMsg_StoppedEngine tests that the consensus messages received while
+// the engine is stopped are discarded without dropping the peer, which may be
+// needed to synchronize the blocks.
+func TestHandleMsg_StoppedEngine(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	pm := &ProtocolManager{engine: stoppedEngine{}}
+	msg := generateMsg(t, backend.IstanbulMsg, []byte{})
+	assert.NoError(t, pm.handleMsg(NewMockPeer(mockCtrl), addrs[0], msg))
+}
@@ -238,6 +238,10 @@ func TestProtocolManager_removePeer(t *testing.T) {
 		mockDownloader.EXPECT().UnregisterPeer(peerID).Times(1)
 		pm.downloader = mockDownloader
 
+		mockTxFetcher := mocks.NewMockProtocolManagerTxFetcher(mockCtrl)
+		mockTxFetcher.EXPECT().Drop(peerID).Times(1)
+		pm.txFetcher = mockTxFetcher
+
 		// Return
 		mockPeer.EXPECT().ExistSnapExtension().Return(false).Times(1)
 
@@ -264,6 +268,10 @@ func TestProtocolManager_removePeer(t *testing.T) {
 		mockDownloader.EXPECT().UnregisterPeer(peerID).Times(1)
 		pm.downloader = mockDownloader
 
+		mockTxFetcher := mocks.NewMockProtocolManagerTxFetcher(mockCtrl)
+		mockTxFetcher.EXPECT().Drop(peerID).Times(1)
+		pm.txFetcher = mockTxFetcher
+
 		// Return
 		mockPeer.EXPECT().ExistSnapExtension().Return(false).Times(1)
 
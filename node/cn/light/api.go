@@ -0,0 +1,234 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package light
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/klaytn/klaytn/api"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/node/cn/filters"
+)
+
+var errUnknownBlock = errors.New("unknown block")
+
+// PublicLightAPI serves the read-only `klay` APIs of a light client. Headers are
+// read from the light chain, while the state, the blocks and the receipts are
+// retrieved from the light servers and verified against the headers.
+type PublicLightAPI struct {
+	lc *LightCN
+}
+
+// NewPublicLightAPI creates the `klay` APIs of a light client.
+func NewPublicLightAPI(lc *LightCN) *PublicLightAPI {
+	return &PublicLightAPI{lc: lc}
+}
+
+// ChainID returns the chain ID of the chain from genesis file.
+func (s *PublicLightAPI) ChainID() *hexutil.Big {
+	return (*hexutil.Big)(s.lc.chainConfig.ChainID)
+}
+
+// BlockNumber returns the number of the latest verified header.
+func (s *PublicLightAPI) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(s.lc.chain.CurrentHeader().Number.Uint64())
+}
+
+// Syncing returns false if the header sync is not running, or the sync progress.
+func (s *PublicLightAPI) Syncing() (interface{}, error) {
+	progress := s.lc.downloader.Progress()
+	if progress.CurrentBlock >= progress.HighestBlock {
+		return false, nil
+	}
+	return map[string]interface{}{
+		"startingBlock": hexutil.Uint64(progress.StartingBlock),
+		"currentBlock":  hexutil.Uint64(progress.CurrentBlock),
+		"highestBlock":  hexutil.Uint64(progress.HighestBlock),
+	}, nil
+}
+
+// GetHeaderByNumber returns the requested canonical block header.
+func (s *PublicLightAPI) GetHeaderByNumber(ctx context.Context, number rpc.BlockNumber) (map[string]interface{}, error) {
+	header, err := s.header(rpc.NewBlockNumberOrHashWithNumber(number))
+	if err != nil {
+		return nil, err
+	}
+	return filters.RPCMarshalHeader(header, s.lc.chainConfig.Rules(header.Number)), nil
+}
+
+// GetHeaderByHash returns the requested block header.
+func (s *PublicLightAPI) GetHeaderByHash(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	header, err := s.header(rpc.NewBlockNumberOrHashWithHash(hash, false))
+	if err != nil {
+		return nil, err
+	}
+	return filters.RPCMarshalHeader(header, s.lc.chainConfig.Rules(header.Number)), nil
+}
+
+// GetBlockByNumber returns the requested canonical block. When fullTx is true all
+// transactions in the block are returned in full detail, otherwise only the
+// transaction hash is returned.
+func (s *PublicLightAPI) GetBlockByNumber(ctx context.Context, number rpc.BlockNumber, fullTx bool) (map[string]interface{}, error) {
+	return s.block(ctx, rpc.NewBlockNumberOrHashWithNumber(number), fullTx)
+}
+
+// GetBlockByHash returns the requested block. When fullTx is true all
+// transactions in the block are returned in full detail, otherwise only the
+// transaction hash is returned.
+func (s *PublicLightAPI) GetBlockByHash(ctx context.Context, hash common.Hash, fullTx bool) (map[string]interface{}, error) {
+	return s.block(ctx, rpc.NewBlockNumberOrHashWithHash(hash, false), fullTx)
+}
+
+// GetBlockReceipts returns the receipts of all transactions in the block.
+func (s *PublicLightAPI) GetBlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]map[string]interface{}, error) {
+	header, err := s.header(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	block, err := s.lc.client.GetBlock(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := s.lc.client.GetReceipts(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+	txs := block.Transactions()
+	fieldsList := make([]map[string]interface{}, 0, len(receipts))
+	for index, receipt := range receipts {
+		fieldsList = append(fieldsList, api.RpcOutputReceipt(header, txs[index], block.Hash(), block.NumberU64(), uint64(index), receipt))
+	}
+	return fieldsList, nil
+}
+
+// GetBalance returns the amount of peb for the given address in the state of the
+// given block.
+func (s *PublicLightAPI) GetBalance(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	acc, err := s.account(ctx, address, blockNrOrHash)
+	if err != nil || acc == nil {
+		return (*hexutil.Big)(common.Big0), err
+	}
+	return (*hexutil.Big)(acc.GetBalance()), nil
+}
+
+// GetTransactionCount returns the nonce of the given address in the state of
+// the given block.
+func (s *PublicLightAPI) GetTransactionCount(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Uint64, error) {
+	acc, err := s.account(ctx, address, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	var nonce hexutil.Uint64
+	if acc != nil {
+		nonce = hexutil.Uint64(acc.GetNonce())
+	}
+	return &nonce, nil
+}
+
+// GetAccount returns the account of the given address in the state of the given block.
+func (s *PublicLightAPI) GetAccount(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*account.AccountSerializer, error) {
+	acc, err := s.account(ctx, address, blockNrOrHash)
+	if err != nil || acc == nil {
+		return &account.AccountSerializer{}, err
+	}
+	return account.NewAccountSerializerWithAccount(acc), nil
+}
+
+// GetCode returns the code of the given address in the state of the given block.
+func (s *PublicLightAPI) GetCode(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	header, err := s.header(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	acc, err := s.lc.client.GetAccount(ctx, header, address)
+	if err != nil {
+		return nil, err
+	}
+	pa := account.GetProgramAccount(acc)
+	if pa == nil {
+		return nil, nil
+	}
+	return s.lc.client.GetCode(ctx, header, common.BytesToHash(pa.GetCodeHash()))
+}
+
+// GetStorageAt returns the storage slot of the given address in the state of the
+// given block.
+func (s *PublicLightAPI) GetStorageAt(ctx context.Context, address common.Address, key string, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	header, err := s.header(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	values, err := s.lc.client.GetStorage(ctx, header, address, []common.Hash{common.HexToHash(key)})
+	if err != nil {
+		return nil, err
+	}
+	return values[0][:], nil
+}
+
+// header returns the verified header of the given block number or hash.
+func (s *PublicLightAPI) header(blockNrOrHash rpc.BlockNumberOrHash) (*types.Header, error) {
+	chain := s.lc.chain
+	if number, ok := blockNrOrHash.Number(); ok {
+		var header *types.Header
+		if number == rpc.LatestBlockNumber || number == rpc.PendingBlockNumber {
+			header = chain.CurrentHeader()
+		} else {
+			header = chain.GetHeaderByNumber(uint64(number.Int64()))
+		}
+		if header == nil {
+			return nil, fmt.Errorf("%w: %d", errUnknownBlock, number)
+		}
+		return header, nil
+	}
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		header := chain.GetHeaderByHash(hash)
+		if header == nil {
+			return nil, fmt.Errorf("%w: %x", errUnknownBlock, hash)
+		}
+		if blockNrOrHash.RequireCanonical && chain.GetHeaderByNumber(header.Number.Uint64()).Hash() != hash {
+			return nil, fmt.Errorf("hash %x is not currently canonical", hash)
+		}
+		return header, nil
+	}
+	return nil, errors.New("invalid arguments; neither block nor hash specified")
+}
+
+func (s *PublicLightAPI) account(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (account.Account, error) {
+	header, err := s.header(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return s.lc.client.GetAccount(ctx, header, address)
+}
+
+func (s *PublicLightAPI) block(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, fullTx bool) (map[string]interface{}, error) {
+	header, err := s.header(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	block, err := s.lc.client.GetBlock(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+	return api.RpcOutputBlock(block, s.lc.chain.GetTd(header.Hash(), header.Number.Uint64()), true, fullTx, s.lc.chainConfig.Rules(header.Number))
+}
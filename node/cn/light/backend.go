@@ -0,0 +1,206 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package light implements the `klight` protocol and the header-only light
+// client. Full nodes serve the headers, the bodies, the receipts and the merkle
+// proofs of the state to the light clients, which sync only the headers and
+// verify everything else against them on demand.
+package light
+
+import (
+	"errors"
+
+	"github.com/klaytn/klaytn/api"
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/datasync/downloader"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/governance"
+	"github.com/klaytn/klaytn/log"
+	"github.com/klaytn/klaytn/networks/p2p"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/node"
+	"github.com/klaytn/klaytn/node/cn"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+var logger = log.NewModuleLogger(log.NodeCNLight)
+
+var errNotLightSync = errors.New("can't run light.LightCN without light sync mode")
+
+// LightCN implements the Klaytn light client service.
+type LightCN struct {
+	config      *cn.Config
+	chainConfig *params.ChainConfig
+
+	chainDB    database.DBManager
+	eventMux   *event.TypeMux
+	engine     consensus.Engine
+	governance governance.Engine
+
+	chain      *LightChain
+	downloader *downloader.Downloader
+	client     *Client
+
+	networkId     uint64
+	netRPCService *api.PublicNetAPI
+
+	components []interface{}
+}
+
+// New creates a light client which syncs the headers only and retrieves the
+// other data from the full nodes serving the `klight` protocol.
+func New(ctx *node.ServiceContext, config *cn.Config) (*LightCN, error) {
+	if config.SyncMode != downloader.LightSync {
+		return nil, errNotLightSync
+	}
+	chainDB := cn.CreateDB(ctx, config, "lightchaindata")
+
+	chainConfig, genesisHash, genesisErr := blockchain.SetupGenesisBlock(chainDB, config.Genesis, config.NetworkId, config.IsPrivate, false)
+	if _, ok := genesisErr.(*params.ConfigCompatError); genesisErr != nil && !ok {
+		return nil, genesisErr
+	}
+	if chainConfig.Istanbul != nil {
+		types.EngineType = types.Engine_IBFT
+	}
+	chainConfig.SetDefaults()
+	gov := governance.NewMixedEngine(chainConfig, chainDB)
+	logger.Info("Initialised chain configuration", "config", chainConfig)
+
+	lc := &LightCN{
+		config:      config,
+		chainConfig: chainConfig,
+		chainDB:     chainDB,
+		eventMux:    ctx.EventMux,
+		engine:      cn.CreateConsensusEngine(ctx, config, chainConfig, chainDB, gov, ctx.NodeType()),
+		governance:  gov,
+		networkId:   config.NetworkId,
+	}
+
+	chain, err := NewLightChain(chainDB, chainConfig, lc.engine)
+	if err != nil {
+		return nil, err
+	}
+	lc.chain = chain
+
+	// The governance parameters are applied from the headers. The parameters of
+	// the governance contract are not available, as it can't be executed without
+	// the state.
+	gov.SetBlockchain(chain)
+	if err := gov.UpdateParams(chain.CurrentHeader().Number.Uint64()); err != nil {
+		return nil, err
+	}
+	blockchain.InitDeriveShaWithGov(chainConfig, gov)
+
+	pset, err := gov.EffectiveParams(chain.CurrentHeader().Number.Uint64() + 1)
+	if err != nil {
+		return nil, err
+	}
+	if chainConfig.Istanbul != nil {
+		chainConfig.Istanbul.ProposerPolicy = pset.Policy()
+	}
+
+	// Rewind the chain in case of an incompatible config upgrade.
+	if compat, ok := genesisErr.(*params.ConfigCompatError); ok {
+		logger.Error("Rewinding chain to upgrade configuration", "err", compat)
+		if head := chain.GetHeaderByNumber(compat.RewindTo); head != nil {
+			chain.SetCurrentHeader(head)
+		}
+		chainDB.WriteChainConfig(genesisHash, chainConfig)
+	}
+
+	// Restore the governance state of the Istanbul snapshot before the sync.
+	head := chain.CurrentHeader()
+	if err := lc.engine.CreateSnapshot(chain, head.Number.Uint64(), head.Hash(), nil); err != nil {
+		logger.Error("CreateSnapshot failed", "err", err)
+	}
+	if istBackend, ok := lc.engine.(consensus.Istanbul); ok {
+		istBackend.SetChain(chain)
+	}
+
+	var proposerPolicy uint64
+	if chainConfig.Istanbul != nil {
+		proposerPolicy = chainConfig.Istanbul.ProposerPolicy
+	}
+	lc.downloader = downloader.New(downloader.LightSync, chainDB, nil, lc.eventMux, nil, chain, lc.removePeer, proposerPolicy)
+	lc.client = NewClient(chain, lc.downloader, config.NetworkId)
+
+	lc.components = append(lc.components, chain, chainDB, lc.engine)
+	return lc, nil
+}
+
+// removePeer disconnects a light server which misbehaved during the header sync.
+func (s *LightCN) removePeer(id string) {
+	s.client.peerLock.RLock()
+	peer := s.client.peers[id]
+	s.client.peerLock.RUnlock()
+
+	if peer != nil && peer.Peer != nil {
+		peer.Disconnect(p2p.DiscUselessPeer)
+	}
+}
+
+func (s *LightCN) Chain() *LightChain                     { return s.chain }
+func (s *LightCN) Client() *Client                        { return s.client }
+func (s *LightCN) Downloader() *downloader.Downloader     { return s.downloader }
+func (s *LightCN) Engine() consensus.Engine               { return s.engine }
+func (s *LightCN) ChainDB() database.DBManager            { return s.chainDB }
+func (s *LightCN) ChainConfig() *params.ChainConfig       { return s.chainConfig }
+func (s *LightCN) Governance() governance.Engine          { return s.governance }
+func (s *LightCN) Components() []interface{}              { return s.components }
+func (s *LightCN) SetComponents(components []interface{}) {}
+
+// Protocols implements node.Service, returning the `klight` protocols to
+// connect to the light servers.
+func (s *LightCN) Protocols() []p2p.Protocol {
+	return s.client.Protocols()
+}
+
+// APIs implements node.Service, returning the RPC services of the light client.
+func (s *LightCN) APIs() []rpc.API {
+	apis := s.engine.APIs(s.chain)
+	return append(apis, []rpc.API{
+		{
+			Namespace: "klay",
+			Version:   "1.0",
+			Service:   NewPublicLightAPI(s),
+			Public:    true,
+		}, {
+			Namespace: "net",
+			Version:   "1.0",
+			Service:   s.netRPCService,
+			Public:    true,
+		},
+	}...)
+}
+
+// Start implements node.Service, starting the header sync.
+func (s *LightCN) Start(srvr p2p.Server) error {
+	s.netRPCService = api.NewPublicNetAPI(srvr, s.networkId)
+	s.client.Start()
+	return nil
+}
+
+// Stop implements node.Service, terminating the header sync and closing the database.
+func (s *LightCN) Stop() error {
+	s.client.Stop()
+	s.chain.Stop()
+	s.chainDB.Close()
+	s.eventMux.Stop()
+	return nil
+}
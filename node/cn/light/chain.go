@@ -0,0 +1,146 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package light
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+// LightChain is the chain of a light client, which consists of the headers
+// verified by the consensus engine only. The committed seals of Istanbul headers
+// are verified against the validator sets of the Istanbul snapshots, which are
+// built from the headers as well.
+//
+// It implements downloader.LightChain and consensus.ChainReader.
+type LightChain struct {
+	*blockchain.HeaderChain
+
+	db            database.DBManager
+	chainHeadFeed event.Feed
+	scope         event.SubscriptionScope
+
+	mu      sync.Mutex // Lock protecting the insertion of headers
+	running int32      // 0 if the chain is running, 1 when stopped
+}
+
+// NewLightChain returns a light chain on top of the headers stored in the
+// database, which must contain the genesis block.
+func NewLightChain(db database.DBManager, config *params.ChainConfig, engine consensus.Engine) (*LightChain, error) {
+	lc := &LightChain{db: db}
+
+	hc, err := blockchain.NewHeaderChain(db, config, engine, lc.procInterrupt)
+	if err != nil {
+		return nil, err
+	}
+	lc.HeaderChain = hc
+
+	// The header chain is restored from the head block, which is not written by
+	// a light client. Restore it from the head header instead.
+	if hash := db.ReadHeadHeaderHash(); hash != (common.Hash{}) {
+		if head := hc.GetHeaderByHash(hash); head != nil {
+			hc.SetCurrentHeader(head)
+		}
+	}
+	logger.Info("Loaded most recent local header", "number", lc.CurrentHeader().Number, "hash", lc.CurrentHeader().Hash())
+	return lc, nil
+}
+
+func (lc *LightChain) procInterrupt() bool {
+	return atomic.LoadInt32(&lc.running) == 1
+}
+
+// Stop stops the insertion of headers.
+func (lc *LightChain) Stop() {
+	if !atomic.CompareAndSwapInt32(&lc.running, 0, 1) {
+		return
+	}
+	lc.scope.Close()
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+}
+
+// Genesis returns the genesis header of the chain.
+func (lc *LightChain) Genesis() *types.Header {
+	return lc.GetHeaderByNumber(0)
+}
+
+// CurrentBlock returns a block with the current head header and no body. It
+// exists for the components which only need the number or the header of the
+// current block.
+func (lc *LightChain) CurrentBlock() *types.Block {
+	return types.NewBlockWithHeader(lc.CurrentHeader())
+}
+
+// InsertHeaderChain verifies the headers with the consensus engine and inserts
+// them into the chain. checkFreq is the frequency of the headers whose seals are
+// verified; the last header is always verified. It implements
+// downloader.LightChain.
+func (lc *LightChain) InsertHeaderChain(chain []*types.Header, checkFreq int) (int, error) {
+	if len(chain) == 0 {
+		return 0, nil
+	}
+	start := time.Now()
+	if i, err := lc.ValidateHeaderChain(chain, checkFreq); err != nil {
+		return i, err
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	var heads []*types.Header
+	whFunc := func(header *types.Header) error {
+		status, err := lc.WriteHeader(header)
+		if err == nil && status == blockchain.CanonStatTy {
+			heads = append(heads, header)
+		}
+		return err
+	}
+	n, err := lc.HeaderChain.InsertHeaderChain(chain, whFunc, start)
+	if len(heads) > 0 {
+		lc.chainHeadFeed.Send(blockchain.ChainHeadEvent{Block: types.NewBlockWithHeader(heads[len(heads)-1])})
+	}
+	return n, err
+}
+
+// Rollback removes the given headers from the head of the chain. It is used by
+// the downloader to discard the uncertain headers of a failed sync.
+func (lc *LightChain) Rollback(chain []common.Hash) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		if head := lc.CurrentHeader(); head.Hash() == chain[i] {
+			lc.SetCurrentHeader(lc.GetHeader(head.ParentHash, head.Number.Uint64()-1))
+		}
+	}
+}
+
+// SubscribeChainHeadEvent registers a subscription of the new heads of the chain.
+func (lc *LightChain) SubscribeChainHeadEvent(ch chan<- blockchain.ChainHeadEvent) event.Subscription {
+	return lc.scope.Track(lc.chainHeadFeed.Subscribe(ch))
+}
@@ -0,0 +1,510 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package light
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/datasync/downloader"
+	"github.com/klaytn/klaytn/networks/p2p"
+	"github.com/klaytn/klaytn/networks/p2p/discover"
+	"github.com/klaytn/klaytn/storage/statedb/proofverifier"
+)
+
+const (
+	// requestTimeout is the maximum time to wait for the response of a peer.
+	requestTimeout = 5 * time.Second
+
+	// maxRetrievalPeers is the maximum number of peers a retrieval is tried with.
+	maxRetrievalPeers = 3
+
+	// forceSyncCycle is the interval of the header sync without announcements.
+	forceSyncCycle = 10 * time.Second
+
+	// downloaderVersion is the protocol version the peers are registered to the
+	// downloader with. Only the header retrievals of klay/62 are used.
+	downloaderVersion = 62
+)
+
+var (
+	// ErrNoPeers is returned if there is no peer to retrieve the data from.
+	ErrNoPeers = errors.New("no light server peers")
+
+	// ErrRetrievalFailed is returned if none of the peers returned valid data.
+	ErrRetrievalFailed = errors.New("failed to retrieve valid data from the light server peers")
+
+	errInvalidResponse = errors.New("invalid response")
+
+	emptyCodeHash = crypto.Keccak256Hash(nil)
+)
+
+// Client is the backend of a light client. It syncs the headers of the light
+// chain from the connected full nodes, and retrieves the data which is not kept
+// by a light client on demand, verifying it against the headers.
+type Client struct {
+	chain      *LightChain
+	downloader *downloader.Downloader
+	networkID  uint64
+
+	peers    map[string]*Peer
+	peerLock sync.RWMutex
+
+	pending     map[uint64]*request
+	pendingLock sync.Mutex
+
+	syncCh chan struct{}
+	quit   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// request is a pending request to a peer, waiting for its response.
+type request struct {
+	peer string
+	kind byte // Message code of the expected response
+	res  chan Packet
+}
+
+// NewClient creates a light client backend syncing the given chain with the
+// downloader. The downloader must be created in the light sync mode.
+func NewClient(chain *LightChain, d *downloader.Downloader, networkID uint64) *Client {
+	return &Client{
+		chain:      chain,
+		downloader: d,
+		networkID:  networkID,
+		peers:      make(map[string]*Peer),
+		pending:    make(map[uint64]*request),
+		syncCh:     make(chan struct{}, 1),
+		quit:       make(chan struct{}),
+	}
+}
+
+// Protocols returns the `klight` protocols to connect to the light servers.
+func (c *Client) Protocols() []p2p.Protocol {
+	protocols := make([]p2p.Protocol, 0, len(ProtocolVersions))
+	for _, version := range ProtocolVersions {
+		version := version
+		protocols = append(protocols, p2p.Protocol{
+			Name:    ProtocolName,
+			Version: version,
+			Length:  ProtocolLengths[version],
+			Run: func(p *p2p.Peer, rw p2p.MsgReadWriter) error {
+				return c.RunPeer(NewPeer(version, p, rw))
+			},
+			RunWithRWs: func(p *p2p.Peer, rws []p2p.MsgReadWriter) error {
+				return c.RunPeer(NewPeer(version, p, rws[p2p.ConnDefault]))
+			},
+			PeerInfo: func(id discover.NodeID) interface{} {
+				return nil
+			},
+		})
+	}
+	return protocols
+}
+
+// Start starts syncing the headers.
+func (c *Client) Start() {
+	c.wg.Add(1)
+	go c.syncLoop()
+}
+
+// Stop stops syncing the headers and waits for the peers to be disconnected.
+func (c *Client) Stop() {
+	close(c.quit)
+	c.downloader.Terminate()
+	c.wg.Wait()
+}
+
+// Chain implements Backend. Light clients do not serve any requests.
+func (c *Client) Chain() ServerChain {
+	return nil
+}
+
+// PeerCount returns the number of the connected light servers.
+func (c *Client) PeerCount() int {
+	c.peerLock.RLock()
+	defer c.peerLock.RUnlock()
+
+	return len(c.peers)
+}
+
+// RunPeer handshakes with a light server and handles its messages until it is
+// disconnected.
+func (c *Client) RunPeer(peer *Peer) error {
+	c.wg.Add(1)
+	defer c.wg.Done()
+
+	var (
+		head   = c.chain.CurrentHeader()
+		hash   = head.Hash()
+		number = head.Number.Uint64()
+	)
+	if err := peer.Handshake(c.networkID, c.chain.GetTd(hash, number), hash, number, c.chain.Genesis().Hash()); err != nil {
+		peer.Log().Debug("Light server handshake failed", "err", err)
+		return err
+	}
+
+	c.peerLock.Lock()
+	if _, ok := c.peers[peer.id]; ok {
+		c.peerLock.Unlock()
+		return p2p.DiscAlreadyConnected
+	}
+	c.peers[peer.id] = peer
+	c.peerLock.Unlock()
+
+	defer func() {
+		c.peerLock.Lock()
+		delete(c.peers, peer.id)
+		c.peerLock.Unlock()
+		c.downloader.UnregisterPeer(peer.id)
+	}()
+	if err := c.downloader.RegisterLightPeer(peer.id, downloaderVersion, peer); err != nil {
+		return err
+	}
+	peer.Log().Debug("Light server connected")
+	c.triggerSync()
+
+	errc := make(chan error, 1)
+	go func() { errc <- Handle(c, peer) }()
+	select {
+	case err := <-errc:
+		return err
+	case <-c.quit:
+		return p2p.DiscQuitting
+	}
+}
+
+// Deliver implements Backend, handling the responses and the announcements of
+// the light servers.
+func (c *Client) Deliver(peer *Peer, packet Packet) error {
+	switch packet := packet.(type) {
+	case *AnnouncePacket:
+		if packet.TD == nil {
+			return fmt.Errorf("%w: announcement without total blockscore", errDecode)
+		}
+		peer.SetHead(packet.Hash, packet.Number, packet.TD)
+		c.triggerSync()
+		return nil
+
+	case *BlockHeadersPacket:
+		if packet.ID == downloaderReqID {
+			return c.downloader.DeliverHeaders(peer.id, packet.Headers)
+		}
+		c.deliver(peer, packet.ID, packet)
+	case *BlockBodiesPacket:
+		c.deliver(peer, packet.ID, packet)
+	case *ReceiptsPacket:
+		c.deliver(peer, packet.ID, packet)
+	case *ProofsPacket:
+		c.deliver(peer, packet.ID, packet)
+	case *CodePacket:
+		c.deliver(peer, packet.ID, packet)
+	default:
+		return fmt.Errorf("%w: %s", errUnexpectedResponse, packet.Name())
+	}
+	return nil
+}
+
+// deliver hands a response over to the pending request it answers. Responses
+// to unknown or expired requests are dropped.
+func (c *Client) deliver(peer *Peer, id uint64, packet Packet) {
+	c.pendingLock.Lock()
+	req, ok := c.pending[id]
+	if ok && req.peer == peer.id && req.kind == packet.Kind() {
+		delete(c.pending, id)
+	} else {
+		ok = false
+	}
+	c.pendingLock.Unlock()
+
+	if !ok {
+		peer.Log().Trace("Dropped unrequested response", "reqid", id, "kind", packet.Name())
+		return
+	}
+	req.res <- packet
+}
+
+// triggerSync schedules a header sync if none is scheduled yet.
+func (c *Client) triggerSync() {
+	select {
+	case c.syncCh <- struct{}{}:
+	default:
+	}
+}
+
+// syncLoop syncs the headers with the best peer whenever a peer connects or
+// announces a new head, and periodically.
+func (c *Client) syncLoop() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(forceSyncCycle)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.syncCh:
+			c.synchronise()
+		case <-ticker.C:
+			c.synchronise()
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// synchronise syncs the headers with the peer with the highest total blockscore,
+// if it is higher than the local one.
+func (c *Client) synchronise() {
+	peers := c.bestPeers()
+	if len(peers) == 0 {
+		return
+	}
+	var (
+		best     = peers[0]
+		head     = c.chain.CurrentHeader()
+		localTd  = c.chain.GetTd(head.Hash(), head.Number.Uint64())
+		hash, td = best.Head()
+	)
+	if localTd != nil && td.Cmp(localTd) <= 0 {
+		return
+	}
+	if err := c.downloader.Synchronise(best.id, hash, td, downloader.LightSync); err != nil {
+		logger.Debug("Header synchronisation failed", "peer", best.id, "err", err)
+	}
+}
+
+// bestPeers returns the connected peers sorted by their total blockscore in the
+// descending order.
+func (c *Client) bestPeers() []*Peer {
+	c.peerLock.RLock()
+	peers := make([]*Peer, 0, len(c.peers))
+	for _, peer := range c.peers {
+		peers = append(peers, peer)
+	}
+	c.peerLock.RUnlock()
+
+	sort.SliceStable(peers, func(i, j int) bool {
+		_, tdi := peers[i].Head()
+		_, tdj := peers[j].Head()
+		return tdi.Cmp(tdj) > 0
+	})
+	return peers
+}
+
+// retrieve sends a request to the peers which know the given block, one after
+// another, until a response passes the validation.
+func (c *Client) retrieve(ctx context.Context, number uint64, kind byte, send func(peer *Peer, id uint64) error, validate func(Packet) error) error {
+	var candidates []*Peer
+	for _, peer := range c.bestPeers() {
+		if peer.HeadNumber() >= number {
+			candidates = append(candidates, peer)
+		}
+	}
+	if len(candidates) == 0 {
+		return ErrNoPeers
+	}
+	if len(candidates) > maxRetrievalPeers {
+		candidates = candidates[:maxRetrievalPeers]
+	}
+
+	for _, peer := range candidates {
+		res, err := c.request(ctx, peer, kind, send)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			peer.Log().Debug("Light retrieval failed", "kind", kind, "err", err)
+			continue
+		}
+		if err := validate(res); err != nil {
+			peer.Log().Warn("Light server returned invalid data", "kind", kind, "err", err)
+			continue
+		}
+		return nil
+	}
+	return ErrRetrievalFailed
+}
+
+// request sends a request to a peer and waits for its response.
+func (c *Client) request(ctx context.Context, peer *Peer, kind byte, send func(peer *Peer, id uint64) error) (Packet, error) {
+	req := &request{peer: peer.id, kind: kind, res: make(chan Packet, 1)}
+
+	c.pendingLock.Lock()
+	var id uint64
+	for id == downloaderReqID || c.pending[id] != nil {
+		id = rand.Uint64()
+	}
+	c.pending[id] = req
+	c.pendingLock.Unlock()
+
+	defer func() {
+		c.pendingLock.Lock()
+		delete(c.pending, id)
+		c.pendingLock.Unlock()
+	}()
+
+	if err := send(peer, id); err != nil {
+		return nil, err
+	}
+	timeout := time.NewTimer(requestTimeout)
+	defer timeout.Stop()
+
+	select {
+	case res := <-req.res:
+		return res, nil
+	case <-timeout.C:
+		return nil, errors.New("request timed out")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-c.quit:
+		return nil, errors.New("client stopped")
+	}
+}
+
+// GetAccount retrieves the account of the given address in the state of the
+// block, verified against the state root of the header. It returns nil if the
+// account does not exist.
+func (c *Client) GetAccount(ctx context.Context, header *types.Header, addr common.Address) (account.Account, error) {
+	var acc account.Account
+	err := c.retrieve(ctx, header.Number.Uint64(), ProofsMsg, func(peer *Peer, id uint64) error {
+		return peer.RequestProofs(id, header.Hash(), addr, nil)
+	}, func(packet Packet) (err error) {
+		acc, err = proofverifier.VerifyAccount(header.Root, addr, packet.(*ProofsPacket).AccountProof)
+		return err
+	})
+	return acc, err
+}
+
+// GetStorage retrieves the values of the storage slots of the given account in
+// the state of the block, verified against the state root of the header. The
+// slots of non-existent accounts are empty.
+func (c *Client) GetStorage(ctx context.Context, header *types.Header, addr common.Address, keys []common.Hash) ([]common.Hash, error) {
+	if len(keys) > MaxProofKeys {
+		return nil, fmt.Errorf("too many storage keys: %d > %d", len(keys), MaxProofKeys)
+	}
+	values := make([]common.Hash, len(keys))
+	err := c.retrieve(ctx, header.Number.Uint64(), ProofsMsg, func(peer *Peer, id uint64) error {
+		return peer.RequestProofs(id, header.Hash(), addr, keys)
+	}, func(packet Packet) error {
+		res := packet.(*ProofsPacket)
+		acc, err := proofverifier.VerifyAccount(header.Root, addr, res.AccountProof)
+		if err != nil {
+			return err
+		}
+		if acc == nil {
+			for i := range values {
+				values[i] = common.Hash{}
+			}
+			return nil
+		}
+		if len(res.StorageProofs) != len(keys) {
+			return fmt.Errorf("%w: %d storage proofs for %d keys", errInvalidResponse, len(res.StorageProofs), len(keys))
+		}
+		root := proofverifier.StorageRoot(acc)
+		for i, key := range keys {
+			if values[i], err = proofverifier.VerifyStorage(root, key, res.StorageProofs[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return values, err
+}
+
+// GetCode retrieves the contract bytecode of the given hash, verified against
+// the hash.
+func (c *Client) GetCode(ctx context.Context, header *types.Header, codeHash common.Hash) ([]byte, error) {
+	if codeHash == (common.Hash{}) || codeHash == emptyCodeHash {
+		return nil, nil
+	}
+	var code []byte
+	err := c.retrieve(ctx, header.Number.Uint64(), CodeMsg, func(peer *Peer, id uint64) error {
+		return peer.RequestCode(id, []common.Hash{codeHash})
+	}, func(packet Packet) error {
+		res := packet.(*CodePacket)
+		if len(res.Codes) != 1 || crypto.Keccak256Hash(res.Codes[0]) != codeHash {
+			return fmt.Errorf("%w: code hash mismatch", errInvalidResponse)
+		}
+		code = res.Codes[0]
+		return nil
+	})
+	return code, err
+}
+
+// GetBlock retrieves the transactions of the block of the given header, verified
+// against the transaction root of the header.
+func (c *Client) GetBlock(ctx context.Context, header *types.Header) (*types.Block, error) {
+	var txs types.Transactions
+	err := c.retrieve(ctx, header.Number.Uint64(), BlockBodiesMsg, func(peer *Peer, id uint64) error {
+		return peer.RequestBodies(id, []common.Hash{header.Hash()})
+	}, func(packet Packet) error {
+		res := packet.(*BlockBodiesPacket)
+		if len(res.Bodies) != 1 {
+			return fmt.Errorf("%w: %d bodies for 1 block", errInvalidResponse, len(res.Bodies))
+		}
+		txs = res.Bodies[0]
+		if hash := types.DeriveSha(txs, header.Number); hash != header.TxHash {
+			return fmt.Errorf("%w: transaction root mismatch: %x != %x", errInvalidResponse, hash, header.TxHash)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return types.NewBlockWithHeader(header).WithBody(txs), nil
+}
+
+// GetReceipts retrieves the receipts of the block of the given header, verified
+// against the receipt root of the header. The derived fields of the receipts
+// are filled with the transactions of the block.
+func (c *Client) GetReceipts(ctx context.Context, header *types.Header) (types.Receipts, error) {
+	var receipts types.Receipts
+	err := c.retrieve(ctx, header.Number.Uint64(), ReceiptsMsg, func(peer *Peer, id uint64) error {
+		return peer.RequestReceipts(id, []common.Hash{header.Hash()})
+	}, func(packet Packet) error {
+		res := packet.(*ReceiptsPacket)
+		if len(res.Receipts) != 1 {
+			return fmt.Errorf("%w: %d receipt lists for 1 block", errInvalidResponse, len(res.Receipts))
+		}
+		receipts = res.Receipts[0]
+		if hash := types.DeriveSha(receipts, header.Number); hash != header.ReceiptHash {
+			return fmt.Errorf("%w: receipt root mismatch: %x != %x", errInvalidResponse, hash, header.ReceiptHash)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	block, err := c.GetBlock(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+	if err := blockchain.SetReceiptsData(c.chain.Config(), block, receipts); err != nil {
+		return nil, err
+	}
+	return receipts, nil
+}
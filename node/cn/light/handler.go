@@ -0,0 +1,345 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package light
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/networks/p2p"
+	"github.com/klaytn/klaytn/params"
+)
+
+const (
+	// softResponseLimit is the target maximum size of replies to data retrievals.
+	softResponseLimit = 2 * 1024 * 1024
+
+	// estHeaderSize is the approximate size of an RLP encoded block header.
+	estHeaderSize = 500
+
+	// MaxHeaderFetch is the maximum number of headers served per request.
+	MaxHeaderFetch = 192
+
+	// MaxBodyFetch is the maximum number of block bodies served per request.
+	MaxBodyFetch = 32
+
+	// MaxReceiptFetch is the maximum number of block receipts served per request.
+	MaxReceiptFetch = 64
+
+	// MaxProofKeys is the maximum number of storage slots proven per request.
+	MaxProofKeys = 64
+
+	// MaxCodeFetch is the maximum number of bytecodes served per request.
+	MaxCodeFetch = 64
+)
+
+// ServerChain is the chain a full node serves the light clients with.
+type ServerChain interface {
+	Config() *params.ChainConfig
+	Genesis() *types.Block
+	CurrentHeader() *types.Header
+	GetHeader(hash common.Hash, number uint64) *types.Header
+	GetHeaderByHash(hash common.Hash) *types.Header
+	GetHeaderByNumber(number uint64) *types.Header
+	GetTd(hash common.Hash, number uint64) *big.Int
+	GetBlockByHash(hash common.Hash) *types.Block
+	GetReceiptsByBlockHash(blockHash common.Hash) types.Receipts
+	StateAt(root common.Hash) (*state.StateDB, error)
+	ContractCode(hash common.Hash) ([]byte, error)
+	SubscribeChainHeadEvent(ch chan<- blockchain.ChainHeadEvent) event.Subscription
+}
+
+// Backend handles the messages of the `klight` protocol received from a peer.
+type Backend interface {
+	// Chain returns the chain to serve the requests of the peer with. Light
+	// clients return nil, and disconnect the peers sending them requests.
+	Chain() ServerChain
+
+	// Deliver is invoked from a peer's message handler when it transmits a
+	// response or an announcement for the local node to consume.
+	Deliver(peer *Peer, packet Packet) error
+}
+
+// Handle is the callback invoked to manage the life cycle of a `klight` peer.
+// When this function terminates, the peer is disconnected.
+func Handle(backend Backend, peer *Peer) error {
+	for {
+		if err := HandleMessage(backend, peer); err != nil {
+			peer.Log().Debug("Message handling failed in `klight`", "err", err)
+			return err
+		}
+	}
+}
+
+// HandleMessage is invoked whenever an inbound message is received from a
+// remote peer on the `klight` protocol. The remote connection is torn down upon
+// returning any error.
+func HandleMessage(backend Backend, peer *Peer) error {
+	// Read the next message from the remote peer, and ensure it's fully consumed
+	msg, err := peer.rw.ReadMsg()
+	if err != nil {
+		return err
+	}
+	if msg.Size > maxMessageSize {
+		return fmt.Errorf("%w: %v > %v", errMsgTooLarge, msg.Size, maxMessageSize)
+	}
+	defer msg.Discard()
+
+	var (
+		chain = backend.Chain()
+		req   Packet
+		res   Packet
+	)
+	switch msg.Code {
+	case GetBlockHeadersMsg:
+		req = new(GetBlockHeadersPacket)
+	case GetBlockBodiesMsg:
+		req = new(GetBlockBodiesPacket)
+	case GetReceiptsMsg:
+		req = new(GetReceiptsPacket)
+	case GetProofsMsg:
+		req = new(GetProofsPacket)
+	case GetCodeMsg:
+		req = new(GetCodePacket)
+	case AnnounceMsg:
+		res = new(AnnouncePacket)
+	case BlockHeadersMsg:
+		res = new(BlockHeadersPacket)
+	case BlockBodiesMsg:
+		res = new(BlockBodiesPacket)
+	case ReceiptsMsg:
+		res = new(ReceiptsPacket)
+	case ProofsMsg:
+		res = new(ProofsPacket)
+	case CodeMsg:
+		res = new(CodePacket)
+	default:
+		return fmt.Errorf("%w: %v", errInvalidMsgCode, msg.Code)
+	}
+
+	if res != nil {
+		if err := msg.Decode(res); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		return backend.Deliver(peer, res)
+	}
+
+	if chain == nil {
+		return errNotServing
+	}
+	if err := msg.Decode(req); err != nil {
+		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+	}
+	// Service the request, potentially returning nothing in case of errors
+	switch req := req.(type) {
+	case *GetBlockHeadersPacket:
+		return p2p.Send(peer.rw, BlockHeadersMsg, &BlockHeadersPacket{
+			ID:      req.ID,
+			Headers: ServiceGetBlockHeadersQuery(chain, req),
+		})
+	case *GetBlockBodiesPacket:
+		return p2p.Send(peer.rw, BlockBodiesMsg, &BlockBodiesPacket{
+			ID:     req.ID,
+			Bodies: ServiceGetBlockBodiesQuery(chain, req),
+		})
+	case *GetReceiptsPacket:
+		return p2p.Send(peer.rw, ReceiptsMsg, &ReceiptsPacket{
+			ID:       req.ID,
+			Receipts: ServiceGetReceiptsQuery(chain, req),
+		})
+	case *GetProofsPacket:
+		accountProof, storageProofs := ServiceGetProofsQuery(chain, req)
+		return p2p.Send(peer.rw, ProofsMsg, &ProofsPacket{
+			ID:            req.ID,
+			AccountProof:  accountProof,
+			StorageProofs: storageProofs,
+		})
+	case *GetCodePacket:
+		return p2p.Send(peer.rw, CodeMsg, &CodePacket{
+			ID:    req.ID,
+			Codes: ServiceGetCodeQuery(chain, req),
+		})
+	}
+	return nil
+}
+
+// ServiceGetBlockHeadersQuery assembles the response to a header query. The
+// headers are returned in the order of the query, and the response stops at the
+// first header unknown to the chain.
+func ServiceGetBlockHeadersQuery(chain ServerChain, req *GetBlockHeadersPacket) []*types.Header {
+	var (
+		origin  = req.Origin
+		headers []*types.Header
+		bytes   int
+	)
+	for uint64(len(headers)) < req.Amount && len(headers) < MaxHeaderFetch && bytes < softResponseLimit {
+		var header *types.Header
+		if origin.Hash != (common.Hash{}) {
+			header = chain.GetHeaderByHash(origin.Hash)
+		} else {
+			header = chain.GetHeaderByNumber(origin.Number)
+		}
+		if header == nil {
+			break
+		}
+		headers = append(headers, header)
+		bytes += estHeaderSize
+
+		// Advance to the next header of the query
+		number := header.Number.Uint64()
+		switch {
+		case origin.Hash != (common.Hash{}) && req.Reverse:
+			// Hash based traversal towards the genesis block
+			for i := uint64(0); i <= req.Skip && header != nil; i++ {
+				if number == 0 {
+					return headers
+				}
+				header = chain.GetHeader(header.ParentHash, number-1)
+				number--
+			}
+			if header == nil {
+				return headers
+			}
+			origin.Hash = header.Hash()
+
+		case origin.Hash != (common.Hash{}):
+			// Hash based traversal towards the leaf block, only on the canonical chain
+			if canonical := chain.GetHeaderByNumber(number); canonical == nil || canonical.Hash() != origin.Hash {
+				return headers
+			}
+			next := number + req.Skip + 1
+			if next <= number {
+				return headers
+			}
+			origin = HashOrNumber{Number: next}
+
+		case req.Reverse:
+			// Number based traversal towards the genesis block
+			if origin.Number < req.Skip+1 {
+				return headers
+			}
+			origin.Number -= req.Skip + 1
+
+		default:
+			// Number based traversal towards the leaf block
+			next := origin.Number + req.Skip + 1
+			if next <= origin.Number {
+				return headers
+			}
+			origin.Number = next
+		}
+	}
+	return headers
+}
+
+// ServiceGetBlockBodiesQuery assembles the response to a block body query.
+// Unknown blocks are answered with empty bodies to keep the response aligned
+// with the request.
+func ServiceGetBlockBodiesQuery(chain ServerChain, req *GetBlockBodiesPacket) [][]*types.Transaction {
+	var (
+		bodies [][]*types.Transaction
+		bytes  common.StorageSize
+	)
+	for _, hash := range req.Hashes {
+		if len(bodies) >= MaxBodyFetch || bytes >= softResponseLimit {
+			break
+		}
+		var txs []*types.Transaction
+		if block := chain.GetBlockByHash(hash); block != nil {
+			txs = block.Transactions()
+			bytes += block.Size()
+		}
+		bodies = append(bodies, txs)
+	}
+	return bodies
+}
+
+// ServiceGetReceiptsQuery assembles the response to a receipt query. Unknown
+// blocks are answered with empty receipts to keep the response aligned with the
+// request.
+func ServiceGetReceiptsQuery(chain ServerChain, req *GetReceiptsPacket) [][]*types.Receipt {
+	var (
+		receipts [][]*types.Receipt
+		bytes    common.StorageSize
+	)
+	for _, hash := range req.Hashes {
+		if len(receipts) >= MaxReceiptFetch || bytes >= softResponseLimit {
+			break
+		}
+		results := chain.GetReceiptsByBlockHash(hash)
+		for _, receipt := range results {
+			bytes += receipt.Size()
+		}
+		receipts = append(receipts, results)
+	}
+	return receipts
+}
+
+// ServiceGetProofsQuery assembles the response to a proof query. Nothing is
+// returned if the state of the block is not available.
+func ServiceGetProofsQuery(chain ServerChain, req *GetProofsPacket) ([][]byte, [][][]byte) {
+	if len(req.Keys) > MaxProofKeys {
+		req.Keys = req.Keys[:MaxProofKeys]
+	}
+	header := chain.GetHeaderByHash(req.BlockHash)
+	if header == nil {
+		return nil, nil
+	}
+	stateDB, err := chain.StateAt(header.Root)
+	if err != nil {
+		logger.Debug("Failed to serve proofs", "block", req.BlockHash, "err", err)
+		return nil, nil
+	}
+	accountProof, err := stateDB.GetProof(req.Address)
+	if err != nil {
+		logger.Debug("Failed to prove account", "block", req.BlockHash, "address", req.Address, "err", err)
+		return nil, nil
+	}
+	storageProofs := make([][][]byte, 0, len(req.Keys))
+	for _, key := range req.Keys {
+		proof, err := stateDB.GetStorageProof(req.Address, key)
+		if err != nil {
+			logger.Debug("Failed to prove storage", "block", req.BlockHash, "address", req.Address, "key", key, "err", err)
+			return nil, nil
+		}
+		storageProofs = append(storageProofs, proof)
+	}
+	return accountProof, storageProofs
+}
+
+// ServiceGetCodeQuery assembles the response to a bytecode query. Unknown codes
+// are answered with empty codes to keep the response aligned with the request.
+func ServiceGetCodeQuery(chain ServerChain, req *GetCodePacket) [][]byte {
+	var (
+		codes [][]byte
+		bytes int
+	)
+	for _, hash := range req.Hashes {
+		if len(codes) >= MaxCodeFetch || bytes >= softResponseLimit {
+			break
+		}
+		code, _ := chain.ContractCode(hash)
+		codes = append(codes, code)
+		bytes += len(code)
+	}
+	return codes
+}
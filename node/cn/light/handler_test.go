@@ -0,0 +1,253 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package light
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/gxhash"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/datasync/downloader"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/networks/p2p"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBlocks = 8
+
+var (
+	testKey, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr     = crypto.PubkeyToAddress(testKey.PublicKey)
+	testContract = common.HexToAddress("0x0000000000000000000000000000000000001234")
+	testCode     = []byte{0x60, 0x00, 0x60, 0x00, 0xf3}
+	testSlot     = common.HexToHash("0x01")
+	testValue    = common.HexToHash("0x2a")
+)
+
+// tamperedChain serves the data of a different state and a different block, to
+// check that the light client rejects the responses of a dishonest server.
+type tamperedChain struct {
+	*blockchain.BlockChain
+}
+
+func (c *tamperedChain) StateAt(root common.Hash) (*state.StateDB, error) {
+	return c.BlockChain.StateAt(c.BlockChain.Genesis().Root())
+}
+
+func (c *tamperedChain) ContractCode(hash common.Hash) ([]byte, error) {
+	return []byte{0x00}, nil
+}
+
+func (c *tamperedChain) GetBlockByHash(hash common.Hash) *types.Block {
+	if block := c.BlockChain.GetBlockByHash(hash); block != nil {
+		return block.WithBody(nil)
+	}
+	return nil
+}
+
+func (c *tamperedChain) GetReceiptsByBlockHash(hash common.Hash) types.Receipts {
+	return nil
+}
+
+func newTestServerChain(t *testing.T) (*blockchain.Genesis, *blockchain.BlockChain) {
+	gspec := &blockchain.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: blockchain.GenesisAlloc{
+			testAddr:     {Balance: big.NewInt(params.KLAY)},
+			testContract: {Balance: big.NewInt(0), Code: testCode, Storage: map[common.Hash]common.Hash{testSlot: testValue}},
+		},
+	}
+	db := database.NewMemoryDBManager()
+	genesis := gspec.MustCommit(db)
+	blockchain.InitDeriveSha(gspec.Config)
+
+	signer := types.LatestSignerForChainID(gspec.Config.ChainID)
+	blocks, _ := blockchain.GenerateChain(gspec.Config, genesis, gxhash.NewFaker(), db, testBlocks, func(i int, b *blockchain.BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(b.TxNonce(testAddr), common.Address{0x1}, big.NewInt(1), params.TxGas, nil, nil), signer, testKey)
+		require.NoError(t, err)
+		b.AddTx(tx)
+	})
+	chain, err := blockchain.NewBlockChain(db, nil, gspec.Config, gxhash.NewFaker(), vm.Config{})
+	require.NoError(t, err)
+	_, err = chain.InsertChain(blocks)
+	require.NoError(t, err)
+	return gspec, chain
+}
+
+func newTestClient(t *testing.T, gspec *blockchain.Genesis) *Client {
+	db := database.NewMemoryDBManager()
+	gspec.MustCommit(db)
+
+	chain, err := NewLightChain(db, gspec.Config, gxhash.NewFaker())
+	require.NoError(t, err)
+	d := downloader.New(downloader.LightSync, db, nil, new(event.TypeMux), nil, chain, func(id string) {}, 0)
+	return NewClient(chain, d, 1)
+}
+
+// connect runs the given server and client over a message pipe, which is closed
+// when the server disconnects the client.
+func connect(server *Server, client *Client) {
+	app, net := p2p.MsgPipe()
+	go func() {
+		server.runPeer(NewFakePeer(LIGHT1, "light-client-peer-id", app))
+		app.Close()
+	}()
+	go client.RunPeer(NewFakePeer(LIGHT1, "light-server-peer-id", net))
+}
+
+func waitSynced(t *testing.T, client *Client, number uint64) {
+	assert.Eventually(t, func() bool {
+		return client.chain.CurrentHeader().Number.Uint64() == number
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestLightClient_SyncAndRetrieve(t *testing.T) {
+	gspec, chain := newTestServerChain(t)
+	defer chain.Stop()
+
+	server := NewServer(chain, 1, 10)
+	server.Start(nil)
+	defer server.Stop()
+
+	client := newTestClient(t, gspec)
+	client.Start()
+	defer client.Stop()
+
+	connect(server, client)
+	waitSynced(t, client, testBlocks)
+
+	head := client.chain.CurrentHeader()
+	assert.Equal(t, chain.CurrentHeader().Hash(), head.Hash())
+
+	ctx := context.Background()
+
+	// The accounts and the storage slots are verified against the state root.
+	acc, err := client.GetAccount(ctx, head, testAddr)
+	require.NoError(t, err)
+	expected, err := chain.StateAt(chain.CurrentBlock().Root())
+	require.NoError(t, err)
+	assert.Equal(t, expected.GetBalance(testAddr), acc.GetBalance())
+	assert.Equal(t, uint64(testBlocks), acc.GetNonce())
+
+	acc, err = client.GetAccount(ctx, head, common.HexToAddress("0xdead"))
+	assert.NoError(t, err)
+	assert.Nil(t, acc)
+
+	values, err := client.GetStorage(ctx, head, testContract, []common.Hash{testSlot, common.HexToHash("0x02")})
+	require.NoError(t, err)
+	assert.Equal(t, []common.Hash{testValue, {}}, values)
+
+	// The code is verified against the code hash of the account.
+	acc, err = client.GetAccount(ctx, head, testContract)
+	require.NoError(t, err)
+	code, err := client.GetCode(ctx, head, common.BytesToHash(account.GetProgramAccount(acc).GetCodeHash()))
+	require.NoError(t, err)
+	assert.Equal(t, testCode, code)
+
+	// The bodies and the receipts are verified against the header.
+	block, err := client.GetBlock(ctx, head)
+	require.NoError(t, err)
+	assert.Equal(t, chain.CurrentBlock().Transactions().Len(), block.Transactions().Len())
+	assert.Equal(t, chain.CurrentBlock().Transactions()[0].Hash(), block.Transactions()[0].Hash())
+
+	receipts, err := client.GetReceipts(ctx, head)
+	require.NoError(t, err)
+	require.Len(t, receipts, 1)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipts[0].Status)
+	assert.Equal(t, block.Transactions()[0].Hash(), receipts[0].TxHash)
+	assert.Equal(t, params.TxGas, receipts[0].GasUsed)
+}
+
+func TestLightClient_RejectTamperedResponses(t *testing.T) {
+	gspec, chain := newTestServerChain(t)
+	defer chain.Stop()
+
+	// The client syncs the headers from an honest server first, as the headers
+	// are verified by the consensus engine.
+	honest := NewServer(chain, 1, 10)
+
+	client := newTestClient(t, gspec)
+	client.Start()
+	defer client.Stop()
+
+	connect(honest, client)
+	waitSynced(t, client, testBlocks)
+	honest.Stop()
+	assert.Eventually(t, func() bool { return client.PeerCount() == 0 }, 5*time.Second, 10*time.Millisecond)
+
+	tampered := NewServer(&tamperedChain{chain}, 1, 10)
+	defer tampered.Stop()
+	connect(tampered, client)
+	assert.Eventually(t, func() bool { return client.PeerCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	var (
+		ctx  = context.Background()
+		head = client.chain.CurrentHeader()
+	)
+	_, err := client.GetAccount(ctx, head, testAddr)
+	assert.Error(t, err)
+
+	_, err = client.GetStorage(ctx, head, testContract, []common.Hash{testSlot})
+	assert.Error(t, err)
+
+	_, err = client.GetCode(ctx, head, crypto.Keccak256Hash(testCode))
+	assert.Error(t, err)
+
+	_, err = client.GetBlock(ctx, head)
+	assert.Error(t, err)
+
+	_, err = client.GetReceipts(ctx, head)
+	assert.Error(t, err)
+}
+
+func TestLightClient_HandshakeMismatch(t *testing.T) {
+	_, chain := newTestServerChain(t)
+	defer chain.Stop()
+
+	server := NewServer(chain, 1, 10)
+	defer server.Stop()
+
+	// A client of another chain is rejected on the handshake.
+	other := &blockchain.Genesis{Config: params.TestChainConfig, Alloc: blockchain.GenesisAlloc{testContract: {Balance: big.NewInt(1)}}}
+	client := newTestClient(t, other)
+	defer client.Stop()
+
+	app, net := p2p.MsgPipe()
+	errc := make(chan error, 1)
+	go server.runPeer(NewFakePeer(LIGHT1, "light-client-peer-id", app))
+	go func() { errc <- client.RunPeer(NewFakePeer(LIGHT1, "light-server-peer-id", net)) }()
+
+	select {
+	case err := <-errc:
+		assert.ErrorIs(t, err, errGenesisMismatch)
+	case <-time.After(5 * time.Second):
+		t.Fatal("handshake did not fail")
+	}
+	assert.Equal(t, 0, server.PeerCount())
+}
@@ -0,0 +1,238 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package light
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/log"
+	"github.com/klaytn/klaytn/networks/p2p"
+)
+
+// handshakeTimeout is the maximum allowed time for the `klight` handshake.
+const handshakeTimeout = 5 * time.Second
+
+// downloaderReqID is the request ID of the header requests of the downloader.
+// Responses with this ID are delivered to the downloader instead of a pending
+// request of a client.
+const downloaderReqID = 0
+
+// Peer is a collection of relevant information we have about a `klight` peer.
+type Peer struct {
+	id string // Unique ID for the peer, cached
+
+	*p2p.Peer                   // The embedded P2P package peer
+	rw        p2p.MsgReadWriter // Input/output streams for klight
+	version   uint              // Protocol version negotiated
+
+	head   common.Hash // Latest advertised head block hash
+	number uint64      // Latest advertised head block number
+	td     *big.Int    // Latest advertised head block total blockscore
+	lock   sync.RWMutex
+
+	logger log.Logger // Contextual logger with the peer id injected
+}
+
+// NewPeer create a wrapper for a network connection and negotiated protocol
+// version.
+func NewPeer(version uint, p *p2p.Peer, rw p2p.MsgReadWriter) *Peer {
+	id := p.ID().String()
+	return &Peer{
+		id:      id[:16],
+		Peer:    p,
+		rw:      rw,
+		version: version,
+		td:      new(big.Int),
+		logger:  logger.NewWith("peer", id[:16]),
+	}
+}
+
+// NewFakePeer create a fake klight peer without a backing p2p peer, for testing purposes.
+func NewFakePeer(version uint, id string, rw p2p.MsgReadWriter) *Peer {
+	return &Peer{
+		id:      id[:16],
+		rw:      rw,
+		version: version,
+		td:      new(big.Int),
+		logger:  logger.NewWith("peer", id[:16]),
+	}
+}
+
+// ID retrieves the peer's unique identifier.
+func (p *Peer) ID() string {
+	return p.id
+}
+
+// Version retrieves the peer's negotiated `klight` protocol version.
+func (p *Peer) Version() uint {
+	return p.version
+}
+
+// Log overrides the P2P logger with the higher level one containing only the id.
+func (p *Peer) Log() log.Logger {
+	return p.logger
+}
+
+// Head retrieves the current head hash and total blockscore of the peer.
+func (p *Peer) Head() (hash common.Hash, td *big.Int) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.head, new(big.Int).Set(p.td)
+}
+
+// HeadNumber retrieves the current head number of the peer.
+func (p *Peer) HeadNumber() uint64 {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.number
+}
+
+// SetHead updates the head hash, number and total blockscore of the peer.
+func (p *Peer) SetHead(hash common.Hash, number uint64, td *big.Int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.head, p.number = hash, number
+	p.td.Set(td)
+}
+
+// Handshake exchanges the status of the chains with the remote peer, and
+// checks that both peers are on the same network.
+func (p *Peer) Handshake(networkID uint64, td *big.Int, head common.Hash, number uint64, genesis common.Hash) error {
+	errc := make(chan error, 2)
+	var status StatusPacket // safe to read after two values have been received from errc
+
+	go func() {
+		errc <- p2p.Send(p.rw, StatusMsg, &StatusPacket{
+			ProtocolVersion: uint32(p.version),
+			NetworkID:       networkID,
+			TD:              td,
+			Head:            head,
+			Number:          number,
+			Genesis:         genesis,
+		})
+	}()
+	go func() {
+		errc <- p.readStatus(networkID, genesis, &status)
+	}()
+	timeout := time.NewTimer(handshakeTimeout)
+	defer timeout.Stop()
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-errc:
+			if err != nil {
+				return err
+			}
+		case <-timeout.C:
+			return p2p.DiscReadTimeout
+		}
+	}
+	p.SetHead(status.Head, status.Number, status.TD)
+	return nil
+}
+
+func (p *Peer) readStatus(networkID uint64, genesis common.Hash, status *StatusPacket) error {
+	msg, err := p.rw.ReadMsg()
+	if err != nil {
+		return err
+	}
+	defer msg.Discard()
+
+	if msg.Code != StatusMsg {
+		return fmt.Errorf("%w: first msg has code %x (!= %x)", errNoStatusMsg, msg.Code, StatusMsg)
+	}
+	if msg.Size > maxMessageSize {
+		return fmt.Errorf("%w: %v > %v", errMsgTooLarge, msg.Size, maxMessageSize)
+	}
+	if err := msg.Decode(status); err != nil {
+		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+	}
+	if status.Genesis != genesis {
+		return fmt.Errorf("%w: %x (!= %x)", errGenesisMismatch, status.Genesis[:8], genesis[:8])
+	}
+	if status.NetworkID != networkID {
+		return fmt.Errorf("%w: %d (!= %d)", errNetworkIDMismatch, status.NetworkID, networkID)
+	}
+	if uint(status.ProtocolVersion) != p.version {
+		return fmt.Errorf("%w: %d (!= %d)", errProtocolVersionMismatch, status.ProtocolVersion, p.version)
+	}
+	if status.TD == nil {
+		status.TD = new(big.Int)
+	}
+	return nil
+}
+
+// Announce notifies the remote peer of a new head of the local chain.
+func (p *Peer) Announce(hash common.Hash, number uint64, td *big.Int) error {
+	return p2p.Send(p.rw, AnnounceMsg, &AnnouncePacket{Hash: hash, Number: number, TD: td})
+}
+
+// RequestHeadersByHash fetches a batch of headers for the downloader, starting
+// at the given hash. It implements downloader.LightPeer.
+func (p *Peer) RequestHeadersByHash(origin common.Hash, amount int, skip int, reverse bool) error {
+	p.logger.Debug("Fetching batch of headers", "count", amount, "fromhash", origin, "skip", skip, "reverse", reverse)
+	return p.RequestHeaders(downloaderReqID, HashOrNumber{Hash: origin}, amount, skip, reverse)
+}
+
+// RequestHeadersByNumber fetches a batch of headers for the downloader, starting
+// at the given number. It implements downloader.LightPeer.
+func (p *Peer) RequestHeadersByNumber(origin uint64, amount int, skip int, reverse bool) error {
+	p.logger.Debug("Fetching batch of headers", "count", amount, "fromnum", origin, "skip", skip, "reverse", reverse)
+	return p.RequestHeaders(downloaderReqID, HashOrNumber{Number: origin}, amount, skip, reverse)
+}
+
+// RequestHeaders fetches a batch of headers starting at the given origin.
+func (p *Peer) RequestHeaders(id uint64, origin HashOrNumber, amount int, skip int, reverse bool) error {
+	return p2p.Send(p.rw, GetBlockHeadersMsg, &GetBlockHeadersPacket{
+		ID:      id,
+		Origin:  origin,
+		Amount:  uint64(amount),
+		Skip:    uint64(skip),
+		Reverse: reverse,
+	})
+}
+
+// RequestBodies fetches the transactions of a batch of blocks.
+func (p *Peer) RequestBodies(id uint64, hashes []common.Hash) error {
+	p.logger.Trace("Fetching batch of block bodies", "reqid", id, "count", len(hashes))
+	return p2p.Send(p.rw, GetBlockBodiesMsg, &GetBlockBodiesPacket{ID: id, Hashes: hashes})
+}
+
+// RequestReceipts fetches the receipts of a batch of blocks.
+func (p *Peer) RequestReceipts(id uint64, hashes []common.Hash) error {
+	p.logger.Trace("Fetching batch of receipts", "reqid", id, "count", len(hashes))
+	return p2p.Send(p.rw, GetReceiptsMsg, &GetReceiptsPacket{ID: id, Hashes: hashes})
+}
+
+// RequestProofs fetches the merkle proofs of an account and its storage slots
+// in the state of the given block.
+func (p *Peer) RequestProofs(id uint64, blockHash common.Hash, addr common.Address, keys []common.Hash) error {
+	p.logger.Trace("Fetching account proofs", "reqid", id, "block", blockHash, "address", addr, "keys", len(keys))
+	return p2p.Send(p.rw, GetProofsMsg, &GetProofsPacket{ID: id, BlockHash: blockHash, Address: addr, Keys: keys})
+}
+
+// RequestCode fetches a batch of contract bytecodes by hash.
+func (p *Peer) RequestCode(id uint64, hashes []common.Hash) error {
+	p.logger.Trace("Fetching set of byte codes", "reqid", id, "count", len(hashes))
+	return p2p.Send(p.rw, GetCodeMsg, &GetCodePacket{ID: id, Hashes: hashes})
+}
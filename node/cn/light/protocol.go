@@ -0,0 +1,238 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package light
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+// Constants to match up protocol versions and messages
+const (
+	LIGHT1 = 1
+)
+
+// ProtocolName is the official short name of the `klight` protocol used during
+// devp2p capability negotiation.
+const ProtocolName = "klight"
+
+// ProtocolVersions are the supported versions of the `klight` protocol (first
+// is primary).
+var ProtocolVersions = []uint{LIGHT1}
+
+// ProtocolLengths are the number of implemented message corresponding to
+// different protocol versions.
+var ProtocolLengths = map[uint]uint64{LIGHT1: 12}
+
+// maxMessageSize is the maximum cap on the size of a protocol message.
+const maxMessageSize = 10 * 1024 * 1024
+
+const (
+	StatusMsg          = 0x00
+	AnnounceMsg        = 0x01
+	GetBlockHeadersMsg = 0x02
+	BlockHeadersMsg    = 0x03
+	GetBlockBodiesMsg  = 0x04
+	BlockBodiesMsg     = 0x05
+	GetReceiptsMsg     = 0x06
+	ReceiptsMsg        = 0x07
+	GetProofsMsg       = 0x08
+	ProofsMsg          = 0x09
+	GetCodeMsg         = 0x0a
+	CodeMsg            = 0x0b
+)
+
+var (
+	errMsgTooLarge             = errors.New("message too long")
+	errDecode                  = errors.New("invalid message")
+	errInvalidMsgCode          = errors.New("invalid message code")
+	errNoStatusMsg             = errors.New("no status message")
+	errNetworkIDMismatch       = errors.New("network ID mismatch")
+	errGenesisMismatch         = errors.New("genesis mismatch")
+	errProtocolVersionMismatch = errors.New("protocol version mismatch")
+	errUnexpectedResponse      = errors.New("unexpected response")
+	errNotServing              = errors.New("light client requests are not served")
+)
+
+// Packet represents a p2p message in the `klight` protocol.
+type Packet interface {
+	Name() string // Name returns a string corresponding to the message type.
+	Kind() byte   // Kind returns the message type.
+}
+
+// StatusPacket is the network packet for the status message.
+type StatusPacket struct {
+	ProtocolVersion uint32
+	NetworkID       uint64
+	TD              *big.Int
+	Head            common.Hash
+	Number          uint64
+	Genesis         common.Hash
+}
+
+// AnnouncePacket announces a new head of the chain of a server.
+type AnnouncePacket struct {
+	Hash   common.Hash // Hash of the new head block
+	Number uint64      // Number of the new head block
+	TD     *big.Int    // Total blockscore of the new head block
+}
+
+// HashOrNumber is a combined field for specifying an origin block.
+type HashOrNumber struct {
+	Hash   common.Hash // Block hash from which to retrieve headers (excludes Number)
+	Number uint64      // Block hash from which to retrieve headers (excludes Hash)
+}
+
+// EncodeRLP is a specialized encoder for HashOrNumber to encode only one of the
+// two contained union fields.
+func (hn *HashOrNumber) EncodeRLP(w io.Writer) error {
+	if hn.Hash == (common.Hash{}) {
+		return rlp.Encode(w, hn.Number)
+	}
+	if hn.Number != 0 {
+		return fmt.Errorf("both origin hash (%x) and number (%d) provided", hn.Hash, hn.Number)
+	}
+	return rlp.Encode(w, hn.Hash)
+}
+
+// DecodeRLP is a specialized decoder for HashOrNumber to decode the contents
+// into either a block hash or a block number.
+func (hn *HashOrNumber) DecodeRLP(s *rlp.Stream) error {
+	_, size, _ := s.Kind()
+	origin, err := s.Raw()
+	if err == nil {
+		switch {
+		case size == 32:
+			err = rlp.DecodeBytes(origin, &hn.Hash)
+		case size <= 8:
+			err = rlp.DecodeBytes(origin, &hn.Number)
+		default:
+			err = fmt.Errorf("invalid input size %d for origin", size)
+		}
+	}
+	return err
+}
+
+// GetBlockHeadersPacket represents a block header query.
+type GetBlockHeadersPacket struct {
+	ID      uint64       // Request ID to match up responses with
+	Origin  HashOrNumber // Block from which to retrieve headers
+	Amount  uint64       // Maximum number of headers to retrieve
+	Skip    uint64       // Blocks to skip between consecutive headers
+	Reverse bool         // Query direction (false = rising towards latest, true = falling towards genesis)
+}
+
+// BlockHeadersPacket represents a block header query response.
+type BlockHeadersPacket struct {
+	ID      uint64          // ID of the request this is a response for
+	Headers []*types.Header // Requested block headers
+}
+
+// GetBlockBodiesPacket represents a block body query.
+type GetBlockBodiesPacket struct {
+	ID     uint64        // Request ID to match up responses with
+	Hashes []common.Hash // Block hashes to retrieve the bodies for
+}
+
+// BlockBodiesPacket represents a block body query response. The bodies are the
+// transactions of the requested blocks.
+type BlockBodiesPacket struct {
+	ID     uint64                 // ID of the request this is a response for
+	Bodies [][]*types.Transaction // Transactions of the requested blocks
+}
+
+// GetReceiptsPacket represents a block receipt query.
+type GetReceiptsPacket struct {
+	ID     uint64        // Request ID to match up responses with
+	Hashes []common.Hash // Block hashes to retrieve the receipts for
+}
+
+// ReceiptsPacket represents a block receipt query response.
+type ReceiptsPacket struct {
+	ID       uint64             // ID of the request this is a response for
+	Receipts [][]*types.Receipt // Receipts of the requested blocks
+}
+
+// GetProofsPacket represents a query of the merkle proofs of an account and
+// its storage slots in the state of a block.
+type GetProofsPacket struct {
+	ID        uint64         // Request ID to match up responses with
+	BlockHash common.Hash    // Hash of the block whose state is proven
+	Address   common.Address // Address of the account to prove
+	Keys      []common.Hash  // Storage slots of the account to prove
+}
+
+// ProofsPacket represents an account and storage proof query response.
+type ProofsPacket struct {
+	ID            uint64     // ID of the request this is a response for
+	AccountProof  [][]byte   // Trie nodes proving the account
+	StorageProofs [][][]byte // Trie nodes proving each of the requested slots
+}
+
+// GetCodePacket represents a contract bytecode query.
+type GetCodePacket struct {
+	ID     uint64        // Request ID to match up responses with
+	Hashes []common.Hash // Code hashes to retrieve the code for
+}
+
+// CodePacket represents a contract bytecode query response.
+type CodePacket struct {
+	ID    uint64   // ID of the request this is a response for
+	Codes [][]byte // Requested contract bytecodes
+}
+
+func (*StatusPacket) Name() string { return "Status" }
+func (*StatusPacket) Kind() byte   { return StatusMsg }
+
+func (*AnnouncePacket) Name() string { return "Announce" }
+func (*AnnouncePacket) Kind() byte   { return AnnounceMsg }
+
+func (*GetBlockHeadersPacket) Name() string { return "GetBlockHeaders" }
+func (*GetBlockHeadersPacket) Kind() byte   { return GetBlockHeadersMsg }
+
+func (*BlockHeadersPacket) Name() string { return "BlockHeaders" }
+func (*BlockHeadersPacket) Kind() byte   { return BlockHeadersMsg }
+
+func (*GetBlockBodiesPacket) Name() string { return "GetBlockBodies" }
+func (*GetBlockBodiesPacket) Kind() byte   { return GetBlockBodiesMsg }
+
+func (*BlockBodiesPacket) Name() string { return "BlockBodies" }
+func (*BlockBodiesPacket) Kind() byte   { return BlockBodiesMsg }
+
+func (*GetReceiptsPacket) Name() string { return "GetReceipts" }
+func (*GetReceiptsPacket) Kind() byte   { return GetReceiptsMsg }
+
+func (*ReceiptsPacket) Name() string { return "Receipts" }
+func (*ReceiptsPacket) Kind() byte   { return ReceiptsMsg }
+
+func (*GetProofsPacket) Name() string { return "GetProofs" }
+func (*GetProofsPacket) Kind() byte   { return GetProofsMsg }
+
+func (*ProofsPacket) Name() string { return "Proofs" }
+func (*ProofsPacket) Kind() byte   { return ProofsMsg }
+
+func (*GetCodePacket) Name() string { return "GetCode" }
+func (*GetCodePacket) Kind() byte   { return GetCodeMsg }
+
+func (*CodePacket) Name() string { return "Code" }
+func (*CodePacket) Kind() byte   { return CodeMsg }
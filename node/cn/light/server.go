@@ -0,0 +1,189 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package light
+
+import (
+	"sync"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/networks/p2p"
+	"github.com/klaytn/klaytn/networks/p2p/discover"
+)
+
+// Server serves the light clients connected over the `klight` protocol with the
+// chain of a full node, and announces the new heads of the chain to them.
+type Server struct {
+	chain     ServerChain
+	networkID uint64
+	maxPeers  int
+
+	peers map[string]*Peer
+	lock  sync.RWMutex
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewServer creates a light server which serves at most maxPeers light clients.
+func NewServer(chain ServerChain, networkID uint64, maxPeers int) *Server {
+	return &Server{
+		chain:     chain,
+		networkID: networkID,
+		maxPeers:  maxPeers,
+		peers:     make(map[string]*Peer),
+		quit:      make(chan struct{}),
+	}
+}
+
+// Protocols returns the `klight` protocols served to the light clients.
+func (s *Server) Protocols() []p2p.Protocol {
+	protocols := make([]p2p.Protocol, 0, len(ProtocolVersions))
+	for _, version := range ProtocolVersions {
+		version := version
+		protocols = append(protocols, p2p.Protocol{
+			Name:    ProtocolName,
+			Version: version,
+			Length:  ProtocolLengths[version],
+			Run: func(p *p2p.Peer, rw p2p.MsgReadWriter) error {
+				return s.runPeer(NewPeer(version, p, rw))
+			},
+			RunWithRWs: func(p *p2p.Peer, rws []p2p.MsgReadWriter) error {
+				return s.runPeer(NewPeer(version, p, rws[p2p.ConnDefault]))
+			},
+			PeerInfo: func(id discover.NodeID) interface{} {
+				return nil
+			},
+		})
+	}
+	return protocols
+}
+
+// Start starts announcing the new heads of the chain to the light clients.
+func (s *Server) Start(srvr p2p.Server) {
+	s.wg.Add(1)
+	go s.announceLoop()
+}
+
+// Stop stops the announcements and waits for the light clients to be disconnected.
+func (s *Server) Stop() {
+	close(s.quit)
+	s.wg.Wait()
+}
+
+// SetBloomBitsIndexer is a no-op, as the log filters are not served to light clients.
+func (s *Server) SetBloomBitsIndexer(bbIndexer *blockchain.ChainIndexer) {}
+
+// Chain implements Backend, returning the chain to serve the light clients with.
+func (s *Server) Chain() ServerChain {
+	return s.chain
+}
+
+// Deliver implements Backend. Light clients do not send responses to the server.
+func (s *Server) Deliver(peer *Peer, packet Packet) error {
+	return errUnexpectedResponse
+}
+
+// PeerCount returns the number of the connected light clients.
+func (s *Server) PeerCount() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return len(s.peers)
+}
+
+// runPeer handshakes with a light client and serves its requests until it is
+// disconnected.
+func (s *Server) runPeer(peer *Peer) error {
+	s.wg.Add(1)
+	defer s.wg.Done()
+
+	var (
+		genesis = s.chain.Genesis()
+		head    = s.chain.CurrentHeader()
+		hash    = head.Hash()
+		number  = head.Number.Uint64()
+	)
+	if err := peer.Handshake(s.networkID, s.chain.GetTd(hash, number), hash, number, genesis.Hash()); err != nil {
+		peer.Log().Debug("Light client handshake failed", "err", err)
+		return err
+	}
+
+	s.lock.Lock()
+	if _, ok := s.peers[peer.id]; ok {
+		s.lock.Unlock()
+		return p2p.DiscAlreadyConnected
+	}
+	if len(s.peers) >= s.maxPeers {
+		s.lock.Unlock()
+		return p2p.DiscTooManyPeers
+	}
+	s.peers[peer.id] = peer
+	s.lock.Unlock()
+
+	defer func() {
+		s.lock.Lock()
+		delete(s.peers, peer.id)
+		s.lock.Unlock()
+	}()
+	peer.Log().Debug("Light client connected")
+
+	errc := make(chan error, 1)
+	go func() { errc <- Handle(s, peer) }()
+	select {
+	case err := <-errc:
+		return err
+	case <-s.quit:
+		return p2p.DiscQuitting
+	}
+}
+
+// announceLoop announces the new heads of the chain to the light clients.
+func (s *Server) announceLoop() {
+	defer s.wg.Done()
+
+	headCh := make(chan blockchain.ChainHeadEvent, 10)
+	headSub := s.chain.SubscribeChainHeadEvent(headCh)
+	defer headSub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-headCh:
+			var (
+				hash   = ev.Block.Hash()
+				number = ev.Block.NumberU64()
+				td     = s.chain.GetTd(hash, number)
+			)
+			if td == nil {
+				continue
+			}
+			s.lock.RLock()
+			for _, peer := range s.peers {
+				go func(peer *Peer) {
+					if err := peer.Announce(hash, number, td); err != nil {
+						peer.Log().Debug("Failed to announce new head", "number", number, "err", err)
+					}
+				}(peer)
+			}
+			s.lock.RUnlock()
+
+		case <-headSub.Err():
+			return
+		case <-s.quit:
+			return
+		}
+	}
+}
@@ -33,6 +33,10 @@ var (
 	propTxnOutPacketsMeter               = metrics.NewRegisteredMeter("klay/prop/txns/out/packets", nil)
 	propTxnOutTrafficMeter               = metrics.NewRegisteredMeter("klay/prop/txns/out/traffic", nil)
 	propTxPeersGauge                     = metrics.NewRegisteredGauge("klay/prop/tx/peers/gauge", nil)
+	propTxHashInPacketsMeter             = metrics.NewRegisteredMeter("klay/prop/txhashes/in/packets", nil)
+	propTxHashInTrafficMeter             = metrics.NewRegisteredMeter("klay/prop/txhashes/in/traffic", nil)
+	propTxHashOutPacketsMeter            = metrics.NewRegisteredMeter("klay/prop/txhashes/out/packets", nil)
+	propTxHashOutTrafficMeter            = metrics.NewRegisteredMeter("klay/prop/txhashes/out/traffic", nil)
 	propHashInPacketsMeter               = metrics.NewRegisteredMeter("klay/prop/hashes/in/packets", nil)
 	propHashInTrafficMeter               = metrics.NewRegisteredMeter("klay/prop/hashes/in/traffic", nil)
 	propHashOutPacketsMeter              = metrics.NewRegisteredMeter("klay/prop/hashes/out/packets", nil)
@@ -41,6 +45,10 @@ var (
 	propBlockInTrafficMeter              = metrics.NewRegisteredMeter("klay/prop/blocks/in/traffic", nil)
 	propBlockOutPacketsMeter             = metrics.NewRegisteredMeter("klay/prop/blocks/out/packets", nil)
 	propBlockOutTrafficMeter             = metrics.NewRegisteredMeter("klay/prop/blocks/out/traffic", nil)
+	reqTxnInPacketsMeter                 = metrics.NewRegisteredMeter("klay/req/txns/in/packets", nil)
+	reqTxnInTrafficMeter                 = metrics.NewRegisteredMeter("klay/req/txns/in/traffic", nil)
+	reqTxnOutPacketsMeter                = metrics.NewRegisteredMeter("klay/req/txns/out/packets", nil)
+	reqTxnOutTrafficMeter                = metrics.NewRegisteredMeter("klay/req/txns/out/traffic", nil)
 	reqHeaderInPacketsMeter              = metrics.NewRegisteredMeter("klay/req/headers/in/packets", nil)
 	reqHeaderInTrafficMeter              = metrics.NewRegisteredMeter("klay/req/headers/in/traffic", nil)
 	reqHeaderOutPacketsMeter             = metrics.NewRegisteredMeter("klay/req/headers/out/packets", nil)
@@ -121,6 +129,10 @@ func (rw *meteredMsgReadWriter) ReadMsg() (p2p.Msg, error) {
 		packets, traffic = propBlockInPacketsMeter, propBlockInTrafficMeter
 	case msg.Code == TxMsg:
 		packets, traffic = propTxnInPacketsMeter, propTxnInTrafficMeter
+	case rw.version >= klay66 && msg.Code == NewPooledTransactionHashesMsg:
+		packets, traffic = propTxHashInPacketsMeter, propTxHashInTrafficMeter
+	case rw.version >= klay66 && msg.Code == PooledTransactionsMsg:
+		packets, traffic = reqTxnInPacketsMeter, reqTxnInTrafficMeter
 	case msg.Code == backend.IstanbulMsg:
 		packets, traffic = propConsensusIstanbulInPacketsMeter, propConsensusIstanbulInTrafficMeter
 	}
@@ -150,6 +162,10 @@ func (rw *meteredMsgReadWriter) WriteMsg(msg p2p.Msg) error {
 		packets, traffic = propBlockOutPacketsMeter, propBlockOutTrafficMeter
 	case msg.Code == TxMsg:
 		packets, traffic = propTxnOutPacketsMeter, propTxnOutTrafficMeter
+	case rw.version >= klay66 && msg.Code == NewPooledTransactionHashesMsg:
+		packets, traffic = propTxHashOutPacketsMeter, propTxHashOutTrafficMeter
+	case rw.version >= klay66 && msg.Code == PooledTransactionsMsg:
+		packets, traffic = reqTxnOutPacketsMeter, reqTxnOutTrafficMeter
 	case msg.Code == backend.IstanbulMsg:
 		packets, traffic = propConsensusIstanbulOutPacketsMeter, propConsensusIstanbulOutTrafficMeter
 	}
@@ -40,6 +40,20 @@ func (m *MockProtocolManagerDownloader) EXPECT() *MockProtocolManagerDownloaderM
 	return m.recorder
 }
 
+// BackfillHistory mocks base method.
+func (m *MockProtocolManagerDownloader) BackfillHistory(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillHistory", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BackfillHistory indicates an expected call of BackfillHistory.
+func (mr *MockProtocolManagerDownloaderMockRecorder) BackfillHistory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillHistory", reflect.TypeOf((*MockProtocolManagerDownloader)(nil).BackfillHistory), arg0)
+}
+
 // Cancel mocks base method.
 func (m *MockProtocolManagerDownloader) Cancel() {
 	m.ctrl.T.Helper()
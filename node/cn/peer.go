@@ -57,6 +57,10 @@ const (
 	// contain a single transaction, or thousands.
 	maxQueuedTxs = 128
 
+	// maxQueuedTxAnns is the maximum number of transaction announcements to queue
+	// up before dropping broadcasts.
+	maxQueuedTxAnns = 128
+
 	// maxQueuedProps is the maximum number of block propagations to queue up before
 	// dropping broadcasts. There's not much point in queueing stale blocks, so a few
 	// that might cover uncles should be enough.
@@ -124,6 +128,23 @@ type Peer interface {
 	// AsyncSendTransactions sends transactions asynchronously to the peer.
 	AsyncSendTransactions(txs types.Transactions)
 
+	// SendPooledTransactionHashes announces the availability of a batch of
+	// transactions through their hashes, and includes the hashes in the peer's
+	// transaction hash set for future reference.
+	SendPooledTransactionHashes(hashes []common.Hash) error
+
+	// AsyncSendPooledTransactionHashes queues a batch of transaction hashes for
+	// announcement to the peer. If the peer's announcement queue is full, the
+	// hashes are silently dropped.
+	AsyncSendPooledTransactionHashes(hashes []common.Hash)
+
+	// SendPooledTransactionsRLP sends a batch of requested transactions to the
+	// peer from an already RLP encoded format.
+	SendPooledTransactionsRLP(txs []rlp.RawValue) error
+
+	// RequestTxs fetches a batch of announced transactions from the peer.
+	RequestTxs(hashes []common.Hash) error
+
 	// SendNewBlockHashes announces the availability of a number of blocks through
 	// a hash notification.
 	SendNewBlockHashes(hashes []common.Hash, numbers []uint64) error
@@ -262,6 +283,7 @@ type basePeer struct {
 	knownTxsCache    common.Cache              // FIFO cache of transaction hashes known to be known by this peer
 	knownBlocksCache common.Cache              // FIFO cache of block hashes known to be known by this peer
 	queuedTxs        chan []*types.Transaction // Queue of transactions to broadcast to the peer
+	queuedTxAnns     chan []common.Hash        // Queue of transaction hashes to announce to the peer
 	queuedProps      chan *propEvent           // Queue of blocks to broadcast to the peer
 	queuedAnns       chan *types.Block         // Queue of blocks to announce to the peer
 	term             chan struct{}             // Termination channel to stop the broadcaster
@@ -294,6 +316,7 @@ func newPeer(version int, p *p2p.Peer, rw p2p.MsgReadWriter) Peer {
 			knownTxsCache:    newKnownTxCache(),
 			knownBlocksCache: newKnownBlockCache(),
 			queuedTxs:        make(chan []*types.Transaction, maxQueuedTxs),
+			queuedTxAnns:     make(chan []common.Hash, maxQueuedTxAnns),
 			queuedProps:      make(chan *propEvent, maxQueuedProps),
 			queuedAnns:       make(chan *types.Block, maxQueuedAnns),
 			term:             make(chan struct{}),
@@ -325,6 +348,11 @@ var ChannelOfMessage = map[uint64]int{
 	// Protocol messages belonging to klay/65
 	StakingInfoRequestMsg: p2p.ConnDefault,
 	StakingInfoMsg:        p2p.ConnDefault,
+
+	// Protocol messages belonging to klay/66
+	NewPooledTransactionHashesMsg: p2p.ConnTxMsg,
+	PooledTransactionsRequestMsg:  p2p.ConnTxMsg,
+	PooledTransactionsMsg:         p2p.ConnTxMsg,
 }
 
 var ConcurrentOfChannel = []int{
@@ -348,6 +376,7 @@ func newPeerWithRWs(version int, p *p2p.Peer, rws []p2p.MsgReadWriter) (Peer, er
 			knownTxsCache:    newKnownTxCache(),
 			knownBlocksCache: newKnownBlockCache(),
 			queuedTxs:        make(chan []*types.Transaction, maxQueuedTxs),
+			queuedTxAnns:     make(chan []common.Hash, maxQueuedTxAnns),
 			queuedProps:      make(chan *propEvent, maxQueuedProps),
 			queuedAnns:       make(chan *types.Block, maxQueuedAnns),
 			term:             make(chan struct{}),
@@ -376,6 +405,13 @@ func (p *basePeer) Broadcast() {
 			}
 			p.Log().Trace("Broadcast transactions", "peer", p.id, "count", len(txs))
 
+		case hashes := <-p.queuedTxAnns:
+			if err := p.SendPooledTransactionHashes(hashes); err != nil {
+				logger.Error("fail to SendPooledTransactionHashes", "peer", p.id, "err", err)
+				continue
+			}
+			p.Log().Trace("Announced transactions", "peer", p.id, "count", len(hashes))
+
 		case prop := <-p.queuedProps:
 			if err := p.SendNewBlock(prop.block, prop.td); err != nil {
 				logger.Error("fail to SendNewBlock", "peer", p.id, "err", err)
@@ -487,6 +523,42 @@ func (p *basePeer) AsyncSendTransactions(txs types.Transactions) {
 	}
 }
 
+// SendPooledTransactionHashes announces the availability of a batch of
+// transactions through their hashes, and includes the hashes in the peer's
+// transaction hash set for future reference.
+func (p *basePeer) SendPooledTransactionHashes(hashes []common.Hash) error {
+	for _, hash := range hashes {
+		p.AddToKnownTxs(hash)
+	}
+	return p2p.Send(p.rw, NewPooledTransactionHashesMsg, hashes)
+}
+
+// AsyncSendPooledTransactionHashes queues a batch of transaction hashes for
+// announcement to the peer. If the peer's announcement queue is full, the
+// hashes are silently dropped.
+func (p *basePeer) AsyncSendPooledTransactionHashes(hashes []common.Hash) {
+	select {
+	case p.queuedTxAnns <- hashes:
+		for _, hash := range hashes {
+			p.AddToKnownTxs(hash)
+		}
+	default:
+		p.Log().Trace("Dropping transaction announcement", "count", len(hashes))
+	}
+}
+
+// SendPooledTransactionsRLP sends a batch of requested transactions to the peer
+// from an already RLP encoded format.
+func (p *basePeer) SendPooledTransactionsRLP(txs []rlp.RawValue) error {
+	return p2p.Send(p.rw, PooledTransactionsMsg, txs)
+}
+
+// RequestTxs fetches a batch of announced transactions from the peer.
+func (p *basePeer) RequestTxs(hashes []common.Hash) error {
+	p.Log().Debug("Fetching batch of transactions", "count", len(hashes))
+	return p2p.Send(p.rw, PooledTransactionsRequestMsg, hashes)
+}
+
 // SendNewBlockHashes announces the availability of a number of blocks through
 // a hash notification.
 func (p *basePeer) SendNewBlockHashes(hashes []common.Hash, numbers []uint64) error {
@@ -830,6 +902,13 @@ func (p *multiChannelPeer) Broadcast() {
 			}
 			p.Log().Trace("Broadcast transactions", "peer", p.id, "count", len(txs))
 
+		case hashes := <-p.queuedTxAnns:
+			if err := p.SendPooledTransactionHashes(hashes); err != nil {
+				logger.Error("fail to SendPooledTransactionHashes", "peer", p.id, "err", err)
+				continue
+			}
+			p.Log().Trace("Announced transactions", "peer", p.id, "count", len(hashes))
+
 		case prop := <-p.queuedProps:
 			if err := p.SendNewBlock(prop.block, prop.td); err != nil {
 				logger.Error("fail to SendNewBlock", "peer", p.id, "err", err)
@@ -877,6 +956,28 @@ func (p *multiChannelPeer) ReSendTransactions(txs types.Transactions) error {
 	return p.msgSender(TxMsg, txs)
 }
 
+// SendPooledTransactionHashes announces the availability of a batch of
+// transactions through their hashes, and includes the hashes in the peer's
+// transaction hash set for future reference.
+func (p *multiChannelPeer) SendPooledTransactionHashes(hashes []common.Hash) error {
+	for _, hash := range hashes {
+		p.AddToKnownTxs(hash)
+	}
+	return p.msgSender(NewPooledTransactionHashesMsg, hashes)
+}
+
+// SendPooledTransactionsRLP sends a batch of requested transactions to the peer
+// from an already RLP encoded format.
+func (p *multiChannelPeer) SendPooledTransactionsRLP(txs []rlp.RawValue) error {
+	return p.msgSender(PooledTransactionsMsg, txs)
+}
+
+// RequestTxs fetches a batch of announced transactions from the peer.
+func (p *multiChannelPeer) RequestTxs(hashes []common.Hash) error {
+	p.Log().Debug("Fetching batch of transactions", "count", len(hashes))
+	return p.msgSender(PooledTransactionsRequestMsg, hashes)
+}
+
 // SendNewBlockHashes announces the availability of a number of blocks through
 // a hash notification.
 func (p *multiChannelPeer) SendNewBlockHashes(hashes []common.Hash, numbers []uint64) error {
@@ -1062,12 +1163,14 @@ func (p *multiChannelPeer) ReadMsg(rw p2p.MsgReadWriter, connectionOrder int, er
 		msgCh, err := p.chMgr.GetChannelWithMsgCode(connectionOrder, msg.Code)
 		if err != nil {
 			p.GetP2PPeer().Log().Warn("ProtocolManager failed to get msg channel", "err", err)
+			p.GetP2PPeer().Report(p2p.ScoreInvalidMessage)
 			errCh <- err
 			return
 		}
 		if msg.Size > ProtocolMaxMsgSize {
 			err = errResp(ErrMsgTooLarge, "%v > %v", msg.Size, ProtocolMaxMsgSize)
 			p.GetP2PPeer().Log().Warn("ProtocolManager over max msg size", "err", err)
+			p.GetP2PPeer().Report(p2p.ScoreInvalidMessage)
 			errCh <- err
 			return
 		}
@@ -716,3 +716,57 @@ func (mr *MockPeerMockRecorder) UpdateRWImplementationVersion() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRWImplementationVersion", reflect.TypeOf((*MockPeer)(nil).UpdateRWImplementationVersion))
 }
+
+// AsyncSendPooledTransactionHashes mocks base method
+func (m *MockPeer) AsyncSendPooledTransactionHashes(arg0 []common.Hash) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AsyncSendPooledTransactionHashes", arg0)
+}
+
+// AsyncSendPooledTransactionHashes indicates an expected call of AsyncSendPooledTransactionHashes
+func (mr *MockPeerMockRecorder) AsyncSendPooledTransactionHashes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AsyncSendPooledTransactionHashes", reflect.TypeOf((*MockPeer)(nil).AsyncSendPooledTransactionHashes), arg0)
+}
+
+// SendPooledTransactionHashes mocks base method
+func (m *MockPeer) SendPooledTransactionHashes(arg0 []common.Hash) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPooledTransactionHashes", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPooledTransactionHashes indicates an expected call of SendPooledTransactionHashes
+func (mr *MockPeerMockRecorder) SendPooledTransactionHashes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPooledTransactionHashes", reflect.TypeOf((*MockPeer)(nil).SendPooledTransactionHashes), arg0)
+}
+
+// SendPooledTransactionsRLP mocks base method
+func (m *MockPeer) SendPooledTransactionsRLP(arg0 []rlp.RawValue) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPooledTransactionsRLP", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPooledTransactionsRLP indicates an expected call of SendPooledTransactionsRLP
+func (mr *MockPeerMockRecorder) SendPooledTransactionsRLP(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPooledTransactionsRLP", reflect.TypeOf((*MockPeer)(nil).SendPooledTransactionsRLP), arg0)
+}
+
+// RequestTxs mocks base method
+func (m *MockPeer) RequestTxs(arg0 []common.Hash) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestTxs", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestTxs indicates an expected call of RequestTxs
+func (mr *MockPeerMockRecorder) RequestTxs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestTxs", reflect.TypeOf((*MockPeer)(nil).RequestTxs), arg0)
+}
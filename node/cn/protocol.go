@@ -42,16 +42,17 @@ const (
 	klay63 = 63
 	klay64 = 64
 	klay65 = 65
+	klay66 = 66
 )
 
 // ProtocolName is the official short name of the protocol used during capability negotiation.
 var ProtocolName = "klay"
 
 // ProtocolVersions are the upported versions of the klay protocol (first is primary).
-var ProtocolVersions = []uint{klay65, klay64, klay63, klay62}
+var ProtocolVersions = []uint{klay66, klay65, klay64, klay63, klay62}
 
 // ProtocolLengths are the number of implemented message corresponding to different protocol versions.
-var ProtocolLengths = []uint64{21, 19, 17, 8}
+var ProtocolLengths = []uint64{24, 21, 19, 17, 8}
 
 const ProtocolMaxMsgSize = 12 * 1024 * 1024 // Maximum cap on the size of a protocol message
 
@@ -86,7 +87,12 @@ const (
 	StakingInfoRequestMsg = 0x12
 	StakingInfoMsg        = 0x13
 
-	MsgCodeEnd = 0x14
+	// Protocol messages belonging to klay/66
+	NewPooledTransactionHashesMsg = 0x14
+	PooledTransactionsRequestMsg  = 0x15
+	PooledTransactionsMsg         = 0x16
+
+	MsgCodeEnd = 0x17
 )
 
 type errCode int
@@ -149,6 +155,7 @@ type ProtocolManagerDownloader interface {
 	GetSnapSyncer() *snap.Syncer
 	SyncStakingInfo(id string, from, to uint64) error
 	SyncStakingInfoStatus() *downloader.SyncingStatus
+	BackfillHistory(id string) error
 }
 
 //go:generate mockgen -destination=node/cn/mocks/fetcher_mock.go -package=mocks github.com/klaytn/klaytn/node/cn ProtocolManagerFetcher
@@ -162,6 +169,16 @@ type ProtocolManagerFetcher interface {
 	Stop()
 }
 
+//go:generate mockgen -destination=node/cn/mocks/tx_fetcher_mock.go -package=mocks github.com/klaytn/klaytn/node/cn ProtocolManagerTxFetcher
+// ProtocolManagerTxFetcher is an interface of fetcher.TxFetcher used by ProtocolManager.
+type ProtocolManagerTxFetcher interface {
+	Notify(peer string, hashes []common.Hash) error
+	Enqueue(peer string, txs []*types.Transaction, direct bool) error
+	Drop(peer string) error
+	Start()
+	Stop()
+}
+
 // statusData is the network packet for the status message.
 type statusData struct {
 	ProtocolVersion uint32
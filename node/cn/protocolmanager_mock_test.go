@@ -188,3 +188,15 @@ func (mr *MockBackendProtocolManagerMockRecorder) Stop() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockBackendProtocolManager)(nil).Stop))
 }
+
+// AddLatencySources mocks base method.
+func (m *MockBackendProtocolManager) AddLatencySources(arg0 *p2p.PeerScorer) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddLatencySources", arg0)
+}
+
+// AddLatencySources indicates an expected call of AddLatencySources.
+func (mr *MockBackendProtocolManagerMockRecorder) AddLatencySources(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLatencySources", reflect.TypeOf((*MockBackendProtocolManager)(nil).AddLatencySources), arg0)
+}
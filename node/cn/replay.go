@@ -0,0 +1,56 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"fmt"
+
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/p2p"
+)
+
+// ReplayCapture feeds the klay messages received in a capture, as recorded by a
+// p2p.MsgRecorder, to the protocol manager in their order, as if p sent them.
+// It stops at the first message the protocol manager fails to handle and
+// returns its error, so that an incident recorded in production can be
+// reproduced in a test.
+//
+// The handshake is not replayed, so the status messages are skipped, and the
+// messages of all the peers in the capture are attributed to p. Filter the
+// capture beforehand to replay the messages of a single peer.
+func (pm *ProtocolManager) ReplayCapture(p Peer, msgs []*p2p.CapturedMsg) error {
+	pubKey, err := p.GetP2PPeerID().Pubkey()
+	if err != nil {
+		return err
+	}
+	addr := crypto.PubkeyToAddress(*pubKey)
+
+	for i, captured := range msgs {
+		if !captured.Inbound || captured.Protocol != ProtocolName || captured.Code == StatusMsg {
+			continue
+		}
+		msg := captured.Msg()
+		if msg.Size > ProtocolMaxMsgSize {
+			return fmt.Errorf("captured message %d (code %#x): %w", i, msg.Code, errResp(ErrMsgTooLarge, "%v > %v", msg.Size, ProtocolMaxMsgSize))
+		}
+		if err := pm.handleMsg(p, addr, msg); err != nil {
+			return fmt.Errorf("captured message %d (code %#x): %w", i, msg.Code, err)
+		}
+		msg.Discard()
+	}
+	return nil
+}
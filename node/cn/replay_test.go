@@ -0,0 +1,64 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/networks/p2p"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// capturedMsg creates a captured message of the klay protocol.
+func capturedMsg(t *testing.T, inbound bool, code uint64, data interface{}) *p2p.CapturedMsg {
+	payload, err := rlp.EncodeToBytes(data)
+	require.NoError(t, err)
+	return &p2p.CapturedMsg{Peer: nodeids[0], Protocol: ProtocolName, Inbound: inbound, Code: code, Payload: payload}
+}
+
+func TestProtocolManager_ReplayCapture(t *testing.T) {
+	mockCtrl, mockDownloader, mockPeer, pm := prepareDownloader(t)
+	defer mockCtrl.Finish()
+	mockPeer.EXPECT().GetP2PPeerID().Return(nodeids[0]).AnyTimes()
+
+	headers := []*types.Header{blocks[0].Header(), blocks[1].Header()}
+	snapMsg := capturedMsg(t, true, BlockHeadersMsg, headers)
+	snapMsg.Protocol = "snap"
+
+	// The handshake, the messages sent and those of the other protocols are
+	// skipped. The replay stops at the message that fails to decode.
+	msgs := []*p2p.CapturedMsg{
+		capturedMsg(t, true, StatusMsg, "status"),
+		capturedMsg(t, false, BlockHeadersMsg, headers),
+		snapMsg,
+		capturedMsg(t, true, BlockHeadersMsg, headers),
+		capturedMsg(t, true, BlockHeadersMsg, headers[0]),
+		capturedMsg(t, true, BlockHeadersMsg, headers),
+	}
+	mockDownloader.EXPECT().DeliverHeaders(nodeids[0].String(), gomock.Eq(headers)).Return(nil).Times(1)
+
+	err := pm.ReplayCapture(mockPeer, msgs)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "captured message 4")
+
+	mockDownloader.EXPECT().DeliverHeaders(nodeids[0].String(), gomock.Eq(headers)).Return(nil).Times(1)
+	assert.NoError(t, pm.ReplayCapture(mockPeer, msgs[:4]))
+}
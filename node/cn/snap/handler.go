@@ -36,7 +36,7 @@ import (
 )
 
 const (
-	// softResponseLimit is the target maximum size of replies to data retrievals.
+	// softResponseLimit is the default target maximum size of replies to data retrievals.
 	softResponseLimit = 2 * 1024 * 1024
 
 	// maxCodeLookups is the maximum number of bytecodes to serve. This number is
@@ -52,7 +52,7 @@ const (
 	// number is there to limit the number of disk lookups.
 	maxTrieNodeLookups = 1024
 
-	// maxTrieNodeTimeSpent is the maximum time we should spend on looking up trie nodes.
+	// maxTrieNodeTimeSpent is the default maximum time we should spend on looking up state.
 	// If we spend too much time, then it's a fairly high chance of timing out
 	// at the remote side, which means all the work is in vain.
 	maxTrieNodeTimeSpent = 5 * time.Second
@@ -77,9 +77,9 @@ type SnapshotDownloader interface {
 
 // Handle is the callback invoked to manage the life cycle of a `snap` peer.
 // When this function terminates, the peer is disconnected.
-func Handle(reader SnapshotReader, downloader SnapshotDownloader, peer *Peer) error {
+func Handle(reader SnapshotReader, downloader SnapshotDownloader, limits *ServingLimits, peer *Peer) error {
 	for {
-		if err := HandleMessage(reader, downloader, peer); err != nil {
+		if err := HandleMessage(reader, downloader, limits, peer); err != nil {
 			peer.Log().Debug("Message handling failed in `snap`", "err", err)
 			return err
 		}
@@ -88,8 +88,8 @@ func Handle(reader SnapshotReader, downloader SnapshotDownloader, peer *Peer) er
 
 // HandleMessage is invoked whenever an inbound message is received from a
 // remote peer on the `snap` protocol. The remote connection is torn down upon
-// returning any error.
-func HandleMessage(reader SnapshotReader, downloader SnapshotDownloader, peer *Peer) error {
+// returning any error. The requests of the peer are served within the limits.
+func HandleMessage(reader SnapshotReader, downloader SnapshotDownloader, limits *ServingLimits, peer *Peer) error {
 	// Read the next message from the remote peer, and ensure it's fully consumed
 	msg, err := peer.rw.ReadMsg()
 	if err != nil {
@@ -99,7 +99,6 @@ func HandleMessage(reader SnapshotReader, downloader SnapshotDownloader, peer *P
 		return fmt.Errorf("%w: %v > %v", errMsgTooLarge, msg.Size, maxMessageSize)
 	}
 	defer msg.Discard()
-	start := time.Now()
 	// TODO-Klaytn-SnapSync add metric to track the amount of time it takes to serve the request and run the manager
 	// Handle the message depending on its contents
 	switch {
@@ -110,7 +109,9 @@ func HandleMessage(reader SnapshotReader, downloader SnapshotDownloader, peer *P
 			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
 		}
 		// Service the request, potentially returning nothing in case of errors
-		accounts, proofs := ServiceGetAccountRangeQuery(reader, &req)
+		start := limits.acquire()
+		accounts, proofs := ServiceGetAccountRangeQuery(reader, &req, limits, start)
+		limits.release(start)
 
 		// Send back anything accumulated (or empty in case of errors)
 		return p2p.Send(peer.rw, AccountRangeMsg, &AccountRangePacket{
@@ -142,7 +143,9 @@ func HandleMessage(reader SnapshotReader, downloader SnapshotDownloader, peer *P
 			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
 		}
 		// Service the request, potentially returning nothing in case of errors
-		slots, proofs := ServiceGetStorageRangesQuery(reader, &req)
+		start := limits.acquire()
+		slots, proofs := ServiceGetStorageRangesQuery(reader, &req, limits, start)
+		limits.release(start)
 
 		// Send back anything accumulated (or empty in case of errors)
 		return p2p.Send(peer.rw, StorageRangesMsg, &StorageRangesPacket{
@@ -176,7 +179,9 @@ func HandleMessage(reader SnapshotReader, downloader SnapshotDownloader, peer *P
 			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
 		}
 		// Service the request, potentially returning nothing in case of errors
-		codes := ServiceGetByteCodesQuery(reader, &req)
+		start := limits.acquire()
+		codes := ServiceGetByteCodesQuery(reader, &req, limits, start)
+		limits.release(start)
 
 		// Send back anything accumulated (or empty in case of errors)
 		return p2p.Send(peer.rw, ByteCodesMsg, &ByteCodesPacket{
@@ -201,7 +206,9 @@ func HandleMessage(reader SnapshotReader, downloader SnapshotDownloader, peer *P
 			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
 		}
 		// Service the request, potentially returning nothing in case of errors
-		nodes, err := ServiceGetTrieNodesQuery(reader, &req, start)
+		start := limits.acquire()
+		nodes, err := ServiceGetTrieNodesQuery(reader, &req, limits, start)
+		limits.release(start)
 		if err != nil {
 			return err
 		}
@@ -228,10 +235,8 @@ func HandleMessage(reader SnapshotReader, downloader SnapshotDownloader, peer *P
 
 // ServiceGetAccountRangeQuery assembles the response to an account range query.
 // It is exposed to allow external packages to test protocol behavior.
-func ServiceGetAccountRangeQuery(chain SnapshotReader, req *GetAccountRangePacket) ([]*AccountData, [][]byte) {
-	if req.Bytes > softResponseLimit {
-		req.Bytes = softResponseLimit
-	}
+func ServiceGetAccountRangeQuery(chain SnapshotReader, req *GetAccountRangePacket, limits *ServingLimits, start time.Time) ([]*AccountData, [][]byte) {
+	limits.capBytes(&req.Bytes)
 	// TODO-Klaytn-SnapSync investigate the cache pollution
 	// Retrieve the requested state and bail out if non existent
 	tr, err := statedb.NewTrie(req.Root, chain.StateCache().TrieDB(), nil)
@@ -265,7 +270,7 @@ func ServiceGetAccountRangeQuery(chain SnapshotReader, req *GetAccountRangePacke
 			break
 		}
 		// TODO-Klaytn-SnapSync check if the size is much larger than soft response limit
-		if size > req.Bytes {
+		if size > req.Bytes || limits.expired(start) {
 			break
 		}
 	}
@@ -291,10 +296,10 @@ func ServiceGetAccountRangeQuery(chain SnapshotReader, req *GetAccountRangePacke
 	return accounts, proofs
 }
 
-func ServiceGetStorageRangesQuery(chain SnapshotReader, req *GetStorageRangesPacket) ([][]*StorageData, [][]byte) {
-	if req.Bytes > softResponseLimit {
-		req.Bytes = softResponseLimit
-	}
+// ServiceGetStorageRangesQuery assembles the response to a storage ranges query.
+// It is exposed to allow external packages to test protocol behavior.
+func ServiceGetStorageRangesQuery(chain SnapshotReader, req *GetStorageRangesPacket, limits *ServingLimits, start time.Time) ([][]*StorageData, [][]byte) {
+	limits.capBytes(&req.Bytes)
 	// TODO-Klaytn-SnapSync Do we want to enforce > 0 accounts and 1 account if origin is set?
 	// TODO-Klaytn-SnapSync   - Logging locally is not ideal as remote faulst annoy the local user
 	// TODO-Klaytn-SnapSync   - Dropping the remote peer is less flexible wrt client bugs (slow is better than non-functional)
@@ -311,7 +316,7 @@ func ServiceGetStorageRangesQuery(chain SnapshotReader, req *GetStorageRangesPac
 	for _, accountHash := range req.Accounts {
 		// If we've exceeded the requested data limit, abort without opening
 		// a new storage range (that we'd need to prove due to exceeded size)
-		if size >= req.Bytes {
+		if size >= req.Bytes || limits.expired(start) {
 			break
 		}
 		// The first account might start from a different origin and end sooner
@@ -335,7 +340,7 @@ func ServiceGetStorageRangesQuery(chain SnapshotReader, req *GetStorageRangesPac
 			abort   bool
 		)
 		for it.Next() {
-			if size >= hardLimit {
+			if size >= hardLimit || limits.expired(start) {
 				abort = true
 				break
 			}
@@ -378,7 +383,7 @@ func ServiceGetStorageRangesQuery(chain SnapshotReader, req *GetStorageRangesPac
 				// TODO-Klaytn-SnapSync it would be better to continue rather than return. Do not waste the completed job until now.
 				return nil, nil
 			}
-			stTrie, err := statedb.NewStorageTrie(pacc.GetStorageRoot(), chain.StateCache().TrieDB(), nil)
+			stTrie, err := statedb.NewStorageTrie(pacc.GetStorageRoot(), chain.StateCache().TrieDB(), &statedb.TrieOpts{Owner: accountHash})
 			if err != nil {
 				return nil, nil
 			}
@@ -407,10 +412,8 @@ func ServiceGetStorageRangesQuery(chain SnapshotReader, req *GetStorageRangesPac
 
 // ServiceGetByteCodesQuery assembles the response to a byte codes query.
 // It is exposed to allow external packages to test protocol behavior.
-func ServiceGetByteCodesQuery(chain SnapshotReader, req *GetByteCodesPacket) [][]byte {
-	if req.Bytes > softResponseLimit {
-		req.Bytes = softResponseLimit
-	}
+func ServiceGetByteCodesQuery(chain SnapshotReader, req *GetByteCodesPacket, limits *ServingLimits, start time.Time) [][]byte {
+	limits.capBytes(&req.Bytes)
 	if len(req.Hashes) > maxCodeLookups {
 		req.Hashes = req.Hashes[:maxCodeLookups]
 	}
@@ -428,7 +431,7 @@ func ServiceGetByteCodesQuery(chain SnapshotReader, req *GetByteCodesPacket) [][
 			codes = append(codes, blob)
 			bytes += uint64(len(blob))
 		}
-		if bytes > req.Bytes {
+		if bytes > req.Bytes || limits.expired(start) {
 			break
 		}
 	}
@@ -437,10 +440,8 @@ func ServiceGetByteCodesQuery(chain SnapshotReader, req *GetByteCodesPacket) [][
 
 // ServiceGetTrieNodesQuery assembles the response to a trie nodes query.
 // It is exposed to allow external packages to test protocol behavior.
-func ServiceGetTrieNodesQuery(chain SnapshotReader, req *GetTrieNodesPacket, start time.Time) ([][]byte, error) {
-	if req.Bytes > softResponseLimit {
-		req.Bytes = softResponseLimit
-	}
+func ServiceGetTrieNodesQuery(chain SnapshotReader, req *GetTrieNodesPacket, limits *ServingLimits, start time.Time) ([][]byte, error) {
+	limits.capBytes(&req.Bytes)
 	// Make sure we have the state associated with the request
 	triedb := chain.StateCache().TrieDB()
 
@@ -490,7 +491,7 @@ func ServiceGetTrieNodesQuery(chain SnapshotReader, req *GetTrieNodesPacket, sta
 			if pacc == nil {
 				break
 			}
-			stTrie, err := statedb.NewSecureStorageTrie(pacc.GetStorageRoot(), triedb, nil)
+			stTrie, err := statedb.NewSecureStorageTrie(pacc.GetStorageRoot(), triedb, &statedb.TrieOpts{Owner: common.BytesToHash(pathset[0])})
 			loads++ // always account database reads, even for failures
 			if err != nil {
 				break
@@ -505,13 +506,13 @@ func ServiceGetTrieNodesQuery(chain SnapshotReader, req *GetTrieNodesPacket, sta
 				bytes += uint64(len(blob))
 
 				// Sanity check limits to avoid DoS on the store trie loads
-				if bytes > req.Bytes || loads > maxTrieNodeLookups || time.Since(start) > maxTrieNodeTimeSpent {
+				if bytes > req.Bytes || loads > maxTrieNodeLookups || limits.expired(start) {
 					break
 				}
 			}
 		}
 		// Abort request processing if we've exceeded our limits
-		if bytes > req.Bytes || loads > maxTrieNodeLookups || time.Since(start) > maxTrieNodeTimeSpent {
+		if bytes > req.Bytes || loads > maxTrieNodeLookups || limits.expired(start) {
 			break
 		}
 	}
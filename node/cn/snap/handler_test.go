@@ -208,7 +208,7 @@ func TestHandleMessage_ReadMsgErr(t *testing.T) {
 	peer.rw = &testMsgRW{reader: func() (p2p.Msg, error) { return p2p.Msg{}, testErr }}
 
 	// failed to handle message due to read msg error
-	err := HandleMessage(reader, &testDownloader{}, peer)
+	err := HandleMessage(reader, &testDownloader{}, NewServingLimits(ServingConfig{}), peer)
 	assert.Equal(t, err, testErr)
 }
 
@@ -221,7 +221,7 @@ func TestHandleMessage_LargeMessageErr(t *testing.T) {
 	peer := mockPeer(msg)
 
 	// failed to handle message due to too large message size
-	err := HandleMessage(reader, &testDownloader{}, peer)
+	err := HandleMessage(reader, &testDownloader{}, NewServingLimits(ServingConfig{}), peer)
 	assert.True(t, strings.Contains(err.Error(), errMsgTooLarge.Error()))
 }
 
@@ -233,7 +233,7 @@ func TestHandleMessage_LargeMessageInvalidMsgErr(t *testing.T) {
 	peer := mockPeer(msg)
 
 	// failed to handle message due to too large message size
-	err := HandleMessage(reader, &testDownloader{}, peer)
+	err := HandleMessage(reader, &testDownloader{}, NewServingLimits(ServingConfig{}), peer)
 	assert.True(t, strings.Contains(err.Error(), errInvalidMsgCode.Error()))
 }
 
@@ -241,7 +241,7 @@ func TestHandleMessage_GetAccountRange_EmptyItem(t *testing.T) {
 	items := []*testKV{}
 	reader, root := NewTestSnapshotReader(items)
 
-	err := HandleMessage(reader, &testDownloader{}, mockPeer(createAccountRangeReqMsg(root)))
+	err := HandleMessage(reader, &testDownloader{}, NewServingLimits(ServingConfig{}), mockPeer(createAccountRangeReqMsg(root)))
 	assert.NoError(t, err)
 }
 
@@ -294,7 +294,7 @@ func TestHandleMessage_Success(t *testing.T) {
 	msgs = append(msgs, msg)
 
 	for _, msg := range msgs {
-		err := HandleMessage(reader, &testDownloader{}, mockPeer(msg))
+		err := HandleMessage(reader, &testDownloader{}, NewServingLimits(ServingConfig{}), mockPeer(msg))
 		assert.NoError(t, err)
 	}
 }
@@ -0,0 +1,126 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// maxResponseSize is the largest configurable response size. It leaves room
+	// for the proofs and the slack of the state responses within a message.
+	maxResponseSize = maxMessageSize / 2
+
+	// servingBurst is how long the serving budget accumulates while idle, so
+	// that a burst of requests after a quiet period is served without delay.
+	servingBurst = time.Second
+)
+
+// ServingConfig limits the resources spent on serving the snap requests of the
+// peers.
+type ServingConfig struct {
+	// ResponseSize is the target maximum size of a response in bytes. Zero
+	// defaults to softResponseLimit.
+	ResponseSize uint64
+
+	// ResponseTime is the maximum time spent on assembling a response. Zero
+	// defaults to maxTrieNodeTimeSpent.
+	ResponseTime time.Duration
+
+	// CPUShare is the share of the time spent on serving the requests of all
+	// the peers together, e.g. 0.5 for half a CPU core. The requests exceeding
+	// it are delayed. Zero means unlimited.
+	CPUShare float64
+}
+
+// ServingLimits enforces a ServingConfig on the snap request handlers.
+type ServingLimits struct {
+	responseSize uint64
+	responseTime time.Duration
+	cpuShare     float64
+	now          func() time.Time
+
+	lock sync.Mutex
+	next time.Time // When the serving time charged so far is paid off
+}
+
+// NewServingLimits returns the serving limits of the given configuration.
+func NewServingLimits(cfg ServingConfig) *ServingLimits {
+	l := &ServingLimits{
+		responseSize: cfg.ResponseSize,
+		responseTime: cfg.ResponseTime,
+		cpuShare:     cfg.CPUShare,
+		now:          time.Now,
+	}
+	if l.responseSize == 0 {
+		l.responseSize = softResponseLimit
+	}
+	if l.responseSize > maxResponseSize {
+		logger.Warn("Capping the snap response size", "configured", cfg.ResponseSize, "max", maxResponseSize)
+		l.responseSize = maxResponseSize
+	}
+	if l.responseTime == 0 {
+		l.responseTime = maxTrieNodeTimeSpent
+	}
+	if l.cpuShare < 0 {
+		l.cpuShare = 0
+	}
+	return l
+}
+
+// capBytes lowers the requested response size to the configured one.
+func (l *ServingLimits) capBytes(bytes *uint64) {
+	if *bytes > l.responseSize {
+		*bytes = l.responseSize
+	}
+}
+
+// expired returns whether a response started at start took too long to assemble.
+func (l *ServingLimits) expired(start time.Time) bool {
+	return l.now().Sub(start) > l.responseTime
+}
+
+// acquire waits until the CPU share allows serving another request, and returns
+// the time the serving starts.
+func (l *ServingLimits) acquire() time.Time {
+	if l.cpuShare > 0 {
+		l.lock.Lock()
+		wait := l.next.Sub(l.now())
+		l.lock.Unlock()
+		if wait > 0 {
+			time.Sleep(wait)
+		}
+	}
+	return l.now()
+}
+
+// release charges the time spent on serving a request started at start. At the
+// CPU share s, it takes elapsed/s of wall time to pay it off.
+func (l *ServingLimits) release(start time.Time) {
+	if l.cpuShare == 0 {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	if earliest := now.Add(-servingBurst); l.next.Before(earliest) {
+		l.next = earliest
+	}
+	l.next = l.next.Add(time.Duration(float64(now.Sub(start)) / l.cpuShare))
+}
@@ -0,0 +1,86 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewServingLimits(t *testing.T) {
+	l := NewServingLimits(ServingConfig{})
+	assert.Equal(t, uint64(softResponseLimit), l.responseSize)
+	assert.Equal(t, maxTrieNodeTimeSpent, l.responseTime)
+	assert.Equal(t, 0.0, l.cpuShare)
+
+	l = NewServingLimits(ServingConfig{ResponseSize: 2 * maxMessageSize, ResponseTime: time.Second, CPUShare: -1})
+	assert.Equal(t, uint64(maxResponseSize), l.responseSize)
+	assert.Equal(t, time.Second, l.responseTime)
+	assert.Equal(t, 0.0, l.cpuShare)
+}
+
+func TestServingLimits_Response(t *testing.T) {
+	now := time.Unix(1000, 0)
+	l := NewServingLimits(ServingConfig{ResponseSize: 1000, ResponseTime: time.Second})
+	l.now = func() time.Time { return now }
+
+	bytes := uint64(500)
+	l.capBytes(&bytes)
+	assert.Equal(t, uint64(500), bytes)
+	bytes = 5000
+	l.capBytes(&bytes)
+	assert.Equal(t, uint64(1000), bytes)
+
+	start := l.acquire()
+	assert.Equal(t, now, start)
+	now = now.Add(time.Second)
+	assert.False(t, l.expired(start))
+	now = now.Add(time.Millisecond)
+	assert.True(t, l.expired(start))
+}
+
+func TestServingLimits_CPUShare(t *testing.T) {
+	now := time.Unix(1000, 0)
+	l := NewServingLimits(ServingConfig{CPUShare: 0.5})
+	l.now = func() time.Time { return now }
+
+	// A second of serving time is available for a burst.
+	start := l.acquire()
+	now = now.Add(400 * time.Millisecond)
+	l.release(start)
+	assert.Equal(t, now.Add(-200*time.Millisecond), l.next)
+
+	start = l.acquire()
+	now = now.Add(200 * time.Millisecond)
+	l.release(start)
+	assert.Equal(t, now, l.next)
+
+	// The budget is used up, so the next request waits for it.
+	start = l.acquire()
+	now = now.Add(100 * time.Millisecond)
+	l.release(start)
+	begin := time.Now()
+	l.acquire()
+	assert.True(t, time.Since(begin) >= 90*time.Millisecond)
+
+	// The budget accumulates for a second at most while idle.
+	now = now.Add(10 * time.Second)
+	l.release(l.acquire())
+	assert.Equal(t, now.Add(-servingBurst), l.next)
+}
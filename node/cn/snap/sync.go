@@ -81,6 +81,10 @@ const (
 	// and waste round trip times. If it's too high, we're capping responses and
 	// waste bandwidth.
 	maxTrieRequestCount = maxRequestSize / 512
+
+	// syncStatusSaveInterval is how often the sync progress is persisted while
+	// syncing, so that the sync resumes from it after a crash.
+	syncStatusSaveInterval = time.Minute
 )
 
 var (
@@ -356,6 +360,9 @@ type SyncProgress struct {
 type SyncPending struct {
 	TrienodeHeal uint64 // Number of state trie nodes pending
 	BytecodeHeal uint64 // Number of bytecodes pending
+
+	Healing bool          // Whether the sync is in the healing phase
+	ETA     time.Duration // Estimated time left until the healing phase, zero if unknown
 }
 
 // SyncPeer abstracts out the methods required for a peer to be synced against
@@ -428,6 +435,8 @@ type Syncer struct {
 	storageBytes   common.StorageSize // Number of storage trie bytes persisted to disk
 
 	extProgress *SyncProgress // progress that can be exposed to external caller.
+	extHealing  bool          // Whether the sync is in the healing phase, exposed to external caller
+	extETA      time.Duration // Estimated time left until the healing phase, exposed to external caller
 
 	// Request tracking during healing phase
 	trienodeHealIdlers map[string]struct{} // Peers that aren't serving trie node requests
@@ -451,8 +460,12 @@ type Syncer struct {
 	storageHealed      uint64                   // Number of storage slots downloaded during the healing stage
 	storageHealedBytes common.StorageSize       // Number of raw storage bytes persisted to disk during the healing stage
 
-	startTime time.Time // Time instance when snapshot sync started
-	logTime   time.Time // Time instance when status was last reported
+	startTime  time.Time          // Time instance when snapshot sync started
+	startBytes common.StorageSize // Number of bytes already synced when snapshot sync started
+	logTime    time.Time          // Time instance when status was last reported
+
+	saveInterval time.Duration // Interval of persisting the sync progress while syncing
+	saveTime     time.Time     // Time instance when the sync progress was last persisted
 
 	pend sync.WaitGroup // Tracks network request goroutines for graceful shutdown
 	lock sync.RWMutex   // Protects fields that can change outside of sync (peers, reqs, root)
@@ -485,10 +498,16 @@ func NewSyncer(db database.DBManager) *Syncer {
 		bytecodeHealReqs: make(map[uint64]*bytecodeHealRequest),
 		stateWriter:      db.NewSnapshotDBBatch(),
 
-		extProgress: new(SyncProgress),
+		extProgress:  new(SyncProgress),
+		saveInterval: syncStatusSaveInterval,
 	}
 }
 
+// Rates returns the message throughput rates measured for the peers.
+func (s *Syncer) Rates() *msgrate.Trackers {
+	return s.rates
+}
+
 // Register injects a new data source into the syncer's peerset.
 func (s *Syncer) Register(peer SyncPeer) error {
 	// Make sure the peer is not registered yet
@@ -562,11 +581,13 @@ func (s *Syncer) Sync(root common.Hash, cancel chan struct{}) error {
 	s.statelessPeers = make(map[string]struct{})
 	s.lock.Unlock()
 
+	// Retrieve the previous sync status from LevelDB and abort if already synced
+	s.loadSyncStatus()
 	if s.startTime == (time.Time{}) {
 		s.startTime = time.Now()
+		s.startBytes = s.accountBytes + s.bytecodeBytes + s.storageBytes
 	}
-	// Retrieve the previous sync status from LevelDB and abort if already synced
-	s.loadSyncStatus()
+	s.saveTime = time.Now()
 	if len(s.tasks) == 0 && s.healer.scheduler.Pending() == 0 {
 		logger.Debug("Snapshot sync already completed")
 		return nil
@@ -657,6 +678,13 @@ func (s *Syncer) Sync(root common.Hash, cancel chan struct{}) error {
 			BytecodeHealSynced: s.bytecodeHealSynced,
 			BytecodeHealBytes:  s.bytecodeHealBytes,
 		}
+		s.extHealing = len(s.tasks) == 0
+		s.extETA = 0
+		if !s.extHealing {
+			if _, eta, ok := s.estimateSyncProgress(); ok {
+				s.extETA = eta
+			}
+		}
 		s.lock.Unlock()
 		// Wait for something to happen
 		select {
@@ -693,9 +721,30 @@ func (s *Syncer) Sync(root common.Hash, cancel chan struct{}) error {
 		}
 		// Report stats if something meaningful happened
 		s.report(false)
+
+		// Persist the progress every now and then to survive crashes
+		if time.Since(s.saveTime) >= s.saveInterval {
+			s.checkpoint()
+		}
 	}
 }
 
+// checkpoint persists the progress of a running sync, so that it resumes from
+// there if the process crashes. Unlike at the end of a sync cycle, the account
+// tasks can't be forwarded as their responses may still be filling up, so the
+// progress only covers the data persisted so far.
+func (s *Syncer) checkpoint() {
+	if s.stateWriter.ValueSize() > 0 {
+		if err := s.stateWriter.Write(); err != nil {
+			logger.Error("Failed to persist storage slots", "err", err)
+			return
+		}
+		s.stateWriter.Reset()
+	}
+	s.saveSyncStatus()
+	s.saveTime = time.Now()
+}
+
 // loadSyncStatus retrieves a previously aborted sync status from the database,
 // or generates a fresh one if none is available.
 func (s *Syncer) loadSyncStatus() {
@@ -783,9 +832,9 @@ func (s *Syncer) saveSyncStatus() {
 		if err := task.trieDb.Commit(task.genTrie.Hash(), false, 0); err != nil {
 			logger.Error("Failed to persist account slots", "err", err)
 		}
-		for _, subtasks := range task.SubTasks {
+		for accountHash, subtasks := range task.SubTasks {
 			for _, subtask := range subtasks {
-				if err := subtask.trieDb.Commit(subtask.genTrie.Hash(), false, 0); err != nil {
+				if err := subtask.trieDb.CommitStorage(accountHash, subtask.genTrie.Hash()); err != nil {
 					logger.Error("Failed to persist storage slots", "err", err)
 				}
 			}
@@ -816,7 +865,10 @@ func (s *Syncer) saveSyncStatus() {
 func (s *Syncer) Progress() (*SyncProgress, *SyncPending) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
-	pending := new(SyncPending)
+	pending := &SyncPending{
+		Healing: s.extHealing,
+		ETA:     s.extETA,
+	}
 	if s.healer != nil {
 		pending.TrienodeHeal = uint64(len(s.healer.trieTasks))
 		pending.BytecodeHeal = uint64(len(s.healer.codeTasks))
@@ -2021,7 +2073,7 @@ func (s *Syncer) processStorageResponse(res *storageResponse) {
 			}
 			root, _ := tr.Commit(nil)
 			_, nodeSize, _ := db.Size()
-			if err := db.Commit(root, false, 0); err != nil {
+			if err := db.CommitStorage(accountHash, root); err != nil {
 				logger.Error("Failed to persist storage slots", "err", err)
 			} else {
 				s.storageBytes += nodeSize
@@ -2047,7 +2099,7 @@ func (s *Syncer) processStorageResponse(res *storageResponse) {
 			root, _ := res.subTask.genTrie.Commit(nil)
 			_, nodeSize, _ := res.subTask.trieDb.Size()
 
-			if err := res.subTask.trieDb.Commit(root, false, 0); err != nil {
+			if err := res.subTask.trieDb.CommitStorage(res.accounts[len(res.accounts)-1], root); err != nil {
 				logger.Error("Failed to persist stack slots", "root", root, "err", err)
 			} else if root == res.subTask.root {
 				s.storageBytes += nodeSize
@@ -2802,9 +2854,32 @@ func (s *Syncer) reportSyncProgress(force bool) {
 		return
 	}
 	// Don't report anything until we have a meaningful progress
+	synced, eta, ok := s.estimateSyncProgress()
+	if !ok {
+		return
+	}
+	s.logTime = time.Now()
+
+	// Create a mega progress report
+	var (
+		state    = s.accountBytes + s.bytecodeBytes + s.storageBytes
+		progress = fmt.Sprintf("%.2f%%", synced*100)
+		accounts = fmt.Sprintf("%v@%v", s.accountSynced, s.accountBytes.TerminalString())
+		storage  = fmt.Sprintf("%v@%v", s.storageSynced, s.storageBytes.TerminalString())
+		bytecode = fmt.Sprintf("%v@%v", s.bytecodeSynced, s.bytecodeBytes.TerminalString())
+	)
+	logger.Info("State sync in progress", "synced", progress, "state", state,
+		"accounts", accounts, "slots", storage, "codes", bytecode, "eta", common.PrettyDuration(eta))
+}
+
+// estimateSyncProgress estimates the share of the state synced so far from the
+// share of the account hash space covered, and the time left to sync the rest
+// at the rate of the current run. It returns false until there is a meaningful
+// progress to estimate from.
+func (s *Syncer) estimateSyncProgress() (float64, time.Duration, bool) {
 	synced := s.accountBytes + s.bytecodeBytes + s.storageBytes
 	if synced == 0 {
-		return
+		return 0, 0, false
 	}
 	accountGaps := new(big.Int)
 	for _, task := range s.tasks {
@@ -2812,29 +2887,26 @@ func (s *Syncer) reportSyncProgress(force bool) {
 	}
 	accountFills := new(big.Int).Sub(hashSpace, accountGaps)
 	if accountFills.BitLen() == 0 {
-		return
+		return 0, 0, false
 	}
-	s.logTime = time.Now()
 	estBytes := float64(new(big.Int).Div(
 		new(big.Int).Mul(new(big.Int).SetUint64(uint64(synced)), hashSpace),
 		accountFills,
 	).Uint64())
-	// Don't report anything until we have a meaningful progress
 	if estBytes < 1.0 {
-		return
+		return 0, 0, false
 	}
-	elapsed := time.Since(s.startTime)
-	estTime := elapsed / time.Duration(synced) * time.Duration(estBytes)
+	progress := float64(synced) / estBytes
 
-	// Create a mega progress report
-	var (
-		progress = fmt.Sprintf("%.2f%%", float64(synced)*100/estBytes)
-		accounts = fmt.Sprintf("%v@%v", s.accountSynced, s.accountBytes.TerminalString())
-		storage  = fmt.Sprintf("%v@%v", s.storageSynced, s.storageBytes.TerminalString())
-		bytecode = fmt.Sprintf("%v@%v", s.bytecodeSynced, s.bytecodeBytes.TerminalString())
-	)
-	logger.Info("State sync in progress", "synced", progress, "state", synced,
-		"accounts", accounts, "slots", storage, "codes", bytecode, "eta", common.PrettyDuration(estTime-elapsed))
+	// The bytes restored from a previous run were not synced at the current rate
+	var eta time.Duration
+	if runBytes := synced - s.startBytes; runBytes > 0 {
+		elapsed := time.Since(s.startTime)
+		if left := estBytes - float64(synced); left > 0 {
+			eta = time.Duration(float64(elapsed) * left / float64(runBytes))
+		}
+	}
+	return progress, eta, true
 }
 
 // reportHealProgress calculates various status reports and provides it to the user.
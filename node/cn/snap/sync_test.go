@@ -24,10 +24,12 @@ import (
 	"bytes"
 	"crypto/rand"
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -1717,3 +1719,247 @@ func TestSlotEstimation(t *testing.T) {
 		}
 	}
 }
+
+// waitSyncStatus waits until the sync status persisted in db satisfies cond,
+// and returns the raw status.
+func waitSyncStatus(t *testing.T, db database.DBManager, cond func(*SyncProgress) bool) []byte {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		if status := db.ReadSnapshotSyncStatus(); status != nil {
+			var progress SyncProgress
+			if err := json.Unmarshal(status, &progress); err != nil {
+				t.Fatal(err)
+			}
+			if cond(&progress) {
+				return status
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("sync status not persisted in time")
+	return nil
+}
+
+// crashSyncer stops a running sync and rolls the persisted sync status back
+// to status, as if the process had crashed right after persisting it instead
+// of shutting down gracefully.
+func crashSyncer(t *testing.T, syncer *Syncer, term func(), errc chan error, status []byte) {
+	t.Helper()
+	term()
+	if err := <-errc; err != ErrCancelled {
+		t.Fatalf("sync returned %v, want %v", err, ErrCancelled)
+	}
+	syncer.db.WriteSnapshotSyncStatus(status)
+}
+
+// TestSyncResumeAfterCrash tests that a sync killed during the sync phase
+// resumes from the progress persisted while syncing.
+func TestSyncResumeAfterCrash(t *testing.T) {
+	t.Parallel()
+
+	var (
+		once   sync.Once
+		cancel = make(chan struct{})
+		term   = func() {
+			once.Do(func() {
+				close(cancel)
+			})
+		}
+	)
+	sourceAccountTrie, elems, storageTries, storageElems := makeAccountTrieWithStorage(100, 50, true, false)
+
+	// The accounts are counted per syncer, as the handlers of a crashed one
+	// may still be running.
+	countingAccountRequestHandler := func(delivered *int64) accountHandlerFunc {
+		return func(t *testPeer, id uint64, root common.Hash, origin common.Hash, limit common.Hash, cap uint64) error {
+			keys, vals, proofs := createAccountRequestResponse(t, root, origin, limit, cap)
+			for _, key := range keys {
+				// The response may overrun the limit by an account
+				if bytes.Compare(key[:], limit[:]) <= 0 {
+					atomic.AddInt64(delivered, 1)
+				}
+			}
+			return t.remote.OnAccounts(t, id, keys, vals, proofs)
+		}
+	}
+	mkSource := func(name string, term func(), delivered *int64) *testPeer {
+		source := newTestPeer(name, t, term)
+		source.accountTrie = sourceAccountTrie
+		source.accountValues = elems
+		source.storageTries = storageTries
+		source.storageValues = storageElems
+		source.accountRequestHandler = countingAccountRequestHandler(delivered)
+		return source
+	}
+	// Serve only a part of the account ranges, and crash the syncer once the
+	// progress is persisted.
+	var (
+		served    int32
+		delivered int64
+		handler   = countingAccountRequestHandler(&delivered)
+		source    = mkSource("source", term, &delivered)
+	)
+	source.accountRequestHandler = func(t *testPeer, id uint64, root common.Hash, origin common.Hash, limit common.Hash, cap uint64) error {
+		if atomic.AddInt32(&served, 1) > int32(accountConcurrency/2) {
+			return nil
+		}
+		return handler(t, id, root, origin, limit, cap)
+	}
+	syncer := setupSyncer(source)
+	syncer.saveInterval = 0
+
+	errc := make(chan error, 1)
+	go func() { errc <- syncer.Sync(sourceAccountTrie.Hash(), cancel) }()
+
+	status := waitSyncStatus(t, syncer.db, func(progress *SyncProgress) bool {
+		return progress.AccountSynced > 0 && len(progress.Tasks) > 0
+	})
+	if _, pending := syncer.Progress(); pending.Healing {
+		t.Fatal("syncer reported healing during the sync phase")
+	}
+	crashSyncer(t, syncer, term, errc, status)
+
+	// Restart the syncer on the same database with well-behaving peers
+	var (
+		once2   sync.Once
+		cancel2 = make(chan struct{})
+		term2   = func() {
+			once2.Do(func() {
+				close(cancel2)
+			})
+		}
+	)
+	var resumedDelivered int64
+	resumed := NewSyncer(syncer.db)
+	for _, peer := range []*testPeer{mkSource("sourceA", term2, &resumedDelivered), mkSource("sourceB", term2, &resumedDelivered)} {
+		resumed.Register(peer)
+		peer.remote = resumed
+	}
+	done := checkStall(t, term2)
+	if err := resumed.Sync(sourceAccountTrie.Hash(), cancel2); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	close(done)
+	verifyTrie(resumed.db, sourceAccountTrie.Hash(), t)
+
+	if have := atomic.LoadInt64(&resumedDelivered); have >= int64(len(elems)) {
+		t.Errorf("resumed sync fetched all accounts again: have %d, total %d", have, len(elems))
+	}
+}
+
+// TestSyncResumeHealAfterCrash tests that a sync killed during the healing
+// phase keeps the trie nodes healed before the crash.
+func TestSyncResumeHealAfterCrash(t *testing.T) {
+	t.Parallel()
+
+	var (
+		once   sync.Once
+		cancel = make(chan struct{})
+		term   = func() {
+			once.Do(func() {
+				close(cancel)
+			})
+		}
+	)
+	sourceAccountTrie, elems := makeAccountTrieNoStorage(3000)
+
+	// Count the nodes to heal, as the whole trie is healed
+	total := 0
+	for it := sourceAccountTrie.NodeIterator(nil); it.Next(true); {
+		if it.Hash() != (common.Hash{}) {
+			total++
+		}
+	}
+	// Serve a few nodes per request, so that the healing takes many rounds.
+	// The nodes are counted per syncer, as the handlers of a crashed one may
+	// still be running.
+	cappedTrieRequestHandler := func(delivered *int64) trieHandlerFunc {
+		return func(t *testPeer, requestId uint64, root common.Hash, paths []TrieNodePathSet, cap uint64) error {
+			if len(paths) > 10 {
+				paths = paths[:10]
+			}
+			var nodes [][]byte
+			for _, pathset := range paths {
+				blob, _, err := t.accountTrie.TryGetNode(pathset[0])
+				if err != nil {
+					t.logger.Info("Error handling req", "error", err)
+					continue
+				}
+				nodes = append(nodes, blob)
+			}
+			atomic.AddInt64(delivered, int64(len(nodes)))
+			return t.remote.OnTrieNodes(t, requestId, nodes)
+		}
+	}
+	mkSource := func(name string, term func(), delivered *int64) *testPeer {
+		source := newTestPeer(name, t, term)
+		source.accountTrie = sourceAccountTrie
+		source.accountValues = elems
+		source.trieRequestHandler = cappedTrieRequestHandler(delivered)
+		return source
+	}
+	// Skip the sync phase, so that the whole trie is healed
+	db := database.NewMemoryDBManager()
+	status, err := json.Marshal(new(SyncProgress))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.WriteSnapshotSyncStatus(status)
+
+	// Heal about half of the trie, and crash the syncer once the progress is
+	// persisted.
+	var (
+		delivered int64
+		handler   = cappedTrieRequestHandler(&delivered)
+		source    = mkSource("source", term, &delivered)
+	)
+	source.trieRequestHandler = func(t *testPeer, requestId uint64, root common.Hash, paths []TrieNodePathSet, cap uint64) error {
+		if atomic.LoadInt64(&delivered) >= int64(total/2) {
+			return nil
+		}
+		return handler(t, requestId, root, paths, cap)
+	}
+	syncer := NewSyncer(db)
+	syncer.Register(source)
+	source.remote = syncer
+	syncer.saveInterval = 0
+
+	errc := make(chan error, 1)
+	go func() { errc <- syncer.Sync(sourceAccountTrie.Hash(), cancel) }()
+
+	status = waitSyncStatus(t, db, func(progress *SyncProgress) bool {
+		return progress.TrienodeHealSynced >= uint64(total/2)
+	})
+	if _, pending := syncer.Progress(); !pending.Healing {
+		t.Fatal("syncer did not report healing during the healing phase")
+	}
+	crashSyncer(t, syncer, term, errc, status)
+
+	// Restart the syncer on the same database with well-behaving peers
+	var (
+		once2   sync.Once
+		cancel2 = make(chan struct{})
+		term2   = func() {
+			once2.Do(func() {
+				close(cancel2)
+			})
+		}
+	)
+	var resumedDelivered int64
+	resumed := NewSyncer(db)
+	for _, peer := range []*testPeer{mkSource("sourceA", term2, &resumedDelivered), mkSource("sourceB", term2, &resumedDelivered)} {
+		resumed.Register(peer)
+		peer.remote = resumed
+	}
+	done := checkStall(t, term2)
+	if err := resumed.Sync(sourceAccountTrie.Hash(), cancel2); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	close(done)
+	verifyTrie(db, sourceAccountTrie.Hash(), t)
+
+	if have := atomic.LoadInt64(&resumedDelivered); have >= int64(total) {
+		t.Errorf("resumed heal fetched all trie nodes again: have %d, total %d", have, total)
+	}
+}
@@ -198,6 +198,12 @@ func (pm *ProtocolManager) synchronise(peer Peer) {
 
 	pHead, pTd := peer.Head()
 	if pTd.Cmp(td) <= 0 {
+		// Nothing to sync, so spend the time on the history below the checkpoint if any
+		if pm.historyBackfill {
+			if err := pm.downloader.BackfillHistory(peer.GetID()); err != nil {
+				logger.Debug("History backfill failed", "peer", peer.GetID(), "err", err)
+			}
+		}
 		return
 	}
 	// Otherwise try to sync with the downloader
@@ -29,6 +29,7 @@ import (
 	"strings"
 
 	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/accounts/external"
 	"github.com/klaytn/klaytn/accounts/keystore"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/crypto"
@@ -89,6 +90,13 @@ type Config struct {
 	// is created by New and destroyed when the node is stopped.
 	KeyStoreDir string `toml:",omitempty"`
 
+	// ExternalSigner is the endpoint of an external signer, an IPC socket path or an
+	// http(s) URL, whose accounts are added to the account manager.
+	ExternalSigner string `toml:",omitempty"`
+
+	// ExternalSignerToken authenticates the node to the external signer.
+	ExternalSignerToken string `toml:"-"`
+
 	// UseLightweightKDF lowers the memory and CPU requirements of the key store
 	// scrypt KDF at the expense of security.
 	UseLightweightKDF bool `toml:",omitempty"`
@@ -464,5 +472,12 @@ func makeAccountManager(conf *Config) (*accounts.Manager, string, error) {
 	backends := []accounts.Backend{
 		keystore.NewKeyStore(keydir, scryptN, scryptP),
 	}
+	if conf.ExternalSigner != "" {
+		extapi, err := external.NewExternalBackend(conf.ExternalSigner, conf.ExternalSignerToken)
+		if err != nil {
+			return nil, "", err
+		}
+		backends = append(backends, extapi)
+	}
 	return accounts.NewManager(backends...), ephemeral, nil
 }
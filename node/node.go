@@ -603,6 +603,10 @@ func (n *Node) Stop() error {
 	n.services = nil
 	n.server = nil
 
+	// The services stop the event mux, so a new one is given to the services
+	// constructed when the node is started again.
+	n.eventmux = new(event.TypeMux)
+
 	// Release instance directory lock.
 	if n.instanceDirLock != nil {
 		if err := n.instanceDirLock.Release(); err != nil {
@@ -21,13 +21,17 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
+	"github.com/klaytn/klaytn/accounts/abi/bind"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/contracts/bridge"
+	"github.com/klaytn/klaytn/contracts/kip13"
 	"github.com/klaytn/klaytn/networks/p2p"
 	"github.com/klaytn/klaytn/networks/p2p/discover"
 	"github.com/klaytn/klaytn/node"
+	"github.com/klaytn/klaytn/node/sc/bridgepool"
 	"github.com/klaytn/klaytn/params"
 	"github.com/pkg/errors"
 )
@@ -35,6 +39,7 @@ import (
 var (
 	ErrInvalidBridgePair             = errors.New("Invalid bridge pair")
 	ErrBridgeContractVersionMismatch = errors.New("Bridge contract version mismatch")
+	ErrNoAnchoringProof              = errors.New("No anchoring proof for the block")
 )
 
 func parseBridgeAddrWithAlias(sb *SubBridge, cBridgeAddrOrAlias, pBridgeAddrOrFirstParam string, args ...interface{}) (common.Address, common.Address, []interface{}, error) {
@@ -120,6 +125,105 @@ func (sb *SubBridgeAPI) GetAnchoringTxHashByBlockNumber(bn uint64) common.Hash {
 	return receipt.TxHash
 }
 
+// GetAnchoringProof returns the anchoring tx which anchored the given block with a
+// Merkle root and the proof path of the block hash up to the root.
+func (sb *SubBridgeAPI) GetAnchoringProof(bn uint64) (map[string]interface{}, error) {
+	batch := sb.subBridge.handler.GetAnchoringBatch(bn)
+	if batch == nil || bn < batch.StartBlockNumber {
+		return nil, ErrNoAnchoringProof
+	}
+	index := bn - batch.StartBlockNumber
+	proof, err := types.AnchoringMerkleProof(batch.BlockHashes, index)
+	if err != nil {
+		return nil, err
+	}
+	lastBlockHash := batch.BlockHashes[len(batch.BlockHashes)-1]
+	return map[string]interface{}{
+		"anchoringTxHash":  batch.AnchoringTxHash,
+		"anchoredBlock":    batch.StartBlockNumber + uint64(len(batch.BlockHashes)) - 1,
+		"startBlockNumber": batch.StartBlockNumber,
+		"blockCount":       len(batch.BlockHashes),
+		"blockHashesRoot":  types.AnchoringMerkleRoot(batch.BlockHashes),
+		"blockNumber":      bn,
+		"blockHash":        batch.BlockHashes[index],
+		"index":            index,
+		"proof":            proof,
+		"receipt":          sb.subBridge.handler.GetReceiptFromParentChain(lastBlockHash),
+	}, nil
+}
+
+// ReconcileValueTransfers reports whether the value transfers of the given bridge
+// pair are consistent in the given block ranges. A zero end block stands for the
+// current block of the chain.
+func (sb *SubBridgeAPI) ReconcileValueTransfers(cBridgeAddr, pBridgeAddr common.Address, childFromBlock, childToBlock, parentFromBlock, parentToBlock uint64) (*BridgeReconciliation, error) {
+	return sb.subBridge.bridgeManager.ReconcileValueTransfers(cBridgeAddr, pBridgeAddr,
+		ReconcileRange{childFromBlock, childToBlock}, ReconcileRange{parentFromBlock, parentToBlock})
+}
+
+// GetTransferLimits returns the value transfer limits, whether relaying value
+// transfers is paused and the usage of the limits in the current period.
+func (sb *SubBridgeAPI) GetTransferLimits() map[string]interface{} {
+	limiter := sb.subBridge.transferLimiter
+	return map[string]interface{}{
+		"limits": sb.subBridge.config.TransferLimits,
+		"period": uint64(limiter.period / time.Second),
+		"paused": limiter.paused(),
+		"usage":  limiter.usage(),
+	}
+}
+
+// GetHeldValueTransfers returns the value transfer requests held over the limits.
+func (sb *SubBridgeAPI) GetHeldValueTransfers() []*bridgepool.TransferDecisionRecord {
+	return sb.subBridge.transferLimiter.pool.Held()
+}
+
+// ApproveValueTransfer releases the held request of the given nonce, which the
+// given bridge handles, regardless of the limits.
+func (sb *SubBridgeAPI) ApproveValueTransfer(bridgeAddr common.Address, requestNonce uint64, reason *string) error {
+	bi, ok := sb.subBridge.bridgeManager.GetBridgeInfo(bridgeAddr)
+	if !ok {
+		return ErrNoBridgeInfo
+	}
+	event, err := sb.subBridge.transferLimiter.pool.Approve(bridgeAddr, requestNonce, stringDeref(reason))
+	if err != nil {
+		return err
+	}
+	// A request held before a restart is found again by the value transfer recovery.
+	if ev, ok := event.(IRequestValueTransferEvent); ok {
+		bi.AddRequestValueTransferEvents([]IRequestValueTransferEvent{ev})
+	}
+	logger.Info("Approved a held value transfer", "bridge", bridgeAddr.String(), "nonce", requestNonce)
+	return nil
+}
+
+// RejectValueTransfer drops the held request of the given nonce, which the given
+// bridge handles, for good.
+func (sb *SubBridgeAPI) RejectValueTransfer(bridgeAddr common.Address, requestNonce uint64, reason *string) error {
+	if err := sb.subBridge.transferLimiter.pool.Reject(bridgeAddr, requestNonce, stringDeref(reason)); err != nil {
+		return err
+	}
+	logger.Warn("Rejected a held value transfer", "bridge", bridgeAddr.String(), "nonce", requestNonce)
+	return nil
+}
+
+// PauseValueTransfers stops relaying all value transfers until they are resumed.
+func (sb *SubBridgeAPI) PauseValueTransfers(reason *string) error {
+	if err := sb.subBridge.transferLimiter.pool.SetPaused(true, stringDeref(reason)); err != nil {
+		return err
+	}
+	logger.Warn("Paused relaying value transfers", "reason", stringDeref(reason))
+	return nil
+}
+
+// ResumeValueTransfers resumes relaying value transfers.
+func (sb *SubBridgeAPI) ResumeValueTransfers(reason *string) error {
+	if err := sb.subBridge.transferLimiter.pool.SetPaused(false, stringDeref(reason)); err != nil {
+		return err
+	}
+	logger.Info("Resumed relaying value transfers", "reason", stringDeref(reason))
+	return nil
+}
+
 func (sb *SubBridgeAPI) RegisterOperator(bridgeAddr, operatorAddr common.Address) (common.Hash, error) {
 	return sb.subBridge.bridgeManager.RegisterOperator(bridgeAddr, operatorAddr)
 }
@@ -288,6 +392,67 @@ func (sb *SubBridgeAPI) GetBridgeInformation(bridgeAddr common.Address) (map[str
 		"onServiceChain":   bi.onChildChain,
 		"isSubscribed":     bi.subscribed,
 		"pendingEventSize": bi.pendingRequestEvent.Len(),
+		"parentChainID":    bi.parentChainID,
+	}, nil
+}
+
+// GetMessageInformation returns the cross-chain message status of the bridge.
+// requestNonce counts the messages of the counterpart bridge and handleNonce is
+// the nonce of the next one the bridge executes.
+func (sb *SubBridgeAPI) GetMessageInformation(bridgeAddr common.Address) (map[string]interface{}, error) {
+	if ctBridge := sb.subBridge.bridgeManager.GetCounterPartBridgeAddr(bridgeAddr); ctBridge == (common.Address{}) {
+		return nil, ErrInvalidBridgePair
+	}
+
+	bi, ok := sb.subBridge.bridgeManager.GetBridgeInfo(bridgeAddr)
+	if !ok {
+		return nil, ErrNoBridgeInfo
+	}
+	if err := bi.checkMessageSupport(); err != nil {
+		return nil, err
+	}
+
+	sentNonce, err := bi.bridge.RequestMessageNonce(nil)
+	if err != nil {
+		return nil, err
+	}
+	handleNonce, err := bi.bridge.HandleMessageNonce(nil)
+	if err != nil {
+		return nil, err
+	}
+	if handleNonce > 0 {
+		bi.MarkHandledMessage(handleNonce - 1)
+	}
+
+	return map[string]interface{}{
+		"sentNonce":          sentNonce,
+		"requestNonce":       bi.requestMessageNonceFromCounterPart,
+		"handleNonce":        bi.handleMessageNonce,
+		"counterPart":        bi.counterpartAddress,
+		"pendingMessageSize": bi.pendingMessages.Len(),
+	}, nil
+}
+
+// GetMessageStatus returns whether the bridge executed the message requested
+// by the given transaction of the counterpart chain, and the hash of the
+// transaction this node sent to vote for it.
+func (sb *SubBridgeAPI) GetMessageStatus(bridgeAddr common.Address, requestTxHash common.Hash) (map[string]interface{}, error) {
+	bi, ok := sb.subBridge.bridgeManager.GetBridgeInfo(bridgeAddr)
+	if !ok {
+		return nil, ErrNoBridgeInfo
+	}
+	if err := bi.checkMessageSupport(); err != nil {
+		return nil, err
+	}
+
+	handled, err := bi.bridge.HandledMessageTx(nil, requestTxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"handled":      handled,
+		"handleTxHash": sb.subBridge.chainDB.ReadHandleTxHashFromRequestTxHash(requestTxHash),
 	}, nil
 }
 
@@ -311,22 +476,26 @@ func (sb *SubBridgeAPI) GetAnchoring() bool {
 	return sb.subBridge.GetAnchoringTx()
 }
 
-func (sb *SubBridgeAPI) doRegisterBridge(cBridgeAddr common.Address, pBridgeAddr common.Address) error {
+func (sb *SubBridgeAPI) doRegisterBridge(cBridgeAddr common.Address, pBridgeAddr common.Address, parentChainID uint64) error {
+	parentBackend, pAccount, err := sb.subBridge.parentChainOf(parentChainID)
+	if err != nil {
+		return err
+	}
 	cBridge, err := bridge.NewBridge(cBridgeAddr, sb.subBridge.localBackend)
 	if err != nil {
 		return err
 	}
-	pBridge, err := bridge.NewBridge(pBridgeAddr, sb.subBridge.remoteBackend)
+	pBridge, err := bridge.NewBridge(pBridgeAddr, parentBackend)
 	if err != nil {
 		return err
 	}
 
 	bm := sb.subBridge.bridgeManager
-	err = bm.SetBridgeInfo(cBridgeAddr, cBridge, pBridgeAddr, pBridge, sb.subBridge.bridgeAccounts.cAccount, true, false)
+	err = bm.SetParentChainBridgeInfo(cBridgeAddr, cBridge, pBridgeAddr, pBridge, sb.subBridge.bridgeAccounts.cAccount, true, false, parentChainID)
 	if err != nil {
 		return err
 	}
-	err = bm.SetBridgeInfo(pBridgeAddr, pBridge, cBridgeAddr, cBridge, sb.subBridge.bridgeAccounts.pAccount, false, false)
+	err = bm.SetParentChainBridgeInfo(pBridgeAddr, pBridge, cBridgeAddr, cBridge, pAccount, false, false, parentChainID)
 	if err != nil {
 		bm.DeleteBridgeInfo(cBridgeAddr)
 		return err
@@ -339,7 +508,23 @@ func (sb *SubBridgeAPI) RegisterBridge(cBridgeAddr, pBridgeAddr common.Address,
 	if err := sb.subBridge.bridgeManager.SetJournal(bridgeAlias, cBridgeAddr, pBridgeAddr); err != nil {
 		return err
 	}
-	return sb.doRegisterBridge(cBridgeAddr, pBridgeAddr)
+	return sb.doRegisterBridge(cBridgeAddr, pBridgeAddr, 0)
+}
+
+// RegisterParentChainBridge registers a bridge pair whose parent bridge is on
+// the given additional parent chain.
+func (sb *SubBridgeAPI) RegisterParentChainBridge(parentChainID uint64, cBridgeAddr, pBridgeAddr common.Address, bridgeAliasP *string) error {
+	if _, _, err := sb.subBridge.parentChainOf(parentChainID); err != nil {
+		return err
+	}
+	if parentChainID == sb.subBridge.config.ParentChainID {
+		parentChainID = 0
+	}
+	bridgeAlias := stringDeref(bridgeAliasP)
+	if err := sb.subBridge.bridgeManager.SetParentChainJournal(bridgeAlias, cBridgeAddr, pBridgeAddr, parentChainID); err != nil {
+		return err
+	}
+	return sb.doRegisterBridge(cBridgeAddr, pBridgeAddr, parentChainID)
 }
 
 func (sb *SubBridgeAPI) doDeregisterBridge(cBridgeAddr common.Address, pBridgeAddr common.Address) error {
@@ -399,7 +584,21 @@ func (sb *SubBridgeAPI) doRegisterToken(cBridgeAddr, pBridgeAddr, cTokenAddr, pT
 		return ErrNoBridgeInfo
 	}
 
-	err := cBi.RegisterToken(cTokenAddr, pTokenAddr)
+	parentBackend, _, err := sb.subBridge.parentChainOf(pBi.parentChainID)
+	if err != nil {
+		return err
+	}
+
+	if isERC1155Token(sb.subBridge.localBackend, cTokenAddr) || isERC1155Token(parentBackend, pTokenAddr) {
+		if err := cBi.checkERC1155Support(); err != nil {
+			return err
+		}
+		if err := pBi.checkERC1155Support(); err != nil {
+			return err
+		}
+	}
+
+	err = cBi.RegisterToken(cTokenAddr, pTokenAddr)
 	if err != nil {
 		return err
 	}
@@ -435,6 +634,17 @@ func (sb *SubBridgeAPI) doRegisterToken(cBridgeAddr, pBridgeAddr, cTokenAddr, pT
 	return nil
 }
 
+// isERC1155Token returns true if the token contract declares the ERC1155
+// interface with KIP-13.
+func isERC1155Token(backend bind.ContractCaller, token common.Address) bool {
+	caller, err := kip13.NewInterfaceIdentifierCaller(token, backend)
+	if err != nil {
+		return false
+	}
+	supported, err := caller.SupportsInterface(nil, erc1155InterfaceID)
+	return err == nil && supported
+}
+
 func (sb *SubBridgeAPI) RegisterToken(cBridgeAddrOrAlias, pBridgeOrChildToken, cTokenAddrOrPtokenAddr, pTokenAddrOrEmpty *string) error {
 	cBridgeAddrOrAliasStr, pBridgeAddrOrChildTokenStr, cTokenAddrOrPtokenAddrStr, pTokenAddrOrEmptyStr := stringDeref(cBridgeAddrOrAlias), stringDeref(pBridgeOrChildToken), stringDeref(cTokenAddrOrPtokenAddr), stringDeref(pTokenAddrOrEmpty)
 	cBridgeAddr, pBridgeAddr, args, err := parseBridgeAddrWithAlias(sb.subBridge, cBridgeAddrOrAliasStr, pBridgeAddrOrChildTokenStr, cTokenAddrOrPtokenAddrStr, pTokenAddrOrEmptyStr)
@@ -589,6 +799,40 @@ func (sb *SubBridgeAPI) NodeInfo() (*p2p.NodeInfo, error) {
 	return server.NodeInfo(), nil
 }
 
+// GetParentChains returns the status of the parent chains which the sub-bridge
+// connects to, starting with the one of the parent chain ID option.
+func (sb *SubBridgeAPI) GetParentChains() []map[string]interface{} {
+	handlers := []*SubBridgeHandler{sb.subBridge.handler}
+	for _, pc := range sb.subBridge.parentChains {
+		handlers = append(handlers, pc.handler)
+	}
+
+	res := make([]map[string]interface{}, 0, len(handlers))
+	for _, h := range handlers {
+		res = append(res, map[string]interface{}{
+			"chainID":                   h.getParentChainID(),
+			"operator":                  h.pAccount.address,
+			"operatorNonce":             h.getParentOperatorNonce(),
+			"isNonceSynced":             h.getParentOperatorNonceSynced(),
+			"gasPrice":                  h.getRemoteGasPrice(),
+			"anchoring":                 h.anchoringEnabled(),
+			"anchoringPeriod":           h.GetAnchoringPeriod(),
+			"latestAnchoredBlockNumber": h.GetLatestAnchoredBlockNumber(),
+			"peers":                     len(h.parentPeers()),
+		})
+	}
+	return res
+}
+
+// UnlockParentChainOperator unlocks the operator account of the given additional parent chain.
+func (sb *SubBridgeAPI) UnlockParentChainOperator(parentChainID uint64, passphrase string, duration *uint64) error {
+	acc, ok := sb.subBridge.bridgeAccounts.GetParentChainAccount(parentChainID)
+	if !ok {
+		return ErrUnknownParentChain
+	}
+	return acc.UnLockAccount(passphrase, duration)
+}
+
 func (sb *SubBridgeAPI) GetParentOperatorAddr() common.Address {
 	return sb.subBridge.bridgeAccounts.pAccount.address
 }
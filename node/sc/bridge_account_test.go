@@ -24,7 +24,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/accounts/external"
 	"github.com/klaytn/klaytn/accounts/keystore"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/storage/database"
 	"github.com/stretchr/testify/assert"
 )
@@ -197,3 +202,57 @@ func TestBridgeAccountInformation(t *testing.T) {
 	assert.Equal(t, cRes["isNonceSynced"], bAcc.cAccount.isNonceSynced)
 	assert.Equal(t, cRes["isUnlocked"], bAcc.cAccount.IsUnlockedAccount())
 }
+
+// TestBridgeAccountOperatorSigner checks that an operator account of the external
+// signer signs the transactions of the operator instead of the keystore.
+func TestBridgeAccountOperatorSigner(t *testing.T) {
+	tempDir, err := os.MkdirTemp(os.TempDir(), "sc")
+	assert.NoError(t, err)
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Fatalf("fail to delete file %v", err)
+		}
+	}()
+
+	key, _ := crypto.GenerateKey()
+	operator := crypto.PubkeyToAddress(key.PublicKey)
+	signer, err := external.NewStandInSigner("token", key)
+	assert.NoError(t, err)
+	defer signer.Close()
+	endpoint := path.Join(tempDir, "signer.ipc")
+	assert.NoError(t, signer.ServeIPC(endpoint))
+
+	backend, err := external.NewExternalBackend(endpoint, "token")
+	assert.NoError(t, err)
+	am := accounts.NewManager(backend)
+	defer am.Close()
+
+	bAcc, err := NewBridgeAccounts(am, tempDir, database.NewDBManager(&database.DBConfig{DBType: database.MemoryDB}), DefaultBridgeTxGasLimit, DefaultBridgeTxGasLimit)
+	assert.NoError(t, err)
+	assert.Error(t, bAcc.SetOperatorSigners(am, common.HexToAddress("0x1"), common.Address{}))
+
+	cOperator := bAcc.cAccount.address
+	assert.NoError(t, bAcc.SetOperatorSigners(am, operator, common.Address{}))
+	assert.Equal(t, operator, bAcc.pAccount.address)
+	assert.Equal(t, cOperator, bAcc.cAccount.address)
+	assert.Equal(t, true, bAcc.pAccount.IsUnlockedAccount())
+	assert.Equal(t, errOperatorSignerLocking, bAcc.pAccount.LockAccount())
+	assert.Equal(t, errOperatorSignerLocking, bAcc.pAccount.UnLockAccount("", nil))
+
+	chainID := big.NewInt(1000)
+	bAcc.pAccount.SetChainID(chainID)
+	tx := types.NewTransaction(0, common.HexToAddress("0x2"), big.NewInt(1), DefaultBridgeTxGasLimit, big.NewInt(25), nil)
+	signedTx, err := bAcc.pAccount.SignTx(tx)
+	assert.NoError(t, err)
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, operator, from)
+
+	opts := bAcc.pAccount.GenerateTransactOpts()
+	assert.Equal(t, operator, opts.From)
+	signedTx, err = opts.Signer(types.LatestSignerForChainID(chainID), operator, tx)
+	assert.NoError(t, err)
+	from, err = types.Sender(types.LatestSignerForChainID(chainID), signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, operator, from)
+}
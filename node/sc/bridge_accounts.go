@@ -18,6 +18,7 @@ package sc
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"math/big"
 	"os"
@@ -41,7 +42,10 @@ const (
 	ChildBridgeAccountName  = "child_bridge_account"
 )
 
-var errUnlockDurationTooLarge = errors.New("unlock duration too large")
+var (
+	errUnlockDurationTooLarge = errors.New("unlock duration too large")
+	errOperatorSignerLocking  = errors.New("the operator account of a wallet is locked and unlocked by the wallet")
+)
 
 type feePayerDB interface {
 	WriteParentOperatorFeePayer(feePayer common.Address)
@@ -54,6 +58,7 @@ type feePayerDB interface {
 type accountInfo struct {
 	am          *accounts.Manager  // the account manager of the node for the fee payer.
 	keystore    *keystore.KeyStore // the keystore of the operator.
+	signer      accounts.Wallet    // the wallet of the operator used instead of the keystore, such as an external signer.
 	address     common.Address
 	nonce       uint64
 	chainID     *big.Int
@@ -72,6 +77,9 @@ type BridgeAccounts struct {
 	pAccount *accountInfo
 	cAccount *accountInfo
 	db       feePayerDB
+
+	// parentChainAccounts are the operator accounts of the additional parent chains by chain ID.
+	parentChainAccounts map[uint64]*accountInfo
 }
 
 // GetBridgeOperators returns the information of bridgeOperator.
@@ -80,6 +88,9 @@ func (ba *BridgeAccounts) GetBridgeOperators() map[string]interface{} {
 
 	res[ParentOperatorStr] = ba.pAccount.GetAccountInfo()
 	res[ChildOperatorStr] = ba.cAccount.GetAccountInfo()
+	for chainID, acc := range ba.parentChainAccounts {
+		res[fmt.Sprintf("%s_%d", ParentOperatorStr, chainID)] = acc.GetAccountInfo()
+	}
 
 	return res
 }
@@ -189,12 +200,73 @@ func NewBridgeAccounts(am *accounts.Manager, dataDir string, db feePayerDB, pare
 	}
 
 	return &BridgeAccounts{
-		pAccount: pAccInfo,
-		cAccount: cAccInfo,
-		db:       db,
+		pAccount:            pAccInfo,
+		cAccount:            cAccInfo,
+		db:                  db,
+		parentChainAccounts: make(map[uint64]*accountInfo),
 	}, nil
 }
 
+// AddParentChainAccount loads the operator account of an additional parent chain,
+// creating it if there is none. Its keystore is kept apart from the one of the
+// primary parent chain operator.
+func (ba *BridgeAccounts) AddParentChainAccount(am *accounts.Manager, dataDir string, chainID, gasLimit uint64) (*accountInfo, error) {
+	if _, ok := ba.parentChainAccounts[chainID]; ok {
+		return nil, fmt.Errorf("duplicated parent chain %d", chainID)
+	}
+
+	name := fmt.Sprintf("%s_%d", ParentBridgeAccountName, chainID)
+	ks, addr, isLock, err := InitializeBridgeAccountKeystore(path.Join(dataDir, name))
+	if err != nil {
+		return nil, err
+	}
+
+	if isLock {
+		logger.Warn("parent bridge account is locked. Please unlock the account manually for Service Chain", "name", name)
+	}
+	logger.Info("parent chain bridge account is loaded", "chainID", chainID, "parent", addr.String())
+
+	acc := &accountInfo{
+		am:       am,
+		keystore: ks,
+		address:  addr,
+		nonce:    0,
+		chainID:  new(big.Int).SetUint64(chainID),
+		gasPrice: nil,
+		gasLimit: gasLimit,
+	}
+	ba.parentChainAccounts[chainID] = acc
+	return acc, nil
+}
+
+// SetOperatorSigners makes the given accounts of the node's wallets, such as the
+// external signer, the parent and child operators instead of the keystore ones.
+// A zero address keeps the keystore operator.
+func (ba *BridgeAccounts) SetOperatorSigners(am *accounts.Manager, parentOperator, childOperator common.Address) error {
+	for _, op := range []struct {
+		acc  *accountInfo
+		addr common.Address
+	}{{ba.pAccount, parentOperator}, {ba.cAccount, childOperator}} {
+		if op.addr == (common.Address{}) {
+			continue
+		}
+		wallet, err := am.Find(accounts.Account{Address: op.addr})
+		if err != nil {
+			return fmt.Errorf("failed to find the bridge operator %s in the wallets: %v", op.addr.String(), err)
+		}
+		logger.Info("bridge operator is signed by the wallet", "operator", op.addr.String(), "wallet", wallet.URL())
+		op.acc.signer = wallet
+		op.acc.address = op.addr
+	}
+	return nil
+}
+
+// GetParentChainAccount returns the operator account of the given additional parent chain.
+func (ba *BridgeAccounts) GetParentChainAccount(chainID uint64) (*accountInfo, bool) {
+	acc, ok := ba.parentChainAccounts[chainID]
+	return acc, ok
+}
+
 // InitializeBridgeAccountKeystore initializes a keystore, imports existing keys, and tries to unlock the bridge account.
 // This returns the 1st account of the wallet, its address, the lock status and the error.
 func InitializeBridgeAccountKeystore(keystorePath string) (*keystore.KeyStore, common.Address, bool, error) {
@@ -264,12 +336,20 @@ func (acc *accountInfo) GenerateTransactOpts() *bind.TransactOpts {
 		gasPrice = new(big.Int).SetUint64(acc.kip71Config.UpperBoundBaseFee)
 	}
 
+	if acc.signer != nil {
+		return bind.MakeTransactOptsWithWallet(acc.signer, acc.address, nonce, acc.chainID, acc.gasLimit, gasPrice)
+	}
 	return bind.MakeTransactOptsWithKeystore(acc.keystore, acc.address, nonce, acc.chainID, acc.gasLimit, gasPrice)
 }
 
 // SignTx signs a transaction with the accountInfo.
 func (acc *accountInfo) SignTx(tx *types.Transaction) (*types.Transaction, error) {
-	tx, err := acc.keystore.SignTx(accounts.Account{Address: acc.address}, tx, acc.chainID)
+	var err error
+	if acc.signer != nil {
+		tx, err = acc.signer.SignTx(accounts.Account{Address: acc.address}, tx, acc.chainID)
+	} else {
+		tx, err = acc.keystore.SignTx(accounts.Account{Address: acc.address}, tx, acc.chainID)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -330,6 +410,9 @@ func (acc *accountInfo) LockAccount() error {
 	acc.mu.Lock()
 	defer acc.mu.Unlock()
 
+	if acc.signer != nil {
+		return errOperatorSignerLocking
+	}
 	if err := acc.keystore.Lock(acc.address); err != nil {
 		logger.Error("Failed to lock the account", "account", acc.address)
 		return err
@@ -343,6 +426,10 @@ func (acc *accountInfo) UnLockAccount(passphrase string, duration *uint64) error
 	acc.mu.Lock()
 	defer acc.mu.Unlock()
 
+	if acc.signer != nil {
+		return errOperatorSignerLocking
+	}
+
 	const max = uint64(time.Duration(math.MaxInt64) / time.Second)
 	var d time.Duration
 	if duration == nil {
@@ -365,5 +452,8 @@ func (acc *accountInfo) UnLockAccount(passphrase string, duration *uint64) error
 func (acc *accountInfo) IsUnlockedAccount() bool {
 	acc.mu.Lock()
 	defer acc.mu.Unlock()
+	if acc.signer != nil {
+		return true
+	}
 	return acc.keystore.IsUnlocked(acc.address)
 }
@@ -144,6 +144,11 @@ func (journal *bridgeAddrJournal) ChangeBridgeAlias(oldBridgeAlias, newBridgeAli
 
 // insert adds the specified address to the local disk journal.
 func (journal *bridgeAddrJournal) insert(bridgeAlias string, localAddress common.Address, remoteAddress common.Address) error {
+	return journal.insertWithParentChain(bridgeAlias, localAddress, remoteAddress, 0)
+}
+
+// insertWithParentChain adds a new bridge pair whose parent bridge is on the given parent chain.
+func (journal *bridgeAddrJournal) insertWithParentChain(bridgeAlias string, localAddress common.Address, remoteAddress common.Address, parentChainID uint64) error {
 	// lock order is important
 	journal.cacheMu.Lock()
 	journal.writerMu.Lock()
@@ -176,6 +181,7 @@ func (journal *bridgeAddrJournal) insert(bridgeAlias string, localAddress common
 		localAddress,
 		remoteAddress,
 		false,
+		parentChainID,
 		false,
 	}
 	if err := rlp.Encode(journal.writer, &item); err != nil {
@@ -19,6 +19,7 @@ package sc
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
 	"path"
@@ -47,11 +48,24 @@ const (
 	KLAY uint8 = iota
 	ERC20
 	ERC721
+	ERC1155
 )
 
+// erc1155BridgeVersion is the first version of the bridge contract with the
+// ERC1155 transfer module.
+const erc1155BridgeVersion = 2
+
+// messageBridgeVersion is the first version of the bridge contract with the
+// cross-chain message module.
+const messageBridgeVersion = 3
+
+// erc1155InterfaceID is the KIP-13 interface identifier of ERC1155 tokens.
+var erc1155InterfaceID = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+
 const (
 	voteTypeValueTransfer = 0
 	voteTypeConfiguration = 1
+	voteTypeMessage       = 2
 )
 
 var (
@@ -63,12 +77,15 @@ var (
 	ErrAlreadySubscribed       = errors.New("already subscribed")
 	ErrBridgeRestore           = errors.New("restoring bridges is failed")
 	ErrBridgeAliasFormatDecode = errors.New("failed to decode alias-format bridge")
+	ErrERC1155NotSupported     = errors.New("bridge contract does not support ERC1155 transfers")
+	ErrMessageNotSupported     = errors.New("bridge contract does not support cross-chain messages")
 )
 
 var handleVTmethods = map[uint8]string{
-	KLAY:   "handleKLAYTransfer",
-	ERC20:  "handleERC20Transfer",
-	ERC721: "handleERC721Transfer",
+	KLAY:    "handleKLAYTransfer",
+	ERC20:   "handleERC20Transfer",
+	ERC721:  "handleERC721Transfer",
+	ERC1155: "handleERC1155Transfer",
 }
 
 // HandleValueTransferEvent from Bridge contract
@@ -81,6 +98,7 @@ type BridgeJournal struct {
 	ChildAddress          common.Address `json:"childAddress"`
 	ParentAddress         common.Address `json:"parentAddress"`
 	Subscribed            bool           `json:"subscribed"`
+	ParentChainID         uint64         `json:"parentChainID,omitempty"` // zero for the parent chain of ParentChainID.
 	isLegacyBridgeJournal bool
 }
 
@@ -100,6 +118,8 @@ type BridgeInfo struct {
 	counterpartToken map[common.Address]common.Address
 	ctTokenMu        sync.RWMutex
 
+	parentChainID uint64 // the parent chain of the bridge pair, zero for the one of ParentChainID.
+
 	pendingRequestEvent *bridgepool.ItemSortedMap
 
 	isRunning                   bool
@@ -108,6 +128,10 @@ type BridgeInfo struct {
 	requestNonceFromCounterPart uint64 // the nonce from the request value transfer event from the counter part bridge.
 	requestNonce                uint64 // the nonce from the request value transfer event from the counter part bridge.
 
+	pendingMessages                    *bridgepool.ItemSortedMap
+	handleMessageNonce                 uint64 // the nonce of the next message of the counter part bridge to execute.
+	requestMessageNonceFromCounterPart uint64 // the nonce from the request message event from the counter part bridge.
+
 	newEvent chan struct{}
 	closed   chan struct{}
 
@@ -141,6 +165,7 @@ func NewBridgeInfo(sb *SubBridge, addr common.Address, bridge *bridgecontract.Br
 		lowerHandleNonce:            0,
 		requestNonceFromCounterPart: 0,
 		requestNonce:                0,
+		pendingMessages:             bridgepool.NewItemSortedMap(bridgepool.UnlimitedItemSortedMap),
 		newEvent:                    make(chan struct{}),
 		closed:                      make(chan struct{}),
 		handledEvent:                bridgepool.NewItemSortedMap(maxHandledEventSize),
@@ -178,9 +203,11 @@ func (bi *BridgeInfo) loop() {
 		select {
 		case <-bi.newEvent:
 			bi.processingPendingRequestEvents()
+			bi.processingPendingMessages()
 
 		case <-ticker.C:
 			bi.processingPendingRequestEvents()
+			bi.processingPendingMessages()
 
 		case <-bi.closed:
 			logger.Info("stop bridge loop", "addr", bi.address.String(), "onChildChain", bi.onChildChain)
@@ -236,6 +263,12 @@ func (bi *BridgeInfo) GetPendingRequestEvents() []IRequestValueTransferEvent {
 
 // processingPendingRequestEvents handles pending request value transfer events of the bridge.
 func (bi *BridgeInfo) processingPendingRequestEvents() {
+	// The events are kept pending while value transfers are paused.
+	limiter := bi.transferLimiter()
+	if limiter.paused() {
+		return
+	}
+
 	ReadyEvent := bi.GetReadyRequestValueTransferEvents()
 	if ReadyEvent == nil {
 		return
@@ -249,14 +282,31 @@ func (bi *BridgeInfo) processingPendingRequestEvents() {
 			continue
 		}
 
+		switch limiter.check(bi, ev) {
+		case transferHeld:
+			continue
+		case transferRejected:
+			logger.Trace("rejected requests are ignored", "RequestNonce", ev.GetRequestNonce())
+			continue
+		}
+
 		if err := bi.handleRequestValueTransferEvent(ev); err != nil {
 			bi.AddRequestValueTransferEvents(ReadyEvent[idx:])
 			logger.Error("Failed handle request value transfer event", "err", err, "len(RePutEvent)", len(ReadyEvent[idx:]))
 			return
 		}
+		limiter.commit(bi, ev)
 	}
 }
 
+// transferLimiter returns the transfer limiter of the sub-bridge, if any.
+func (bi *BridgeInfo) transferLimiter() *transferLimiter {
+	if bi.subBridge == nil {
+		return nil
+	}
+	return bi.subBridge.transferLimiter
+}
+
 func (bi *BridgeInfo) UpdateInfo() error {
 	if bi == nil {
 		return ErrNoBridgeInfo
@@ -346,6 +396,22 @@ func (bi *BridgeInfo) handleRequestValueTransferEvent(ev IRequestValueTransferEv
 			return err
 		}
 		handleValueTransferLog(bi.onChildChain, handleVTmethods[ERC721], handleTx.Hash().String(), requestNonce, from, to, valueOrTokenId)
+	case ERC1155:
+		// An older bridge contract would take the call for its fallback, which
+		// requests a KLAY transfer, so its version is checked first.
+		if err := bi.checkERC1155Support(); err != nil {
+			return err
+		}
+		amount := GetERC1155Amount(ev)
+		if amount == nil {
+			return fmt.Errorf("no amount in the ERC1155 transfer request (nonce %d)", requestNonce)
+		}
+		uri := GetURI(ev)
+		handleTx, err = bi.bridge.HandleERC1155Transfer(auth, txHash, from, to, ctpartTokenAddr, valueOrTokenId, amount, requestNonce, blkNumber, uri, extraData)
+		if err != nil {
+			return err
+		}
+		handleValueTransferLog(bi.onChildChain, handleVTmethods[ERC1155], handleTx.Hash().String(), requestNonce, from, to, valueOrTokenId)
 	default:
 		logger.Error("Got Unknown Token Type ReceivedEvent", "bridge", contractAddr, "nonce", requestNonce, "from", from)
 		return nil
@@ -357,6 +423,31 @@ func (bi *BridgeInfo) handleRequestValueTransferEvent(ev IRequestValueTransferEv
 	return nil
 }
 
+// checkERC1155Support returns ErrERC1155NotSupported if the bridge contract
+// has no ERC1155 transfer module.
+func (bi *BridgeInfo) checkERC1155Support() error {
+	return checkBridgeVersion(bi.bridge, erc1155BridgeVersion, ErrERC1155NotSupported)
+}
+
+// checkMessageSupport returns ErrMessageNotSupported if the bridge contract
+// has no cross-chain message module.
+func (bi *BridgeInfo) checkMessageSupport() error {
+	return checkBridgeVersion(bi.bridge, messageBridgeVersion, ErrMessageNotSupported)
+}
+
+// checkBridgeVersion returns errUnsupported if the version of the bridge
+// contract is lower than the given one.
+func checkBridgeVersion(bridge *bridgecontract.Bridge, version uint64, errUnsupported error) error {
+	v, err := bridge.VERSION(nil)
+	if err != nil {
+		return err
+	}
+	if v < version {
+		return errUnsupported
+	}
+	return nil
+}
+
 // SetRequestNonceFromCounterpart sets the request nonce from counterpart bridge.
 func (bi *BridgeInfo) SetRequestNonceFromCounterpart(nonce uint64) {
 	if bi.requestNonceFromCounterPart < nonce {
@@ -434,7 +525,20 @@ func (bi *BridgeInfo) GetCurrentBlockNumber() (uint64, error) {
 		defer cancel()
 		return bi.subBridge.localBackend.CurrentBlockNumber(ctx)
 	}
-	return bi.subBridge.remoteBackend.CurrentBlockNumber(context.Background())
+	parentBackend, _, err := bi.subBridge.parentChainOf(bi.parentChainID)
+	if err != nil {
+		return 0, err
+	}
+	return parentBackend.CurrentBlockNumber(context.Background())
+}
+
+// chainBackend returns the backend of the chain the bridge is deployed on.
+func (bi *BridgeInfo) chainBackend() (Backend, error) {
+	if bi.onChildChain {
+		return bi.subBridge.localBackend, nil
+	}
+	parentBackend, _, err := bi.subBridge.parentChainOf(bi.parentChainID)
+	return parentBackend, err
 }
 
 // DecodeRLP decodes the Klaytn
@@ -449,6 +553,7 @@ func (b *BridgeJournal) DecodeRLP(s *rlp.Stream) error {
 		LocalAddress  common.Address
 		RemoteAddress common.Address
 		Paired        bool
+		ParentChainID uint64 `rlp:"optional"`
 	}
 	if !b.isLegacyBridgeJournal {
 		if err := s.Decode(&BridgeAddrInfo); err != nil {
@@ -460,6 +565,7 @@ func (b *BridgeJournal) DecodeRLP(s *rlp.Stream) error {
 			return ErrBridgeAliasFormatDecode
 		}
 		b.BridgeAlias, b.ChildAddress, b.ParentAddress, b.Subscribed = BridgeAddrInfo.BridgeAlias, BridgeAddrInfo.LocalAddress, BridgeAddrInfo.RemoteAddress, BridgeAddrInfo.Paired
+		b.ParentChainID = BridgeAddrInfo.ParentChainID
 	} else {
 		if err := s.Decode(&LegacyBridgeAddrInfo); err != nil {
 			return err
@@ -471,12 +577,18 @@ func (b *BridgeJournal) DecodeRLP(s *rlp.Stream) error {
 
 // EncodeRLP serializes a BridgeJournal into the Klaytn RLP BridgeJournal format.
 func (b *BridgeJournal) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, []interface{}{
+	items := []interface{}{
 		b.BridgeAlias,
 		b.ChildAddress,
 		b.ParentAddress,
 		b.Subscribed,
-	})
+	}
+	// The parent chain ID is left out for the primary parent chain, so that
+	// older nodes can still read the journal.
+	if b.ParentChainID != 0 {
+		items = append(items, b.ParentChainID)
+	}
+	return rlp.Encode(w, items)
 }
 
 // BridgeManager manages Bridge SmartContracts
@@ -493,6 +605,8 @@ type BridgeManager struct {
 	reqVTevFeeder        event.Feed
 	reqVTevEncodedFeeder event.Feed
 	handleEventFeeder    event.Feed
+	reqMsgEvFeeder       event.Feed
+	handleMsgEvFeeder    event.Feed
 
 	scope event.SubscriptionScope
 
@@ -616,6 +730,16 @@ func (bm *BridgeManager) SubscribeHandleVTev(ch chan<- *HandleValueTransferEvent
 	return bm.scope.Track(bm.handleEventFeeder.Subscribe(ch))
 }
 
+// SubscribeReqMsgEv registers a subscription of RequestMessageEvent.
+func (bm *BridgeManager) SubscribeReqMsgEv(ch chan<- RequestMessageEvent) event.Subscription {
+	return bm.scope.Track(bm.reqMsgEvFeeder.Subscribe(ch))
+}
+
+// SubscribeHandleMsgEv registers a subscription of HandleMessageEvent.
+func (bm *BridgeManager) SubscribeHandleMsgEv(ch chan<- *HandleMessageEvent) event.Subscription {
+	return bm.scope.Track(bm.handleMsgEvFeeder.Subscribe(ch))
+}
+
 // getAddrByAlias returns a pair of child bridge address and parent bridge address
 func (bm *BridgeManager) getAddrByAlias(bridgeAlias string) (common.Address, common.Address, error) {
 	bm.journal.cacheMu.RLock()
@@ -679,6 +803,11 @@ func (bm *BridgeManager) DeleteBridgeInfo(addr common.Address) error {
 
 // SetBridgeInfo stores the address and bridge pair with local/remote and subscription status.
 func (bm *BridgeManager) SetBridgeInfo(addr common.Address, bridge *bridgecontract.Bridge, cpAddr common.Address, cpBridge *bridgecontract.Bridge, account *accountInfo, local bool, subscribed bool) error {
+	return bm.SetParentChainBridgeInfo(addr, bridge, cpAddr, cpBridge, account, local, subscribed, 0)
+}
+
+// SetParentChainBridgeInfo is SetBridgeInfo for a bridge pair with the given parent chain.
+func (bm *BridgeManager) SetParentChainBridgeInfo(addr common.Address, bridge *bridgecontract.Bridge, cpAddr common.Address, cpBridge *bridgecontract.Bridge, account *accountInfo, local bool, subscribed bool, parentChainID uint64) error {
 	bm.bridgesMu.Lock()
 	defer bm.bridgesMu.Unlock()
 
@@ -688,13 +817,18 @@ func (bm *BridgeManager) SetBridgeInfo(addr common.Address, bridge *bridgecontra
 
 	var counterpartBackend Backend
 	if local {
-		counterpartBackend = bm.subBridge.remoteBackend
+		parentBackend, _, err := bm.subBridge.parentChainOf(parentChainID)
+		if err != nil {
+			return err
+		}
+		counterpartBackend = parentBackend
 	} else {
 		counterpartBackend = bm.subBridge.localBackend
 	}
 
-	var err error
-	bm.bridges[addr], err = NewBridgeInfo(bm.subBridge, addr, bridge, cpAddr, cpBridge, account, local, subscribed, counterpartBackend)
+	bi, err := NewBridgeInfo(bm.subBridge, addr, bridge, cpAddr, cpBridge, account, local, subscribed, counterpartBackend)
+	bi.parentChainID = parentChainID
+	bm.bridges[addr] = bi
 	return err
 }
 
@@ -705,7 +839,7 @@ func (bm *BridgeManager) RestoreBridges() error {
 		return ErrBridgeRestore
 	}
 
-	counter := 0
+	counter, skipped := 0, 0
 	bm.stopAllRecoveries()
 
 	bm.journal.cacheMu.RLock()
@@ -716,6 +850,14 @@ func (bm *BridgeManager) RestoreBridges() error {
 		pBridgeAddr := journal.ParentAddress
 		bacc := bm.subBridge.bridgeAccounts
 
+		parentBackend, pAccount, err := bm.subBridge.parentChainOf(journal.ParentChainID)
+		if err != nil {
+			// Retrying does not help until the parent chain is configured.
+			logger.Error("skipping the bridge of an unconfigured parent chain", "err", err, "parentChainID", journal.ParentChainID, "bridge", pBridgeAddr.String())
+			skipped++
+			continue
+		}
+
 		// Set bridge info
 		cBridgeInfo, cOk := bm.GetBridgeInfo(cBridgeAddr)
 		pBridgeInfo, pOk := bm.GetBridgeInfo(pBridgeAddr)
@@ -726,14 +868,14 @@ func (bm *BridgeManager) RestoreBridges() error {
 			break
 		}
 
-		pBridge, err := bridgecontract.NewBridge(pBridgeAddr, bm.subBridge.remoteBackend)
+		pBridge, err := bridgecontract.NewBridge(pBridgeAddr, parentBackend)
 		if err != nil {
 			logger.Error("remote bridge creation is failed", "err", err, "bridge", pBridge)
 			break
 		}
 
 		if !cOk {
-			err = bm.SetBridgeInfo(cBridgeAddr, cBridge, pBridgeAddr, pBridge, bacc.cAccount, true, false)
+			err = bm.SetParentChainBridgeInfo(cBridgeAddr, cBridge, pBridgeAddr, pBridge, bacc.cAccount, true, false, journal.ParentChainID)
 			if err != nil {
 				logger.Error("setting local bridge info is failed", "err", err)
 				bm.DeleteBridgeInfo(cBridgeAddr)
@@ -743,7 +885,7 @@ func (bm *BridgeManager) RestoreBridges() error {
 		}
 
 		if !pOk {
-			err = bm.SetBridgeInfo(pBridgeAddr, pBridge, cBridgeAddr, cBridgeInfo.bridge, bacc.pAccount, false, false)
+			err = bm.SetParentChainBridgeInfo(pBridgeAddr, pBridge, cBridgeAddr, cBridgeInfo.bridge, pAccount, false, false, journal.ParentChainID)
 			if err != nil {
 				logger.Error("setting remote bridge info is failed", "err", err)
 				bm.DeleteBridgeInfo(pBridgeAddr)
@@ -781,8 +923,8 @@ func (bm *BridgeManager) RestoreBridges() error {
 		counter++
 	}
 
-	if len(bm.journal.cache) == counter {
-		logger.Info("succeeded to restore bridges", "pairs", counter)
+	if len(bm.journal.cache) == counter+skipped {
+		logger.Info("succeeded to restore bridges", "pairs", counter, "skipped", skipped)
 		return nil
 	}
 	return ErrBridgeRestore
@@ -793,6 +935,11 @@ func (bm *BridgeManager) SetJournal(bridgeAlias string, localAddress, remoteAddr
 	return bm.journal.insert(bridgeAlias, localAddress, remoteAddress)
 }
 
+// SetParentChainJournal is SetJournal for a bridge pair with the given parent chain.
+func (bm *BridgeManager) SetParentChainJournal(bridgeAlias string, localAddress, remoteAddress common.Address, parentChainID uint64) error {
+	return bm.journal.insertWithParentChain(bridgeAlias, localAddress, remoteAddress, parentChainID)
+}
+
 // AddRecovery starts value transfer recovery for a given addresses pair.
 func (bm *BridgeManager) AddRecovery(localAddress, remoteAddress common.Address) error {
 	if !bm.subBridge.config.VTRecovery {
@@ -1015,6 +1162,8 @@ func (bm *BridgeManager) subscribeEvent(addr common.Address, bridge *bridgecontr
 	chanReqVT := make(chan *bridgecontract.BridgeRequestValueTransfer, TokenEventChanSize)
 	chanReqVTencoded := make(chan *bridgecontract.BridgeRequestValueTransferEncoded, TokenEventChanSize)
 	chanHandleVT := make(chan *bridgecontract.BridgeHandleValueTransfer, TokenEventChanSize)
+	chanReqMsg := make(chan *bridgecontract.BridgeRequestMessage, TokenEventChanSize)
+	chanHandleMsg := make(chan *bridgecontract.BridgeHandleMessage, TokenEventChanSize)
 
 	vtEv, err := bridge.WatchRequestValueTransfer(nil, chanReqVT, nil, nil, nil)
 	if err != nil {
@@ -1030,11 +1179,36 @@ func (bm *BridgeManager) subscribeEvent(addr common.Address, bridge *bridgecontr
 	}
 	bm.receivedEvents[addr] = append(bm.receivedEvents[addr], vtEncodedev)
 
+	// Bridge contracts without the message module never emit these events,
+	// so the subscriptions are harmless for them.
+	reqMsgEv, err := bridge.WatchRequestMessage(nil, chanReqMsg, nil, nil)
+	if err != nil {
+		logger.Error("Failed to watch RequestMessage event", "err", err)
+		vtEv.Unsubscribe()
+		vtEncodedev.Unsubscribe()
+		delete(bm.receivedEvents, addr)
+		return err
+	}
+	bm.receivedEvents[addr] = append(bm.receivedEvents[addr], reqMsgEv)
+
+	handleMsgEv, err := bridge.WatchHandleMessage(nil, chanHandleMsg, nil, nil)
+	if err != nil {
+		logger.Error("Failed to watch HandleMessage event", "err", err)
+		vtEv.Unsubscribe()
+		vtEncodedev.Unsubscribe()
+		reqMsgEv.Unsubscribe()
+		delete(bm.receivedEvents, addr)
+		return err
+	}
+	bm.receivedEvents[addr] = append(bm.receivedEvents[addr], handleMsgEv)
+
 	withdrawnSub, err := bridge.WatchHandleValueTransfer(nil, chanHandleVT, nil, nil, nil)
 	if err != nil {
 		logger.Error("Failed to watch HandleValueTransfer event", "err", err)
 		vtEv.Unsubscribe()
 		vtEncodedev.Unsubscribe()
+		reqMsgEv.Unsubscribe()
+		handleMsgEv.Unsubscribe()
 		delete(bm.receivedEvents, addr)
 		return err
 	}
@@ -1044,6 +1218,8 @@ func (bm *BridgeManager) subscribeEvent(addr common.Address, bridge *bridgecontr
 	if !ok {
 		vtEv.Unsubscribe()
 		vtEncodedev.Unsubscribe()
+		reqMsgEv.Unsubscribe()
+		handleMsgEv.Unsubscribe()
 		withdrawnSub.Unsubscribe()
 		delete(bm.receivedEvents, addr)
 		delete(bm.withdrawEvents, addr)
@@ -1051,7 +1227,7 @@ func (bm *BridgeManager) subscribeEvent(addr common.Address, bridge *bridgecontr
 	}
 	bridgeInfo.subscribed = true
 
-	go bm.loop(addr, chanReqVT, chanReqVTencoded, chanHandleVT, vtEv, vtEncodedev, withdrawnSub)
+	go bm.loop(addr, chanReqVT, chanReqVTencoded, chanHandleVT, chanReqMsg, chanHandleMsg, vtEv, vtEncodedev, withdrawnSub, reqMsgEv, handleMsgEv)
 
 	return nil
 }
@@ -1085,12 +1261,17 @@ func (bm *BridgeManager) loop(
 	chanReqVT <-chan *bridgecontract.BridgeRequestValueTransfer,
 	chanReqVTencoded <-chan *bridgecontract.BridgeRequestValueTransferEncoded,
 	chanHandleVT <-chan *bridgecontract.BridgeHandleValueTransfer,
+	chanReqMsg <-chan *bridgecontract.BridgeRequestMessage,
+	chanHandleMsg <-chan *bridgecontract.BridgeHandleMessage,
 	reqVTevSub, reqVTencodedEvSub event.Subscription,
 	handleEventSub event.Subscription,
+	reqMsgEvSub, handleMsgEvSub event.Subscription,
 ) {
 	defer reqVTevSub.Unsubscribe()
 	defer reqVTencodedEvSub.Unsubscribe()
 	defer handleEventSub.Unsubscribe()
+	defer reqMsgEvSub.Unsubscribe()
+	defer handleMsgEvSub.Unsubscribe()
 
 	bi, ok := bm.GetBridgeInfo(addr)
 	if !ok {
@@ -1109,6 +1290,10 @@ func (bm *BridgeManager) loop(
 			bm.reqVTevEncodedFeeder.Send(RequestValueTransferEncodedEvent{ev})
 		case ev := <-chanHandleVT:
 			bm.handleEventFeeder.Send(&HandleValueTransferEvent{ev})
+		case ev := <-chanReqMsg:
+			bm.reqMsgEvFeeder.Send(RequestMessageEvent{ev})
+		case ev := <-chanHandleMsg:
+			bm.handleMsgEvFeeder.Send(&HandleMessageEvent{ev})
 		case err := <-reqVTevSub.Err():
 			logger.Info("Contract Event Loop Running Stop by receivedSub.Err()", "err", err)
 			return
@@ -1118,6 +1303,12 @@ func (bm *BridgeManager) loop(
 		case err := <-handleEventSub.Err():
 			logger.Info("Contract Event Loop Running Stop by withdrawSub.Err()", "err", err)
 			return
+		case err := <-reqMsgEvSub.Err():
+			logger.Info("Contract Event Loop Running Stop by reqMsgEvSub.Err()", "err", err)
+			return
+		case err := <-handleMsgEvSub.Err():
+			logger.Info("Contract Event Loop Running Stop by handleMsgEvSub.Err()", "err", err)
+			return
 		}
 	}
 }
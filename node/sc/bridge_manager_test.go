@@ -1464,7 +1464,7 @@ func TestErrorDupSubscription(t *testing.T) {
 
 	bm.bridges[addr], err = NewBridgeInfo(sc, addr, bridge, common.Address{}, nil, bacc.cAccount, true, true, sim)
 
-	bm.journal.cache[addr] = &BridgeJournal{"", addr, addr, true, false}
+	bm.journal.cache[addr] = &BridgeJournal{"", addr, addr, true, 0, false}
 
 	bm.SubscribeEvent(addr)
 	err = bm.SubscribeEvent(addr)
@@ -1910,6 +1910,115 @@ func TestAnchoringPeriod(t *testing.T) {
 	}
 }
 
+// TestMerkleAnchoring tests that an anchoring tx commits to every block of the
+// anchoring period and that the inclusion proof of each block can be served.
+func TestMerkleAnchoring(t *testing.T) {
+	tempDir, err := os.MkdirTemp(os.TempDir(), "merkleAnchoring")
+	assert.NoError(t, err)
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Fatalf("fail to delete file %v", err)
+		}
+	}()
+
+	config := &SCConfig{AnchoringPeriod: 4, MerkleAnchoring: true}
+	config.DataDir = tempDir
+
+	bAcc, _ := NewBridgeAccounts(nil, tempDir, database.NewDBManager(&database.DBConfig{DBType: database.MemoryDB}), DefaultBridgeTxGasLimit, DefaultBridgeTxGasLimit)
+	bAcc.pAccount.chainID = big.NewInt(0)
+	bAcc.cAccount.chainID = big.NewInt(0)
+
+	alloc := blockchain.GenesisAlloc{}
+	sim := backends.NewSimulatedBackend(alloc)
+	defer sim.Close()
+
+	sc := &SubBridge{
+		config:         config,
+		peers:          newBridgePeerSet(),
+		localBackend:   sim,
+		remoteBackend:  sim,
+		bridgeAccounts: bAcc,
+		chainDB:        database.NewDBManager(&database.DBConfig{DBType: database.MemoryDB}),
+	}
+	sc.blockchain = sim.BlockChain()
+
+	sc.handler, err = NewSubBridgeHandler(sc)
+	if err != nil {
+		t.Fatalf("Failed to initialize bridgeHandler : %v", err)
+	}
+	sc.bridgeTxPool = bridgepool.NewBridgeTxPool(bridgepool.BridgeTxPoolConfig{
+		Journal:     path.Join(tempDir, "bridge_transactions.rlp"),
+		GlobalQueue: 1024,
+	})
+	defer sc.bridgeTxPool.Stop()
+
+	for i := 0; i < 4; i++ {
+		sim.Commit()
+		sc.handler.blockAnchoringManager(sim.BlockChain().CurrentBlock())
+	}
+	curBlk := sim.BlockChain().CurrentBlock()
+	assert.Equal(t, uint64(4), curBlk.NumberU64())
+
+	pending := sc.GetBridgeTxPool().Pending()
+	assert.Equal(t, 1, len(pending))
+
+	var anchoringTx *types.Transaction
+	for _, v := range pending {
+		anchoringTx = v[0]
+	}
+	data, err := anchoringTx.AnchoredData()
+	assert.NoError(t, err)
+	decoded, err := types.DecodeAnchoringData(data)
+	assert.NoError(t, err)
+	anchoringData, ok := decoded.(*types.AnchoringDataInternalType1)
+	if !ok {
+		t.Fatalf("unexpected anchoring data type %T", decoded)
+	}
+	assert.Equal(t, curBlk.Hash(), anchoringData.BlockHash)
+	assert.Equal(t, uint64(1), anchoringData.StartBlockNumber.Uint64())
+	assert.Equal(t, uint64(4), anchoringData.BlockCount.Uint64())
+
+	api := &SubBridgeAPI{sc}
+	for bn := uint64(1); bn <= 4; bn++ {
+		result, err := api.GetAnchoringProof(bn)
+		assert.NoError(t, err)
+		assert.Equal(t, anchoringTx.Hash(), result["anchoringTxHash"])
+
+		blockHash := sim.BlockChain().GetHeaderByNumber(bn).Hash()
+		assert.Equal(t, blockHash, result["blockHash"])
+		assert.True(t, types.VerifyAnchoringMerkleProof(anchoringData.BlockHashesRoot, blockHash, result["index"].(uint64), anchoringData.BlockCount.Uint64(), result["proof"].([]common.Hash)))
+	}
+
+	// The genesis block and the blocks of the next period are not anchored yet.
+	_, err = api.GetAnchoringProof(0)
+	assert.Equal(t, ErrNoAnchoringProof, err)
+	_, err = api.GetAnchoringProof(5)
+	assert.Equal(t, ErrNoAnchoringProof, err)
+
+	// After a restart, the next batch still starts right after the last anchored
+	// block, including the blocks made while the node was down.
+	sc.chainDB.WriteAnchoredBlockNumber(4)
+	sc.handler, err = NewSubBridgeHandler(sc)
+	if err != nil {
+		t.Fatalf("Failed to initialize bridgeHandler : %v", err)
+	}
+	sim.Commit()
+	sim.Commit()
+	for i := 0; i < 2; i++ {
+		sim.Commit()
+		sc.handler.blockAnchoringManager(sim.BlockChain().CurrentBlock())
+	}
+	assert.Equal(t, uint64(8), sim.BlockChain().CurrentBlock().NumberU64())
+
+	api = &SubBridgeAPI{sc}
+	for bn := uint64(5); bn <= 8; bn++ {
+		result, err := api.GetAnchoringProof(bn)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(5), result["startBlockNumber"])
+		assert.Equal(t, 4, result["blockCount"])
+	}
+}
+
 // decodeAndCheckAnchoringTx decodes anchoring tx and check with a block.
 func decodeAndCheckAnchoringTx(t *testing.T, tx *types.Transaction, blk *types.Block, blockCount, txCounts int64) {
 	assert.Equal(t, types.TxTypeChainDataAnchoring, tx.Type())
@@ -2730,3 +2839,56 @@ func TestGetBridgeContractBalance(t *testing.T) {
 		}
 	}
 }
+
+// TestHandleERC1155TransferOnOldBridge checks that an ERC1155 transfer request
+// is not handled by a bridge contract without the ERC1155 transfer module.
+func TestHandleERC1155TransferOnOldBridge(t *testing.T) {
+	tempDir := t.TempDir()
+
+	config := &SCConfig{DataDir: tempDir}
+	bacc, _ := NewBridgeAccounts(nil, config.DataDir, database.NewDBManager(&database.DBConfig{DBType: database.MemoryDB}), DefaultBridgeTxGasLimit, DefaultBridgeTxGasLimit)
+	bacc.pAccount.chainID = big.NewInt(0)
+	bacc.cAccount.chainID = big.NewInt(0)
+
+	alloc := blockchain.GenesisAlloc{
+		bacc.pAccount.address: {Balance: big.NewInt(params.KLAY)},
+		bacc.cAccount.address: {Balance: big.NewInt(params.KLAY)},
+	}
+	sim := backends.NewSimulatedBackend(alloc)
+	defer sim.Close()
+
+	sc := &SubBridge{
+		chainDB:        database.NewDBManager(&database.DBConfig{DBType: database.MemoryDB}),
+		config:         config,
+		peers:          newBridgePeerSet(),
+		bridgeAccounts: bacc,
+		localBackend:   sim,
+		remoteBackend:  sim,
+	}
+	bridgeManager, err := NewBridgeManager(sc)
+	assert.NoError(t, err)
+
+	addr, err := bridgeManager.DeployBridgeTest(sim, 10000, false)
+	assert.NoError(t, err)
+	sim.Commit()
+	bridgeInfo, _ := bridgeManager.GetBridgeInfo(addr)
+
+	version, err := bridgeInfo.bridge.VERSION(nil)
+	assert.NoError(t, err)
+	assert.Less(t, version, uint64(erc1155BridgeVersion))
+
+	tokenAddr := common.HexToAddress("0x1155")
+	bridgeInfo.RegisterToken(tokenAddr, tokenAddr)
+
+	nonce := bridgeInfo.account.GetNonce()
+	ev := RequestValueTransferEncodedEvent{&bridge.BridgeRequestValueTransferEncoded{
+		TokenType:      ERC1155,
+		TokenAddress:   tokenAddr,
+		ValueOrTokenId: big.NewInt(7),
+		EncodingVer:    3,
+		EncodedData:    packERC1155Data(t, big.NewInt(300), "ipfs://item/7"),
+		Raw:            types.Log{Address: addr},
+	}}
+	assert.ErrorIs(t, bridgeInfo.handleRequestValueTransferEvent(ev), ErrERC1155NotSupported)
+	assert.Equal(t, nonce, bridgeInfo.account.GetNonce())
+}
@@ -0,0 +1,249 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package bridgepool
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+)
+
+var ErrNoHeldTransfer = errors.New("no held value transfer")
+
+type transferKey struct {
+	bridge common.Address
+	nonce  uint64
+}
+
+type heldTransfer struct {
+	record *TransferDecisionRecord
+	event  interface{} // nil if the transfer was held before a restart.
+}
+
+// HeldTransferPool keeps the value transfer requests held by the transfer limits
+// of the bridge operators until an admin approves or rejects them. Every decision
+// is written into its journal, from which the pool restores the held, approved
+// and rejected transfers and the pause.
+type HeldTransferPool struct {
+	mu sync.RWMutex
+
+	held     map[transferKey]*heldTransfer
+	approved map[transferKey]bool
+	rejected map[transferKey]bool
+	paused   bool
+
+	journal *transferJournal
+}
+
+// NewHeldTransferPool creates a held transfer pool with the given journal file.
+func NewHeldTransferPool(journalPath string) (*HeldTransferPool, error) {
+	pool := &HeldTransferPool{
+		held:     make(map[transferKey]*heldTransfer),
+		approved: make(map[transferKey]bool),
+		rejected: make(map[transferKey]bool),
+		journal:  newTransferJournal(journalPath),
+	}
+	if err := pool.journal.load(pool.apply); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+// apply updates the state of the pool with the given decision.
+func (pool *HeldTransferPool) apply(record *TransferDecisionRecord) {
+	key := transferKey{record.HandleBridge, record.RequestNonce}
+	switch record.Decision {
+	case TransferHeld:
+		if !pool.approved[key] && !pool.rejected[key] {
+			pool.held[key] = &heldTransfer{record: record}
+		}
+	case TransferApproved:
+		delete(pool.held, key)
+		pool.approved[key] = true
+	case TransferRejected:
+		delete(pool.held, key)
+		pool.rejected[key] = true
+	case TransferAllowed:
+		delete(pool.approved, key)
+	case TransfersPaused:
+		pool.paused = true
+	case TransfersResumed:
+		pool.paused = false
+	}
+}
+
+// record journals the decision and applies it to the pool.
+func (pool *HeldTransferPool) record(record *TransferDecisionRecord) error {
+	if record.Time == 0 {
+		record.Time = uint64(time.Now().Unix())
+	}
+	err := pool.journal.insert(record)
+	if err != nil {
+		logger.Error("Failed to journal a transfer decision", "decision", record.Decision, "nonce", record.RequestNonce, "err", err)
+	}
+	pool.apply(record)
+	return err
+}
+
+// Hold keeps the given request event until it is approved or rejected.
+func (pool *HeldTransferPool) Hold(record *TransferDecisionRecord, event interface{}) error {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	key := transferKey{record.HandleBridge, record.RequestNonce}
+	if held, ok := pool.held[key]; ok {
+		// The request can be found again by the value transfer recovery.
+		held.event = event
+		return nil
+	}
+	record.Decision = TransferHeld
+	err := pool.record(record)
+	if held, ok := pool.held[key]; ok {
+		held.event = event
+	}
+	return err
+}
+
+// Allow journals that the given request is relayed.
+func (pool *HeldTransferPool) Allow(record *TransferDecisionRecord) error {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	record.Decision = TransferAllowed
+	return pool.record(record)
+}
+
+// ReplayAllowed passes the requests which were relayed within the limits, i.e.
+// without an approval, to fn in the order of the journal.
+func (pool *HeldTransferPool) ReplayAllowed(fn func(*TransferDecisionRecord)) error {
+	approved := make(map[transferKey]bool)
+	return pool.journal.replay(func(record *TransferDecisionRecord) {
+		key := transferKey{record.HandleBridge, record.RequestNonce}
+		switch record.Decision {
+		case TransferApproved:
+			approved[key] = true
+		case TransferAllowed:
+			if approved[key] {
+				delete(approved, key)
+				return
+			}
+			fn(record)
+		}
+	})
+}
+
+// Approve releases the held request of the given bridge and nonce. It returns the
+// request event, which is nil if it was held before a restart of the node.
+func (pool *HeldTransferPool) Approve(bridge common.Address, nonce uint64, reason string) (interface{}, error) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	held, ok := pool.held[transferKey{bridge, nonce}]
+	if !ok {
+		return nil, ErrNoHeldTransfer
+	}
+	record := *held.record
+	record.Time, record.Decision, record.Reason = 0, TransferApproved, reason
+	return held.event, pool.record(&record)
+}
+
+// Reject drops the held request of the given bridge and nonce for good.
+func (pool *HeldTransferPool) Reject(bridge common.Address, nonce uint64, reason string) error {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	held, ok := pool.held[transferKey{bridge, nonce}]
+	if !ok {
+		return ErrNoHeldTransfer
+	}
+	record := *held.record
+	record.Time, record.Decision, record.Reason = 0, TransferRejected, reason
+	return pool.record(&record)
+}
+
+// IsApproved returns true if the request of the given bridge and nonce is approved
+// and not yet relayed.
+func (pool *HeldTransferPool) IsApproved(bridge common.Address, nonce uint64) bool {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+	return pool.approved[transferKey{bridge, nonce}]
+}
+
+// IsRejected returns true if the request of the given bridge and nonce is rejected.
+func (pool *HeldTransferPool) IsRejected(bridge common.Address, nonce uint64) bool {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+	return pool.rejected[transferKey{bridge, nonce}]
+}
+
+// IsHeld returns true if the request of the given bridge and nonce is held.
+func (pool *HeldTransferPool) IsHeld(bridge common.Address, nonce uint64) bool {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+	_, ok := pool.held[transferKey{bridge, nonce}]
+	return ok
+}
+
+// Held returns the held requests ordered by bridge and nonce.
+func (pool *HeldTransferPool) Held() []*TransferDecisionRecord {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	records := make([]*TransferDecisionRecord, 0, len(pool.held))
+	for _, held := range pool.held {
+		records = append(records, held.record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].HandleBridge != records[j].HandleBridge {
+			return records[i].HandleBridge.Hex() < records[j].HandleBridge.Hex()
+		}
+		return records[i].RequestNonce < records[j].RequestNonce
+	})
+	return records
+}
+
+// SetPaused pauses or resumes relaying all value transfers.
+func (pool *HeldTransferPool) SetPaused(paused bool, reason string) error {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if pool.paused == paused {
+		return nil
+	}
+	decision := TransfersResumed
+	if paused {
+		decision = TransfersPaused
+	}
+	return pool.record(&TransferDecisionRecord{Decision: decision, Reason: reason})
+}
+
+// Paused returns true if relaying value transfers is paused.
+func (pool *HeldTransferPool) Paused() bool {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+	return pool.paused
+}
+
+// Stop closes the journal of the pool.
+func (pool *HeldTransferPool) Stop() {
+	if err := pool.journal.close(); err != nil {
+		logger.Error("Failed to close the transfer decision journal", "err", err)
+	}
+}
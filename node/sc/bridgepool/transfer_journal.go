@@ -0,0 +1,165 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package bridgepool
+
+import (
+	"io"
+	"math/big"
+	"os"
+	"sync"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+// TransferDecision is a decision of the transfer limits of the bridge operators.
+type TransferDecision uint8
+
+const (
+	TransferAllowed TransferDecision = iota
+	TransferHeld
+	TransferApproved
+	TransferRejected
+	TransfersPaused
+	TransfersResumed
+)
+
+var transferDecisionNames = map[TransferDecision]string{
+	TransferAllowed:  "allowed",
+	TransferHeld:     "held",
+	TransferApproved: "approved",
+	TransferRejected: "rejected",
+	TransfersPaused:  "paused",
+	TransfersResumed: "resumed",
+}
+
+func (d TransferDecision) String() string {
+	if name, ok := transferDecisionNames[d]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// MarshalText returns the name of the decision.
+func (d TransferDecision) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// TransferDecisionRecord is an entry of the transfer decision journal. The
+// transfer fields are empty for the pause and resume decisions.
+type TransferDecisionRecord struct {
+	Time          uint64           `json:"time"`
+	Decision      TransferDecision `json:"decision"`
+	HandleBridge  common.Address   `json:"handleBridge"`
+	RequestNonce  uint64           `json:"requestNonce"`
+	RequestTxHash common.Hash      `json:"requestTxHash"`
+	TokenType     uint8            `json:"tokenType"`
+	Token         common.Address   `json:"token"`
+	Amount        *big.Int         `json:"amount"`
+	Reason        string           `json:"reason"`
+}
+
+// transferJournal is an append-only log of the transfer decisions, kept for
+// auditing and to restore the approvals, rejections and pause across restarts.
+type transferJournal struct {
+	path   string
+	writer io.WriteCloser
+	mu     sync.Mutex
+}
+
+func newTransferJournal(path string) *transferJournal {
+	return &transferJournal{path: path}
+}
+
+// load reads all records of the journal and opens it for appending.
+func (journal *transferJournal) load(add func(*TransferDecisionRecord)) error {
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+
+	total, err := journal.read(add)
+	if err != nil {
+		return err
+	}
+	logger.Info("Loaded transfer decision journal", "decisions", total)
+
+	sink, err := os.OpenFile(journal.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	journal.writer = sink
+	return nil
+}
+
+// replay reads all records of the journal again.
+func (journal *transferJournal) replay(add func(*TransferDecisionRecord)) error {
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+
+	_, err := journal.read(add)
+	return err
+}
+
+// read passes the records of the journal file to add and returns their number.
+// A missing journal has no records.
+func (journal *transferJournal) read(add func(*TransferDecisionRecord)) (int, error) {
+	input, err := os.Open(journal.path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	defer input.Close()
+
+	stream := rlp.NewStream(input, 0)
+	total := 0
+	for {
+		record := new(TransferDecisionRecord)
+		if err := stream.Decode(record); err != nil {
+			if err != io.EOF {
+				logger.Error("Failed to decode the transfer decision journal", "path", journal.path, "err", err)
+			}
+			break
+		}
+		add(record)
+		total++
+	}
+	return total, nil
+}
+
+// insert appends the record to the journal.
+func (journal *transferJournal) insert(record *TransferDecisionRecord) error {
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+
+	if journal.writer == nil {
+		return errNoActiveJournal
+	}
+	return rlp.Encode(journal.writer, record)
+}
+
+// close flushes the journal to disk and closes it.
+func (journal *transferJournal) close() error {
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+
+	var err error
+	if journal.writer != nil {
+		err = journal.writer.Close()
+		journal.writer = nil
+	}
+	return err
+}
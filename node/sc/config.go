@@ -23,10 +23,12 @@ package sc
 import (
 	"crypto/ecdsa"
 	"fmt"
+	"math/big"
 	"os"
 	"os/user"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -91,13 +93,19 @@ type SCConfig struct {
 	ServiceChainConsensus string
 	AnchoringPeriod       uint64
 	SentChainTxsLimit     uint64
+	MerkleAnchoring       bool // anchors a Merkle root over every block of the anchoring period
 
 	ParentChainID                      uint64
+	ParentChains                       []ParentChainConfig // parent chains connected besides the one of ParentChainID.
+	TransferLimits                     []TransferLimit     // value transfers over a limit are held until an admin approves them.
+	TransferLimitPeriod                uint64              // period of the per-period transfer limits in seconds.
 	VTRecovery                         bool
 	VTRecoveryInterval                 uint64
 	Anchoring                          bool
 	ServiceChainParentOperatorGasLimit uint64
 	ServiceChainChildOperatorGasLimit  uint64
+	ServiceChainParentOperatorSigner   common.Address // the operator accounts of the node's wallets used instead of the keystores.
+	ServiceChainChildOperatorSigner    common.Address
 
 	// KAS
 	KASAnchor               bool
@@ -110,6 +118,97 @@ type SCConfig struct {
 	KASAnchorRequestTimeout time.Duration
 }
 
+// ParentChainConfig is the configuration of a parent chain which a sub-bridge
+// connects to besides the one of ParentChainID. Each has its own parent
+// operator account and anchoring schedule.
+type ParentChainConfig struct {
+	ChainID         uint64
+	AnchoringPeriod uint64 // anchoring to the chain is disabled if zero.
+}
+
+// ParseParentChains parses a comma-separated list of chainID[:anchoringPeriod] entries.
+func ParseParentChains(s string) ([]ParentChainConfig, error) {
+	var parents []ParentChainConfig
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		var parent ParentChainConfig
+		fields := strings.SplitN(entry, ":", 2)
+		chainID, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parent chain ID %q: %v", fields[0], err)
+		}
+		parent.ChainID = chainID
+		if len(fields) == 2 {
+			period, err := strconv.ParseUint(fields[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid anchoring period %q of parent chain %d: %v", fields[1], chainID, err)
+			}
+			parent.AnchoringPeriod = period
+		}
+		for _, p := range parents {
+			if p.ChainID == chainID {
+				return nil, fmt.Errorf("duplicated parent chain %d", chainID)
+			}
+		}
+		parents = append(parents, parent)
+	}
+	return parents, nil
+}
+
+// TransferLimit limits the value transfers of a token. A nil limit is unlimited.
+// The amount of an ERC721 transfer is one token.
+type TransferLimit struct {
+	Token       common.Address // the zero address for KLAY.
+	PerTransfer *big.Int
+	PerPeriod   *big.Int
+}
+
+// ParseTransferLimits parses comma-separated token:perTransfer:perPeriod entries.
+// The token is "klay" or a token address and an empty or zero limit is unlimited.
+func ParseTransferLimits(s string) ([]TransferLimit, error) {
+	var limits []TransferLimit
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		fields := strings.Split(entry, ":")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid transfer limit %q, expected token:perTransfer:perPeriod", entry)
+		}
+		var limit TransferLimit
+		if !strings.EqualFold(fields[0], "klay") {
+			if !common.IsHexAddress(fields[0]) {
+				return nil, fmt.Errorf("invalid token address %q", fields[0])
+			}
+			limit.Token = common.HexToAddress(fields[0])
+		}
+		for i, dst := range []**big.Int{&limit.PerTransfer, &limit.PerPeriod} {
+			field := fields[i+1]
+			if field == "" {
+				continue
+			}
+			v, ok := new(big.Int).SetString(field, 10)
+			if !ok || v.Sign() < 0 {
+				return nil, fmt.Errorf("invalid transfer limit %q of token %s", field, fields[0])
+			}
+			if v.Sign() > 0 {
+				*dst = v
+			}
+		}
+		for _, l := range limits {
+			if l.Token == limit.Token {
+				return nil, fmt.Errorf("duplicated transfer limit of token %s", fields[0])
+			}
+		}
+		limits = append(limits, limit)
+	}
+	return limits, nil
+}
+
 // NodeName returns the devp2p node identifier.
 func (c *SCConfig) NodeName() string {
 	name := c.name()
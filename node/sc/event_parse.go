@@ -2,6 +2,7 @@ package sc
 
 import (
 	"bytes"
+	"math/big"
 	"strings"
 
 	"github.com/klaytn/klaytn/accounts/abi"
@@ -20,18 +21,37 @@ var RequestValueTransferEncodeABIs = map[uint]string{
 			"name": "packedURI",
 			"type": "event"
 		}]`,
+	3: `[{
+			"anonymous":false,
+			"inputs": [{
+				"name": "amount",
+				"type": "uint256"
+			}, {
+				"name": "uri",
+				"type": "string"
+			}],
+			"name": "packedERC1155",
+			"type": "event"
+		}]`,
+}
+
+// encodedDataNames are the names of the events describing the encoded data in
+// RequestValueTransferEncodeABIs.
+var encodedDataNames = map[uint8]string{
+	2: "packedURI",
+	3: "packedERC1155",
 }
 
 func UnpackEncodedData(ver uint8, packed []byte) map[string]interface{} {
 	switch ver {
-	case 2:
+	case 2, 3:
 		encodedEvent := map[string]interface{}{}
-		abi, err := abi.JSON(strings.NewReader(RequestValueTransferEncodeABIs[2]))
+		abi, err := abi.JSON(strings.NewReader(RequestValueTransferEncodeABIs[uint(ver)]))
 		if err != nil {
 			logger.Error("Failed to ABI setup", "err", err)
 			return nil
 		}
-		if err := abi.UnpackIntoMap(encodedEvent, "packedURI", packed); err != nil {
+		if err := abi.UnpackIntoMap(encodedEvent, encodedDataNames[ver], packed); err != nil {
 			logger.Error("Failed to unpack the values", "err", err)
 			return nil
 		}
@@ -57,3 +77,18 @@ func GetURI(ev IRequestValueTransferEvent) string {
 	}
 	return ""
 }
+
+// GetERC1155Amount returns the amount of tokens an ERC1155 transfer request
+// carries in its encoded data, or nil if there is none.
+func GetERC1155Amount(ev IRequestValueTransferEvent) *big.Int {
+	switch evType := ev.(type) {
+	case RequestValueTransferEncodedEvent:
+		decoded := UnpackEncodedData(evType.EncodingVer, evType.EncodedData)
+		amount, ok := decoded["amount"].(*big.Int)
+		if !ok {
+			return nil
+		}
+		return amount
+	}
+	return nil
+}
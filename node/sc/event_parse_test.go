@@ -0,0 +1,59 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package sc
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/klaytn/klaytn/accounts/abi"
+	"github.com/klaytn/klaytn/contracts/bridge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// packERC1155Data encodes the amount and URI of an ERC1155 transfer request as
+// the bridge contract does, with the URI as returned by the token contract.
+func packERC1155Data(t *testing.T, amount *big.Int, uri string) []byte {
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	rawURI, err := abi.Arguments{{Type: stringType}}.Pack(uri)
+	require.NoError(t, err)
+
+	parsed, err := abi.JSON(strings.NewReader(RequestValueTransferEncodeABIs[3]))
+	require.NoError(t, err)
+	packed, err := parsed.Events["packedERC1155"].Inputs.Pack(amount, string(rawURI))
+	require.NoError(t, err)
+	return packed
+}
+
+func TestUnpackEncodedData_ERC1155(t *testing.T) {
+	ev := RequestValueTransferEncodedEvent{&bridge.BridgeRequestValueTransferEncoded{
+		TokenType:      ERC1155,
+		ValueOrTokenId: big.NewInt(7),
+		EncodingVer:    3,
+		EncodedData:    packERC1155Data(t, big.NewInt(300), "ipfs://item/7"),
+	}}
+	assert.Equal(t, big.NewInt(300), GetERC1155Amount(ev))
+	assert.Equal(t, "ipfs://item/7", GetURI(ev))
+
+	// An ERC721 request carries no amount.
+	ev.EncodingVer, ev.EncodedData = 2, nil
+	assert.Nil(t, GetERC1155Amount(ev))
+	assert.Nil(t, GetERC1155Amount(RequestValueTransferEvent{&bridge.BridgeRequestValueTransfer{}}))
+}
@@ -0,0 +1,134 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package sc
+
+import (
+	bridgecontract "github.com/klaytn/klaytn/contracts/bridge"
+)
+
+// RequestMessageEvent is a cross-chain message requested on a Bridge contract.
+type RequestMessageEvent struct {
+	*bridgecontract.BridgeRequestMessage
+}
+
+func (ev RequestMessageEvent) Nonce() uint64 {
+	return ev.MessageNonce
+}
+
+// HandleMessageEvent is a cross-chain message executed by a Bridge contract.
+type HandleMessageEvent struct {
+	*bridgecontract.BridgeHandleMessage
+}
+
+// AddRequestMessageEvents adds the message requests of the counterpart bridge
+// to the pending list of the bridge.
+func (bi *BridgeInfo) AddRequestMessageEvents(evs []RequestMessageEvent) {
+	for _, ev := range evs {
+		if bi.pendingMessages.Len() > maxPendingNonceDiff {
+			flatten := bi.pendingMessages.Flatten()
+			maxNonce := flatten[len(flatten)-1].Nonce()
+			if ev.Nonce() >= maxNonce || bi.pendingMessages.Exist(ev.Nonce()) {
+				continue
+			}
+			bi.pendingMessages.Remove(maxNonce)
+			logger.Trace("List is full but add requestMessage", "newNonce", ev.Nonce(), "removedNonce", maxNonce)
+		}
+
+		bi.SetRequestMessageNonceFromCounterpart(ev.Nonce() + 1)
+		bi.pendingMessages.Put(ev)
+	}
+	logger.Trace("added pending messages to the bridge info", "len(pendingMessages)", bi.pendingMessages.Len())
+
+	select {
+	case bi.newEvent <- struct{}{}:
+	default:
+	}
+}
+
+// GetPendingMessages pops the pending messages in the order of their nonces.
+func (bi *BridgeInfo) GetPendingMessages() []RequestMessageEvent {
+	ready := bi.pendingMessages.Pop(maxPendingNonceDiff / 2)
+	readyEvent := make([]RequestMessageEvent, len(ready))
+	for i, item := range ready {
+		readyEvent[i] = item.(RequestMessageEvent)
+	}
+	return readyEvent
+}
+
+// processingPendingMessages relays pending messages of the counterpart bridge to the bridge.
+func (bi *BridgeInfo) processingPendingMessages() {
+	if bi.pendingMessages.Len() == 0 {
+		return
+	}
+
+	readyEvent := bi.GetPendingMessages()
+	for idx, ev := range readyEvent {
+		if ev.Nonce() < bi.handleMessageNonce {
+			logger.Trace("handled messages can be ignored", "messageNonce", ev.Nonce(), "handleMessageNonce", bi.handleMessageNonce)
+			continue
+		}
+
+		if err := bi.handleRequestMessageEvent(ev); err != nil {
+			bi.AddRequestMessageEvents(readyEvent[idx:])
+			logger.Error("Failed handle request message event", "err", err, "len(RePutEvent)", len(readyEvent[idx:]))
+			return
+		}
+	}
+}
+
+// handleRequestMessageEvent sends a transaction voting for the given message to the bridge.
+func (bi *BridgeInfo) handleRequestMessageEvent(ev RequestMessageEvent) error {
+	// An older bridge contract would take the call for its fallback, which
+	// requests a KLAY transfer, so its version is checked first.
+	if err := bi.checkMessageSupport(); err != nil {
+		return err
+	}
+
+	bridgeAcc := bi.account
+
+	bridgeAcc.Lock()
+	defer bridgeAcc.UnLock()
+
+	auth := bridgeAcc.GenerateTransactOpts()
+
+	txHash := ev.Raw.TxHash
+	handleTx, err := bi.bridge.HandleMessage(auth, txHash, ev.From, ev.Target, ev.MessageNonce, ev.Raw.BlockNumber, ev.Data)
+	if err != nil {
+		return err
+	}
+	logger.Trace("Bridge contract transaction is created", "contractCall", "handleMessage",
+		"nonce", ev.MessageNonce, "txHash", handleTx.Hash().String(), "from", ev.From, "target", ev.Target)
+
+	bridgeAcc.IncNonce()
+
+	bi.bridgeDB.WriteHandleTxHashFromRequestTxHash(txHash, handleTx.Hash())
+	return nil
+}
+
+// SetRequestMessageNonceFromCounterpart sets the message nonce requested from the counterpart bridge.
+func (bi *BridgeInfo) SetRequestMessageNonceFromCounterpart(nonce uint64) {
+	if bi.requestMessageNonceFromCounterPart < nonce {
+		bi.requestMessageNonceFromCounterPart = nonce
+	}
+}
+
+// MarkHandledMessage marks the message of the given nonce and the previous ones as executed.
+func (bi *BridgeInfo) MarkHandledMessage(nonce uint64) {
+	if bi.handleMessageNonce <= nonce {
+		bi.handleMessageNonce = nonce + 1
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package sc
+
+import (
+	"github.com/klaytn/klaytn/accounts/abi/bind"
+	"github.com/pkg/errors"
+)
+
+// recoverMessages resends the message requests which are not executed by the
+// counterpart bridge for a recovery interval. It is skipped if one of the
+// bridges has no message module.
+func (vtr *valueTransferRecovery) recoverMessages() error {
+	if vtr.cBridgeInfo == nil {
+		return errors.New("child chain bridge is nil")
+	}
+	if vtr.pBridgeInfo == nil {
+		return errors.New("parent chain bridge is nil")
+	}
+	if vtr.cBridgeInfo.checkMessageSupport() != nil || vtr.pBridgeInfo.checkMessageSupport() != nil {
+		return nil
+	}
+
+	var err error
+	vtr.child2parentMsgHint, err = updateMessageRecoveryHintFromTo(vtr.child2parentMsgHint, vtr.cBridgeInfo, vtr.pBridgeInfo)
+	if err != nil {
+		return err
+	}
+	vtr.parent2childMsgHint, err = updateMessageRecoveryHintFromTo(vtr.parent2childMsgHint, vtr.pBridgeInfo, vtr.cBridgeInfo)
+	if err != nil {
+		return err
+	}
+
+	// Update the hint for the initial status.
+	if !vtr.isRunning {
+		vtr.child2parentMsgHint.prevHandleNonce = vtr.child2parentMsgHint.handleNonce
+		vtr.parent2childMsgHint.prevHandleNonce = vtr.parent2childMsgHint.handleNonce
+		vtr.child2parentMsgHint.candidate = true
+		vtr.parent2childMsgHint.candidate = true
+	}
+
+	childMessages, err := retrievePendingMessagesFrom(vtr.child2parentMsgHint, vtr.cBridgeInfo, vtr.pBridgeInfo)
+	if err != nil {
+		return err
+	}
+	parentMessages, err := retrievePendingMessagesFrom(vtr.parent2childMsgHint, vtr.pBridgeInfo, vtr.cBridgeInfo)
+	if err != nil {
+		return err
+	}
+
+	if len(childMessages) > 0 {
+		logger.Warn("Message Recovery : Child -> Parent Chain", "cBridge", vtr.cBridgeInfo.address.String(), "messages", len(childMessages))
+		vtr.pBridgeInfo.AddRequestMessageEvents(childMessages)
+	}
+	if len(parentMessages) > 0 {
+		logger.Warn("Message Recovery : Parent -> Child Chain", "pBridge", vtr.pBridgeInfo.address.String(), "messages", len(parentMessages))
+		vtr.cBridgeInfo.AddRequestMessageEvents(parentMessages)
+	}
+	return nil
+}
+
+// updateMessageRecoveryHintFromTo updates a hint for the one-way messages.
+func updateMessageRecoveryHintFromTo(prevHint *valueTransferHint, from, to *BridgeInfo) (*valueTransferHint, error) {
+	var err error
+	var hint valueTransferHint
+
+	hint.blockNumber, err = to.bridge.MessageRecoveryBlockNumber(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	hint.requestNonce, err = from.bridge.RequestMessageNonce(nil)
+	if err != nil {
+		return nil, err
+	}
+	to.SetRequestMessageNonceFromCounterpart(hint.requestNonce)
+
+	hint.handleNonce, err = to.bridge.HandleMessageNonce(nil)
+	if err != nil {
+		return nil, err
+	}
+	if hint.handleNonce > 0 {
+		to.MarkHandledMessage(hint.handleNonce - 1)
+	}
+
+	if prevHint != nil {
+		hint.prevHandleNonce = prevHint.handleNonce
+		hint.candidate = prevHint.candidate
+	}
+
+	logger.Trace("updateMessageRecoveryHintFromTo finish", "rnonce", hint.requestNonce, "hnonce", hint.handleNonce, "phnonce", hint.prevHandleNonce, "cand", hint.candidate)
+	return &hint, nil
+}
+
+// retrievePendingMessagesFrom retrieves the message requests of the from bridge
+// which are not executed by the to bridge, by using the hint provided.
+func retrievePendingMessagesFrom(hint *valueTransferHint, from, to *BridgeInfo) ([]RequestMessageEvent, error) {
+	if hint.requestNonce == hint.handleNonce {
+		return nil, nil
+	}
+	if !checkRecoveryCondition(hint) {
+		return nil, nil
+	}
+
+	var pendingMessages []RequestMessageEvent
+
+	curBlkNum, err := from.GetCurrentBlockNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	startBlkNum := hint.blockNumber
+	endBlkNum := startBlkNum + filterLogsStride
+
+pendingTxLoop:
+	for startBlkNum <= curBlkNum {
+		if endBlkNum > curBlkNum {
+			endBlkNum = curBlkNum
+		}
+		opts := &bind.FilterOpts{Start: startBlkNum, End: &endBlkNum}
+		reqMsgIt, err := from.bridge.FilterRequestMessage(opts, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for reqMsgIt.Next() {
+			ev := reqMsgIt.Event
+			if ev.MessageNonce < hint.handleNonce {
+				continue
+			}
+			// Check if the message is already executed in target bridge contract
+			if handled, err := to.bridge.HandledMessageTx(nil, ev.Raw.TxHash); err == nil && handled {
+				logger.Trace("skip handled message", "nonce", ev.MessageNonce)
+				continue
+			}
+			logger.Trace("filtered pending message", "messageNonce", ev.MessageNonce, "handleMessageNonce", hint.handleNonce)
+			pendingMessages = append(pendingMessages, RequestMessageEvent{ev})
+			if len(pendingMessages) >= maxPendingTxs {
+				reqMsgIt.Close()
+				break pendingTxLoop
+			}
+		}
+		startBlkNum = endBlkNum + 1
+		endBlkNum = startBlkNum + filterLogsStride
+		reqMsgIt.Close()
+	}
+
+	if len(pendingMessages) > 0 {
+		logger.Info("retrieved pending messages", "bridge", from.address.String(),
+			"len(pendingMessages)", len(pendingMessages), "1st nonce", pendingMessages[0].Nonce())
+	}
+	return pendingMessages, nil
+}
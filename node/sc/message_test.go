@@ -0,0 +1,125 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package sc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/accounts/abi/bind/backends"
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/contracts/bridge"
+	"github.com/klaytn/klaytn/node/sc/bridgepool"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRequestMessageEvent(nonce uint64) RequestMessageEvent {
+	return RequestMessageEvent{&bridge.BridgeRequestMessage{
+		From:         common.HexToAddress("0x1"),
+		Target:       common.HexToAddress("0x2"),
+		MessageNonce: nonce,
+		Data:         []byte{0xca, 0xfe},
+	}}
+}
+
+// TestAddRequestMessageEvents checks that pending messages are popped in the
+// order of their nonces and the requested nonce follows the latest message.
+func TestAddRequestMessageEvents(t *testing.T) {
+	bi := &BridgeInfo{
+		pendingMessages: bridgepool.NewItemSortedMap(bridgepool.UnlimitedItemSortedMap),
+		newEvent:        make(chan struct{}),
+	}
+
+	bi.AddRequestMessageEvents([]RequestMessageEvent{
+		newTestRequestMessageEvent(2),
+		newTestRequestMessageEvent(0),
+		newTestRequestMessageEvent(1),
+	})
+	assert.Equal(t, uint64(3), bi.requestMessageNonceFromCounterPart)
+	assert.Equal(t, 3, bi.pendingMessages.Len())
+
+	msgs := bi.GetPendingMessages()
+	assert.Equal(t, 3, len(msgs))
+	for i, msg := range msgs {
+		assert.Equal(t, uint64(i), msg.Nonce())
+	}
+	assert.Equal(t, 0, bi.pendingMessages.Len())
+}
+
+// TestMarkHandledMessage checks that the handle message nonce never goes back.
+func TestMarkHandledMessage(t *testing.T) {
+	bi := &BridgeInfo{}
+
+	bi.MarkHandledMessage(4)
+	assert.Equal(t, uint64(5), bi.handleMessageNonce)
+
+	bi.MarkHandledMessage(2)
+	assert.Equal(t, uint64(5), bi.handleMessageNonce)
+}
+
+// TestHandleMessageOnOldBridge checks that messages are not relayed to a bridge
+// contract without the message module, and that they stay pending.
+func TestHandleMessageOnOldBridge(t *testing.T) {
+	tempDir := t.TempDir()
+
+	config := &SCConfig{DataDir: tempDir}
+	bacc, _ := NewBridgeAccounts(nil, config.DataDir, database.NewDBManager(&database.DBConfig{DBType: database.MemoryDB}), DefaultBridgeTxGasLimit, DefaultBridgeTxGasLimit)
+	bacc.pAccount.chainID = big.NewInt(0)
+	bacc.cAccount.chainID = big.NewInt(0)
+
+	alloc := blockchain.GenesisAlloc{
+		bacc.pAccount.address: {Balance: big.NewInt(params.KLAY)},
+		bacc.cAccount.address: {Balance: big.NewInt(params.KLAY)},
+	}
+	sim := backends.NewSimulatedBackend(alloc)
+	defer sim.Close()
+
+	sc := &SubBridge{
+		chainDB:        database.NewDBManager(&database.DBConfig{DBType: database.MemoryDB}),
+		config:         config,
+		peers:          newBridgePeerSet(),
+		bridgeAccounts: bacc,
+		localBackend:   sim,
+		remoteBackend:  sim,
+	}
+	bridgeManager, err := NewBridgeManager(sc)
+	assert.NoError(t, err)
+
+	addr, err := bridgeManager.DeployBridgeTest(sim, 10000, false)
+	assert.NoError(t, err)
+	sim.Commit()
+	bridgeInfo, _ := bridgeManager.GetBridgeInfo(addr)
+
+	version, err := bridgeInfo.bridge.VERSION(nil)
+	assert.NoError(t, err)
+	assert.Less(t, version, uint64(messageBridgeVersion))
+
+	nonce := bridgeInfo.account.GetNonce()
+	ev := newTestRequestMessageEvent(0)
+	ev.Raw = types.Log{Address: addr}
+	assert.ErrorIs(t, bridgeInfo.handleRequestMessageEvent(ev), ErrMessageNotSupported)
+	assert.Equal(t, nonce, bridgeInfo.account.GetNonce())
+
+	// The recovery skips the bridges without the message module.
+	vtr := NewValueTransferRecovery(config, bridgeInfo, bridgeInfo)
+	assert.NoError(t, vtr.recoverMessages())
+	assert.Equal(t, uint64(0), vtr.child2parentMsgHint.requestNonce)
+}
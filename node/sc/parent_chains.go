@@ -0,0 +1,102 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package sc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"path"
+	"time"
+
+	"github.com/klaytn/klaytn/node/sc/bridgepool"
+)
+
+var ErrUnknownParentChain = errors.New("unknown parent chain")
+
+// parentChain is a parent chain which the sub-bridge connects to besides the
+// one of ParentChainID. It has its own operator account, transaction pool,
+// handler and RPC connection through its peers.
+type parentChain struct {
+	config  ParentChainConfig
+	account *accountInfo
+	txPool  *bridgepool.BridgeTxPool
+	handler *SubBridgeHandler
+	backend *RemoteBackend
+	rpcConn net.Conn
+}
+
+// addParentChain sets up an additional parent chain of the sub-bridge.
+func (sb *SubBridge) addParentChain(config ParentChainConfig) error {
+	if config.ChainID == sb.config.ParentChainID {
+		return fmt.Errorf("parent chain %d is already the primary parent chain", config.ChainID)
+	}
+
+	account, err := sb.bridgeAccounts.AddParentChainAccount(sb.accountManager, sb.config.DataDir, config.ChainID, sb.config.ServiceChainParentOperatorGasLimit)
+	if err != nil {
+		return err
+	}
+
+	pc := &parentChain{
+		config:  config,
+		account: account,
+		txPool: bridgepool.NewBridgeTxPool(bridgepool.BridgeTxPoolConfig{
+			ParentChainID: new(big.Int).SetUint64(config.ChainID),
+			Journal:       path.Join(sb.config.DataDir, fmt.Sprintf("bridge_transactions_%d.rlp", config.ChainID)),
+			Rejournal:     time.Hour,
+			GlobalQueue:   8192,
+		}),
+	}
+	pc.handler = newParentChainHandler(sb, pc)
+	sb.parentChains[config.ChainID] = pc
+
+	logger.Info("Added a parent chain", "chainID", config.ChainID, "operator", account.address.String(), "anchoringPeriod", config.AnchoringPeriod)
+	return nil
+}
+
+func (pc *parentChain) setRPCConn(conn net.Conn) {
+	pc.rpcConn = conn
+
+	go readRPCPipe(conn, func(data []byte) {
+		pc.handler.sendRPCData(data)
+	})
+}
+
+// handlerOf returns the handler of the parent chain of the given peer.
+func (sb *SubBridge) handlerOf(p BridgePeer) *SubBridgeHandler {
+	if len(sb.parentChains) == 0 {
+		return sb.handler
+	}
+	if pc, ok := sb.parentChains[p.GetChainID().Uint64()]; ok {
+		return pc.handler
+	}
+	return sb.handler
+}
+
+// parentChainOf returns the backend and the operator account of the given
+// parent chain. A zero chain ID stands for the parent chain of ParentChainID.
+func (sb *SubBridge) parentChainOf(chainID uint64) (Backend, *accountInfo, error) {
+	if chainID == 0 || chainID == sb.config.ParentChainID {
+		return sb.remoteBackend, sb.bridgeAccounts.pAccount, nil
+	}
+	pc, ok := sb.parentChains[chainID]
+	if !ok {
+		return nil, nil, ErrUnknownParentChain
+	}
+	return pc.backend, pc.account, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package sc
+
+import (
+	"math/big"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/node"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseParentChains(t *testing.T) {
+	parents, err := ParseParentChains("2000:10, 3000,,")
+	assert.NoError(t, err)
+	assert.Equal(t, []ParentChainConfig{{ChainID: 2000, AnchoringPeriod: 10}, {ChainID: 3000}}, parents)
+
+	parents, err = ParseParentChains("")
+	assert.NoError(t, err)
+	assert.Empty(t, parents)
+
+	_, err = ParseParentChains("2000,2000:5")
+	assert.Error(t, err)
+	_, err = ParseParentChains("abc")
+	assert.Error(t, err)
+	_, err = ParseParentChains("2000:x")
+	assert.Error(t, err)
+}
+
+// TestBridgeJournal_ParentChainID checks that the parent chain ID survives an RLP
+// round trip and that journals of the primary parent chain keep the old format.
+func TestBridgeJournal_ParentChainID(t *testing.T) {
+	addr := common.BytesToAddress([]byte("bridge"))
+
+	journal := &BridgeJournal{"alias", addr, addr, true, 2000, false}
+	data, err := rlp.EncodeToBytes(journal)
+	assert.NoError(t, err)
+
+	var decoded BridgeJournal
+	assert.NoError(t, rlp.DecodeBytes(data, &decoded))
+	assert.Equal(t, uint64(2000), decoded.ParentChainID)
+	assert.Equal(t, "alias", decoded.BridgeAlias)
+
+	journal.ParentChainID = 0
+	data, err = rlp.EncodeToBytes(journal)
+	assert.NoError(t, err)
+
+	var items []rlp.RawValue
+	assert.NoError(t, rlp.DecodeBytes(data, &items))
+	assert.Equal(t, 4, len(items))
+
+	decoded = BridgeJournal{}
+	assert.NoError(t, rlp.DecodeBytes(data, &decoded))
+	assert.Equal(t, uint64(0), decoded.ParentChainID)
+}
+
+// TestSubBridge_parentChains checks that peers and accounts are routed to the
+// parent chain they belong to.
+func TestSubBridge_parentChains(t *testing.T) {
+	tempDir, err := os.MkdirTemp(os.TempDir(), "klaytn-test-sb-parents-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	config := &SCConfig{
+		NetworkId:     testNetVersion,
+		DataDir:       tempDir,
+		ParentChainID: 1000,
+		ParentChains:  []ParentChainConfig{{ChainID: 2000, AnchoringPeriod: 10}},
+	}
+	sCtx := node.NewServiceContext(&node.DefaultConfig, map[reflect.Type]node.Service{}, &event.TypeMux{}, &accounts.Manager{})
+	sBridge, err := NewSubBridge(sCtx, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sBridge.chainDB.Close()
+	defer sBridge.bridgeTxPool.Stop()
+
+	pc, ok := sBridge.parentChains[2000]
+	if !ok {
+		t.Fatal("parent chain 2000 is not added")
+	}
+	defer pc.txPool.Stop()
+	assert.True(t, pc.handler.anchoringEnabled())
+
+	acc, ok := sBridge.bridgeAccounts.GetParentChainAccount(2000)
+	assert.True(t, ok)
+	assert.Equal(t, pc.account, acc)
+	assert.NotEqual(t, sBridge.bridgeAccounts.pAccount.address, acc.address)
+	assert.Equal(t, uint64(2000), acc.chainID.Uint64())
+
+	_, primary, err := sBridge.parentChainOf(0)
+	assert.NoError(t, err)
+	assert.Equal(t, sBridge.bridgeAccounts.pAccount, primary)
+	_, extra, err := sBridge.parentChainOf(2000)
+	assert.NoError(t, err)
+	assert.Equal(t, acc, extra)
+	_, _, err = sBridge.parentChainOf(3000)
+	assert.Equal(t, ErrUnknownParentChain, err)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	primaryPeer := NewMockBridgePeer(mockCtrl)
+	primaryPeer.EXPECT().GetID().Return("primary").AnyTimes()
+	primaryPeer.EXPECT().GetChainID().Return(big.NewInt(1000)).AnyTimes()
+	primaryPeer.EXPECT().Close().Return().AnyTimes()
+
+	extraPeer := NewMockBridgePeer(mockCtrl)
+	extraPeer.EXPECT().GetID().Return("extra").AnyTimes()
+	extraPeer.EXPECT().GetChainID().Return(big.NewInt(2000)).AnyTimes()
+	extraPeer.EXPECT().Close().Return().AnyTimes()
+
+	assert.NoError(t, sBridge.peers.Register(primaryPeer))
+	assert.NoError(t, sBridge.peers.Register(extraPeer))
+
+	assert.Equal(t, sBridge.handler, sBridge.handlerOf(primaryPeer))
+	assert.Equal(t, pc.handler, sBridge.handlerOf(extraPeer))
+
+	assert.Equal(t, []BridgePeer{primaryPeer}, sBridge.handler.parentPeers())
+	assert.Equal(t, []BridgePeer{extraPeer}, pc.handler.parentPeers())
+}
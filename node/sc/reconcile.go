@@ -0,0 +1,438 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package sc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/klaytn/klaytn"
+	"github.com/klaytn/klaytn/accounts/abi/bind"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+)
+
+var ErrInvalidReconcileRange = errors.New("invalid block range")
+
+// ReconcileRange is a block range to scan the bridge events in. A zero ToBlock
+// stands for the current block.
+type ReconcileRange struct {
+	FromBlock uint64 `json:"fromBlock"`
+	ToBlock   uint64 `json:"toBlock"`
+}
+
+// ValueTransferRecord is a RequestValueTransfer or HandleValueTransfer event
+// found during a reconciliation.
+type ValueTransferRecord struct {
+	Nonce          uint64         `json:"nonce"`
+	TokenType      uint8          `json:"tokenType"`
+	From           common.Address `json:"from"`
+	To             common.Address `json:"to"`
+	TokenAddress   common.Address `json:"tokenAddress"`
+	ValueOrTokenId *big.Int       `json:"valueOrTokenId"`
+	Amount         *big.Int       `json:"amount,omitempty"` // the amount of an ERC1155 transfer, nil if it is unknown.
+	BlockNumber    uint64         `json:"blockNumber"`
+	TxHash         common.Hash    `json:"txHash"`
+}
+
+// ValueTransferMismatch is a request whose handle does not transfer the same token
+// or value to the same receiver.
+type ValueTransferMismatch struct {
+	Request *ValueTransferRecord `json:"request"`
+	Handle  *ValueTransferRecord `json:"handle"`
+	Reasons []string             `json:"reasons"`
+}
+
+// DoubleHandle is a request nonce handled more than once.
+type DoubleHandle struct {
+	Nonce   uint64                 `json:"nonce"`
+	Handles []*ValueTransferRecord `json:"handles"`
+}
+
+// TokenTotal sums up the transfers of a token. Amount is only given for KLAY, ERC20
+// and ERC1155 tokens, summed over all token IDs for the latter; for ERC721 tokens
+// the transfers are counted.
+type TokenTotal struct {
+	TokenType        uint8          `json:"tokenType"`
+	Token            common.Address `json:"token"`
+	CounterpartToken common.Address `json:"counterpartToken"`
+	Count            uint64         `json:"count"`
+	Amount           *big.Int       `json:"amount,omitempty"`
+}
+
+// ValueTransferReconciliation is the reconciliation report of the value transfers
+// requested on one bridge and handled on its counterpart bridge.
+type ValueTransferReconciliation struct {
+	RequestBridge common.Address `json:"requestBridge"`
+	HandleBridge  common.Address `json:"handleBridge"`
+	RequestRange  ReconcileRange `json:"requestRange"`
+	HandleRange   ReconcileRange `json:"handleRange"`
+
+	Requests int `json:"requests"`
+	Handles  int `json:"handles"`
+	Matched  int `json:"matched"`
+
+	Unhandled        []*ValueTransferRecord   `json:"unhandled"`
+	DoubleHandled    []*DoubleHandle          `json:"doubleHandled"`
+	Mismatches       []*ValueTransferMismatch `json:"mismatches"`
+	UnmatchedHandles []*ValueTransferRecord   `json:"unmatchedHandles"` // handles whose request is out of the request range.
+
+	RequestedTotals []*TokenTotal `json:"requestedTotals"` // locked or burned on the requesting chain.
+	HandledTotals   []*TokenTotal `json:"handledTotals"`   // unlocked or minted on the handling chain.
+}
+
+// Consistent returns true if the report has no unhandled, double handled or
+// mismatched value transfers.
+func (r *ValueTransferReconciliation) Consistent() bool {
+	return len(r.Unhandled) == 0 && len(r.DoubleHandled) == 0 && len(r.Mismatches) == 0
+}
+
+// BridgeReconciliation is the reconciliation report of a bridge pair for both directions.
+type BridgeReconciliation struct {
+	ChildToParent *ValueTransferReconciliation `json:"childToParent"`
+	ParentToChild *ValueTransferReconciliation `json:"parentToChild"`
+	Consistent    bool                         `json:"consistent"`
+}
+
+// ReconcileValueTransfers scans the value transfer events of the given bridge pair
+// in the given block ranges of the child and parent chains, pairs the requests
+// with their handles by nonce and reports the inconsistencies.
+func (bm *BridgeManager) ReconcileValueTransfers(cBridgeAddr, pBridgeAddr common.Address, childRange, parentRange ReconcileRange) (*BridgeReconciliation, error) {
+	cBi, ok := bm.GetBridgeInfo(cBridgeAddr)
+	if !ok || !cBi.onChildChain {
+		return nil, ErrInvalidBridgePair
+	}
+	pBi, ok := bm.GetBridgeInfo(pBridgeAddr)
+	if !ok || pBi.onChildChain {
+		return nil, ErrInvalidBridgePair
+	}
+
+	var err error
+	if childRange, err = resolveReconcileRange(cBi, childRange); err != nil {
+		return nil, err
+	}
+	if parentRange, err = resolveReconcileRange(pBi, parentRange); err != nil {
+		return nil, err
+	}
+
+	child2parent, err := reconcileValueTransfersFromTo(cBi, pBi, childRange, parentRange)
+	if err != nil {
+		return nil, err
+	}
+	parent2child, err := reconcileValueTransfersFromTo(pBi, cBi, parentRange, childRange)
+	if err != nil {
+		return nil, err
+	}
+	return &BridgeReconciliation{
+		ChildToParent: child2parent,
+		ParentToChild: parent2child,
+		Consistent:    child2parent.Consistent() && parent2child.Consistent(),
+	}, nil
+}
+
+// resolveReconcileRange replaces a zero ToBlock with the current block number of the bridge's chain.
+func resolveReconcileRange(bi *BridgeInfo, r ReconcileRange) (ReconcileRange, error) {
+	if r.ToBlock == 0 {
+		curBlkNum, err := bi.GetCurrentBlockNumber()
+		if err != nil {
+			return r, err
+		}
+		r.ToBlock = curBlkNum
+	}
+	if r.FromBlock > r.ToBlock {
+		return r, fmt.Errorf("%w: %d > %d", ErrInvalidReconcileRange, r.FromBlock, r.ToBlock)
+	}
+	return r, nil
+}
+
+// reconcileValueTransfersFromTo reconciles the value transfers requested on the
+// from bridge with the ones handled on the to bridge.
+func reconcileValueTransfersFromTo(from, to *BridgeInfo, requestRange, handleRange ReconcileRange) (*ValueTransferReconciliation, error) {
+	requests, err := filterValueTransferRequests(from, requestRange)
+	if err != nil {
+		return nil, err
+	}
+	handles, err := filterValueTransferHandles(to, handleRange)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ValueTransferReconciliation{
+		RequestBridge:    from.address,
+		HandleBridge:     to.address,
+		RequestRange:     requestRange,
+		HandleRange:      handleRange,
+		Requests:         len(requests),
+		Handles:          len(handles),
+		Unhandled:        []*ValueTransferRecord{},
+		DoubleHandled:    []*DoubleHandle{},
+		Mismatches:       []*ValueTransferMismatch{},
+		UnmatchedHandles: []*ValueTransferRecord{},
+	}
+
+	handlesByNonce := make(map[uint64][]*ValueTransferRecord)
+	for _, handle := range handles {
+		handlesByNonce[handle.Nonce] = append(handlesByNonce[handle.Nonce], handle)
+	}
+	requested := make(map[uint64]bool, len(requests))
+	for _, request := range requests {
+		requested[request.Nonce] = true
+
+		matched := handlesByNonce[request.Nonce]
+		switch len(matched) {
+		case 0:
+			report.Unhandled = append(report.Unhandled, request)
+			continue
+		case 1:
+		default:
+			report.DoubleHandled = append(report.DoubleHandled, &DoubleHandle{request.Nonce, matched})
+		}
+		if reasons := compareValueTransfer(from, request, matched[0]); len(reasons) > 0 {
+			report.Mismatches = append(report.Mismatches, &ValueTransferMismatch{request, matched[0], reasons})
+			continue
+		}
+		report.Matched++
+	}
+	for _, handle := range handles {
+		if !requested[handle.Nonce] {
+			report.UnmatchedHandles = append(report.UnmatchedHandles, handle)
+		}
+	}
+	for nonce, matched := range handlesByNonce {
+		if len(matched) > 1 && !requested[nonce] {
+			report.DoubleHandled = append(report.DoubleHandled, &DoubleHandle{nonce, matched})
+		}
+	}
+	sort.Slice(report.DoubleHandled, func(i, j int) bool {
+		return report.DoubleHandled[i].Nonce < report.DoubleHandled[j].Nonce
+	})
+
+	report.RequestedTotals = sumTokenTotals(requests, from.GetCounterPartToken)
+	report.HandledTotals = sumTokenTotals(handles, to.GetCounterPartToken)
+	return report, nil
+}
+
+// compareValueTransfer returns the reasons why the handle does not match the request.
+func compareValueTransfer(from *BridgeInfo, request, handle *ValueTransferRecord) []string {
+	var reasons []string
+	if request.TokenType != handle.TokenType {
+		reasons = append(reasons, fmt.Sprintf("token type %d != %d", request.TokenType, handle.TokenType))
+	}
+	if request.From != handle.From {
+		reasons = append(reasons, fmt.Sprintf("sender %s != %s", request.From.String(), handle.From.String()))
+	}
+	if request.To != handle.To {
+		reasons = append(reasons, fmt.Sprintf("receiver %s != %s", request.To.String(), handle.To.String()))
+	}
+	if request.ValueOrTokenId.Cmp(handle.ValueOrTokenId) != 0 {
+		reasons = append(reasons, fmt.Sprintf("value or token ID %s != %s", request.ValueOrTokenId.String(), handle.ValueOrTokenId.String()))
+	}
+	if request.TokenType == ERC1155 {
+		switch {
+		case request.Amount == nil:
+			reasons = append(reasons, "no amount in the ERC1155 request")
+		case handle.Amount == nil:
+			reasons = append(reasons, "no amount in the ERC1155 handle")
+		case request.Amount.Cmp(handle.Amount) != 0:
+			reasons = append(reasons, fmt.Sprintf("amount %s != %s", request.Amount.String(), handle.Amount.String()))
+		}
+	}
+	// The token pair is only checked when it is registered on the bridge.
+	if request.TokenType != KLAY {
+		if counterpart := from.GetCounterPartToken(request.TokenAddress); counterpart != (common.Address{}) && counterpart != handle.TokenAddress {
+			reasons = append(reasons, fmt.Sprintf("token %s != %s", counterpart.String(), handle.TokenAddress.String()))
+		}
+	}
+	return reasons
+}
+
+// sumTokenTotals sums up the given value transfers by token.
+func sumTokenTotals(records []*ValueTransferRecord, counterpartOf func(common.Address) common.Address) []*TokenTotal {
+	totals := make(map[common.Address]*TokenTotal)
+	for _, record := range records {
+		total, ok := totals[record.TokenAddress]
+		if !ok {
+			total = &TokenTotal{TokenType: record.TokenType, Token: record.TokenAddress}
+			if record.TokenType != KLAY {
+				total.CounterpartToken = counterpartOf(record.TokenAddress)
+			}
+			if record.TokenType == KLAY || record.TokenType == ERC20 || record.TokenType == ERC1155 {
+				total.Amount = new(big.Int)
+			}
+			totals[record.TokenAddress] = total
+		}
+		total.Count++
+		switch record.TokenType {
+		case KLAY, ERC20:
+			total.Amount.Add(total.Amount, record.ValueOrTokenId)
+		case ERC1155:
+			// An unknown amount is reported as a mismatch instead.
+			if record.Amount != nil {
+				total.Amount.Add(total.Amount, record.Amount)
+			}
+		}
+	}
+
+	result := make([]*TokenTotal, 0, len(totals))
+	for _, total := range totals {
+		result = append(result, total)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return bytes.Compare(result[i].Token.Bytes(), result[j].Token.Bytes()) < 0
+	})
+	return result
+}
+
+// filterValueTransferRequests returns the value transfer requests of the bridge
+// in the given block range, ordered by nonce.
+func filterValueTransferRequests(bi *BridgeInfo, r ReconcileRange) ([]*ValueTransferRecord, error) {
+	var records []*ValueTransferRecord
+	for start := r.FromBlock; start <= r.ToBlock; start += filterLogsStride + 1 {
+		end := start + filterLogsStride
+		if end > r.ToBlock {
+			end = r.ToBlock
+		}
+		opts := &bind.FilterOpts{Start: start, End: &end}
+
+		reqVTevIt, err := bi.bridge.FilterRequestValueTransfer(opts, nil, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		for reqVTevIt.Next() {
+			records = append(records, newValueTransferRequestRecord(RequestValueTransferEvent{reqVTevIt.Event}))
+		}
+		err = reqVTevIt.Error()
+		reqVTevIt.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		reqVTencodedEvIt, err := bi.bridge.FilterRequestValueTransferEncoded(opts, nil, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		for reqVTencodedEvIt.Next() {
+			records = append(records, newValueTransferRequestRecord(RequestValueTransferEncodedEvent{reqVTencodedEvIt.Event}))
+		}
+		err = reqVTencodedEvIt.Error()
+		reqVTencodedEvIt.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Nonce < records[j].Nonce })
+	return records, nil
+}
+
+// filterValueTransferHandles returns the value transfer handles of the bridge in
+// the given block range, ordered by nonce.
+func filterValueTransferHandles(bi *BridgeInfo, r ReconcileRange) ([]*ValueTransferRecord, error) {
+	var records []*ValueTransferRecord
+	for start := r.FromBlock; start <= r.ToBlock; start += filterLogsStride + 1 {
+		end := start + filterLogsStride
+		if end > r.ToBlock {
+			end = r.ToBlock
+		}
+		handleVTevIt, err := bi.bridge.FilterHandleValueTransfer(&bind.FilterOpts{Start: start, End: &end}, nil, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		for handleVTevIt.Next() {
+			ev := handleVTevIt.Event
+			records = append(records, &ValueTransferRecord{
+				Nonce:          ev.HandleNonce,
+				TokenType:      ev.TokenType,
+				From:           ev.From,
+				To:             ev.To,
+				TokenAddress:   ev.TokenAddress,
+				ValueOrTokenId: ev.ValueOrTokenId,
+				BlockNumber:    ev.Raw.BlockNumber,
+				TxHash:         ev.Raw.TxHash,
+			})
+		}
+		err = handleVTevIt.Error()
+		handleVTevIt.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, record := range records {
+		if record.TokenType != ERC1155 {
+			continue
+		}
+		amount, err := erc1155HandledAmount(bi, record)
+		if err != nil {
+			return nil, err
+		}
+		record.Amount = amount
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Nonce < records[j].Nonce })
+	return records, nil
+}
+
+// erc1155TransferSingleSig is the topic of the TransferSingle event of ERC1155 tokens.
+var erc1155TransferSingleSig = crypto.Keccak256Hash([]byte("TransferSingle(address,address,address,uint256,uint256)"))
+
+// erc1155HandledAmount returns the amount of the given ERC1155 handle. The handle
+// event does not carry it, so it is taken from the TransferSingle event which
+// the token emitted to the receiver in the same transaction, or nil if none is found.
+func erc1155HandledAmount(bi *BridgeInfo, handle *ValueTransferRecord) (*big.Int, error) {
+	backend, err := bi.chainBackend()
+	if err != nil {
+		return nil, err
+	}
+	blockNum := new(big.Int).SetUint64(handle.BlockNumber)
+	logs, err := backend.FilterLogs(context.Background(), klaytn.FilterQuery{
+		FromBlock: blockNum,
+		ToBlock:   blockNum,
+		Addresses: []common.Address{handle.TokenAddress},
+		Topics:    [][]common.Hash{{erc1155TransferSingleSig}, nil, nil, {common.BytesToHash(handle.To.Bytes())}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range logs {
+		// The data holds the token ID and the amount.
+		if l.TxHash != handle.TxHash || len(l.Data) != 2*common.HashLength {
+			continue
+		}
+		if new(big.Int).SetBytes(l.Data[:common.HashLength]).Cmp(handle.ValueOrTokenId) != 0 {
+			continue
+		}
+		return new(big.Int).SetBytes(l.Data[common.HashLength:]), nil
+	}
+	return nil, nil
+}
+
+func newValueTransferRequestRecord(ev IRequestValueTransferEvent) *ValueTransferRecord {
+	raw := ev.GetRaw()
+	return &ValueTransferRecord{
+		Nonce:          ev.GetRequestNonce(),
+		TokenType:      ev.GetTokenType(),
+		From:           ev.GetFrom(),
+		To:             ev.GetTo(),
+		TokenAddress:   ev.GetTokenAddress(),
+		ValueOrTokenId: ev.GetValueOrTokenId(),
+		Amount:         GetERC1155Amount(ev),
+		BlockNumber:    raw.BlockNumber,
+		TxHash:         raw.TxHash,
+	}
+}
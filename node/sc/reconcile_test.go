@@ -0,0 +1,146 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package sc
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestReconcileValueTransfers tests that unhandled requests are reported until
+// they are handled on the counterpart bridge.
+func TestReconcileValueTransfers(t *testing.T) {
+	info := prepare(t, func(info *testInfo) {
+		for i := 0; i < testTxCount; i++ {
+			ops[KLAY].request(info, info.localInfo)
+		}
+	})
+	defer info.sim.Close()
+
+	cBridgeAddr, pBridgeAddr := info.localInfo.address, info.remoteInfo.address
+
+	report, err := info.bm.ReconcileValueTransfers(cBridgeAddr, pBridgeAddr, ReconcileRange{}, ReconcileRange{})
+	assert.NoError(t, err)
+	assert.False(t, report.Consistent)
+
+	c2p := report.ChildToParent
+	assert.Equal(t, testTxCount, c2p.Requests)
+	assert.Equal(t, testTxCount-testPendingCount, c2p.Handles)
+	assert.Equal(t, testTxCount-testPendingCount, c2p.Matched)
+	assert.Equal(t, testPendingCount, len(c2p.Unhandled))
+	for i, record := range c2p.Unhandled {
+		assert.Equal(t, uint64(testTxCount-testPendingCount+i), record.Nonce)
+		assert.Equal(t, info.aliceAuth.From, record.To)
+	}
+	assert.Empty(t, c2p.DoubleHandled)
+	assert.Empty(t, c2p.Mismatches)
+	assert.Empty(t, c2p.UnmatchedHandles)
+
+	assert.Equal(t, 1, len(c2p.RequestedTotals))
+	assert.Equal(t, uint64(testTxCount), c2p.RequestedTotals[0].Count)
+	assert.Equal(t, big.NewInt(testAmount*testTxCount), c2p.RequestedTotals[0].Amount)
+	assert.Equal(t, 1, len(c2p.HandledTotals))
+	assert.Equal(t, big.NewInt(testAmount*(testTxCount-testPendingCount)), c2p.HandledTotals[0].Amount)
+
+	assert.True(t, report.ParentToChild.Consistent())
+	assert.Equal(t, 0, report.ParentToChild.Requests)
+
+	// Handle the pending requests.
+	vtr := NewValueTransferRecovery(&SCConfig{VTRecovery: true}, info.localInfo, info.remoteInfo)
+	assert.NoError(t, vtr.updateRecoveryHint())
+	assert.NoError(t, vtr.retrievePendingEvents())
+	info.recoveryCh <- true
+	assert.NoError(t, vtr.recoverPendingEvents())
+	ops[KLAY].dummyHandle(info, info.remoteInfo)
+
+	report, err = info.bm.ReconcileValueTransfers(cBridgeAddr, pBridgeAddr, ReconcileRange{}, ReconcileRange{})
+	assert.NoError(t, err)
+	assert.True(t, report.Consistent)
+	assert.Equal(t, testTxCount, report.ChildToParent.Matched)
+	assert.Equal(t, report.ChildToParent.RequestedTotals, report.ChildToParent.HandledTotals)
+
+	// The handles of requests out of the request range are reported apart.
+	report, err = info.bm.ReconcileValueTransfers(cBridgeAddr, pBridgeAddr, ReconcileRange{0, 1}, ReconcileRange{})
+	assert.NoError(t, err)
+	assert.True(t, report.Consistent)
+	assert.Equal(t, testTxCount, len(report.ChildToParent.UnmatchedHandles))
+
+	_, err = info.bm.ReconcileValueTransfers(pBridgeAddr, cBridgeAddr, ReconcileRange{}, ReconcileRange{})
+	assert.Equal(t, ErrInvalidBridgePair, err)
+	_, err = info.bm.ReconcileValueTransfers(cBridgeAddr, pBridgeAddr, ReconcileRange{10, 1}, ReconcileRange{})
+	assert.True(t, errors.Is(err, ErrInvalidReconcileRange))
+}
+
+func TestCompareValueTransfer(t *testing.T) {
+	token, counterpart := common.HexToAddress("0x1"), common.HexToAddress("0x2")
+	bi := &BridgeInfo{counterpartToken: map[common.Address]common.Address{token: counterpart}}
+
+	request := &ValueTransferRecord{
+		Nonce:          1,
+		TokenType:      ERC20,
+		From:           common.HexToAddress("0xa"),
+		To:             common.HexToAddress("0xb"),
+		TokenAddress:   token,
+		ValueOrTokenId: big.NewInt(100),
+	}
+	handle := *request
+	handle.TokenAddress = counterpart
+	assert.Empty(t, compareValueTransfer(bi, request, &handle))
+
+	handle.ValueOrTokenId = big.NewInt(99)
+	handle.To = common.HexToAddress("0xc")
+	handle.TokenAddress = token
+	assert.Equal(t, 3, len(compareValueTransfer(bi, request, &handle)))
+
+	// The amounts of ERC1155 transfers are compared too.
+	request.TokenType, request.Amount = ERC1155, big.NewInt(3)
+	handle = *request
+	handle.TokenAddress = counterpart
+	handle.Amount = big.NewInt(3)
+	assert.Empty(t, compareValueTransfer(bi, request, &handle))
+
+	handle.Amount = big.NewInt(2)
+	assert.Equal(t, []string{"amount 3 != 2"}, compareValueTransfer(bi, request, &handle))
+	handle.Amount = nil
+	assert.Equal(t, []string{"no amount in the ERC1155 handle"}, compareValueTransfer(bi, request, &handle))
+	request.Amount = nil
+	assert.Equal(t, []string{"no amount in the ERC1155 request"}, compareValueTransfer(bi, request, &handle))
+}
+
+func TestSumTokenTotals(t *testing.T) {
+	token, nft, multi := common.HexToAddress("0x1"), common.HexToAddress("0x2"), common.HexToAddress("0x3")
+	records := []*ValueTransferRecord{
+		{TokenType: KLAY, ValueOrTokenId: big.NewInt(10)},
+		{TokenType: KLAY, ValueOrTokenId: big.NewInt(20)},
+		{TokenType: ERC20, TokenAddress: token, ValueOrTokenId: big.NewInt(5)},
+		{TokenType: ERC721, TokenAddress: nft, ValueOrTokenId: big.NewInt(7321)},
+		{TokenType: ERC721, TokenAddress: nft, ValueOrTokenId: big.NewInt(7322)},
+		{TokenType: ERC1155, TokenAddress: multi, ValueOrTokenId: big.NewInt(1), Amount: big.NewInt(40)},
+		{TokenType: ERC1155, TokenAddress: multi, ValueOrTokenId: big.NewInt(2), Amount: big.NewInt(2)},
+	}
+	totals := sumTokenTotals(records, func(common.Address) common.Address { return common.HexToAddress("0xff") })
+
+	assert.Equal(t, 4, len(totals))
+	assert.Equal(t, &TokenTotal{TokenType: KLAY, Count: 2, Amount: big.NewInt(30)}, totals[0])
+	assert.Equal(t, &TokenTotal{TokenType: ERC20, Token: token, CounterpartToken: common.HexToAddress("0xff"), Count: 1, Amount: big.NewInt(5)}, totals[1])
+	assert.Equal(t, &TokenTotal{TokenType: ERC721, Token: nft, CounterpartToken: common.HexToAddress("0xff"), Count: 2}, totals[2])
+	assert.Equal(t, &TokenTotal{TokenType: ERC1155, Token: multi, CounterpartToken: common.HexToAddress("0xff"), Count: 2, Amount: big.NewInt(42)}, totals[3])
+}
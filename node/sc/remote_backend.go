@@ -37,6 +37,7 @@ const timeout = 30 * time.Second
 // TODO-Klaytn currently RemoteBackend is only for ServiceChain, especially Bridge SmartContract
 type RemoteBackend struct {
 	subBridge *SubBridge
+	parent    *parentChain // nil for the parent chain of ParentChainID.
 
 	rpcClient *rpc.Client
 	chainID   *big.Int
@@ -61,7 +62,27 @@ func NewRemoteBackend(sb *SubBridge) (*RemoteBackend, error) {
 	}, nil
 }
 
+// newParentChainRemoteBackend returns a RemoteBackend reaching an additional
+// parent chain through its own peers.
+func newParentChainRemoteBackend(sb *SubBridge, pc *parentChain) *RemoteBackend {
+	c, _ := rpc.NewClient(context.Background(), func(ctx context.Context) (rpc.ServerCodec, error) {
+		p1, p2 := net.Pipe()
+		pc.setRPCConn(p1)
+		return rpc.NewCodec(p2), nil
+	})
+
+	return &RemoteBackend{
+		subBridge: sb,
+		parent:    pc,
+		rpcClient: c,
+		chainID:   new(big.Int).SetUint64(pc.config.ChainID),
+	}
+}
+
 func (rb *RemoteBackend) checkParentPeer() bool {
+	if rb.parent != nil {
+		return len(rb.parent.handler.parentPeers()) > 0
+	}
 	return rb.subBridge.peers.Len() > 0
 }
 
@@ -141,6 +162,9 @@ func (rb *RemoteBackend) SendTransaction(ctx context.Context, tx *types.Transact
 	if !rb.checkParentPeer() {
 		return NoParentPeerErr
 	}
+	if rb.parent != nil {
+		return rb.parent.txPool.AddLocal(tx)
+	}
 	return rb.subBridge.bridgeTxPool.AddLocal(tx)
 }
 
@@ -170,6 +194,9 @@ func (rb *RemoteBackend) TransactionReceiptRpcOutput(ctx context.Context, txHash
 
 // ChainID returns the chain ID of the sub-bridge configuration.
 func (rb *RemoteBackend) ChainID(ctx context.Context) (*big.Int, error) {
+	if rb.chainID != nil {
+		return rb.chainID, nil
+	}
 	return big.NewInt(int64(rb.subBridge.config.ParentChainID)), nil
 }
 
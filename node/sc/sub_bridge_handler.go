@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net"
 
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/types"
@@ -54,6 +55,10 @@ type InvalidParentChainTx struct {
 
 type SubBridgeHandler struct {
 	subbridge *SubBridge
+	// parent is the additional parent chain which the handler works for.
+	// It is nil for the parent chain of ParentChainID.
+	parent   *parentChain
+	pAccount *accountInfo
 	// parentChainID is the first received chainID from parent chain peer.
 	// It will be reset to nil if there's no parent peer.
 	parentChainID *big.Int
@@ -67,6 +72,7 @@ type SubBridgeHandler struct {
 	latestTxCountAddedBlockNumber uint64
 	txCountStartingBlockNumber    uint64
 	txCount                       uint64 // accumulated tx counts in blocks for each anchoring period.
+	lastBatchedBlockNumber        uint64 // the last block of the latest anchoring batch generated by this handler.
 
 	// TODO-Klaytn-ServiceChain Need to limit the number independently? Or just managing the size of sentServiceChainTxs?
 	sentServiceChainTxsLimit uint64
@@ -77,6 +83,7 @@ type SubBridgeHandler struct {
 func NewSubBridgeHandler(main *SubBridge) (*SubBridgeHandler, error) {
 	return &SubBridgeHandler{
 		subbridge:                     main,
+		pAccount:                      main.bridgeAccounts.pAccount,
 		parentChainID:                 new(big.Int).SetUint64(main.config.ParentChainID),
 		remoteGasPrice:                uint64(0),
 		mainChainAccountNonce:         uint64(0),
@@ -87,9 +94,86 @@ func NewSubBridgeHandler(main *SubBridge) (*SubBridgeHandler, error) {
 	}, nil
 }
 
+// newParentChainHandler returns a handler for an additional parent chain.
+func newParentChainHandler(main *SubBridge, pc *parentChain) *SubBridgeHandler {
+	return &SubBridgeHandler{
+		subbridge:                     main,
+		parent:                        pc,
+		pAccount:                      pc.account,
+		parentChainID:                 new(big.Int).SetUint64(pc.config.ChainID),
+		remoteGasPrice:                uint64(0),
+		mainChainAccountNonce:         uint64(0),
+		nonceSynced:                   false,
+		chainTxPeriod:                 pc.config.AnchoringPeriod,
+		latestTxCountAddedBlockNumber: uint64(0),
+		sentServiceChainTxsLimit:      main.config.SentChainTxsLimit,
+	}
+}
+
+// bridgeTxPool returns the pool of the transactions to the parent chain.
+func (sbh *SubBridgeHandler) bridgeTxPool() BridgeTxPool {
+	if sbh.parent != nil {
+		return sbh.parent.txPool
+	}
+	return sbh.subbridge.GetBridgeTxPool()
+}
+
+// remoteBackend returns the backend of the parent chain.
+func (sbh *SubBridgeHandler) remoteBackend() Backend {
+	if sbh.parent != nil {
+		return sbh.parent.backend
+	}
+	return sbh.subbridge.remoteBackend
+}
+
+// rpcConn returns the pipe of the RPC client to the parent chain.
+func (sbh *SubBridgeHandler) rpcConn() net.Conn {
+	if sbh.parent != nil {
+		return sbh.parent.rpcConn
+	}
+	return sbh.subbridge.rpcConn
+}
+
+// anchoringEnabled returns whether the child chain blocks are anchored to the parent chain.
+func (sbh *SubBridgeHandler) anchoringEnabled() bool {
+	if sbh.parent != nil {
+		return sbh.parent.config.AnchoringPeriod > 0
+	}
+	return sbh.subbridge.GetAnchoringTx()
+}
+
+// parentPeers returns the peers of the parent chain. All peers belong to it
+// unless additional parent chains are configured.
+func (sbh *SubBridgeHandler) parentPeers() []BridgePeer {
+	peers := make([]BridgePeer, 0, len(sbh.subbridge.BridgePeerSet().peers))
+	for _, peer := range sbh.subbridge.BridgePeerSet().peers {
+		if len(sbh.subbridge.parentChains) > 0 && peer.GetChainID().Cmp(sbh.parentChainID) != 0 {
+			continue
+		}
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
+// sendRPCData sends the RPC request data to a peer of the parent chain.
+func (sbh *SubBridgeHandler) sendRPCData(data []byte) error {
+	peers := sbh.parentPeers()
+	logger.Trace("send rpc message from the subbridge", "len", len(data), "peers", len(peers))
+	for _, peer := range peers {
+		err := peer.SendRequestRPC(data)
+		if err != nil {
+			logger.Error("SendRPCData Error", "err", err)
+		}
+		return err
+	}
+	logger.Trace("send rpc message from the subbridge, done")
+
+	return nil
+}
+
 func (sbh *SubBridgeHandler) setParentChainID(chainId *big.Int) {
 	sbh.parentChainID = chainId
-	sbh.subbridge.bridgeAccounts.pAccount.SetChainID(chainId)
+	sbh.pAccount.SetChainID(chainId)
 }
 
 func (sbh *SubBridgeHandler) getParentChainID() *big.Int {
@@ -97,26 +181,26 @@ func (sbh *SubBridgeHandler) getParentChainID() *big.Int {
 }
 
 func (sbh *SubBridgeHandler) LockParentOperator() {
-	sbh.subbridge.bridgeAccounts.pAccount.Lock()
+	sbh.pAccount.Lock()
 }
 
 func (sbh *SubBridgeHandler) UnLockParentOperator() {
-	sbh.subbridge.bridgeAccounts.pAccount.UnLock()
+	sbh.pAccount.UnLock()
 }
 
 // getParentOperatorNonce returns the parent chain operator nonce of parent chain operator address.
 func (sbh *SubBridgeHandler) getParentOperatorNonce() uint64 {
-	return sbh.subbridge.bridgeAccounts.pAccount.GetNonce()
+	return sbh.pAccount.GetNonce()
 }
 
 // setParentOperatorNonce sets the parent chain operator nonce of parent chain operator address.
 func (sbh *SubBridgeHandler) setParentOperatorNonce(newNonce uint64) {
-	sbh.subbridge.bridgeAccounts.pAccount.SetNonce(newNonce)
+	sbh.pAccount.SetNonce(newNonce)
 }
 
 // addParentOperatorNonce increases nonce by number
 func (sbh *SubBridgeHandler) addParentOperatorNonce(number uint64) {
-	sbh.subbridge.bridgeAccounts.pAccount.IncNonce()
+	sbh.pAccount.IncNonce()
 }
 
 // getParentOperatorNonceSynced returns whether the parent chain operator account nonce is synced or not.
@@ -128,7 +212,7 @@ func (sbh *SubBridgeHandler) getParentOperatorNonceSynced() bool {
 func (sbh *SubBridgeHandler) getParentOperatorBalance() (*big.Int, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
-	return sbh.subbridge.remoteBackend.BalanceAt(ctx, sbh.subbridge.bridgeAccounts.pAccount.address, nil)
+	return sbh.remoteBackend().BalanceAt(ctx, sbh.pAccount.address, nil)
 }
 
 // getParentBridgeContractBalance returns the parent bridge contract's balance.
@@ -140,7 +224,7 @@ func (sbh *SubBridgeHandler) getParentBridgeContractBalance(addr common.Address)
 		logger.Error(ErrUnknownBridgeContractAddr.Error(), "addr", addr)
 		return common.Big0, ErrUnknownBridgeContractAddr
 	}
-	return sbh.subbridge.remoteBackend.BalanceAt(ctx, addr, nil)
+	return sbh.remoteBackend().BalanceAt(ctx, addr, nil)
 }
 
 // setParentOperatorNonceSynced sets whether the parent chain operator account nonce is synced or not.
@@ -177,7 +261,7 @@ func (sbh *SubBridgeHandler) getRemoteGasPrice() uint64 {
 
 // setRemoteGasPrice sets parent chain's gasprice
 func (sbh *SubBridgeHandler) setRemoteGasPrice(gasPrice uint64) {
-	sbh.subbridge.bridgeAccounts.pAccount.SetGasPrice(big.NewInt(int64(gasPrice)))
+	sbh.pAccount.SetGasPrice(big.NewInt(int64(gasPrice)))
 	sbh.remoteGasPrice = gasPrice
 }
 
@@ -187,17 +271,17 @@ func (sbh *SubBridgeHandler) setRemoteChainValues(pcInfo parentChainInfo) {
 	if pcInfo.IsMagmaEnabled {
 		// Set parent chain's gasprice with upperboundbasefee
 		sbh.remoteGasPrice = pcInfo.KIP71Config.UpperBoundBaseFee
-		sbh.subbridge.bridgeAccounts.SetParentKIP71Config(pcInfo.KIP71Config)
-		kip71Config := sbh.subbridge.bridgeAccounts.GetParentKIP71Config()
+		sbh.pAccount.kip71Config = pcInfo.KIP71Config
+		kip71Config := sbh.pAccount.kip71Config
 
-		logger.Info("[SC][Sync] Updated parent chain values", "gasPrice", sbh.subbridge.bridgeAccounts.GetParentGasPrice(),
+		logger.Info("[SC][Sync] Updated parent chain values", "gasPrice", sbh.pAccount.gasPrice,
 			"LowerBoundBaseFee", kip71Config.LowerBoundBaseFee,
 			"UpperBoundBaseFee", kip71Config.UpperBoundBaseFee,
 			"GasTarget", kip71Config.GasTarget,
 			"MaxBlockGasUsedForBaseFee", kip71Config.MaxBlockGasUsedForBaseFee,
 			"BaseFeeDenominator", kip71Config.BaseFeeDenominator)
 	} else {
-		logger.Info("Updated parent chain's gas price", "gasPrice", sbh.subbridge.bridgeAccounts.GetParentGasPrice())
+		logger.Info("Updated parent chain's gas price", "gasPrice", sbh.pAccount.gasPrice)
 	}
 }
 
@@ -205,7 +289,7 @@ func (sbh *SubBridgeHandler) setRemoteChainValues(pcInfo parentChainInfo) {
 // If given as a parameter, it will use it. If not given, it will use the address of the public key
 // derived from chainKey.
 func (sbh *SubBridgeHandler) GetParentOperatorAddr() *common.Address {
-	return &sbh.subbridge.bridgeAccounts.pAccount.address
+	return &sbh.pAccount.address
 }
 
 // GetChildOperatorAddr returns a pointer of a hex address of an account used for child chain.
@@ -237,7 +321,7 @@ func (sbh *SubBridgeHandler) HandleMainMsg(p BridgePeer, msg p2p.Msg) error {
 			return nil
 		}
 		logger.Trace("send rpc response to the rpc client")
-		_, err = sbh.subbridge.rpcConn.Write(data)
+		_, err = sbh.rpcConn().Write(data)
 		if err != nil {
 			return err
 		}
@@ -277,7 +361,7 @@ func (sbh *SubBridgeHandler) handleParentChainInfoResponseMsg(p BridgePeer, msg
 	sbh.LockParentOperator()
 	defer sbh.UnLockParentOperator()
 
-	poolNonce := sbh.subbridge.bridgeTxPool.GetMaxTxNonce(sbh.GetParentOperatorAddr())
+	poolNonce := sbh.bridgeTxPool().GetMaxTxNonce(sbh.GetParentOperatorAddr())
 	if poolNonce > 0 {
 		poolNonce += 1
 		// just check
@@ -321,8 +405,16 @@ func (sbh *SubBridgeHandler) handleParentChainReceiptResponseMsg(p BridgePeer, m
 
 // genUnsignedChainDataAnchoringTx generates an unsigned transaction, which type is TxTypeChainDataAnchoring.
 // Nonce of account used for service chain transaction will be increased after the signing.
-func (sbh *SubBridgeHandler) genUnsignedChainDataAnchoringTx(block *types.Block) (*types.Transaction, error) {
-	anchoringData, err := types.NewAnchoringDataType0(block, block.NumberU64()-sbh.txCountStartingBlockNumber+1, sbh.txCount)
+func (sbh *SubBridgeHandler) genUnsignedChainDataAnchoringTx(block *types.Block, blockHashes []common.Hash) (*types.Transaction, error) {
+	var (
+		anchoringData *types.AnchoringData
+		err           error
+	)
+	if blockHashes != nil {
+		anchoringData, err = types.NewAnchoringDataType1(block, blockHashes, sbh.txCount)
+	} else {
+		anchoringData, err = types.NewAnchoringDataType0(block, block.NumberU64()-sbh.txCountStartingBlockNumber+1, sbh.txCount)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -341,7 +433,7 @@ func (sbh *SubBridgeHandler) genUnsignedChainDataAnchoringTx(block *types.Block)
 
 	txType := types.TxTypeChainDataAnchoring
 
-	if feePayer := sbh.subbridge.bridgeAccounts.GetParentOperatorFeePayer(); feePayer != (common.Address{}) {
+	if feePayer := sbh.pAccount.feePayer; feePayer != (common.Address{}) {
 		values[types.TxValueKeyFeePayer] = feePayer
 		txType = types.TxTypeFeeDelegatedChainDataAnchoring
 	}
@@ -357,7 +449,7 @@ func (sbh *SubBridgeHandler) genUnsignedChainDataAnchoringTx(block *types.Block)
 func (sbh *SubBridgeHandler) LocalChainHeadEvent(block *types.Block) {
 	if sbh.getParentOperatorNonceSynced() {
 		// TODO-Klaytn if other feature use below chainTx, this condition should be refactored to use it for other feature.
-		if sbh.subbridge.GetAnchoringTx() {
+		if sbh.anchoringEnabled() {
 			sbh.blockAnchoringManager(block)
 		}
 		sbh.broadcastServiceChainTx()
@@ -383,7 +475,7 @@ func (sbh *SubBridgeHandler) handleParentChainInvalidTxResponseMsg(msg p2p.Msg)
 	if err := msg.Decode(&invalidTxs); err != nil && err != rlp.EOL {
 		return errResp(ErrDecode, "msg %v: %v", msg, err)
 	}
-	txPool := sbh.subbridge.GetBridgeTxPool()
+	txPool := sbh.bridgeTxPool()
 	for _, invalidTx := range invalidTxs {
 		if tx := txPool.Get(invalidTx.TxHash); tx != nil {
 			logger.Error("A bridge tx was not executed", "err", invalidTx.ErrStr,
@@ -395,7 +487,7 @@ func (sbh *SubBridgeHandler) handleParentChainInvalidTxResponseMsg(msg p2p.Msg)
 
 				logger.Error("Bridge tx is removed which has lower gasPrice than UpperBoundBaseFee")
 				// Remove the tx and delegate re-execution of the tx by Value Transfer Recovery feature
-				if err := sbh.subbridge.GetBridgeTxPool().RemoveTx(tx); err != nil {
+				if err := sbh.bridgeTxPool().RemoveTx(tx); err != nil {
 					logger.Error("Failed to remove bridge tx",
 						"txType", tx.Type(), "txNonce", tx.Nonce(), "txHash", tx.Hash().String())
 				} else {
@@ -416,8 +508,8 @@ func (sbh *SubBridgeHandler) broadcastServiceChainTx() {
 	if parentChainID == nil {
 		logger.Error("unexpected nil parentChainID while broadcastServiceChainTx")
 	}
-	txs := sbh.subbridge.GetBridgeTxPool().PendingTxsByAddress(&sbh.subbridge.bridgeAccounts.pAccount.address, int(sbh.GetSentChainTxsLimit())) // TODO-Klaytn-Servicechain change GetSentChainTxsLimit type to int from uint64
-	peers := sbh.subbridge.BridgePeerSet().peers
+	txs := sbh.bridgeTxPool().PendingTxsByAddress(&sbh.pAccount.address, int(sbh.GetSentChainTxsLimit())) // TODO-Klaytn-Servicechain change GetSentChainTxsLimit type to int from uint64
+	peers := sbh.parentPeers()
 
 	for _, peer := range peers {
 		if peer.GetChainID().Cmp(parentChainID) != 0 {
@@ -434,7 +526,7 @@ func (sbh *SubBridgeHandler) broadcastServiceChainTx() {
 func (sbh *SubBridgeHandler) writeServiceChainTxReceipts(bc *blockchain.BlockChain, receipts []*types.ReceiptForStorage) {
 	for _, receipt := range receipts {
 		txHash := receipt.TxHash
-		if tx := sbh.subbridge.GetBridgeTxPool().Get(txHash); tx != nil {
+		if tx := sbh.bridgeTxPool().Get(txHash); tx != nil {
 			if tx.Type().IsChainDataAnchoring() {
 				data, err := tx.AnchoredData()
 				if err != nil {
@@ -450,7 +542,7 @@ func (sbh *SubBridgeHandler) writeServiceChainTxReceipts(bc *blockchain.BlockCha
 				sbh.WriteAnchoredBlockNumber(decodedData.GetBlockNumber().Uint64())
 			}
 			// TODO-Klaytn-ServiceChain: support other tx types if needed.
-			sbh.subbridge.GetBridgeTxPool().RemoveTx(tx)
+			sbh.bridgeTxPool().RemoveTx(tx)
 		} else {
 			logger.Trace("received service chain transaction receipt does not exist in sentServiceChainTxs", "txHash", txHash.String())
 		}
@@ -472,8 +564,8 @@ func (sbh *SubBridgeHandler) RegisterNewPeer(p BridgePeer) error {
 
 // broadcastServiceChainReceiptRequest broadcasts receipt requests for service chain transactions.
 func (sbh *SubBridgeHandler) broadcastServiceChainReceiptRequest() {
-	hashes := sbh.subbridge.GetBridgeTxPool().PendingTxHashesByAddress(sbh.GetParentOperatorAddr(), int(sbh.GetSentChainTxsLimit())) // TODO-Klaytn-Servicechain change GetSentChainTxsLimit type to int from uint64
-	for _, peer := range sbh.subbridge.BridgePeerSet().peers {
+	hashes := sbh.bridgeTxPool().PendingTxHashesByAddress(sbh.GetParentOperatorAddr(), int(sbh.GetSentChainTxsLimit())) // TODO-Klaytn-Servicechain change GetSentChainTxsLimit type to int from uint64
+	for _, peer := range sbh.parentPeers() {
 		peer.SendServiceChainReceiptRequest(hashes)
 		logger.Debug("sent ServiceChainReceiptRequest", "peerID", peer.GetID(), "numReceiptsRequested", len(hashes))
 	}
@@ -534,7 +626,17 @@ func (sbh *SubBridgeHandler) generateAndAddAnchoringTxIntoTxPool(block *types.Bl
 	sbh.LockParentOperator()
 	defer sbh.UnLockParentOperator()
 
-	unsignedTx, err := sbh.genUnsignedChainDataAnchoringTx(block)
+	var blockHashes []common.Hash
+	if sbh.subbridge.config.MerkleAnchoring {
+		hashes, err := sbh.anchoringBlockHashes(block)
+		if err != nil {
+			logger.Error("Failed to collect block hashes of the anchoring period", "blockNum", block.NumberU64(), "err", err)
+			return err
+		}
+		blockHashes = hashes
+	}
+
+	unsignedTx, err := sbh.genUnsignedChainDataAnchoringTx(block, blockHashes)
 	if err != nil {
 		logger.Error("Failed to generate service chain transaction", "blockNum", block.NumberU64(), "err", err)
 		return err
@@ -544,33 +646,90 @@ func (sbh *SubBridgeHandler) generateAndAddAnchoringTxIntoTxPool(block *types.Bl
 	sbh.txCount = 0
 	sbh.txCountStartingBlockNumber = block.NumberU64() + 1
 
-	signedTx, err := sbh.subbridge.bridgeAccounts.pAccount.SignTx(unsignedTx)
+	signedTx, err := sbh.pAccount.SignTx(unsignedTx)
 	if err != nil {
 		logger.Error("failed signing tx", "err", err)
 		return err
 	}
-	if err := sbh.subbridge.GetBridgeTxPool().AddLocal(signedTx); err == nil {
+	if err := sbh.bridgeTxPool().AddLocal(signedTx); err == nil {
 		sbh.addParentOperatorNonce(1)
 	} else {
 		logger.Debug("failed to add tx into bridge txpool", "err", err)
 		return err
 	}
 
+	if blockHashes != nil {
+		sbh.lastBatchedBlockNumber = block.NumberU64()
+		sbh.writeAnchoringBatch(&types.AnchoringBatch{
+			StartBlockNumber: block.NumberU64() - uint64(len(blockHashes)) + 1,
+			BlockHashes:      blockHashes,
+			AnchoringTxHash:  signedTx.Hash(),
+		})
+	}
+
 	logger.Info("Generate an anchoring tx", "blockNum", block.NumberU64(), "blockhash", block.Hash().String(), "txCount", txCount, "txHash", signedTx.Hash().String())
 
 	return nil
 }
 
+// anchoringBlockHashes returns the hashes of the blocks of the anchoring period
+// which ends with the given block. The period starts right after the last anchored
+// block, so the blocks made while anchoring was stopped (e.g., by a restart or
+// while the parent operator nonce was not synced) are still in a batch.
+func (sbh *SubBridgeHandler) anchoringBlockHashes(block *types.Block) ([]common.Hash, error) {
+	start := sbh.txCountStartingBlockNumber
+	if anchored := sbh.GetLatestAnchoredBlockNumber(); anchored != 0 {
+		start = anchored + 1
+	}
+	// The receipt of the latest anchoring tx may not have arrived yet.
+	if sbh.lastBatchedBlockNumber >= start {
+		start = sbh.lastBatchedBlockNumber + 1
+	}
+	if start == 0 || start > block.NumberU64() {
+		start = block.NumberU64()
+	}
+	hashes := make([]common.Hash, 0, block.NumberU64()-start+1)
+	for i := start; i < block.NumberU64(); i++ {
+		header := sbh.subbridge.blockchain.GetHeaderByNumber(i)
+		if header == nil {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidBlock, i)
+		}
+		hashes = append(hashes, header.Hash())
+	}
+	return append(hashes, block.Hash()), nil
+}
+
+// writeAnchoringBatch stores the blocks anchored by an anchoring tx so that their
+// inclusion proofs can be served.
+func (sbh *SubBridgeHandler) writeAnchoringBatch(batch *types.AnchoringBatch) {
+	if sbh.parent != nil {
+		sbh.subbridge.chainDB.WriteParentChainAnchoringBatch(sbh.parent.config.ChainID, batch)
+		return
+	}
+	sbh.subbridge.chainDB.WriteAnchoringBatch(batch)
+}
+
+// GetAnchoringBatch returns the anchoring batch which includes the given block number.
+func (sbh *SubBridgeHandler) GetAnchoringBatch(blockNum uint64) *types.AnchoringBatch {
+	if sbh.parent != nil {
+		return sbh.subbridge.chainDB.ReadParentChainAnchoringBatch(sbh.parent.config.ChainID, blockNum)
+	}
+	return sbh.subbridge.chainDB.ReadAnchoringBatch(blockNum)
+}
+
 // SyncNonceAndGasPrice requests the nonce of address used for service chain tx to parent chain peers.
 func (scpm *SubBridgeHandler) SyncNonceAndGasPrice() {
 	addr := scpm.GetParentOperatorAddr()
-	for _, peer := range scpm.subbridge.BridgePeerSet().peers {
+	for _, peer := range scpm.parentPeers() {
 		peer.SendServiceChainInfoRequest(addr)
 	}
 }
 
 // GetLatestAnchoredBlockNumber returns the latest block number whose data has been anchored to the parent chain.
 func (sbh *SubBridgeHandler) GetLatestAnchoredBlockNumber() uint64 {
+	if sbh.parent != nil {
+		return sbh.subbridge.ChainDB().ReadParentChainAnchoredBlockNumber(sbh.parent.config.ChainID)
+	}
 	return sbh.subbridge.ChainDB().ReadAnchoredBlockNumber()
 }
 
@@ -584,19 +743,31 @@ func (sbh *SubBridgeHandler) UpdateLatestTxCountAddedBlockNumber(newLatestAnchor
 // WriteAnchoredBlockNumber writes the block number whose data has been anchored to the parent chain.
 func (sbh *SubBridgeHandler) WriteAnchoredBlockNumber(blockNum uint64) {
 	if sbh.GetLatestAnchoredBlockNumber() < blockNum {
+		if sbh.parent != nil {
+			sbh.subbridge.chainDB.WriteParentChainAnchoredBlockNumber(sbh.parent.config.ChainID, blockNum)
+			return
+		}
 		sbh.subbridge.chainDB.WriteAnchoredBlockNumber(blockNum)
 		lastAnchoredBlockNumGauge.Update(int64(blockNum))
 	}
 }
 
 // WriteReceiptFromParentChain writes a receipt received from parent chain to child chain
-// with corresponding block hash. It assumes that a child chain has only one parent chain.
+// with corresponding block hash. The receipts of an additional parent chain are kept
+// apart from the ones of the primary parent chain.
 func (sbh *SubBridgeHandler) WriteReceiptFromParentChain(blockHash common.Hash, receipt *types.Receipt) {
+	if sbh.parent != nil {
+		sbh.subbridge.chainDB.WriteParentChainReceipt(sbh.parent.config.ChainID, blockHash, receipt)
+		return
+	}
 	sbh.subbridge.chainDB.WriteReceiptFromParentChain(blockHash, receipt)
 }
 
 // GetReceiptFromParentChain returns a receipt received from parent chain to child chain
-// with corresponding block hash. It assumes that a child chain has only one parent chain.
+// with corresponding block hash.
 func (sbh *SubBridgeHandler) GetReceiptFromParentChain(blockHash common.Hash) *types.Receipt {
+	if sbh.parent != nil {
+		return sbh.subbridge.chainDB.ReadParentChainReceipt(sbh.parent.config.ChainID, blockHash)
+	}
 	return sbh.subbridge.chainDB.ReadReceiptFromParentChain(blockHash)
 }
@@ -44,6 +44,9 @@ func NewChildChainEventHandler(bridge *SubBridge, handler *SubBridgeHandler) (*C
 func (cce *ChildChainEventHandler) HandleChainHeadEvent(block *types.Block) error {
 	logger.Trace("bridgeNode block number", "number", block.Number())
 	cce.handler.LocalChainHeadEvent(block)
+	for _, pc := range cce.subbridge.parentChains {
+		pc.handler.LocalChainHeadEvent(block)
+	}
 
 	// Logging information of value transfer
 	cce.subbridge.bridgeManager.LogBridgeStatus()
@@ -103,6 +106,41 @@ func (cce *ChildChainEventHandler) ProcessHandleEvent(ev *HandleValueTransferEve
 	return nil
 }
 
+// ProcessRequestMessageEvent adds the message request to the pending list of the counterpart bridge.
+func (cce *ChildChainEventHandler) ProcessRequestMessageEvent(ev RequestMessageEvent) error {
+	addr := ev.Raw.Address
+
+	handleBridgeAddr := cce.subbridge.bridgeManager.GetCounterPartBridgeAddr(addr)
+	if handleBridgeAddr == (common.Address{}) {
+		return fmt.Errorf("there is no counter part bridge of the bridge(%v)", addr.String())
+	}
+
+	handleBridgeInfo, ok := cce.subbridge.bridgeManager.GetBridgeInfo(handleBridgeAddr)
+	if !ok {
+		return fmt.Errorf("there is no counter part bridge info(%v) of the bridge(%v)", handleBridgeAddr.String(), addr.String())
+	}
+
+	handleBridgeInfo.AddRequestMessageEvents([]RequestMessageEvent{ev})
+	return nil
+}
+
+func (cce *ChildChainEventHandler) ProcessHandleMessageEvent(ev *HandleMessageEvent) error {
+	handleBridgeInfo, ok := cce.subbridge.bridgeManager.GetBridgeInfo(ev.Raw.Address)
+	if !ok {
+		return errors.New("there is no bridge")
+	}
+
+	handleBridgeInfo.MarkHandledMessage(ev.MessageNonce)
+
+	logger.Trace("HandleMessage Event",
+		"bridgeAddr", ev.Raw.Address.String(),
+		"messageNonce", ev.MessageNonce,
+		"from", ev.From.String(),
+		"target", ev.Target.String(),
+		"success", ev.Success)
+	return nil
+}
+
 // ConvertChildChainBlockHashToParentChainTxHash returns a transaction hash of a transaction which contains
 // AnchoringData, with the key made with given child chain block hash.
 // Index is built when child chain indexing is enabled.
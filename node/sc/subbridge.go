@@ -160,6 +160,10 @@ type SubBridge struct {
 	reqVTencodedEvSub  event.Subscription
 	chanHandleVTev     chan *HandleValueTransferEvent
 	handleVTevSub      event.Subscription
+	chanReqMsgEv       chan RequestMessageEvent
+	reqMsgEvSub        event.Subscription
+	chanHandleMsgEv    chan *HandleMessageEvent
+	handleMsgEvSub     event.Subscription
 
 	bridgeAccounts *BridgeAccounts
 
@@ -173,6 +177,11 @@ type SubBridge struct {
 
 	// KAS Anchor
 	kasAnchor *kas.Anchor
+
+	// parentChains are the parent chains connected besides the one of ParentChainID.
+	parentChains map[uint64]*parentChain
+
+	transferLimiter *transferLimiter
 }
 
 // New creates a new CN object (including the
@@ -198,11 +207,19 @@ func NewSubBridge(ctx *node.ServiceContext, config *SCConfig) (*SubBridge, error
 		chanReqVTev:        make(chan RequestValueTransferEvent, chanReqVTevanSize),
 		chanReqVTencodedEv: make(chan RequestValueTransferEncodedEvent, chanReqVTevanSize),
 		chanHandleVTev:     make(chan *HandleValueTransferEvent, chanHandleVTevanSize),
+		chanReqMsgEv:       make(chan RequestMessageEvent, chanReqVTevanSize),
+		chanHandleMsgEv:    make(chan *HandleMessageEvent, chanHandleVTevanSize),
 		quitSync:           make(chan struct{}),
 		maxPeers:           config.MaxPeer,
 		onAnchoringTx:      config.Anchoring,
 		bootFail:           false,
 		rpcSendCh:          make(chan []byte),
+		parentChains:       make(map[uint64]*parentChain),
+	}
+	// Each parent chain needs its own main-bridge peer.
+	if minPeers := 1 + len(config.ParentChains); len(config.ParentChains) > 0 && sb.maxPeers < minPeers {
+		logger.Info("Raise the max number of main-bridge peers for the parent chains", "maxPeer", minPeers)
+		sb.maxPeers = minPeers
 	}
 	// TODO-Klaytn change static config to user define config
 	bridgetxConfig := bridgepool.BridgeTxPoolConfig{
@@ -217,11 +234,23 @@ func NewSubBridge(ctx *node.ServiceContext, config *SCConfig) (*SubBridge, error
 
 	sb.bridgeTxPool = bridgepool.NewBridgeTxPool(bridgetxConfig)
 
-	var err error
+	heldTransfers, err := bridgepool.NewHeldTransferPool(path.Join(config.DataDir, "bridge_transfer_decisions.rlp"))
+	if err != nil {
+		return nil, err
+	}
+	sb.transferLimiter, err = newTransferLimiter(config, heldTransfers)
+	if err != nil {
+		heldTransfers.Stop()
+		return nil, err
+	}
+
 	sb.bridgeAccounts, err = NewBridgeAccounts(sb.accountManager, config.DataDir, chainDB, sb.config.ServiceChainParentOperatorGasLimit, sb.config.ServiceChainChildOperatorGasLimit)
 	if err != nil {
 		return nil, err
 	}
+	if err := sb.bridgeAccounts.SetOperatorSigners(sb.accountManager, config.ServiceChainParentOperatorSigner, config.ServiceChainChildOperatorSigner); err != nil {
+		return nil, err
+	}
 	sb.handler, err = NewSubBridgeHandler(sb)
 	if err != nil {
 		return nil, err
@@ -232,45 +261,45 @@ func NewSubBridge(ctx *node.ServiceContext, config *SCConfig) (*SubBridge, error
 	}
 	sb.bridgeAccounts.pAccount.SetChainID(new(big.Int).SetUint64(config.ParentChainID))
 
+	for _, parent := range config.ParentChains {
+		if err := sb.addParentChain(parent); err != nil {
+			return nil, err
+		}
+	}
+
 	return sb, nil
 }
 
 func (sb *SubBridge) SetRPCConn(conn net.Conn) {
 	sb.rpcConn = conn
 
-	go func() {
-		for {
-			data := make([]byte, rpcBufferSize)
-			rlen, err := sb.rpcConn.Read(data)
-			if err != nil {
-				if err == io.EOF {
-					logger.Trace("EOF from the rpc pipe")
-					time.Sleep(100 * time.Millisecond)
-					continue
-				} else {
-					// If no one closes the pipe, this situation should not happen.
-					logger.Error("failed to read from the rpc pipe", "err", err, "rlen", rlen)
-					return
-				}
-			}
-			sb.rpcSendCh <- data[:rlen]
-		}
-	}()
+	go readRPCPipe(conn, func(data []byte) {
+		sb.rpcSendCh <- data
+	})
 }
 
-func (sb *SubBridge) SendRPCData(data []byte) error {
-	peers := sb.BridgePeerSet().peers
-	logger.Trace("send rpc message from the subbridge", "len", len(data), "peers", len(peers))
-	for _, peer := range peers {
-		err := peer.SendRequestRPC(data)
+// readRPCPipe passes the RPC requests written to the pipe to send.
+func readRPCPipe(conn net.Conn, send func([]byte)) {
+	for {
+		data := make([]byte, rpcBufferSize)
+		rlen, err := conn.Read(data)
 		if err != nil {
-			logger.Error("SendRPCData Error", "err", err)
+			if err == io.EOF {
+				logger.Trace("EOF from the rpc pipe")
+				time.Sleep(100 * time.Millisecond)
+				continue
+			} else {
+				// If no one closes the pipe, this situation should not happen.
+				logger.Error("failed to read from the rpc pipe", "err", err, "rlen", rlen)
+				return
+			}
 		}
-		return err
+		send(data[:rlen])
 	}
-	logger.Trace("send rpc message from the subbridge, done")
+}
 
-	return nil
+func (sb *SubBridge) SendRPCData(data []byte) error {
+	return sb.handler.sendRPCData(data)
 }
 
 // implement PeerSetManager
@@ -364,6 +393,9 @@ func (sb *SubBridge) SetComponents(components []interface{}) {
 			sb.bootFail = true
 			return
 		}
+		for _, pc := range sb.parentChains {
+			pc.backend = newParentChainRemoteBackend(sb, pc)
+		}
 	}
 
 	es := filters.NewEventSystem(sb.eventMux, &filterLocalBackend{sb}, false)
@@ -378,6 +410,8 @@ func (sb *SubBridge) SetComponents(components []interface{}) {
 	sb.reqVTevSub = sb.bridgeManager.SubscribeReqVTev(sb.chanReqVTev)
 	sb.reqVTencodedEvSub = sb.bridgeManager.SubscribeReqVTencodedEv(sb.chanReqVTencodedEv)
 	sb.handleVTevSub = sb.bridgeManager.SubscribeHandleVTev(sb.chanHandleVTev)
+	sb.reqMsgEvSub = sb.bridgeManager.SubscribeReqMsgEv(sb.chanReqMsgEv)
+	sb.handleMsgEvSub = sb.bridgeManager.SubscribeHandleMsgEv(sb.chanHandleMsgEv)
 
 	sb.pmwg.Add(1)
 	go sb.restoreBridgeLoop()
@@ -538,7 +572,7 @@ func (sb *SubBridge) handle(p BridgePeer) error {
 	}
 	defer sb.removePeer(p.GetID())
 
-	sb.handler.RegisterNewPeer(p)
+	sb.handlerOf(p).RegisterNewPeer(p)
 
 	p.GetP2PPeer().Log().Info("Added a P2P Peer", "peerID", p.GetP2PPeerID())
 
@@ -571,6 +605,9 @@ func (sb *SubBridge) resetBridgeLoop() {
 			if peerCount == 0 {
 				needResetSubscription = true
 				sb.handler.setParentOperatorNonceSynced(false)
+				for _, pc := range sb.parentChains {
+					pc.handler.setParentOperatorNonceSynced(false)
+				}
 			}
 		case <-ticker.C:
 			if needResetSubscription && peerCount > 0 {
@@ -652,6 +689,14 @@ func (sb *SubBridge) loop() {
 			if err := sb.eventhandler.ProcessHandleEvent(ev); err != nil {
 				logger.Error("fail to process handle value transfer event ", "err", err)
 			}
+		case ev := <-sb.chanReqMsgEv:
+			if err := sb.eventhandler.ProcessRequestMessageEvent(ev); err != nil {
+				logger.Error("fail to process request message event ", "err", err)
+			}
+		case ev := <-sb.chanHandleMsgEv:
+			if err := sb.eventhandler.ProcessHandleMessageEvent(ev); err != nil {
+				logger.Error("fail to process handle message event ", "err", err)
+			}
 		case err := <-sb.chainSub.Err():
 			if err != nil {
 				logger.Error("subbridge block subscription ", "err", err)
@@ -682,6 +727,16 @@ func (sb *SubBridge) loop() {
 				logger.Error("subbridge token-transfer subscription ", "err", err)
 			}
 			return
+		case err := <-sb.reqMsgEvSub.Err():
+			if err != nil {
+				logger.Error("subbridge message-request subscription ", "err", err)
+			}
+			return
+		case err := <-sb.handleMsgEvSub.Err():
+			if err != nil {
+				logger.Error("subbridge message-handle subscription ", "err", err)
+			}
+			return
 		}
 	}
 }
@@ -699,6 +754,10 @@ func (sb *SubBridge) removePeer(id string) {
 	if err := sb.peers.Unregister(id); err != nil {
 		logger.Error("Peer removal failed", "peer", id, "err", err)
 	}
+	// The operator nonce is synced again once a peer of the parent chain comes back.
+	if handler := sb.handlerOf(peer); len(sb.parentChains) > 0 && len(handler.parentPeers()) == 0 {
+		handler.setParentOperatorNonceSynced(false)
+	}
 	// Hard disconnect at the networking layer
 	if peer != nil {
 		peer.GetP2PPeer().Disconnect(p2p.DiscUselessPeer)
@@ -722,7 +781,7 @@ func (sb *SubBridge) handleMsg(p BridgePeer) error {
 	}
 	defer msg.Discard()
 
-	return sb.handler.HandleMainMsg(p, msg)
+	return sb.handlerOf(p).HandleMainMsg(p, msg)
 }
 
 func (sb *SubBridge) syncer() {
@@ -766,11 +825,19 @@ func (sb *SubBridge) Stop() error {
 	sb.reqVTevSub.Unsubscribe()
 	sb.reqVTencodedEvSub.Unsubscribe()
 	sb.handleVTevSub.Unsubscribe()
+	sb.reqMsgEvSub.Unsubscribe()
+	sb.handleMsgEvSub.Unsubscribe()
 	sb.eventMux.Stop()
 	sb.chainDB.Close()
 
 	sb.bridgeManager.Stop()
 	sb.bridgeTxPool.Stop()
+	if sb.transferLimiter != nil {
+		sb.transferLimiter.pool.Stop()
+	}
+	for _, pc := range sb.parentChains {
+		pc.txPool.Stop()
+	}
 	sb.bridgeServer.Stop()
 
 	return nil
@@ -0,0 +1,223 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package sc
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/node/sc/bridgepool"
+)
+
+// transferCheck is the result of checking a value transfer request against the
+// transfer limits.
+type transferCheck uint8
+
+const (
+	transferAllowed transferCheck = iota
+	transferHeld
+	transferRejected
+)
+
+// defaultTransferLimitPeriod is the period of the per-period limits if it is not configured.
+const defaultTransferLimitPeriod = 24 * time.Hour
+
+type transferWindow struct {
+	start time.Time
+	used  *big.Int
+}
+
+// transferLimiter holds the value transfer requests which exceed the per-transfer
+// or per-period limit of their token until an admin approves them, and pauses
+// relaying all requests in an emergency.
+type transferLimiter struct {
+	limits map[common.Address]TransferLimit
+	period time.Duration
+	pool   *bridgepool.HeldTransferPool
+
+	windows map[common.Address]map[common.Address]*transferWindow // handle bridge -> token -> usage in the current period
+	mu      sync.Mutex
+}
+
+// newTransferLimiter creates a transfer limiter whose per-period usage is rebuilt
+// from the requests relayed before, as found in the journal of the pool.
+func newTransferLimiter(config *SCConfig, pool *bridgepool.HeldTransferPool) (*transferLimiter, error) {
+	limits := make(map[common.Address]TransferLimit, len(config.TransferLimits))
+	for _, limit := range config.TransferLimits {
+		limits[limit.Token] = limit
+	}
+	period := time.Duration(config.TransferLimitPeriod) * time.Second
+	if period == 0 {
+		period = defaultTransferLimitPeriod
+	}
+	tl := &transferLimiter{
+		limits:  limits,
+		period:  period,
+		pool:    pool,
+		windows: make(map[common.Address]map[common.Address]*transferWindow),
+	}
+	err := pool.ReplayAllowed(func(record *bridgepool.TransferDecisionRecord) {
+		if limit, ok := tl.limits[record.Token]; ok && limit.PerPeriod != nil && record.Amount != nil {
+			window := tl.window(record.HandleBridge, record.Token, time.Unix(int64(record.Time), 0))
+			window.used.Add(window.used, record.Amount)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tl, nil
+}
+
+// transferAmount returns the amount of tokens the request transfers, or nil if
+// the amount of an ERC1155 transfer cannot be decoded. An ERC721 transfer counts
+// as one token.
+func transferAmount(ev IRequestValueTransferEvent) *big.Int {
+	switch ev.GetTokenType() {
+	case ERC721:
+		return big.NewInt(1)
+	case ERC1155:
+		return GetERC1155Amount(ev)
+	default:
+		return ev.GetValueOrTokenId()
+	}
+}
+
+func newTransferDecisionRecord(bi *BridgeInfo, ev IRequestValueTransferEvent, reason string) *bridgepool.TransferDecisionRecord {
+	return &bridgepool.TransferDecisionRecord{
+		HandleBridge:  bi.address,
+		RequestNonce:  ev.GetRequestNonce(),
+		RequestTxHash: ev.GetRaw().TxHash,
+		TokenType:     ev.GetTokenType(),
+		Token:         ev.GetTokenAddress(),
+		Amount:        transferAmount(ev),
+		Reason:        reason,
+	}
+}
+
+// window returns the usage of the token on the bridge in the current period.
+func (tl *transferLimiter) window(bridge, token common.Address, now time.Time) *transferWindow {
+	windows, ok := tl.windows[bridge]
+	if !ok {
+		windows = make(map[common.Address]*transferWindow)
+		tl.windows[bridge] = windows
+	}
+	window, ok := windows[token]
+	if !ok || now.Sub(window.start) >= tl.period {
+		window = &transferWindow{start: now, used: new(big.Int)}
+		windows[token] = window
+	}
+	return window
+}
+
+// paused returns true if relaying value transfers is paused.
+func (tl *transferLimiter) paused() bool {
+	return tl != nil && tl.pool.Paused()
+}
+
+// check checks the request which the given bridge is about to handle. A request
+// over a limit is held in the pool until an admin approves or rejects it.
+func (tl *transferLimiter) check(bi *BridgeInfo, ev IRequestValueTransferEvent) transferCheck {
+	if tl == nil {
+		return transferAllowed
+	}
+	nonce := ev.GetRequestNonce()
+	switch {
+	case tl.pool.IsRejected(bi.address, nonce):
+		return transferRejected
+	case tl.pool.IsApproved(bi.address, nonce):
+		return transferAllowed
+	case tl.pool.IsHeld(bi.address, nonce):
+		tl.pool.Hold(newTransferDecisionRecord(bi, ev, ""), ev)
+		return transferHeld
+	}
+
+	limit, ok := tl.limits[ev.GetTokenAddress()]
+	if !ok {
+		return transferAllowed
+	}
+
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+
+	amount := transferAmount(ev)
+	reason := ""
+	if amount == nil {
+		reason = "amount cannot be decoded"
+	} else if limit.PerTransfer != nil && amount.Cmp(limit.PerTransfer) > 0 {
+		reason = fmt.Sprintf("amount %v is over the per-transfer limit %v", amount, limit.PerTransfer)
+	} else if limit.PerPeriod != nil {
+		window := tl.window(bi.address, ev.GetTokenAddress(), time.Now())
+		if used := new(big.Int).Add(window.used, amount); used.Cmp(limit.PerPeriod) > 0 {
+			reason = fmt.Sprintf("amount %v in the period is over the per-period limit %v", used, limit.PerPeriod)
+		}
+	}
+	if reason == "" {
+		return transferAllowed
+	}
+
+	logger.Warn("Held a value transfer over the limit", "bridge", bi.address.String(), "nonce", nonce, "token", ev.GetTokenAddress().String(), "reason", reason)
+	tl.pool.Hold(newTransferDecisionRecord(bi, ev, reason), ev)
+	return transferHeld
+}
+
+// commit accounts and journals the request which the given bridge has handled.
+// An approved request is not accounted in the period.
+func (tl *transferLimiter) commit(bi *BridgeInfo, ev IRequestValueTransferEvent) {
+	if tl == nil {
+		return
+	}
+	reason := "approved"
+	if !tl.pool.IsApproved(bi.address, ev.GetRequestNonce()) {
+		// The requests of a token without limits are not journaled.
+		limit, ok := tl.limits[ev.GetTokenAddress()]
+		if !ok {
+			return
+		}
+		if amount := transferAmount(ev); limit.PerPeriod != nil && amount != nil {
+			tl.mu.Lock()
+			window := tl.window(bi.address, ev.GetTokenAddress(), time.Now())
+			window.used.Add(window.used, amount)
+			tl.mu.Unlock()
+		}
+		reason = "within the limits"
+	}
+	tl.pool.Allow(newTransferDecisionRecord(bi, ev, reason))
+}
+
+// usage returns the usage of each limited token on each bridge in the current period.
+func (tl *transferLimiter) usage() map[common.Address]map[common.Address]*big.Int {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+
+	now := time.Now()
+	usage := make(map[common.Address]map[common.Address]*big.Int)
+	for bridge, windows := range tl.windows {
+		for token, window := range windows {
+			if now.Sub(window.start) >= tl.period {
+				continue
+			}
+			if _, ok := usage[bridge]; !ok {
+				usage[bridge] = make(map[common.Address]*big.Int)
+			}
+			usage[bridge][token] = new(big.Int).Set(window.used)
+		}
+	}
+	return usage
+}
@@ -0,0 +1,149 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package sc
+
+import (
+	"math/big"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/contracts/bridge"
+	"github.com/klaytn/klaytn/node/sc/bridgepool"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTransferLimits(t *testing.T) {
+	token := common.HexToAddress("0x0000000000000000000000000000000000000123")
+
+	limits, err := ParseTransferLimits("klay:100:1000, " + token.Hex() + "::0,,")
+	assert.NoError(t, err)
+	assert.Equal(t, []TransferLimit{
+		{Token: common.Address{}, PerTransfer: big.NewInt(100), PerPeriod: big.NewInt(1000)},
+		{Token: token},
+	}, limits)
+
+	limits, err = ParseTransferLimits("")
+	assert.NoError(t, err)
+	assert.Empty(t, limits)
+
+	for _, s := range []string{"klay:1", "klay:1:1,KLAY:2:2", "0x12:1:1", "klay:-1:1", "klay:1:x"} {
+		_, err = ParseTransferLimits(s)
+		assert.Error(t, err, s)
+	}
+}
+
+// TestTransferLimiter checks that the requests over the limits are held until
+// they are approved or rejected, and that the decisions survive a restart.
+func TestTransferLimiter(t *testing.T) {
+	tempDir, err := os.MkdirTemp(os.TempDir(), "sc")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	journalPath := path.Join(tempDir, "bridge_transfer_decisions.rlp")
+
+	token := common.HexToAddress("0x0000000000000000000000000000000000000123")
+	config := &SCConfig{TransferLimits: []TransferLimit{{Token: token, PerTransfer: big.NewInt(100), PerPeriod: big.NewInt(150)}}}
+	bi := &BridgeInfo{address: common.HexToAddress("0x0000000000000000000000000000000000000abc")}
+	request := func(nonce uint64, tokenType uint8, tokenAddr common.Address, amount int64) IRequestValueTransferEvent {
+		return RequestValueTransferEvent{&bridge.BridgeRequestValueTransfer{
+			TokenType:      tokenType,
+			TokenAddress:   tokenAddr,
+			ValueOrTokenId: big.NewInt(amount),
+			RequestNonce:   nonce,
+		}}
+	}
+
+	pool, err := bridgepool.NewHeldTransferPool(journalPath)
+	assert.NoError(t, err)
+	limiter, err := newTransferLimiter(config, pool)
+	assert.NoError(t, err)
+
+	// Tokens without limits are always allowed.
+	assert.Equal(t, transferAllowed, limiter.check(bi, request(0, KLAY, common.Address{}, 1000)))
+
+	// The per-transfer and per-period limits.
+	ev := request(1, ERC20, token, 100)
+	assert.Equal(t, transferAllowed, limiter.check(bi, ev))
+	limiter.commit(bi, ev)
+	assert.Equal(t, transferHeld, limiter.check(bi, request(2, ERC20, token, 101)))
+	assert.Equal(t, transferHeld, limiter.check(bi, request(3, ERC20, token, 60)))
+	ev = request(4, ERC20, token, 50)
+	assert.Equal(t, transferAllowed, limiter.check(bi, ev))
+	limiter.commit(bi, ev)
+	assert.Equal(t, big.NewInt(150), limiter.usage()[bi.address][token])
+
+	held := pool.Held()
+	assert.Equal(t, 2, len(held))
+	assert.Equal(t, uint64(2), held[0].RequestNonce)
+	assert.Equal(t, big.NewInt(101), held[0].Amount)
+
+	// An approved request is allowed once and a rejected one is dropped.
+	approved, err := pool.Approve(bi.address, 2, "checked")
+	assert.NoError(t, err)
+	assert.NotNil(t, approved)
+	assert.NoError(t, pool.Reject(bi.address, 3, "suspicious"))
+	assert.Equal(t, bridgepool.ErrNoHeldTransfer, pool.Reject(bi.address, 5, ""))
+	assert.Equal(t, transferAllowed, limiter.check(bi, request(2, ERC20, token, 101)))
+	assert.Equal(t, transferRejected, limiter.check(bi, request(3, ERC20, token, 60)))
+
+	// An approved request which is relayed is not accounted in the period.
+	ev = request(9, ERC20, token, 200)
+	assert.Equal(t, transferHeld, limiter.check(bi, ev))
+	_, err = pool.Approve(bi.address, 9, "")
+	assert.NoError(t, err)
+	assert.Equal(t, transferAllowed, limiter.check(bi, ev))
+	limiter.commit(bi, ev)
+	assert.Equal(t, big.NewInt(150), limiter.usage()[bi.address][token])
+
+	// An ERC1155 request whose amount cannot be decoded is held.
+	assert.Equal(t, transferHeld, limiter.check(bi, request(10, ERC1155, token, 1)))
+	assert.Equal(t, "amount cannot be decoded", pool.Held()[0].Reason)
+	assert.NoError(t, pool.Reject(bi.address, 10, ""))
+
+	assert.NoError(t, pool.SetPaused(true, "emergency"))
+	assert.True(t, limiter.paused())
+	assert.Equal(t, transferHeld, limiter.check(bi, request(6, ERC20, token, 1000)))
+	pool.Stop()
+
+	// The decisions are restored from the journal.
+	pool, err = bridgepool.NewHeldTransferPool(journalPath)
+	assert.NoError(t, err)
+	defer pool.Stop()
+	limiter, err = newTransferLimiter(config, pool)
+	assert.NoError(t, err)
+
+	// So is the usage in the period, which an approved request does not count in.
+	assert.Equal(t, big.NewInt(150), limiter.usage()[bi.address][token])
+	assert.Equal(t, transferHeld, limiter.check(bi, request(7, ERC20, token, 1)))
+
+	assert.True(t, limiter.paused())
+	assert.True(t, pool.IsApproved(bi.address, 2))
+	assert.True(t, pool.IsRejected(bi.address, 3))
+	assert.True(t, pool.IsHeld(bi.address, 6))
+	assert.Equal(t, 2, len(pool.Held()))
+
+	// A request held before the restart is released without its event.
+	approved, err = pool.Approve(bi.address, 6, "")
+	assert.NoError(t, err)
+	assert.Nil(t, approved)
+	assert.Equal(t, transferAllowed, limiter.check(bi, request(6, ERC20, token, 1000)))
+
+	var nilLimiter *transferLimiter
+	assert.False(t, nilLimiter.paused())
+	assert.Equal(t, transferAllowed, nilLimiter.check(bi, request(8, ERC20, token, 1000)))
+}
@@ -49,6 +49,9 @@ type valueTransferRecovery struct {
 	childEvents      []IRequestValueTransferEvent
 	parentEvents     []IRequestValueTransferEvent
 
+	child2parentMsgHint *valueTransferHint
+	parent2childMsgHint *valueTransferHint
+
 	config      *SCConfig
 	cBridgeInfo *BridgeInfo
 	pBridgeInfo *BridgeInfo
@@ -71,16 +74,18 @@ func isHandledEvent(to *BridgeInfo, ev IRequestValueTransferEvent) bool {
 // NewValueTransferRecovery creates a new value transfer recovery structure.
 func NewValueTransferRecovery(config *SCConfig, cBridgeInfo, pBridgeInfo *BridgeInfo) *valueTransferRecovery {
 	return &valueTransferRecovery{
-		stopCh:           make(chan interface{}),
-		isRunning:        false,
-		wg:               sync.WaitGroup{},
-		child2parentHint: &valueTransferHint{},
-		parent2childHint: &valueTransferHint{},
-		childEvents:      []IRequestValueTransferEvent{},
-		parentEvents:     []IRequestValueTransferEvent{},
-		config:           config,
-		cBridgeInfo:      cBridgeInfo,
-		pBridgeInfo:      pBridgeInfo,
+		stopCh:              make(chan interface{}),
+		isRunning:           false,
+		wg:                  sync.WaitGroup{},
+		child2parentHint:    &valueTransferHint{},
+		parent2childHint:    &valueTransferHint{},
+		child2parentMsgHint: &valueTransferHint{},
+		parent2childMsgHint: &valueTransferHint{},
+		childEvents:         []IRequestValueTransferEvent{},
+		parentEvents:        []IRequestValueTransferEvent{},
+		config:              config,
+		cBridgeInfo:         cBridgeInfo,
+		pBridgeInfo:         pBridgeInfo,
 	}
 }
 
@@ -160,6 +165,12 @@ func (vtr *valueTransferRecovery) Recover() error {
 		return err
 	}
 
+	logger.Trace("recover pending messages")
+	err = vtr.recoverMessages()
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -317,7 +328,7 @@ pendingTxLoop:
 				}
 			}
 		}
-		// For the FilterRequestValueTransferEncoded type
+		// For the FilterRequestValueTransferEncoded type, which ERC721 and ERC1155 requests use
 		for reqVTencodedDataIt.Next() {
 			logger.Trace("pending nonce in the RequestValueTransferEncoded event", "requestNonce", reqVTencodedDataIt.Event.RequestNonce)
 			if reqVTencodedDataIt.Event.RequestNonce >= hint.handleNonce {
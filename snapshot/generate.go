@@ -241,6 +241,15 @@ func (result *proofResult) forEach(callback func(key []byte, val []byte) error)
 	return nil
 }
 
+// trieOwner returns the owner of the trie whose snapshot entries are stored
+// under the given prefix. It is zero for the account trie.
+func trieOwner(prefix []byte) common.Hash {
+	if len(prefix) != len(database.SnapshotStoragePrefix)+common.HashLength {
+		return common.Hash{}
+	}
+	return common.BytesToHash(prefix[len(database.SnapshotStoragePrefix):])
+}
+
 // proveRange proves the snapshot segment with particular prefix is "valid".
 // The iteration start point will be assigned if the iterator is restored from
 // the last interruption. Max will be assigned in order to limit the maximum
@@ -325,7 +334,7 @@ func (dl *diskLayer) proveRange(stats *generatorStats, root common.Hash, prefix
 		return &proofResult{keys: keys, vals: vals}, nil
 	}
 	// Snap state is chunked, generate edge proofs for verification.
-	tr, err := statedb.NewTrie(root, dl.triedb, nil)
+	tr, err := statedb.NewTrie(root, dl.triedb, &statedb.TrieOpts{Owner: trieOwner(prefix)})
 	if err != nil {
 		stats.Log("Trie missing, state snapshotting paused", dl.root, dl.genMarker)
 		return nil, errMissingTrie
@@ -448,7 +457,7 @@ func (dl *diskLayer) generateRange(root common.Hash, prefix []byte, kind string,
 	}
 	tr := result.tr
 	if tr == nil {
-		tr, err = statedb.NewTrie(root, dl.triedb, nil)
+		tr, err = statedb.NewTrie(root, dl.triedb, &statedb.TrieOpts{Owner: trieOwner(prefix)})
 		if err != nil {
 			stats.Log("Trie missing, state snapshotting paused", dl.root, dl.genMarker)
 			return false, nil, errMissingTrie
@@ -140,6 +140,57 @@ func TestChildChainData_ReadAndWrite_ReceiptFromParentChain(t *testing.T) {
 	assert.Nil(t, rctFromDB)
 }
 
+func TestChildChainData_ReadAndWrite_ParentChainData(t *testing.T) {
+	dir, err := os.MkdirTemp("", "klaytn-test-child-chain-data")
+	if err != nil {
+		t.Fatalf("cannot create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	dbc := &DBConfig{Dir: dir, DBType: LevelDB, LevelDBCacheSize: 32, OpenFilesLimit: 32}
+	dbm := NewDBManager(dbc)
+	defer dbm.Close()
+
+	parentChainID := uint64(2019)
+
+	// The anchored block number of each parent chain is kept apart.
+	dbm.WriteAnchoredBlockNumber(123)
+	assert.Equal(t, uint64(0), dbm.ReadParentChainAnchoredBlockNumber(parentChainID))
+
+	dbm.WriteParentChainAnchoredBlockNumber(parentChainID, 321)
+	assert.Equal(t, uint64(321), dbm.ReadParentChainAnchoredBlockNumber(parentChainID))
+	assert.Equal(t, uint64(0), dbm.ReadParentChainAnchoredBlockNumber(parentChainID+1))
+	assert.Equal(t, uint64(123), dbm.ReadAnchoredBlockNumber())
+
+	// So are the receipts of the same block.
+	blockHash := common.HexToHash("0x0e0e0e0e0e0e0e0e0e0e0e0e0e0e0e0e")
+	rct := &types.Receipt{TxHash: common.BigToHash(big.NewInt(12345)), GasUsed: 12345, Status: types.ReceiptStatusSuccessful}
+	parentRct := &types.Receipt{TxHash: common.BigToHash(big.NewInt(54321)), GasUsed: 54321, Status: types.ReceiptStatusFailed}
+
+	dbm.WriteReceiptFromParentChain(blockHash, rct)
+	assert.Nil(t, dbm.ReadParentChainReceipt(parentChainID, blockHash))
+
+	dbm.WriteParentChainReceipt(parentChainID, blockHash, parentRct)
+	rctFromDB := dbm.ReadParentChainReceipt(parentChainID, blockHash)
+	assert.Equal(t, parentRct.Status, rctFromDB.Status)
+	assert.Equal(t, parentRct.GasUsed, rctFromDB.GasUsed)
+	assert.Equal(t, parentRct.TxHash, rctFromDB.TxHash)
+	assert.Equal(t, rct.TxHash, dbm.ReadReceiptFromParentChain(blockHash).TxHash)
+	assert.Nil(t, dbm.ReadParentChainReceipt(parentChainID+1, blockHash))
+
+	// And the anchoring batches.
+	batch := &types.AnchoringBatch{StartBlockNumber: 5, BlockHashes: []common.Hash{{5}, {6}}, AnchoringTxHash: common.Hash{1}}
+	parentBatch := &types.AnchoringBatch{StartBlockNumber: 3, BlockHashes: []common.Hash{{3}, {4}, {5}}, AnchoringTxHash: common.Hash{2}}
+	dbm.WriteAnchoringBatch(batch)
+	assert.Nil(t, dbm.ReadParentChainAnchoringBatch(parentChainID, 5))
+
+	dbm.WriteParentChainAnchoringBatch(parentChainID, parentBatch)
+	assert.Equal(t, parentBatch, dbm.ReadParentChainAnchoringBatch(parentChainID, 5))
+	assert.Equal(t, batch, dbm.ReadAnchoringBatch(5))
+	assert.Nil(t, dbm.ReadAnchoringBatch(3))
+	assert.Nil(t, dbm.ReadParentChainAnchoringBatch(parentChainID+1, 5))
+}
+
 func TestChildChainData_ReadAndWrite_ValueTransferTxHash(t *testing.T) {
 	dir, err := os.MkdirTemp("", "klaytn-test-child-chain-data")
 	if err != nil {
@@ -218,3 +269,29 @@ func TestChildChainData_ReadAndWrite_OperatorFeePayer(t *testing.T) {
 		assert.Equal(t, common.Address{}, feePayer)
 	}
 }
+
+func TestChildChainData_ReadAndWrite_AnchoringBatch(t *testing.T) {
+	dir, err := os.MkdirTemp("", "klaytn-test-child-chain-data")
+	if err != nil {
+		t.Fatalf("cannot create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	dbc := &DBConfig{Dir: dir, DBType: LevelDB, LevelDBCacheSize: 32, OpenFilesLimit: 32}
+	dbm := NewDBManager(dbc)
+	defer dbm.Close()
+
+	batch := &types.AnchoringBatch{
+		StartBlockNumber: 5,
+		BlockHashes:      []common.Hash{common.HexToHash("0x05"), common.HexToHash("0x06"), common.HexToHash("0x07")},
+		AnchoringTxHash:  common.HexToHash("0x0e0e"),
+	}
+	assert.Nil(t, dbm.ReadAnchoringBatch(5))
+
+	dbm.WriteAnchoringBatch(batch)
+	for bn := uint64(5); bn <= 7; bn++ {
+		assert.Equal(t, batch, dbm.ReadAnchoringBatch(bn))
+	}
+	assert.Nil(t, dbm.ReadAnchoringBatch(4))
+	assert.Nil(t, dbm.ReadAnchoringBatch(8))
+}
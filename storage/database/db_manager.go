@@ -64,6 +64,8 @@ type DBManager interface {
 	getDatabase(DBEntryType) Database
 	CreateMigrationDBAndSetStatus(blockNum uint64) error
 	FinishStateMigration(succeed bool) chan struct{}
+	ReshardStateTrieDB(numShards uint) (<-chan error, error)
+	StateTrieReshardingStatus() (ReshardingStatus, error)
 	GetStateTrieDB() Database
 	GetStateTrieMigrationDB() Database
 	GetMiscDB() Database
@@ -89,6 +91,10 @@ type DBManager interface {
 	ReadFastTrieProgress() uint64
 	WriteFastTrieProgress(count uint64)
 
+	ReadHistoryTail() uint64
+	WriteHistoryTail(number uint64)
+	DeleteHistoryTail()
+
 	HasHeader(hash common.Hash, number uint64) bool
 	ReadHeader(hash common.Hash, number uint64) *types.Header
 	ReadHeaderRLP(hash common.Hash, number uint64) rlp.RawValue
@@ -177,6 +183,19 @@ type DBManager interface {
 	WriteLastPrunedBlockNumber(blockNumber uint64)
 	ReadLastPrunedBlockNumber() (uint64, error)
 
+	// Path-based state trie
+	ReadTrieNodeByPath(owner common.Hash, path []byte) []byte
+	ReadStorageTrieNodes(owner common.Hash) ([][]byte, [][]byte)
+	PutTrieNodeByPathToBatch(batch Batch, owner common.Hash, path []byte, node []byte)
+	DeleteTrieNodeByPathFromBatch(batch Batch, owner common.Hash, path []byte)
+	ReadTrieHistory(number uint64) []byte
+	WriteTrieHistory(number uint64, history []byte)
+	DeleteTrieHistory(number uint64)
+	ReadTrieHistoryRange() (uint64, uint64)
+	WriteTrieHistoryRange(tail, head uint64)
+	ReadStateScheme() string
+	WriteStateScheme(scheme string)
+
 	// from accessors_indexes.go
 	ReadTxLookupEntry(hash common.Hash) (common.Hash, uint64, uint64)
 	WriteTxLookupEntries(block *types.Block)
@@ -258,6 +277,17 @@ type DBManager interface {
 	WriteReceiptFromParentChain(blockHash common.Hash, receipt *types.Receipt)
 	ReadReceiptFromParentChain(blockHash common.Hash) *types.Receipt
 
+	// the parent chain variants are used for the additional parent chains of a child chain.
+	WriteParentChainAnchoredBlockNumber(parentChainID uint64, blockNum uint64)
+	ReadParentChainAnchoredBlockNumber(parentChainID uint64) uint64
+	WriteParentChainReceipt(parentChainID uint64, blockHash common.Hash, receipt *types.Receipt)
+	ReadParentChainReceipt(parentChainID uint64, blockHash common.Hash) *types.Receipt
+
+	WriteAnchoringBatch(batch *types.AnchoringBatch)
+	ReadAnchoringBatch(blockNum uint64) *types.AnchoringBatch
+	WriteParentChainAnchoringBatch(parentChainID uint64, batch *types.AnchoringBatch)
+	ReadParentChainAnchoringBatch(parentChainID uint64, blockNum uint64) *types.AnchoringBatch
+
 	WriteHandleTxHashFromRequestTxHash(rTx, hTx common.Hash)
 	ReadHandleTxHashFromRequestTxHash(rTx common.Hash) common.Hash
 
@@ -518,7 +548,9 @@ func databaseDBManager(dbc *DBConfig) (*databaseManager, error) {
 			newDBC := getDBEntryConfig(dbc, entryType, dir)
 			if dbc.NumStateTrieShards > 1 && !dbc.DBType.selfShardable() { // make non-sharding db if the db is sharding itself
 				db, err = newShardedDB(newDBC, entryType, dbc.NumStateTrieShards)
-			} else {
+			} else if dbc.DBType.selfShardable() {
+				db, err = newDatabase(newDBC, entryType)
+			} else if err = checkNotSharded(newDBC); err == nil {
 				db, err = newDatabase(newDBC, entryType)
 			}
 		default:
@@ -775,6 +807,10 @@ func (dbm *databaseManager) CreateMigrationDBAndSetStatus(blockNum uint64) error
 		logger.Warn("Setting a new database for state trie migration is allowed for non-single database only")
 		return errors.New("singleDB does not support state trie migration")
 	}
+	if status, err := dbm.StateTrieReshardingStatus(); err == nil && status.InProgress {
+		logger.Warn("Failed to set a new state trie migration db. Resharding is in progress")
+		return errMigrationInResharding
+	}
 
 	logger.Info("Start setting a new database for state trie migration", "blockNum", blockNum)
 
@@ -834,6 +870,32 @@ func (dbm *databaseManager) FinishStateMigration(succeed bool) chan struct{} {
 	return endCheck
 }
 
+// ReshardStateTrieDB increases the number of shards of stateTrieDB in the
+// background. The result is sent to the returned channel when it is finished.
+func (dbm *databaseManager) ReshardStateTrieDB(numShards uint) (<-chan error, error) {
+	if dbm.InMigration() {
+		return nil, errReshardInMigration
+	}
+
+	dbm.lockInMigration.RLock()
+	defer dbm.lockInMigration.RUnlock()
+
+	sdb, ok := dbm.dbs[StateTrieDB].(*shardedDB)
+	if !ok {
+		return nil, errReshardNotShardedDB
+	}
+	return sdb.Reshard(numShards)
+}
+
+// StateTrieReshardingStatus returns the progress of resharding stateTrieDB.
+func (dbm *databaseManager) StateTrieReshardingStatus() (ReshardingStatus, error) {
+	sdb, ok := dbm.GetStateTrieDB().(*shardedDB)
+	if !ok {
+		return ReshardingStatus{}, errReshardNotShardedDB
+	}
+	return sdb.ReshardingStatus(), nil
+}
+
 func removeDB(dbPath string, endCheck chan struct{}) {
 	defer func() {
 		if endCheck != nil {
@@ -1074,6 +1136,39 @@ func (dbm *databaseManager) WriteFastTrieProgress(count uint64) {
 	}
 }
 
+// History Tail operations.
+// ReadHistoryTail retrieves the lowest block number above the genesis whose
+// header is stored, if the chain started from a trusted checkpoint. The blocks
+// between the genesis and the tail are missing, and so may be the body of the
+// tail block. It returns 0 if the history is complete.
+func (dbm *databaseManager) ReadHistoryTail() uint64 {
+	db := dbm.getDatabase(MiscDB)
+	data, _ := db.Get(historyTailKey)
+	if len(data) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(data)
+}
+
+// WriteHistoryTail stores the lowest block number above the genesis whose
+// header is stored.
+func (dbm *databaseManager) WriteHistoryTail(number uint64) {
+	db := dbm.getDatabase(MiscDB)
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], number)
+	if err := db.Put(historyTailKey, buf[:]); err != nil {
+		logger.Crit("Failed to store history tail", "err", err)
+	}
+}
+
+// DeleteHistoryTail deletes the history tail once the history is complete.
+func (dbm *databaseManager) DeleteHistoryTail() {
+	db := dbm.getDatabase(MiscDB)
+	if err := db.Delete(historyTailKey); err != nil {
+		logger.Crit("Failed to remove history tail", "err", err)
+	}
+}
+
 // (Block)Header operations.
 // HasHeader verifies the existence of a block header corresponding to the hash.
 func (dbm *databaseManager) HasHeader(hash common.Hash, number uint64) bool {
@@ -2014,6 +2109,102 @@ func (dbm *databaseManager) ReadLastPrunedBlockNumber() (uint64, error) {
 	return binary.LittleEndian.Uint64(lastPruned), nil
 }
 
+// ReadTrieNodeByPath retrieves the trie node stored at the given path of the
+// trie owned by the given account hash. Nodes of the account trie have the zero owner.
+func (dbm *databaseManager) ReadTrieNodeByPath(owner common.Hash, path []byte) []byte {
+	db := dbm.getDatabase(StateTrieDB)
+	enc, _ := db.Get(TrieNodePathKey(owner, path))
+	return enc
+}
+
+// ReadStorageTrieNodes retrieves the paths and the nodes of the storage trie
+// owned by the given account hash.
+func (dbm *databaseManager) ReadStorageTrieNodes(owner common.Hash) ([][]byte, [][]byte) {
+	prefix := storageTrieNodeKey(owner, nil)
+	it := dbm.getDatabase(StateTrieDB).NewIterator(prefix, nil)
+	defer it.Release()
+
+	var paths, nodes [][]byte
+	for it.Next() {
+		paths = append(paths, common.CopyBytes(it.Key()[len(prefix):]))
+		nodes = append(nodes, common.CopyBytes(it.Value()))
+	}
+	return paths, nodes
+}
+
+// PutTrieNodeByPathToBatch puts the trie node at the given path to the batch.
+func (dbm *databaseManager) PutTrieNodeByPathToBatch(batch Batch, owner common.Hash, path []byte, node []byte) {
+	if err := batch.Put(TrieNodePathKey(owner, path), node); err != nil {
+		logger.Crit("Failed to store trie node", "owner", owner, "path", path, "err", err)
+	}
+}
+
+// DeleteTrieNodeByPathFromBatch puts the deletion of the trie node at the given path to the batch.
+func (dbm *databaseManager) DeleteTrieNodeByPathFromBatch(batch Batch, owner common.Hash, path []byte) {
+	if err := batch.Delete(TrieNodePathKey(owner, path)); err != nil {
+		logger.Crit("Failed to delete trie node", "owner", owner, "path", path, "err", err)
+	}
+}
+
+// ReadTrieHistory retrieves the reverse diff of the trie nodes written by the given block.
+func (dbm *databaseManager) ReadTrieHistory(number uint64) []byte {
+	db := dbm.getDatabase(MiscDB)
+	data, _ := db.Get(trieHistoryKey(number))
+	return data
+}
+
+// WriteTrieHistory stores the reverse diff of the trie nodes written by the given block.
+func (dbm *databaseManager) WriteTrieHistory(number uint64, history []byte) {
+	db := dbm.getDatabase(MiscDB)
+	if err := db.Put(trieHistoryKey(number), history); err != nil {
+		logger.Crit("Failed to store trie history", "number", number, "err", err)
+	}
+}
+
+// DeleteTrieHistory removes the reverse diff of the trie nodes written by the given block.
+func (dbm *databaseManager) DeleteTrieHistory(number uint64) {
+	db := dbm.getDatabase(MiscDB)
+	if err := db.Delete(trieHistoryKey(number)); err != nil {
+		logger.Crit("Failed to delete trie history", "number", number, "err", err)
+	}
+}
+
+// ReadTrieHistoryRange retrieves the block numbers of the oldest and the newest
+// trie histories. Both are zero if no history is stored.
+func (dbm *databaseManager) ReadTrieHistoryRange() (uint64, uint64) {
+	db := dbm.getDatabase(MiscDB)
+	data, _ := db.Get(trieHistoryRangeKey)
+	if len(data) != 16 {
+		return 0, 0
+	}
+	return binary.BigEndian.Uint64(data[:8]), binary.BigEndian.Uint64(data[8:])
+}
+
+// WriteTrieHistoryRange stores the block numbers of the oldest and the newest trie histories.
+func (dbm *databaseManager) WriteTrieHistoryRange(tail, head uint64) {
+	db := dbm.getDatabase(MiscDB)
+	data := append(common.Int64ToByteBigEndian(tail), common.Int64ToByteBigEndian(head)...)
+	if err := db.Put(trieHistoryRangeKey, data); err != nil {
+		logger.Crit("Failed to store trie history range", "err", err)
+	}
+}
+
+// ReadStateScheme retrieves the scheme used to store the state trie nodes.
+// It returns an empty string if the scheme has never been stored.
+func (dbm *databaseManager) ReadStateScheme() string {
+	db := dbm.getDatabase(MiscDB)
+	data, _ := db.Get(stateSchemeKey)
+	return string(data)
+}
+
+// WriteStateScheme stores the scheme used to store the state trie nodes.
+func (dbm *databaseManager) WriteStateScheme(scheme string) {
+	db := dbm.getDatabase(MiscDB)
+	if err := db.Put(stateSchemeKey, []byte(scheme)); err != nil {
+		logger.Crit("Failed to store state scheme", "err", err)
+	}
+}
+
 // ReadTxLookupEntry retrieves the positional metadata associated with a transaction
 // hash to allow retrieving the transaction or receipt by hash.
 func (dbm *databaseManager) ReadTxLookupEntry(hash common.Hash) (common.Hash, uint64, uint64) {
@@ -2497,16 +2688,34 @@ func (dbm *databaseManager) GetLastIndexedBlockNumber() uint64 {
 
 // WriteAnchoredBlockNumber writes the block number whose data has been anchored to the parent chain.
 func (dbm *databaseManager) WriteAnchoredBlockNumber(blockNum uint64) {
-	key := lastServiceChainTxReceiptKey
+	dbm.writeAnchoredBlockNumber(lastServiceChainTxReceiptKey, blockNum)
+}
+
+// ReadAnchoredBlockNumber returns the latest block number whose data has been anchored to the parent chain.
+func (dbm *databaseManager) ReadAnchoredBlockNumber() uint64 {
+	return dbm.readAnchoredBlockNumber(lastServiceChainTxReceiptKey)
+}
+
+// WriteParentChainAnchoredBlockNumber writes the block number whose data has been
+// anchored to the given additional parent chain.
+func (dbm *databaseManager) WriteParentChainAnchoredBlockNumber(parentChainID uint64, blockNum uint64) {
+	dbm.writeAnchoredBlockNumber(parentChainKey(parentChainID, lastServiceChainTxReceiptKey), blockNum)
+}
+
+// ReadParentChainAnchoredBlockNumber returns the latest block number whose data has
+// been anchored to the given additional parent chain.
+func (dbm *databaseManager) ReadParentChainAnchoredBlockNumber(parentChainID uint64) uint64 {
+	return dbm.readAnchoredBlockNumber(parentChainKey(parentChainID, lastServiceChainTxReceiptKey))
+}
+
+func (dbm *databaseManager) writeAnchoredBlockNumber(key []byte, blockNum uint64) {
 	db := dbm.getDatabase(bridgeServiceDB)
 	if err := db.Put(key, common.Int64ToByteBigEndian(blockNum)); err != nil {
 		logger.Crit("Failed to store LatestServiceChainBlockNum", "blockNumber", blockNum, "err", err)
 	}
 }
 
-// ReadAnchoredBlockNumber returns the latest block number whose data has been anchored to the parent chain.
-func (dbm *databaseManager) ReadAnchoredBlockNumber() uint64 {
-	key := lastServiceChainTxReceiptKey
+func (dbm *databaseManager) readAnchoredBlockNumber(key []byte) uint64 {
 	db := dbm.getDatabase(bridgeServiceDB)
 	data, _ := db.Get(key)
 	if len(data) != 8 {
@@ -2540,23 +2749,41 @@ func (dbm *databaseManager) ReadHandleTxHashFromRequestTxHash(rTx common.Hash) c
 // WriteReceiptFromParentChain writes a receipt received from parent chain to child chain
 // with corresponding block hash. It assumes that a child chain has only one parent chain.
 func (dbm *databaseManager) WriteReceiptFromParentChain(blockHash common.Hash, receipt *types.Receipt) {
+	dbm.writeReceiptFromParentChain(receiptFromParentChainKey(blockHash), receipt)
+}
+
+// ReadReceiptFromParentChain returns a receipt received from parent chain to child chain
+// with corresponding block hash. It assumes that a child chain has only one parent chain.
+func (dbm *databaseManager) ReadReceiptFromParentChain(blockHash common.Hash) *types.Receipt {
+	return dbm.readReceiptFromParentChain(receiptFromParentChainKey(blockHash))
+}
+
+// WriteParentChainReceipt writes a receipt received from the given additional
+// parent chain with corresponding block hash.
+func (dbm *databaseManager) WriteParentChainReceipt(parentChainID uint64, blockHash common.Hash, receipt *types.Receipt) {
+	dbm.writeReceiptFromParentChain(parentChainKey(parentChainID, receiptFromParentChainKey(blockHash)), receipt)
+}
+
+// ReadParentChainReceipt returns a receipt received from the given additional
+// parent chain with corresponding block hash.
+func (dbm *databaseManager) ReadParentChainReceipt(parentChainID uint64, blockHash common.Hash) *types.Receipt {
+	return dbm.readReceiptFromParentChain(parentChainKey(parentChainID, receiptFromParentChainKey(blockHash)))
+}
+
+func (dbm *databaseManager) writeReceiptFromParentChain(key []byte, receipt *types.Receipt) {
 	receiptForStorage := (*types.ReceiptForStorage)(receipt)
 	db := dbm.getDatabase(bridgeServiceDB)
 	byte, err := rlp.EncodeToBytes(receiptForStorage)
 	if err != nil {
 		logger.Crit("Failed to RLP encode receipt received from parent chain", "receipt.TxHash", receipt.TxHash, "err", err)
 	}
-	key := receiptFromParentChainKey(blockHash)
 	if err = db.Put(key, byte); err != nil {
 		logger.Crit("Failed to store receipt received from parent chain", "receipt.TxHash", receipt.TxHash, "err", err)
 	}
 }
 
-// ReadReceiptFromParentChain returns a receipt received from parent chain to child chain
-// with corresponding block hash. It assumes that a child chain has only one parent chain.
-func (dbm *databaseManager) ReadReceiptFromParentChain(blockHash common.Hash) *types.Receipt {
+func (dbm *databaseManager) readReceiptFromParentChain(key []byte) *types.Receipt {
 	db := dbm.getDatabase(bridgeServiceDB)
-	key := receiptFromParentChainKey(blockHash)
 	data, _ := db.Get(key)
 	if data == nil || len(data) == 0 {
 		return nil
@@ -2569,6 +2796,71 @@ func (dbm *databaseManager) ReadReceiptFromParentChain(blockHash common.Hash) *t
 	return (*types.Receipt)(serviceChainTxReceipt)
 }
 
+// WriteAnchoringBatch writes a batch of blocks anchored by one anchoring tx and
+// indexes it by the number of each block in it.
+func (dbm *databaseManager) WriteAnchoringBatch(anchoringBatch *types.AnchoringBatch) {
+	dbm.writeAnchoringBatch(func(key []byte) []byte { return key }, anchoringBatch)
+}
+
+// ReadAnchoringBatch returns the anchoring batch which includes the given block number.
+func (dbm *databaseManager) ReadAnchoringBatch(blockNum uint64) *types.AnchoringBatch {
+	return dbm.readAnchoringBatch(func(key []byte) []byte { return key }, blockNum)
+}
+
+// WriteParentChainAnchoringBatch writes a batch of blocks anchored to the given
+// additional parent chain by one anchoring tx.
+func (dbm *databaseManager) WriteParentChainAnchoringBatch(parentChainID uint64, anchoringBatch *types.AnchoringBatch) {
+	dbm.writeAnchoringBatch(func(key []byte) []byte { return parentChainKey(parentChainID, key) }, anchoringBatch)
+}
+
+// ReadParentChainAnchoringBatch returns the batch anchored to the given additional
+// parent chain which includes the given block number.
+func (dbm *databaseManager) ReadParentChainAnchoringBatch(parentChainID uint64, blockNum uint64) *types.AnchoringBatch {
+	return dbm.readAnchoringBatch(func(key []byte) []byte { return parentChainKey(parentChainID, key) }, blockNum)
+}
+
+// writeAnchoringBatch stores the anchoring batch and its index under the keys
+// returned by keyOf.
+func (dbm *databaseManager) writeAnchoringBatch(keyOf func([]byte) []byte, anchoringBatch *types.AnchoringBatch) {
+	data, err := rlp.EncodeToBytes(anchoringBatch)
+	if err != nil {
+		logger.Crit("Failed to RLP encode anchoring batch", "startBlockNumber", anchoringBatch.StartBlockNumber, "err", err)
+	}
+	batch := dbm.getDatabase(bridgeServiceDB).NewBatch()
+	defer batch.Release()
+
+	if err := batch.Put(keyOf(anchoringBatchKey(anchoringBatch.StartBlockNumber)), data); err != nil {
+		logger.Crit("Failed to store anchoring batch", "startBlockNumber", anchoringBatch.StartBlockNumber, "err", err)
+	}
+	startBlockNum := common.Int64ToByteBigEndian(anchoringBatch.StartBlockNumber)
+	for i := range anchoringBatch.BlockHashes {
+		if err := batch.Put(keyOf(anchoringBatchIndexKey(anchoringBatch.StartBlockNumber+uint64(i))), startBlockNum); err != nil {
+			logger.Crit("Failed to store anchoring batch index", "startBlockNumber", anchoringBatch.StartBlockNumber, "err", err)
+		}
+	}
+	if err := batch.Write(); err != nil {
+		logger.Crit("Failed to write anchoring batch", "startBlockNumber", anchoringBatch.StartBlockNumber, "err", err)
+	}
+}
+
+func (dbm *databaseManager) readAnchoringBatch(keyOf func([]byte) []byte, blockNum uint64) *types.AnchoringBatch {
+	db := dbm.getDatabase(bridgeServiceDB)
+	startBlockNum, _ := db.Get(keyOf(anchoringBatchIndexKey(blockNum)))
+	if len(startBlockNum) != 8 {
+		return nil
+	}
+	data, _ := db.Get(keyOf(anchoringBatchKey(binary.BigEndian.Uint64(startBlockNum))))
+	if len(data) == 0 {
+		return nil
+	}
+	anchoringBatch := new(types.AnchoringBatch)
+	if err := rlp.DecodeBytes(data, anchoringBatch); err != nil {
+		logger.Error("Invalid anchoring batch RLP", "blockNumber", blockNum, "err", err)
+		return nil
+	}
+	return anchoringBatch
+}
+
 // WriteParentOperatorFeePayer writes a fee payer of parent operator.
 func (dbm *databaseManager) WriteParentOperatorFeePayer(feePayer common.Address) {
 	key := parentOperatorFeePayerPrefix
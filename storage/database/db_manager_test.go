@@ -209,6 +209,23 @@ func TestDBManager_FastTrieProgress(t *testing.T) {
 	}
 }
 
+// TestDBManager_HistoryTail tests read, write and delete operations of the history tail.
+func TestDBManager_HistoryTail(t *testing.T) {
+	log.EnableLogForTest(log.LvlCrit, log.LvlTrace)
+	for _, dbm := range dbManagers {
+		assert.Equal(t, uint64(0), dbm.ReadHistoryTail())
+
+		dbm.WriteHistoryTail(num1)
+		assert.Equal(t, num1, dbm.ReadHistoryTail())
+
+		dbm.WriteHistoryTail(num2)
+		assert.Equal(t, num2, dbm.ReadHistoryTail())
+
+		dbm.DeleteHistoryTail()
+		assert.Equal(t, uint64(0), dbm.ReadHistoryTail())
+	}
+}
+
 // TestDBManager_Header tests read, write and delete operations of blockchain headers.
 func TestDBManager_Header(t *testing.T) {
 	log.EnableLogForTest(log.LvlCrit, log.LvlTrace)
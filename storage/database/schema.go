@@ -48,6 +48,10 @@ var (
 	// fastTrieProgressKey tracks the number of trie entries imported during fast sync.
 	fastTrieProgressKey = []byte("TrieSync")
 
+	// historyTailKey tracks the lowest stored block header of a chain started
+	// from a trusted checkpoint.
+	historyTailKey = []byte("HistoryTail")
+
 	validSectionKey = []byte("count")
 
 	sectionHeadKeyPrefix = []byte("shead")
@@ -90,6 +94,12 @@ var (
 	SnapshotStoragePrefix = []byte("o") // SnapshotStoragePrefix + account hash + storage hash -> storage trie value
 	codePrefix            = []byte("c") // codePrefix + code hash -> contract code
 
+	trieNodeAccountPrefix = []byte("A")            // trieNodeAccountPrefix + hex path -> account trie node (path scheme)
+	trieNodeStoragePrefix = []byte("O")            // trieNodeStoragePrefix + account hash + hex path -> storage trie node (path scheme)
+	trieHistoryPrefix     = []byte("TrieHistory-") // trieHistoryPrefix + num (uint64 big endian) -> reverse diff of trie nodes
+	trieHistoryRangeKey   = []byte("TrieHistoryRange")
+	stateSchemeKey        = []byte("StateScheme")
+
 	preimagePrefix = []byte("secure-key-")  // preimagePrefix + hash -> preimage
 	configPrefix   = []byte("klay-config-") // config prefix for the db
 
@@ -109,6 +119,9 @@ var (
 	lastServiceChainTxReceiptKey    = []byte("LastServiceChainTxReceipt")
 	lastIndexedBlockKey             = []byte("LastIndexedBlockKey")
 	receiptFromParentChainKeyPrefix = []byte("receiptFromParentChain")
+	parentChainPrefix               = []byte("parentChain")         // Prefix + parent chain ID (uint64 big endian) + key of the primary parent chain
+	anchoringBatchPrefix            = []byte("anchoringBatch")      // Prefix + start block number (uint64 big endian) -> anchoring batch
+	anchoringBatchIndexPrefix       = []byte("anchoringBatchIndex") // Prefix + block number (uint64 big endian) -> start block number
 
 	parentOperatorFeePayerPrefix = []byte("parentOperatorFeePayer")
 	childOperatorFeePayerPrefix  = []byte("childOperatorFeePayer")
@@ -234,6 +247,20 @@ func receiptFromParentChainKey(blockHash common.Hash) []byte {
 	return append(receiptFromParentChainKeyPrefix, blockHash.Bytes()...)
 }
 
+// parentChainKey returns the key of the data of an additional parent chain,
+// stored under the given key for the primary parent chain.
+func parentChainKey(parentChainID uint64, key []byte) []byte {
+	return append(append(append([]byte{}, parentChainPrefix...), common.Int64ToByteBigEndian(parentChainID)...), key...)
+}
+
+func anchoringBatchKey(startBlockNum uint64) []byte {
+	return append(append([]byte{}, anchoringBatchPrefix...), common.Int64ToByteBigEndian(startBlockNum)...)
+}
+
+func anchoringBatchIndexKey(blockNum uint64) []byte {
+	return append(append([]byte{}, anchoringBatchIndexPrefix...), common.Int64ToByteBigEndian(blockNum)...)
+}
+
 func valueTransferTxHashKey(rTxHash common.Hash) []byte {
 	return append(valueTransferTxHashPrefix, rTxHash.Bytes()...)
 }
@@ -266,6 +293,33 @@ func TrieNodeKey(hash common.ExtHash) []byte {
 	}
 }
 
+// accountTrieNodeKey = trieNodeAccountPrefix + hex path
+func accountTrieNodeKey(path []byte) []byte {
+	return append(append([]byte{}, trieNodeAccountPrefix...), path...)
+}
+
+// storageTrieNodeKey = trieNodeStoragePrefix + account hash + hex path
+func storageTrieNodeKey(owner common.Hash, path []byte) []byte {
+	key := make([]byte, 0, len(trieNodeStoragePrefix)+common.HashLength+len(path))
+	key = append(append(key, trieNodeStoragePrefix...), owner.Bytes()...)
+	return append(key, path...)
+}
+
+// TrieNodePathKey returns the key of the trie node stored by its path. Nodes of
+// the account trie have the zero owner, and nodes of a storage trie are owned by
+// the hash of the account.
+func TrieNodePathKey(owner common.Hash, path []byte) []byte {
+	if owner == (common.Hash{}) {
+		return accountTrieNodeKey(path)
+	}
+	return storageTrieNodeKey(owner, path)
+}
+
+// trieHistoryKey = trieHistoryPrefix + num (uint64 big endian)
+func trieHistoryKey(number uint64) []byte {
+	return append(append([]byte{}, trieHistoryPrefix...), common.Int64ToByteBigEndian(number)...)
+}
+
 type PruningMark struct {
 	Number uint64
 	Hash   common.ExtHash
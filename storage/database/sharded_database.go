@@ -34,9 +34,21 @@ const numShardsLimit = 256
 
 type shardedDB struct {
 	fn        string
+	dbc       DBConfig    // copied config used to open new shards while resharding
+	et        DBEntryType // entry type of the sharded database
 	shards    []Database
 	numShards uint
 
+	// layoutLock protects shards, numShards and the resharding state below.
+	// Normal operations take the read lock, and the lock is held exclusively
+	// only when the routing of keys changes.
+	layoutLock *sync.RWMutex
+	layoutGen  uint64 // increased whenever the routing of keys changes
+
+	meterPrefix string // prefix given to Meter, used to meter shards added by resharding
+
+	reshard *reshardState // non-nil while the database is being resharded
+
 	sdbBatchTaskCh chan sdbBatchTask
 }
 
@@ -53,6 +65,10 @@ type sdbBatchResult struct {
 
 // newShardedDB creates database with numShards shards, or partitions.
 // The type of database is specified DBConfig.DBType.
+// If the database already exists on disk, numShards should match the shard
+// layout stored with it. If a resharding was interrupted, either the shard
+// count before or after the resharding is accepted and the resharding is
+// resumed in the background.
 func newShardedDB(dbc *DBConfig, et DBEntryType, numShards uint) (*shardedDB, error) {
 	if numShards == 0 {
 		logger.Crit("numShards should be greater than 0!")
@@ -66,39 +82,71 @@ func newShardedDB(dbc *DBConfig, et DBEntryType, numShards uint) (*shardedDB, er
 		logger.Crit(fmt.Sprintf("numShards should be power of two, but it is %v", numShards))
 	}
 
-	shards := make([]Database, 0, numShards)
-	sdbBatchTaskCh := make(chan sdbBatchTask, numShards*2)
-	sdbLevelDBCacheSize := dbc.LevelDBCacheSize / int(numShards)
-	sdbOpenFilesLimit := dbc.OpenFilesLimit / int(numShards)
-	sdbRocksDBCacheSize := GetDefaultRocksDBConfig().CacheSize / uint64(numShards)
-	sdbRocksDBMaxOpenFiles := GetDefaultRocksDBConfig().MaxOpenFiles / int(numShards)
-	if dbc.RocksDBConfig != nil {
-		sdbRocksDBCacheSize = dbc.RocksDBConfig.CacheSize / uint64(numShards)
-		sdbRocksDBMaxOpenFiles = dbc.RocksDBConfig.MaxOpenFiles / int(numShards)
-	}
-	for i := 0; i < int(numShards); i++ {
-		copiedDBC := *dbc
-		copiedDBC.Dir = path.Join(copiedDBC.Dir, strconv.Itoa(i))
-		copiedDBC.LevelDBCacheSize = sdbLevelDBCacheSize
-		copiedDBC.OpenFilesLimit = sdbOpenFilesLimit
-		if copiedDBC.RocksDBConfig != nil {
-			copiedDBC.RocksDBConfig.CacheSize = sdbRocksDBCacheSize
-			copiedDBC.RocksDBConfig.MaxOpenFiles = sdbRocksDBMaxOpenFiles
-		}
+	layout, err := loadShardLayout(dbc)
+	if err != nil {
+		return nil, err
+	}
+	if layout == nil {
+		layout = &shardLayout{NumShards: numShards}
+	} else if err := layout.validate(numShards); err != nil {
+		return nil, err
+	}
+
+	// While resharding, shards for both the old and the new layout are opened.
+	numOpenShards := layout.NumShards
+	if layout.ReshardTo > numOpenShards {
+		numOpenShards = layout.ReshardTo
+	}
 
-		db, err := newDatabase(&copiedDBC, et)
+	// Cache and open files limits are divided by the configured shard count so
+	// that resharding does not change the per-shard settings in the meantime.
+	shards := make([]Database, 0, numOpenShards)
+	sdbBatchTaskCh := make(chan sdbBatchTask, numShardsLimit*2)
+	db := &shardedDB{
+		fn: dbc.Dir, dbc: *dbc, et: et,
+		numShards: layout.NumShards, layoutLock: &sync.RWMutex{},
+		sdbBatchTaskCh: sdbBatchTaskCh,
+	}
+	for i := 0; i < int(numOpenShards); i++ {
+		shard, err := db.openShard(i, numShards)
 		if err != nil {
+			for _, opened := range shards {
+				opened.Close()
+			}
 			return nil, err
 		}
-		shards = append(shards, db)
+		shards = append(shards, shard)
 		go batchWriteWorker(sdbBatchTaskCh)
 	}
+	db.shards = shards
+
+	if err := db.storeShardLayout(layout); err != nil {
+		db.Close()
+		return nil, err
+	}
 
-	logger.Info("Created a sharded database", "dbType", et, "numShards", numShards)
-	return &shardedDB{
-		fn: dbc.Dir, shards: shards,
-		numShards: numShards, sdbBatchTaskCh: sdbBatchTaskCh,
-	}, nil
+	logger.Info("Created a sharded database", "dbType", et, "numShards", layout.NumShards)
+	if layout.ReshardTo != 0 {
+		logger.Warn("Resuming interrupted resharding", "dbType", et, "from", layout.NumShards, "to", layout.ReshardTo)
+		db.startReshard(layout.ReshardTo, nil)
+	}
+	return db, nil
+}
+
+// openShard opens the shard of the given index. Resources are divided by
+// configuredShards, the shard count given by the configuration.
+func (db *shardedDB) openShard(index int, configuredShards uint) (Database, error) {
+	copiedDBC := db.dbc
+	copiedDBC.Dir = path.Join(copiedDBC.Dir, strconv.Itoa(index))
+	copiedDBC.LevelDBCacheSize = db.dbc.LevelDBCacheSize / int(configuredShards)
+	copiedDBC.OpenFilesLimit = db.dbc.OpenFilesLimit / int(configuredShards)
+	if db.dbc.RocksDBConfig != nil {
+		rocksDBConfig := *db.dbc.RocksDBConfig
+		rocksDBConfig.CacheSize = db.dbc.RocksDBConfig.CacheSize / uint64(configuredShards)
+		rocksDBConfig.MaxOpenFiles = db.dbc.RocksDBConfig.MaxOpenFiles / int(configuredShards)
+		copiedDBC.RocksDBConfig = &rocksDBConfig
+	}
+	return newDatabase(&copiedDBC, db.et)
 }
 
 // batchWriteWorker executes passed batch tasks.
@@ -124,47 +172,115 @@ func shardIndexByKey(key []byte, numShards uint) (int, error) {
 }
 
 // getShardByKey returns the shard corresponding to the given key.
-func (db *shardedDB) getShardByKey(key []byte) (Database, error) {
-	if shardIndex, err := shardIndexByKey(key, uint(db.numShards)); err != nil {
-		return nil, err
-	} else {
-		return db.shards[shardIndex], nil
+// While resharding, it also returns the shard which owned the key before
+// resharding started, or nil if the owner is not changed.
+// The caller should hold the read lock of layoutLock.
+func (db *shardedDB) getShardByKey(key []byte) (Database, Database, error) {
+	if db.reshard == nil {
+		shardIndex, err := shardIndexByKey(key, db.numShards)
+		if err != nil {
+			return nil, nil, err
+		}
+		return db.shards[shardIndex], nil, nil
+	}
+
+	shardIndex, err := shardIndexByKey(key, db.reshard.to)
+	if err != nil {
+		return nil, nil, err
 	}
+	prevIndex, _ := shardIndexByKey(key, db.numShards)
+	if prevIndex == shardIndex {
+		return db.shards[shardIndex], nil, nil
+	}
+	return db.shards[shardIndex], db.shards[prevIndex], nil
 }
 
+// Put writes the given key/value to its shard. While resharding, the key is
+// written to the new shard and removed from the previous one, so that the
+// resharding worker does not overwrite the new value with the old one.
 func (db *shardedDB) Put(key []byte, value []byte) error {
-	if shard, err := db.getShardByKey(key); err != nil {
+	db.layoutLock.RLock()
+	defer db.layoutLock.RUnlock()
+
+	shard, prevShard, err := db.getShardByKey(key)
+	if err != nil {
 		return err
-	} else {
+	}
+	if prevShard == nil {
 		return shard.Put(key, value)
 	}
+
+	db.reshard.moveLock.RLock()
+	defer db.reshard.moveLock.RUnlock()
+	if err := shard.Put(key, value); err != nil {
+		return err
+	}
+	return prevShard.Delete(key)
 }
 
+// Get reads the value of the given key. While resharding, the previous shard
+// of the key is looked up if the key has not been moved yet.
 func (db *shardedDB) Get(key []byte) ([]byte, error) {
-	if shard, err := db.getShardByKey(key); err != nil {
+	db.layoutLock.RLock()
+	defer db.layoutLock.RUnlock()
+
+	shard, prevShard, err := db.getShardByKey(key)
+	if err != nil {
 		return nil, err
-	} else {
-		return shard.Get(key)
 	}
+	val, err := shard.Get(key)
+	if err != dataNotFoundErr || prevShard == nil {
+		return val, err
+	}
+	if val, err = prevShard.Get(key); err != dataNotFoundErr {
+		return val, err
+	}
+	// The key can be moved after the first lookup. Since moved keys are
+	// written to the new shard before deleted from the previous one, it is
+	// found in the new shard in that case.
+	return shard.Get(key)
 }
 
 func (db *shardedDB) Has(key []byte) (bool, error) {
-	if shard, err := db.getShardByKey(key); err != nil {
+	db.layoutLock.RLock()
+	defer db.layoutLock.RUnlock()
+
+	shard, prevShard, err := db.getShardByKey(key)
+	if err != nil {
 		return false, err
-	} else {
-		return shard.Has(key)
 	}
+	has, err := shard.Has(key)
+	if err != nil || has || prevShard == nil {
+		return has, err
+	}
+	if has, err = prevShard.Has(key); err != nil || has {
+		return has, err
+	}
+	return shard.Has(key)
 }
 
 func (db *shardedDB) Delete(key []byte) error {
-	if shard, err := db.getShardByKey(key); err != nil {
+	db.layoutLock.RLock()
+	defer db.layoutLock.RUnlock()
+
+	shard, prevShard, err := db.getShardByKey(key)
+	if err != nil {
 		return err
-	} else {
+	}
+	if prevShard == nil {
 		return shard.Delete(key)
 	}
+
+	db.reshard.moveLock.RLock()
+	defer db.reshard.moveLock.RUnlock()
+	if err := shard.Delete(key); err != nil {
+		return err
+	}
+	return prevShard.Delete(key)
 }
 
 func (db *shardedDB) Close() {
+	db.stopReshard()
 	close(db.sdbBatchTaskCh)
 
 	for _, shard := range db.shards {
@@ -189,6 +305,8 @@ type shardedDBIterator struct {
 // NewIterator creates a binary-alphabetical iterator over a subset
 // of database content with a particular key prefix, starting at a particular
 // initial key (or after, if it does not exist).
+// Iterators created while resharding are not a consistent snapshot of the
+// database, since keys can be moved between shards during the iteration.
 func (db *shardedDB) NewIterator(prefix []byte, start []byte) Iterator {
 	it := &shardedDBIterator{
 		parallelIterator: db.NewParallelIterator(context.TODO(), prefix, start, nil),
@@ -227,6 +345,7 @@ func (it *shardedDBIterator) runCombineWorker() {
 		}
 	}
 
+	var lastKey []byte
 chanIter:
 	for len(*entries) != 0 {
 		// check if done
@@ -240,8 +359,12 @@ chanIter:
 		// look for smallest key
 		minEntry := heap.Pop(entries).(entryWithShardNum)
 
-		// fill resultCh with smallest key
-		it.resultCh <- minEntry.Entry
+		// fill resultCh with smallest key. A key can exist in two shards for
+		// a moment while resharding, so the duplicated one is skipped.
+		if lastKey == nil || !bytes.Equal(lastKey, minEntry.Key) {
+			it.resultCh <- minEntry.Entry
+			lastKey = minEntry.Key
+		}
 
 		// fill used entry with new entry
 		// skip this if channel is closed
@@ -350,18 +473,22 @@ func (db *shardedDB) NewParallelIterator(ctx context.Context, prefix []byte, sta
 		ctx = context.TODO()
 	}
 
+	db.layoutLock.RLock()
+	shards := db.shards
+	db.layoutLock.RUnlock()
+
 	it := shardedDBParallelIterator{
 		ctx:          ctx,
 		cancel:       nil,
-		iterators:    make([]Iterator, len(db.shards)),
+		iterators:    make([]Iterator, len(shards)),
 		combinedChan: resultCh != nil,
-		shardNum:     len(db.shards),
+		shardNum:     len(shards),
 		shardNumMu:   &sync.Mutex{},
-		resultChs:    make([]chan common.Entry, len(db.shards)),
+		resultChs:    make([]chan common.Entry, len(shards)),
 	}
 	it.ctx, it.cancel = context.WithCancel(ctx)
 
-	for i, shard := range db.shards {
+	for i, shard := range shards {
 		it.iterators[i] = shard.NewIterator(prefix, start)
 		if resultCh == nil {
 			it.resultChs[i] = make(chan common.Entry, shardedDBSubChannelSize)
@@ -417,15 +544,30 @@ func (it *shardedDBParallelIterator) Release() {
 }
 
 func (db *shardedDB) NewBatch() Batch {
-	batches := make([]Batch, 0, db.numShards)
-	for i := 0; i < int(db.numShards); i++ {
+	db.layoutLock.RLock()
+	defer db.layoutLock.RUnlock()
+
+	return db.newBatch()
+}
+
+// newBatch creates a batch routing keys by the current shard layout.
+// The caller should hold the read lock of layoutLock.
+func (db *shardedDB) newBatch() *shardedDBBatch {
+	numBatches := uint(len(db.shards))
+	batches := make([]Batch, 0, numBatches)
+	for i := 0; i < int(numBatches); i++ {
 		batches = append(batches, db.shards[i].NewBatch())
 	}
 
-	return &shardedDBBatch{
+	sdbBatch := &shardedDBBatch{
+		db: db, layoutGen: db.layoutGen,
 		batches: batches, numBatches: db.numShards,
-		taskCh: db.sdbBatchTaskCh, resultCh: make(chan sdbBatchResult, db.numShards),
+		taskCh: db.sdbBatchTaskCh, resultCh: make(chan sdbBatchResult, numBatches),
+	}
+	if db.reshard != nil {
+		sdbBatch.reshardTo = db.reshard.to
 	}
+	return sdbBatch
 }
 
 func (db *shardedDB) Type() DBType {
@@ -433,12 +575,19 @@ func (db *shardedDB) Type() DBType {
 }
 
 func (db *shardedDB) Meter(prefix string) {
+	db.layoutLock.Lock()
+	defer db.layoutLock.Unlock()
+
+	db.meterPrefix = prefix
 	for index, shard := range db.shards {
 		shard.Meter(prefix + strconv.Itoa(index))
 	}
 }
 
 func (db *shardedDB) GetProperty(name string) string {
+	db.layoutLock.RLock()
+	defer db.layoutLock.RUnlock()
+
 	var buf bytes.Buffer
 	for index, shard := range db.shards {
 		buf.WriteString(fmt.Sprintf("shard %d: %s\n", index, shard.GetProperty(name)))
@@ -447,6 +596,9 @@ func (db *shardedDB) GetProperty(name string) string {
 }
 
 func (db *shardedDB) TryCatchUpWithPrimary() error {
+	db.layoutLock.RLock()
+	defer db.layoutLock.RUnlock()
+
 	for _, shard := range db.shards {
 		if err := shard.TryCatchUpWithPrimary(); err != nil {
 			return err
@@ -456,27 +608,60 @@ func (db *shardedDB) TryCatchUpWithPrimary() error {
 }
 
 type shardedDBBatch struct {
+	db        *shardedDB
+	layoutGen uint64 // layout generation of db when the batch is created
+
 	batches    []Batch
 	numBatches uint
+	reshardTo  uint // number of shards after resharding, 0 if not resharding
 
 	taskCh   chan sdbBatchTask
 	resultCh chan sdbBatchResult
 }
 
+// shardIndexesByKey returns the index of the batch for the given key and,
+// while resharding, the index of the batch of its previous shard.
+// The previous index is -1 if it is not resharding or the owner is not changed.
+func (sdbBatch *shardedDBBatch) shardIndexesByKey(key []byte) (int, int, error) {
+	shardIndex, err := shardIndexByKey(key, sdbBatch.numBatches)
+	if err != nil || sdbBatch.reshardTo == 0 {
+		return shardIndex, -1, err
+	}
+	newIndex, _ := shardIndexByKey(key, sdbBatch.reshardTo)
+	if newIndex == shardIndex {
+		return shardIndex, -1, nil
+	}
+	return newIndex, shardIndex, nil
+}
+
+// Put writes the given key/value to the batch of its shard. While resharding,
+// a deletion of the key is also written to the batch of its previous shard.
 func (sdbBatch *shardedDBBatch) Put(key []byte, value []byte) error {
-	if ShardIndex, err := shardIndexByKey(key, sdbBatch.numBatches); err != nil {
+	shardIndex, prevIndex, err := sdbBatch.shardIndexesByKey(key)
+	if err != nil {
 		return err
-	} else {
-		return sdbBatch.batches[ShardIndex].Put(key, value)
 	}
+	if err := sdbBatch.batches[shardIndex].Put(key, value); err != nil {
+		return err
+	}
+	if prevIndex >= 0 {
+		return sdbBatch.batches[prevIndex].Delete(key)
+	}
+	return nil
 }
 
 func (sdbBatch *shardedDBBatch) Delete(key []byte) error {
-	if ShardIndex, err := shardIndexByKey(key, sdbBatch.numBatches); err != nil {
+	shardIndex, prevIndex, err := sdbBatch.shardIndexesByKey(key)
+	if err != nil {
+		return err
+	}
+	if err := sdbBatch.batches[shardIndex].Delete(key); err != nil {
 		return err
-	} else {
-		return sdbBatch.batches[ShardIndex].Delete(key)
 	}
+	if prevIndex >= 0 {
+		return sdbBatch.batches[prevIndex].Delete(key)
+	}
+	return nil
 }
 
 // ValueSize is called to determine whether to write batches when it exceeds
@@ -494,7 +679,32 @@ func (sdbBatch *shardedDBBatch) ValueSize() int {
 
 // Write passes the list of batch tasks to taskCh so batch can be processed
 // by underlying workers. Write waits until all workers return the result.
+// If the shard layout has changed since the batch was created, the batch is
+// rerouted by the current layout before it is written.
 func (sdbBatch *shardedDBBatch) Write() error {
+	db := sdbBatch.db
+	db.layoutLock.RLock()
+	defer db.layoutLock.RUnlock()
+
+	batch := sdbBatch
+	if sdbBatch.layoutGen != db.layoutGen {
+		// Replaying in the order of shard indexes keeps the last operation of
+		// each key, since a previous shard always has a smaller index.
+		batch = db.newBatch()
+		defer batch.Release()
+		if err := sdbBatch.Replay(batch); err != nil {
+			return err
+		}
+	}
+
+	if db.reshard != nil {
+		db.reshard.moveLock.RLock()
+		defer db.reshard.moveLock.RUnlock()
+	}
+	return batch.write()
+}
+
+func (sdbBatch *shardedDBBatch) write() error {
 	for index, batch := range sdbBatch.batches {
 		sdbBatch.taskCh <- sdbBatchTask{batch, index, sdbBatch.resultCh}
 	}
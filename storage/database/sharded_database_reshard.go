@@ -0,0 +1,428 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// shardLayoutFileName is the name of the file storing the shard layout of a
+// sharded database. It is placed in the directory of the sharded database.
+const shardLayoutFileName = "SHARD_LAYOUT"
+
+// reshardBatchKeys is the number of keys moved at once while resharding.
+const reshardBatchKeys = 1024
+
+var (
+	errShardLayoutMismatch   = errors.New("the number of shards does not match the shard layout of the database")
+	errReshardInProgress     = errors.New("resharding is already in progress")
+	errReshardInvalidTarget  = errors.New("the number of shards can only be increased to a larger power of two")
+	errReshardStopped        = errors.New("resharding is stopped")
+	errReshardNotShardedDB   = errors.New("the database is not a sharded database")
+	errReshardInMigration    = errors.New("resharding is not allowed during state migration")
+	errMigrationInResharding = errors.New("state migration is not allowed during resharding")
+)
+
+// shardLayout is the shard layout of a sharded database stored on disk.
+// ReshardTo is set only while the database is being resharded.
+type shardLayout struct {
+	NumShards uint `json:"numShards"`
+	ReshardTo uint `json:"reshardTo,omitempty"`
+}
+
+// validate checks if the database can be opened with the given number of shards.
+// While resharding, both the number of shards before and after resharding are allowed.
+func (l *shardLayout) validate(numShards uint) error {
+	if numShards == l.NumShards || (l.ReshardTo != 0 && numShards == l.ReshardTo) {
+		return nil
+	}
+	if l.ReshardTo != 0 {
+		return fmt.Errorf("%w (configured: %d, stored: %d resharding to %d)", errShardLayoutMismatch, numShards, l.NumShards, l.ReshardTo)
+	}
+	return fmt.Errorf("%w (configured: %d, stored: %d)", errShardLayoutMismatch, numShards, l.NumShards)
+}
+
+// checkNotSharded returns an error if the database to be opened as a plain
+// database is a sharded database.
+func checkNotSharded(dbc *DBConfig) error {
+	layout, err := loadShardLayout(dbc)
+	if err != nil || layout == nil {
+		return err
+	}
+	if layout.ReshardTo != 0 {
+		return fmt.Errorf("%w (configured: 1, stored: %d resharding to %d)", errShardLayoutMismatch, layout.NumShards, layout.ReshardTo)
+	}
+	return fmt.Errorf("%w (configured: 1, stored: %d)", errShardLayoutMismatch, layout.NumShards)
+}
+
+// persistentShardLayout returns if the shard layout of the given database is stored on disk.
+func persistentShardLayout(dbc *DBConfig) bool {
+	return dbc.DBType != MemoryDB && dbc.Dir != ""
+}
+
+// loadShardLayout reads the shard layout of a sharded database from disk.
+// For a database created before the shard layout was stored, the layout is
+// inferred from its shard directories. It returns nil if the database does not exist.
+func loadShardLayout(dbc *DBConfig) (*shardLayout, error) {
+	if !persistentShardLayout(dbc) {
+		return nil, nil
+	}
+
+	enc, err := os.ReadFile(path.Join(dbc.Dir, shardLayoutFileName))
+	if err == nil {
+		layout := new(shardLayout)
+		if err := json.Unmarshal(enc, layout); err != nil {
+			return nil, fmt.Errorf("failed to decode the shard layout: %w", err)
+		}
+		return layout, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	numShards := uint(0)
+	for ; numShards < numShardsLimit; numShards++ {
+		if _, err := os.Stat(path.Join(dbc.Dir, strconv.Itoa(int(numShards)))); err != nil {
+			break
+		}
+	}
+	if numShards == 0 {
+		return nil, nil
+	}
+	return &shardLayout{NumShards: numShards}, nil
+}
+
+// storeShardLayout writes the shard layout of the database to disk atomically.
+func (db *shardedDB) storeShardLayout(layout *shardLayout) error {
+	if !persistentShardLayout(&db.dbc) {
+		return nil
+	}
+
+	enc, err := json.Marshal(layout)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(db.fn, 0o755); err != nil {
+		return err
+	}
+	file := path.Join(db.fn, shardLayoutFileName)
+	if err := os.WriteFile(file+".tmp", enc, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(file+".tmp", file)
+}
+
+// ReshardingStatus shows the progress of resharding.
+type ReshardingStatus struct {
+	InProgress  bool   `json:"inProgress"`
+	From        uint   `json:"from"`        // number of shards before resharding
+	To          uint   `json:"to"`          // number of shards after resharding
+	SplitShards int    `json:"splitShards"` // number of shards whose keys are all moved
+	MovedKeys   uint64 `json:"movedKeys"`
+	Err         string `json:"err,omitempty"` // error stopping the resharding worker, if any
+}
+
+// reshardState is the state of an ongoing resharding.
+type reshardState struct {
+	to uint // number of shards after resharding
+
+	// moveLock serializes moving keys between shards and writing to the keys
+	// being moved. The resharding worker takes the write lock. It is always
+	// taken while holding the read lock of layoutLock, never the other way.
+	moveLock *sync.RWMutex
+
+	splitShards int32
+	movedKeys   uint64
+	err         atomic.Value // error stopping the worker; resharding is resumed on the next open
+
+	quitCh   chan struct{}
+	doneCh   chan struct{}
+	resultCh chan error // receives the result of resharding, if not nil
+}
+
+// Reshard increases the number of shards of the database to the given number.
+// Keys are moved to their new shards by a background worker while the database
+// keeps serving requests. The result is sent to the returned channel when
+// resharding is finished or stopped.
+func (db *shardedDB) Reshard(numShards uint) (<-chan error, error) {
+	db.layoutLock.RLock()
+	from, inProgress := db.numShards, db.reshard != nil
+	db.layoutLock.RUnlock()
+
+	if inProgress {
+		return nil, errReshardInProgress
+	}
+	if numShards <= from || numShards > numShardsLimit || !IsPow2(numShards) {
+		return nil, errReshardInvalidTarget
+	}
+
+	resultCh := make(chan error, 1)
+	if err := db.prepareReshard(numShards); err != nil {
+		return nil, err
+	}
+	db.startReshard(numShards, resultCh)
+	return resultCh, nil
+}
+
+// prepareReshard opens the new shards and stores the shard layout marking
+// the database in resharding.
+func (db *shardedDB) prepareReshard(numShards uint) error {
+	db.layoutLock.RLock()
+	from := db.numShards
+	db.layoutLock.RUnlock()
+
+	newShards := make([]Database, 0, numShards-from)
+	for i := int(from); i < int(numShards); i++ {
+		shard, err := db.openShard(i, from)
+		if err != nil {
+			for _, opened := range newShards {
+				opened.Close()
+			}
+			return err
+		}
+		newShards = append(newShards, shard)
+	}
+
+	db.layoutLock.Lock()
+	defer db.layoutLock.Unlock()
+
+	if db.reshard != nil || len(db.shards) != int(from) {
+		for _, opened := range newShards {
+			opened.Close()
+		}
+		return errReshardInProgress
+	}
+	if err := db.storeShardLayout(&shardLayout{NumShards: from, ReshardTo: numShards}); err != nil {
+		for _, opened := range newShards {
+			opened.Close()
+		}
+		return err
+	}
+	for i, shard := range newShards {
+		if db.meterPrefix != "" {
+			shard.Meter(db.meterPrefix + strconv.Itoa(int(from)+i))
+		}
+		go batchWriteWorker(db.sdbBatchTaskCh)
+	}
+	db.shards = append(db.shards, newShards...)
+	return nil
+}
+
+// startReshard starts the resharding worker. The new shards should be opened
+// and the shard layout should be stored before it is called.
+func (db *shardedDB) startReshard(numShards uint, resultCh chan error) {
+	db.layoutLock.Lock()
+	defer db.layoutLock.Unlock()
+
+	db.reshard = &reshardState{
+		to:       numShards,
+		moveLock: &sync.RWMutex{},
+		quitCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+		resultCh: resultCh,
+	}
+	db.layoutGen++
+	go db.runReshard(db.reshard, db.numShards)
+}
+
+// stopReshard stops the resharding worker, if any, and waits for it to stop.
+// The resharding is resumed when the database is opened next time.
+func (db *shardedDB) stopReshard() {
+	db.layoutLock.RLock()
+	state := db.reshard
+	db.layoutLock.RUnlock()
+
+	if state == nil {
+		return
+	}
+	select {
+	case <-state.quitCh:
+	default:
+		close(state.quitCh)
+	}
+	<-state.doneCh
+}
+
+// runReshard moves keys of each previous shard to their new shards, and
+// updates the shard layout when all keys are moved.
+func (db *shardedDB) runReshard(state *reshardState, from uint) {
+	defer close(state.doneCh)
+
+	start := time.Now()
+	logger.Info("Start resharding", "dbType", db.et, "from", from, "to", state.to)
+
+	var err error
+	for i := 0; i < int(from) && err == nil; i++ {
+		if err = db.splitShard(state, i); err == nil {
+			atomic.AddInt32(&state.splitShards, 1)
+		}
+	}
+	if err == nil {
+		err = db.finishReshard(state)
+	}
+
+	if err != nil {
+		state.err.Store(err)
+		logger.Warn("Resharding is not finished", "dbType", db.et, "from", from, "to", state.to,
+			"movedKeys", atomic.LoadUint64(&state.movedKeys), "elapsed", time.Since(start), "err", err)
+	} else {
+		logger.Info("Resharding is finished. Set the number of shards in the configuration accordingly",
+			"dbType", db.et, "numShards", state.to, "movedKeys", atomic.LoadUint64(&state.movedKeys), "elapsed", time.Since(start))
+	}
+	if state.resultCh != nil {
+		state.resultCh <- err
+	}
+}
+
+// splitShard moves the keys of the given shard which belong to other shards
+// by the new shard layout.
+func (db *shardedDB) splitShard(state *reshardState, shardIndex int) error {
+	db.layoutLock.RLock()
+	src := db.shards[shardIndex]
+	db.layoutLock.RUnlock()
+
+	it := src.NewIterator(nil, nil)
+	defer it.Release()
+
+	keys := make([][]byte, 0, reshardBatchKeys)
+	for it.Next() {
+		if idx, _ := shardIndexByKey(it.Key(), state.to); idx == shardIndex {
+			continue
+		}
+		keys = append(keys, append([]byte{}, it.Key()...))
+		if len(keys) < reshardBatchKeys {
+			continue
+		}
+
+		select {
+		case <-state.quitCh:
+			return errReshardStopped
+		default:
+		}
+		if err := db.moveKeys(state, src, keys); err != nil {
+			return err
+		}
+		keys = keys[:0]
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return db.moveKeys(state, src, keys)
+}
+
+// moveKeys moves the given keys from src to their new shards. The values are
+// read again while holding moveLock, so a value written in the meantime is
+// not overwritten with the stale one.
+func (db *shardedDB) moveKeys(state *reshardState, src Database, keys [][]byte) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	// layoutLock is always taken before moveLock, as writers do.
+	db.layoutLock.RLock()
+	defer db.layoutLock.RUnlock()
+
+	state.moveLock.Lock()
+	defer state.moveLock.Unlock()
+
+	shards := db.shards
+
+	dstBatches := make(map[int]Batch)
+	srcBatch := src.NewBatch()
+	defer srcBatch.Release()
+
+	moved := uint64(0)
+	for _, key := range keys {
+		val, err := src.Get(key)
+		if err == dataNotFoundErr {
+			continue // already moved by a write
+		} else if err != nil {
+			return err
+		}
+
+		idx, _ := shardIndexByKey(key, state.to)
+		batch, ok := dstBatches[idx]
+		if !ok {
+			batch = shards[idx].NewBatch()
+			defer batch.Release()
+			dstBatches[idx] = batch
+		}
+		if err := batch.Put(key, val); err != nil {
+			return err
+		}
+		if err := srcBatch.Delete(key); err != nil {
+			return err
+		}
+		moved++
+	}
+
+	// Keys are written to the new shards before they are deleted from the
+	// previous one, so that they can be always found by reads.
+	for _, batch := range dstBatches {
+		if err := batch.Write(); err != nil {
+			return err
+		}
+	}
+	if err := srcBatch.Write(); err != nil {
+		return err
+	}
+	atomic.AddUint64(&state.movedKeys, moved)
+	return nil
+}
+
+// finishReshard updates the shard layout after all keys are moved.
+func (db *shardedDB) finishReshard(state *reshardState) error {
+	db.layoutLock.Lock()
+	defer db.layoutLock.Unlock()
+
+	if err := db.storeShardLayout(&shardLayout{NumShards: state.to}); err != nil {
+		return err
+	}
+	db.numShards = state.to
+	db.reshard = nil
+	db.layoutGen++
+	return nil
+}
+
+// ReshardingStatus returns the progress of the ongoing resharding.
+func (db *shardedDB) ReshardingStatus() ReshardingStatus {
+	db.layoutLock.RLock()
+	defer db.layoutLock.RUnlock()
+
+	if db.reshard == nil {
+		return ReshardingStatus{From: db.numShards, To: db.numShards}
+	}
+	status := ReshardingStatus{
+		InProgress:  true,
+		From:        db.numShards,
+		To:          db.reshard.to,
+		SplitShards: int(atomic.LoadInt32(&db.reshard.splitShards)),
+		MovedKeys:   atomic.LoadUint64(&db.reshard.movedKeys),
+	}
+	if err, ok := db.reshard.err.Load().(error); ok {
+		status.Err = err.Error()
+	}
+	return status
+}
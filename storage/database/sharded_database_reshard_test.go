@@ -0,0 +1,254 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"errors"
+	"os"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestReshardDBConfig(t *testing.T) *DBConfig {
+	dir, err := os.MkdirTemp(os.TempDir(), "test-shardedDB-reshard")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	return &DBConfig{Dir: dir, DBType: LevelDB, NumStateTrieShards: 2, ParallelDBWrite: true}
+}
+
+func writeTestEntries(t *testing.T, db Database, entries []common.Entry) {
+	batch := db.NewBatch()
+	for _, entry := range entries {
+		require.NoError(t, batch.Put(entry.Key, entry.Val))
+	}
+	require.NoError(t, batch.Write())
+}
+
+func checkTestEntries(t *testing.T, db Database, entries []common.Entry) {
+	for _, entry := range entries {
+		val, err := db.Get(entry.Key)
+		require.NoError(t, err)
+		assert.Equal(t, entry.Val, val)
+	}
+}
+
+// TestShardedDB_Reshard tests if all keys are moved to their new shards and
+// the new shard layout is stored.
+func TestShardedDB_Reshard(t *testing.T) {
+	dbc := newTestReshardDBConfig(t)
+	entries := common.CreateEntries(3000)
+
+	db, err := newShardedDB(dbc, StateTrieDB, 2)
+	require.NoError(t, err)
+	writeTestEntries(t, db, entries)
+
+	resultCh, err := db.Reshard(8)
+	require.NoError(t, err)
+	require.NoError(t, <-resultCh)
+
+	status := db.ReshardingStatus()
+	assert.False(t, status.InProgress)
+	assert.Equal(t, uint(8), status.To)
+	checkTestEntries(t, db, entries)
+
+	// each key should be stored only in its own shard
+	for i, shard := range db.shards {
+		it := shard.NewIterator(nil, nil)
+		for it.Next() {
+			idx, _ := shardIndexByKey(it.Key(), 8)
+			assert.Equal(t, i, idx)
+		}
+		it.Release()
+	}
+	db.Close()
+
+	// the database cannot be opened with the previous number of shards
+	_, err = newShardedDB(dbc, StateTrieDB, 2)
+	assert.True(t, errors.Is(err, errShardLayoutMismatch))
+
+	db, err = newShardedDB(dbc, StateTrieDB, 8)
+	require.NoError(t, err)
+	checkTestEntries(t, db, entries)
+	db.Close()
+}
+
+// TestShardedDB_ReshardInvalidTarget tests if the number of shards can only be increased.
+func TestShardedDB_ReshardInvalidTarget(t *testing.T) {
+	dbc := newTestReshardDBConfig(t)
+	db, err := newShardedDB(dbc, StateTrieDB, 4)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for _, numShards := range []uint{1, 2, 4, 6, 512} {
+		_, err := db.Reshard(numShards)
+		assert.Equal(t, errReshardInvalidTarget, err)
+	}
+}
+
+// TestShardedDB_ReshardOnline tests if reads and writes are served correctly
+// while keys are being moved.
+func TestShardedDB_ReshardOnline(t *testing.T) {
+	dbc := newTestReshardDBConfig(t)
+	entries := common.CreateEntries(5000)
+	updated := common.CreateEntries(1000)
+	for i := range updated {
+		updated[i].Key = entries[i].Key
+	}
+
+	db, err := newShardedDB(dbc, StateTrieDB, 2)
+	require.NoError(t, err)
+	defer db.Close()
+	writeTestEntries(t, db, entries)
+
+	// a batch created before resharding is written after it starts
+	staleBatch := db.NewBatch()
+	for _, entry := range updated[:500] {
+		require.NoError(t, staleBatch.Put(entry.Key, entry.Val))
+	}
+
+	resultCh, err := db.Reshard(4)
+	require.NoError(t, err)
+	require.NoError(t, staleBatch.Write())
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for _, entry := range updated[500:] {
+			assert.NoError(t, db.Put(entry.Key, entry.Val))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for _, entry := range entries[len(updated):] {
+			val, err := db.Get(entry.Key)
+			assert.NoError(t, err)
+			assert.Equal(t, entry.Val, val)
+		}
+	}()
+	wg.Wait()
+	require.NoError(t, <-resultCh)
+
+	checkTestEntries(t, db, updated)
+	checkTestEntries(t, db, entries[len(updated):])
+}
+
+// TestShardedDB_ReshardResume tests if an interrupted resharding is resumed
+// when the database is opened again.
+func TestShardedDB_ReshardResume(t *testing.T) {
+	dbc := newTestReshardDBConfig(t)
+	entries := common.CreateEntries(1000)
+
+	db, err := newShardedDB(dbc, StateTrieDB, 2)
+	require.NoError(t, err)
+	writeTestEntries(t, db, entries)
+	db.Close()
+
+	// mark the database as interrupted in resharding
+	layout := &shardLayout{NumShards: 2, ReshardTo: 4}
+	require.NoError(t, (&shardedDB{fn: dbc.Dir, dbc: *dbc}).storeShardLayout(layout))
+
+	_, err = newShardedDB(dbc, StateTrieDB, 8)
+	assert.True(t, errors.Is(err, errShardLayoutMismatch))
+
+	db, err = newShardedDB(dbc, StateTrieDB, 4)
+	require.NoError(t, err)
+	checkTestEntries(t, db, entries)
+	for db.ReshardingStatus().InProgress {
+		time.Sleep(10 * time.Millisecond)
+	}
+	checkTestEntries(t, db, entries)
+	db.Close()
+
+	stored, err := loadShardLayout(dbc)
+	require.NoError(t, err)
+	assert.Equal(t, &shardLayout{NumShards: 4}, stored)
+}
+
+// TestShardedDB_LegacyLayout tests if the shard layout of a database created
+// without the layout file is inferred from its shard directories.
+func TestShardedDB_LegacyLayout(t *testing.T) {
+	dbc := newTestReshardDBConfig(t)
+
+	db, err := newShardedDB(dbc, StateTrieDB, 4)
+	require.NoError(t, err)
+	db.Close()
+	require.NoError(t, os.Remove(path.Join(dbc.Dir, shardLayoutFileName)))
+
+	_, err = newShardedDB(dbc, StateTrieDB, 2)
+	assert.True(t, errors.Is(err, errShardLayoutMismatch))
+
+	db, err = newShardedDB(dbc, StateTrieDB, 4)
+	require.NoError(t, err)
+	db.Close()
+}
+
+// TestCheckNotSharded tests if a sharded database is not opened as a plain database.
+func TestCheckNotSharded(t *testing.T) {
+	dbc := newTestReshardDBConfig(t)
+	assert.NoError(t, checkNotSharded(dbc))
+
+	db, err := newShardedDB(dbc, StateTrieDB, 2)
+	require.NoError(t, err)
+	db.Close()
+	assert.True(t, errors.Is(checkNotSharded(dbc), errShardLayoutMismatch))
+
+	// the layout is inferred from the shard directories without the layout file
+	require.NoError(t, os.Remove(path.Join(dbc.Dir, shardLayoutFileName)))
+	assert.True(t, errors.Is(checkNotSharded(dbc), errShardLayoutMismatch))
+}
+
+// TestShardedDB_ReshardMeter tests if metering the database does not deadlock
+// with the resharding worker and writers.
+func TestShardedDB_ReshardMeter(t *testing.T) {
+	dbc := newTestReshardDBConfig(t)
+	entries := common.CreateEntries(5000)
+
+	db, err := newShardedDB(dbc, StateTrieDB, 2)
+	require.NoError(t, err)
+	defer db.Close()
+	writeTestEntries(t, db, entries)
+
+	resultCh, err := db.Reshard(16)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i, entry := range entries {
+			assert.NoError(t, db.Put(entry.Key, entry.Val))
+			if i%100 == 0 {
+				db.Meter("test/")
+			}
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(30 * time.Second):
+		t.Fatal("writes and Meter are blocked while resharding")
+	}
+	require.NoError(t, <-resultCh)
+	checkTestEntries(t, db, entries)
+}
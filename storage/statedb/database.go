@@ -111,6 +111,8 @@ type Database struct {
 	trieNodeCache                TrieNodeCache        // GC friendly memory cache of trie node RLPs
 	trieNodeCacheConfig          *TrieNodeCacheConfig // Configuration of trieNodeCache
 	savingTrieNodeCacheTriggered bool                 // Whether saving trie node cache has been triggered or not
+
+	pathDB *pathDB // Trie nodes persisted by their paths, nil in the hash scheme
 }
 
 // rawNode is a simple binary blob used to differentiate between collapsed trie
@@ -314,6 +316,7 @@ func NewDatabaseWithNewCache(diskDB database.DBManager, cacheConfig *TrieNodeCac
 		preimages:           make(map[common.Hash][]byte),
 		trieNodeCache:       trieNodeCache,
 		trieNodeCacheConfig: cacheConfig,
+		pathDB:              openPathDB(diskDB),
 	}
 }
 
@@ -326,6 +329,7 @@ func NewDatabaseWithExistingCache(diskDB database.DBManager, cache TrieNodeCache
 		nodes:         map[common.ExtHash]*cachedNode{{}: {}},
 		preimages:     make(map[common.Hash][]byte),
 		trieNodeCache: cache,
+		pathDB:        openPathDB(diskDB),
 	}
 }
 
@@ -544,6 +548,15 @@ func (db *Database) Node(hash common.ExtHash) ([]byte, error) {
 	if node != nil {
 		return node.rlp(), nil
 	}
+	// In the path scheme, only the state roots can be retrieved by hash
+	if db.pathDB != nil {
+		if enc := db.pathDB.readNode(common.Hash{}, nil, hash.Unextend()); enc != nil {
+			db.setCachedNode(hash, enc)
+			recordTrieCacheMiss()
+			return enc, nil
+		}
+		return nil, ErrPathSchemeUnsupported
+	}
 	// Content unavailable in memory, attempt to retrieve from disk
 	enc, err := db.diskDB.ReadTrieNode(hash)
 	if err == nil && enc != nil {
@@ -596,6 +609,9 @@ func (db *Database) DoesExistNodeInPersistent(hash common.ExtHash) bool {
 	if enc := db.getCachedNode(hash); enc != nil {
 		return true
 	}
+	if db.pathDB != nil {
+		return db.pathDB.hasState(hash.Unextend())
+	}
 
 	// Content unavailable in DB cache, attempt to retrieve from disk
 	enc, err := db.diskDB.ReadTrieNode(hash)
@@ -904,6 +920,9 @@ func (db *Database) concurrentCommit(hash common.ExtHash, resultCh chan<- commit
 //
 // As a side effect, all pre-images accumulated up to this point are also written.
 func (db *Database) Commit(root common.Hash, report bool, blockNum uint64) error {
+	if db.pathDB != nil {
+		return db.commitPath(common.Hash{}, root, report, blockNum)
+	}
 	hash := root.ExtendZero()
 	// Create a database batch to flush persistent data out. It is important that
 	// outside code doesn't see an inconsistent state (referenced data removed from
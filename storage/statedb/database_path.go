@@ -0,0 +1,613 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package statedb
+
+import (
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/rcrowley/go-metrics"
+)
+
+// trieHistoryCacheSize is the number of decoded trie histories kept in memory.
+const trieHistoryCacheSize = 16
+
+var (
+	pathCommitWrittenMeter = metrics.NewRegisteredMeter("trie/path/commit/written", nil)
+	pathCommitDeletedMeter = metrics.NewRegisteredMeter("trie/path/commit/deleted", nil)
+	pathHistoryReadMeter   = metrics.NewRegisteredMeter("trie/path/history/read", nil)
+	pathHistoryMissMeter   = metrics.NewRegisteredMeter("trie/path/history/miss", nil)
+)
+
+// trieHistoryNode is the value of a trie node before it was overwritten or
+// deleted by a block. An empty Prev means the node did not exist.
+type trieHistoryNode struct {
+	Owner common.Hash
+	Path  []byte
+	Prev  []byte
+}
+
+// trieHistory is the reverse diff of the trie nodes written by a block in the
+// path scheme. Applying it to the persisted state of Root rolls the state back
+// to ParentRoot.
+type trieHistory struct {
+	Root       common.Hash
+	ParentRoot common.Hash
+	Nodes      []trieHistoryNode
+
+	prevs map[string][]byte // Previous node values by their path keys
+}
+
+// prev returns the previous value of the node at the given path.
+func (h *trieHistory) prev(owner common.Hash, path []byte) ([]byte, bool) {
+	prev, ok := h.prevs[pathNodeKey(owner, path)]
+	return prev, ok
+}
+
+// pathDB keeps track of the state persisted in the path scheme and the reverse
+// diffs of the recent blocks, which are used to serve the recent states and to
+// roll the persisted state back.
+type pathDB struct {
+	diskDB database.DBManager
+	limit  uint64 // Number of recent blocks whose trie histories are kept
+
+	commitLock sync.Mutex   // Lock for serializing the writes of the persisted state
+	lock       sync.RWMutex // Lock for the range of the trie histories
+	tail       uint64       // Block number of the oldest trie history
+	head       uint64       // Block number of the newest trie history
+	histories  *lru.Cache   // Decoded recent trie histories by block number
+}
+
+// openPathDB returns the path-keyed state of the database if the database
+// stores the state in the path scheme, or nil otherwise.
+func openPathDB(diskDB database.DBManager) *pathDB {
+	if diskDB == nil || ReadStateScheme(diskDB) != PathScheme {
+		return nil
+	}
+	return newPathDB(diskDB)
+}
+
+func newPathDB(diskDB database.DBManager) *pathDB {
+	histories, _ := lru.New(trieHistoryCacheSize)
+	p := &pathDB{
+		diskDB:    diskDB,
+		limit:     DefaultTrieHistoryLimit,
+		histories: histories,
+	}
+	p.tail, p.head = diskDB.ReadTrieHistoryRange()
+	return p
+}
+
+// diskRoot returns the root of the state persisted on disk.
+func (p *pathDB) diskRoot() common.Hash {
+	if enc := p.diskDB.ReadTrieNodeByPath(common.Hash{}, nil); enc != nil {
+		return crypto.Keccak256Hash(enc)
+	}
+	return emptyRoot
+}
+
+// historyRange returns the block numbers of the oldest and the newest trie histories.
+func (p *pathDB) historyRange() (uint64, uint64) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.tail, p.head
+}
+
+// setHistoryRange updates and stores the range of the trie histories.
+func (p *pathDB) setHistoryRange(tail, head uint64) {
+	p.lock.Lock()
+	p.tail, p.head = tail, head
+	p.lock.Unlock()
+	p.diskDB.WriteTrieHistoryRange(tail, head)
+}
+
+// history returns the decoded trie history of the given block, or nil if it
+// does not exist.
+func (p *pathDB) history(number uint64) *trieHistory {
+	if h, ok := p.histories.Get(number); ok {
+		return h.(*trieHistory)
+	}
+	enc := p.diskDB.ReadTrieHistory(number)
+	if enc == nil {
+		return nil
+	}
+	h := new(trieHistory)
+	if err := rlp.DecodeBytes(enc, h); err != nil {
+		logger.Error("Failed to decode trie history", "number", number, "err", err)
+		return nil
+	}
+	h.prevs = make(map[string][]byte, len(h.Nodes))
+	for _, n := range h.Nodes {
+		h.prevs[pathNodeKey(n.Owner, n.Path)] = n.Prev
+	}
+	p.histories.Add(number, h)
+	return h
+}
+
+// readNode retrieves the node of the given hash at the given path, either from
+// the persisted state or from the trie histories.
+func (p *pathDB) readNode(owner common.Hash, path []byte, hash common.Hash) []byte {
+	if enc := p.diskDB.ReadTrieNodeByPath(owner, path); enc != nil && crypto.Keccak256Hash(enc) == hash {
+		return enc
+	}
+	tail, head := p.historyRange()
+	for number := head; number >= tail && number > 0; number-- {
+		h := p.history(number)
+		if h == nil {
+			continue
+		}
+		if enc, ok := h.prev(owner, path); ok && len(enc) > 0 && crypto.Keccak256Hash(enc) == hash {
+			pathHistoryReadMeter.Mark(1)
+			return enc
+		}
+	}
+	pathHistoryMissMeter.Mark(1)
+	return nil
+}
+
+// hasState returns if the state of the given root can be read.
+func (p *pathDB) hasState(root common.Hash) bool {
+	if root == p.diskRoot() {
+		return true
+	}
+	tail, head := p.historyRange()
+	for number := head; number >= tail && number > 0; number-- {
+		if h := p.history(number); h != nil && h.ParentRoot == root {
+			return true
+		}
+	}
+	return false
+}
+
+// recover rolls the persisted state back to the given root by applying the
+// trie histories from the newest one.
+func (p *pathDB) recover(root common.Hash) error {
+	p.commitLock.Lock()
+	defer p.commitLock.Unlock()
+
+	diskRoot := p.diskRoot()
+	if root == diskRoot {
+		return nil
+	}
+	tail, head := p.historyRange()
+	var target uint64
+	for number := head; number >= tail && number > 0; number-- {
+		if h := p.history(number); h != nil && h.ParentRoot == root {
+			target = number
+			break
+		}
+	}
+	if target == 0 {
+		return ErrStateHistoryNotFound
+	}
+	start := time.Now()
+	for number := head; number >= target; number-- {
+		h := p.history(number)
+		if h == nil {
+			continue
+		}
+		batch := p.diskDB.NewBatch(database.StateTrieDB)
+		for i := len(h.Nodes) - 1; i >= 0; i-- {
+			n := h.Nodes[i]
+			if len(n.Prev) == 0 {
+				p.diskDB.DeleteTrieNodeByPathFromBatch(batch, n.Owner, n.Path)
+			} else {
+				p.diskDB.PutTrieNodeByPathToBatch(batch, n.Owner, n.Path, n.Prev)
+			}
+			if _, err := database.WriteBatchesOverThreshold(batch); err != nil {
+				batch.Release()
+				return err
+			}
+		}
+		if err := batch.Write(); err != nil {
+			batch.Release()
+			return err
+		}
+		batch.Release()
+		p.diskDB.DeleteTrieHistory(number)
+		p.histories.Remove(number)
+	}
+	if head = target - 1; head < tail {
+		tail, head = 0, 0
+	}
+	p.setHistoryRange(tail, head)
+	logger.Info("Recovered the persisted state", "root", root, "from", diskRoot, "elapsed", time.Since(start))
+	return nil
+}
+
+// pathNodeKey identifies a trie node by its path in the trie of the owner.
+func pathNodeKey(owner common.Hash, path []byte) string {
+	return string(database.TrieNodePathKey(owner, path))
+}
+
+// pathNode is a dirty trie node to be written by its path.
+type pathNode struct {
+	owner common.Hash
+	path  []byte
+	hash  common.ExtHash
+	enc   []byte
+}
+
+// pathCommitter writes the dirty trie nodes of a state by their paths and
+// deletes the persisted nodes which are not part of the state anymore.
+type pathCommitter struct {
+	db    *Database
+	batch database.Batch
+
+	nodes   []*pathNode            // Dirty nodes in the order of the traversal
+	written map[string]struct{}    // Path keys of the dirty nodes
+	refs    map[string]struct{}    // Path keys of the nodes referenced by the dirty nodes
+	deleted map[string]struct{}    // Path keys of the deleted nodes
+	history []trieHistoryNode      // Previous values of the modified nodes
+	oldRoot map[common.Hash][]byte // Storage roots of the overwritten accounts
+	newRoot map[common.Hash][]byte // Storage roots of the written accounts
+}
+
+// children returns the paths and the hashes of the trie nodes referenced by
+// the given node, and the values of the leaves embedded in it.
+func nodeChildren(n node, path []byte, children map[string]common.ExtHash, leaves map[string][]byte) {
+	switch n := n.(type) {
+	case *shortNode:
+		key := n.Key
+		if hasTerm(key) {
+			key = key[:len(key)-1]
+		}
+		nodeChildren(n.Val, append(append([]byte{}, path...), key...), children, leaves)
+	case *fullNode:
+		for i := 0; i < 16; i++ {
+			if n.Children[i] != nil {
+				nodeChildren(n.Children[i], append(append([]byte{}, path...), byte(i)), children, leaves)
+			}
+		}
+		if n.Children[16] != nil {
+			nodeChildren(n.Children[16], path, children, leaves)
+		}
+	case hashNode:
+		children[string(path)] = common.BytesToExtHash(n)
+	case valueNode:
+		if leaves != nil && len(path) == 2*common.HashLength {
+			leaves[string(path)] = n
+		}
+	}
+}
+
+// storageRoot returns the storage root of the account leaf. The empty root is
+// returned for an account without storage, and false for a value which is not
+// an account.
+func storageRoot(leaf []byte) (common.Hash, bool) {
+	serializer := account.NewAccountSerializer()
+	if err := rlp.DecodeBytes(leaf, serializer); err != nil {
+		return common.Hash{}, false
+	}
+	if pa := account.GetProgramAccount(serializer.GetAccount()); pa != nil {
+		return pa.GetStorageRoot().Unextend(), true
+	}
+	return emptyRoot, true
+}
+
+// collect gathers the dirty nodes reachable from the given node. The storage
+// tries of the accounts found in the account trie are gathered as well.
+func (c *pathCommitter) collect(owner common.Hash, path []byte, hash common.ExtHash) {
+	key := pathNodeKey(owner, path)
+	if _, ok := c.written[key]; ok {
+		return
+	}
+	cached, ok := c.db.nodes[hash]
+	if !ok {
+		return
+	}
+	c.written[key] = struct{}{}
+	c.nodes = append(c.nodes, &pathNode{owner: owner, path: path, hash: hash, enc: cached.rlp()})
+
+	var (
+		children = make(map[string]common.ExtHash)
+		leaves   map[string][]byte
+	)
+	if owner == (common.Hash{}) {
+		leaves = make(map[string][]byte)
+	}
+	nodeChildren(cached.obj(hash), path, children, leaves)
+	for childPath, child := range children {
+		c.refs[pathNodeKey(owner, []byte(childPath))] = struct{}{}
+		c.collect(owner, []byte(childPath), child)
+	}
+	for leafPath, leaf := range leaves {
+		root, ok := storageRoot(leaf)
+		if !ok {
+			continue
+		}
+		accountHash := common.BytesToHash(hexToKeybytes([]byte(leafPath)))
+		c.newRoot[accountHash] = root.Bytes()
+		if root != emptyRoot {
+			c.collect(accountHash, nil, root.ExtendZero())
+		}
+	}
+}
+
+// readPrev reads the persisted node at the given path and records it in the
+// history if it is read for the first time.
+func (c *pathCommitter) readPrev(owner common.Hash, path []byte) []byte {
+	prev := c.db.diskDB.ReadTrieNodeByPath(owner, path)
+	c.history = append(c.history, trieHistoryNode{Owner: owner, Path: path, Prev: prev})
+	return prev
+}
+
+// diff compares the overwritten node with its new children and deletes the
+// persisted subtries which are not referenced anymore.
+func (c *pathCommitter) diff(owner common.Hash, path []byte, prev []byte) {
+	if prev == nil {
+		return
+	}
+	var (
+		children = make(map[string]common.ExtHash)
+		leaves   map[string][]byte
+	)
+	if owner == (common.Hash{}) {
+		leaves = make(map[string][]byte)
+	}
+	nodeChildren(mustDecodeNode(nil, prev), path, children, leaves)
+	for childPath := range children {
+		c.deleteSubtrie(owner, []byte(childPath))
+	}
+	for leafPath, leaf := range leaves {
+		if root, ok := storageRoot(leaf); ok {
+			c.oldRoot[common.BytesToHash(hexToKeybytes([]byte(leafPath)))] = root.Bytes()
+		}
+	}
+}
+
+// deleteSubtrie deletes the persisted subtrie at the given path unless it is
+// written or referenced by the new state.
+func (c *pathCommitter) deleteSubtrie(owner common.Hash, path []byte) {
+	key := pathNodeKey(owner, path)
+	if _, ok := c.written[key]; ok {
+		return
+	}
+	if _, ok := c.refs[key]; ok {
+		return
+	}
+	if _, ok := c.deleted[key]; ok {
+		return
+	}
+	c.deleted[key] = struct{}{}
+
+	prev := c.readPrev(owner, path)
+	if prev == nil {
+		return
+	}
+	c.db.diskDB.DeleteTrieNodeByPathFromBatch(c.batch, owner, path)
+	c.diff(owner, path, prev)
+}
+
+// deleteStorage deletes all persisted nodes of the storage trie of the account.
+func (c *pathCommitter) deleteStorage(owner common.Hash) {
+	paths, nodes := c.db.diskDB.ReadStorageTrieNodes(owner)
+	for i, path := range paths {
+		key := pathNodeKey(owner, path)
+		if _, ok := c.deleted[key]; ok {
+			continue
+		}
+		c.deleted[key] = struct{}{}
+		c.history = append(c.history, trieHistoryNode{Owner: owner, Path: path, Prev: nodes[i]})
+		c.db.diskDB.DeleteTrieNodeByPathFromBatch(c.batch, owner, path)
+	}
+}
+
+// commit writes the dirty nodes of the trie of the given root owned by the
+// owner. If raw is true, the nodes are written without deleting stale nodes
+// and recording the history, which is used for the tries generated outside
+// of block processing, e.g. the genesis state and the tries of snap sync.
+func (c *pathCommitter) commit(owner common.Hash, root common.Hash, raw bool) error {
+	c.collect(owner, nil, root.ExtendZero())
+
+	if !raw {
+		for _, n := range c.nodes {
+			c.diff(n.owner, n.path, c.readPrev(n.owner, n.path))
+		}
+		if owner == (common.Hash{}) && (root == emptyRoot || root == common.Hash{}) {
+			c.deleteSubtrie(owner, nil)
+		}
+		// Delete the storage tries of the destructed accounts and the
+		// accounts whose storage is cleared.
+		for accountHash, prevRoot := range c.oldRoot {
+			if common.BytesToHash(prevRoot) == emptyRoot {
+				continue
+			}
+			if newRoot, ok := c.newRoot[accountHash]; !ok || common.BytesToHash(newRoot) == emptyRoot {
+				c.deleteStorage(accountHash)
+			}
+		}
+	}
+	for _, n := range c.nodes {
+		c.db.diskDB.PutTrieNodeByPathToBatch(c.batch, n.owner, n.path, n.enc)
+		if _, err := database.WriteBatchesOverThreshold(c.batch); err != nil {
+			return err
+		}
+	}
+	pathCommitWrittenMeter.Mark(int64(len(c.nodes)))
+	pathCommitDeletedMeter.Mark(int64(len(c.deleted)))
+	return nil
+}
+
+// commitPath writes the trie of the given root by the paths of the nodes. If
+// the block number is nonzero, the state is committed on top of the persisted
+// state, and the previous values of the modified nodes are stored as the trie
+// history of the block.
+func (db *Database) commitPath(owner common.Hash, root common.Hash, report bool, blockNum uint64) error {
+	p := db.pathDB
+	p.commitLock.Lock()
+	defer p.commitLock.Unlock()
+
+	raw := blockNum == 0 || owner != (common.Hash{})
+
+	db.lock.RLock()
+	commitStart := time.Now()
+	db.diskDB.WritePreimages(0, db.preimages)
+	numPreimages := len(db.preimages)
+	numNodes, nodesSize := len(db.nodes), db.nodesSize
+
+	batch := db.diskDB.NewBatch(database.StateTrieDB)
+	defer batch.Release()
+	c := &pathCommitter{
+		db:      db,
+		batch:   batch,
+		written: make(map[string]struct{}),
+		refs:    make(map[string]struct{}),
+		deleted: make(map[string]struct{}),
+		oldRoot: make(map[common.Hash][]byte),
+		newRoot: make(map[common.Hash][]byte),
+	}
+	if err := c.commit(owner, root, raw); err != nil {
+		db.lock.RUnlock()
+		return err
+	}
+	db.lock.RUnlock()
+
+	// The history is stored before the nodes so that the previous state is
+	// still readable while the nodes are being overwritten.
+	if !raw && len(c.history) > 0 {
+		enc, err := rlp.EncodeToBytes(&trieHistory{Root: root, ParentRoot: p.diskRoot(), Nodes: c.history})
+		if err != nil {
+			return err
+		}
+		tail, head := p.historyRange()
+		for number := head; number >= blockNum && number > 0; number-- {
+			// Histories of the blocks which have not been recovered are discarded.
+			p.diskDB.DeleteTrieHistory(number)
+			p.histories.Remove(number)
+		}
+		p.diskDB.WriteTrieHistory(blockNum, enc)
+		if head == 0 || tail >= blockNum {
+			tail = blockNum
+		}
+		for ; tail+p.limit <= blockNum; tail++ {
+			p.diskDB.DeleteTrieHistory(tail)
+			p.histories.Remove(tail)
+		}
+		p.setHistoryRange(tail, blockNum)
+	}
+	if err := batch.Write(); err != nil {
+		logger.Error("Failed to write trie to disk", "err", err)
+		return err
+	}
+	for _, n := range c.nodes {
+		db.setCachedNode(n.hash, n.enc)
+	}
+
+	// Write successful, clear out the flushed data
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.preimages = make(map[common.Hash][]byte)
+	db.preimagesSize = 0
+	for _, n := range c.nodes {
+		db.uncache(n.hash)
+	}
+	commitEnd := time.Now()
+
+	memcacheCommitTimeGauge.Update(int64(commitEnd.Sub(commitStart)))
+	memcacheCommitSizeMeter.Mark(int64(nodesSize - db.nodesSize))
+	memcacheCommitNodesMeter.Mark(int64(numNodes - len(db.nodes)))
+
+	localLogger := logger.Info
+	if !report {
+		localLogger = logger.Debug
+	}
+	localLogger("Persisted trie by path from memory database", "blockNum", blockNum,
+		"written", len(c.nodes), "deleted", len(c.deleted), "history", len(c.history),
+		"time", commitEnd.Sub(commitStart), "livenodes", len(db.nodes), "livesize", db.nodesSize,
+		"preimages", numPreimages)
+	return nil
+}
+
+// Scheme returns the scheme used to persist the trie nodes.
+func (db *Database) Scheme() string {
+	if db.pathDB != nil {
+		return PathScheme
+	}
+	return HashScheme
+}
+
+// Recover rolls the state persisted in the path scheme back to the given root
+// using the trie histories of the recent blocks. It does nothing in the hash
+// scheme, where every committed state stays on disk.
+func (db *Database) Recover(root common.Hash) error {
+	if db.pathDB == nil {
+		return nil
+	}
+	return db.pathDB.recover(root)
+}
+
+// CommitStorage writes the storage trie of the given root owned by the account
+// hash to disk. It is used for the storage tries generated outside of block
+// processing, which cannot be reached from a state root.
+func (db *Database) CommitStorage(owner common.Hash, root common.Hash) error {
+	if db.pathDB == nil {
+		return db.Commit(root, false, 0)
+	}
+	return db.commitPath(owner, root, false, 0)
+}
+
+// readNode retrieves the trie node of the given hash. In the path scheme, the
+// node is looked up by its path in the trie owned by the given account hash.
+func (db *Database) readNode(owner common.Hash, path []byte, hash common.ExtHash) (node, bool) {
+	if db.pathDB == nil {
+		return db.node(hash)
+	}
+	enc, fromDB := db.readNodeBlob(owner, path, hash)
+	if enc == nil {
+		return nil, fromDB
+	}
+	return mustDecodeNode(hash[:], enc), fromDB
+}
+
+// nodeBlob retrieves the encoded trie node of the given hash, which is located
+// at the given path in the trie owned by the given account hash.
+func (db *Database) nodeBlob(owner common.Hash, path []byte, hash common.ExtHash) []byte {
+	if db.pathDB == nil {
+		enc, _ := db.Node(hash)
+		return enc
+	}
+	enc, _ := db.readNodeBlob(owner, path, hash)
+	return enc
+}
+
+// readNodeBlob retrieves the encoded trie node of the given hash by its path
+// in the trie owned by the given account hash. It is used in the path scheme.
+func (db *Database) readNodeBlob(owner common.Hash, path []byte, hash common.ExtHash) ([]byte, bool) {
+	if enc := db.getCachedNode(hash); enc != nil {
+		return enc, false
+	}
+	db.lock.RLock()
+	cached := db.nodes[hash]
+	db.lock.RUnlock()
+	if cached != nil {
+		return cached.rlp(), false
+	}
+	enc := db.pathDB.readNode(owner, path, hash.Unextend())
+	if enc != nil {
+		db.setCachedNode(hash, enc)
+		recordTrieCacheMiss()
+	}
+	return enc, true
+}
@@ -0,0 +1,246 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package statedb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPathSchemeDBManager() database.DBManager {
+	dbm := database.NewMemoryDBManager()
+	dbm.WriteStateScheme(PathScheme)
+	return dbm
+}
+
+// countPathNodes returns the number of the account trie nodes stored by path.
+func countPathNodes(dbm database.DBManager) int {
+	it := dbm.GetMemDB().NewIterator(database.TrieNodePathKey(common.Hash{}, nil), nil)
+	defer it.Release()
+
+	count := 0
+	for it.Next() {
+		count++
+	}
+	return count
+}
+
+// countTrieNodes returns the number of the trie nodes which are not embedded in their parents.
+func countTrieNodes(t *testing.T, db *Database, root common.Hash) int {
+	tr, err := NewSecureTrie(root, db, nil)
+	require.NoError(t, err)
+
+	count := 0
+	it := tr.NodeIterator(nil)
+	for it.Next(true) {
+		if it.Hash() != (common.Hash{}) {
+			count++
+		}
+	}
+	require.NoError(t, it.Error())
+	return count
+}
+
+// commitPathTrie updates the trie of the given root with the entries, and
+// commits it as the state of the given block.
+func commitPathTrie(t *testing.T, db *Database, root common.Hash, blockNum uint64, entries map[string]string) common.Hash {
+	tr, err := NewSecureTrie(root, db, nil)
+	require.NoError(t, err)
+	for k, v := range entries {
+		if v == "" {
+			tr.Delete([]byte(k))
+		} else {
+			tr.Update([]byte(k), []byte(v))
+		}
+	}
+	newRoot, err := tr.Commit(nil)
+	require.NoError(t, err)
+	require.NoError(t, db.Commit(newRoot, false, blockNum))
+	return newRoot
+}
+
+func checkPathTrie(t *testing.T, db *Database, root common.Hash, entries map[string]string) {
+	tr, err := NewSecureTrie(root, db, nil)
+	require.NoError(t, err)
+	for k, v := range entries {
+		val, err := tr.TryGet([]byte(k))
+		require.NoError(t, err, k)
+		if v == "" {
+			assert.Nil(t, val, k)
+		} else {
+			assert.Equal(t, []byte(v), val, k)
+		}
+	}
+}
+
+func testEntries(from, to int, value string) map[string]string {
+	entries := make(map[string]string)
+	for i := from; i < to; i++ {
+		entries[fmt.Sprintf("key-%03d", i)] = fmt.Sprintf("%s-%03d", value, i)
+	}
+	return entries
+}
+
+func deletedEntries(from, to int) map[string]string {
+	entries := make(map[string]string)
+	for i := from; i < to; i++ {
+		entries[fmt.Sprintf("key-%03d", i)] = ""
+	}
+	return entries
+}
+
+func TestPathScheme_Commit(t *testing.T) {
+	dbm := newPathSchemeDBManager()
+	db := NewDatabase(dbm)
+	assert.Equal(t, PathScheme, db.Scheme())
+
+	entries := testEntries(0, 100, "value")
+	root := commitPathTrie(t, db, common.Hash{}, 1, entries)
+
+	// The nodes are not stored by their hashes
+	has, _ := dbm.HasTrieNode(root.ExtendZero())
+	assert.False(t, has)
+	assert.Equal(t, root, db.pathDB.diskRoot())
+
+	// The trie is readable from a fresh database
+	db = NewDatabase(dbm)
+	checkPathTrie(t, db, root, entries)
+	assert.Equal(t, countTrieNodes(t, db, root), countPathNodes(dbm))
+}
+
+func TestPathScheme_DeleteStaleNodes(t *testing.T) {
+	dbm := newPathSchemeDBManager()
+	db := NewDatabase(dbm)
+
+	root := commitPathTrie(t, db, common.Hash{}, 1, testEntries(0, 100, "value"))
+
+	// Update some entries and delete the others
+	entries := testEntries(0, 30, "updated")
+	for k := range deletedEntries(50, 100) {
+		entries[k] = ""
+	}
+	root = commitPathTrie(t, db, root, 2, entries)
+
+	db = NewDatabase(dbm)
+	checkPathTrie(t, db, root, entries)
+	assert.Equal(t, countTrieNodes(t, db, root), countPathNodes(dbm))
+
+	// Deleting all entries leaves no node on disk
+	root = commitPathTrie(t, db, root, 3, deletedEntries(0, 50))
+	assert.Equal(t, emptyRoot, root)
+	assert.Equal(t, 0, countPathNodes(dbm))
+}
+
+func TestPathScheme_HistoryAndRecover(t *testing.T) {
+	dbm := newPathSchemeDBManager()
+	db := NewDatabase(dbm)
+
+	entries1 := testEntries(0, 100, "value")
+	root1 := commitPathTrie(t, db, common.Hash{}, 1, entries1)
+	entries2 := testEntries(0, 50, "updated")
+	root2 := commitPathTrie(t, db, root1, 2, entries2)
+	entries3 := testEntries(100, 150, "added")
+	root3 := commitPathTrie(t, db, root2, 3, entries3)
+
+	// The recent states are readable from the trie histories
+	db = NewDatabase(dbm)
+	assert.True(t, db.DoesExistNodeInPersistent(root1.ExtendZero()))
+	assert.True(t, db.DoesExistNodeInPersistent(root2.ExtendZero()))
+	assert.True(t, db.DoesExistNodeInPersistent(root3.ExtendZero()))
+	checkPathTrie(t, db, root1, entries1)
+	checkPathTrie(t, db, root2, entries2)
+	checkPathTrie(t, db, root3, entries3)
+
+	// Roll the persisted state back to the first block
+	require.NoError(t, db.Recover(root1))
+	assert.Equal(t, root1, db.pathDB.diskRoot())
+	assert.False(t, db.DoesExistNodeInPersistent(root3.ExtendZero()))
+	tail, head := dbm.ReadTrieHistoryRange()
+	assert.Equal(t, uint64(1), tail)
+	assert.Equal(t, uint64(1), head)
+
+	db = NewDatabase(dbm)
+	checkPathTrie(t, db, root1, entries1)
+	assert.Equal(t, countTrieNodes(t, db, root1), countPathNodes(dbm))
+
+	// An unknown state cannot be recovered
+	assert.ErrorIs(t, db.Recover(root3), ErrStateHistoryNotFound)
+
+	// A new state can be committed on top of the recovered one
+	root2 = commitPathTrie(t, db, root1, 2, entries2)
+	checkPathTrie(t, db, root2, entries2)
+	assert.Equal(t, countTrieNodes(t, db, root2), countPathNodes(dbm))
+}
+
+func TestPathScheme_HistoryLimit(t *testing.T) {
+	dbm := newPathSchemeDBManager()
+	db := NewDatabase(dbm)
+	db.pathDB.limit = 2
+
+	var roots []common.Hash
+	root := common.Hash{}
+	for i := uint64(1); i <= 4; i++ {
+		root = commitPathTrie(t, db, root, i, testEntries(0, 10, fmt.Sprintf("value%d", i)))
+		roots = append(roots, root)
+	}
+	tail, head := dbm.ReadTrieHistoryRange()
+	assert.Equal(t, uint64(3), tail)
+	assert.Equal(t, uint64(4), head)
+	assert.Nil(t, dbm.ReadTrieHistory(2))
+
+	// Only the states of the recent blocks can be accessed
+	assert.False(t, db.DoesExistNodeInPersistent(roots[0].ExtendZero()))
+	assert.True(t, db.DoesExistNodeInPersistent(roots[1].ExtendZero()))
+	assert.True(t, db.DoesExistNodeInPersistent(roots[2].ExtendZero()))
+	assert.True(t, db.DoesExistNodeInPersistent(roots[3].ExtendZero()))
+}
+
+func TestParseStateScheme(t *testing.T) {
+	// A fresh database stores the provided scheme
+	dbm := database.NewMemoryDBManager()
+	scheme, err := ParseStateScheme(PathScheme, dbm)
+	require.NoError(t, err)
+	assert.Equal(t, PathScheme, scheme)
+	assert.Equal(t, PathScheme, dbm.ReadStateScheme())
+
+	// The stored scheme is used if none is provided
+	scheme, err = ParseStateScheme("", dbm)
+	require.NoError(t, err)
+	assert.Equal(t, PathScheme, scheme)
+
+	// The stored scheme cannot be changed
+	_, err = ParseStateScheme(HashScheme, dbm)
+	assert.ErrorIs(t, err, ErrStateSchemeMismatch)
+
+	// An invalid scheme is rejected
+	_, err = ParseStateScheme("unknown", dbm)
+	assert.ErrorIs(t, err, ErrInvalidStateScheme)
+
+	// An existing database without a scheme uses the hash scheme
+	dbm = database.NewMemoryDBManager()
+	dbm.WriteHeadBlockHash(common.HexToHash("0x01"))
+	scheme, err = ParseStateScheme("", dbm)
+	require.NoError(t, err)
+	assert.Equal(t, HashScheme, scheme)
+	_, err = ParseStateScheme(PathScheme, dbm)
+	assert.ErrorIs(t, err, ErrStateSchemeMismatch)
+}
@@ -42,4 +42,9 @@ func (err *MissingNodeError) Error() string {
 var (
 	ErrZeroHashNode    = errors.New("cannot retrieve a node which has 0x00 hash value")
 	ErrPruningDisabled = errors.New("pruning is disabled on database")
+
+	ErrInvalidStateScheme    = errors.New("invalid state scheme")
+	ErrStateSchemeMismatch   = errors.New("state scheme mismatch")
+	ErrStateHistoryNotFound  = errors.New("state is not found in the trie histories")
+	ErrPathSchemeUnsupported = errors.New("not supported in the path scheme")
 )
@@ -0,0 +1,122 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package proofverifier verifies the merkle proofs of accounts and storage slots,
+// such as the ones returned by klay_getProof, against the state root of a block
+// header. It requires neither a database nor a running node.
+package proofverifier
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/statedb"
+)
+
+var (
+	// ErrAccountNotFound is returned if the storage of a non-existent account is verified.
+	ErrAccountNotFound = errors.New("account does not exist")
+
+	errNodeNotFound = errors.New("proof node not found")
+)
+
+// NodeSet is a set of the encoded trie nodes of merkle proofs, indexed by the
+// hashes of the nodes. It implements statedb.ProofDBReader.
+type NodeSet map[common.Hash][]byte
+
+// NewNodeSet returns a NodeSet with the given encoded trie nodes.
+func NewNodeSet(proofs ...[][]byte) NodeSet {
+	set := make(NodeSet)
+	for _, proof := range proofs {
+		for _, node := range proof {
+			set[crypto.Keccak256Hash(node)] = node
+		}
+	}
+	return set
+}
+
+// ReadTrieNode returns the encoded trie node of the given hash.
+func (s NodeSet) ReadTrieNode(hash common.ExtHash) ([]byte, error) {
+	if node, ok := s[hash.Unextend()]; ok {
+		return node, nil
+	}
+	return nil, errNodeNotFound
+}
+
+// VerifyAccount verifies the merkle proof of the account of the given address
+// against the state root. It returns the account decoded from the Klaytn
+// serialization, or nil if the proof shows that the account does not exist.
+func VerifyAccount(stateRoot common.Hash, addr common.Address, proof [][]byte) (account.Account, error) {
+	enc, err, _ := statedb.VerifyProof(stateRoot, crypto.Keccak256(addr.Bytes()), NewNodeSet(proof))
+	if err != nil {
+		return nil, fmt.Errorf("invalid account proof: %v", err)
+	}
+	if len(enc) == 0 {
+		return nil, nil
+	}
+	serializer := account.NewAccountSerializer()
+	if err := rlp.DecodeBytes(enc, serializer); err != nil {
+		return nil, fmt.Errorf("invalid account: %v", err)
+	}
+	return serializer.GetAccount(), nil
+}
+
+// VerifyStorage verifies the merkle proof of the storage slot against the
+// storage root of an account, and returns the value of the slot.
+func VerifyStorage(storageRoot common.Hash, key common.Hash, proof [][]byte) (common.Hash, error) {
+	if storageRoot == types.EmptyRootHashOriginal || storageRoot == (common.Hash{}) {
+		return common.Hash{}, nil
+	}
+	enc, err, _ := statedb.VerifyProof(storageRoot, crypto.Keccak256(key.Bytes()), NewNodeSet(proof))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("invalid storage proof: %v", err)
+	}
+	if len(enc) == 0 {
+		return common.Hash{}, nil
+	}
+	_, content, _, err := rlp.Split(enc)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("invalid storage value: %v", err)
+	}
+	return common.BytesToHash(content), nil
+}
+
+// VerifyAccountStorage verifies the account of the given address against the
+// state root, and then the storage slot of the account against its storage root.
+func VerifyAccountStorage(stateRoot common.Hash, addr common.Address, accountProof [][]byte, key common.Hash, storageProof [][]byte) (common.Hash, error) {
+	acc, err := VerifyAccount(stateRoot, addr, accountProof)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if acc == nil {
+		return common.Hash{}, ErrAccountNotFound
+	}
+	return VerifyStorage(StorageRoot(acc), key, storageProof)
+}
+
+// StorageRoot returns the merkle hash of the storage root of the account.
+// Accounts without storage have the empty root.
+func StorageRoot(acc account.Account) common.Hash {
+	if pa := account.GetProgramAccount(acc); pa != nil {
+		return pa.GetStorageRoot().Unextend()
+	}
+	return types.EmptyRootHashOriginal
+}
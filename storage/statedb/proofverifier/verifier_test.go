@@ -0,0 +1,115 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package proofverifier
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyProof(t *testing.T) {
+	var (
+		key, _   = crypto.GenerateKey()
+		eoa      = crypto.PubkeyToAddress(key.PublicKey)
+		contract = common.HexToAddress("0xc0de")
+		empty    = common.HexToAddress("0xe3")
+		missing  = common.HexToAddress("0xdead")
+	)
+	stateDB, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	require.NoError(t, err)
+
+	stateDB.CreateEOA(eoa, false, accountkey.NewAccountKeyPublicWithValue(&key.PublicKey))
+	stateDB.AddBalance(eoa, big.NewInt(1000))
+	stateDB.CreateSmartContractAccount(contract, params.CodeFormatEVM, params.TestChainConfig.Rules(common.Big0))
+	stateDB.SetCode(contract, []byte{0x60, 0x00})
+	for i := int64(1); i <= 20; i++ {
+		stateDB.SetState(contract, common.BigToHash(big.NewInt(i)), common.BigToHash(big.NewInt(i*100)))
+	}
+	stateDB.CreateSmartContractAccount(empty, params.CodeFormatEVM, params.TestChainConfig.Rules(common.Big0))
+	for i := 0; i < 20; i++ {
+		stateDB.AddBalance(common.BigToAddress(big.NewInt(int64(i+1))), big.NewInt(1))
+	}
+	root, err := stateDB.Commit(false)
+	require.NoError(t, err)
+
+	stateDB, err = state.New(root, stateDB.Database(), nil, nil)
+	require.NoError(t, err)
+
+	// The EOA is verified with its account key
+	proof, err := stateDB.GetProof(eoa)
+	require.NoError(t, err)
+	acc, err := VerifyAccount(root, eoa, proof)
+	require.NoError(t, err)
+	require.NotNil(t, acc)
+	assert.Equal(t, account.ExternallyOwnedAccountType, acc.Type())
+	assert.Equal(t, big.NewInt(1000), acc.GetBalance())
+	assert.True(t, account.GetAccountWithKey(acc).GetKey().Equal(stateDB.GetKey(eoa)))
+	assert.Equal(t, types.EmptyRootHashOriginal, StorageRoot(acc))
+
+	// The storage slots of the contract are verified against its storage root
+	accountProof, err := stateDB.GetProof(contract)
+	require.NoError(t, err)
+	contractAcc, err := VerifyAccount(root, contract, accountProof)
+	require.NoError(t, err)
+	require.NotNil(t, contractAcc)
+	assert.Equal(t, account.SmartContractAccountType, contractAcc.Type())
+	for i := int64(0); i <= 21; i++ {
+		slot := common.BigToHash(big.NewInt(i))
+		storageProof, err := stateDB.GetStorageProof(contract, slot)
+		require.NoError(t, err)
+		value, err := VerifyAccountStorage(root, contract, accountProof, slot, storageProof)
+		require.NoError(t, err, i)
+		assert.Equal(t, stateDB.GetState(contract, slot), value, i)
+	}
+
+	// A contract without storage has the empty storage root
+	proof, err = stateDB.GetProof(empty)
+	require.NoError(t, err)
+	value, err := VerifyAccountStorage(root, empty, proof, common.Hash{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash{}, value)
+
+	// A proof of absence is verified for a non-existent account
+	proof, err = stateDB.GetProof(missing)
+	require.NoError(t, err)
+	acc, err = VerifyAccount(root, missing, proof)
+	require.NoError(t, err)
+	assert.Nil(t, acc)
+	_, err = VerifyAccountStorage(root, missing, proof, common.Hash{}, nil)
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+
+	// Incomplete or forged proofs are rejected
+	_, err = VerifyAccount(root, eoa, accountProof)
+	assert.Error(t, err)
+	_, err = VerifyAccount(common.HexToHash("0x01"), contract, accountProof)
+	assert.Error(t, err)
+	storageProof, err := stateDB.GetStorageProof(contract, common.BigToHash(big.NewInt(1)))
+	require.NoError(t, err)
+	_, err = VerifyStorage(StorageRoot(contractAcc), common.BigToHash(big.NewInt(1)), storageProof[:len(storageProof)-1])
+	assert.Error(t, err)
+}
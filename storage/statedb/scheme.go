@@ -0,0 +1,81 @@
+// Copyright 2024 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package statedb
+
+import (
+	"fmt"
+
+	"github.com/klaytn/klaytn/common"
+)
+
+const (
+	// HashScheme stores the trie nodes keyed by their hashes. Every version of
+	// the state is kept until it is pruned.
+	HashScheme = "hash"
+
+	// PathScheme stores the trie nodes keyed by their paths in the trie. Only
+	// the latest state is kept on disk, and the recent states are served from
+	// the reverse diffs of the recent blocks.
+	PathScheme = "path"
+)
+
+// DefaultTrieHistoryLimit is the number of recent blocks whose states can be
+// accessed in the path scheme.
+const DefaultTrieHistoryLimit = 128
+
+// stateSchemeDB is the set of database operations used to select the state scheme.
+type stateSchemeDB interface {
+	ReadStateScheme() string
+	WriteStateScheme(scheme string)
+	ReadHeadBlockHash() common.Hash
+}
+
+// ReadStateScheme returns the state scheme of the given database. The hash
+// scheme is returned for the databases created without a scheme.
+func ReadStateScheme(db interface{ ReadStateScheme() string }) string {
+	if scheme := db.ReadStateScheme(); scheme != "" {
+		return scheme
+	}
+	return HashScheme
+}
+
+// ParseStateScheme checks the given state scheme against the one stored in the
+// database and returns the scheme to be used. If the database has no scheme
+// yet, the given one (or the hash scheme by default) is stored for a fresh
+// database, and the hash scheme is used for an existing one.
+func ParseStateScheme(provided string, db stateSchemeDB) (string, error) {
+	if provided != "" && provided != HashScheme && provided != PathScheme {
+		return "", fmt.Errorf("%w: %q", ErrInvalidStateScheme, provided)
+	}
+	stored := db.ReadStateScheme()
+	if stored == "" {
+		if db.ReadHeadBlockHash() != (common.Hash{}) {
+			stored = HashScheme
+		} else {
+			stored = provided
+			if stored == "" {
+				stored = HashScheme
+			}
+			db.WriteStateScheme(stored)
+			logger.Info("Initialized state scheme", "scheme", stored)
+		}
+	}
+	if provided != "" && provided != stored {
+		return "", fmt.Errorf("%w: stored %s, provided %s", ErrStateSchemeMismatch, stored, provided)
+	}
+	return stored, nil
+}
@@ -21,6 +21,7 @@
 package statedb
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"strconv"
@@ -28,6 +29,7 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/prque"
+	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/storage/database"
 )
 
@@ -101,15 +103,17 @@ type SyncResult struct {
 // syncMemBatch is an in-memory buffer of successfully downloaded but not yet
 // persisted data items.
 type syncMemBatch struct {
-	nodes map[common.Hash][]byte // In-memory membatch of recently completed nodes
-	codes map[common.Hash][]byte // In-memory membatch of recently completed codes
+	nodes     map[common.Hash][]byte // In-memory membatch of recently completed nodes
+	pathNodes map[string][]byte      // In-memory membatch of recently completed nodes by their paths (path scheme)
+	codes     map[common.Hash][]byte // In-memory membatch of recently completed codes
 }
 
 // newSyncMemBatch allocates a new memory-buffer for not-yet persisted trie nodes.
 func newSyncMemBatch() *syncMemBatch {
 	return &syncMemBatch{
-		nodes: make(map[common.Hash][]byte),
-		codes: make(map[common.Hash][]byte),
+		nodes:     make(map[common.Hash][]byte),
+		pathNodes: make(map[string][]byte),
+		codes:     make(map[common.Hash][]byte),
 	}
 }
 
@@ -129,22 +133,26 @@ type StateTrieReadDB interface {
 	ReadTrieNode(hash common.ExtHash) ([]byte, error)
 	HasTrieNode(hash common.ExtHash) (bool, error)
 	HasCodeWithPrefix(hash common.Hash) bool
+	ReadTrieNodeByPath(owner common.Hash, path []byte) []byte
+	ReadStateScheme() string
 }
 
 // TrieSync is the main state trie synchronisation scheduler, which provides yet
 // unknown trie hashes to retrieve, accepts node data associated with said hashes
 // and reconstructs the trie step by step until all is done.
 type TrieSync struct {
-	database         StateTrieReadDB          // Persistent database to check for existing entries
-	membatch         *syncMemBatch            // Memory buffer to avoid frequent database writes
-	nodeReqs         map[common.Hash]*request // Pending requests pertaining to a trie node hash
-	codeReqs         map[common.Hash]*request // Pending requests pertaining to a code hash
-	queue            *prque.Prque             // Priority queue with the pending requests
-	fetches          map[int]int              // Number of active fetches per trie node depth
-	retrievedByDepth map[int]int              // Retrieved trie node number counted by depth
-	committedByDepth map[int]int              // Committed trie nodes number counted by depth
-	bloom            *SyncBloom               // Bloom filter for fast state existence checks
-	exist            *lru.Cache               // exist to check if the trie node is already written or not
+	database         StateTrieReadDB            // Persistent database to check for existing entries
+	scheme           string                     // Scheme used to persist the trie nodes
+	membatch         *syncMemBatch              // Memory buffer to avoid frequent database writes
+	nodeReqs         map[common.Hash]*request   // Pending requests pertaining to a trie node hash
+	pathReqs         map[common.Hash][]*request // Pending requests of the same node at other paths (path scheme)
+	codeReqs         map[common.Hash]*request   // Pending requests pertaining to a code hash
+	queue            *prque.Prque               // Priority queue with the pending requests
+	fetches          map[int]int                // Number of active fetches per trie node depth
+	retrievedByDepth map[int]int                // Retrieved trie node number counted by depth
+	committedByDepth map[int]int                // Committed trie nodes number counted by depth
+	bloom            *SyncBloom                 // Bloom filter for fast state existence checks
+	exist            *lru.Cache                 // exist to check if the trie node is already written or not
 }
 
 // NewTrieSync creates a new trie data download scheduler.
@@ -152,8 +160,10 @@ type TrieSync struct {
 func NewTrieSync(root common.Hash, database StateTrieReadDB, callback LeafCallback, bloom *SyncBloom, lruCache *lru.Cache) *TrieSync {
 	ts := &TrieSync{
 		database:         database,
+		scheme:           ReadStateScheme(database),
 		membatch:         newSyncMemBatch(),
 		nodeReqs:         make(map[common.Hash]*request),
+		pathReqs:         make(map[common.Hash][]*request),
 		codeReqs:         make(map[common.Hash]*request),
 		queue:            prque.New(),
 		fetches:          make(map[int]int),
@@ -172,10 +182,13 @@ func (s *TrieSync) AddSubTrie(root common.Hash, path []byte, depth int, parent c
 	if root == emptyRoot {
 		return
 	}
-	if s.membatch.hasNode(root) {
+	if s.scheme == PathScheme {
+		if s.hasPathNode(path, root) {
+			return
+		}
+	} else if s.membatch.hasNode(root) {
 		return
-	}
-	if s.exist != nil {
+	} else if s.exist != nil {
 		if _, ok := s.exist.Get(root); ok {
 			// already written in migration, skip the node
 			return
@@ -313,24 +326,17 @@ func (s *TrieSync) Process(result SyncResult) error {
 	// There is an pending node request for this data, fill it.
 	if req := s.nodeReqs[result.Hash]; req != nil && req.data == nil {
 		filled = true
-		// Decode the node data content and update the request
-		node, err := decodeNode(result.Hash[:], result.Data)
-		if err != nil {
-			return err
-		}
-		req.data = result.Data
-
-		// Create and schedule a request for all the children nodes
-		requests, err := s.children(req, node)
-		if err != nil {
+		if err := s.fill(req, result.Data); err != nil {
 			return err
 		}
-		if len(requests) == 0 && req.deps == 0 {
-			s.commit(req)
-		} else {
-			req.deps += len(requests)
-			for _, child := range requests {
-				s.schedule(child)
+		// Fill the requests of the same node at the other paths as well
+		reqs := append([]*request(nil), s.pathReqs[result.Hash]...)
+		for _, req := range reqs {
+			if req.data != nil {
+				continue
+			}
+			if err := s.fill(req, result.Data); err != nil {
+				return err
 			}
 		}
 	}
@@ -340,11 +346,64 @@ func (s *TrieSync) Process(result SyncResult) error {
 	return nil
 }
 
+// fill injects the received data into the node request, and schedules the
+// retrieval of its missing children.
+func (s *TrieSync) fill(req *request, data []byte) error {
+	// Decode the node data content and update the request
+	node, err := decodeNode(req.hash[:], data)
+	if err != nil {
+		return err
+	}
+	req.data = data
+
+	// Create and schedule a request for all the children nodes
+	requests, err := s.children(req, node)
+	if err != nil {
+		return err
+	}
+	if len(requests) == 0 && req.deps == 0 {
+		s.commit(req)
+	} else {
+		req.deps += len(requests)
+		for _, child := range requests {
+			s.schedule(child)
+		}
+	}
+	return nil
+}
+
+// hasPathNode reports whether the node of the given hash is already stored at
+// the given composite path in the path scheme.
+func (s *TrieSync) hasPathNode(path []byte, hash common.Hash) bool {
+	if _, ok := s.membatch.pathNodes[string(path)]; ok {
+		return true
+	}
+	owner, inner := splitSyncPath(path)
+	enc := s.database.ReadTrieNodeByPath(owner, inner)
+	return enc != nil && crypto.Keccak256Hash(enc) == hash
+}
+
+// splitSyncPath splits the composite hex path into the owner of the trie and
+// the path of the node in the trie.
+func splitSyncPath(path []byte) (common.Hash, []byte) {
+	if len(path) < 2*common.HashLength {
+		return common.Hash{}, path
+	}
+	return common.BytesToHash(hexToKeybytes(path[:2*common.HashLength])), path[2*common.HashLength:]
+}
+
 // Commit flushes the data stored in the internal membatch out to persistent
 // storage, returning the number of items written and any occurred error.
 func (s *TrieSync) Commit(dbw database.Batch) (int, error) {
 	written := 0
 	// Dump the membatch into a database dbw
+	for path, value := range s.membatch.pathNodes {
+		owner, inner := splitSyncPath([]byte(path))
+		if err := dbw.Put(database.TrieNodePathKey(owner, inner), value); err != nil {
+			return written, err
+		}
+		written += 1
+	}
 	for key, value := range s.membatch.nodes {
 		if err := dbw.Put(database.TrieNodeKey(key.ExtendZero()), value); err != nil { // only works with hash32
 			return written, err
@@ -390,7 +449,19 @@ func (s *TrieSync) schedule(req *request) {
 	}
 	// If we're already requesting this node, add a new reference and stop
 	if old, ok := reqset[req.hash]; ok {
-		old.parents = append(old.parents, req.parents...)
+		if s.scheme != PathScheme || req.code || bytes.Equal(old.path, req.path) {
+			old.parents = append(old.parents, req.parents...)
+			return
+		}
+		// In the path scheme, the same node at another path is stored
+		// separately, along with its children.
+		s.retrievedByDepth[req.depth]++
+		s.pathReqs[req.hash] = append(s.pathReqs[req.hash], req)
+		if old.data != nil {
+			if err := s.fill(req, old.data); err != nil {
+				logger.Error("Failed to fill the trie node request", "hash", req.hash, "err", err)
+			}
+		}
 		return
 	}
 
@@ -468,10 +539,13 @@ func (s *TrieSync) children(req *request, object node) ([]*request, error) {
 		if node, ok := (child.node).(hashNode); ok {
 			// Try to resolve the node from the local database
 			hash := common.BytesToExtHash(node).Unextend()
-			if s.membatch.hasNode(hash) {
+			if s.scheme == PathScheme {
+				if s.hasPathNode(child.path, hash) {
+					continue
+				}
+			} else if s.membatch.hasNode(hash) {
 				continue
-			}
-			if s.exist != nil {
+			} else if s.exist != nil {
 				if _, ok := s.exist.Get(hash); ok {
 					// already written in migration, skip the node
 					continue
@@ -512,6 +586,14 @@ func (s *TrieSync) commit(req *request) (err error) {
 		s.membatch.codes[req.hash] = req.data
 		delete(s.codeReqs, req.hash)
 		s.fetches[len(req.path)]--
+	} else if s.scheme == PathScheme {
+		s.membatch.pathNodes[string(req.path)] = req.data
+		if s.nodeReqs[req.hash] == req {
+			delete(s.nodeReqs, req.hash)
+			s.fetches[len(req.path)]--
+		} else {
+			s.removePathReq(req)
+		}
 	} else {
 		s.membatch.nodes[req.hash] = req.data
 		delete(s.nodeReqs, req.hash)
@@ -529,6 +611,22 @@ func (s *TrieSync) commit(req *request) (err error) {
 	return nil
 }
 
+// removePathReq removes the committed request of a node at another path.
+func (s *TrieSync) removePathReq(req *request) {
+	reqs := s.pathReqs[req.hash]
+	for i, r := range reqs {
+		if r == req {
+			reqs = append(reqs[:i], reqs[i+1:]...)
+			break
+		}
+	}
+	if len(reqs) == 0 {
+		delete(s.pathReqs, req.hash)
+	} else {
+		s.pathReqs[req.hash] = reqs
+	}
+}
+
 // RetrievedByDepth returns the retrieved trie count by given depth.
 // This number is same as the number of nodes that needs to be committed to complete trie sync.
 func (s *TrieSync) RetrievedByDepth(depth int) int {
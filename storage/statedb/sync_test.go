@@ -221,6 +221,22 @@ func testIterativeTrieSync(t *testing.T, count int, bypath bool) {
 		// Cross check that the two tries are in sync
 		checkTrieContents(t, triedb, srcTrie.Hash().Bytes(), srcData)
 	}
+
+	// test with the path scheme
+	{
+		memDBManager := database.NewMemoryDBManager()
+		memDBManager.WriteStateScheme(PathScheme)
+		diskdb := memDBManager.GetMemDB()
+		triedb := NewDatabase(memDBManager)
+		sched := NewTrieSync(srcTrie.Hash(), memDBManager, nil, nil, nil)
+
+		trieSyncLoop(t, count, srcTrie, sched, srcDb, diskdb, bypath)
+		// Cross check that the two tries are in sync
+		checkTrieContents(t, triedb, srcTrie.Hash().Bytes(), srcData)
+		if has, _ := memDBManager.HasTrieNode(srcTrie.Hash().ExtendZero()); has {
+			t.Error("trie node is stored by hash in the path scheme")
+		}
+	}
 }
 
 // Tests that the trie scheduler can correctly reconstruct the state even if only
@@ -46,6 +46,19 @@ type TrieOpts struct {
 	// This option is only viable when the pruning is enabled on database.
 	LivePruningEnabled bool
 	PruningBlockNumber uint64
+
+	// Owner is the hash of the account owning a storage trie. It is used to
+	// locate the trie nodes in the path scheme, and is zero for the account trie.
+	Owner common.Hash
+
+	// If Recorder is set, the trie nodes resolved from the database are
+	// recorded into it. It is used to collect the witness of a block.
+	Recorder NodeRecorder
+}
+
+// NodeRecorder records the encoded trie nodes resolved from the database.
+type NodeRecorder interface {
+	RecordNode(hash common.Hash, enc []byte)
 }
 
 // LeafCallback is a callback type invoked when a trie operation reaches a leaf
@@ -234,6 +247,13 @@ func (t *Trie) tryGetNode(origNode node, path []byte, pos int) (item []byte, new
 		if hash == nil {
 			return nil, origNode, 0, errors.New("non-consensus node")
 		}
+		if t.db.Scheme() == PathScheme {
+			blob, _ := t.db.readNodeBlob(t.Owner, path[:pos], common.BytesToExtHash(hash))
+			if blob == nil {
+				return nil, origNode, 1, &MissingNodeError{NodeHash: common.BytesToHash(hash), Path: path[:pos]}
+			}
+			return blob, origNode, 1, nil
+		}
 		blob, err := t.db.Node(common.BytesToExtHash(hash))
 		return blob, origNode, 1, err
 	}
@@ -485,7 +505,7 @@ func (t *Trie) delete(n node, prefix, key []byte) (bool, node, error) {
 				// shortNode{..., shortNode{...}}.  Since the entry
 				// might not be loaded yet, resolve it just for this
 				// check.
-				cnode, err := t.resolve(n.Children[pos], prefix)
+				cnode, err := t.resolve(n.Children[pos], append(prefix, byte(pos)))
 				if err != nil {
 					return false, nil, err
 				}
@@ -542,11 +562,14 @@ func (t *Trie) resolve(n node, prefix []byte) (node, error) {
 
 func (t *Trie) resolveHash(n hashNode, prefix []byte) (node, error) {
 	hash := common.BytesToExtHash(n)
-	node, fromDB := t.db.node(hash)
+	node, fromDB := t.db.readNode(t.Owner, prefix, hash)
 	if t.Prefetching && fromDB {
 		memcacheCleanPrefetchMissMeter.Mark(1)
 	}
 	if node != nil {
+		if t.Recorder != nil {
+			t.Recorder.RecordNode(hash.Unextend(), t.db.nodeBlob(t.Owner, prefix, hash))
+		}
 		return node, nil
 	}
 	return nil, &MissingNodeError{NodeHash: hash.Unextend(), Path: prefix}